@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,8 +17,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/consumer"
+	eventHandlers "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/oidc"
+	authPostgres "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/persistence/postgres"
 	httpRouter "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/interfaces/http"
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/interfaces/http/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
@@ -67,23 +72,70 @@ func main() {
 		appLogger.Info("redis connected successfully")
 	}
 
-	jwtManager := utils.NewJwtManager(
-		cfg.Jwt.SecretKey,
-		cfg.Jwt.AccessTokenDuration,
-		cfg.Jwt.RefreshTokenDuration,
-	)
+	jwtManager, err := newJwtManager(cfg.Jwt)
+	if err != nil {
+		appLogger.Fatal("failed to create jwt manager", zap.Error(err))
+	}
 
 	userRepo := postgres.NewPostgresUserRepository(db)
+	twoFactorRepo := authPostgres.NewPostgresTwoFactorRepository(db)
+	externalIdentityRepo := authPostgres.NewPostgresExternalIdentityRepository(db)
+	serviceAccountRepo := authPostgres.NewPostgresServiceAccountRepository(db)
+	apiKeyRepo := authPostgres.NewPostgresAPIKeyRepository(db)
+	invitationRepo := authPostgres.NewPostgresInvitationRepository(db)
+	securityEventRepo := authPostgres.NewPostgresSecurityEventRepository(db)
+	oidcProviders := oidc.NewRegistry(cfg.OIDC, nil)
 
-	loginUseCase := usecases.NewLoginUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis)
+	loginUseCase := usecases.NewLoginUseCase(userRepo, twoFactorRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.TwoFactor, cfg.Lockout)
 	registerStudentUseCase := usecases.NewRegisterStudentUseCase(userRepo, rabbitMQ, appLogger, &cfg.RabbitMQ, redis, cfg.Server.APIGatewayURL)
-	forgotPasswordUseCase := usecases.NewForgotPasswordUseCase(userRepo, rabbitMQ, appLogger)
-	verifyOTPUseCase := usecases.NewVerifyOTPUseCase(userRepo, appLogger, redis)
-	resetPasswordUseCase := usecases.NewResetPasswordUseCase(userRepo, rabbitMQ, appLogger, redis)
-	verifyUseCase := usecases.NewVerifyUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis)
+	forgotPasswordUseCase := usecases.NewForgotPasswordUseCase(userRepo, rabbitMQ, appLogger, redis, cfg.Lockout)
+	verifyOTPUseCase := usecases.NewVerifyOTPUseCase(userRepo, rabbitMQ, appLogger, redis, cfg.Lockout)
+	resetPasswordUseCase := usecases.NewResetPasswordUseCase(userRepo, securityEventRepo, rabbitMQ, appLogger, redis)
+	verifyUseCase := usecases.NewVerifyUseCase(userRepo, serviceAccountRepo, apiKeyRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.UserCache)
 	verifyEmailUseCase := usecases.NewVerifyEmailUseCase(userRepo, rabbitMQ, appLogger, redis)
 	requestEmailVerifyUseCase := usecases.NewRequestEmailVerifyUseCase(userRepo, rabbitMQ, appLogger, redis, cfg.Server.APIGatewayURL)
-	refreshTokenUseCase := usecases.NewRefreshTokenUseCase(userRepo, appLogger, jwtManager, redis)
+	refreshTokenUseCase := usecases.NewRefreshTokenUseCase(userRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis)
+	logoutUseCase := usecases.NewLogoutUseCase(userRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis)
+	listSessionsUseCase := usecases.NewListSessionsUseCase(appLogger, jwtManager, redis)
+	revokeSessionUseCase := usecases.NewRevokeSessionUseCase(userRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis)
+	getJWKSUseCase := usecases.NewGetJWKSUseCase(jwtManager)
+	unlockAccountUseCase := usecases.NewUnlockAccountUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis)
+	loginTwoFactorUseCase := usecases.NewLoginTwoFactorUseCase(userRepo, twoFactorRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.TwoFactor)
+	setupTwoFactorUseCase := usecases.NewSetupTwoFactorUseCase(userRepo, twoFactorRepo, appLogger, jwtManager, redis, cfg.TwoFactor)
+	enableTwoFactorUseCase := usecases.NewEnableTwoFactorUseCase(twoFactorRepo, securityEventRepo, appLogger, jwtManager, redis)
+	disableTwoFactorUseCase := usecases.NewDisableTwoFactorUseCase(userRepo, twoFactorRepo, securityEventRepo, appLogger, jwtManager, redis, cfg.TwoFactor)
+	regenerateRecoveryCodesUseCase := usecases.NewRegenerateRecoveryCodesUseCase(twoFactorRepo, securityEventRepo, appLogger, jwtManager, redis)
+	getTwoFactorStatusUseCase := usecases.NewGetTwoFactorStatusUseCase(twoFactorRepo, appLogger, jwtManager, redis, cfg.TwoFactor)
+	startOIDCLoginUseCase := usecases.NewStartOIDCLoginUseCase(oidcProviders, redis, appLogger, cfg.OIDC)
+	completeOIDCLoginUseCase := usecases.NewCompleteOIDCLoginUseCase(userRepo, externalIdentityRepo, twoFactorRepo, securityEventRepo, oidcProviders, rabbitMQ, appLogger, jwtManager, redis, cfg.TwoFactor)
+	createServiceAccountUseCase := usecases.NewCreateServiceAccountUseCase(serviceAccountRepo, appLogger, jwtManager, redis)
+	listServiceAccountsUseCase := usecases.NewListServiceAccountsUseCase(serviceAccountRepo, appLogger, jwtManager, redis)
+	disableServiceAccountUseCase := usecases.NewDisableServiceAccountUseCase(serviceAccountRepo, apiKeyRepo, rabbitMQ, appLogger, jwtManager, redis)
+	createAPIKeyUseCase := usecases.NewCreateAPIKeyUseCase(serviceAccountRepo, apiKeyRepo, rabbitMQ, appLogger, jwtManager, redis)
+	listAPIKeysUseCase := usecases.NewListAPIKeysUseCase(serviceAccountRepo, apiKeyRepo, appLogger, jwtManager, redis)
+	revokeAPIKeyUseCase := usecases.NewRevokeAPIKeyUseCase(serviceAccountRepo, apiKeyRepo, rabbitMQ, appLogger, jwtManager, redis)
+	startImpersonationUseCase := usecases.NewStartImpersonationUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.Impersonation)
+	stopImpersonationUseCase := usecases.NewStopImpersonationUseCase(rabbitMQ, appLogger, jwtManager, redis)
+	getMeUseCase := usecases.NewGetMeUseCase(userRepo, appLogger, jwtManager, redis)
+	updateMeUseCase := usecases.NewUpdateMeUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis)
+	changePasswordUseCase := usecases.NewChangePasswordUseCase(userRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis)
+	changeEmailUseCase := usecases.NewChangeEmailUseCase(userRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.Server.APIGatewayURL)
+	createInvitationUseCase := usecases.NewCreateInvitationUseCase(userRepo, invitationRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.Invitation)
+	listInvitationsUseCase := usecases.NewListInvitationsUseCase(invitationRepo, appLogger, jwtManager, redis)
+	resendInvitationUseCase := usecases.NewResendInvitationUseCase(invitationRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.Invitation)
+	revokeInvitationUseCase := usecases.NewRevokeInvitationUseCase(invitationRepo, rabbitMQ, appLogger, jwtManager, redis)
+	acceptInvitationUseCase := usecases.NewAcceptInvitationUseCase(userRepo, invitationRepo, rabbitMQ, appLogger)
+	requestMagicLinkUseCase := usecases.NewRequestMagicLinkUseCase(userRepo, rabbitMQ, appLogger, redis, cfg.MagicLink)
+	verifyMagicLinkUseCase := usecases.NewVerifyMagicLinkUseCase(userRepo, twoFactorRepo, securityEventRepo, rabbitMQ, appLogger, jwtManager, redis, cfg.TwoFactor, cfg.MagicLink)
+	listSecurityEventsUseCase := usecases.NewListSecurityEventsUseCase(userRepo, securityEventRepo, appLogger, jwtManager, redis)
+
+	eventConsumer := consumer.NewEventConsumer(
+		rabbitMQ,
+		eventHandlers.NewUserUpdatedHandler(redis, appLogger),
+		eventHandlers.NewUserDeletedHandler(redis, appLogger),
+		eventHandlers.NewUserSuspendedHandler(redis, appLogger),
+		appLogger,
+	)
 
 	authHandler := handlers.NewAuthHandler(
 		loginUseCase,
@@ -95,14 +147,69 @@ func main() {
 		verifyEmailUseCase,
 		requestEmailVerifyUseCase,
 		refreshTokenUseCase,
+		logoutUseCase,
+		listSessionsUseCase,
+		revokeSessionUseCase,
+		getJWKSUseCase,
+		unlockAccountUseCase,
+	)
+
+	twoFactorHandler := handlers.NewTwoFactorHandler(
+		loginTwoFactorUseCase,
+		setupTwoFactorUseCase,
+		enableTwoFactorUseCase,
+		disableTwoFactorUseCase,
+		regenerateRecoveryCodesUseCase,
+		getTwoFactorStatusUseCase,
+	)
+
+	oidcHandler := handlers.NewOIDCHandler(
+		startOIDCLoginUseCase,
+		completeOIDCLoginUseCase,
+	)
+
+	serviceAccountHandler := handlers.NewServiceAccountHandler(
+		createServiceAccountUseCase,
+		listServiceAccountsUseCase,
+		disableServiceAccountUseCase,
+		createAPIKeyUseCase,
+		listAPIKeysUseCase,
+		revokeAPIKeyUseCase,
+	)
+
+	impersonationHandler := handlers.NewImpersonationHandler(
+		startImpersonationUseCase,
+		stopImpersonationUseCase,
+	)
+
+	meHandler := handlers.NewMeHandler(
+		getMeUseCase,
+		updateMeUseCase,
+		changePasswordUseCase,
+		changeEmailUseCase,
+	)
+
+	invitationHandler := handlers.NewInvitationHandler(
+		createInvitationUseCase,
+		listInvitationsUseCase,
+		resendInvitationUseCase,
+		revokeInvitationUseCase,
+		acceptInvitationUseCase,
 	)
 
+	magicLinkHandler := handlers.NewMagicLinkHandler(
+		requestMagicLinkUseCase,
+		verifyMagicLinkUseCase,
+	)
+
+	securityEventHandler := handlers.NewSecurityEventHandler(listSecurityEventsUseCase)
+
 	if cfg.Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
-	httpRouter.SetupRoutes(router, authHandler, appLogger)
+	httpRouter.SetupRoutes(router, authHandler, twoFactorHandler, oidcHandler, serviceAccountHandler, impersonationHandler, meHandler, invitationHandler, magicLinkHandler, securityEventHandler, redis, cfg.RateLimit, appLogger)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -112,6 +219,15 @@ func main() {
 		IdleTimeout:  16 * time.Second,
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := eventConsumer.Start(ctx); err != nil {
+			appLogger.Error("event consumer stopped", zap.Error(err))
+		}
+	}()
+
 	go func() {
 		appLogger.Info("starting server", zap.String("port", cfg.Server.Port))
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -124,8 +240,30 @@ func main() {
 	<-quit
 
 	appLogger.Info("Shutting down server...")
+	cancel()
+
 	if err := utils.GracefulShutDown(server, rabbitMQ, db, appLogger); err != nil {
 		appLogger.Error("failed to shutdown server", zap.Error(err))
 	}
 	appLogger.Info("Server shutdown completed")
 }
+
+// newJwtManager signs with the HS256 secret unless signing keys are configured,
+// in which case JWT_ACTIVE_KEY_ID must name one of them. To rotate, add the new
+// key file and deploy, then point JWT_ACTIVE_KEY_ID at it; old keys keep
+// verifying until their files are removed. HS256 tokens are only accepted
+// alongside the keys until JWT_ACCEPT_HS256_UNTIL.
+func newJwtManager(cfg config.JwtConfig) (*utils.JwtManager, error) {
+	if cfg.SigningKeysDir == "" {
+		return utils.NewJwtManager(cfg.SecretKey, cfg.AccessTokenDuration, cfg.RefreshTokenDuration), nil
+	}
+	if cfg.ActiveKeyID == "" {
+		return nil, fmt.Errorf("%w: JWT_ACTIVE_KEY_ID is required with JWT_SIGNING_KEYS_DIR", utils.ErrNoSigningKey)
+	}
+
+	keys, err := utils.LoadJwtSigningKeys(cfg.SigningKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewJwtManagerWithKeys(cfg.SecretKey, cfg.AcceptHS256Until, keys, cfg.ActiveKeyID, cfg.AccessTokenDuration, cfg.RefreshTokenDuration)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// UserDeletedHandler drops the user's cached status so /verify stops
+// accepting their tokens straight away, and ends their sessions so a restored
+// user has to sign in again. It handles purged users the same way, since a
+// user can be purged without being deleted first.
+type UserDeletedHandler struct {
+	redis  utils.RedisInterface
+	logger *logger.Logger
+}
+
+func NewUserDeletedHandler(
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+) *UserDeletedHandler {
+	return &UserDeletedHandler{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+func (h *UserDeletedHandler) Handle(body []byte) error {
+	var event events.UserDeletedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user deleted event", zap.Error(err))
+		return err
+	}
+
+	if err := h.redis.InvalidateUserStatus(context.Background(), event.ID); err != nil {
+		h.logger.Error("failed to invalidate cached user status",
+			zap.String("user_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if err := revokeUserSessions(context.Background(), h.redis, event.ID); err != nil {
+		h.logger.Error("failed to revoke sessions of deleted user",
+			zap.String("user_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("signed out deleted user",
+		zap.String("user_id", event.ID),
+	)
+
+	return nil
+}
+
+// revokeUserSessions signs the user out of every device.
+func revokeUserSessions(ctx context.Context, redis utils.RedisInterface, userID string) error {
+	sessions, err := redis.ListUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if err := redis.RevokeAccessToken(ctx, session.AccessToken); err != nil {
+			return err
+		}
+		if err := redis.RevokeRefreshToken(ctx, session.RefreshToken); err != nil {
+			return err
+		}
+		if err := redis.RevokeRefreshTokenFamily(ctx, session.SessionID); err != nil {
+			return err
+		}
+		if err := redis.DeleteUserSession(ctx, session.SessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// UserSuspendedHandler signs a suspended user out of every device. Their
+// cached status is dropped too, so /verify sees the suspension even if the
+// user updated event has not been handled yet.
+type UserSuspendedHandler struct {
+	redis  utils.RedisInterface
+	logger *logger.Logger
+}
+
+func NewUserSuspendedHandler(
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+) *UserSuspendedHandler {
+	return &UserSuspendedHandler{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+func (h *UserSuspendedHandler) Handle(body []byte) error {
+	var event events.UserSuspendedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user suspended event", zap.Error(err))
+		return err
+	}
+
+	ctx := context.Background()
+	if err := h.redis.InvalidateUserStatus(ctx, event.ID); err != nil {
+		h.logger.Error("failed to invalidate cached user status",
+			zap.String("user_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if err := revokeUserSessions(ctx, h.redis, event.ID); err != nil {
+		h.logger.Error("failed to revoke sessions of suspended user",
+			zap.String("user_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("signed out suspended user",
+		zap.String("user_id", event.ID),
+		zap.String("suspension_id", event.SuspensionID),
+	)
+
+	return nil
+}
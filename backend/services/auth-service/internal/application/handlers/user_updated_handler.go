@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// UserUpdatedHandler drops the user's cached status so /verify picks up a new
+// role or a ban on the next request.
+type UserUpdatedHandler struct {
+	redis  utils.RedisInterface
+	logger *logger.Logger
+}
+
+func NewUserUpdatedHandler(
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+) *UserUpdatedHandler {
+	return &UserUpdatedHandler{
+		redis:  redis,
+		logger: logger,
+	}
+}
+
+func (h *UserUpdatedHandler) Handle(body []byte) error {
+	var event events.UserUpdatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user updated event", zap.Error(err))
+		return err
+	}
+
+	if err := h.redis.InvalidateUserStatus(context.Background(), event.ID); err != nil {
+		h.logger.Error("failed to invalidate cached user status",
+			zap.String("user_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("invalidated cached user status",
+		zap.String("user_id", event.ID),
+		zap.String("status", event.Status),
+		zap.String("role", event.Role),
+	)
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	sharedEntities "github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type AcceptInvitationInput struct {
+	Token    string
+	Username string
+	Password string
+}
+
+type AcceptInvitationOutput struct {
+	User *dtos.UserDTO `json:"user"`
+}
+
+type AcceptInvitationUseCase struct {
+	userRepo       repositories.UserRepository
+	invitationRepo authRepositories.InvitationRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+}
+
+func NewAcceptInvitationUseCase(
+	userRepo repositories.UserRepository,
+	invitationRepo authRepositories.InvitationRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		publisher:      publisher,
+		logger:         logger,
+	}
+}
+
+// Execute creates the invitee's account with the password they chose. The
+// email is marked verified, since the token could only be read from it.
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, input AcceptInvitationInput) (*AcceptInvitationOutput, error) {
+	if input.Token == "" {
+		return nil, ErrInvalidInvitation
+	}
+	username := strings.TrimSpace(input.Username)
+	if username == "" {
+		return nil, ErrUsernameRequired
+	}
+	if input.Password == "" {
+		return nil, ErrPasswordRequired
+	}
+	if err := utils.ValidatePassword(input.Password); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPassword, err)
+	}
+
+	invitation, err := uc.invitationRepo.FindByTokenHash(ctx, utils.HashToken(input.Token))
+	if err != nil {
+		uc.logger.Error("failed to find invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if invitation == nil || invitation.Status(time.Now().UTC()) != entities.InvitationStatusPending {
+		return nil, ErrInvalidInvitation
+	}
+
+	email, err := valueobjects.NewEmail(invitation.Email)
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	existingEmail, _ := uc.userRepo.FindByEmail(ctx, email.String())
+	if existingEmail != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+	existingUsername, _ := uc.userRepo.FindByUsername(ctx, username)
+	if existingUsername != nil {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	passwordHash, err := utils.HashPassword(input.Password)
+	if err != nil {
+		uc.logger.Error("failed to hash password", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	user := sharedEntities.NewUser(email, username, invitation.Role, passwordHash)
+	user.VerifyEmail()
+	user.MoveToOrganization(invitation.OrganizationID)
+	// users.email is unique, so a second accept of the same token fails here.
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		uc.logger.Error("failed to create invited user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	invitation.Accept(user.ID)
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		uc.logger.Error("failed to mark invitation accepted", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthInvitationAcceptedEvent{
+		ID:         invitation.ID,
+		UserID:     user.ID,
+		Email:      user.Email.String(),
+		Username:   user.Username,
+		Role:       user.Role.String(),
+		InvitedBy:  invitation.InvitedBy,
+		AcceptedAt: *invitation.AcceptedAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthInvitationAccepted, event); err != nil {
+		uc.logger.Error("failed to publish invitation accepted event", zap.Error(err))
+	}
+
+	uc.logger.Info("invitation accepted",
+		zap.String("invitation_id", invitation.ID),
+		zap.String("user_id", user.ID),
+	)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &AcceptInvitationOutput{User: &dto}, nil
+}
@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAccountLocked   = errors.New("account is temporarily locked due to too many failed attempts")
+	ErrTooManyAttempts = errors.New("too many attempts, please try again later")
+)
+
+// Actions guarded against brute force. Each has its own counters so failing
+// one does not lock the others.
+const (
+	attemptActionLogin          = "login"
+	attemptActionOTP            = "otp"
+	attemptActionForgotPassword = "forgot_password"
+)
+
+var attemptActions = []string{attemptActionLogin, attemptActionOTP, attemptActionForgotPassword}
+
+// LockoutError is returned while an account or IP is blocked. RetryAfter
+// tells the client how long to wait.
+type LockoutError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LockoutError) Unwrap() error {
+	return e.Err
+}
+
+// attemptGuard keeps per account and per IP failure counters in Redis. Redis
+// errors are logged and the attempt is allowed, so an outage does not lock
+// everyone out.
+type attemptGuard struct {
+	redis     utils.RedisInterface
+	publisher messaging.Publisher
+	logger    *logger.Logger
+	config    config.LockoutConfig
+}
+
+func newAttemptGuard(redis utils.RedisInterface, publisher messaging.Publisher, logger *logger.Logger, config config.LockoutConfig) *attemptGuard {
+	return &attemptGuard{redis: redis, publisher: publisher, logger: logger, config: config}
+}
+
+func accountAttemptSubject(action, email string) string {
+	return action + ":account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptSubject(action, ip string) string {
+	return action + ":ip:" + ip
+}
+
+// check rejects the attempt while the account or the IP is locked.
+func (g *attemptGuard) check(ctx context.Context, action, email, ip string) error {
+	if remaining := g.lockout(ctx, accountAttemptSubject(action, email)); remaining > 0 {
+		return &LockoutError{Err: ErrAccountLocked, RetryAfter: remaining}
+	}
+	if ip != "" {
+		if remaining := g.lockout(ctx, ipAttemptSubject(action, ip)); remaining > 0 {
+			return &LockoutError{Err: ErrTooManyAttempts, RetryAfter: remaining}
+		}
+	}
+	return nil
+}
+
+func (g *attemptGuard) lockout(ctx context.Context, subject string) time.Duration {
+	remaining, err := g.redis.GetAuthLockout(ctx, subject)
+	if err != nil {
+		g.logger.Warn("failed to check auth lockout", zap.String("subject", subject), zap.Error(err))
+		return 0
+	}
+	return remaining
+}
+
+// fail records a failed attempt. After DelayAfter failures the account is
+// blocked for an exponentially growing delay, and at MaxAccountFailures it is
+// locked for LockoutDuration. user is optional; when set the owner is notified
+// of the lockout.
+func (g *attemptGuard) fail(ctx context.Context, action, email string, user *entities.User, ip, userAgent string) {
+	subject := accountAttemptSubject(action, email)
+	failures, err := g.redis.IncrementAuthFailures(ctx, subject, g.config.FailureWindow)
+	if err != nil {
+		g.logger.Warn("failed to record auth failure", zap.String("subject", subject), zap.Error(err))
+	} else if failures >= int64(g.config.MaxAccountFailures) {
+		g.lock(ctx, subject, g.config.LockoutDuration)
+		if user != nil {
+			g.publishLocked(ctx, action, user, failures, ip, userAgent)
+		}
+	} else if delay := g.delayFor(failures); delay > 0 {
+		g.lock(ctx, subject, delay)
+	}
+
+	if ip == "" {
+		return
+	}
+	ipSubject := ipAttemptSubject(action, ip)
+	ipFailures, err := g.redis.IncrementAuthFailures(ctx, ipSubject, g.config.FailureWindow)
+	if err != nil {
+		g.logger.Warn("failed to record auth failure", zap.String("subject", ipSubject), zap.Error(err))
+		return
+	}
+	if ipFailures >= int64(g.config.MaxIPFailures) {
+		g.lock(ctx, ipSubject, g.config.LockoutDuration)
+	}
+}
+
+// succeed clears the account's failure count. IP counters are left alone so
+// one valid login cannot reset a spray across many accounts.
+func (g *attemptGuard) succeed(ctx context.Context, action, email string) {
+	subject := accountAttemptSubject(action, email)
+	if err := g.redis.ResetAuthFailures(ctx, subject); err != nil {
+		g.logger.Warn("failed to reset auth failures", zap.String("subject", subject), zap.Error(err))
+	}
+}
+
+func (g *attemptGuard) delayFor(failures int64) time.Duration {
+	over := failures - int64(g.config.DelayAfter)
+	if over <= 0 || g.config.BaseDelay <= 0 {
+		return 0
+	}
+	delay := g.config.BaseDelay
+	for i := int64(1); i < over && delay < g.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if g.config.MaxDelay > 0 && delay > g.config.MaxDelay {
+		delay = g.config.MaxDelay
+	}
+	return delay
+}
+
+func (g *attemptGuard) lock(ctx context.Context, subject string, duration time.Duration) {
+	if err := g.redis.LockAuthSubject(ctx, subject, duration); err != nil {
+		g.logger.Warn("failed to lock auth subject", zap.String("subject", subject), zap.Error(err))
+	}
+}
+
+func (g *attemptGuard) publishLocked(ctx context.Context, action string, user *entities.User, failures int64, ip, userAgent string) {
+	now := time.Now()
+	event := events.AuthAccountLockedEvent{
+		ID:             user.ID,
+		Email:          user.Email.String(),
+		Username:       user.Username,
+		Action:         action,
+		FailedAttempts: failures,
+		IPAddress:      ip,
+		UserAgent:      userAgent,
+		LockedAt:       now,
+		LockedUntil:    now.Add(g.config.LockoutDuration),
+	}
+
+	if err := g.publisher.Publish(ctx, events.EventTypeAuthAccountLocked, event); err != nil {
+		g.logger.Error("failed to publish account locked event", zap.Error(err))
+	}
+}
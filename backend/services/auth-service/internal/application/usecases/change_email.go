@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var ErrEmailUnchanged = errors.New("new email must differ from the current email")
+
+type ChangeEmailInput struct {
+	AccessToken     string
+	NewEmail        string
+	CurrentPassword string
+}
+
+type ChangeEmailOutput struct {
+	Message      string `json:"message"`
+	PendingEmail string `json:"pending_email"`
+}
+
+type ChangeEmailUseCase struct {
+	userRepo      repositories.UserRepository
+	publisher     messaging.Publisher
+	logger        *logger.Logger
+	jwtManager    *utils.JwtManager
+	redis         utils.RedisInterface
+	apiGatewayURL string
+}
+
+func NewChangeEmailUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	apiGatewayURL string,
+) *ChangeEmailUseCase {
+	return &ChangeEmailUseCase{
+		userRepo:      userRepo,
+		publisher:     publisher,
+		logger:        logger,
+		jwtManager:    jwtManager,
+		redis:         redis,
+		apiGatewayURL: apiGatewayURL,
+	}
+}
+
+// Execute starts an email change. The account keeps its current address until
+// the verify email link sent to the new address is opened.
+func (uc *ChangeEmailUseCase) Execute(ctx context.Context, input ChangeEmailInput) (*ChangeEmailOutput, error) {
+	if input.NewEmail == "" {
+		return nil, ErrEmailRequired
+	}
+	if input.CurrentPassword == "" {
+		return nil, ErrCurrentPasswordRequired
+	}
+	newEmail, err := valueobjects.NewEmail(input.NewEmail)
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := utils.VerifyPassword(input.CurrentPassword, user.PasswordHash); err != nil {
+		return nil, ErrCurrentPasswordIncorrect
+	}
+	if newEmail.String() == user.Email.String() {
+		return nil, ErrEmailUnchanged
+	}
+	existing, _ := uc.userRepo.FindByEmail(ctx, newEmail.String())
+	if existing != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	token := uuid.New().String()
+	if err := uc.redis.StoreVerifyEmailToken(ctx, user.ID, token); err != nil {
+		uc.logger.Error("failed to store verify email token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if err := uc.redis.StorePendingEmail(ctx, token, newEmail.String()); err != nil {
+		uc.logger.Error("failed to store pending email", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthUserRequestedEmailVerificationEvent{
+		ID:                   user.ID,
+		Email:                newEmail.String(),
+		EmailVerificationURL: utils.GenerateEmailVerificationURL(uc.apiGatewayURL, token),
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthUserRequestedEmailVerification, event); err != nil {
+		uc.logger.Error("failed to publish email verification request event", zap.Error(err))
+	}
+
+	return &ChangeEmailOutput{
+		Message:      "Verification email sent to the new address",
+		PendingEmail: newEmail.String(),
+	}, nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCurrentPasswordRequired  = errors.New("current password is required")
+	ErrCurrentPasswordIncorrect = errors.New("current password is incorrect")
+	ErrPasswordUnchanged        = errors.New("new password must differ from the current password")
+)
+
+type ChangePasswordInput struct {
+	AccessToken     string
+	CurrentPassword string
+	NewPassword     string
+	IPAddress       string
+	UserAgent       string
+}
+
+type ChangePasswordOutput struct {
+	Message         string `json:"message"`
+	RevokedSessions int    `json:"revoked_sessions"`
+}
+
+type ChangePasswordUseCase struct {
+	userRepo    repositories.UserRepository
+	publisher   messaging.Publisher
+	logger      *logger.Logger
+	jwtManager  *utils.JwtManager
+	redis       utils.RedisInterface
+	securityLog *securityLog
+}
+
+func NewChangePasswordUseCase(
+	userRepo repositories.UserRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo:    userRepo,
+		publisher:   publisher,
+		logger:      logger,
+		jwtManager:  jwtManager,
+		redis:       redis,
+		securityLog: newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute changes the caller's password after checking the current one, then
+// revokes every other session of the user. The session making the request
+// stays signed in.
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, input ChangePasswordInput) (*ChangePasswordOutput, error) {
+	if input.CurrentPassword == "" {
+		return nil, ErrCurrentPasswordRequired
+	}
+	if input.NewPassword == "" {
+		return nil, ErrPasswordRequired
+	}
+
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := utils.VerifyPassword(input.CurrentPassword, user.PasswordHash); err != nil {
+		return nil, ErrCurrentPasswordIncorrect
+	}
+	if input.NewPassword == input.CurrentPassword {
+		return nil, ErrPasswordUnchanged
+	}
+	if err := utils.ValidatePassword(input.NewPassword); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPassword, err)
+	}
+
+	passwordHash, err := utils.HashPassword(input.NewPassword)
+	if err != nil {
+		uc.logger.Error("failed to hash password", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+		uc.logger.Error("failed to update password", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	sessions, err := uc.redis.ListUserSessions(ctx, user.ID)
+	if err != nil {
+		uc.logger.Error("failed to list user sessions", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	revoked := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.SessionID == claims.SessionID {
+			continue
+		}
+		if err := revokeSession(ctx, uc.redis, session); err != nil {
+			uc.logger.Error("failed to revoke session", zap.String("session_id", session.SessionID), zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		revoked = append(revoked, session.SessionID)
+	}
+
+	uc.securityLog.record(ctx, newSecurityEvent(user.ID, entities.SecurityEventPasswordChanged, "", input.IPAddress, input.UserAgent))
+
+	event := events.AuthUserPasswordChangedEvent{
+		ID:              user.ID,
+		Email:           user.Email.String(),
+		Username:        user.Username,
+		RevokedSessions: revoked,
+		IPAddress:       input.IPAddress,
+		UserAgent:       input.UserAgent,
+		ChangedAt:       time.Now(),
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthUserPasswordChanged, event); err != nil {
+		uc.logger.Error("failed to publish password changed event", zap.Error(err))
+	}
+
+	uc.logger.Info("user changed password",
+		zap.String("user_id", user.ID),
+		zap.Int("revoked_sessions", len(revoked)),
+	)
+
+	return &ChangePasswordOutput{
+		Message:         "Password changed successfully",
+		RevokedSessions: len(revoked),
+	}, nil
+}
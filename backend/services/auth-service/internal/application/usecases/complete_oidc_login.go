@@ -0,0 +1,364 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	authEntities "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/oidc"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrOIDCCodeRequired          = errors.New("authorization code is required")
+	ErrOIDCStateRequired         = errors.New("state is required")
+	ErrInvalidOIDCState          = errors.New("invalid or expired sign-in state")
+	ErrOIDCLoginFailed           = errors.New("sign-in with provider failed")
+	ErrOIDCEmailNotVerified      = errors.New("provider did not verify the email address")
+	ErrOIDCEmailDomainNotAllowed = errors.New("email domain is not allowed for this provider")
+)
+
+const maxUsernameAttempts = 5
+
+type CompleteOIDCLoginInput struct {
+	Provider  string
+	Code      string
+	State     string
+	IPAddress string
+	UserAgent string
+}
+
+type CompleteOIDCLoginUseCase struct {
+	userRepo        repositories.UserRepository
+	identityRepo    authRepositories.ExternalIdentityRepository
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	providers       *oidc.Registry
+	publisher       messaging.Publisher
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+	securityLog     *securityLog
+}
+
+func NewCompleteOIDCLoginUseCase(
+	userRepo repositories.UserRepository,
+	identityRepo authRepositories.ExternalIdentityRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	providers *oidc.Registry,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+) *CompleteOIDCLoginUseCase {
+	return &CompleteOIDCLoginUseCase{
+		userRepo:        userRepo,
+		identityRepo:    identityRepo,
+		twoFactorRepo:   twoFactorRepo,
+		providers:       providers,
+		publisher:       publisher,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+		securityLog:     newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute finishes the authorization code flow. The provider identity is
+// matched by its subject first, then linked to an account with the same
+// verified email, and otherwise a new student is created. Users with
+// two-factor authentication still have to pass /login/2fa.
+func (uc *CompleteOIDCLoginUseCase) Execute(ctx context.Context, input CompleteOIDCLoginInput) (*LoginOutput, error) {
+	if input.Code == "" {
+		return nil, ErrOIDCCodeRequired
+	}
+	if input.State == "" {
+		return nil, ErrOIDCStateRequired
+	}
+
+	provider, err := uc.providers.Provider(input.Provider)
+	if err != nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	state, err := uc.redis.ConsumeOIDCState(ctx, input.State)
+	if err != nil {
+		if !errors.Is(err, utils.ErrOIDCStateNotFound) {
+			uc.logger.Error("failed to load oidc state", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		return nil, ErrInvalidOIDCState
+	}
+	if state.Provider != provider.Name() {
+		return nil, ErrInvalidOIDCState
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, input.Code, state.CodeVerifier)
+	if err != nil {
+		uc.logger.Warn("failed to exchange authorization code", zap.String("provider", provider.Name()), zap.Error(err))
+		return nil, ErrOIDCLoginFailed
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		uc.logger.Warn("failed to verify id token", zap.String("provider", provider.Name()), zap.Error(err))
+		return nil, ErrOIDCLoginFailed
+	}
+
+	if !provider.AllowsEmail(claims.Email) {
+		return nil, ErrOIDCEmailDomainNotAllowed
+	}
+
+	user, err := uc.resolveUser(ctx, provider, claims, input)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactorEnabled := hasTwoFactorEnabled(ctx, uc.twoFactorRepo, uc.logger, user.ID)
+	if twoFactorEnabled || uc.twoFactorConfig.IsRequiredFor(user.Role.String()) {
+		return startTwoFactorChallenge(ctx, uc.redis, uc.logger, uc.twoFactorConfig, user, input.IPAddress, input.UserAgent, !twoFactorEnabled)
+	}
+
+	session, err := startSession(ctx, uc.jwtManager, uc.redis, uc.logger, user, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	publishUserLoggedIn(ctx, uc.publisher, uc.logger, user, input.IPAddress, input.UserAgent)
+	uc.securityLog.recordLogin(ctx, uc.publisher, user, loginMethodOIDC+":"+provider.Name(), input.IPAddress, input.UserAgent)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &LoginOutput{
+		User:         &dto,
+		AccessToken:  session.AccessToken,
+		RefreshToken: session.RefreshToken,
+	}, nil
+}
+
+func (uc *CompleteOIDCLoginUseCase) resolveUser(ctx context.Context, provider *oidc.Provider, claims *oidc.IDTokenClaims, input CompleteOIDCLoginInput) (*entities.User, error) {
+	identity, err := uc.identityRepo.FindByProviderSubject(ctx, provider.Name(), claims.Subject)
+	if err != nil {
+		uc.logger.Error("failed to find external identity", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	if identity != nil {
+		user, err := uc.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil || user == nil {
+			uc.logger.Error("failed to find user for external identity", zap.String("user_id", identity.UserID), zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		identity.MarkLoggedIn(claims.Email)
+		if err := uc.identityRepo.Update(ctx, identity); err != nil {
+			uc.logger.Warn("failed to update external identity", zap.String("identity_id", identity.ID), zap.Error(err))
+		}
+		return user, nil
+	}
+
+	// Only an email the provider vouches for may be matched to an account.
+	if claims.Email == "" || !provider.EmailVerified(claims) {
+		return nil, ErrOIDCEmailNotVerified
+	}
+	email, err := valueobjects.NewEmail(claims.Email)
+	if err != nil {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email.String())
+	if err != nil {
+		uc.logger.Error("failed to find user by email", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	created := false
+	if user == nil {
+		user, err = uc.createStudent(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		created = true
+	} else if !user.EmailVerified {
+		if err := uc.claimUnverifiedAccount(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	identity = authEntities.NewExternalIdentity(user.ID, provider.Name(), claims.Subject, email.String())
+	if err := uc.identityRepo.Create(ctx, identity); err != nil {
+		uc.logger.Error("failed to create external identity", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	if created {
+		uc.publishStudentRegistered(ctx, user)
+	}
+	uc.publishIdentityLinked(ctx, user, identity, created, input)
+
+	return user, nil
+}
+
+// claimUnverifiedAccount handles an account someone registered with this
+// email but never verified. The provider has now proven who owns the email,
+// so the unproven password is replaced and its sessions revoked to shut out
+// whoever set it.
+func (uc *CompleteOIDCLoginUseCase) claimUnverifiedAccount(ctx context.Context, user *entities.User) error {
+	passwordHash, err := unusablePasswordHash()
+	if err != nil {
+		uc.logger.Error("failed to generate password", zap.Error(err))
+		return ErrInternalServerError
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+		uc.logger.Error("failed to reset password of unverified account", zap.String("user_id", user.ID), zap.Error(err))
+		return ErrInternalServerError
+	}
+	if err := uc.userRepo.UpdateEmailVerified(ctx, user.ID, true); err != nil {
+		uc.logger.Error("failed to mark email verified", zap.String("user_id", user.ID), zap.Error(err))
+		return ErrInternalServerError
+	}
+	user.ChangePassword(passwordHash)
+	user.VerifyEmail()
+
+	sessions, err := uc.redis.ListUserSessions(ctx, user.ID)
+	if err != nil {
+		uc.logger.Warn("failed to list sessions of unverified account", zap.String("user_id", user.ID), zap.Error(err))
+		return nil
+	}
+	for _, session := range sessions {
+		if err := revokeSession(ctx, uc.redis, session); err != nil {
+			uc.logger.Warn("failed to revoke session", zap.String("session_id", session.SessionID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// createStudent registers a student for a first time provider login. The
+// password is random; the student can set one through forgot password.
+func (uc *CompleteOIDCLoginUseCase) createStudent(ctx context.Context, email valueobjects.Email) (*entities.User, error) {
+	username, err := uc.availableUsername(ctx, email.String())
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := unusablePasswordHash()
+	if err != nil {
+		uc.logger.Error("failed to generate password", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	user := entities.NewUser(email, username, valueobjects.RoleStudent, passwordHash)
+	user.VerifyEmail()
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		uc.logger.Error("failed to create user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	return user, nil
+}
+
+// availableUsername derives a username from the email's local part, adding a
+// random suffix when it is already taken.
+func (uc *CompleteOIDCLoginUseCase) availableUsername(ctx context.Context, email string) (string, error) {
+	base := usernameFromEmail(email)
+	candidate := base
+	for i := 0; i < maxUsernameAttempts; i++ {
+		existing, err := uc.userRepo.FindByUsername(ctx, candidate)
+		if err != nil {
+			uc.logger.Error("failed to find user by username", zap.Error(err))
+			return "", ErrInternalServerError
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			uc.logger.Error("failed to generate username suffix", zap.Error(err))
+			return "", ErrInternalServerError
+		}
+		candidate = base + "-" + hex.EncodeToString(suffix)
+	}
+	return "", ErrUsernameAlreadyExists
+}
+
+func usernameFromEmail(email string) string {
+	local := strings.ToLower(email)
+	if at := strings.Index(local, "@"); at >= 0 {
+		local = local[:at]
+	}
+
+	var b strings.Builder
+	for _, r := range local {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	username := b.String()
+	if len(username) > 30 {
+		username = username[:30]
+	}
+	if username == "" {
+		username = "student"
+	}
+	return username
+}
+
+func unusablePasswordHash() (string, error) {
+	password, err := authUtils.GeneratePasswordResetToken()
+	if err != nil {
+		return "", err
+	}
+	return utils.HashPassword(password)
+}
+
+func (uc *CompleteOIDCLoginUseCase) publishStudentRegistered(ctx context.Context, user *entities.User) {
+	event := events.AuthStudentRegisteredEvent{
+		ID:              user.ID,
+		Email:           user.Email.String(),
+		Username:        user.Username,
+		Role:            user.Role.String(),
+		Status:          user.Status.String(),
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthStudentRegistered, event); err != nil {
+		uc.logger.Error("failed to publish student registered event", zap.Error(err))
+	}
+}
+
+func (uc *CompleteOIDCLoginUseCase) publishIdentityLinked(ctx context.Context, user *entities.User, identity *authEntities.ExternalIdentity, created bool, input CompleteOIDCLoginInput) {
+	event := events.AuthIdentityLinkedEvent{
+		ID:          user.ID,
+		Email:       user.Email.String(),
+		Username:    user.Username,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		UserCreated: created,
+		IPAddress:   input.IPAddress,
+		UserAgent:   input.UserAgent,
+		LinkedAt:    time.Now(),
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthIdentityLinked, event); err != nil {
+		uc.logger.Error("failed to publish identity linked event", zap.Error(err))
+	}
+}
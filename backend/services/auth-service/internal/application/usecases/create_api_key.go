@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type CreateAPIKeyInput struct {
+	AccessToken      string
+	ServiceAccountID string
+	Name             string
+	Scopes           []string
+	ExpiresAt        *time.Time
+}
+
+// CreateAPIKeyOutput carries the key in plain text. Only its hash is stored,
+// so this is the only time it can be shown.
+type CreateAPIKeyOutput struct {
+	APIKeyOutput
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	apiKeyRepo         authRepositories.APIKeyRepository
+	publisher          messaging.Publisher
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewCreateAPIKeyUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	apiKeyRepo authRepositories.APIKeyRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		apiKeyRepo:         apiKeyRepo,
+		publisher:          publisher,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, input CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrAPIKeyNameRequired
+	}
+	if err := validateScopes(input.Scopes); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	account, err := uc.serviceAccountRepo.FindByID(ctx, input.ServiceAccountID)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if account == nil || !canManageServiceAccount(ctx, claims, account) {
+		return nil, ErrServiceAccountNotFound
+	}
+	if account.IsDisabled() {
+		return nil, ErrServiceAccountDisabled
+	}
+
+	plainKey, prefix, err := authUtils.GenerateAPIKey()
+	if err != nil {
+		uc.logger.Error("failed to generate api key", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	key := entities.NewAPIKey(account.ID, name, prefix, utils.HashToken(plainKey), input.Scopes, input.ExpiresAt, claims.UserID)
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		uc.logger.Error("failed to create api key", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthAPIKeyCreatedEvent{
+		ID:                 key.ID,
+		ServiceAccountID:   account.ID,
+		ServiceAccountName: account.Name,
+		Name:               key.Name,
+		Prefix:             key.Prefix,
+		Scopes:             key.Scopes,
+		ExpiresAt:          key.ExpiresAt,
+		CreatedBy:          claims.UserID,
+		CreatedAt:          key.CreatedAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthAPIKeyCreated, event); err != nil {
+		uc.logger.Error("failed to publish api key created event", zap.Error(err))
+	}
+
+	uc.logger.Info("api key created",
+		zap.String("service_account_id", account.ID),
+		zap.String("api_key_id", key.ID),
+		zap.String("created_by", claims.UserID),
+	)
+
+	return &CreateAPIKeyOutput{
+		APIKeyOutput: newAPIKeyOutput(key, now),
+		Key:          plainKey,
+	}, nil
+}
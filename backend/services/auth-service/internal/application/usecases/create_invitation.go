@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type CreateInvitationInput struct {
+	AccessToken string
+	Email       string
+	Role        string
+}
+
+type CreateInvitationUseCase struct {
+	userRepo         repositories.UserRepository
+	invitationRepo   authRepositories.InvitationRepository
+	publisher        messaging.Publisher
+	logger           *logger.Logger
+	jwtManager       *utils.JwtManager
+	redis            utils.RedisInterface
+	invitationConfig config.InvitationConfig
+}
+
+func NewCreateInvitationUseCase(
+	userRepo repositories.UserRepository,
+	invitationRepo authRepositories.InvitationRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	invitationConfig config.InvitationConfig,
+) *CreateInvitationUseCase {
+	return &CreateInvitationUseCase{
+		userRepo:         userRepo,
+		invitationRepo:   invitationRepo,
+		publisher:        publisher,
+		logger:           logger,
+		jwtManager:       jwtManager,
+		redis:            redis,
+		invitationConfig: invitationConfig,
+	}
+}
+
+// Execute invites email to join with role. An expired invitation for the
+// same email is revoked and replaced; a pending one has to be resent instead.
+func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvitationInput) (*InvitationOutput, error) {
+	if input.Email == "" {
+		return nil, ErrEmailRequired
+	}
+	email, err := valueobjects.NewEmail(input.Email)
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	role, err := validateInvitationRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	existingUser, _ := uc.userRepo.FindByEmail(ctx, email.String())
+	if existingUser != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	now := time.Now().UTC()
+	open, err := uc.invitationRepo.FindOpenByEmail(ctx, email.String())
+	if err != nil {
+		uc.logger.Error("failed to find invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if open != nil {
+		// Another organization's invitation, even an expired one, is not
+		// the caller's to replace.
+		if open.Status(now) == entities.InvitationStatusPending || !canManageInvitation(ctx, claims, open) {
+			return nil, ErrInvitationAlreadyPending
+		}
+		open.Revoke()
+		if err := uc.invitationRepo.Update(ctx, open); err != nil {
+			uc.logger.Error("failed to revoke expired invitation", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+	}
+
+	token, tokenHash, expiresAt, err := newInvitationToken(uc.invitationConfig.TokenDuration)
+	if err != nil {
+		uc.logger.Error("failed to generate invitation token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	// Invitees join the inviting admin's organization, so a tenant admin can
+	// never create an account outside their own.
+	var organizationID *string
+	if claims.OrganizationID != "" {
+		organizationID = &claims.OrganizationID
+	}
+	invitation := entities.NewInvitation(email.String(), role, tokenHash, claims.UserID, organizationID, expiresAt)
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		uc.logger.Error("failed to create invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	publishUserInvited(ctx, uc.publisher, uc.logger, invitation, claims, uc.invitationConfig.AcceptURL, token)
+
+	uc.logger.Info("user invited",
+		zap.String("invitation_id", invitation.ID),
+		zap.String("role", invitation.Role.String()),
+		zap.String("invited_by", claims.UserID),
+	)
+
+	output := newInvitationOutput(invitation, now)
+	return &output, nil
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// CreateServiceAccountInput names the account's organization only for
+// super-admins; accounts created by an organization's admin always join it.
+type CreateServiceAccountInput struct {
+	AccessToken    string
+	Name           string
+	Description    string
+	Role           string
+	OrganizationID *string
+}
+
+type CreateServiceAccountUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewCreateServiceAccountUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *CreateServiceAccountUseCase {
+	return &CreateServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+// Execute registers a new service account. It has no keys until one is
+// created for it.
+func (uc *CreateServiceAccountUseCase) Execute(ctx context.Context, input CreateServiceAccountInput) (*ServiceAccountOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrServiceAccountNameRequired
+	}
+	role, err := valueobjects.NewRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+	if input.OrganizationID != nil && *input.OrganizationID == "" {
+		input.OrganizationID = nil
+	}
+	organizationID := tenant.Owner(tenant.WithOrganizationID(ctx, claims.OrganizationID), input.OrganizationID)
+	if organizationID == nil && !role.IsAdmin() {
+		return nil, ErrServiceAccountOrganizationRequired
+	}
+
+	existing, err := uc.serviceAccountRepo.FindByName(ctx, name)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if existing != nil {
+		return nil, ErrServiceAccountAlreadyExists
+	}
+
+	account := entities.NewServiceAccount(name, strings.TrimSpace(input.Description), role, organizationID, claims.UserID)
+	if err := uc.serviceAccountRepo.Create(ctx, account); err != nil {
+		uc.logger.Error("failed to create service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	uc.logger.Info("service account created",
+		zap.String("service_account_id", account.ID),
+		zap.String("role", account.Role.String()),
+		zap.String("created_by", claims.UserID),
+	)
+
+	output := newServiceAccountOutput(account)
+	return &output, nil
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type DisableServiceAccountInput struct {
+	AccessToken      string
+	ServiceAccountID string
+}
+
+type DisableServiceAccountUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	apiKeyRepo         authRepositories.APIKeyRepository
+	publisher          messaging.Publisher
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewDisableServiceAccountUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	apiKeyRepo authRepositories.APIKeyRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *DisableServiceAccountUseCase {
+	return &DisableServiceAccountUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		apiKeyRepo:         apiKeyRepo,
+		publisher:          publisher,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+// Execute disables the account and revokes every key it holds. Disabled
+// accounts are kept so their keys' history stays available.
+func (uc *DisableServiceAccountUseCase) Execute(ctx context.Context, input DisableServiceAccountInput) (*ServiceAccountOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.serviceAccountRepo.FindByID(ctx, input.ServiceAccountID)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if account == nil || !canManageServiceAccount(ctx, claims, account) {
+		return nil, ErrServiceAccountNotFound
+	}
+	if account.IsDisabled() {
+		output := newServiceAccountOutput(account)
+		return &output, nil
+	}
+
+	account.Disable()
+	if err := uc.apiKeyRepo.RevokeByServiceAccountID(ctx, account.ID, *account.DisabledAt); err != nil {
+		uc.logger.Error("failed to revoke service account api keys", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if err := uc.serviceAccountRepo.Update(ctx, account); err != nil {
+		uc.logger.Error("failed to disable service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthServiceAccountDisabledEvent{
+		ID:         account.ID,
+		Name:       account.Name,
+		DisabledBy: claims.UserID,
+		DisabledAt: *account.DisabledAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthServiceAccountDisabled, event); err != nil {
+		uc.logger.Error("failed to publish service account disabled event", zap.Error(err))
+	}
+
+	uc.logger.Info("service account disabled",
+		zap.String("service_account_id", account.ID),
+		zap.String("disabled_by", claims.UserID),
+	)
+
+	output := newServiceAccountOutput(account)
+	return &output, nil
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type DisableTwoFactorInput struct {
+	AccessToken  string
+	Password     string
+	Code         string
+	RecoveryCode string
+	IPAddress    string
+	UserAgent    string
+}
+
+type DisableTwoFactorUseCase struct {
+	userRepo        repositories.UserRepository
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+	securityLog     *securityLog
+}
+
+func NewDisableTwoFactorUseCase(
+	userRepo repositories.UserRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+) *DisableTwoFactorUseCase {
+	return &DisableTwoFactorUseCase{
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+		securityLog:     newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute turns two-factor authentication off after checking both the
+// password and a second factor, so a stolen session alone cannot remove it.
+func (uc *DisableTwoFactorUseCase) Execute(ctx context.Context, input DisableTwoFactorInput) error {
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return ErrInternalServerError
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if uc.twoFactorConfig.IsRequiredFor(user.Role.String()) {
+		return ErrTwoFactorRequiredForRole
+	}
+	if err := utils.VerifyPassword(input.Password, user.PasswordHash); err != nil {
+		return ErrInvalidPassword
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return ErrInternalServerError
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if err := verifySecondFactor(ctx, uc.twoFactorRepo, twoFactor, input.Code, input.RecoveryCode); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) || errors.Is(err, ErrTwoFactorCodeRequired) {
+			return err
+		}
+		uc.logger.Error("failed to verify two factor code", zap.Error(err))
+		return ErrInternalServerError
+	}
+
+	if err := uc.twoFactorRepo.Delete(ctx, user.ID); err != nil {
+		uc.logger.Error("failed to disable two factor", zap.Error(err))
+		return ErrInternalServerError
+	}
+
+	uc.securityLog.record(ctx, newSecurityEvent(user.ID, entities.SecurityEventTwoFactorDisabled, "", input.IPAddress, input.UserAgent))
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type EnableTwoFactorInput struct {
+	AccessToken string
+	Code        string
+	IPAddress   string
+	UserAgent   string
+}
+
+type EnableTwoFactorOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type EnableTwoFactorUseCase struct {
+	twoFactorRepo authRepositories.TwoFactorRepository
+	logger        *logger.Logger
+	jwtManager    *utils.JwtManager
+	redis         utils.RedisInterface
+	securityLog   *securityLog
+}
+
+func NewEnableTwoFactorUseCase(
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *EnableTwoFactorUseCase {
+	return &EnableTwoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+		logger:        logger,
+		jwtManager:    jwtManager,
+		redis:         redis,
+		securityLog:   newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute confirms enrollment with a code from the authenticator app and
+// returns a fresh set of recovery codes.
+func (uc *EnableTwoFactorUseCase) Execute(ctx context.Context, input EnableTwoFactorInput) (*EnableTwoFactorOutput, error) {
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if twoFactor == nil {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	if twoFactor.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	twoFactor.Enable()
+	if err := verifyTOTP(ctx, uc.twoFactorRepo, twoFactor, input.Code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) || errors.Is(err, ErrTwoFactorCodeRequired) {
+			return nil, err
+		}
+		uc.logger.Error("failed to enable two factor", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	codes, err := issueRecoveryCodes(ctx, uc.twoFactorRepo, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to issue recovery codes", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	uc.securityLog.record(ctx, newSecurityEvent(claims.UserID, entities.SecurityEventTwoFactorEnabled, "", input.IPAddress, input.UserAgent))
+
+	return &EnableTwoFactorOutput{RecoveryCodes: codes}, nil
+}
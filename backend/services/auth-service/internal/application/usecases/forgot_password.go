@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +22,8 @@ var (
 
 type ForgotPasswordInput struct {
 	Email string
+	IPAddress string
+	UserAgent string
 }
 
 type ForgotPasswordOutput struct {
@@ -30,19 +34,21 @@ type ForgotPasswordUseCase struct {
 	userRepo repositories.UserRepository
 	publisher messaging.Publisher
 	logger *logger.Logger
-
+	guard *attemptGuard
 }
 
 func NewForgotPasswordUseCase(
 	userRepo repositories.UserRepository,
 	 publisher messaging.Publisher,
 	 logger *logger.Logger,
-
+	redis utils.RedisInterface,
+	lockoutConfig config.LockoutConfig,
 ) *ForgotPasswordUseCase {
 	return &ForgotPasswordUseCase{
 		userRepo: userRepo,
 		publisher: publisher,
 		logger: logger,
+		guard: newAttemptGuard(redis, publisher, logger, lockoutConfig),
 	}
 }
 
@@ -60,6 +66,13 @@ func (uc *ForgotPasswordUseCase) Execute(
 		return nil, fmt.Errorf("invalid email: %w", err)
 	}
 
+	if err := uc.guard.check(ctx, attemptActionForgotPassword, email.String(), input.IPAddress); err != nil {
+		return nil, err
+	}
+	// Every request counts, successful or not, so the progressive delay
+	// throttles OTP emails to the same account and OTP re-issues.
+	uc.guard.fail(ctx, attemptActionForgotPassword, email.String(), nil, input.IPAddress, input.UserAgent)
+
 	user, err := uc.userRepo.FindByEmail(ctx, email.String())
 	if err != nil {
 		return nil, err
@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+)
+
+type GetJWKSUseCase struct {
+	jwtManager *utils.JwtManager
+}
+
+func NewGetJWKSUseCase(jwtManager *utils.JwtManager) *GetJWKSUseCase {
+	return &GetJWKSUseCase{
+		jwtManager: jwtManager,
+	}
+}
+
+// Execute returns the public keys that downstream services can use to verify
+// access tokens. The set is empty while the service still signs with HS256.
+func (uc *GetJWKSUseCase) Execute(ctx context.Context) (*utils.JSONWebKeySet, error) {
+	jwks := uc.jwtManager.JWKS()
+	return &jwks, nil
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type GetMeInput struct {
+	AccessToken string
+}
+
+type GetMeOutput struct {
+	User *dtos.UserDTO `json:"user"`
+}
+
+type GetMeUseCase struct {
+	userRepo   repositories.UserRepository
+	logger     *logger.Logger
+	jwtManager *utils.JwtManager
+	redis      utils.RedisInterface
+}
+
+func NewGetMeUseCase(
+	userRepo repositories.UserRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *GetMeUseCase {
+	return &GetMeUseCase{
+		userRepo:   userRepo,
+		logger:     logger,
+		jwtManager: jwtManager,
+		redis:      redis,
+	}
+}
+
+// Execute returns the profile of the user the access token belongs to.
+func (uc *GetMeUseCase) Execute(ctx context.Context, input GetMeInput) (*GetMeOutput, error) {
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &GetMeOutput{User: &dto}, nil
+}
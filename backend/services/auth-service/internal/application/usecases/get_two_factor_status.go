@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type GetTwoFactorStatusInput struct {
+	AccessToken string
+}
+
+type GetTwoFactorStatusOutput struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+type GetTwoFactorStatusUseCase struct {
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+}
+
+func NewGetTwoFactorStatusUseCase(
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+) *GetTwoFactorStatusUseCase {
+	return &GetTwoFactorStatusUseCase{
+		twoFactorRepo:   twoFactorRepo,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+	}
+}
+
+func (uc *GetTwoFactorStatusUseCase) Execute(ctx context.Context, input GetTwoFactorStatusInput) (*GetTwoFactorStatusOutput, error) {
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetTwoFactorStatusOutput{
+		Required: uc.twoFactorConfig.IsRequiredFor(claims.Role),
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return output, nil
+	}
+
+	output.Enabled = true
+	output.RecoveryCodesRemaining, err = uc.twoFactorRepo.CountUnusedRecoveryCodes(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to count recovery codes", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	return output, nil
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvitationAlreadyPending = errors.New("a pending invitation already exists for this email")
+	ErrInvitationNotOpen        = errors.New("invitation was already accepted or revoked")
+	ErrInvalidInvitation        = errors.New("invitation is invalid or has expired")
+	ErrInvalidInvitationRole    = errors.New("only instructors and admins can be invited")
+)
+
+// validateInvitationRole only allows roles that cannot self-register.
+func validateInvitationRole(role string) (valueobjects.Role, error) {
+	r, err := valueobjects.NewRole(role)
+	if err != nil {
+		return "", err
+	}
+	if !r.IsInstructor() && !r.IsAdmin() {
+		return "", ErrInvalidInvitationRole
+	}
+	return r, nil
+}
+
+// newInvitationToken returns a fresh token, its hash and the expiry for an
+// invitation.
+func newInvitationToken(duration time.Duration) (token, tokenHash string, expiresAt time.Time, err error) {
+	token, err = authUtils.GenerateInvitationToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, utils.HashToken(token), time.Now().UTC().Add(duration), nil
+}
+
+func publishUserInvited(
+	ctx context.Context,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	invitation *entities.Invitation,
+	claims utils.JwtClaims,
+	acceptURL, token string,
+) {
+	event := events.AuthUserInvitedEvent{
+		ID:             invitation.ID,
+		Email:          invitation.Email,
+		Role:           invitation.Role.String(),
+		InvitedBy:      claims.UserID,
+		InvitedByEmail: claims.Email,
+		InvitationURL:  utils.GenerateInvitationURL(acceptURL, token),
+		ExpiresAt:      invitation.ExpiresAt,
+	}
+	if err := publisher.Publish(ctx, events.EventTypeAuthUserInvited, event); err != nil {
+		logger.Error("failed to publish user invited event", zap.Error(err))
+	}
+}
+
+// canManageInvitation reports whether the admin behind claims may see and
+// manage invitation. Admins of an organization only manage its own
+// invitations.
+func canManageInvitation(ctx context.Context, claims utils.JwtClaims, invitation *entities.Invitation) bool {
+	return tenant.Allows(tenant.WithOrganizationID(ctx, claims.OrganizationID), invitation.OrganizationID)
+}
+
+type InvitationOutput struct {
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	InvitedBy      string     `json:"invited_by"`
+	OrganizationID *string    `json:"organization_id,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID string     `json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func newInvitationOutput(invitation *entities.Invitation, now time.Time) InvitationOutput {
+	return InvitationOutput{
+		ID:             invitation.ID,
+		Email:          invitation.Email,
+		Role:           invitation.Role.String(),
+		Status:         invitation.Status(now),
+		InvitedBy:      invitation.InvitedBy,
+		OrganizationID: invitation.OrganizationID,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+		AcceptedUserID: invitation.AcceptedUserID,
+		RevokedAt:      invitation.RevokedAt,
+		CreatedAt:      invitation.CreatedAt,
+		UpdatedAt:      invitation.UpdatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type ListAPIKeysInput struct {
+	AccessToken      string
+	ServiceAccountID string
+}
+
+type ListAPIKeysOutput struct {
+	APIKeys []APIKeyOutput `json:"api_keys"`
+	Total   int            `json:"total"`
+}
+
+type ListAPIKeysUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	apiKeyRepo         authRepositories.APIKeyRepository
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewListAPIKeysUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	apiKeyRepo authRepositories.APIKeyRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		apiKeyRepo:         apiKeyRepo,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+// Execute lists every key of the account, including revoked and expired
+// ones, without their secrets.
+func (uc *ListAPIKeysUseCase) Execute(ctx context.Context, input ListAPIKeysInput) (*ListAPIKeysOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.serviceAccountRepo.FindByID(ctx, input.ServiceAccountID)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if account == nil || !canManageServiceAccount(ctx, claims, account) {
+		return nil, ErrServiceAccountNotFound
+	}
+
+	keys, err := uc.apiKeyRepo.ListByServiceAccountID(ctx, account.ID)
+	if err != nil {
+		uc.logger.Error("failed to list api keys", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	now := time.Now().UTC()
+	output := &ListAPIKeysOutput{
+		APIKeys: make([]APIKeyOutput, 0, len(keys)),
+		Total:   len(keys),
+	}
+	for _, key := range keys {
+		output.APIKeys = append(output.APIKeys, newAPIKeyOutput(key, now))
+	}
+	return output, nil
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type ListInvitationsInput struct {
+	AccessToken string
+}
+
+type ListInvitationsOutput struct {
+	Invitations []InvitationOutput `json:"invitations"`
+}
+
+type ListInvitationsUseCase struct {
+	invitationRepo authRepositories.InvitationRepository
+	logger         *logger.Logger
+	jwtManager     *utils.JwtManager
+	redis          utils.RedisInterface
+}
+
+func NewListInvitationsUseCase(
+	invitationRepo authRepositories.InvitationRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ListInvitationsUseCase {
+	return &ListInvitationsUseCase{
+		invitationRepo: invitationRepo,
+		logger:         logger,
+		jwtManager:     jwtManager,
+		redis:          redis,
+	}
+}
+
+// Execute lists invitations that were neither accepted nor revoked, including
+// expired ones that can still be resent.
+func (uc *ListInvitationsUseCase) Execute(ctx context.Context, input ListInvitationsInput) (*ListInvitationsOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := uc.invitationRepo.ListOpen(ctx)
+	if err != nil {
+		uc.logger.Error("failed to list invitations", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	now := time.Now().UTC()
+	output := &ListInvitationsOutput{Invitations: make([]InvitationOutput, 0, len(invitations))}
+	for _, invitation := range invitations {
+		if canManageInvitation(ctx, claims, invitation) {
+			output.Invitations = append(output.Invitations, newInvitationOutput(invitation, now))
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSecurityEventLimit = 50
+	maxSecurityEventLimit     = 100
+)
+
+type ListSecurityEventsInput struct {
+	AccessToken string
+	// UserID is the user whose history is listed. Left empty, it is the
+	// caller's own; anyone else's needs an admin.
+	UserID string
+	Limit  int
+}
+
+type ListSecurityEventsOutput struct {
+	Events []SecurityEventOutput `json:"events"`
+}
+
+type ListSecurityEventsUseCase struct {
+	userRepo          repositories.UserRepository
+	securityEventRepo authRepositories.SecurityEventRepository
+	logger            *logger.Logger
+	jwtManager        *utils.JwtManager
+	redis             utils.RedisInterface
+}
+
+func NewListSecurityEventsUseCase(
+	userRepo repositories.UserRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ListSecurityEventsUseCase {
+	return &ListSecurityEventsUseCase{
+		userRepo:          userRepo,
+		securityEventRepo: securityEventRepo,
+		logger:            logger,
+		jwtManager:        jwtManager,
+		redis:             redis,
+	}
+}
+
+// Execute lists a user's security events, newest first.
+func (uc *ListSecurityEventsUseCase) Execute(ctx context.Context, input ListSecurityEventsInput) (*ListSecurityEventsOutput, error) {
+	userID, err := uc.authorize(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultSecurityEventLimit
+	}
+	if limit > maxSecurityEventLimit {
+		limit = maxSecurityEventLimit
+	}
+
+	securityEvents, err := uc.securityEventRepo.ListByUserID(ctx, userID, limit)
+	if err != nil {
+		uc.logger.Error("failed to list security events", zap.String("user_id", userID), zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	output := &ListSecurityEventsOutput{Events: make([]SecurityEventOutput, 0, len(securityEvents))}
+	for _, event := range securityEvents {
+		output.Events = append(output.Events, newSecurityEventOutput(event))
+	}
+	return output, nil
+}
+
+func (uc *ListSecurityEventsUseCase) authorize(ctx context.Context, input ListSecurityEventsInput) (string, error) {
+	if input.UserID == "" {
+		claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+		if err != nil {
+			return "", err
+		}
+		return claims.UserID, nil
+	}
+
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.String("user_id", input.UserID), zap.Error(err))
+		return "", ErrInternalServerError
+	}
+	// Admins of an organization can only read its own users' events.
+	if user == nil || !tenant.Allows(tenant.WithOrganizationID(ctx, claims.OrganizationID), user.OrganizationID) {
+		return "", ErrUserNotFound
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type ListServiceAccountsInput struct {
+	AccessToken string
+}
+
+type ListServiceAccountsOutput struct {
+	ServiceAccounts []ServiceAccountOutput `json:"service_accounts"`
+	Total           int                    `json:"total"`
+}
+
+type ListServiceAccountsUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewListServiceAccountsUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ListServiceAccountsUseCase {
+	return &ListServiceAccountsUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+func (uc *ListServiceAccountsUseCase) Execute(ctx context.Context, input ListServiceAccountsInput) (*ListServiceAccountsOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := uc.serviceAccountRepo.List(ctx)
+	if err != nil {
+		uc.logger.Error("failed to list service accounts", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	output := &ListServiceAccountsOutput{
+		ServiceAccounts: make([]ServiceAccountOutput, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		if canManageServiceAccount(ctx, claims, account) {
+			output.ServiceAccounts = append(output.ServiceAccounts, newServiceAccountOutput(account))
+		}
+	}
+	output.Total = len(output.ServiceAccounts)
+	return output, nil
+}
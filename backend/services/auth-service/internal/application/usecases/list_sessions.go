@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type ListSessionsInput struct {
+	AccessToken string
+}
+
+type SessionInfo struct {
+	SessionID    string    `json:"session_id"`
+	Device       string    `json:"device"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	Current      bool      `json:"current"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActivity time.Time `json:"last_activity"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type ListSessionsOutput struct {
+	Sessions []SessionInfo `json:"sessions"`
+}
+
+type ListSessionsUseCase struct {
+	logger     *logger.Logger
+	jwtManager *utils.JwtManager
+	redis      utils.RedisInterface
+}
+
+func NewListSessionsUseCase(
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		logger:     logger,
+		jwtManager: jwtManager,
+		redis:      redis,
+	}
+}
+
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, input ListSessionsInput) (*ListSessionsOutput, error) {
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.redis.ListUserSessions(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to list user sessions", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	output := &ListSessionsOutput{Sessions: make([]SessionInfo, 0, len(sessions))}
+	for _, session := range sessions {
+		output.Sessions = append(output.Sessions, SessionInfo{
+			SessionID:    session.SessionID,
+			Device:       authUtils.DescribeDevice(session.UserAgent),
+			IPAddress:    session.IPAddress,
+			UserAgent:    session.UserAgent,
+			Current:      session.SessionID == claims.SessionID,
+			CreatedAt:    time.Unix(session.CreatedAt, 0).UTC(),
+			LastActivity: time.Unix(session.LastActivity, 0).UTC(),
+			ExpiresAt:    time.Unix(session.ExpiresAt, 0).UTC(),
+		})
+	}
+
+	sort.Slice(output.Sessions, func(i, j int) bool {
+		return output.Sessions[i].LastActivity.After(output.Sessions[j].LastActivity)
+	})
+
+	return output, nil
+}
@@ -5,15 +5,15 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
-	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
-	"go.uber.org/zap"
 )
 
 
@@ -29,33 +29,51 @@ type LoginInput struct {
 	UserAgent string
 }
 
+// LoginOutput carries either the token pair, or a challenge token when the
+// user still has to pass the two-factor step.
 type LoginOutput struct {
-	User         *dtos.UserDTO `json:"user"`
-	AccessToken  string         `json:"access_token"`
-	RefreshToken string         `json:"refresh_token"`
+	User                   *dtos.UserDTO `json:"user,omitempty"`
+	AccessToken            string        `json:"access_token,omitempty"`
+	RefreshToken           string        `json:"refresh_token,omitempty"`
+	TwoFactorRequired      bool          `json:"two_factor_required,omitempty"`
+	TwoFactorSetupRequired bool          `json:"two_factor_setup_required,omitempty"`
+	ChallengeToken         string        `json:"challenge_token,omitempty"`
+	RecoveryCodes          []string      `json:"recovery_codes,omitempty"`
 }
 
 type LoginUseCase struct {
 	userRepo repositories.UserRepository
+	twoFactorRepo authRepositories.TwoFactorRepository
 	publisher messaging.Publisher
 	logger *logger.Logger
 	jwtManager *utils.JwtManager
 	redis utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+	guard *attemptGuard
+	securityLog *securityLog
 }
 
 func NewLoginUseCase(
 	userRepo repositories.UserRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
 	publisher messaging.Publisher,
 	logger *logger.Logger,
 	jwtManager *utils.JwtManager,
 	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+	lockoutConfig config.LockoutConfig,
 ) *LoginUseCase {
 	return &LoginUseCase{
 		userRepo: userRepo,
+		twoFactorRepo: twoFactorRepo,
 		publisher: publisher,
 		logger: logger,
 		jwtManager: jwtManager,
 		redis: redis,
+		twoFactorConfig: twoFactorConfig,
+		guard: newAttemptGuard(redis, publisher, logger, lockoutConfig),
+		securityLog: newSecurityLog(securityEventRepo, logger),
 	}
 }
 
@@ -70,78 +88,48 @@ func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOu
 		return nil, fmt.Errorf("invalid email: %w", err)
 	}
 
+	if err := uc.guard.check(ctx, attemptActionLogin, email.String(), input.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := uc.userRepo.FindByEmail(ctx, email.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find user by email: %w", err)
 	}
 
 	if user == nil {
+		uc.guard.fail(ctx, attemptActionLogin, email.String(), nil, input.IPAddress, input.UserAgent)
 		return nil, ErrUserNotFound
 	}
 
 	if err := utils.VerifyPassword(input.Password, user.PasswordHash); err != nil {
+		uc.guard.fail(ctx, attemptActionLogin, email.String(), user, input.IPAddress, input.UserAgent)
+		uc.securityLog.record(ctx, newSecurityEvent(user.ID, entities.SecurityEventLoginFailed, loginMethodPassword, input.IPAddress, input.UserAgent))
 		return nil, ErrInvalidPassword
 	}
 
-	accessToken, err := uc.jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
+	uc.guard.succeed(ctx, attemptActionLogin, email.String())
 
-	refreshToken, err := uc.jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	twoFactorEnabled := hasTwoFactorEnabled(ctx, uc.twoFactorRepo, uc.logger, user.ID)
+	if twoFactorEnabled || uc.twoFactorConfig.IsRequiredFor(user.Role.String()) {
+		return startTwoFactorChallenge(ctx, uc.redis, uc.logger, uc.twoFactorConfig, user, input.IPAddress, input.UserAgent, !twoFactorEnabled)
 	}
 
-	sessionID := uuid.NewString()
-
-
-	if err := uc.redis.StoreAccessToken(ctx, user.ID, accessToken, uc.jwtManager.AccessTokenDuration()); err != nil {
-		uc.logger.Warn("failed to store access token", zap.Error(err))
-	}
-
-	if err := uc.redis.StoreRefreshToken(ctx, user.ID, refreshToken, uc.jwtManager.RefreshTokenDuration()); err != nil {
-		uc.logger.Warn("failed to store refresh token", zap.Error(err))
-	}
-
-	
-	if err := uc.redis.StoreUserSession(
-		ctx,
-		sessionID,
-		user.ID,
-		accessToken,
-		refreshToken,
-		input.IPAddress,
-		input.UserAgent,
-		uc.jwtManager.AccessTokenDuration(),
-	); err != nil {
-		uc.logger.Warn("failed to store user session", zap.Error(err))
-	}
-
-	event := events.AuthUserLoggedInEvent{
-		ID:            user.ID,
-		Email:         user.Email.String(),
-		Username:      user.Username,
-		Role:          user.Role.String(),
-		Status:        user.Status.String(),
-		EmailVerified: user.EmailVerified,
-		EmailVerifiedAt: user.EmailVerifiedAt,
-		CreatedAt:     user.CreatedAt,
-		UpdatedAt:     user.UpdatedAt,
+	session, err := startSession(ctx, uc.jwtManager, uc.redis, uc.logger, user, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
 	}
 
-	
-	if err := uc.publisher.Publish(ctx, events.EventTypeAuthUserLoggedIn, event); err != nil {
-		uc.logger.Error("failed to publish user logged in event", zap.Error(err))
-	}
+	publishUserLoggedIn(ctx, uc.publisher, uc.logger, user, input.IPAddress, input.UserAgent)
+	uc.securityLog.recordLogin(ctx, uc.publisher, user, loginMethodPassword, input.IPAddress, input.UserAgent)
 
 	var dto dtos.UserDTO
 	dto.FromEntity(user)
 
 	return &LoginOutput{
 		User:         &dto,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  session.AccessToken,
+		RefreshToken: session.RefreshToken,
 	}, nil
 }
 
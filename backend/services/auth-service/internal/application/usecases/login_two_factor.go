@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type LoginTwoFactorInput struct {
+	ChallengeToken string
+	Code           string
+	RecoveryCode   string
+	IPAddress      string
+	UserAgent      string
+}
+
+type LoginTwoFactorUseCase struct {
+	userRepo        repositories.UserRepository
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	publisher       messaging.Publisher
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+	securityLog     *securityLog
+}
+
+func NewLoginTwoFactorUseCase(
+	userRepo repositories.UserRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+) *LoginTwoFactorUseCase {
+	return &LoginTwoFactorUseCase{
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		publisher:       publisher,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+		securityLog:     newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute completes a login started by LoginUseCase. For users finishing a
+// forced enrollment, the code also confirms the new secret and the recovery
+// codes are returned alongside the tokens.
+func (uc *LoginTwoFactorUseCase) Execute(ctx context.Context, input LoginTwoFactorInput) (*LoginOutput, error) {
+	if input.ChallengeToken == "" {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	challenge, err := uc.redis.GetTwoFactorChallenge(ctx, input.ChallengeToken)
+	if err != nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if challenge.Attempts >= uc.twoFactorConfig.MaxAttempts {
+		uc.revokeChallenge(ctx, input.ChallengeToken)
+		return nil, ErrTooManyTwoFactorAttempts
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil || !user.IsActive() {
+		uc.revokeChallenge(ctx, input.ChallengeToken)
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	if challenge.SetupRequired {
+		if twoFactor == nil || twoFactor.Enabled {
+			return nil, ErrTwoFactorNotSetUp
+		}
+		twoFactor.Enable()
+		err = verifyTOTP(ctx, uc.twoFactorRepo, twoFactor, input.Code)
+	} else {
+		if twoFactor == nil || !twoFactor.Enabled {
+			uc.revokeChallenge(ctx, input.ChallengeToken)
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		err = verifySecondFactor(ctx, uc.twoFactorRepo, twoFactor, input.Code, input.RecoveryCode)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			uc.recordFailedAttempt(ctx, input.ChallengeToken, challenge)
+			uc.securityLog.record(ctx, newSecurityEvent(user.ID, entities.SecurityEventLoginFailed, loginMethodTwoFactor, input.IPAddress, input.UserAgent))
+			return nil, err
+		}
+		if errors.Is(err, ErrTwoFactorCodeRequired) {
+			return nil, err
+		}
+		uc.logger.Error("failed to verify two factor code", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	uc.revokeChallenge(ctx, input.ChallengeToken)
+
+	var recoveryCodes []string
+	if challenge.SetupRequired {
+		recoveryCodes, err = issueRecoveryCodes(ctx, uc.twoFactorRepo, user.ID)
+		if err != nil {
+			uc.logger.Error("failed to issue recovery codes", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		uc.securityLog.record(ctx, newSecurityEvent(user.ID, entities.SecurityEventTwoFactorEnabled, "", input.IPAddress, input.UserAgent))
+	}
+
+	session, err := startSession(ctx, uc.jwtManager, uc.redis, uc.logger, user, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	publishUserLoggedIn(ctx, uc.publisher, uc.logger, user, input.IPAddress, input.UserAgent)
+	uc.securityLog.recordLogin(ctx, uc.publisher, user, loginMethodTwoFactor, input.IPAddress, input.UserAgent)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &LoginOutput{
+		User:          &dto,
+		AccessToken:   session.AccessToken,
+		RefreshToken:  session.RefreshToken,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (uc *LoginTwoFactorUseCase) recordFailedAttempt(ctx context.Context, token string, challenge *utils.TwoFactorChallenge) {
+	challenge.Attempts++
+	ttl := time.Until(time.Unix(challenge.ExpiresAt, 0))
+	if ttl <= 0 {
+		uc.revokeChallenge(ctx, token)
+		return
+	}
+	if err := uc.redis.StoreTwoFactorChallenge(ctx, token, challenge, ttl); err != nil {
+		uc.logger.Warn("failed to record two factor attempt", zap.Error(err))
+	}
+}
+
+func (uc *LoginTwoFactorUseCase) revokeChallenge(ctx context.Context, token string) {
+	if err := uc.redis.RevokeTwoFactorChallenge(ctx, token); err != nil {
+		uc.logger.Warn("failed to revoke two factor challenge", zap.Error(err))
+	}
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type LogoutInput struct {
+	AccessToken string
+	AllSessions bool
+	IPAddress   string
+	UserAgent   string
+}
+
+type LogoutOutput struct {
+	Message         string `json:"message"`
+	RevokedSessions int    `json:"revoked_sessions"`
+}
+
+type LogoutUseCase struct {
+	userRepo    repositories.UserRepository
+	publisher   messaging.Publisher
+	logger      *logger.Logger
+	jwtManager  *utils.JwtManager
+	redis       utils.RedisInterface
+	securityLog *securityLog
+}
+
+func NewLogoutUseCase(
+	userRepo repositories.UserRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *LogoutUseCase {
+	return &LogoutUseCase{
+		userRepo:    userRepo,
+		publisher:   publisher,
+		logger:      logger,
+		jwtManager:  jwtManager,
+		redis:       redis,
+		securityLog: newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+func (uc *LogoutUseCase) Execute(ctx context.Context, input LogoutInput) (*LogoutOutput, error) {
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*utils.SessionData
+	if input.AllSessions {
+		sessions, err = uc.redis.ListUserSessions(ctx, claims.UserID)
+		if err != nil {
+			uc.logger.Error("failed to list user sessions", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+	} else if claims.SessionID != "" {
+		session, err := uc.redis.GetUserSession(ctx, claims.SessionID)
+		if err == nil && session.UserID == claims.UserID {
+			sessions = append(sessions, session)
+		}
+	}
+
+	sessionIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if err := revokeSession(ctx, uc.redis, session); err != nil {
+			uc.logger.Error("failed to revoke session", zap.String("session_id", session.SessionID), zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		sessionIDs = append(sessionIDs, session.SessionID)
+	}
+
+	// The presented token may predate session tracking, so revoke it directly too.
+	if err := uc.redis.RevokeAccessToken(ctx, input.AccessToken); err != nil {
+		uc.logger.Error("failed to revoke access token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	if input.AllSessions {
+		event := newSecurityEvent(claims.UserID, entities.SecurityEventSessionRevoked, "all_sessions", input.IPAddress, input.UserAgent)
+		event.ActorID = actorOf(claims)
+		uc.securityLog.record(ctx, event)
+	}
+
+	publishUserLoggedOut(ctx, uc.userRepo, uc.publisher, uc.logger, claims.UserID, sessionIDs)
+
+	uc.logger.Info("user logged out",
+		zap.String("user_id", claims.UserID),
+		zap.Bool("all_sessions", input.AllSessions),
+		zap.Int("revoked_sessions", len(sessionIDs)),
+	)
+
+	message := "Logged out successfully"
+	if input.AllSessions {
+		message = "Logged out of all sessions successfully"
+	}
+
+	return &LogoutOutput{
+		Message:         message,
+		RevokedSessions: len(sessionIDs),
+	}, nil
+}
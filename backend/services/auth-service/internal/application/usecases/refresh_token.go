@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -15,10 +20,13 @@ import (
 var (
 	ErrInvalidRefreshToken   = errors.New("invalid refresh token")
 	ErrRefreshTokenRequired  = errors.New("refresh token is required")
+	ErrRefreshTokenReused    = errors.New("refresh token has already been used")
 )
 
 type RefreshTokenInput struct {
 	RefreshToken string
+	IPAddress    string
+	UserAgent    string
 }
 
 type RefreshTokenOutput struct {
@@ -27,23 +35,29 @@ type RefreshTokenOutput struct {
 }
 
 type RefreshTokenUseCase struct {
-	userRepo   repositories.UserRepository
-	logger     *logger.Logger
-	jwtManager *utils.JwtManager
-	redis      utils.RedisInterface
+	userRepo    repositories.UserRepository
+	publisher   messaging.Publisher
+	logger      *logger.Logger
+	jwtManager  *utils.JwtManager
+	redis       utils.RedisInterface
+	securityLog *securityLog
 }
 
 func NewRefreshTokenUseCase(
 	userRepo repositories.UserRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
 	logger *logger.Logger,
 	jwtManager *utils.JwtManager,
 	redis utils.RedisInterface,
 ) *RefreshTokenUseCase {
 	return &RefreshTokenUseCase{
-		userRepo:   userRepo,
-		logger:     logger,
-		jwtManager: jwtManager,
-		redis:      redis,
+		userRepo:    userRepo,
+		publisher:   publisher,
+		logger:      logger,
+		jwtManager:  jwtManager,
+		redis:       redis,
+		securityLog: newSecurityLog(securityEventRepo, logger),
 	}
 }
 
@@ -59,6 +73,28 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		return nil, ErrInvalidRefreshToken
 	}
 
+	// Tokens issued with a session belong to a family keyed by the session ID.
+	// A token that is valid but no longer the latest in its family has been
+	// used before, so someone is replaying it.
+	familyID := claims.SessionID
+	if familyID != "" {
+		family, err := uc.redis.GetRefreshTokenFamily(ctx, familyID)
+		if err != nil {
+			uc.logger.Error("refresh token family not found", zap.String("family_id", familyID), zap.Error(err))
+			return nil, ErrInvalidRefreshToken
+		}
+		if family.UserID != claims.UserID {
+			uc.logger.Error("user ID mismatch between token and family",
+				zap.String("token_user_id", claims.UserID),
+				zap.String("family_user_id", family.UserID))
+			return nil, ErrInvalidRefreshToken
+		}
+		if !family.IsCurrent(input.RefreshToken) {
+			uc.handleReuse(ctx, claims.UserID, familyID, input)
+			return nil, ErrRefreshTokenReused
+		}
+	}
+
 	userID, err := uc.redis.GetUserFromRefreshToken(ctx, input.RefreshToken)
 	if err != nil {
 		uc.logger.Error("refresh token not found in Redis", zap.Error(err))
@@ -91,6 +127,8 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		user.Email.String(),
 		user.Role.String(),
 		user.Status.String(),
+		claims.SessionID,
+		organizationIDOf(user),
 	)
 	if err != nil {
 		uc.logger.Error("failed to generate access token", zap.Error(err))
@@ -103,6 +141,8 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		user.Email.String(),
 		user.Role.String(),
 		user.Status.String(),
+		claims.SessionID,
+		organizationIDOf(user),
 	)
 	if err != nil {
 		uc.logger.Error("failed to generate refresh token", zap.Error(err))
@@ -110,6 +150,22 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 	}
 
 
+	if familyID != "" {
+		err := uc.redis.RotateRefreshTokenFamily(ctx, familyID, input.RefreshToken, newRefreshToken, uc.jwtManager.RefreshTokenDuration())
+		if errors.Is(err, utils.ErrRefreshTokenReused) {
+			uc.handleReuse(ctx, user.ID, familyID, input)
+			return nil, ErrRefreshTokenReused
+		}
+		if err != nil {
+			uc.logger.Error("failed to rotate refresh token family", zap.Error(err))
+			return nil, ErrInvalidRefreshToken
+		}
+	}
+
+	if err := uc.redis.RevokeRefreshToken(ctx, input.RefreshToken); err != nil {
+		uc.logger.Warn("failed to revoke used refresh token", zap.Error(err))
+	}
+
 	if err := uc.redis.StoreAccessToken(ctx, user.ID, accessToken, uc.jwtManager.AccessTokenDuration()); err != nil {
 		uc.logger.Warn("failed to store access token", zap.Error(err))
 	}
@@ -119,12 +175,61 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		uc.logger.Warn("failed to store refresh token", zap.Error(err))
 	}
 
+	if claims.SessionID != "" {
+		// The session only remembers its latest access token, so the one being
+		// replaced is revoked now; otherwise logging out would leave it valid
+		// until it expires.
+		if session, err := uc.redis.GetUserSession(ctx, claims.SessionID); err == nil {
+			if err := uc.redis.RevokeAccessToken(ctx, session.AccessToken); err != nil {
+				uc.logger.Warn("failed to revoke replaced access token", zap.Error(err))
+			}
+		}
+		if err := uc.redis.UpdateUserSessionTokens(ctx, claims.SessionID, accessToken, newRefreshToken, uc.jwtManager.RefreshTokenDuration()); err != nil {
+			uc.logger.Warn("failed to update user session tokens", zap.Error(err))
+		}
+	}
+
 	return &RefreshTokenOutput{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
 	}, nil
 }
 
+// handleReuse revokes the whole token family along with its session, so both
+// the attacker and the legitimate client have to log in again.
+func (uc *RefreshTokenUseCase) handleReuse(ctx context.Context, userID, familyID string, input RefreshTokenInput) {
+	uc.logger.Warn("refresh token reuse detected",
+		zap.String("user_id", userID),
+		zap.String("family_id", familyID),
+		zap.String("ip_address", input.IPAddress))
+
+	if session, err := uc.redis.GetUserSession(ctx, familyID); err == nil {
+		if err := revokeSession(ctx, uc.redis, session); err != nil {
+			uc.logger.Error("failed to revoke session of reused refresh token", zap.Error(err))
+		}
+	} else if err := uc.redis.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		uc.logger.Error("failed to revoke refresh token family", zap.Error(err))
+	}
+
+	uc.securityLog.record(ctx, newSecurityEvent(userID, entities.SecurityEventSessionRevoked, "refresh_token_reused", input.IPAddress, input.UserAgent))
+
+	event := events.AuthRefreshTokenReusedEvent{
+		ID:         userID,
+		FamilyID:   familyID,
+		IPAddress:  input.IPAddress,
+		UserAgent:  input.UserAgent,
+		DetectedAt: time.Now(),
+	}
+	if user, err := uc.userRepo.FindByID(ctx, userID); err == nil && user != nil {
+		event.Email = user.Email.String()
+		event.Username = user.Username
+	}
+
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthRefreshTokenReused, event); err != nil {
+		uc.logger.Error("failed to publish refresh token reused event", zap.Error(err))
+	}
+}
+
 func (uc *RefreshTokenUseCase) validateInput(input RefreshTokenInput) error {
 	if input.RefreshToken == "" {
 		return ErrRefreshTokenRequired
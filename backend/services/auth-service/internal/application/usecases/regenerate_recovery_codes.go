@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type RegenerateRecoveryCodesInput struct {
+	AccessToken string
+	Code        string
+	IPAddress   string
+	UserAgent   string
+}
+
+type RegenerateRecoveryCodesOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type RegenerateRecoveryCodesUseCase struct {
+	twoFactorRepo authRepositories.TwoFactorRepository
+	logger        *logger.Logger
+	jwtManager    *utils.JwtManager
+	redis         utils.RedisInterface
+	securityLog   *securityLog
+}
+
+func NewRegenerateRecoveryCodesUseCase(
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *RegenerateRecoveryCodesUseCase {
+	return &RegenerateRecoveryCodesUseCase{
+		twoFactorRepo: twoFactorRepo,
+		logger:        logger,
+		jwtManager:    jwtManager,
+		redis:         redis,
+		securityLog:   newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute invalidates all existing recovery codes and issues new ones. A
+// current TOTP code is required so recovery codes cannot be used to mint more.
+func (uc *RegenerateRecoveryCodesUseCase) Execute(ctx context.Context, input RegenerateRecoveryCodesInput) (*RegenerateRecoveryCodesOutput, error) {
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := uc.twoFactorRepo.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err := verifyTOTP(ctx, uc.twoFactorRepo, twoFactor, input.Code); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) || errors.Is(err, ErrTwoFactorCodeRequired) {
+			return nil, err
+		}
+		uc.logger.Error("failed to verify two factor code", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	codes, err := issueRecoveryCodes(ctx, uc.twoFactorRepo, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to issue recovery codes", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	uc.securityLog.record(ctx, newSecurityEvent(claims.UserID, entities.SecurityEventRecoveryCodesRegenerated, "", input.IPAddress, input.UserAgent))
+
+	return &RegenerateRecoveryCodesOutput{RecoveryCodes: codes}, nil
+}
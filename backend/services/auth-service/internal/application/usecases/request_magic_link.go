@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type RequestMagicLinkInput struct {
+	Email     string
+	IPAddress string
+	UserAgent string
+}
+
+type RequestMagicLinkOutput struct {
+	Message string `json:"message"`
+}
+
+type RequestMagicLinkUseCase struct {
+	userRepo        repositories.UserRepository
+	publisher       messaging.Publisher
+	logger          *logger.Logger
+	redis           utils.RedisInterface
+	magicLinkConfig config.MagicLinkConfig
+}
+
+func NewRequestMagicLinkUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	redis utils.RedisInterface,
+	magicLinkConfig config.MagicLinkConfig,
+) *RequestMagicLinkUseCase {
+	return &RequestMagicLinkUseCase{
+		userRepo:        userRepo,
+		publisher:       publisher,
+		logger:          logger,
+		redis:           redis,
+		magicLinkConfig: magicLinkConfig,
+	}
+}
+
+// Execute emails a single-use sign-in link. The response is the same whether
+// or not a link was sent, so it cannot be used to find registered emails or
+// their roles.
+func (uc *RequestMagicLinkUseCase) Execute(ctx context.Context, input RequestMagicLinkInput) (*RequestMagicLinkOutput, error) {
+	if input.Email == "" {
+		return nil, ErrEmailRequired
+	}
+	email, err := valueobjects.NewEmail(input.Email)
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+
+	output := &RequestMagicLinkOutput{
+		Message: "If the account can sign in by email, a sign-in link has been sent",
+	}
+
+	user, _ := uc.userRepo.FindByEmail(ctx, email.String())
+	if user == nil || !user.Status.CanLogin() || !uc.magicLinkConfig.IsAllowedFor(user.Role.String()) {
+		return output, nil
+	}
+
+	token := uuid.New().String()
+	if err := uc.redis.StoreMagicLinkToken(ctx, user.ID, token, uc.magicLinkConfig.TokenTTL); err != nil {
+		uc.logger.Error("failed to store magic link token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthMagicLinkRequestedEvent{
+		ID:           user.ID,
+		Email:        user.Email.String(),
+		Username:     user.Username,
+		MagicLinkURL: utils.GenerateMagicLinkURL(uc.magicLinkConfig.LoginURL, token),
+		IPAddress:    input.IPAddress,
+		UserAgent:    input.UserAgent,
+		ExpiresAt:    time.Now().Add(uc.magicLinkConfig.TokenTTL),
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthMagicLinkRequested, event); err != nil {
+		uc.logger.Error("failed to publish magic link requested event", zap.Error(err))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type ResendInvitationInput struct {
+	AccessToken  string
+	InvitationID string
+}
+
+type ResendInvitationUseCase struct {
+	invitationRepo   authRepositories.InvitationRepository
+	publisher        messaging.Publisher
+	logger           *logger.Logger
+	jwtManager       *utils.JwtManager
+	redis            utils.RedisInterface
+	invitationConfig config.InvitationConfig
+}
+
+func NewResendInvitationUseCase(
+	invitationRepo authRepositories.InvitationRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	invitationConfig config.InvitationConfig,
+) *ResendInvitationUseCase {
+	return &ResendInvitationUseCase{
+		invitationRepo:   invitationRepo,
+		publisher:        publisher,
+		logger:           logger,
+		jwtManager:       jwtManager,
+		redis:            redis,
+		invitationConfig: invitationConfig,
+	}
+}
+
+// Execute sends a pending or expired invitation again with a new token and
+// expiry. The link in the earlier email stops working.
+func (uc *ResendInvitationUseCase) Execute(ctx context.Context, input ResendInvitationInput) (*InvitationOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.InvitationID)
+	if err != nil {
+		uc.logger.Error("failed to find invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if invitation == nil || !canManageInvitation(ctx, claims, invitation) {
+		return nil, ErrInvitationNotFound
+	}
+	if !invitation.IsOpen() {
+		return nil, ErrInvitationNotOpen
+	}
+
+	token, tokenHash, expiresAt, err := newInvitationToken(uc.invitationConfig.TokenDuration)
+	if err != nil {
+		uc.logger.Error("failed to generate invitation token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	invitation.Renew(tokenHash, expiresAt)
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		uc.logger.Error("failed to update invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	publishUserInvited(ctx, uc.publisher, uc.logger, invitation, claims, uc.invitationConfig.AcceptURL, token)
+
+	uc.logger.Info("invitation resent",
+		zap.String("invitation_id", invitation.ID),
+		zap.String("resent_by", claims.UserID),
+	)
+
+	output := newInvitationOutput(invitation, time.Now().UTC())
+	return &output, nil
+}
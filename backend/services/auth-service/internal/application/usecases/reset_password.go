@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
@@ -35,10 +37,11 @@ type ResetPasswordUseCase struct {
 	publisher messaging.Publisher
 	logger *logger.Logger
 	redis utils.RedisInterface
+	securityLog *securityLog
 }
 
-func NewResetPasswordUseCase(userRepo repositories.UserRepository, publisher messaging.Publisher, logger *logger.Logger, redis utils.RedisInterface) *ResetPasswordUseCase {
-	return &ResetPasswordUseCase{userRepo: userRepo, publisher: publisher, logger: logger, redis: redis}
+func NewResetPasswordUseCase(userRepo repositories.UserRepository, securityEventRepo authRepositories.SecurityEventRepository, publisher messaging.Publisher, logger *logger.Logger, redis utils.RedisInterface) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{userRepo: userRepo, publisher: publisher, logger: logger, redis: redis, securityLog: newSecurityLog(securityEventRepo, logger)}
 }
 
 func (uc *ResetPasswordUseCase) Execute(ctx context.Context, input ResetPasswordInput) (*ResetPasswordOutput, error) {
@@ -91,6 +94,7 @@ func (uc *ResetPasswordUseCase) Execute(ctx context.Context, input ResetPassword
 		output.Message = err.Error()
 		return output, err
 	}
+	uc.securityLog.record(ctx, newSecurityEvent(userID, entities.SecurityEventPasswordReset, "", input.IPAddress, input.UserAgent))
 	event := events.AuthUserResetPasswordEvent{
 		ID: userID,
 		Username: user.Username,
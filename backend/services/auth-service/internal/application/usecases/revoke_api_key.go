@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type RevokeAPIKeyInput struct {
+	AccessToken      string
+	ServiceAccountID string
+	APIKeyID         string
+}
+
+type RevokeAPIKeyOutput struct {
+	Message string `json:"message"`
+}
+
+type RevokeAPIKeyUseCase struct {
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	apiKeyRepo         authRepositories.APIKeyRepository
+	publisher          messaging.Publisher
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+}
+
+func NewRevokeAPIKeyUseCase(
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	apiKeyRepo authRepositories.APIKeyRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		serviceAccountRepo: serviceAccountRepo,
+		apiKeyRepo:         apiKeyRepo,
+		publisher:          publisher,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+	}
+}
+
+// Execute revokes the key at once; /verify rejects it from the next request.
+// Revoking an already revoked key succeeds without changing it.
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, input RevokeAPIKeyInput) (*RevokeAPIKeyOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := uc.apiKeyRepo.FindByID(ctx, input.APIKeyID)
+	if err != nil {
+		uc.logger.Error("failed to find api key", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if key == nil || key.ServiceAccountID != input.ServiceAccountID {
+		return nil, ErrAPIKeyNotFound
+	}
+	account, err := uc.serviceAccountRepo.FindByID(ctx, key.ServiceAccountID)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if account == nil || !canManageServiceAccount(ctx, claims, account) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if key.IsRevoked() {
+		return &RevokeAPIKeyOutput{Message: "API key revoked successfully"}, nil
+	}
+
+	revokedAt := time.Now().UTC()
+	if err := uc.apiKeyRepo.Revoke(ctx, key.ID, revokedAt); err != nil {
+		uc.logger.Error("failed to revoke api key", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthAPIKeyRevokedEvent{
+		ID:               key.ID,
+		ServiceAccountID: key.ServiceAccountID,
+		Prefix:           key.Prefix,
+		RevokedBy:        claims.UserID,
+		RevokedAt:        revokedAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthAPIKeyRevoked, event); err != nil {
+		uc.logger.Error("failed to publish api key revoked event", zap.Error(err))
+	}
+
+	uc.logger.Info("api key revoked",
+		zap.String("service_account_id", key.ServiceAccountID),
+		zap.String("api_key_id", key.ID),
+		zap.String("revoked_by", claims.UserID),
+	)
+
+	return &RevokeAPIKeyOutput{Message: "API key revoked successfully"}, nil
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type RevokeInvitationInput struct {
+	AccessToken  string
+	InvitationID string
+}
+
+type RevokeInvitationOutput struct {
+	Message string `json:"message"`
+}
+
+type RevokeInvitationUseCase struct {
+	invitationRepo authRepositories.InvitationRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+	jwtManager     *utils.JwtManager
+	redis          utils.RedisInterface
+}
+
+func NewRevokeInvitationUseCase(
+	invitationRepo authRepositories.InvitationRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *RevokeInvitationUseCase {
+	return &RevokeInvitationUseCase{
+		invitationRepo: invitationRepo,
+		publisher:      publisher,
+		logger:         logger,
+		jwtManager:     jwtManager,
+		redis:          redis,
+	}
+}
+
+// Execute revokes an invitation so its link can no longer be accepted.
+// Accepted invitations cannot be revoked; revoking twice succeeds.
+func (uc *RevokeInvitationUseCase) Execute(ctx context.Context, input RevokeInvitationInput) (*RevokeInvitationOutput, error) {
+	claims, err := authenticateAdmin(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := uc.invitationRepo.FindByID(ctx, input.InvitationID)
+	if err != nil {
+		uc.logger.Error("failed to find invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if invitation == nil || !canManageInvitation(ctx, claims, invitation) {
+		return nil, ErrInvitationNotFound
+	}
+	if invitation.RevokedAt != nil {
+		return &RevokeInvitationOutput{Message: "Invitation revoked successfully"}, nil
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, ErrInvitationNotOpen
+	}
+
+	invitation.Revoke()
+	if err := uc.invitationRepo.Update(ctx, invitation); err != nil {
+		uc.logger.Error("failed to revoke invitation", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthInvitationRevokedEvent{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		RevokedBy: claims.UserID,
+		RevokedAt: *invitation.RevokedAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthInvitationRevoked, event); err != nil {
+		uc.logger.Error("failed to publish invitation revoked event", zap.Error(err))
+	}
+
+	uc.logger.Info("invitation revoked",
+		zap.String("invitation_id", invitation.ID),
+		zap.String("revoked_by", claims.UserID),
+	)
+
+	return &RevokeInvitationOutput{Message: "Invitation revoked successfully"}, nil
+}
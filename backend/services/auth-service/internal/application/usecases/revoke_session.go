@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type RevokeSessionInput struct {
+	AccessToken string
+	SessionID   string
+	IPAddress   string
+	UserAgent   string
+}
+
+type RevokeSessionOutput struct {
+	Message string `json:"message"`
+}
+
+type RevokeSessionUseCase struct {
+	userRepo    repositories.UserRepository
+	publisher   messaging.Publisher
+	logger      *logger.Logger
+	jwtManager  *utils.JwtManager
+	redis       utils.RedisInterface
+	securityLog *securityLog
+}
+
+func NewRevokeSessionUseCase(
+	userRepo repositories.UserRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		userRepo:    userRepo,
+		publisher:   publisher,
+		logger:      logger,
+		jwtManager:  jwtManager,
+		redis:       redis,
+		securityLog: newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, input RevokeSessionInput) (*RevokeSessionOutput, error) {
+	if input.SessionID == "" {
+		return nil, ErrSessionIDRequired
+	}
+
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := uc.redis.GetUserSession(ctx, input.SessionID)
+	if err != nil || session.UserID != claims.UserID {
+		return nil, ErrSessionNotFound
+	}
+
+	if err := revokeSession(ctx, uc.redis, session); err != nil {
+		uc.logger.Error("failed to revoke session", zap.String("session_id", session.SessionID), zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := newSecurityEvent(claims.UserID, entities.SecurityEventSessionRevoked, "session", input.IPAddress, input.UserAgent)
+	event.ActorID = actorOf(claims)
+	uc.securityLog.record(ctx, event)
+
+	publishUserLoggedOut(ctx, uc.userRepo, uc.publisher, uc.logger, claims.UserID, []string{session.SessionID})
+
+	uc.logger.Info("session revoked",
+		zap.String("user_id", claims.UserID),
+		zap.String("session_id", session.SessionID),
+	)
+
+	return &RevokeSessionOutput{Message: "Session revoked successfully"}, nil
+}
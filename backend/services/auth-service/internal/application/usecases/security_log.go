@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	sharedEntities "github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// Login methods recorded as the detail of login events.
+const (
+	loginMethodPassword  = "password"
+	loginMethodTwoFactor = "two_factor"
+	loginMethodMagicLink = "magic_link"
+	loginMethodOIDC      = "oidc"
+)
+
+// securityLog writes users' security history. Errors are logged and never
+// fail the action being recorded.
+type securityLog struct {
+	repo   authRepositories.SecurityEventRepository
+	logger *logger.Logger
+}
+
+func newSecurityLog(repo authRepositories.SecurityEventRepository, logger *logger.Logger) *securityLog {
+	return &securityLog{repo: repo, logger: logger}
+}
+
+func newSecurityEvent(userID, eventType, detail, ipAddress, userAgent string) *entities.SecurityEvent {
+	return entities.NewSecurityEvent(userID, eventType, detail, ipAddress, userAgent, authUtils.DescribeDevice(userAgent))
+}
+
+// actorOf returns the admin behind an impersonation token, if any.
+func actorOf(claims utils.JwtClaims) string {
+	if claims.IsImpersonated() {
+		return claims.Actor.Subject
+	}
+	return ""
+}
+
+func (l *securityLog) record(ctx context.Context, event *entities.SecurityEvent) {
+	if err := l.repo.Create(ctx, event); err != nil {
+		l.logger.Warn("failed to record security event",
+			zap.String("user_id", event.UserID),
+			zap.String("type", event.Type),
+			zap.Error(err),
+		)
+	}
+}
+
+// recordLogin records a successful login. When the user has signed in before
+// but never from this IP address or device, an alert for them is published.
+func (l *securityLog) recordLogin(ctx context.Context, publisher messaging.Publisher, user *sharedEntities.User, method, ipAddress, userAgent string) {
+	event := newSecurityEvent(user.ID, entities.SecurityEventLogin, method, ipAddress, userAgent)
+
+	// The source is looked up before this login is stored, or it would
+	// always be known.
+	source, err := l.repo.FindLoginSource(ctx, user.ID, event.IPAddress, event.Device)
+	if err != nil {
+		l.logger.Warn("failed to check login source", zap.String("user_id", user.ID), zap.Error(err))
+	}
+
+	l.record(ctx, event)
+
+	if source == nil || !source.IsNew() {
+		return
+	}
+
+	alert := events.AuthNewLoginDetectedEvent{
+		ID:         user.ID,
+		Email:      user.Email.String(),
+		Username:   user.Username,
+		Device:     event.Device,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		NewIP:      !source.KnownIP,
+		NewDevice:  !source.KnownDevice,
+		LoggedInAt: event.CreatedAt,
+	}
+	if err := publisher.Publish(ctx, events.EventTypeAuthNewLoginDetected, alert); err != nil {
+		l.logger.Error("failed to publish new login detected event", zap.Error(err))
+	}
+}
+
+// SecurityEventOutput is one entry of a user's security history.
+type SecurityEventOutput struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Detail    string    `json:"detail,omitempty"`
+	IPAddress string    `json:"ip_address"`
+	Device    string    `json:"device"`
+	UserAgent string    `json:"user_agent"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newSecurityEventOutput(event *entities.SecurityEvent) SecurityEventOutput {
+	return SecurityEventOutput{
+		ID:        event.ID,
+		Type:      event.Type,
+		Detail:    event.Detail,
+		IPAddress: event.IPAddress,
+		Device:    event.Device,
+		UserAgent: event.UserAgent,
+		ActorID:   event.ActorID,
+		CreatedAt: event.CreatedAt,
+	}
+}
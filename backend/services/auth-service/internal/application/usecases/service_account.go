@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+)
+
+var (
+	ErrServiceAccountNotFound      = errors.New("service account not found")
+	ErrServiceAccountNameRequired  = errors.New("service account name is required")
+	ErrServiceAccountAlreadyExists = errors.New("service account already exists")
+	ErrServiceAccountDisabled      = errors.New("service account is disabled")
+	ErrAPIKeyNotFound              = errors.New("api key not found")
+	ErrAPIKeyNameRequired          = errors.New("api key name is required")
+	ErrAPIKeyScopesRequired        = errors.New("api key needs at least one scope")
+	ErrInvalidAPIKeyScope          = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiry         = errors.New("api key expiry must be in the future")
+	// ErrServiceAccountOrganizationRequired is returned for non-admin
+	// accounts outside any organization, which /verify would refuse.
+	ErrServiceAccountOrganizationRequired = errors.New("only admin service accounts can belong to no organization")
+)
+
+var apiKeyScopePattern = regexp.MustCompile(`^[a-z0-9-]+:(read|write|\*)$`)
+
+// validateScopes accepts "*" or <resource>:<read|write|*> scopes.
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return ErrAPIKeyScopesRequired
+	}
+	for _, scope := range scopes {
+		if scope != entities.ScopeAll && !apiKeyScopePattern.MatchString(scope) {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+	return nil
+}
+
+// authenticateAdmin is authenticateAccountOwner for endpoints only admins
+// may use.
+func authenticateAdmin(ctx context.Context, jwtManager *utils.JwtManager, redis utils.RedisInterface, token string) (utils.JwtClaims, error) {
+	claims, err := authenticateAccountOwner(ctx, jwtManager, redis, token)
+	if err != nil {
+		return utils.JwtClaims{}, err
+	}
+	if claims.Role != valueobjects.RoleAdmin.String() {
+		return utils.JwtClaims{}, ErrInsufficientPermissions
+	}
+	return claims, nil
+}
+
+// canManageServiceAccount reports whether the admin behind claims may see and
+// manage account. Admins of an organization only manage its own accounts.
+func canManageServiceAccount(ctx context.Context, claims utils.JwtClaims, account *entities.ServiceAccount) bool {
+	return tenant.Allows(tenant.WithOrganizationID(ctx, claims.OrganizationID), account.OrganizationID)
+}
+
+type ServiceAccountOutput struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Role           string     `json:"role"`
+	OrganizationID *string    `json:"organization_id,omitempty"`
+	Disabled       bool       `json:"disabled"`
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func newServiceAccountOutput(account *entities.ServiceAccount) ServiceAccountOutput {
+	return ServiceAccountOutput{
+		ID:             account.ID,
+		Name:           account.Name,
+		Description:    account.Description,
+		Role:           account.Role.String(),
+		OrganizationID: account.OrganizationID,
+		Disabled:       account.IsDisabled(),
+		DisabledAt:     account.DisabledAt,
+		CreatedBy:      account.CreatedBy,
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	}
+}
+
+type APIKeyOutput struct {
+	ID               string     `json:"id"`
+	ServiceAccountID string     `json:"service_account_id"`
+	Name             string     `json:"name"`
+	Prefix           string     `json:"prefix"`
+	Scopes           []string   `json:"scopes"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP       string     `json:"last_used_ip,omitempty"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	Active           bool       `json:"active"`
+	CreatedBy        string     `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func newAPIKeyOutput(key *entities.APIKey, now time.Time) APIKeyOutput {
+	return APIKeyOutput{
+		ID:               key.ID,
+		ServiceAccountID: key.ServiceAccountID,
+		Name:             key.Name,
+		Prefix:           key.Prefix,
+		Scopes:           key.Scopes,
+		ExpiresAt:        key.ExpiresAt,
+		LastUsedAt:       key.LastUsedAt,
+		LastUsedIP:       key.LastUsedIP,
+		RevokedAt:        key.RevokedAt,
+		Active:           !key.IsRevoked() && !key.IsExpired(now),
+		CreatedBy:        key.CreatedBy,
+		CreatedAt:        key.CreatedAt,
+	}
+}
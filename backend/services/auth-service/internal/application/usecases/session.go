@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrSessionIDRequired = errors.New("session id is required")
+	ErrTokenRequired     = errors.New("token is required")
+)
+
+// organizationIDOf is the organization claim of the tokens issued to user.
+func organizationIDOf(user *entities.User) string {
+	if user.OrganizationID == nil {
+		return ""
+	}
+	return *user.OrganizationID
+}
+
+type issuedSession struct {
+	SessionID    string
+	AccessToken  string
+	RefreshToken string
+}
+
+// startSession issues a token pair for user under a new session, which also
+// starts a new refresh token family.
+func startSession(
+	ctx context.Context,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+	user *entities.User,
+	ipAddress, userAgent string,
+) (*issuedSession, error) {
+	sessionID := uuid.NewString()
+
+	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), sessionID, organizationIDOf(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), sessionID, organizationIDOf(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	if err := redis.StoreAccessToken(ctx, user.ID, accessToken, jwtManager.AccessTokenDuration()); err != nil {
+		logger.Warn("failed to store access token", zap.Error(err))
+	}
+
+	if err := redis.StoreRefreshToken(ctx, user.ID, refreshToken, jwtManager.RefreshTokenDuration()); err != nil {
+		logger.Warn("failed to store refresh token", zap.Error(err))
+	}
+
+	if err := redis.StoreUserSession(
+		ctx,
+		sessionID,
+		user.ID,
+		accessToken,
+		refreshToken,
+		ipAddress,
+		userAgent,
+		jwtManager.RefreshTokenDuration(),
+	); err != nil {
+		logger.Warn("failed to store user session", zap.Error(err))
+	}
+
+	// The session doubles as the refresh token family.
+	if err := redis.StoreRefreshTokenFamily(ctx, sessionID, user.ID, refreshToken, jwtManager.RefreshTokenDuration()); err != nil {
+		logger.Warn("failed to store refresh token family", zap.Error(err))
+	}
+
+	return &issuedSession{
+		SessionID:    sessionID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func publishUserLoggedIn(ctx context.Context, publisher messaging.Publisher, logger *logger.Logger, user *entities.User, ipAddress, userAgent string) {
+	event := events.AuthUserLoggedInEvent{
+		ID:              user.ID,
+		Email:           user.Email.String(),
+		Username:        user.Username,
+		Role:            user.Role.String(),
+		Status:          user.Status.String(),
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		IPAddress:       ipAddress,
+		UserAgent:       userAgent,
+	}
+
+	if err := publisher.Publish(ctx, events.EventTypeAuthUserLoggedIn, event); err != nil {
+		logger.Error("failed to publish user logged in event", zap.Error(err))
+	}
+}
+
+// authenticateAccessToken checks the token signature and that it has not been
+// revoked, returning its claims.
+func authenticateAccessToken(
+	ctx context.Context,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	token string,
+) (utils.JwtClaims, error) {
+	if token == "" {
+		return utils.JwtClaims{}, ErrTokenRequired
+	}
+
+	claims, err := jwtManager.VerifyToken(token)
+	if err != nil {
+		return utils.JwtClaims{}, ErrUnauthorized
+	}
+
+	userID, err := redis.GetUserFromAccessToken(ctx, token)
+	if err != nil || userID != claims.UserID {
+		return utils.JwtClaims{}, ErrUnauthorized
+	}
+
+	return claims, nil
+}
+
+// authenticateAccountOwner is authenticateAccessToken for changes only the
+// user themselves may make, such as to their credentials. Impersonation
+// tokens are refused.
+func authenticateAccountOwner(
+	ctx context.Context,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	token string,
+) (utils.JwtClaims, error) {
+	claims, err := authenticateAccessToken(ctx, jwtManager, redis, token)
+	if err != nil {
+		return utils.JwtClaims{}, err
+	}
+	if claims.IsImpersonated() {
+		return utils.JwtClaims{}, ErrNotAllowedWhileImpersonating
+	}
+	return claims, nil
+}
+
+// revokeSession invalidates both tokens held by the session, its refresh token
+// family and removes it.
+func revokeSession(ctx context.Context, redis utils.RedisInterface, session *utils.SessionData) error {
+	if err := redis.RevokeAccessToken(ctx, session.AccessToken); err != nil {
+		return err
+	}
+	if err := redis.RevokeRefreshToken(ctx, session.RefreshToken); err != nil {
+		return err
+	}
+	if err := redis.RevokeRefreshTokenFamily(ctx, session.SessionID); err != nil {
+		return err
+	}
+	return redis.DeleteUserSession(ctx, session.SessionID)
+}
+
+func publishUserLoggedOut(
+	ctx context.Context,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	userID string,
+	sessionIDs []string,
+) {
+	event := events.AuthUserLoggedOutEvent{
+		ID:          userID,
+		SessionIDs:  sessionIDs,
+		LoggedOutAt: time.Now(),
+	}
+
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		logger.Warn("failed to load user for logged out event", zap.String("user_id", userID), zap.Error(err))
+	} else {
+		event.Email = user.Email.String()
+		event.Username = user.Username
+		event.Role = user.Role.String()
+		event.Status = user.Status.String()
+		event.EmailVerified = user.EmailVerified
+		event.EmailVerifiedAt = user.EmailVerifiedAt
+		event.CreatedAt = user.CreatedAt
+		event.UpdatedAt = user.UpdatedAt
+	}
+
+	if err := publisher.Publish(ctx, events.EventTypeAuthUserLoggedOut, event); err != nil {
+		logger.Error("failed to publish user logged out event", zap.Error(err))
+	}
+}
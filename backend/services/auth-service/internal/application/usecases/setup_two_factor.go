@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// SetupTwoFactorInput identifies the user either by a regular access token or,
+// for users whose role enforces 2FA and who have not enrolled yet, by the
+// challenge token returned from the password step of login.
+type SetupTwoFactorInput struct {
+	AccessToken    string
+	ChallengeToken string
+}
+
+type SetupTwoFactorOutput struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type SetupTwoFactorUseCase struct {
+	userRepo        repositories.UserRepository
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+}
+
+func NewSetupTwoFactorUseCase(
+	userRepo repositories.UserRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+) *SetupTwoFactorUseCase {
+	return &SetupTwoFactorUseCase{
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+	}
+}
+
+func (uc *SetupTwoFactorUseCase) Execute(ctx context.Context, input SetupTwoFactorInput) (*SetupTwoFactorOutput, error) {
+	userID, err := uc.resolveUserID(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := uc.twoFactorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		uc.logger.Error("failed to load two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if existing != nil && existing.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	// Starting setup again replaces any secret that was never confirmed.
+	secret, err := authUtils.GenerateTOTPSecret()
+	if err != nil {
+		uc.logger.Error("failed to generate totp secret", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if err := uc.twoFactorRepo.Save(ctx, entities.NewTwoFactor(userID, secret)); err != nil {
+		uc.logger.Error("failed to save two factor settings", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	return &SetupTwoFactorOutput{
+		Secret:     secret,
+		OTPAuthURI: authUtils.TOTPURI(uc.twoFactorConfig.Issuer, user.Email.String(), secret),
+	}, nil
+}
+
+func (uc *SetupTwoFactorUseCase) resolveUserID(ctx context.Context, input SetupTwoFactorInput) (string, error) {
+	if input.ChallengeToken != "" {
+		challenge, err := uc.redis.GetTwoFactorChallenge(ctx, input.ChallengeToken)
+		if err != nil || !challenge.SetupRequired {
+			return "", ErrInvalidTwoFactorChallenge
+		}
+		return challenge.UserID, nil
+	}
+
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
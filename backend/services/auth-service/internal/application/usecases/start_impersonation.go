@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrImpersonationReasonRequired  = errors.New("a reason is required to impersonate a user")
+	ErrCannotImpersonateUser        = errors.New("this user cannot be impersonated")
+	ErrNotImpersonating             = errors.New("token is not an impersonation token")
+	ErrNotAllowedWhileImpersonating = errors.New("not allowed while impersonating a user")
+)
+
+type StartImpersonationInput struct {
+	AccessToken string
+	UserID      string
+	Reason      string
+	IPAddress   string
+	UserAgent   string
+}
+
+type StartImpersonationOutput struct {
+	ImpersonationID string    `json:"impersonation_id"`
+	AccessToken     string    `json:"access_token"`
+	TokenType       string    `json:"token_type"`
+	ExpiresIn       int64     `json:"expires_in"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	UserID          string    `json:"user_id"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role"`
+}
+
+type StartImpersonationUseCase struct {
+	userRepo            repositories.UserRepository
+	publisher           messaging.Publisher
+	logger              *logger.Logger
+	jwtManager          *utils.JwtManager
+	redis               utils.RedisInterface
+	impersonationConfig config.ImpersonationConfig
+}
+
+func NewStartImpersonationUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	impersonationConfig config.ImpersonationConfig,
+) *StartImpersonationUseCase {
+	return &StartImpersonationUseCase{
+		userRepo:            userRepo,
+		publisher:           publisher,
+		logger:              logger,
+		jwtManager:          jwtManager,
+		redis:               redis,
+		impersonationConfig: impersonationConfig,
+	}
+}
+
+// Execute issues a short-lived access token for the target user that names
+// the admin as its actor. Admins cannot be impersonated, and the token cannot
+// be refreshed or used to start another impersonation.
+func (uc *StartImpersonationUseCase) Execute(ctx context.Context, input StartImpersonationInput) (*StartImpersonationOutput, error) {
+	if input.UserID == "" {
+		return nil, ErrUserIDRequired
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrImpersonationReasonRequired
+	}
+
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role != valueobjects.RoleAdmin.String() {
+		return nil, ErrInsufficientPermissions
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	// Admins of an organization can only impersonate its own users.
+	if user == nil || !tenant.Allows(tenant.WithOrganizationID(ctx, claims.OrganizationID), user.OrganizationID) {
+		return nil, ErrUserNotFound
+	}
+	if user.ID == claims.UserID || user.Role.IsAdmin() || user.Status != valueobjects.StatusActive {
+		return nil, ErrCannotImpersonateUser
+	}
+
+	impersonationID := uuid.NewString()
+	duration := uc.impersonationConfig.TokenDuration
+	actor := utils.JwtActor{Subject: claims.UserID, Email: claims.Email}
+
+	accessToken, err := uc.jwtManager.GenerateImpersonationToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), impersonationID, organizationIDOf(user), actor, duration)
+	if err != nil {
+		uc.logger.Error("failed to generate impersonation token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	// Only the access token is stored. No session is created, so the token
+	// does not show up in the user's own session list and cannot be refreshed.
+	if err := uc.redis.StoreAccessToken(ctx, user.ID, accessToken, duration); err != nil {
+		uc.logger.Error("failed to store impersonation token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	startedAt := time.Now()
+	expiresAt := startedAt.Add(duration)
+	event := events.AuthImpersonationStartedEvent{
+		ID:           impersonationID,
+		ActorID:      claims.UserID,
+		ActorEmail:   claims.Email,
+		TargetUserID: user.ID,
+		TargetEmail:  user.Email.String(),
+		TargetRole:   user.Role.String(),
+		Reason:       reason,
+		IPAddress:    input.IPAddress,
+		UserAgent:    input.UserAgent,
+		StartedAt:    startedAt,
+		ExpiresAt:    expiresAt,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthImpersonationStarted, event); err != nil {
+		uc.logger.Error("failed to publish impersonation started event", zap.Error(err))
+	}
+
+	uc.logger.Info("impersonation started",
+		zap.String("impersonation_id", impersonationID),
+		zap.String("actor_id", claims.UserID),
+		zap.String("user_id", user.ID),
+	)
+
+	return &StartImpersonationOutput{
+		ImpersonationID: impersonationID,
+		AccessToken:     accessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(duration.Seconds()),
+		ExpiresAt:       expiresAt,
+		UserID:          user.ID,
+		Email:           user.Email.String(),
+		Role:            user.Role.String(),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/oidc"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrOIDCProviderNotFound    = errors.New("sign-in provider not found")
+	ErrOIDCProviderUnavailable = errors.New("sign-in provider is unavailable")
+)
+
+type StartOIDCLoginInput struct {
+	Provider string
+}
+
+type StartOIDCLoginOutput struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type StartOIDCLoginUseCase struct {
+	providers  *oidc.Registry
+	redis      utils.RedisInterface
+	logger     *logger.Logger
+	oidcConfig config.OIDCConfig
+}
+
+func NewStartOIDCLoginUseCase(
+	providers *oidc.Registry,
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+	oidcConfig config.OIDCConfig,
+) *StartOIDCLoginUseCase {
+	return &StartOIDCLoginUseCase{
+		providers:  providers,
+		redis:      redis,
+		logger:     logger,
+		oidcConfig: oidcConfig,
+	}
+}
+
+// Execute prepares an authorization code request with PKCE. The state, nonce
+// and code verifier stay in Redis until the callback comes back.
+func (uc *StartOIDCLoginUseCase) Execute(ctx context.Context, input StartOIDCLoginInput) (*StartOIDCLoginOutput, error) {
+	provider, err := uc.providers.Provider(input.Provider)
+	if err != nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		uc.logger.Error("failed to generate oidc state", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		uc.logger.Error("failed to generate oidc nonce", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	codeVerifier, err := oidc.RandomString(32)
+	if err != nil {
+		uc.logger.Error("failed to generate pkce code verifier", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(codeVerifier))
+	if err != nil {
+		uc.logger.Error("failed to build authorization url", zap.String("provider", provider.Name()), zap.Error(err))
+		return nil, ErrOIDCProviderUnavailable
+	}
+
+	if err := uc.redis.StoreOIDCState(ctx, state, &utils.OIDCState{
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	}, uc.oidcConfig.StateTTL); err != nil {
+		uc.logger.Error("failed to store oidc state", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	return &StartOIDCLoginOutput{AuthorizationURL: authorizationURL}, nil
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type StopImpersonationInput struct {
+	AccessToken string
+	IPAddress   string
+	UserAgent   string
+}
+
+type StopImpersonationOutput struct {
+	Message string `json:"message"`
+}
+
+type StopImpersonationUseCase struct {
+	publisher  messaging.Publisher
+	logger     *logger.Logger
+	jwtManager *utils.JwtManager
+	redis      utils.RedisInterface
+}
+
+func NewStopImpersonationUseCase(
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *StopImpersonationUseCase {
+	return &StopImpersonationUseCase{
+		publisher:  publisher,
+		logger:     logger,
+		jwtManager: jwtManager,
+		redis:      redis,
+	}
+}
+
+// Execute ends an impersonation by revoking its token. It is called with the
+// impersonation token itself.
+func (uc *StopImpersonationUseCase) Execute(ctx context.Context, input StopImpersonationInput) (*StopImpersonationOutput, error) {
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsImpersonated() {
+		return nil, ErrNotImpersonating
+	}
+
+	if err := uc.redis.RevokeAccessToken(ctx, input.AccessToken); err != nil {
+		uc.logger.Error("failed to revoke impersonation token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	event := events.AuthImpersonationStoppedEvent{
+		ID:           claims.SessionID,
+		ActorID:      claims.Actor.Subject,
+		TargetUserID: claims.UserID,
+		IPAddress:    input.IPAddress,
+		UserAgent:    input.UserAgent,
+		StoppedAt:    time.Now(),
+	}
+	if claims.IssuedAt != nil {
+		event.StartedAt = claims.IssuedAt.Time
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthImpersonationStopped, event); err != nil {
+		uc.logger.Error("failed to publish impersonation stopped event", zap.Error(err))
+	}
+
+	uc.logger.Info("impersonation stopped",
+		zap.String("impersonation_id", claims.SessionID),
+		zap.String("actor_id", claims.Actor.Subject),
+		zap.String("user_id", claims.UserID),
+	)
+
+	return &StopImpersonationOutput{Message: "Impersonation stopped successfully"}, nil
+}
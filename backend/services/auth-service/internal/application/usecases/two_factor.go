@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	sharedEntities "github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp         = errors.New("two-factor authentication has not been set up")
+	ErrTwoFactorCodeRequired     = errors.New("two-factor code is required")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrTwoFactorRequiredForRole  = errors.New("two-factor authentication is required for this role")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	ErrTooManyTwoFactorAttempts  = errors.New("too many two-factor attempts")
+)
+
+const recoveryCodeCount = 10
+
+// issueRecoveryCodes replaces the user's recovery codes and returns the new
+// ones in plain text. Only their hashes are stored, so this is the only time
+// they can be shown.
+func issueRecoveryCodes(ctx context.Context, twoFactorRepo authRepositories.TwoFactorRepository, userID string) ([]string, error) {
+	codes, err := authUtils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*entities.RecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		records = append(records, entities.NewRecoveryCode(userID, utils.HashToken(authUtils.NormalizeRecoveryCode(code))))
+	}
+	if err := twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyTOTP checks a code against the user's secret and records its time
+// step so the code cannot be used again.
+func verifyTOTP(ctx context.Context, twoFactorRepo authRepositories.TwoFactorRepository, twoFactor *entities.TwoFactor, code string) error {
+	if code == "" {
+		return ErrTwoFactorCodeRequired
+	}
+	step, ok := authUtils.ValidateTOTPCode(twoFactor.Secret, code, time.Now(), twoFactor.LastUsedStep)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	twoFactor.MarkStepUsed(step)
+	return twoFactorRepo.Save(ctx, twoFactor)
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code.
+func verifySecondFactor(ctx context.Context, twoFactorRepo authRepositories.TwoFactorRepository, twoFactor *entities.TwoFactor, code, recoveryCode string) error {
+	if code != "" {
+		return verifyTOTP(ctx, twoFactorRepo, twoFactor, code)
+	}
+	if recoveryCode == "" {
+		return ErrTwoFactorCodeRequired
+	}
+
+	used, err := twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, utils.HashToken(authUtils.NormalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// hasTwoFactorEnabled reports whether the user has confirmed a TOTP secret.
+func hasTwoFactorEnabled(ctx context.Context, twoFactorRepo authRepositories.TwoFactorRepository, logger *logger.Logger, userID string) bool {
+	twoFactor, err := twoFactorRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		// Failing open here would let a password alone through for enrolled
+		// users, so treat lookup errors as "enabled".
+		logger.Error("failed to load two factor settings", zap.String("user_id", userID), zap.Error(err))
+		return true
+	}
+	return twoFactor != nil && twoFactor.Enabled
+}
+
+// startTwoFactorChallenge ends the first login step without issuing tokens.
+// The client has to present the challenge token with a TOTP or recovery code
+// to /login/2fa to finish logging in.
+func startTwoFactorChallenge(
+	ctx context.Context,
+	redis utils.RedisInterface,
+	logger *logger.Logger,
+	twoFactorConfig config.TwoFactorConfig,
+	user *sharedEntities.User,
+	ipAddress, userAgent string,
+	setupRequired bool,
+) (*LoginOutput, error) {
+	challengeToken, err := authUtils.GeneratePasswordResetToken()
+	if err != nil {
+		logger.Error("failed to generate two factor challenge token", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	challenge := &utils.TwoFactorChallenge{
+		UserID:        user.ID,
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		SetupRequired: setupRequired,
+		ExpiresAt:     time.Now().Add(twoFactorConfig.ChallengeTTL).Unix(),
+	}
+	if err := redis.StoreTwoFactorChallenge(ctx, challengeToken, challenge, twoFactorConfig.ChallengeTTL); err != nil {
+		logger.Error("failed to store two factor challenge", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+
+	return &LoginOutput{
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: setupRequired,
+		ChallengeToken:         challengeToken,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var ErrUserIDRequired = errors.New("user id is required")
+
+type UnlockAccountInput struct {
+	AccessToken string
+	UserID      string
+}
+
+type UnlockAccountOutput struct {
+	Message string `json:"message"`
+}
+
+type UnlockAccountUseCase struct {
+	userRepo   repositories.UserRepository
+	publisher  messaging.Publisher
+	logger     *logger.Logger
+	jwtManager *utils.JwtManager
+	redis      utils.RedisInterface
+}
+
+func NewUnlockAccountUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *UnlockAccountUseCase {
+	return &UnlockAccountUseCase{
+		userRepo:   userRepo,
+		publisher:  publisher,
+		logger:     logger,
+		jwtManager: jwtManager,
+		redis:      redis,
+	}
+}
+
+// Execute lets an admin lift every brute-force lock on a user's account
+// before it expires on its own.
+func (uc *UnlockAccountUseCase) Execute(ctx context.Context, input UnlockAccountInput) (*UnlockAccountOutput, error) {
+	if input.UserID == "" {
+		return nil, ErrUserIDRequired
+	}
+
+	claims, err := authenticateAccessToken(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Role != valueobjects.RoleAdmin.String() {
+		return nil, ErrInsufficientPermissions
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	for _, action := range attemptActions {
+		if err := uc.redis.ClearAuthLockout(ctx, accountAttemptSubject(action, user.Email.String())); err != nil {
+			uc.logger.Error("failed to clear auth lockout", zap.String("user_id", user.ID), zap.String("action", action), zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+	}
+
+	event := events.AuthAccountUnlockedEvent{
+		ID:         user.ID,
+		Email:      user.Email.String(),
+		Username:   user.Username,
+		UnlockedBy: claims.UserID,
+		UnlockedAt: time.Now(),
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthAccountUnlocked, event); err != nil {
+		uc.logger.Error("failed to publish account unlocked event", zap.Error(err))
+	}
+
+	uc.logger.Info("account unlocked",
+		zap.String("user_id", user.ID),
+		zap.String("unlocked_by", claims.UserID),
+	)
+
+	return &UnlockAccountOutput{Message: "Account unlocked successfully"}, nil
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+type UpdateMeInput struct {
+	AccessToken string
+	Username    string
+	Profile     entities.ProfileUpdate
+}
+
+type UpdateMeOutput struct {
+	User *dtos.UserDTO `json:"user"`
+}
+
+type UpdateMeUseCase struct {
+	userRepo   repositories.UserRepository
+	publisher  messaging.Publisher
+	logger     *logger.Logger
+	jwtManager *utils.JwtManager
+	redis      utils.RedisInterface
+}
+
+func NewUpdateMeUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+) *UpdateMeUseCase {
+	return &UpdateMeUseCase{
+		userRepo:   userRepo,
+		publisher:  publisher,
+		logger:     logger,
+		jwtManager: jwtManager,
+		redis:      redis,
+	}
+}
+
+// Execute updates the caller's own username and profile. Role, status and
+// email are not editable here; email changes go through ChangeEmailUseCase.
+func (uc *UpdateMeUseCase) Execute(ctx context.Context, input UpdateMeInput) (*UpdateMeOutput, error) {
+	username := strings.TrimSpace(input.Username)
+	if username == "" && input.Profile.IsEmpty() {
+		return nil, ErrUsernameRequired
+	}
+
+	claims, err := authenticateAccountOwner(ctx, uc.jwtManager, uc.redis, input.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if username == "" {
+		username = user.Username
+	}
+	usernameChanged := username != user.Username
+	if usernameChanged {
+		existing, _ := uc.userRepo.FindByUsername(ctx, username)
+		if existing != nil && existing.ID != user.ID {
+			return nil, ErrUsernameAlreadyExists
+		}
+	}
+	if err := user.UpdateProfile(input.Profile); err != nil {
+		return nil, err
+	}
+
+	if usernameChanged || !input.Profile.IsEmpty() {
+		user.Username = username
+		user.UpdatedAt = time.Now()
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			uc.logger.Error("failed to update user", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		publishUserUpdated(ctx, uc.publisher, uc.logger, user)
+	}
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &UpdateMeOutput{User: &dto}, nil
+}
+
+func publishUserUpdated(ctx context.Context, publisher messaging.Publisher, logger *logger.Logger, user *entities.User) {
+	event := events.UserUpdatedEvent{
+		ID:              user.ID,
+		OrganizationID:  user.OrganizationID,
+		Email:           user.Email.String(),
+		Username:        user.Username,
+		Role:            user.Role.String(),
+		Status:          user.Status.String(),
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		DisplayName:     user.DisplayName,
+		AvatarID:        user.AvatarID,
+		Timezone:        user.Timezone,
+		Locale:          user.Locale,
+		Phone:           user.Phone,
+		GroupIDs:        user.GroupIDs,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+	if event.GroupIDs == nil {
+		event.GroupIDs = []string{}
+	}
+
+	if err := publisher.Publish(ctx, events.EventTypeUserUpdated, event); err != nil {
+		logger.Error("failed to publish user updated event", zap.Error(err))
+	}
+}
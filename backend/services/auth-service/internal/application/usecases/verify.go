@@ -2,9 +2,15 @@ package usecases
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"net/http"
 	"strings"
+	"time"
 
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
@@ -19,32 +25,72 @@ var (
 	ErrInsufficientPermissions = errors.New("insufficient permissions")
 )
 
+// apiKeyLastUsedInterval limits how often a key's last use is written, as
+// /verify runs on every request through the gateway.
+const apiKeyLastUsedInterval = time.Minute
+
 type VerifyUseCase struct {
-	userRepo   repositories.UserRepository
-	publisher  messaging.Publisher
-	logger     *logger.Logger
-	jwtManager *utils.JwtManager
-	redis      utils.RedisInterface
+	userRepo           repositories.UserRepository
+	serviceAccountRepo authRepositories.ServiceAccountRepository
+	apiKeyRepo         authRepositories.APIKeyRepository
+	publisher          messaging.Publisher
+	logger             *logger.Logger
+	jwtManager         *utils.JwtManager
+	redis              utils.RedisInterface
+	userCacheConfig    config.UserCacheConfig
 }
 
+// VerifyInput carries the original request as forwarded by the gateway.
+// OriginalMethod and OriginalURI are only used to check API key scopes.
 type VerifyInput struct {
-	Token        string
-	RequiredRole string
+	Token          string
+	RequiredRole   string
+	OriginalMethod string
+	OriginalURI    string
+	IPAddress      string
 }
 
+// VerifyOutput is the authenticated principal. ActorID and ActorEmail name
+// the admin when the token is an impersonation token.
+type VerifyOutput struct {
+	dtos.UserDTO
+	ActorID    string
+	ActorEmail string
+}
 
+// impersonationBlockedPaths are APIs that can change a user's password or
+// email. Impersonation tokens may only read from them.
+var impersonationBlockedPaths = []string{
+	"/api/v1/users",
+}
 
-func NewVerifyUseCase(userRepo repositories.UserRepository, publisher messaging.Publisher, logger *logger.Logger, jwtManager *utils.JwtManager, redis utils.RedisInterface) *VerifyUseCase {
+func NewVerifyUseCase(
+	userRepo repositories.UserRepository,
+	serviceAccountRepo authRepositories.ServiceAccountRepository,
+	apiKeyRepo authRepositories.APIKeyRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	userCacheConfig config.UserCacheConfig,
+) *VerifyUseCase {
 	return &VerifyUseCase{
-		userRepo:   userRepo,
-		publisher:  publisher,
-		logger:     logger,
-		jwtManager: jwtManager,
-		redis:      redis,
+		userRepo:           userRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		apiKeyRepo:         apiKeyRepo,
+		publisher:          publisher,
+		logger:             logger,
+		jwtManager:         jwtManager,
+		redis:              redis,
+		userCacheConfig:    userCacheConfig,
 	}
 }
 
-func (uc *VerifyUseCase) Execute(ctx context.Context, input VerifyInput) (*dtos.UserDTO, error) {
+func (uc *VerifyUseCase) Execute(ctx context.Context, input VerifyInput) (*VerifyOutput, error) {
+	if authUtils.IsAPIKey(input.Token) {
+		return uc.verifyAPIKey(ctx, input)
+	}
+
 	claims, err := uc.jwtManager.VerifyToken(input.Token)
 	if err != nil {
 		uc.logger.Error("failed to verify token", zap.Error(err))
@@ -56,7 +102,7 @@ func (uc *VerifyUseCase) Execute(ctx context.Context, input VerifyInput) (*dtos.
 		return nil, ErrUnauthorized
 	}
 
-	user, err := uc.userRepo.FindByID(ctx, userID)
+	user, err := uc.findUserStatus(ctx, userID)
 	if err != nil {
 		uc.logger.Error("failed to find user", zap.Error(err))
 		return nil, ErrUnauthorized
@@ -65,23 +111,184 @@ func (uc *VerifyUseCase) Execute(ctx context.Context, input VerifyInput) (*dtos.
 		uc.logger.Error("user not found", zap.String("user_id", claims.UserID))
 		return nil, ErrUnauthorized
 	}
-	if user.Status != valueobjects.StatusActive {
+	if user.Status != valueobjects.StatusActive.String() {
 		uc.logger.Error("user is not active", zap.String("user_id", claims.UserID))
 		return nil, ErrUnauthorized
 	}
+	// Tokens issued before the user moved to another organization would
+	// otherwise keep reaching the old one's data.
+	if claims.OrganizationID != user.OrganizationID {
+		uc.logger.Error("token organization does not match user", zap.String("user_id", claims.UserID))
+		return nil, ErrUnauthorized
+	}
+
+	// Only super-admins may act outside an organization. Services would leave
+	// anyone else without one unscoped, able to read every organization's rows.
+	if user.OrganizationID == "" && user.Role != valueobjects.RoleAdmin.String() {
+		return nil, ErrInsufficientPermissions
+	}
 
 	if input.RequiredRole != "" {
 		if !uc.hasRequiredRole(claims.Role, input.RequiredRole) {
 			return nil, ErrInsufficientPermissions
 		}
-		if !uc.hasRequiredRole(user.Role.String(), input.RequiredRole) {
+		if !uc.hasRequiredRole(user.Role, input.RequiredRole) {
 		return nil, ErrInsufficientPermissions
 		}
 	}
 
-	dto := dtos.UserDTO{}
-	dto.FromEntity(user)
-	return &dto, nil
+	output := &VerifyOutput{
+		UserDTO: dtos.UserDTO{
+			ID:     user.UserID,
+			Email:  user.Email,
+			Role:   user.Role,
+			Status: user.Status,
+		},
+	}
+	if user.OrganizationID != "" {
+		output.OrganizationID = &user.OrganizationID
+	}
+
+	if claims.IsImpersonated() {
+		if isCredentialChange(input.OriginalMethod, input.OriginalURI) {
+			uc.logger.Warn("blocked credential change while impersonating",
+				zap.String("user_id", user.UserID),
+				zap.String("actor_id", claims.Actor.Subject),
+				zap.String("uri", input.OriginalURI),
+			)
+			return nil, ErrInsufficientPermissions
+		}
+		output.ActorID = claims.Actor.Subject
+		output.ActorEmail = claims.Actor.Email
+		// Impersonation tokens have no session to track activity on.
+		return output, nil
+	}
+
+	if claims.SessionID != "" {
+		if err := uc.redis.UpdateLastActivity(ctx, claims.SessionID); err != nil {
+			uc.logger.Warn("failed to update session last activity", zap.Error(err))
+		}
+	}
+
+	return output, nil
+}
+
+// findUserStatus returns the user's current role and status, from the cache
+// when possible. Changes made in user-service remove the entry through the
+// user.user.updated and user.user.deleted events. An update landing between
+// the database read and the cache write below can still be missed, which the
+// short TTL bounds. A nil status means the user does not exist.
+func (uc *VerifyUseCase) findUserStatus(ctx context.Context, userID string) (*utils.CachedUserStatus, error) {
+	if uc.userCacheConfig.TTL > 0 {
+		cached, err := uc.redis.GetCachedUserStatus(ctx, userID)
+		if err == nil {
+			return cached, nil
+		}
+		if !errors.Is(err, utils.ErrUserStatusNotCached) {
+			uc.logger.Warn("failed to read cached user status", zap.String("user_id", userID), zap.Error(err))
+		}
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	status := &utils.CachedUserStatus{
+		UserID: user.ID,
+		Email:  user.Email.String(),
+		Role:   user.Role.String(),
+		Status: user.Status.String(),
+	}
+	if user.OrganizationID != nil {
+		status.OrganizationID = *user.OrganizationID
+	}
+	if uc.userCacheConfig.TTL > 0 {
+		if err := uc.redis.CacheUserStatus(ctx, status, uc.userCacheConfig.TTL); err != nil {
+			uc.logger.Warn("failed to cache user status", zap.String("user_id", userID), zap.Error(err))
+		}
+	}
+	return status, nil
+}
+
+// isCredentialChange reports whether a request writes to an API that can
+// change a user's password or email.
+func isCredentialChange(method, uri string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	path, _, _ := strings.Cut(uri, "?")
+	for _, blocked := range impersonationBlockedPaths {
+		if path == blocked || strings.HasPrefix(path, blocked+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyAPIKey authenticates a service account by one of its API keys. The
+// returned principal carries the account's ID, role and organization, so
+// gateway policies and services treat it like a user with that role.
+func (uc *VerifyUseCase) verifyAPIKey(ctx context.Context, input VerifyInput) (*VerifyOutput, error) {
+	prefix, ok := authUtils.ParseAPIKeyPrefix(input.Token)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	key, err := uc.apiKeyRepo.FindByPrefix(ctx, prefix)
+	if err != nil {
+		uc.logger.Error("failed to find api key", zap.Error(err))
+		return nil, ErrUnauthorized
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(utils.HashToken(input.Token)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if key.IsRevoked() || key.IsExpired(now) {
+		uc.logger.Warn("rejected inactive api key", zap.String("api_key_id", key.ID))
+		return nil, ErrUnauthorized
+	}
+
+	account, err := uc.serviceAccountRepo.FindByID(ctx, key.ServiceAccountID)
+	if err != nil {
+		uc.logger.Error("failed to find service account", zap.Error(err))
+		return nil, ErrUnauthorized
+	}
+	if account == nil || account.IsDisabled() {
+		return nil, ErrUnauthorized
+	}
+	// Accounts made before they carried an organization can still be
+	// unscoped; only admin ones may stay that way, as for users.
+	if account.OrganizationID == nil && !account.Role.IsAdmin() {
+		return nil, ErrInsufficientPermissions
+	}
+
+	if input.RequiredRole != "" && !uc.hasRequiredRole(account.Role.String(), input.RequiredRole) {
+		return nil, ErrInsufficientPermissions
+	}
+	if !key.AllowsRequest(input.OriginalMethod, input.OriginalURI) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedInterval || key.LastUsedIP != input.IPAddress {
+		if err := uc.apiKeyRepo.UpdateLastUsed(ctx, key.ID, input.IPAddress, now); err != nil {
+			uc.logger.Warn("failed to record api key use", zap.String("api_key_id", key.ID), zap.Error(err))
+		}
+	}
+
+	return &VerifyOutput{
+		UserDTO: dtos.UserDTO{
+			ID:             account.ID,
+			Username:       account.Name,
+			Role:           account.Role.String(),
+			Status:         valueobjects.StatusActive.String(),
+			OrganizationID: account.OrganizationID,
+			CreatedAt:      account.CreatedAt,
+			UpdatedAt:      account.UpdatedAt,
+		},
+	}, nil
 }
 
 func (uc *VerifyUseCase) hasRequiredRole(userRole, requiredRoles string) bool {
@@ -3,12 +3,16 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
 )
 
 type VerifyEmailInput struct {
@@ -54,16 +58,31 @@ func (uc *VerifyEmailUseCase) Execute(ctx context.Context, input VerifyEmailInpu
 		return nil, errors.New("user not found")
 	}
 
+	// Tokens issued by an email change carry the new address; the account is
+	// only switched over once that address is verified here.
+	pendingEmail, err := uc.redis.GetPendingEmail(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.Email != "" {
 		email, err := valueobjects.NewEmail(input.Email)
 		if err != nil {
 			return nil, err
 		}
-		if user.Email.String() != email.String() {
+		expected := user.Email.String()
+		if pendingEmail != "" {
+			expected = pendingEmail
+		}
+		if expected != email.String() {
 			return nil, errors.New("email does not match token")
 		}
 	}
 
+	if pendingEmail != "" {
+		return uc.switchEmail(ctx, user, pendingEmail, input.Token)
+	}
+
 	if user.EmailVerified {
 		return &VerifyEmailOutput{Message: "Email already verified"}, nil
 	}
@@ -77,6 +96,48 @@ func (uc *VerifyEmailUseCase) Execute(ctx context.Context, input VerifyEmailInpu
 	return &VerifyEmailOutput{Message: "Email verified successfully"}, nil
 }
 
+func (uc *VerifyEmailUseCase) switchEmail(ctx context.Context, user *entities.User, pendingEmail, token string) (*VerifyEmailOutput, error) {
+	newEmail, err := valueobjects.NewEmail(pendingEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, _ := uc.userRepo.FindByEmail(ctx, newEmail.String())
+	if existing != nil && existing.ID != user.ID {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	oldEmail := user.Email.String()
+	now := time.Now()
+	user.Email = newEmail
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.redis.RevokeVerifyEmailToken(ctx, token)
+	uc.redis.RevokePendingEmail(ctx, token)
+	// /verify caches the email it passes on to other services.
+	if err := uc.redis.InvalidateUserStatus(ctx, user.ID); err != nil {
+		uc.logger.Warn("failed to invalidate cached user status", zap.String("user_id", user.ID), zap.Error(err))
+	}
+
+	event := events.AuthUserEmailChangedEvent{
+		ID:        user.ID,
+		Username:  user.Username,
+		OldEmail:  oldEmail,
+		NewEmail:  newEmail.String(),
+		ChangedAt: now,
+	}
+	if err := uc.publisher.Publish(ctx, events.EventTypeAuthUserEmailChanged, event); err != nil {
+		uc.logger.Error("failed to publish email changed event", zap.Error(err))
+	}
+
+	return &VerifyEmailOutput{Message: "Email changed successfully"}, nil
+}
+
 func (uc *VerifyEmailUseCase) validateInput(input VerifyEmailInput) error {
 	if input.Token == "" {
 		return errors.New("token is required")
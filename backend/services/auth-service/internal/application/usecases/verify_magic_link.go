@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	authRepositories "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidMagicLink = errors.New("invalid or expired sign-in link")
+
+type VerifyMagicLinkInput struct {
+	Token     string
+	IPAddress string
+	UserAgent string
+}
+
+type VerifyMagicLinkUseCase struct {
+	userRepo        repositories.UserRepository
+	twoFactorRepo   authRepositories.TwoFactorRepository
+	publisher       messaging.Publisher
+	logger          *logger.Logger
+	jwtManager      *utils.JwtManager
+	redis           utils.RedisInterface
+	twoFactorConfig config.TwoFactorConfig
+	magicLinkConfig config.MagicLinkConfig
+	securityLog     *securityLog
+}
+
+func NewVerifyMagicLinkUseCase(
+	userRepo repositories.UserRepository,
+	twoFactorRepo authRepositories.TwoFactorRepository,
+	securityEventRepo authRepositories.SecurityEventRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	jwtManager *utils.JwtManager,
+	redis utils.RedisInterface,
+	twoFactorConfig config.TwoFactorConfig,
+	magicLinkConfig config.MagicLinkConfig,
+) *VerifyMagicLinkUseCase {
+	return &VerifyMagicLinkUseCase{
+		userRepo:        userRepo,
+		twoFactorRepo:   twoFactorRepo,
+		publisher:       publisher,
+		logger:          logger,
+		jwtManager:      jwtManager,
+		redis:           redis,
+		twoFactorConfig: twoFactorConfig,
+		magicLinkConfig: magicLinkConfig,
+		securityLog:     newSecurityLog(securityEventRepo, logger),
+	}
+}
+
+// Execute exchanges a magic link token for a session. The token is consumed
+// first so a link works once even when the sign in is then refused. The role
+// is checked again in case it changed after the link was sent, and users with
+// two-factor authentication still have to pass /login/2fa.
+func (uc *VerifyMagicLinkUseCase) Execute(ctx context.Context, input VerifyMagicLinkInput) (*LoginOutput, error) {
+	if input.Token == "" {
+		return nil, ErrTokenRequired
+	}
+
+	userID, err := uc.redis.ConsumeMagicLinkToken(ctx, input.Token)
+	if err != nil {
+		if !errors.Is(err, utils.ErrMagicLinkNotFound) {
+			uc.logger.Error("failed to consume magic link token", zap.Error(err))
+			return nil, ErrInternalServerError
+		}
+		return nil, ErrInvalidMagicLink
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		uc.logger.Error("failed to find user", zap.String("user_id", userID), zap.Error(err))
+		return nil, ErrInternalServerError
+	}
+	if user == nil || !user.Status.CanLogin() || !uc.magicLinkConfig.IsAllowedFor(user.Role.String()) {
+		return nil, ErrInvalidMagicLink
+	}
+
+	twoFactorEnabled := hasTwoFactorEnabled(ctx, uc.twoFactorRepo, uc.logger, user.ID)
+	if twoFactorEnabled || uc.twoFactorConfig.IsRequiredFor(user.Role.String()) {
+		return startTwoFactorChallenge(ctx, uc.redis, uc.logger, uc.twoFactorConfig, user, input.IPAddress, input.UserAgent, !twoFactorEnabled)
+	}
+
+	session, err := startSession(ctx, uc.jwtManager, uc.redis, uc.logger, user, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	publishUserLoggedIn(ctx, uc.publisher, uc.logger, user, input.IPAddress, input.UserAgent)
+	uc.securityLog.recordLogin(ctx, uc.publisher, user, loginMethodMagicLink, input.IPAddress, input.UserAgent)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+
+	return &LoginOutput{
+		User:         &dto,
+		AccessToken:  session.AccessToken,
+		RefreshToken: session.RefreshToken,
+	}, nil
+}
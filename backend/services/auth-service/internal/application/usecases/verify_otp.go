@@ -4,20 +4,25 @@ import (
 	"context"
 	"errors"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
 	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrInvalidOTP = errors.New("invalid OTP")
+	ErrOTPAttemptsExceeded = errors.New("too many invalid OTP attempts, please request a new one")
 )
 
 type VerifyOTPInput struct {
 	Email string
 	OTP string
+	IPAddress string
+	UserAgent string
 }
 
 type VerifyOTPOutput struct {
@@ -30,11 +35,24 @@ type VerifyOTPUseCase struct {
 	userRepo repositories.UserRepository
 	logger *logger.Logger
 	redis utils.RedisInterface
-
+	lockoutConfig config.LockoutConfig
+	guard *attemptGuard
 }
 
-func NewVerifyOTPUseCase(userRepo repositories.UserRepository, logger *logger.Logger, redis utils.RedisInterface) *VerifyOTPUseCase {
-	return &VerifyOTPUseCase{userRepo: userRepo, logger: logger, redis: redis}
+func NewVerifyOTPUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	redis utils.RedisInterface,
+	lockoutConfig config.LockoutConfig,
+) *VerifyOTPUseCase {
+	return &VerifyOTPUseCase{
+		userRepo: userRepo,
+		logger: logger,
+		redis: redis,
+		lockoutConfig: lockoutConfig,
+		guard: newAttemptGuard(redis, publisher, logger, lockoutConfig),
+	}
 }
 
 func (uc *VerifyOTPUseCase) Execute(ctx context.Context, input VerifyOTPInput) (*VerifyOTPOutput, error) {
@@ -45,40 +63,40 @@ func (uc *VerifyOTPUseCase) Execute(ctx context.Context, input VerifyOTPInput) (
 		return output, err
 	}
 
-	userID, err := uc.redis.GetUserFromForgotPasswordOTP(ctx, input.OTP)
-	if err != nil {
-		uc.logger.Error("failed to get user from OTP", zap.Error(err))
-		output.ErrorMessage = ErrInvalidOTP.Error()
-		return output, ErrInvalidOTP
-	}
-
-	if userID == "" {
-		output.ErrorMessage = ErrInvalidOTP.Error()
-		return output, ErrInvalidOTP
+	if err := uc.guard.check(ctx, attemptActionOTP, input.Email, input.IPAddress); err != nil {
+		output.ErrorMessage = err.Error()
+		return output, err
 	}
 
-	user, err := uc.userRepo.FindByID(ctx, userID)
+	user, err := uc.userRepo.FindByEmail(ctx, input.Email)
 	if err != nil {
 		uc.logger.Error("failed to find user", zap.Error(err))
-		output.ErrorMessage = ErrInvalidOTP.Error()
-		return output, ErrInvalidOTP
+		output.ErrorMessage = ErrInternalServerError.Error()
+		return output, ErrInternalServerError
 	}
 
 	if user == nil {
+		uc.guard.fail(ctx, attemptActionOTP, input.Email, nil, input.IPAddress, input.UserAgent)
 		output.ErrorMessage = ErrInvalidOTP.Error()
 		return output, ErrInvalidOTP
 	}
 
-	if user.Email.String() != input.Email {
+	// The OTP is looked up by the user it was issued to, so a code guessed
+	// for one account can never be redeemed for another.
+	if err := uc.redis.VerifyForgotPasswordOTP(ctx, user.ID, input.OTP, uc.lockoutConfig.OTPMaxAttempts); err != nil {
+		uc.guard.fail(ctx, attemptActionOTP, input.Email, user, input.IPAddress, input.UserAgent)
+		if errors.Is(err, utils.ErrOTPAttemptsExceeded) {
+			output.ErrorMessage = ErrOTPAttemptsExceeded.Error()
+			return output, ErrOTPAttemptsExceeded
+		}
+		if !errors.Is(err, utils.ErrOTPInvalid) {
+			uc.logger.Error("failed to verify OTP", zap.Error(err))
+		}
 		output.ErrorMessage = ErrInvalidOTP.Error()
 		return output, ErrInvalidOTP
 	}
 
-	if err := uc.redis.RevokeForgotPasswordOTP(ctx, input.OTP); err != nil {
-		uc.logger.Error("failed to revoke OTP", zap.Error(err))
-		output.ErrorMessage = ErrInvalidOTP.Error()
-		return output, ErrInvalidOTP
-	}
+	uc.guard.succeed(ctx, attemptActionOTP, input.Email)
 
 	passwordResetToken, err := authUtils.GeneratePasswordResetToken()
 	if err != nil {
@@ -87,7 +105,7 @@ func (uc *VerifyOTPUseCase) Execute(ctx context.Context, input VerifyOTPInput) (
 		return output, ErrInternalServerError
 	}
 
-	if err := uc.redis.StoreResetPasswordToken(ctx, userID, passwordResetToken); err != nil {
+	if err := uc.redis.StoreResetPasswordToken(ctx, user.ID, passwordResetToken); err != nil {
 		uc.logger.Error("failed to store reset password token", zap.Error(err))
 		output.ErrorMessage = ErrInternalServerError.Error()
 		return output, ErrInternalServerError
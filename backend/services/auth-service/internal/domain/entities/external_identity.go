@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalIdentity links an account at an OpenID Connect provider, identified
+// by the provider's stable subject, to a user.
+type ExternalIdentity struct {
+	ID          string
+	UserID      string
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+func NewExternalIdentity(userID, provider, subject, email string) *ExternalIdentity {
+	now := time.Now().UTC()
+	return &ExternalIdentity{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     subject,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+}
+
+func (i *ExternalIdentity) MarkLoggedIn(email string) {
+	i.Email = email
+	i.LastLoginAt = time.Now().UTC()
+}
@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+)
+
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// Invitation lets an admin onboard an instructor or admin by email instead of
+// setting their password. Only a hash of the single-use token is stored.
+// OrganizationID is the inviting admin's organization, which the invitee
+// joins; it is nil for invitations made by super-admins.
+type Invitation struct {
+	ID             string
+	Email          string
+	Role           valueobjects.Role
+	TokenHash      string
+	InvitedBy      string
+	OrganizationID *string
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	AcceptedUserID string
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewInvitation(email string, role valueobjects.Role, tokenHash, invitedBy string, organizationID *string, expiresAt time.Time) *Invitation {
+	now := time.Now().UTC()
+	return &Invitation{
+		ID:             uuid.NewString(),
+		Email:          email,
+		Role:           role,
+		TokenHash:      tokenHash,
+		InvitedBy:      invitedBy,
+		OrganizationID: organizationID,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (i *Invitation) Status(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// IsOpen reports whether the invitation was neither accepted nor revoked. An
+// open invitation may have expired and can still be resent.
+func (i *Invitation) IsOpen() bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil
+}
+
+// Renew replaces the token and expiry, so links from earlier emails stop
+// working.
+func (i *Invitation) Renew(tokenHash string, expiresAt time.Time) {
+	i.TokenHash = tokenHash
+	i.ExpiresAt = expiresAt
+	i.UpdatedAt = time.Now().UTC()
+}
+
+func (i *Invitation) Accept(userID string) {
+	now := time.Now().UTC()
+	i.AcceptedAt = &now
+	i.AcceptedUserID = userID
+	i.UpdatedAt = now
+}
+
+func (i *Invitation) Revoke() {
+	now := time.Now().UTC()
+	i.RevokedAt = &now
+	i.UpdatedAt = now
+}
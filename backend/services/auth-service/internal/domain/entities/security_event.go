@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Security event types recorded in a user's history.
+const (
+	SecurityEventLogin                    = "login"
+	SecurityEventLoginFailed              = "login_failed"
+	SecurityEventPasswordReset            = "password_reset"
+	SecurityEventPasswordChanged          = "password_changed"
+	SecurityEventTwoFactorEnabled         = "two_factor_enabled"
+	SecurityEventTwoFactorDisabled        = "two_factor_disabled"
+	SecurityEventRecoveryCodesRegenerated = "recovery_codes_regenerated"
+	SecurityEventSessionRevoked           = "session_revoked"
+)
+
+// SecurityEvent is an entry in a user's security history. Detail narrows the
+// type down, such as the login method or why a session was revoked. ActorID
+// is set when an admin acted on the user's account.
+type SecurityEvent struct {
+	ID        string
+	UserID    string
+	Type      string
+	Detail    string
+	IPAddress string
+	UserAgent string
+	Device    string
+	ActorID   string
+	CreatedAt time.Time
+}
+
+func NewSecurityEvent(userID, eventType, detail, ipAddress, userAgent, device string) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Type:      eventType,
+		Detail:    detail,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Device:    device,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// LoginSource tells whether a user has signed in before from an IP address
+// and from a device.
+type LoginSource struct {
+	HasPriorLogins bool
+	KnownIP        bool
+	KnownDevice    bool
+}
+
+// IsNew reports whether the login should be flagged. A user's first login is
+// not, as every source would be new.
+func (s LoginSource) IsNew() bool {
+	return s.HasPriorLogins && (!s.KnownIP || !s.KnownDevice)
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+)
+
+// ServiceAccount is a non-human principal, such as the SIS integration, that
+// calls the API with API keys instead of logging in. Role decides what the
+// gateway lets it reach, the same way it does for users, and OrganizationID
+// scopes it the same way; only admin accounts may belong to no organization.
+type ServiceAccount struct {
+	ID             string
+	Name           string
+	Description    string
+	Role           valueobjects.Role
+	OrganizationID *string
+	CreatedBy      string
+	DisabledAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewServiceAccount(name, description string, role valueobjects.Role, organizationID *string, createdBy string) *ServiceAccount {
+	now := time.Now().UTC()
+	return &ServiceAccount{
+		ID:             uuid.NewString(),
+		Name:           name,
+		Description:    description,
+		Role:           role,
+		OrganizationID: organizationID,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (s *ServiceAccount) Disable() {
+	now := time.Now().UTC()
+	s.DisabledAt = &now
+	s.UpdatedAt = now
+}
+
+func (s *ServiceAccount) IsDisabled() bool {
+	return s.DisabledAt != nil
+}
+
+// APIKey is a credential of a service account. Only a hash of the key is
+// stored; Prefix is the public part used to look it up.
+//
+// Scopes limit the key to parts of the API, written as <resource>:<access>
+// where resource is the first path segment after /api/v1 and access is read,
+// write or *. The scope * allows everything the account's role does.
+type APIKey struct {
+	ID               string
+	ServiceAccountID string
+	Name             string
+	Prefix           string
+	KeyHash          string
+	Scopes           []string
+	ExpiresAt        *time.Time
+	LastUsedAt       *time.Time
+	LastUsedIP       string
+	RevokedAt        *time.Time
+	CreatedBy        string
+	CreatedAt        time.Time
+}
+
+const ScopeAll = "*"
+
+func NewAPIKey(serviceAccountID, name, prefix, keyHash string, scopes []string, expiresAt *time.Time, createdBy string) *APIKey {
+	return &APIKey{
+		ID:               uuid.NewString(),
+		ServiceAccountID: serviceAccountID,
+		Name:             name,
+		Prefix:           prefix,
+		KeyHash:          keyHash,
+		Scopes:           scopes,
+		ExpiresAt:        expiresAt,
+		CreatedBy:        createdBy,
+		CreatedAt:        time.Now().UTC(),
+	}
+}
+
+func (k *APIKey) Revoke() {
+	now := time.Now().UTC()
+	k.RevokedAt = &now
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// MarkUsed records a successful authentication with the key.
+func (k *APIKey) MarkUsed(ip string, at time.Time) {
+	k.LastUsedAt = &at
+	k.LastUsedIP = ip
+}
+
+// AllowsRequest reports whether the key's scopes cover a request with the
+// given method to the given URI.
+func (k *APIKey) AllowsRequest(method, uri string) bool {
+	resource := apiResource(uri)
+	access := "write"
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		access = "read"
+	}
+
+	for _, scope := range k.Scopes {
+		if scope == ScopeAll {
+			return true
+		}
+		scopeResource, scopeAccess, ok := strings.Cut(scope, ":")
+		if !ok || resource == "" || scopeResource != resource {
+			continue
+		}
+		if scopeAccess == ScopeAll || scopeAccess == access {
+			return true
+		}
+	}
+	return false
+}
+
+// apiResource returns the first path segment after /api/v1, ignoring any
+// query string.
+func apiResource(uri string) string {
+	path, _, _ := strings.Cut(uri, "?")
+	path = strings.TrimPrefix(path, "/api/v1/")
+	if strings.HasPrefix(path, "/") {
+		return ""
+	}
+	resource, _, _ := strings.Cut(path, "/")
+	return resource
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor holds a user's TOTP secret. It is created unconfirmed when the
+// user starts enrollment and only enforced at login once Enabled is set.
+type TwoFactor struct {
+	UserID       string
+	Secret       string
+	Enabled      bool
+	EnabledAt    *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func NewTwoFactor(userID, secret string) *TwoFactor {
+	now := time.Now().UTC()
+	return &TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		Enabled:   false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (t *TwoFactor) Enable() {
+	now := time.Now().UTC()
+	t.Enabled = true
+	t.EnabledAt = &now
+	t.UpdatedAt = now
+}
+
+// MarkStepUsed records the time step of an accepted code so the same code
+// cannot be replayed within its validity window.
+func (t *TwoFactor) MarkStepUsed(step int64) {
+	t.LastUsedStep = step
+	t.UpdatedAt = time.Now().UTC()
+}
+
+type RecoveryCode struct {
+	ID        string
+	UserID    string
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewRecoveryCode(userID, codeHash string) *RecoveryCode {
+	return &RecoveryCode{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CodeHash:  codeHash,
+		CreatedAt: time.Now().UTC(),
+	}
+}
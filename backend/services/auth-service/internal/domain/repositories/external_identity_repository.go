@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+)
+
+type ExternalIdentityRepository interface {
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entities.ExternalIdentity, error)
+	Create(ctx context.Context, identity *entities.ExternalIdentity) error
+	Update(ctx context.Context, identity *entities.ExternalIdentity) error
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+)
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.Invitation) error
+	FindByID(ctx context.Context, id string) (*entities.Invitation, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Invitation, error)
+	// FindOpenByEmail returns the invitation for email that was neither
+	// accepted nor revoked, if any.
+	FindOpenByEmail(ctx context.Context, email string) (*entities.Invitation, error)
+	ListOpen(ctx context.Context) ([]*entities.Invitation, error)
+	Update(ctx context.Context, invitation *entities.Invitation) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+)
+
+type SecurityEventRepository interface {
+	Create(ctx context.Context, event *entities.SecurityEvent) error
+	// ListByUserID returns the user's most recent events first.
+	ListByUserID(ctx context.Context, userID string, limit int) ([]*entities.SecurityEvent, error)
+	// FindLoginSource compares ipAddress and device with the user's earlier
+	// logins.
+	FindLoginSource(ctx context.Context, userID, ipAddress, device string) (*entities.LoginSource, error)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+)
+
+type ServiceAccountRepository interface {
+	Create(ctx context.Context, account *entities.ServiceAccount) error
+	FindByID(ctx context.Context, id string) (*entities.ServiceAccount, error)
+	FindByName(ctx context.Context, name string) (*entities.ServiceAccount, error)
+	List(ctx context.Context) ([]*entities.ServiceAccount, error)
+	Update(ctx context.Context, account *entities.ServiceAccount) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	FindByID(ctx context.Context, id string) (*entities.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+	RevokeByServiceAccountID(ctx context.Context, serviceAccountID string, revokedAt time.Time) error
+	UpdateLastUsed(ctx context.Context, id, ip string, usedAt time.Time) error
+}
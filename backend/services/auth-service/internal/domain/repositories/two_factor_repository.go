@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+)
+
+type TwoFactorRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*entities.TwoFactor, error)
+	Save(ctx context.Context, twoFactor *entities.TwoFactor) error
+	Delete(ctx context.Context, userID string) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*entities.RecoveryCode) error
+	// UseRecoveryCode marks an unused code as used and reports whether one matched.
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+}
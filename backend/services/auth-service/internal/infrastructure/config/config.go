@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	sharedConfig "github.com/paingphyoaungkhant/asto-microservice/shared/config"
@@ -10,11 +11,166 @@ type JwtConfig struct {
 	SecretKey            string
 	AccessTokenDuration  time.Duration
 	RefreshTokenDuration time.Duration
+	// SigningKeysDir holds <kid>.pem files. When set, tokens are signed with
+	// ActiveKeyID instead of the HS256 secret.
+	SigningKeysDir string
+	ActiveKeyID    string
+	// AcceptHS256Until keeps tokens signed with SecretKey valid until then
+	// after switching to asymmetric keys. The zero time refuses them.
+	AcceptHS256Until time.Time
+}
+
+type TwoFactorConfig struct {
+	// Issuer is the account label shown in authenticator apps.
+	Issuer string
+	// RequiredRoles must pass a TOTP check at login and cannot disable it.
+	RequiredRoles []string
+	ChallengeTTL  time.Duration
+	MaxAttempts   int
+}
+
+// IsRequiredFor reports whether users with role must use two-factor authentication.
+func (c TwoFactorConfig) IsRequiredFor(role string) bool {
+	for _, required := range c.RequiredRoles {
+		if required == role {
+			return true
+		}
+	}
+	return false
+}
+
+// LockoutConfig controls brute-force protection on login and OTP endpoints.
+// Failures are counted per account and per IP within FailureWindow. After
+// DelayAfter failures each further one blocks the account for BaseDelay,
+// doubling up to MaxDelay, and reaching MaxAccountFailures locks it for
+// LockoutDuration.
+type LockoutConfig struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+	DelayAfter         int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	// OTPMaxAttempts is how many wrong guesses a single OTP survives.
+	OTPMaxAttempts int
+}
+
+// OIDCProviderConfig describes an OpenID Connect provider users can sign in
+// with, e.g. a university's Google Workspace or Microsoft Entra tenant.
+type OIDCProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the frontend page the provider returns to. It posts the
+	// code and state on to /oidc/{provider}/callback.
+	RedirectURL string
+	Scopes      []string
+	// AllowedDomains limits sign in to these email domains when set.
+	AllowedDomains []string
+	// TrustEmail treats the email claim as verified for providers that do
+	// not send email_verified.
+	TrustEmail bool
+}
+
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig
+	// StateTTL is how long a user has to finish signing in at the provider.
+	StateTTL time.Duration
+}
+
+// ImpersonationConfig controls admin "view as user" sessions.
+type ImpersonationConfig struct {
+	// TokenDuration is how long an impersonation token is valid. It cannot
+	// be refreshed.
+	TokenDuration time.Duration
+}
+
+// InvitationConfig controls invitation-based onboarding of instructors and
+// admins.
+type InvitationConfig struct {
+	TokenDuration time.Duration
+	// AcceptURL is the frontend page that reads the token from the query
+	// string and posts it to /invitations/accept with the new password.
+	AcceptURL string
+}
+
+// MagicLinkConfig controls passwordless sign in by emailed link. Only roles
+// in AllowedRoles may use it, so it is off when the list is empty. Two-factor
+// authentication still applies after the link is opened.
+type MagicLinkConfig struct {
+	AllowedRoles []string
+	TokenTTL     time.Duration
+	// LoginURL is the frontend page that reads the token from the query
+	// string and posts it to /magic-link/verify.
+	LoginURL string
+}
+
+// IsAllowedFor reports whether users with role may sign in by magic link.
+func (c MagicLinkConfig) IsAllowedFor(role string) bool {
+	for _, allowed := range c.AllowedRoles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// UserCacheConfig controls the Redis cache of each user's role and status
+// read by /verify. Entries are removed when user-service reports a change, so
+// TTL only bounds how stale a missed event can leave them. Zero disables it.
+type UserCacheConfig struct {
+	TTL time.Duration
 }
 
 type Config struct {
 	sharedConfig.BaseConfig
-	Jwt JwtConfig
+	Jwt       JwtConfig
+	TwoFactor TwoFactorConfig
+	Lockout   LockoutConfig
+	// RateLimit applies per IP to the endpoints that accept credentials.
+	RateLimit     sharedConfig.RateLimitConfig
+	OIDC          OIDCConfig
+	Impersonation ImpersonationConfig
+	Invitation    InvitationConfig
+	MagicLink     MagicLinkConfig
+	UserCache     UserCacheConfig
+}
+
+func parseList(value string) []string {
+	roles := []string{}
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// loadOIDCProviders reads OIDC_<NAME>_* settings for every name listed in
+// OIDC_PROVIDERS. Providers without an issuer or client id are skipped.
+func loadOIDCProviders(names string) []OIDCProviderConfig {
+	providers := []OIDCProviderConfig{}
+	for _, name := range parseList(names) {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:           name,
+			IssuerURL:      strings.TrimSuffix(sharedConfig.GetEnv(prefix+"ISSUER_URL", ""), "/"),
+			ClientID:       sharedConfig.GetEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret:   sharedConfig.GetEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:    sharedConfig.GetEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:         strings.Fields(sharedConfig.GetEnv(prefix+"SCOPES", "openid email profile")),
+			AllowedDomains: parseList(strings.ToLower(sharedConfig.GetEnv(prefix+"ALLOWED_DOMAINS", ""))),
+			TrustEmail:     sharedConfig.GetEnv(prefix+"TRUST_EMAIL", "false") == "true",
+		}
+		if provider.IssuerURL == "" || provider.ClientID == "" {
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers
 }
 
 func DefaultConfig() *Config {
@@ -27,6 +183,48 @@ func DefaultConfig() *Config {
 			SecretKey:            sharedConfig.GetEnv("JWT_SECRET_KEY", "secret"),
 			AccessTokenDuration:  sharedConfig.GetEnvAsDuration("JWT_ACCESS_TOKEN_DURATION", 24*time.Hour),
 			RefreshTokenDuration: sharedConfig.GetEnvAsDuration("JWT_REFRESH_TOKEN_DURATION", 7*24*time.Hour),
+			SigningKeysDir:       sharedConfig.GetEnv("JWT_SIGNING_KEYS_DIR", ""),
+			ActiveKeyID:          sharedConfig.GetEnv("JWT_ACTIVE_KEY_ID", ""),
+			AcceptHS256Until:     sharedConfig.GetEnvAsTime("JWT_ACCEPT_HS256_UNTIL", time.Time{}),
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:        sharedConfig.GetEnv("TWO_FACTOR_ISSUER", "Asto LMS"),
+			RequiredRoles: parseList(sharedConfig.GetEnv("TWO_FACTOR_REQUIRED_ROLES", "")),
+			ChallengeTTL:  sharedConfig.GetEnvAsDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+			MaxAttempts:   sharedConfig.GetEnvAsInt("TWO_FACTOR_MAX_ATTEMPTS", 5),
+		},
+		Lockout: LockoutConfig{
+			MaxAccountFailures: sharedConfig.GetEnvAsInt("LOCKOUT_MAX_ACCOUNT_FAILURES", 10),
+			MaxIPFailures:      sharedConfig.GetEnvAsInt("LOCKOUT_MAX_IP_FAILURES", 50),
+			FailureWindow:      sharedConfig.GetEnvAsDuration("LOCKOUT_FAILURE_WINDOW", 15*time.Minute),
+			LockoutDuration:    sharedConfig.GetEnvAsDuration("LOCKOUT_DURATION", 15*time.Minute),
+			DelayAfter:         sharedConfig.GetEnvAsInt("LOCKOUT_DELAY_AFTER", 3),
+			BaseDelay:          sharedConfig.GetEnvAsDuration("LOCKOUT_BASE_DELAY", time.Second),
+			MaxDelay:           sharedConfig.GetEnvAsDuration("LOCKOUT_MAX_DELAY", 30*time.Second),
+			OTPMaxAttempts:     sharedConfig.GetEnvAsInt("OTP_MAX_ATTEMPTS", 5),
+		},
+		RateLimit: sharedConfig.RateLimitConfig{
+			Enabled:  true,
+			Requests: 20,
+			Window:   time.Minute,
+		},
+		OIDC: OIDCConfig{
+			StateTTL: 10 * time.Minute,
+		},
+		Impersonation: ImpersonationConfig{
+			TokenDuration: 30 * time.Minute,
+		},
+		Invitation: InvitationConfig{
+			TokenDuration: 7 * 24 * time.Hour,
+			AcceptURL:     defaults.Server.APIGatewayURL + "/accept-invitation",
+		},
+		MagicLink: MagicLinkConfig{
+			AllowedRoles: []string{},
+			TokenTTL:     15 * time.Minute,
+			LoginURL:     defaults.Server.APIGatewayURL + "/magic-link",
+		},
+		UserCache: UserCacheConfig{
+			TTL: 30 * time.Second,
 		},
 	}
 }
@@ -41,6 +239,45 @@ func Load() (*Config, error) {
 			SecretKey:            sharedConfig.GetEnv("JWT_SECRET_KEY", defaults.Jwt.SecretKey),
 			AccessTokenDuration:  sharedConfig.GetEnvAsDuration("JWT_ACCESS_TOKEN_DURATION", defaults.Jwt.AccessTokenDuration),
 			RefreshTokenDuration: sharedConfig.GetEnvAsDuration("JWT_REFRESH_TOKEN_DURATION", defaults.Jwt.RefreshTokenDuration),
+			SigningKeysDir:       sharedConfig.GetEnv("JWT_SIGNING_KEYS_DIR", defaults.Jwt.SigningKeysDir),
+			ActiveKeyID:          sharedConfig.GetEnv("JWT_ACTIVE_KEY_ID", defaults.Jwt.ActiveKeyID),
+			AcceptHS256Until:     sharedConfig.GetEnvAsTime("JWT_ACCEPT_HS256_UNTIL", defaults.Jwt.AcceptHS256Until),
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:        sharedConfig.GetEnv("TWO_FACTOR_ISSUER", defaults.TwoFactor.Issuer),
+			RequiredRoles: parseList(sharedConfig.GetEnv("TWO_FACTOR_REQUIRED_ROLES", strings.Join(defaults.TwoFactor.RequiredRoles, ","))),
+			ChallengeTTL:  sharedConfig.GetEnvAsDuration("TWO_FACTOR_CHALLENGE_TTL", defaults.TwoFactor.ChallengeTTL),
+			MaxAttempts:   sharedConfig.GetEnvAsInt("TWO_FACTOR_MAX_ATTEMPTS", defaults.TwoFactor.MaxAttempts),
+		},
+		Lockout: LockoutConfig{
+			MaxAccountFailures: sharedConfig.GetEnvAsInt("LOCKOUT_MAX_ACCOUNT_FAILURES", defaults.Lockout.MaxAccountFailures),
+			MaxIPFailures:      sharedConfig.GetEnvAsInt("LOCKOUT_MAX_IP_FAILURES", defaults.Lockout.MaxIPFailures),
+			FailureWindow:      sharedConfig.GetEnvAsDuration("LOCKOUT_FAILURE_WINDOW", defaults.Lockout.FailureWindow),
+			LockoutDuration:    sharedConfig.GetEnvAsDuration("LOCKOUT_DURATION", defaults.Lockout.LockoutDuration),
+			DelayAfter:         sharedConfig.GetEnvAsInt("LOCKOUT_DELAY_AFTER", defaults.Lockout.DelayAfter),
+			BaseDelay:          sharedConfig.GetEnvAsDuration("LOCKOUT_BASE_DELAY", defaults.Lockout.BaseDelay),
+			MaxDelay:           sharedConfig.GetEnvAsDuration("LOCKOUT_MAX_DELAY", defaults.Lockout.MaxDelay),
+			OTPMaxAttempts:     sharedConfig.GetEnvAsInt("OTP_MAX_ATTEMPTS", defaults.Lockout.OTPMaxAttempts),
+		},
+		RateLimit: sharedConfig.LoadRateLimitConfig("RATE_LIMIT_AUTH", defaults.RateLimit),
+		OIDC: OIDCConfig{
+			Providers: loadOIDCProviders(sharedConfig.GetEnv("OIDC_PROVIDERS", "")),
+			StateTTL:  sharedConfig.GetEnvAsDuration("OIDC_STATE_TTL", defaults.OIDC.StateTTL),
+		},
+		Impersonation: ImpersonationConfig{
+			TokenDuration: sharedConfig.GetEnvAsDuration("IMPERSONATION_TOKEN_DURATION", defaults.Impersonation.TokenDuration),
+		},
+		Invitation: InvitationConfig{
+			TokenDuration: sharedConfig.GetEnvAsDuration("INVITATION_TOKEN_DURATION", defaults.Invitation.TokenDuration),
+			AcceptURL:     sharedConfig.GetEnv("INVITATION_ACCEPT_URL", baseCfg.Server.APIGatewayURL+"/accept-invitation"),
+		},
+		MagicLink: MagicLinkConfig{
+			AllowedRoles: parseList(sharedConfig.GetEnv("MAGIC_LINK_ALLOWED_ROLES", strings.Join(defaults.MagicLink.AllowedRoles, ","))),
+			TokenTTL:     sharedConfig.GetEnvAsDuration("MAGIC_LINK_TOKEN_TTL", defaults.MagicLink.TokenTTL),
+			LoginURL:     sharedConfig.GetEnv("MAGIC_LINK_URL", baseCfg.Server.APIGatewayURL+"/magic-link"),
+		},
+		UserCache: UserCacheConfig{
+			TTL: sharedConfig.GetEnvAsDuration("USER_CACHE_TTL", defaults.UserCache.TTL),
 		},
 	}, nil
-}
\ No newline at end of file
+}
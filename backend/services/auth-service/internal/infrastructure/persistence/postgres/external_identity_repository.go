@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+)
+
+type PostgresExternalIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresExternalIdentityRepository(db *sql.DB) repositories.ExternalIdentityRepository {
+	return &PostgresExternalIdentityRepository{db: db}
+}
+
+func (r *PostgresExternalIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entities.ExternalIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		FROM user_external_identities
+		WHERE provider = $1 AND subject = $2
+	`
+	var identity entities.ExternalIdentity
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *PostgresExternalIdentityRepository) Create(ctx context.Context, identity *entities.ExternalIdentity) error {
+	query := `
+		INSERT INTO user_external_identities (id, user_id, provider, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+		identity.LastLoginAt,
+	)
+	return err
+}
+
+func (r *PostgresExternalIdentityRepository) Update(ctx context.Context, identity *entities.ExternalIdentity) error {
+	query := `
+		UPDATE user_external_identities
+		SET email = $1, last_login_at = $2
+		WHERE id = $3
+	`
+	_, err := r.db.ExecContext(ctx, query, identity.Email, identity.LastLoginAt, identity.ID)
+	return err
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+)
+
+type PostgresInvitationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresInvitationRepository(db *sql.DB) repositories.InvitationRepository {
+	return &PostgresInvitationRepository{db: db}
+}
+
+const invitationColumns = `id, email, role, token_hash, invited_by, organization_id, expires_at, accepted_at, accepted_user_id, revoked_at, created_at, updated_at`
+
+func scanInvitation(row rowScanner) (*entities.Invitation, error) {
+	var invitation entities.Invitation
+	var acceptedAt, revokedAt sql.NullTime
+	var acceptedUserID, organizationID sql.NullString
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.TokenHash,
+		&invitation.InvitedBy,
+		&organizationID,
+		&invitation.ExpiresAt,
+		&acceptedAt,
+		&acceptedUserID,
+		&revokedAt,
+		&invitation.CreatedAt,
+		&invitation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	invitation.AcceptedUserID = acceptedUserID.String
+	if organizationID.Valid {
+		invitation.OrganizationID = &organizationID.String
+	}
+	if revokedAt.Valid {
+		invitation.RevokedAt = &revokedAt.Time
+	}
+	return &invitation, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func (r *PostgresInvitationRepository) Create(ctx context.Context, invitation *entities.Invitation) error {
+	query := `
+		INSERT INTO invitations (` + invitationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.Email,
+		invitation.Role.String(),
+		invitation.TokenHash,
+		invitation.InvitedBy,
+		invitation.OrganizationID,
+		invitation.ExpiresAt,
+		invitation.AcceptedAt,
+		nullString(invitation.AcceptedUserID),
+		invitation.RevokedAt,
+		invitation.CreatedAt,
+		invitation.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresInvitationRepository) FindByID(ctx context.Context, id string) (*entities.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE id = $1`
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *PostgresInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE token_hash = $1`
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *PostgresInvitationRepository) FindOpenByEmail(ctx context.Context, email string) (*entities.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE email = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, email))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return invitation, err
+}
+
+func (r *PostgresInvitationRepository) ListOpen(ctx context.Context) ([]*entities.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		WHERE accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []*entities.Invitation{}
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *PostgresInvitationRepository) Update(ctx context.Context, invitation *entities.Invitation) error {
+	query := `
+		UPDATE invitations
+		SET token_hash = $1, expires_at = $2, accepted_at = $3, accepted_user_id = $4, revoked_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.TokenHash,
+		invitation.ExpiresAt,
+		invitation.AcceptedAt,
+		nullString(invitation.AcceptedUserID),
+		invitation.RevokedAt,
+		invitation.UpdatedAt,
+		invitation.ID,
+	)
+	return err
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+)
+
+type PostgresSecurityEventRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSecurityEventRepository(db *sql.DB) repositories.SecurityEventRepository {
+	return &PostgresSecurityEventRepository{db: db}
+}
+
+const securityEventColumns = `id, user_id, type, detail, ip_address, user_agent, device, actor_id, created_at`
+
+func scanSecurityEvent(row rowScanner) (*entities.SecurityEvent, error) {
+	var event entities.SecurityEvent
+	var actorID sql.NullString
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.Type,
+		&event.Detail,
+		&event.IPAddress,
+		&event.UserAgent,
+		&event.Device,
+		&actorID,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.ActorID = actorID.String
+	return &event, nil
+}
+
+func (r *PostgresSecurityEventRepository) Create(ctx context.Context, event *entities.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (` + securityEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Type,
+		event.Detail,
+		event.IPAddress,
+		event.UserAgent,
+		event.Device,
+		nullString(event.ActorID),
+		event.CreatedAt,
+	)
+	return err
+}
+
+func (r *PostgresSecurityEventRepository) ListByUserID(ctx context.Context, userID string, limit int) ([]*entities.SecurityEvent, error) {
+	query := `
+		SELECT ` + securityEventColumns + `
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entities.SecurityEvent{}
+	for rows.Next() {
+		event, err := scanSecurityEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *PostgresSecurityEventRepository) FindLoginSource(ctx context.Context, userID, ipAddress, device string) (*entities.LoginSource, error) {
+	query := `
+		SELECT COUNT(*) > 0,
+			COALESCE(BOOL_OR(ip_address = $2), false),
+			COALESCE(BOOL_OR(device = $3), false)
+		FROM security_events
+		WHERE user_id = $1 AND type = $4
+	`
+	var source entities.LoginSource
+	err := r.db.QueryRowContext(ctx, query, userID, ipAddress, device, entities.SecurityEventLogin).Scan(
+		&source.HasPriorLogins,
+		&source.KnownIP,
+		&source.KnownDevice,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &source, nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+)
+
+type PostgresServiceAccountRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresServiceAccountRepository(db *sql.DB) repositories.ServiceAccountRepository {
+	return &PostgresServiceAccountRepository{db: db}
+}
+
+const serviceAccountColumns = `id, name, description, role, organization_id, created_by, disabled_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanServiceAccount(row rowScanner) (*entities.ServiceAccount, error) {
+	var account entities.ServiceAccount
+	var disabledAt sql.NullTime
+	var organizationID sql.NullString
+	err := row.Scan(
+		&account.ID,
+		&account.Name,
+		&account.Description,
+		&account.Role,
+		&organizationID,
+		&account.CreatedBy,
+		&disabledAt,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if disabledAt.Valid {
+		account.DisabledAt = &disabledAt.Time
+	}
+	if organizationID.Valid {
+		account.OrganizationID = &organizationID.String
+	}
+	return &account, nil
+}
+
+func (r *PostgresServiceAccountRepository) Create(ctx context.Context, account *entities.ServiceAccount) error {
+	query := `
+		INSERT INTO service_accounts (id, name, description, role, organization_id, created_by, disabled_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		account.ID,
+		account.Name,
+		account.Description,
+		account.Role.String(),
+		account.OrganizationID,
+		account.CreatedBy,
+		account.DisabledAt,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresServiceAccountRepository) FindByID(ctx context.Context, id string) (*entities.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts WHERE id = $1`
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return account, err
+}
+
+func (r *PostgresServiceAccountRepository) FindByName(ctx context.Context, name string) (*entities.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts WHERE name = $1`
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return account, err
+}
+
+func (r *PostgresServiceAccountRepository) List(ctx context.Context) ([]*entities.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []*entities.ServiceAccount{}
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func (r *PostgresServiceAccountRepository) Update(ctx context.Context, account *entities.ServiceAccount) error {
+	query := `
+		UPDATE service_accounts
+		SET name = $1, description = $2, role = $3, disabled_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		account.Name,
+		account.Description,
+		account.Role.String(),
+		account.DisabledAt,
+		account.UpdatedAt,
+		account.ID,
+	)
+	return err
+}
+
+type PostgresAPIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAPIKeyRepository(db *sql.DB) repositories.APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, service_account_id, name, prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, revoked_at, created_by, created_at`
+
+// Scopes are stored space separated, the way OAuth writes them.
+func scanAPIKey(row rowScanner) (*entities.APIKey, error) {
+	var key entities.APIKey
+	var scopes string
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.ServiceAccountID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&key.LastUsedIP,
+		&revokedAt,
+		&key.CreatedBy,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	key.Scopes = strings.Fields(scopes)
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
+
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	query := `
+		INSERT INTO service_account_api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		key.ID,
+		key.ServiceAccountID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		strings.Join(key.Scopes, " "),
+		key.ExpiresAt,
+		key.LastUsedAt,
+		key.LastUsedIP,
+		key.RevokedAt,
+		key.CreatedBy,
+		key.CreatedAt,
+	)
+	return err
+}
+
+func (r *PostgresAPIKeyRepository) FindByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM service_account_api_keys WHERE id = $1`
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+func (r *PostgresAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM service_account_api_keys WHERE prefix = $1`
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+func (r *PostgresAPIKeyRepository) ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*entities.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM service_account_api_keys
+		WHERE service_account_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*entities.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *PostgresAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	query := `
+		UPDATE service_account_api_keys
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, revokedAt, id)
+	return err
+}
+
+func (r *PostgresAPIKeyRepository) RevokeByServiceAccountID(ctx context.Context, serviceAccountID string, revokedAt time.Time) error {
+	query := `
+		UPDATE service_account_api_keys
+		SET revoked_at = $1
+		WHERE service_account_id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, revokedAt, serviceAccountID)
+	return err
+}
+
+func (r *PostgresAPIKeyRepository) UpdateLastUsed(ctx context.Context, id, ip string, usedAt time.Time) error {
+	query := `
+		UPDATE service_account_api_keys
+		SET last_used_at = $1, last_used_ip = $2
+		WHERE id = $3
+	`
+	_, err := r.db.ExecContext(ctx, query, usedAt, ip, id)
+	return err
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +20,11 @@ type AuthHandler struct {
 	verifyEmailUseCase *usecases.VerifyEmailUseCase
 	requestEmailVerifyUseCase *usecases.RequestEmailVerifyUseCase
 	refreshTokenUseCase *usecases.RefreshTokenUseCase
+	logoutUseCase *usecases.LogoutUseCase
+	listSessionsUseCase *usecases.ListSessionsUseCase
+	revokeSessionUseCase *usecases.RevokeSessionUseCase
+	getJWKSUseCase *usecases.GetJWKSUseCase
+	unlockAccountUseCase *usecases.UnlockAccountUseCase
 }
 
 func NewAuthHandler(
@@ -30,6 +37,11 @@ func NewAuthHandler(
 	verifyEmailUseCase *usecases.VerifyEmailUseCase,
 	requestEmailVerifyUseCase *usecases.RequestEmailVerifyUseCase,
 	refreshTokenUseCase *usecases.RefreshTokenUseCase,
+	logoutUseCase *usecases.LogoutUseCase,
+	listSessionsUseCase *usecases.ListSessionsUseCase,
+	revokeSessionUseCase *usecases.RevokeSessionUseCase,
+	getJWKSUseCase *usecases.GetJWKSUseCase,
+	unlockAccountUseCase *usecases.UnlockAccountUseCase,
 ) *AuthHandler {
 	return &AuthHandler{
 		loginUseCase: loginUseCase,
@@ -41,9 +53,46 @@ func NewAuthHandler(
 		verifyEmailUseCase: verifyEmailUseCase,
 		requestEmailVerifyUseCase: requestEmailVerifyUseCase,
 		refreshTokenUseCase: refreshTokenUseCase,
+		logoutUseCase: logoutUseCase,
+		listSessionsUseCase: listSessionsUseCase,
+		revokeSessionUseCase: revokeSessionUseCase,
+		getJWKSUseCase: getJWKSUseCase,
+		unlockAccountUseCase: unlockAccountUseCase,
 	}
 }
 
+// bearerToken returns the token from the Authorization header, with or
+// without the "Bearer " prefix.
+func bearerToken(c *gin.Context) string {
+	authHeader := c.GetHeader("Authorization")
+	if strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
+		return strings.TrimSpace(authHeader[7:])
+	}
+	return strings.TrimSpace(authHeader)
+}
+
+// writeLockoutError responds with 423 for a locked account or 429 for a
+// throttled IP, setting Retry-After. It reports whether err was a lockout.
+func writeLockoutError(c *gin.Context, err error) bool {
+	var lockoutErr *usecases.LockoutError
+	if !errors.As(err, &lockoutErr) {
+		return false
+	}
+
+	retryAfter := int(lockoutErr.RetryAfter.Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	status := http.StatusTooManyRequests
+	if errors.Is(err, usecases.ErrAccountLocked) {
+		status = http.StatusLocked
+	}
+	c.JSON(status, gin.H{"error": err.Error(), "retry_after": retryAfter})
+	return true
+}
+
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required" example:"user@example.com"`
 	Password string `json:"password" binding:"required" example:"Password@123"`
@@ -51,7 +100,7 @@ type LoginRequest struct {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate user with email and password, returns access and refresh tokens
+// @Description Authenticate user with email and password, returns access and refresh tokens. If two-factor authentication is enabled or required for the user's role, returns two_factor_required and a challenge_token to complete at /login/2fa instead.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -59,6 +108,8 @@ type LoginRequest struct {
 // @Success 200 {object} map[string]interface{} "Login successful"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
 // @Failure 401 {object} map[string]interface{} "Invalid credentials"
+// @Failure 423 {object} map[string]interface{} "Account temporarily locked after too many failed attempts"
+// @Failure 429 {object} map[string]interface{} "Too many failed attempts from this IP address"
 // @Router /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
@@ -76,6 +127,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	output, err := h.loginUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
+		if writeLockoutError(c, err) {
+			return
+		}
 		c.JSON(401, gin.H{"error": err.Error()})
 		return
 	}
@@ -96,6 +150,8 @@ type ForgotPasswordRequest struct {
 // @Param request body ForgotPasswordRequest true "Email address"
 // @Success 200 {object} map[string]interface{} "OTP sent successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or email not found"
+// @Failure 423 {object} map[string]interface{} "Too many reset requests for this account"
+// @Failure 429 {object} map[string]interface{} "Too many reset requests from this IP address"
 // @Router /forgot-password [post]
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
@@ -107,11 +163,16 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
 	input := usecases.ForgotPasswordInput{
 		Email: req.Email,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
 	}
 
 	output, err := h.forgotPasswordUseCase.Execute(c.Request.Context(), input)
 
 	if err != nil {
+		if writeLockoutError(c, err) {
+			return
+		}
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
@@ -126,13 +187,15 @@ type VerifyOTPRequest struct {
 
 // VerifyOTP godoc
 // @Summary Verify OTP
-// @Description Verify the OTP sent to user's email for password reset
+// @Description Verify the OTP sent to user's email for password reset. Each OTP only allows a few wrong guesses before it has to be requested again.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body VerifyOTPRequest true "Email and OTP"
 // @Success 200 {object} map[string]interface{} "OTP verified successfully, returns reset token"
 // @Failure 400 {object} map[string]interface{} "Invalid OTP or request body"
+// @Failure 423 {object} map[string]interface{} "Account temporarily locked after too many failed attempts"
+// @Failure 429 {object} map[string]interface{} "Too many failed attempts from this IP address"
 // @Router /verify-otp [post]
 func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 	var req VerifyOTPRequest
@@ -144,10 +207,15 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 	input := usecases.VerifyOTPInput{
 		Email: req.Email,
 		OTP: req.OTP,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
 	}
 
 
 	output, err := h.verifyOTPUseCase.Execute(c.Request.Context(), input)
+	if writeLockoutError(c, err) {
+		return
+	}
 	if err != nil || !output.IsValid {
 		c.JSON(400, gin.H{"error": output.ErrorMessage, "details": err.Error()})
 		return
@@ -196,31 +264,22 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 }
 
 // Verify godoc
-// @Summary Verify JWT token
-// @Description Verify JWT access token and optionally check for required role. Returns user information in headers.
+// @Summary Verify JWT token or API key
+// @Description Verify a JWT access token or a service account API key and optionally check for required role. API keys are also checked against their scopes for the original request. Returns user or service account information in headers, X-Organization-ID when the user belongs to an organization, and X-Actor-ID/X-Actor-Email when an admin is impersonating the user.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param Authorization header string true "Bearer token or API key" default(Bearer )
 // @Param X-Required-Role header string false "Required role for access"
+// @Param X-Original-Method header string false "Method of the request being authorized"
+// @Param X-Original-URI header string false "URI of the request being authorized"
 // @Success 200 "Token is valid"
 // @Failure 400 {object} map[string]interface{} "Token is required"
 // @Failure 401 "Token is invalid or expired"
-// @Failure 403 "Insufficient permissions"
+// @Failure 403 "Insufficient permissions, or a credential change while impersonating"
 // @Router /verify [get]
 func (h *AuthHandler) Verify(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(400, gin.H{"error": "Token is required"})
-		return
-	}
-
-	token := authHeader
-	authHeaderLower := strings.ToLower(authHeader)
-	if strings.HasPrefix(authHeaderLower, "bearer ") {
-		token = strings.TrimSpace(authHeader[7:])
-	}
-
+	token := bearerToken(c)
 	if token == "" {
 		c.JSON(400, gin.H{"error": "Token is required"})
 		return
@@ -229,6 +288,9 @@ func (h *AuthHandler) Verify(c *gin.Context) {
 	input := usecases.VerifyInput{
 		Token: token,
 		RequiredRole: c.GetHeader("X-Required-Role"),
+		OriginalMethod: c.GetHeader("X-Original-Method"),
+		OriginalURI: c.GetHeader("X-Original-URI"),
+		IPAddress: c.ClientIP(),
 	}
 
 	user, err := h.verifyUseCase.Execute(c.Request.Context(), input)
@@ -246,6 +308,13 @@ func (h *AuthHandler) Verify(c *gin.Context) {
 	c.Header("X-User-ID", user.ID)
 	c.Header("X-User-Email", user.Email)
 	c.Header("X-User-Role", user.Role)
+	if user.OrganizationID != nil {
+		c.Header("X-Organization-ID", *user.OrganizationID)
+	}
+	if user.ActorID != "" {
+		c.Header("X-Actor-ID", user.ActorID)
+		c.Header("X-Actor-Email", user.ActorEmail)
+	}
 	c.Status(200)
 }
 
@@ -356,7 +425,7 @@ type RefreshTokenRequest struct {
 
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Generate a new access token using a valid refresh token
+// @Description Exchange a refresh token for a new access and refresh token. The used refresh token is revoked; presenting it again revokes the whole session.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -374,6 +443,8 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	input := usecases.RefreshTokenInput{
 		RefreshToken: req.RefreshToken,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.GetHeader("User-Agent"),
 	}
 
 	output, err := h.refreshTokenUseCase.Execute(c.Request.Context(), input)
@@ -385,6 +456,158 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	c.JSON(200, output)
 }
 
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the current session and its tokens
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Logged out successfully"
+// @Failure 400 {object} map[string]interface{} "Token is required"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	h.logout(c, false)
+}
+
+// LogoutAll godoc
+// @Summary Log out everywhere
+// @Description Revoke every active session of the current user, including this one
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Logged out of all sessions successfully"
+// @Failure 400 {object} map[string]interface{} "Token is required"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	h.logout(c, true)
+}
+
+func (h *AuthHandler) logout(c *gin.Context, allSessions bool) {
+	token := bearerToken(c)
+	if token == "" {
+		c.JSON(400, gin.H{"error": "Token is required"})
+		return
+	}
+
+	input := usecases.LogoutInput{
+		AccessToken: token,
+		AllSessions: allSessions,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+
+	output, err := h.logoutUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == usecases.ErrUnauthorized {
+			c.JSON(401, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// ListSessions godoc
+// @Summary List active sessions
+// @Description List the current user's active sessions with device, IP address, user agent and last activity
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Active sessions"
+// @Failure 400 {object} map[string]interface{} "Token is required"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		c.JSON(400, gin.H{"error": "Token is required"})
+		return
+	}
+
+	input := usecases.ListSessionsInput{
+		AccessToken: token,
+	}
+
+	output, err := h.listSessionsUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == usecases.ErrUnauthorized {
+			c.JSON(401, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Log out one of the current user's sessions, e.g. a forgotten lab machine
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{} "Session revoked successfully"
+// @Failure 400 {object} map[string]interface{} "Token is required"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 404 {object} map[string]interface{} "Session not found"
+// @Router /sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		c.JSON(400, gin.H{"error": "Token is required"})
+		return
+	}
+
+	input := usecases.RevokeSessionInput{
+		AccessToken: token,
+		SessionID:   c.Param("id"),
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	}
+
+	output, err := h.revokeSessionUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case usecases.ErrUnauthorized:
+			c.JSON(401, gin.H{"error": err.Error()})
+		case usecases.ErrSessionNotFound:
+			c.JSON(404, gin.H{"error": err.Error()})
+		case usecases.ErrSessionIDRequired:
+			c.JSON(400, gin.H{"error": err.Error()})
+		default:
+			c.JSON(500, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Public keys used to verify access tokens, identified by the "kid" token header
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Key set"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	output, err := h.getJWKSUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, output)
+}
+
 // Health godoc
 // @Summary Health check
 // @Description Check if the auth service is running and healthy
@@ -398,4 +621,48 @@ func (h *AuthHandler) Health(c *gin.Context) {
 		"status":  "healthy",
 		"service": "auth-service",
 	})
-}
\ No newline at end of file
+}
+// UnlockAccount godoc
+// @Summary Unlock a user account
+// @Description Clear brute-force lockouts and failure counters on a user's account. Admin only.
+// @Tags auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param user_id path string true "User ID"
+// @Success 200 {object} map[string]interface{} "Account unlocked successfully"
+// @Failure 400 {object} map[string]interface{} "Token is required"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /lockouts/{user_id} [delete]
+func (h *AuthHandler) UnlockAccount(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		c.JSON(400, gin.H{"error": "Token is required"})
+		return
+	}
+
+	input := usecases.UnlockAccountInput{
+		AccessToken: token,
+		UserID:      c.Param("user_id"),
+	}
+
+	output, err := h.unlockAccountUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case usecases.ErrUnauthorized, usecases.ErrTokenRequired:
+			c.JSON(401, gin.H{"error": err.Error()})
+		case usecases.ErrInsufficientPermissions:
+			c.JSON(403, gin.H{"error": err.Error()})
+		case usecases.ErrUserNotFound:
+			c.JSON(404, gin.H{"error": err.Error()})
+		case usecases.ErrUserIDRequired:
+			c.JSON(400, gin.H{"error": err.Error()})
+		default:
+			c.JSON(500, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(200, output)
+}
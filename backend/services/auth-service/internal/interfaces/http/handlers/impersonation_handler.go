@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+)
+
+type ImpersonationHandler struct {
+	startImpersonationUseCase *usecases.StartImpersonationUseCase
+	stopImpersonationUseCase  *usecases.StopImpersonationUseCase
+}
+
+func NewImpersonationHandler(
+	startImpersonationUseCase *usecases.StartImpersonationUseCase,
+	stopImpersonationUseCase *usecases.StopImpersonationUseCase,
+) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		startImpersonationUseCase: startImpersonationUseCase,
+		stopImpersonationUseCase:  stopImpersonationUseCase,
+	}
+}
+
+func impersonationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrUserIDRequired),
+		errors.Is(err, usecases.ErrImpersonationReasonRequired),
+		errors.Is(err, usecases.ErrNotImpersonating):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrInsufficientPermissions),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating),
+		errors.Is(err, usecases.ErrCannotImpersonateUser):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type StartImpersonationRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"4f1c2a5e-8d3b-4c9a-9e21-1b2f3c4d5e6f"`
+	Reason string `json:"reason" binding:"required" example:"Ticket #1234: student cannot see course materials"`
+}
+
+// StartImpersonation godoc
+// @Summary Impersonate a user
+// @Description Issue a short-lived access token to view the platform as a student or instructor. The token names the admin as its actor, cannot be refreshed and cannot change the user's password or email. Admin only.
+// @Tags impersonation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body StartImpersonationRequest true "User to impersonate and reason"
+// @Success 200 {object} usecases.StartImpersonationOutput "Impersonation token"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions or user cannot be impersonated"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /impersonate [post]
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	var req StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.startImpersonationUseCase.Execute(c.Request.Context(), usecases.StartImpersonationInput{
+		AccessToken: bearerToken(c),
+		UserID:      req.UserID,
+		Reason:      req.Reason,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(impersonationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// StopImpersonation godoc
+// @Summary Stop impersonating a user
+// @Description Revoke the impersonation token sent in the Authorization header.
+// @Tags impersonation
+// @Produce json
+// @Param Authorization header string true "Impersonation token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Impersonation stopped successfully"
+// @Failure 400 {object} map[string]interface{} "Token is not an impersonation token"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /impersonate/stop [post]
+func (h *ImpersonationHandler) StopImpersonation(c *gin.Context) {
+	output, err := h.stopImpersonationUseCase.Execute(c.Request.Context(), usecases.StopImpersonationInput{
+		AccessToken: bearerToken(c),
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(impersonationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
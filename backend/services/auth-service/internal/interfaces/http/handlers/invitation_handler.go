@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+)
+
+type InvitationHandler struct {
+	createInvitationUseCase *usecases.CreateInvitationUseCase
+	listInvitationsUseCase  *usecases.ListInvitationsUseCase
+	resendInvitationUseCase *usecases.ResendInvitationUseCase
+	revokeInvitationUseCase *usecases.RevokeInvitationUseCase
+	acceptInvitationUseCase *usecases.AcceptInvitationUseCase
+}
+
+func NewInvitationHandler(
+	createInvitationUseCase *usecases.CreateInvitationUseCase,
+	listInvitationsUseCase *usecases.ListInvitationsUseCase,
+	resendInvitationUseCase *usecases.ResendInvitationUseCase,
+	revokeInvitationUseCase *usecases.RevokeInvitationUseCase,
+	acceptInvitationUseCase *usecases.AcceptInvitationUseCase,
+) *InvitationHandler {
+	return &InvitationHandler{
+		createInvitationUseCase: createInvitationUseCase,
+		listInvitationsUseCase:  listInvitationsUseCase,
+		resendInvitationUseCase: resendInvitationUseCase,
+		revokeInvitationUseCase: revokeInvitationUseCase,
+		acceptInvitationUseCase: acceptInvitationUseCase,
+	}
+}
+
+func invitationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrEmailRequired),
+		errors.Is(err, usecases.ErrInvalidEmail),
+		errors.Is(err, usecases.ErrInvalidInvitationRole),
+		errors.Is(err, valueobjects.ErrInvalidRole),
+		errors.Is(err, usecases.ErrUsernameRequired),
+		errors.Is(err, usecases.ErrPasswordRequired),
+		errors.Is(err, usecases.ErrInvalidPassword),
+		errors.Is(err, usecases.ErrInvalidInvitation):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrInsufficientPermissions),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrInvitationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrEmailAlreadyExists),
+		errors.Is(err, usecases.ErrUsernameAlreadyExists),
+		errors.Is(err, usecases.ErrInvitationAlreadyPending),
+		errors.Is(err, usecases.ErrInvitationNotOpen):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required" example:"instructor@example.com"`
+	Role  string `json:"role" binding:"required" example:"instructor"`
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user
+// @Description Email a single-use invitation link to a new instructor or admin. Admin only.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body CreateInvitationRequest true "Invitee email and role"
+// @Success 201 {object} usecases.InvitationOutput "Invitation created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, email or role"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "User or pending invitation already exists"
+// @Router /invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var req CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.createInvitationUseCase.Execute(c.Request.Context(), usecases.CreateInvitationInput{
+		AccessToken: bearerToken(c),
+		Email:       req.Email,
+		Role:        req.Role,
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+// ListInvitations godoc
+// @Summary List invitations
+// @Description List invitations that were neither accepted nor revoked, including expired ones. Admin only.
+// @Tags invitations
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} usecases.ListInvitationsOutput "Invitations"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Router /invitations [get]
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	output, err := h.listInvitationsUseCase.Execute(c.Request.Context(), usecases.ListInvitationsInput{
+		AccessToken: bearerToken(c),
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// ResendInvitation godoc
+// @Summary Resend an invitation
+// @Description Send the invitation again with a new link and expiry. Earlier links stop working. Admin only.
+// @Tags invitations
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} usecases.InvitationOutput "Invitation resent"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Invitation not found"
+// @Failure 409 {object} map[string]interface{} "Invitation was already accepted or revoked"
+// @Router /invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	output, err := h.resendInvitationUseCase.Execute(c.Request.Context(), usecases.ResendInvitationInput{
+		AccessToken:  bearerToken(c),
+		InvitationID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Revoke an invitation so its link can no longer be accepted. Admin only.
+// @Tags invitations
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} map[string]interface{} "Invitation revoked successfully"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Invitation not found"
+// @Failure 409 {object} map[string]interface{} "Invitation was already accepted"
+// @Router /invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	output, err := h.revokeInvitationUseCase.Execute(c.Request.Context(), usecases.RevokeInvitationInput{
+		AccessToken:  bearerToken(c),
+		InvitationID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required" example:"Zk9x2c..."`
+	Username string `json:"username" binding:"required" example:"janedoe"`
+	Password string `json:"password" binding:"required" example:"Password123!"`
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Create the invited account with a username and password of the invitee's choosing. The email is marked verified.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body AcceptInvitationRequest true "Invitation token and new credentials"
+// @Success 201 {object} usecases.AcceptInvitationOutput "Account created"
+// @Failure 400 {object} map[string]interface{} "Invalid or expired invitation, or invalid password"
+// @Failure 409 {object} map[string]interface{} "Email or username already exists"
+// @Router /invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.acceptInvitationUseCase.Execute(c.Request.Context(), usecases.AcceptInvitationInput{
+		Token:    req.Token,
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(201, output)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+)
+
+type MagicLinkHandler struct {
+	requestMagicLinkUseCase *usecases.RequestMagicLinkUseCase
+	verifyMagicLinkUseCase  *usecases.VerifyMagicLinkUseCase
+}
+
+func NewMagicLinkHandler(
+	requestMagicLinkUseCase *usecases.RequestMagicLinkUseCase,
+	verifyMagicLinkUseCase *usecases.VerifyMagicLinkUseCase,
+) *MagicLinkHandler {
+	return &MagicLinkHandler{
+		requestMagicLinkUseCase: requestMagicLinkUseCase,
+		verifyMagicLinkUseCase:  verifyMagicLinkUseCase,
+	}
+}
+
+func magicLinkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrEmailRequired),
+		errors.Is(err, usecases.ErrInvalidEmail),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrInvalidMagicLink):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type RequestMagicLinkRequest struct {
+	Email string `json:"email" binding:"required" example:"student@example.com"`
+}
+
+// RequestMagicLink godoc
+// @Summary Request a magic link
+// @Description Email a single-use sign-in link. Only roles allowed by MAGIC_LINK_ALLOWED_ROLES receive one, and the response does not reveal whether a link was sent.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RequestMagicLinkRequest true "Account email"
+// @Success 200 {object} usecases.RequestMagicLinkOutput "Sign-in link sent if allowed"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or email"
+// @Failure 429 {object} map[string]interface{} "Too many requests"
+// @Router /magic-link [post]
+func (h *MagicLinkHandler) RequestMagicLink(c *gin.Context) {
+	var req RequestMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.requestMagicLinkUseCase.Execute(c.Request.Context(), usecases.RequestMagicLinkInput{
+		Email:     req.Email,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(magicLinkErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token" binding:"required" example:"3f1c2a9e-5b7d-4e8f-9a6b-1c2d3e4f5a6b"`
+}
+
+// VerifyMagicLink godoc
+// @Summary Sign in with a magic link
+// @Description Exchange a magic link token for access and refresh tokens. The token works once. If two-factor authentication is enabled or required for the user's role, returns two_factor_required and a challenge_token to complete at /login/2fa instead.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body VerifyMagicLinkRequest true "Magic link token"
+// @Success 200 {object} usecases.LoginOutput "Login successful or two-factor challenge"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Invalid or expired sign-in link"
+// @Failure 429 {object} map[string]interface{} "Too many requests"
+// @Router /magic-link/verify [post]
+func (h *MagicLinkHandler) VerifyMagicLink(c *gin.Context) {
+	var req VerifyMagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.verifyMagicLinkUseCase.Execute(c.Request.Context(), usecases.VerifyMagicLinkInput{
+		Token:     req.Token,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(magicLinkErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
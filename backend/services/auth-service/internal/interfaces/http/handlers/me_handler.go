@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+)
+
+type MeHandler struct {
+	getMeUseCase          *usecases.GetMeUseCase
+	updateMeUseCase       *usecases.UpdateMeUseCase
+	changePasswordUseCase *usecases.ChangePasswordUseCase
+	changeEmailUseCase    *usecases.ChangeEmailUseCase
+}
+
+func NewMeHandler(
+	getMeUseCase *usecases.GetMeUseCase,
+	updateMeUseCase *usecases.UpdateMeUseCase,
+	changePasswordUseCase *usecases.ChangePasswordUseCase,
+	changeEmailUseCase *usecases.ChangeEmailUseCase,
+) *MeHandler {
+	return &MeHandler{
+		getMeUseCase:          getMeUseCase,
+		updateMeUseCase:       updateMeUseCase,
+		changePasswordUseCase: changePasswordUseCase,
+		changeEmailUseCase:    changeEmailUseCase,
+	}
+}
+
+func meErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrUsernameRequired),
+		errors.Is(err, usecases.ErrEmailRequired),
+		errors.Is(err, usecases.ErrInvalidEmail),
+		errors.Is(err, usecases.ErrPasswordRequired),
+		errors.Is(err, usecases.ErrInvalidPassword),
+		errors.Is(err, usecases.ErrCurrentPasswordRequired),
+		errors.Is(err, usecases.ErrPasswordUnchanged),
+		errors.Is(err, usecases.ErrEmailUnchanged),
+		errors.Is(err, valueobjects.ErrDisplayNameTooLong),
+		errors.Is(err, valueobjects.ErrBioTooLong),
+		errors.Is(err, valueobjects.ErrInvalidAvatarID),
+		errors.Is(err, valueobjects.ErrInvalidTimezone),
+		errors.Is(err, valueobjects.ErrInvalidLocale),
+		errors.Is(err, valueobjects.ErrInvalidPhone):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrCurrentPasswordIncorrect),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrUsernameAlreadyExists),
+		errors.Is(err, usecases.ErrEmailAlreadyExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetMe godoc
+// @Summary Get own profile
+// @Description Get the profile of the signed in user.
+// @Tags me
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} usecases.GetMeOutput "Profile"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /me [get]
+func (h *MeHandler) GetMe(c *gin.Context) {
+	output, err := h.getMeUseCase.Execute(c.Request.Context(), usecases.GetMeInput{
+		AccessToken: bearerToken(c),
+	})
+	if err != nil {
+		c.JSON(meErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type UpdateMeRequest struct {
+	Username string `json:"username" example:"johndoe"`
+	// Profile fields are left unchanged when omitted and cleared when empty.
+	DisplayName *string `json:"display_name" example:"John Doe"`
+	Bio         *string `json:"bio" example:"Second year computer science student."`
+	AvatarID    *string `json:"avatar_id" example:"5f0c6f9e-3c1a-4d8e-9a55-0b6f8f3f2f10"`
+	Timezone    *string `json:"timezone" example:"Asia/Yangon"`
+	Locale      *string `json:"locale" example:"my-MM"`
+	Phone       *string `json:"phone" example:"+959123456789"`
+}
+
+// UpdateMe godoc
+// @Summary Update own profile
+// @Description Update the username and profile of the signed in user. Omitted fields are left unchanged. The avatar is the ID of an image uploaded to the user-avatars bucket. Email changes go through /me/email.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body UpdateMeRequest true "Profile fields"
+// @Success 200 {object} usecases.UpdateMeOutput "Updated profile"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or profile field"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Not allowed while impersonating"
+// @Failure 409 {object} map[string]interface{} "Username already exists"
+// @Router /me [patch]
+func (h *MeHandler) UpdateMe(c *gin.Context) {
+	var req UpdateMeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.updateMeUseCase.Execute(c.Request.Context(), usecases.UpdateMeInput{
+		AccessToken: bearerToken(c),
+		Username:    req.Username,
+		Profile: entities.ProfileUpdate{
+			DisplayName: req.DisplayName,
+			Bio:         req.Bio,
+			AvatarID:    req.AvatarID,
+			Timezone:    req.Timezone,
+			Locale:      req.Locale,
+			Phone:       req.Phone,
+		},
+	})
+	if err != nil {
+		c.JSON(meErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"OldPassword123!"`
+	NewPassword     string `json:"new_password" binding:"required" example:"NewPassword123!"`
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Change the signed in user's password. The current password is required, and every other session of the user is signed out.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} usecases.ChangePasswordOutput "Password changed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or new password"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Current password is incorrect or not allowed while impersonating"
+// @Router /me/password [post]
+func (h *MeHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.changePasswordUseCase.Execute(c.Request.Context(), usecases.ChangePasswordInput{
+		AccessToken:     bearerToken(c),
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		IPAddress:       c.ClientIP(),
+		UserAgent:       c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(meErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" binding:"required" example:"new@example.com"`
+	CurrentPassword string `json:"current_password" binding:"required" example:"Password123!"`
+}
+
+// ChangeEmail godoc
+// @Summary Change email
+// @Description Send a verification link to a new email address. The account switches to it once the link is opened.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body ChangeEmailRequest true "New email and current password"
+// @Success 200 {object} usecases.ChangeEmailOutput "Verification email sent"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or email"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Current password is incorrect or not allowed while impersonating"
+// @Failure 409 {object} map[string]interface{} "Email already exists"
+// @Router /me/email [post]
+func (h *MeHandler) ChangeEmail(c *gin.Context) {
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.changeEmailUseCase.Execute(c.Request.Context(), usecases.ChangeEmailInput{
+		AccessToken:     bearerToken(c),
+		NewEmail:        req.NewEmail,
+		CurrentPassword: req.CurrentPassword,
+	})
+	if err != nil {
+		c.JSON(meErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+)
+
+type OIDCHandler struct {
+	startOIDCLoginUseCase    *usecases.StartOIDCLoginUseCase
+	completeOIDCLoginUseCase *usecases.CompleteOIDCLoginUseCase
+}
+
+func NewOIDCHandler(
+	startOIDCLoginUseCase *usecases.StartOIDCLoginUseCase,
+	completeOIDCLoginUseCase *usecases.CompleteOIDCLoginUseCase,
+) *OIDCHandler {
+	return &OIDCHandler{
+		startOIDCLoginUseCase:    startOIDCLoginUseCase,
+		completeOIDCLoginUseCase: completeOIDCLoginUseCase,
+	}
+}
+
+func oidcErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrOIDCCodeRequired),
+		errors.Is(err, usecases.ErrOIDCStateRequired),
+		errors.Is(err, usecases.ErrInvalidOIDCState):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrOIDCLoginFailed),
+		errors.Is(err, usecases.ErrOIDCEmailNotVerified):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrOIDCEmailDomainNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrOIDCProviderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrOIDCProviderUnavailable):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// StartOIDCLogin godoc
+// @Summary Start sign in with an external provider
+// @Description Redirects to the provider's authorization page using the authorization code flow with PKCE. The provider returns to the configured frontend redirect URL with a code and state.
+// @Tags oidc
+// @Param provider path string true "Provider name, e.g. google"
+// @Success 302 "Redirect to the provider"
+// @Failure 404 {object} map[string]interface{} "Provider not configured"
+// @Failure 502 {object} map[string]interface{} "Provider unavailable"
+// @Router /oidc/{provider}/authorize [get]
+func (h *OIDCHandler) StartOIDCLogin(c *gin.Context) {
+	output, err := h.startOIDCLoginUseCase.Execute(c.Request.Context(), usecases.StartOIDCLoginInput{
+		Provider: c.Param("provider"),
+	})
+	if err != nil {
+		c.JSON(oidcErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Redirect(http.StatusFound, output.AuthorizationURL)
+}
+
+type CompleteOIDCLoginRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// CompleteOIDCLogin godoc
+// @Summary Finish sign in with an external provider
+// @Description Exchange the code and state the provider returned for access and refresh tokens. A first time sign in links the provider to the account with the same verified email, or creates a student. Users with two-factor authentication get a challenge token for /login/2fa instead.
+// @Tags oidc
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google"
+// @Param request body CompleteOIDCLoginRequest true "Code and state from the provider redirect"
+// @Success 200 {object} map[string]interface{} "Login successful"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or state"
+// @Failure 401 {object} map[string]interface{} "Provider sign in failed or email not verified"
+// @Failure 403 {object} map[string]interface{} "Email domain not allowed"
+// @Failure 404 {object} map[string]interface{} "Provider not configured"
+// @Router /oidc/{provider}/callback [post]
+func (h *OIDCHandler) CompleteOIDCLogin(c *gin.Context) {
+	var req CompleteOIDCLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.completeOIDCLoginUseCase.Execute(c.Request.Context(), usecases.CompleteOIDCLoginInput{
+		Provider:  c.Param("provider"),
+		Code:      req.Code,
+		State:     req.State,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
+	if err != nil {
+		c.JSON(oidcErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
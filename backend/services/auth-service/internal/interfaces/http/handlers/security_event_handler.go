@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+)
+
+type SecurityEventHandler struct {
+	listSecurityEventsUseCase *usecases.ListSecurityEventsUseCase
+}
+
+func NewSecurityEventHandler(listSecurityEventsUseCase *usecases.ListSecurityEventsUseCase) *SecurityEventHandler {
+	return &SecurityEventHandler{
+		listSecurityEventsUseCase: listSecurityEventsUseCase,
+	}
+}
+
+func securityEventErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrInsufficientPermissions),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListMySecurityEvents godoc
+// @Summary List own security events
+// @Description List logins, failed logins, password resets, two factor changes and revoked sessions of the signed in user, newest first.
+// @Tags me
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param limit query int false "Maximum number of events, up to 100" default(50)
+// @Success 200 {object} usecases.ListSecurityEventsOutput "Security events"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /me/security-events [get]
+func (h *SecurityEventHandler) ListMySecurityEvents(c *gin.Context) {
+	h.listSecurityEvents(c, "")
+}
+
+// ListUserSecurityEvents godoc
+// @Summary List a user's security events
+// @Description List the security events of any user, newest first. Admin only.
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "User ID"
+// @Param limit query int false "Maximum number of events, up to 100" default(50)
+// @Success 200 {object} usecases.ListSecurityEventsOutput "Security events"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /users/{id}/security-events [get]
+func (h *SecurityEventHandler) ListUserSecurityEvents(c *gin.Context) {
+	h.listSecurityEvents(c, c.Param("id"))
+}
+
+func (h *SecurityEventHandler) listSecurityEvents(c *gin.Context, userID string) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(400, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	output, err := h.listSecurityEventsUseCase.Execute(c.Request.Context(), usecases.ListSecurityEventsInput{
+		AccessToken: bearerToken(c),
+		UserID:      userID,
+		Limit:       limit,
+	})
+	if err != nil {
+		c.JSON(securityEventErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+)
+
+type ServiceAccountHandler struct {
+	createServiceAccountUseCase  *usecases.CreateServiceAccountUseCase
+	listServiceAccountsUseCase   *usecases.ListServiceAccountsUseCase
+	disableServiceAccountUseCase *usecases.DisableServiceAccountUseCase
+	createAPIKeyUseCase          *usecases.CreateAPIKeyUseCase
+	listAPIKeysUseCase           *usecases.ListAPIKeysUseCase
+	revokeAPIKeyUseCase          *usecases.RevokeAPIKeyUseCase
+}
+
+func NewServiceAccountHandler(
+	createServiceAccountUseCase *usecases.CreateServiceAccountUseCase,
+	listServiceAccountsUseCase *usecases.ListServiceAccountsUseCase,
+	disableServiceAccountUseCase *usecases.DisableServiceAccountUseCase,
+	createAPIKeyUseCase *usecases.CreateAPIKeyUseCase,
+	listAPIKeysUseCase *usecases.ListAPIKeysUseCase,
+	revokeAPIKeyUseCase *usecases.RevokeAPIKeyUseCase,
+) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		createServiceAccountUseCase:  createServiceAccountUseCase,
+		listServiceAccountsUseCase:   listServiceAccountsUseCase,
+		disableServiceAccountUseCase: disableServiceAccountUseCase,
+		createAPIKeyUseCase:          createAPIKeyUseCase,
+		listAPIKeysUseCase:           listAPIKeysUseCase,
+		revokeAPIKeyUseCase:          revokeAPIKeyUseCase,
+	}
+}
+
+func serviceAccountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrServiceAccountNameRequired),
+		errors.Is(err, usecases.ErrAPIKeyNameRequired),
+		errors.Is(err, usecases.ErrAPIKeyScopesRequired),
+		errors.Is(err, usecases.ErrInvalidAPIKeyScope),
+		errors.Is(err, usecases.ErrInvalidAPIKeyExpiry),
+		errors.Is(err, usecases.ErrServiceAccountOrganizationRequired),
+		errors.Is(err, valueobjects.ErrInvalidRole):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrTokenRequired):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrInsufficientPermissions),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrServiceAccountNotFound),
+		errors.Is(err, usecases.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrServiceAccountAlreadyExists),
+		errors.Is(err, usecases.ErrServiceAccountDisabled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type CreateServiceAccountRequest struct {
+	Name        string `json:"name" binding:"required" example:"sis-integration"`
+	Description string `json:"description" example:"Nightly enrollment sync from the SIS"`
+	Role        string `json:"role" binding:"required" example:"admin"`
+	// OrganizationID is only honoured for super-admins; an organization's
+	// admins always create accounts in their own.
+	OrganizationID *string `json:"organization_id,omitempty" example:"5f1c1c0e-8a7e-4d8e-9a55-0c5d2f1b7a10"`
+}
+
+// CreateServiceAccount godoc
+// @Summary Create a service account
+// @Description Register a non-human principal that authenticates with API keys. Its role decides which gateway routes its keys can reach. Admin only.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body CreateServiceAccountRequest true "Service account details"
+// @Success 201 {object} usecases.ServiceAccountOutput "Service account created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or role"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Service account already exists"
+// @Router /service-accounts [post]
+func (h *ServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	var req CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.createServiceAccountUseCase.Execute(c.Request.Context(), usecases.CreateServiceAccountInput{
+		AccessToken:    bearerToken(c),
+		Name:           req.Name,
+		Description:    req.Description,
+		Role:           req.Role,
+		OrganizationID: req.OrganizationID,
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Description List all service accounts, including disabled ones. Admin only.
+// @Tags service-accounts
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} usecases.ListServiceAccountsOutput "Service accounts"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Router /service-accounts [get]
+func (h *ServiceAccountHandler) ListServiceAccounts(c *gin.Context) {
+	output, err := h.listServiceAccountsUseCase.Execute(c.Request.Context(), usecases.ListServiceAccountsInput{
+		AccessToken: bearerToken(c),
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// DisableServiceAccount godoc
+// @Summary Disable a service account
+// @Description Disable a service account and revoke all of its API keys. Admin only.
+// @Tags service-accounts
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Service account ID"
+// @Success 200 {object} usecases.ServiceAccountOutput "Service account disabled"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Router /service-accounts/{id} [delete]
+func (h *ServiceAccountHandler) DisableServiceAccount(c *gin.Context) {
+	output, err := h.disableServiceAccountUseCase.Execute(c.Request.Context(), usecases.DisableServiceAccountInput{
+		AccessToken:      bearerToken(c),
+		ServiceAccountID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required" example:"nightly-sync"`
+	Scopes    []string   `json:"scopes" binding:"required" example:"users:read,enrollments:write"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2026-12-31T00:00:00Z"`
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Issue an API key for a service account. Scopes are "*" or <resource>:<read|write|*>, where resource is the path segment after /api/v1. The key is only shown in this response. Admin only.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Service account ID"
+// @Param request body CreateAPIKeyRequest true "API key details"
+// @Success 201 {object} usecases.CreateAPIKeyOutput "API key created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, scope or expiry"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Failure 409 {object} map[string]interface{} "Service account is disabled"
+// @Router /service-accounts/{id}/api-keys [post]
+func (h *ServiceAccountHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.createAPIKeyUseCase.Execute(c.Request.Context(), usecases.CreateAPIKeyInput{
+		AccessToken:      bearerToken(c),
+		ServiceAccountID: c.Param("id"),
+		Name:             req.Name,
+		Scopes:           req.Scopes,
+		ExpiresAt:        req.ExpiresAt,
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(201, output)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description List a service account's API keys with their scopes, expiry and last use. Secrets are never returned. Admin only.
+// @Tags service-accounts
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Service account ID"
+// @Success 200 {object} usecases.ListAPIKeysOutput "API keys"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Router /service-accounts/{id}/api-keys [get]
+func (h *ServiceAccountHandler) ListAPIKeys(c *gin.Context) {
+	output, err := h.listAPIKeysUseCase.Execute(c.Request.Context(), usecases.ListAPIKeysInput{
+		AccessToken:      bearerToken(c),
+		ServiceAccountID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key immediately. Admin only.
+// @Tags service-accounts
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param id path string true "Service account ID"
+// @Param key_id path string true "API key ID"
+// @Success 200 {object} map[string]interface{} "API key revoked successfully"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "API key not found"
+// @Router /service-accounts/{id}/api-keys/{key_id} [delete]
+func (h *ServiceAccountHandler) RevokeAPIKey(c *gin.Context) {
+	output, err := h.revokeAPIKeyUseCase.Execute(c.Request.Context(), usecases.RevokeAPIKeyInput{
+		AccessToken:      bearerToken(c),
+		ServiceAccountID: c.Param("id"),
+		APIKeyID:         c.Param("key_id"),
+	})
+	if err != nil {
+		c.JSON(serviceAccountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecases "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+)
+
+type TwoFactorHandler struct {
+	loginTwoFactorUseCase          *usecases.LoginTwoFactorUseCase
+	setupTwoFactorUseCase          *usecases.SetupTwoFactorUseCase
+	enableTwoFactorUseCase         *usecases.EnableTwoFactorUseCase
+	disableTwoFactorUseCase        *usecases.DisableTwoFactorUseCase
+	regenerateRecoveryCodesUseCase *usecases.RegenerateRecoveryCodesUseCase
+	getTwoFactorStatusUseCase      *usecases.GetTwoFactorStatusUseCase
+}
+
+func NewTwoFactorHandler(
+	loginTwoFactorUseCase *usecases.LoginTwoFactorUseCase,
+	setupTwoFactorUseCase *usecases.SetupTwoFactorUseCase,
+	enableTwoFactorUseCase *usecases.EnableTwoFactorUseCase,
+	disableTwoFactorUseCase *usecases.DisableTwoFactorUseCase,
+	regenerateRecoveryCodesUseCase *usecases.RegenerateRecoveryCodesUseCase,
+	getTwoFactorStatusUseCase *usecases.GetTwoFactorStatusUseCase,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		loginTwoFactorUseCase:          loginTwoFactorUseCase,
+		setupTwoFactorUseCase:          setupTwoFactorUseCase,
+		enableTwoFactorUseCase:         enableTwoFactorUseCase,
+		disableTwoFactorUseCase:        disableTwoFactorUseCase,
+		regenerateRecoveryCodesUseCase: regenerateRecoveryCodesUseCase,
+		getTwoFactorStatusUseCase:      getTwoFactorStatusUseCase,
+	}
+}
+
+func twoFactorErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrTokenRequired),
+		errors.Is(err, usecases.ErrTwoFactorCodeRequired),
+		errors.Is(err, usecases.ErrTwoFactorNotSetUp),
+		errors.Is(err, usecases.ErrTwoFactorNotEnabled):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrUnauthorized),
+		errors.Is(err, usecases.ErrInvalidTwoFactorCode),
+		errors.Is(err, usecases.ErrInvalidTwoFactorChallenge),
+		errors.Is(err, usecases.ErrInvalidPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecases.ErrTwoFactorRequiredForRole),
+		errors.Is(err, usecases.ErrNotAllowedWhileImpersonating):
+		return http.StatusForbidden
+	case errors.Is(err, usecases.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrTwoFactorAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, usecases.ErrTooManyTwoFactorAttempts):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" example:"123456"`
+	RecoveryCode   string `json:"recovery_code" example:"abcde-fghij"`
+}
+
+// LoginTwoFactor godoc
+// @Summary Complete two-factor login
+// @Description Exchange the challenge token from /login and a TOTP or recovery code for access and refresh tokens. When finishing a forced enrollment, recovery codes are included in the response.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param request body LoginTwoFactorRequest true "Challenge token and code"
+// @Success 200 {object} map[string]interface{} "Login successful"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or code missing"
+// @Failure 401 {object} map[string]interface{} "Invalid code or challenge"
+// @Failure 429 {object} map[string]interface{} "Too many attempts"
+// @Router /login/2fa [post]
+func (h *TwoFactorHandler) LoginTwoFactor(c *gin.Context) {
+	var req LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	input := usecases.LoginTwoFactorInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		RecoveryCode:   req.RecoveryCode,
+		IPAddress:      c.ClientIP(),
+		UserAgent:      c.GetHeader("User-Agent"),
+	}
+
+	output, err := h.loginTwoFactorUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type SetupTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+// SetupTwoFactorLogin godoc
+// @Summary Start forced two-factor enrollment
+// @Description For users whose role requires two-factor authentication but who have not enrolled yet. Returns a TOTP secret and otpauth URI for the challenge token returned by /login.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param request body SetupTwoFactorLoginRequest true "Challenge token"
+// @Success 200 {object} map[string]interface{} "Secret and otpauth URI"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Invalid challenge"
+// @Router /login/2fa/setup [post]
+func (h *TwoFactorHandler) SetupTwoFactorLogin(c *gin.Context) {
+	var req SetupTwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.setupTwoFactorUseCase.Execute(c.Request.Context(), usecases.SetupTwoFactorInput{
+		ChallengeToken: req.ChallengeToken,
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// GetTwoFactorStatus godoc
+// @Summary Two-factor status
+// @Description Whether two-factor authentication is enabled or required for the current user, and how many recovery codes are left
+// @Tags two-factor
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Two-factor status"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Router /2fa [get]
+func (h *TwoFactorHandler) GetTwoFactorStatus(c *gin.Context) {
+	output, err := h.getTwoFactorStatusUseCase.Execute(c.Request.Context(), usecases.GetTwoFactorStatusInput{
+		AccessToken: bearerToken(c),
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+// SetupTwoFactor godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret for the current user. Two-factor authentication is not enforced until it is confirmed with /2fa/enable.
+// @Tags two-factor
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Success 200 {object} map[string]interface{} "Secret and otpauth URI"
+// @Failure 401 {object} map[string]interface{} "Token is invalid or expired"
+// @Failure 409 {object} map[string]interface{} "Two-factor authentication is already enabled"
+// @Router /2fa/setup [post]
+func (h *TwoFactorHandler) SetupTwoFactor(c *gin.Context) {
+	output, err := h.setupTwoFactorUseCase.Execute(c.Request.Context(), usecases.SetupTwoFactorInput{
+		AccessToken: bearerToken(c),
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// EnableTwoFactor godoc
+// @Summary Confirm two-factor enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app. Returns recovery codes, which are only shown once.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{} "Recovery codes"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or setup not started"
+// @Failure 401 {object} map[string]interface{} "Invalid code or token"
+// @Failure 409 {object} map[string]interface{} "Two-factor authentication is already enabled"
+// @Router /2fa/enable [post]
+func (h *TwoFactorHandler) EnableTwoFactor(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.enableTwoFactorUseCase.Execute(c.Request.Context(), usecases.EnableTwoFactorInput{
+		AccessToken: bearerToken(c),
+		Code:        req.Code,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
+
+type DisableTwoFactorRequest struct {
+	Password     string `json:"password" binding:"required" example:"Password@123"`
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"abcde-fghij"`
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Turn off two-factor authentication. Requires the password and a TOTP or recovery code. Not allowed for roles that require two-factor authentication.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body DisableTwoFactorRequest true "Password and code"
+// @Success 200 {object} map[string]interface{} "Two-factor authentication disabled"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Invalid password, code or token"
+// @Failure 403 {object} map[string]interface{} "Required for this role"
+// @Router /2fa/disable [post]
+func (h *TwoFactorHandler) DisableTwoFactor(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	err := h.disableTwoFactorUseCase.Execute(c.Request.Context(), usecases.DisableTwoFactorInput{
+		AccessToken:  bearerToken(c),
+		Password:     req.Password,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		IPAddress:    c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes with a new set. Requires a current TOTP code.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer )
+// @Param request body TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} map[string]interface{} "Recovery codes"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or not enabled"
+// @Failure 401 {object} map[string]interface{} "Invalid code or token"
+// @Router /2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	output, err := h.regenerateRecoveryCodesUseCase.Execute(c.Request.Context(), usecases.RegenerateRecoveryCodesInput{
+		AccessToken: bearerToken(c),
+		Code:        req.Code,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.JSON(twoFactorErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, output)
+}
@@ -3,14 +3,16 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/interfaces/http/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	_ "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/cmd/docs"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, logger *logger.Logger) {
+func SetupRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, twoFactorHandler *handlers.TwoFactorHandler, oidcHandler *handlers.OIDCHandler, serviceAccountHandler *handlers.ServiceAccountHandler, impersonationHandler *handlers.ImpersonationHandler, meHandler *handlers.MeHandler, invitationHandler *handlers.InvitationHandler, magicLinkHandler *handlers.MagicLinkHandler, securityEventHandler *handlers.SecurityEventHandler, redis utils.RedisInterface, rateLimit config.RateLimitConfig, logger *logger.Logger) {
 
 	// Middleware
 	router.Use(middleware.RequestID())
@@ -19,22 +21,74 @@ func SetupRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, logger *
 	router.Use(middleware.CORS())
 	router.Use(gin.Recovery())
 	router.GET("/health", authHandler.Health)
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
 
 	// Swagger documentation
 	router.GET("/api/v1/auth/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
+	// Credential endpoints share a tight per IP limit. /verify is left out as
+	// the gateway calls it on every request.
+	credentialLimit := middleware.RateLimit(redis, middleware.RateLimitPolicy{
+		Name:    "auth",
+		Config:  rateLimit,
+		KeyFunc: middleware.RateLimitByIP,
+	}, logger)
+
 	// Routes
 	api := router.Group("/api/v1")
 	auth := api.Group("/auth")
 	{
-		auth.POST("/register", authHandler.RegisterStudent)
-		auth.POST("/login", authHandler.Login)
-		auth.POST("/forgot-password", authHandler.ForgotPassword)
-		auth.POST("/verify-otp", authHandler.VerifyOTP)
-		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.POST("/register", credentialLimit, authHandler.RegisterStudent)
+		auth.POST("/login", credentialLimit, authHandler.Login)
+		auth.POST("/forgot-password", credentialLimit, authHandler.ForgotPassword)
+		auth.POST("/verify-otp", credentialLimit, authHandler.VerifyOTP)
+		auth.POST("/reset-password", credentialLimit, authHandler.ResetPassword)
 		auth.GET("/verify", authHandler.Verify)
 		auth.GET("/verify-email", authHandler.VerifyEmail)
-		auth.POST("/request-email-verify", authHandler.RequestEmailVerify)
+		auth.POST("/request-email-verify", credentialLimit, authHandler.RequestEmailVerify)
 		auth.POST("/refresh-token", authHandler.RefreshToken)
+		auth.POST("/logout", authHandler.Logout)
+		auth.POST("/logout-all", authHandler.LogoutAll)
+		auth.GET("/sessions", authHandler.ListSessions)
+		auth.DELETE("/sessions/:id", authHandler.RevokeSession)
+		auth.DELETE("/lockouts/:user_id", authHandler.UnlockAccount)
+
+		auth.POST("/magic-link", credentialLimit, magicLinkHandler.RequestMagicLink)
+		auth.POST("/magic-link/verify", credentialLimit, magicLinkHandler.VerifyMagicLink)
+
+		auth.POST("/login/2fa", credentialLimit, twoFactorHandler.LoginTwoFactor)
+		auth.POST("/login/2fa/setup", credentialLimit, twoFactorHandler.SetupTwoFactorLogin)
+		auth.GET("/2fa", twoFactorHandler.GetTwoFactorStatus)
+		auth.POST("/2fa/setup", twoFactorHandler.SetupTwoFactor)
+		auth.POST("/2fa/enable", twoFactorHandler.EnableTwoFactor)
+		auth.POST("/2fa/disable", twoFactorHandler.DisableTwoFactor)
+		auth.POST("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+
+		auth.GET("/oidc/:provider/authorize", credentialLimit, oidcHandler.StartOIDCLogin)
+		auth.POST("/oidc/:provider/callback", credentialLimit, oidcHandler.CompleteOIDCLogin)
+
+		auth.POST("/service-accounts", serviceAccountHandler.CreateServiceAccount)
+		auth.GET("/service-accounts", serviceAccountHandler.ListServiceAccounts)
+		auth.DELETE("/service-accounts/:id", serviceAccountHandler.DisableServiceAccount)
+		auth.POST("/service-accounts/:id/api-keys", serviceAccountHandler.CreateAPIKey)
+		auth.GET("/service-accounts/:id/api-keys", serviceAccountHandler.ListAPIKeys)
+		auth.DELETE("/service-accounts/:id/api-keys/:key_id", serviceAccountHandler.RevokeAPIKey)
+
+		auth.POST("/impersonate", impersonationHandler.StartImpersonation)
+		auth.POST("/impersonate/stop", impersonationHandler.StopImpersonation)
+
+		auth.GET("/me", meHandler.GetMe)
+		auth.PATCH("/me", meHandler.UpdateMe)
+		auth.POST("/me/password", credentialLimit, meHandler.ChangePassword)
+		auth.POST("/me/email", credentialLimit, meHandler.ChangeEmail)
+		auth.GET("/me/security-events", securityEventHandler.ListMySecurityEvents)
+
+		auth.GET("/users/:id/security-events", securityEventHandler.ListUserSecurityEvents)
+
+		auth.POST("/invitations", invitationHandler.CreateInvitation)
+		auth.GET("/invitations", invitationHandler.ListInvitations)
+		auth.POST("/invitations/accept", credentialLimit, invitationHandler.AcceptInvitation)
+		auth.POST("/invitations/:id/resend", invitationHandler.ResendInvitation)
+		auth.DELETE("/invitations/:id", invitationHandler.RevokeInvitation)
 	}
 }
\ No newline at end of file
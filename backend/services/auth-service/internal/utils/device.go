@@ -0,0 +1,62 @@
+package utils
+
+import "strings"
+
+var browserSignatures = []struct {
+	token string
+	name  string
+}{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+var platformSignatures = []struct {
+	token string
+	name  string
+}{
+	{"Android", "Android"},
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
+
+// DescribeDevice turns a User-Agent header into a short label such as
+// "Chrome on Windows" for display in the session list.
+func DescribeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	for _, sig := range browserSignatures {
+		if strings.Contains(userAgent, sig.token) {
+			browser = sig.name
+			break
+		}
+	}
+
+	platform := ""
+	for _, sig := range platformSignatures {
+		if strings.Contains(userAgent, sig.token) {
+			platform = sig.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
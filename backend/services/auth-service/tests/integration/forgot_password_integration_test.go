@@ -22,6 +22,8 @@ func TestForgotPassword_Integration_Success(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	ctx := context.Background()
 
@@ -35,7 +37,7 @@ func TestForgotPassword_Integration_Success(t *testing.T) {
 
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserForgotPassword, mock.Anything).Return(nil).Once()
 
-	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger)
+	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger, redis, setupLockoutConfig())
 
 	input := usecases.ForgotPasswordInput{
 		Email: "forgot@example.com",
@@ -63,8 +65,10 @@ func TestForgotPassword_Integration_UserNotFound(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger)
+	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger, redis, setupLockoutConfig())
 
 	input := usecases.ForgotPasswordInput{
 		Email: "nonexistent@example.com",
@@ -85,8 +89,10 @@ func TestForgotPassword_Integration_InvalidEmail(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger)
+	forgotPasswordUC := usecases.NewForgotPasswordUseCase(userRepo, publisher, logger, redis, setupLockoutConfig())
 
 	input := usecases.ForgotPasswordInput{
 		Email: "not-an-email",
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
@@ -19,10 +20,12 @@ func TestLogin_Integration_Success(t *testing.T) {
 	defer cleanup()
 
 	userRepo := setupUserRepo(t, db)
+	twoFactorRepo := setupTwoFactorRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
 	jwtManager := setupJwtManager()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	ctx := context.Background()
 
@@ -37,9 +40,10 @@ func TestLogin_Integration_Success(t *testing.T) {
 	redis.On("StoreAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	redis.On("StoreRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	redis.On("StoreUserSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
-	loginUC := usecases.NewLoginUseCase(userRepo, publisher, logger, jwtManager, redis)
+	loginUC := usecases.NewLoginUseCase(userRepo, twoFactorRepo, setupSecurityEventRepo(t, db), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, setupLockoutConfig())
 
 	input := usecases.LoginInput{
 		Email:     "login@example.com",
@@ -66,10 +70,12 @@ func TestLogin_Integration_InvalidPassword(t *testing.T) {
 	defer cleanup()
 
 	userRepo := setupUserRepo(t, db)
+	twoFactorRepo := setupTwoFactorRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
 	jwtManager := setupJwtManager()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	ctx := context.Background()
 
@@ -81,7 +87,7 @@ func TestLogin_Integration_InvalidPassword(t *testing.T) {
 	err := userRepo.Create(ctx, user)
 	require.NoError(t, err)
 
-	loginUC := usecases.NewLoginUseCase(userRepo, publisher, logger, jwtManager, redis)
+	loginUC := usecases.NewLoginUseCase(userRepo, twoFactorRepo, setupSecurityEventRepo(t, db), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, setupLockoutConfig())
 
 	input := usecases.LoginInput{
 		Email:     "login2@example.com",
@@ -103,12 +109,14 @@ func TestLogin_Integration_UserNotFound(t *testing.T) {
 	defer cleanup()
 
 	userRepo := setupUserRepo(t, db)
+	twoFactorRepo := setupTwoFactorRepo(t, db)
 	publisher := new(mocks.MockPublisher)
 	logger := setupTestLogger()
 	jwtManager := setupJwtManager()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	loginUC := usecases.NewLoginUseCase(userRepo, publisher, logger, jwtManager, redis)
+	loginUC := usecases.NewLoginUseCase(userRepo, twoFactorRepo, setupSecurityEventRepo(t, db), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, setupLockoutConfig())
 
 	input := usecases.LoginInput{
 		Email:     "nonexistent@example.com",
@@ -39,7 +39,7 @@ func TestResetPassword_Integration_Success(t *testing.T) {
 	redis.On("RevokeResetPasswordToken", mock.Anything, token).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserResetPassword, mock.Anything).Return(nil).Once()
 
-	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, publisher, logger, redis)
+	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, setupSecurityEventRepo(t, db), publisher, logger, redis)
 
 	input := usecases.ResetPasswordInput{
 		Token:      token,
@@ -73,7 +73,7 @@ func TestResetPassword_Integration_InvalidToken(t *testing.T) {
 
 	redis.On("GetUserFromResetPasswordToken", mock.Anything, "invalid-token").Return("", nil).Once()
 
-	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, publisher, logger, redis)
+	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, setupSecurityEventRepo(t, db), publisher, logger, redis)
 
 	input := usecases.ResetPasswordInput{
 		Token:      "invalid-token",
@@ -112,7 +112,7 @@ func TestResetPassword_Integration_InvalidPassword(t *testing.T) {
 	token := "valid-token"
 	redis.On("GetUserFromResetPasswordToken", mock.Anything, token).Return(user.ID, nil).Once()
 
-	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, publisher, logger, redis)
+	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, setupSecurityEventRepo(t, db), publisher, logger, redis)
 
 	input := usecases.ResetPasswordInput{
 		Token:      token,
@@ -145,7 +145,7 @@ func TestResetPassword_Integration_UserNotFound(t *testing.T) {
 	redis.On("GetUserFromResetPasswordToken", mock.Anything, token).Return(nonExistentUserID, nil).Once()
 	redis.On("RevokeResetPasswordToken", mock.Anything, token).Return(nil).Once()
 
-	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, publisher, logger, redis)
+	resetPasswordUC := usecases.NewResetPasswordUseCase(userRepo, setupSecurityEventRepo(t, db), publisher, logger, redis)
 
 	input := usecases.ResetPasswordInput{
 		Token:      token,
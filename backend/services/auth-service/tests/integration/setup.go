@@ -10,7 +10,7 @@ import (
 func SetupTestDB(t *testing.T) (*sql.DB, func()) {
 	cfg := integration.TestDatabaseConfig{
 		MigrationPath:  "../user-service/migrations",
-		TablesToCleanUp: []string{"users"},
+		TablesToCleanUp: []string{"users", "organizations"},
 	}
 
 	db, cleanup, err := integration.SetUpTestDatabase(t, cfg)
@@ -3,11 +3,19 @@ package integration
 import (
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authPostgres "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	sharedIntegration "github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestDB(t *testing.T) (*sql.DB, func()) {
@@ -19,6 +27,23 @@ func setupUserRepo(t *testing.T, db *sql.DB) *postgres.PostgresUserRepository {
 	return repo.(*postgres.PostgresUserRepository)
 }
 
+func setupTwoFactorRepo(t *testing.T, db *sql.DB) repositories.TwoFactorRepository {
+	return authPostgres.NewPostgresTwoFactorRepository(db)
+}
+
+func setupSecurityEventRepo(t *testing.T, db *sql.DB) repositories.SecurityEventRepository {
+	return authPostgres.NewPostgresSecurityEventRepository(db)
+}
+
+// createTestOrganization adds an organization for users that are not
+// super-admins to belong to.
+func createTestOrganization(t *testing.T, db *sql.DB) string {
+	organizationID := uuid.NewString()
+	_, err := db.Exec(`INSERT INTO organizations (id, name, created_by) VALUES ($1, $2, $3)`, organizationID, "Riverside High", uuid.NewString())
+	require.NoError(t, err)
+	return organizationID
+}
+
 func setupTestLogger() *logger.Logger {
 	return sharedIntegration.SetupTestLogger()
 }
@@ -27,3 +52,23 @@ func setupJwtManager() *utils.JwtManager {
 	return sharedIntegration.SetupJwtManager()
 }
 
+
+func setupLockoutConfig() config.LockoutConfig {
+	return config.LockoutConfig{
+		MaxAccountFailures: 10,
+		MaxIPFailures:      50,
+		FailureWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		DelayAfter:         3,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+		OTPMaxAttempts:     5,
+	}
+}
+
+// allowAttempts lets the brute-force counters run without any lockout.
+func allowAttempts(redis *mocks.MockRedis) {
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil).Maybe()
+	redis.On("IncrementAuthFailures", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Maybe()
+	redis.On("ResetAuthFailures", mock.Anything, mock.Anything).Return(nil).Maybe()
+}
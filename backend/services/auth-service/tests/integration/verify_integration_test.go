@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
@@ -32,16 +34,18 @@ func TestVerify_Integration_Success(t *testing.T) {
 	passwordHash, _ := utils.HashPassword("Password123!")
 	user := entities.NewUser(email, "verifyuser", role, passwordHash)
 	user.Status = activeStatus
+	organizationID := createTestOrganization(t, db)
+	user.MoveToOrganization(&organizationID)
 
 	err := userRepo.Create(ctx, user)
 	require.NoError(t, err)
 
-	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
+	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", organizationID)
 	require.NoError(t, err)
 
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
 
-	verifyUC := usecases.NewVerifyUseCase(userRepo, publisher, logger, jwtManager, redis)
+	verifyUC := usecases.NewVerifyUseCase(userRepo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	input := usecases.VerifyInput{
 		Token:        accessToken,
@@ -77,16 +81,18 @@ func TestVerify_Integration_InsufficientPermissions(t *testing.T) {
 	passwordHash, _ := utils.HashPassword("Password123!")
 	user := entities.NewUser(email, "verifyuser2", role, passwordHash)
 	user.Status = activeStatus
+	organizationID := createTestOrganization(t, db)
+	user.MoveToOrganization(&organizationID)
 
 	err := userRepo.Create(ctx, user)
 	require.NoError(t, err)
 
-	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
+	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", organizationID)
 	require.NoError(t, err)
 
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
 
-	verifyUC := usecases.NewVerifyUseCase(userRepo, publisher, logger, jwtManager, redis)
+	verifyUC := usecases.NewVerifyUseCase(userRepo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	input := usecases.VerifyInput{
 		Token:        accessToken,
@@ -110,7 +116,7 @@ func TestVerify_Integration_InvalidToken(t *testing.T) {
 	jwtManager := setupJwtManager()
 	redis := new(mocks.MockRedis)
 
-	verifyUC := usecases.NewVerifyUseCase(userRepo, publisher, logger, jwtManager, redis)
+	verifyUC := usecases.NewVerifyUseCase(userRepo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	input := usecases.VerifyInput{
 		Token:        "invalid-token",
@@ -146,12 +152,12 @@ func TestVerify_Integration_InactiveUser(t *testing.T) {
 	err := userRepo.Create(ctx, user)
 	require.NoError(t, err)
 
-	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
+	accessToken, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", "")
 	require.NoError(t, err)
 
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
 
-	verifyUC := usecases.NewVerifyUseCase(userRepo, publisher, logger, jwtManager, redis)
+	verifyUC := usecases.NewVerifyUseCase(userRepo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	input := usecases.VerifyInput{
 		Token:        accessToken,
@@ -21,6 +21,7 @@ func TestVerifyOTP_Integration_Success(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	logger := setupTestLogger()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	ctx := context.Background()
 
@@ -33,11 +34,10 @@ func TestVerifyOTP_Integration_Success(t *testing.T) {
 	require.NoError(t, err)
 
 	otp := "123456"
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, otp).Return(user.ID, nil).Once()
-	redis.On("RevokeForgotPasswordOTP", mock.Anything, otp).Return(nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, otp, 5).Return(nil).Once()
 	redis.On("StoreResetPasswordToken", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
 
-	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, logger, redis)
+	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, new(mocks.MockPublisher), logger, redis, setupLockoutConfig())
 
 	input := usecases.VerifyOTPInput{
 		Email: "otp@example.com",
@@ -60,17 +60,28 @@ func TestVerifyOTP_Integration_InvalidOTP(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	logger := setupTestLogger()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "invalid-otp").Return("", nil).Once()
+	ctx := context.Background()
+
+	email, _ := valueobjects.NewEmail("otp3@example.com")
+	role, _ := valueobjects.NewRole("student")
+	passwordHash, _ := utils.HashPassword("Password123!")
+	user := entities.NewUser(email, "otpuser3", role, passwordHash)
+
+	err := userRepo.Create(ctx, user)
+	require.NoError(t, err)
+
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, "invalid-otp", 5).Return(utils.ErrOTPInvalid).Once()
 
-	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, logger, redis)
+	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, new(mocks.MockPublisher), logger, redis, setupLockoutConfig())
 
 	input := usecases.VerifyOTPInput{
-		Email: "otp@example.com",
+		Email: "otp3@example.com",
 		OTP:   "invalid-otp",
 	}
 
-	result, err := verifyOTPUC.Execute(context.Background(), input)
+	result, err := verifyOTPUC.Execute(ctx, input)
 
 	require.ErrorIs(t, err, usecases.ErrInvalidOTP)
 	assert.False(t, result.IsValid)
@@ -85,6 +96,7 @@ func TestVerifyOTP_Integration_EmailMismatch(t *testing.T) {
 	userRepo := setupUserRepo(t, db)
 	logger := setupTestLogger()
 	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	ctx := context.Background()
 
@@ -97,9 +109,8 @@ func TestVerifyOTP_Integration_EmailMismatch(t *testing.T) {
 	require.NoError(t, err)
 
 	otp := "123456"
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, otp).Return(user.ID, nil).Once()
 
-	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, logger, redis)
+	verifyOTPUC := usecases.NewVerifyOTPUseCase(userRepo, new(mocks.MockPublisher), logger, redis, setupLockoutConfig())
 
 	input := usecases.VerifyOTPInput{
 		Email: "different@example.com",
@@ -111,6 +122,5 @@ func TestVerifyOTP_Integration_EmailMismatch(t *testing.T) {
 	require.ErrorIs(t, err, usecases.ErrInvalidOTP)
 	assert.False(t, result.IsValid)
 
-	redis.AssertExpectations(t)
+	redis.AssertNotCalled(t, "VerifyForgotPasswordOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
-
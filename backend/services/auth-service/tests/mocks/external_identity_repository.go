@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExternalIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockExternalIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entities.ExternalIdentity, error) {
+	args := m.Called(ctx, provider, subject)
+	identity, _ := args.Get(0).(*entities.ExternalIdentity)
+	return identity, args.Error(1)
+}
+
+func (m *MockExternalIdentityRepository) Create(ctx context.Context, identity *entities.ExternalIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
+func (m *MockExternalIdentityRepository) Update(ctx context.Context, identity *entities.ExternalIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *entities.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) FindByID(ctx context.Context, id string) (*entities.Invitation, error) {
+	args := m.Called(ctx, id)
+	invitation, _ := args.Get(0).(*entities.Invitation)
+	return invitation, args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*entities.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	invitation, _ := args.Get(0).(*entities.Invitation)
+	return invitation, args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindOpenByEmail(ctx context.Context, email string) (*entities.Invitation, error) {
+	args := m.Called(ctx, email)
+	invitation, _ := args.Get(0).(*entities.Invitation)
+	return invitation, args.Error(1)
+}
+
+func (m *MockInvitationRepository) ListOpen(ctx context.Context) ([]*entities.Invitation, error) {
+	args := m.Called(ctx)
+	invitations, _ := args.Get(0).([]*entities.Invitation)
+	return invitations, args.Error(1)
+}
+
+func (m *MockInvitationRepository) Update(ctx context.Context, invitation *entities.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSecurityEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockSecurityEventRepository) Create(ctx context.Context, event *entities.SecurityEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockSecurityEventRepository) ListByUserID(ctx context.Context, userID string, limit int) ([]*entities.SecurityEvent, error) {
+	args := m.Called(ctx, userID, limit)
+	events, _ := args.Get(0).([]*entities.SecurityEvent)
+	return events, args.Error(1)
+}
+
+func (m *MockSecurityEventRepository) FindLoginSource(ctx context.Context, userID, ipAddress, device string) (*entities.LoginSource, error) {
+	args := m.Called(ctx, userID, ipAddress, device)
+	source, _ := args.Get(0).(*entities.LoginSource)
+	return source, args.Error(1)
+}
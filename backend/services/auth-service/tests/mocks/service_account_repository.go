@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockServiceAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountRepository) Create(ctx context.Context, account *entities.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountRepository) FindByID(ctx context.Context, id string) (*entities.ServiceAccount, error) {
+	args := m.Called(ctx, id)
+	account, _ := args.Get(0).(*entities.ServiceAccount)
+	return account, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) FindByName(ctx context.Context, name string) (*entities.ServiceAccount, error) {
+	args := m.Called(ctx, name)
+	account, _ := args.Get(0).(*entities.ServiceAccount)
+	return account, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) List(ctx context.Context) ([]*entities.ServiceAccount, error) {
+	args := m.Called(ctx)
+	accounts, _ := args.Get(0).([]*entities.ServiceAccount)
+	return accounts, args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) Update(ctx context.Context, account *entities.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id string) (*entities.APIKey, error) {
+	args := m.Called(ctx, id)
+	key, _ := args.Get(0).(*entities.APIKey)
+	return key, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	key, _ := args.Get(0).(*entities.APIKey)
+	return key, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*entities.APIKey, error) {
+	args := m.Called(ctx, serviceAccountID)
+	keys, _ := args.Get(0).([]*entities.APIKey)
+	return keys, args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	args := m.Called(ctx, id, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) RevokeByServiceAccountID(ctx context.Context, serviceAccountID string, revokedAt time.Time) error {
+	args := m.Called(ctx, serviceAccountID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsed(ctx context.Context, id, ip string, usedAt time.Time) error {
+	args := m.Called(ctx, id, ip, usedAt)
+	return args.Error(0)
+}
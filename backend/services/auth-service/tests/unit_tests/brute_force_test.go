@@ -0,0 +1,248 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newLockoutTestConfig() config.LockoutConfig {
+	return config.LockoutConfig{
+		MaxAccountFailures: 10,
+		MaxIPFailures:      50,
+		FailureWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		DelayAfter:         3,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+		OTPMaxAttempts:     5,
+	}
+}
+
+// allowAttempts lets the brute-force counters run without any lockout.
+func allowAttempts(redis *mocks.MockRedis) {
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil).Maybe()
+	redis.On("IncrementAuthFailures", mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil).Maybe()
+	redis.On("ResetAuthFailures", mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func newLoginUseCaseForLockout(repo *mocks.MockUserRepository, publisher *mocks.MockPublisher, redis *mocks.MockRedis) *usecases.LoginUseCase {
+	return usecases.NewLoginUseCase(repo, newNoTwoFactorRepo(), newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, config.TwoFactorConfig{}, newLockoutTestConfig())
+}
+
+func TestLogin_AccountLocked(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, "login:account:user@example.com").Return(5*time.Minute, nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, new(mocks.MockPublisher), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrAccountLocked)
+
+	var lockoutErr *usecases.LockoutError
+	require.True(t, errors.As(err, &lockoutErr))
+	assert.Equal(t, 5*time.Minute, lockoutErr.RetryAfter)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
+
+func TestLogin_IPThrottled(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, "login:account:user@example.com").Return(time.Duration(0), nil).Once()
+	redis.On("GetAuthLockout", mock.Anything, "login:ip:10.0.0.1").Return(30*time.Second, nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, new(mocks.MockPublisher), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "10.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrTooManyAttempts)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
+
+func TestLogin_LockoutCheckFailsOpen(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), errors.New("redis down"))
+	redis.On("IncrementAuthFailures", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("redis down"))
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, new(mocks.MockPublisher), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+
+	repo.AssertExpectations(t)
+}
+
+func TestLogin_FailedPasswordAppliesProgressiveDelay(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	// Fifth failure, two past DelayAfter: 1s doubled once.
+	redis.On("IncrementAuthFailures", mock.Anything, "login:account:user@example.com", 15*time.Minute).Return(int64(5), nil).Once()
+	redis.On("LockAuthSubject", mock.Anything, "login:account:user@example.com", 2*time.Second).Return(nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:ip:127.0.0.1", 15*time.Minute).Return(int64(5), nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, publisher, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "WrongPassword123!",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrInvalidPassword)
+
+	redis.AssertExpectations(t)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogin_ProgressiveDelayIsCapped(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:account:user@example.com", mock.Anything).Return(int64(9), nil).Once()
+	redis.On("LockAuthSubject", mock.Anything, "login:account:user@example.com", 30*time.Second).Return(nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:ip:127.0.0.1", mock.Anything).Return(int64(1), nil).Once()
+
+	cfg := newLockoutTestConfig()
+	cfg.BaseDelay = 10 * time.Second
+	uc := usecases.NewLoginUseCase(repo, newNoTwoFactorRepo(), newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, config.TwoFactorConfig{}, cfg)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "WrongPassword123!",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrInvalidPassword)
+
+	redis.AssertExpectations(t)
+}
+
+func TestLogin_LockoutPublishesEvent(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:account:user@example.com", mock.Anything).Return(int64(10), nil).Once()
+	redis.On("LockAuthSubject", mock.Anything, "login:account:user@example.com", 15*time.Minute).Return(nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:ip:127.0.0.1", mock.Anything).Return(int64(10), nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthAccountLocked, mock.MatchedBy(func(e events.AuthAccountLockedEvent) bool {
+		return e.ID == user.ID && e.Action == "login" && e.FailedAttempts == 10 && e.IPAddress == "127.0.0.1"
+	})).Return(nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, publisher, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "WrongPassword123!",
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	})
+	require.ErrorIs(t, err, usecases.ErrInvalidPassword)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestLogin_IPLockedAfterMaxFailures(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, mock.Anything).Return(time.Duration(0), nil)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:account:user@example.com", mock.Anything).Return(int64(1), nil).Once()
+	redis.On("IncrementAuthFailures", mock.Anything, "login:ip:127.0.0.1", mock.Anything).Return(int64(50), nil).Once()
+	redis.On("LockAuthSubject", mock.Anything, "login:ip:127.0.0.1", 15*time.Minute).Return(nil).Once()
+
+	uc := newLoginUseCaseForLockout(repo, new(mocks.MockPublisher), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+
+	redis.AssertExpectations(t)
+}
+
+func TestUnlockAccount_RequiresAdmin(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "instructor", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+
+	uc := usecases.NewUnlockAccountUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.UnlockAccountInput{AccessToken: accessToken, UserID: "locked-user"})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+
+	redis.AssertNotCalled(t, "ClearAuthLockout", mock.Anything, mock.Anything)
+}
+
+func TestUnlockAccount_Success(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("admin-id", "admin@example.com", "admin", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("admin-id", nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("ClearAuthLockout", mock.Anything, "login:account:user@example.com").Return(nil).Once()
+	redis.On("ClearAuthLockout", mock.Anything, "otp:account:user@example.com").Return(nil).Once()
+	redis.On("ClearAuthLockout", mock.Anything, "forgot_password:account:user@example.com").Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthAccountUnlocked, mock.MatchedBy(func(e events.AuthAccountUnlockedEvent) bool {
+		return e.ID == user.ID && e.UnlockedBy == "admin-id"
+	})).Return(nil).Once()
+
+	uc := usecases.NewUnlockAccountUseCase(repo, publisher, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.UnlockAccountInput{AccessToken: accessToken, UserID: user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Account unlocked successfully", output.Message)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
@@ -20,8 +21,10 @@ func TestForgotPassword_MissingEmail(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger)
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger, redis, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{})
 	require.ErrorIs(t, err, usecases.ErrEmailRequired)
@@ -33,8 +36,10 @@ func TestForgotPassword_InvalidEmail(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
-	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger)
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger, redis, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{
 		Email: "not-an-email",
@@ -49,10 +54,12 @@ func TestForgotPassword_UserNotFound(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), nil).Once()
 
-	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger)
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger, redis, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{
 		Email: "user@example.com",
@@ -71,11 +78,13 @@ func TestForgotPassword_PublisherFailure(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserForgotPassword, mock.Anything).Return(errors.New("publisher error")).Once()
 
-	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger)
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger, redis, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{
 		Email: "user@example.com",
@@ -94,11 +103,13 @@ func TestForgotPassword_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserForgotPassword, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger)
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{
 		Email: "user@example.com",
@@ -117,3 +128,22 @@ func TestForgotPassword_Success(t *testing.T) {
 	publisher.AssertExpectations(t)
 }
 
+
+func TestForgotPassword_Throttled(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, "forgot_password:account:user@example.com").Return(4*time.Second, nil).Once()
+
+	uc := usecases.NewForgotPasswordUseCase(repo, publisher, logger.NewNop(), redis, newLockoutTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.ForgotPasswordInput{
+		Email:     "user@example.com",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrAccountLocked)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,212 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newImpersonationTarget(role string) *entities.User {
+	email, _ := valueobjects.NewEmail("student@example.com")
+	roleVO, _ := valueobjects.NewRole(role)
+	user := entities.NewUser(email, "student", roleVO, "hash")
+	user.Status = valueobjects.StatusActive
+	organizationID := testOrganizationID
+	user.MoveToOrganization(&organizationID)
+	return user
+}
+
+func impersonationToken(t *testing.T, redis *mocks.MockRedis, user *entities.User) string {
+	token, err := setupJwtManagerForUnit().GenerateImpersonationToken(
+		user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "impersonation-1", testOrganizationID,
+		utils.JwtActor{Subject: "admin-id", Email: "admin@example.com"}, 30*time.Minute,
+	)
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return(user.ID, nil)
+	return token
+}
+
+func TestStartImpersonation_Success(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+	token := adminAccessToken(t, redis, "admin")
+
+	target := newImpersonationTarget("student")
+	repo.On("FindByID", mock.Anything, target.ID).Return(target, nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, target.ID, mock.Anything, 30*time.Minute).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthImpersonationStarted, mock.MatchedBy(func(e events.AuthImpersonationStartedEvent) bool {
+		return e.ActorID == "admin-id" && e.TargetUserID == target.ID && e.Reason == "ticket 42"
+	})).Return(nil).Once()
+
+	uc := usecases.NewStartImpersonationUseCase(repo, publisher, logger.NewNop(), jwtManager, redis, config.ImpersonationConfig{TokenDuration: 30 * time.Minute})
+
+	output, err := uc.Execute(context.Background(), usecases.StartImpersonationInput{
+		AccessToken: token,
+		UserID:      target.ID,
+		Reason:      "ticket 42",
+	})
+	require.NoError(t, err)
+
+	claims, err := jwtManager.VerifyToken(output.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, claims.UserID)
+	require.NotNil(t, claims.Actor)
+	assert.Equal(t, "admin-id", claims.Actor.Subject)
+	assert.Equal(t, output.ImpersonationID, claims.SessionID)
+
+	redis.AssertNotCalled(t, "StoreRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	publisher.AssertExpectations(t)
+}
+
+func TestStartImpersonation_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		role   string
+		caller string
+		reason string
+		err    error
+	}{
+		{"admin target", "admin", "admin", "ticket 42", usecases.ErrCannotImpersonateUser},
+		{"non admin caller", "student", "instructor", "ticket 42", usecases.ErrInsufficientPermissions},
+		{"missing reason", "student", "admin", " ", usecases.ErrImpersonationReasonRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockUserRepository)
+			redis := new(mocks.MockRedis)
+			token := adminAccessToken(t, redis, tt.caller)
+
+			target := newImpersonationTarget(tt.role)
+			repo.On("FindByID", mock.Anything, target.ID).Return(target, nil).Maybe()
+
+			uc := usecases.NewStartImpersonationUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, config.ImpersonationConfig{TokenDuration: time.Minute})
+
+			_, err := uc.Execute(context.Background(), usecases.StartImpersonationInput{
+				AccessToken: token,
+				UserID:      target.ID,
+				Reason:      tt.reason,
+			})
+			require.ErrorIs(t, err, tt.err)
+			redis.AssertNotCalled(t, "StoreAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestStartImpersonation_FromImpersonationToken(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	token := impersonationToken(t, redis, newImpersonationTarget("student"))
+
+	uc := usecases.NewStartImpersonationUseCase(new(mocks.MockUserRepository), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, config.ImpersonationConfig{TokenDuration: time.Minute})
+
+	_, err := uc.Execute(context.Background(), usecases.StartImpersonationInput{
+		AccessToken: token,
+		UserID:      "other-user",
+		Reason:      "ticket 42",
+	})
+	require.ErrorIs(t, err, usecases.ErrNotAllowedWhileImpersonating)
+}
+
+func TestStopImpersonation_RevokesToken(t *testing.T) {
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	target := newImpersonationTarget("student")
+	token := impersonationToken(t, redis, target)
+
+	redis.On("RevokeAccessToken", mock.Anything, token).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthImpersonationStopped, mock.MatchedBy(func(e events.AuthImpersonationStoppedEvent) bool {
+		return e.ID == "impersonation-1" && e.ActorID == "admin-id" && e.TargetUserID == target.ID
+	})).Return(nil).Once()
+
+	uc := usecases.NewStopImpersonationUseCase(publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.StopImpersonationInput{AccessToken: token})
+	require.NoError(t, err)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestStopImpersonation_RegularToken(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	uc := usecases.NewStopImpersonationUseCase(new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.StopImpersonationInput{AccessToken: token})
+	require.ErrorIs(t, err, usecases.ErrNotImpersonating)
+
+	redis.AssertNotCalled(t, "RevokeAccessToken", mock.Anything, mock.Anything)
+}
+
+func newImpersonatedVerify(t *testing.T) (*usecases.VerifyUseCase, *mocks.MockRedis, string) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	target := newImpersonationTarget("student")
+	token := impersonationToken(t, redis, target)
+	repo.On("FindByID", mock.Anything, target.ID).Return(target, nil)
+
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, config.UserCacheConfig{})
+	return uc, redis, token
+}
+
+func TestVerify_ImpersonationExposesActor(t *testing.T) {
+	uc, redis, token := newImpersonatedVerify(t)
+
+	output, err := uc.Execute(context.Background(), usecases.VerifyInput{
+		Token:          token,
+		RequiredRole:   "student",
+		OriginalMethod: "GET",
+		OriginalURI:    "/api/v1/courses",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "admin-id", output.ActorID)
+	assert.Equal(t, "admin@example.com", output.ActorEmail)
+
+	redis.AssertNotCalled(t, "UpdateLastActivity", mock.Anything, mock.Anything)
+}
+
+func TestVerify_ImpersonationBlocksCredentialChanges(t *testing.T) {
+	uc, _, token := newImpersonatedVerify(t)
+
+	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
+		Token:          token,
+		OriginalMethod: "PUT",
+		OriginalURI:    "/api/v1/users/some-id",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+
+	_, err = uc.Execute(context.Background(), usecases.VerifyInput{
+		Token:          token,
+		OriginalMethod: "GET",
+		OriginalURI:    "/api/v1/users/some-id",
+	})
+	require.NoError(t, err)
+}
+
+func TestEnableTwoFactor_RejectsImpersonation(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	token := impersonationToken(t, redis, newImpersonationTarget("student"))
+
+	uc := usecases.NewEnableTwoFactorUseCase(twoFactorRepo, newSecurityEventRepo(), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.EnableTwoFactorInput{AccessToken: token, Code: "123456"})
+	require.ErrorIs(t, err, usecases.ErrNotAllowedWhileImpersonating)
+
+	twoFactorRepo.AssertNotCalled(t, "FindByUserID", mock.Anything, mock.Anything)
+}
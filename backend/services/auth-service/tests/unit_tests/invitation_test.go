@@ -0,0 +1,269 @@
+package unit_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	sharedEntities "github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testInvitationConfig = config.InvitationConfig{
+	TokenDuration: 72 * time.Hour,
+	AcceptURL:     "http://asto-lms.local/accept-invitation",
+}
+
+func invitationToken(t *testing.T, invitationURL string) string {
+	u, err := url.Parse(invitationURL)
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func TestCreateInvitation_Success(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	users.On("FindByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+	invitations.On("FindOpenByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+
+	var created *entities.Invitation
+	invitations.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.Invitation)
+	}).Return(nil).Once()
+
+	var sent events.AuthUserInvitedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserInvited, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(2).(events.AuthUserInvitedEvent)
+	}).Return(nil).Once()
+
+	uc := usecases.NewCreateInvitationUseCase(users, invitations, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, testInvitationConfig)
+
+	output, err := uc.Execute(context.Background(), usecases.CreateInvitationInput{
+		AccessToken: token,
+		Email:       "instructor@example.com",
+		Role:        "instructor",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entities.InvitationStatusPending, output.Status)
+
+	require.NotNil(t, created)
+	assert.True(t, strings.HasPrefix(sent.InvitationURL, testInvitationConfig.AcceptURL+"?token="))
+	plain := invitationToken(t, sent.InvitationURL)
+	assert.NotEqual(t, plain, created.TokenHash)
+	assert.Equal(t, utils.HashToken(plain), created.TokenHash)
+	assert.Equal(t, "admin-id", created.InvitedBy)
+}
+
+func TestCreateInvitation_InviteeJoinsAdminOrganization(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := tenantAdminAccessToken(t, redis)
+
+	users.On("FindByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+	invitations.On("FindOpenByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+	invitations.On("Create", mock.Anything, mock.MatchedBy(func(i *entities.Invitation) bool {
+		return i.OrganizationID != nil && *i.OrganizationID == testOrganizationID
+	})).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserInvited, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewCreateInvitationUseCase(users, invitations, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, testInvitationConfig)
+
+	output, err := uc.Execute(context.Background(), usecases.CreateInvitationInput{
+		AccessToken: token,
+		Email:       "instructor@example.com",
+		Role:        "instructor",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output.OrganizationID)
+	assert.Equal(t, testOrganizationID, *output.OrganizationID)
+	invitations.AssertExpectations(t)
+}
+
+func TestCreateInvitation_Rejected(t *testing.T) {
+	pending := entities.NewInvitation("instructor@example.com", valueobjects.RoleInstructor, "hash", "admin-id", nil, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name   string
+		role   string
+		caller string
+		open   *entities.Invitation
+		err    error
+	}{
+		{"student role", "student", "admin", nil, usecases.ErrInvalidInvitationRole},
+		{"non admin caller", "instructor", "instructor", nil, usecases.ErrInsufficientPermissions},
+		{"already pending", "instructor", "admin", pending, usecases.ErrInvitationAlreadyPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := new(mocks.MockUserRepository)
+			invitations := new(authMocks.MockInvitationRepository)
+			redis := new(mocks.MockRedis)
+			token := adminAccessToken(t, redis, tt.caller)
+
+			users.On("FindByEmail", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+			invitations.On("FindOpenByEmail", mock.Anything, mock.Anything).Return(tt.open, nil).Maybe()
+
+			uc := usecases.NewCreateInvitationUseCase(users, invitations, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, testInvitationConfig)
+
+			_, err := uc.Execute(context.Background(), usecases.CreateInvitationInput{
+				AccessToken: token,
+				Email:       "instructor@example.com",
+				Role:        tt.role,
+			})
+			require.ErrorIs(t, err, tt.err)
+			invitations.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestResendInvitation_RotatesToken(t *testing.T) {
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	expired := entities.NewInvitation("instructor@example.com", valueobjects.RoleInstructor, "old-hash", "admin-id", nil, time.Now().Add(-time.Hour))
+	invitations.On("FindByID", mock.Anything, expired.ID).Return(expired, nil).Once()
+	invitations.On("Update", mock.Anything, expired).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserInvited, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewResendInvitationUseCase(invitations, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, testInvitationConfig)
+
+	output, err := uc.Execute(context.Background(), usecases.ResendInvitationInput{AccessToken: token, InvitationID: expired.ID})
+	require.NoError(t, err)
+	assert.Equal(t, entities.InvitationStatusPending, output.Status)
+	assert.NotEqual(t, "old-hash", expired.TokenHash)
+
+	invitations.AssertExpectations(t)
+}
+
+func TestInvitations_TenantAdminOnlyManagesOwnOrganization(t *testing.T) {
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := tenantAdminAccessToken(t, redis)
+
+	organizationID := testOrganizationID
+	own := entities.NewInvitation("own@example.com", valueobjects.RoleInstructor, "hash", "tenant-admin-id", &organizationID, time.Now().Add(time.Hour))
+	other := entities.NewInvitation("other@example.com", valueobjects.RoleAdmin, "hash", "admin-id", nil, time.Now().Add(-time.Hour))
+	invitations.On("ListOpen", mock.Anything).Return([]*entities.Invitation{own, other}, nil).Once()
+	invitations.On("FindByID", mock.Anything, other.ID).Return(other, nil)
+
+	jwtManager := setupJwtManagerForUnit()
+	list, err := usecases.NewListInvitationsUseCase(invitations, logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.ListInvitationsInput{AccessToken: token})
+	require.NoError(t, err)
+	require.Len(t, list.Invitations, 1)
+	assert.Equal(t, own.ID, list.Invitations[0].ID)
+
+	_, err = usecases.NewResendInvitationUseCase(invitations, publisher, logger.NewNop(), jwtManager, redis, testInvitationConfig).Execute(context.Background(), usecases.ResendInvitationInput{AccessToken: token, InvitationID: other.ID})
+	require.ErrorIs(t, err, usecases.ErrInvitationNotFound)
+
+	_, err = usecases.NewRevokeInvitationUseCase(invitations, publisher, logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.RevokeInvitationInput{AccessToken: token, InvitationID: other.ID})
+	require.ErrorIs(t, err, usecases.ErrInvitationNotFound)
+
+	invitations.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAcceptInvitation_CreatesVerifiedUser(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+
+	invitation := entities.NewInvitation("instructor@example.com", valueobjects.RoleInstructor, utils.HashToken("plain-token"), "admin-id", nil, time.Now().Add(time.Hour))
+	invitations.On("FindByTokenHash", mock.Anything, utils.HashToken("plain-token")).Return(invitation, nil).Once()
+	users.On("FindByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+	users.On("FindByUsername", mock.Anything, "jane").Return(nil, nil).Once()
+	users.On("Create", mock.Anything, mock.MatchedBy(func(u *sharedEntities.User) bool {
+		return u.Email.String() == "instructor@example.com" && u.Role.IsInstructor() && u.EmailVerified
+	})).Return(nil).Once()
+	invitations.On("Update", mock.Anything, invitation).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthInvitationAccepted, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewAcceptInvitationUseCase(users, invitations, publisher, logger.NewNop())
+
+	output, err := uc.Execute(context.Background(), usecases.AcceptInvitationInput{
+		Token:    "plain-token",
+		Username: "jane",
+		Password: "Password123!",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "instructor", output.User.Role)
+	assert.Equal(t, entities.InvitationStatusAccepted, invitation.Status(time.Now()))
+	assert.Equal(t, output.User.ID, invitation.AcceptedUserID)
+
+	users.AssertExpectations(t)
+	invitations.AssertExpectations(t)
+}
+
+func TestAcceptInvitation_JoinsInvitationOrganization(t *testing.T) {
+	users := new(mocks.MockUserRepository)
+	invitations := new(authMocks.MockInvitationRepository)
+	publisher := new(mocks.MockPublisher)
+
+	organizationID := testOrganizationID
+	invitation := entities.NewInvitation("instructor@example.com", valueobjects.RoleInstructor, utils.HashToken("plain-token"), "tenant-admin-id", &organizationID, time.Now().Add(time.Hour))
+	invitations.On("FindByTokenHash", mock.Anything, utils.HashToken("plain-token")).Return(invitation, nil).Once()
+	users.On("FindByEmail", mock.Anything, "instructor@example.com").Return(nil, nil).Once()
+	users.On("FindByUsername", mock.Anything, "jane").Return(nil, nil).Once()
+	users.On("Create", mock.Anything, mock.MatchedBy(func(u *sharedEntities.User) bool {
+		return u.OrganizationID != nil && *u.OrganizationID == testOrganizationID
+	})).Return(nil).Once()
+	invitations.On("Update", mock.Anything, invitation).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthInvitationAccepted, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewAcceptInvitationUseCase(users, invitations, publisher, logger.NewNop())
+
+	_, err := uc.Execute(context.Background(), usecases.AcceptInvitationInput{
+		Token:    "plain-token",
+		Username: "jane",
+		Password: "Password123!",
+	})
+	require.NoError(t, err)
+	users.AssertExpectations(t)
+}
+
+func TestAcceptInvitation_RejectsClosedInvitations(t *testing.T) {
+	expired := entities.NewInvitation("a@example.com", valueobjects.RoleInstructor, "hash", "admin-id", nil, time.Now().Add(-time.Minute))
+	revoked := entities.NewInvitation("b@example.com", valueobjects.RoleInstructor, "hash", "admin-id", nil, time.Now().Add(time.Hour))
+	revoked.Revoke()
+	accepted := entities.NewInvitation("c@example.com", valueobjects.RoleInstructor, "hash", "admin-id", nil, time.Now().Add(time.Hour))
+	accepted.Accept("user-id")
+
+	for name, invitation := range map[string]*entities.Invitation{"expired": expired, "revoked": revoked, "accepted": accepted, "unknown": nil} {
+		t.Run(name, func(t *testing.T) {
+			users := new(mocks.MockUserRepository)
+			invitations := new(authMocks.MockInvitationRepository)
+			invitations.On("FindByTokenHash", mock.Anything, mock.Anything).Return(invitation, nil).Once()
+
+			uc := usecases.NewAcceptInvitationUseCase(users, invitations, new(mocks.MockPublisher), logger.NewNop())
+
+			_, err := uc.Execute(context.Background(), usecases.AcceptInvitationInput{
+				Token:    "plain-token",
+				Username: "jane",
+				Password: "Password123!",
+			})
+			require.ErrorIs(t, err, usecases.ErrInvalidInvitation)
+			users.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package unit_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRSASigningKey(t *testing.T, kid string) utils.JwtSigningKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return utils.JwtSigningKey{KeyID: kid, Algorithm: utils.JwtAlgorithmRS256, PrivateKey: key, PublicKey: &key.PublicKey}
+}
+
+func newEdDSASigningKey(t *testing.T, kid string) utils.JwtSigningKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return utils.JwtSigningKey{KeyID: kid, Algorithm: utils.JwtAlgorithmEdDSA, PrivateKey: priv, PublicKey: pub}
+}
+
+func tokenHeader(t *testing.T, token string) map[string]interface{} {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &utils.JwtClaims{})
+	require.NoError(t, err)
+	return parsed.Header
+}
+
+func TestJwtManager_SignsWithActiveKey(t *testing.T) {
+	for _, key := range []utils.JwtSigningKey{newRSASigningKey(t, "rsa-1"), newEdDSASigningKey(t, "ed-1")} {
+		t.Run(key.Algorithm, func(t *testing.T) {
+			jwtManager, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{key}, key.KeyID, 15*time.Minute, 24*time.Hour)
+			require.NoError(t, err)
+
+			token, err := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+			require.NoError(t, err)
+
+			header := tokenHeader(t, token)
+			assert.Equal(t, key.Algorithm, header["alg"])
+			assert.Equal(t, key.KeyID, header["kid"])
+
+			claims, err := jwtManager.VerifyToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-id", claims.UserID)
+			assert.Equal(t, "session-1", claims.SessionID)
+		})
+	}
+}
+
+func TestJwtManager_RotationKeepsOldTokensValid(t *testing.T) {
+	oldKey := newRSASigningKey(t, "2024-01")
+	newKey := newEdDSASigningKey(t, "2024-02")
+
+	jwtManager, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{oldKey}, oldKey.KeyID, 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	oldToken, err := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, jwtManager.RotateKey(newKey))
+	assert.Equal(t, newKey.KeyID, jwtManager.ActiveKeyID())
+
+	newToken, err := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, newKey.KeyID, tokenHeader(t, newToken)["kid"])
+
+	_, err = jwtManager.VerifyToken(oldToken)
+	require.NoError(t, err)
+	_, err = jwtManager.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, jwtManager.RemoveKey(newKey.KeyID), utils.ErrActiveSigningKey)
+	require.NoError(t, jwtManager.RemoveKey(oldKey.KeyID))
+
+	_, err = jwtManager.VerifyToken(oldToken)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestJwtManager_LegacyHS256Tokens(t *testing.T) {
+	key := newRSASigningKey(t, "rsa-1")
+	legacyToken, err := utils.NewJwtManager("test-secret", 15*time.Minute, 24*time.Hour).GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.NoError(t, err)
+
+	withSecret, err := utils.NewJwtManagerWithKeys("test-secret", time.Now().Add(time.Hour), []utils.JwtSigningKey{key}, key.KeyID, 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+	_, err = withSecret.VerifyToken(legacyToken)
+	require.NoError(t, err)
+
+	withoutSecret, err := utils.NewJwtManagerWithKeys("", time.Now().Add(time.Hour), []utils.JwtSigningKey{key}, key.KeyID, 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+	_, err = withoutSecret.VerifyToken(legacyToken)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestJwtManager_LegacyHS256TokensRefusedAfterCutoff(t *testing.T) {
+	key := newRSASigningKey(t, "rsa-1")
+	legacyToken, err := utils.NewJwtManager("test-secret", 15*time.Minute, 24*time.Hour).GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.NoError(t, err)
+
+	for name, cutoff := range map[string]time.Time{"cutoff passed": time.Now().Add(-time.Minute), "no cutoff": {}} {
+		t.Run(name, func(t *testing.T) {
+			jwtManager, err := utils.NewJwtManagerWithKeys("test-secret", cutoff, []utils.JwtSigningKey{key}, key.KeyID, 15*time.Minute, 24*time.Hour)
+			require.NoError(t, err)
+			_, err = jwtManager.VerifyToken(legacyToken)
+			require.ErrorIs(t, err, utils.ErrInvalidToken)
+		})
+	}
+}
+
+func TestJwtManager_RejectsUnknownKid(t *testing.T) {
+	signer, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{newRSASigningKey(t, "rsa-1")}, "rsa-1", 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+	verifier, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{newRSASigningKey(t, "rsa-2")}, "rsa-2", 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	token, err := signer.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(token)
+	require.ErrorIs(t, err, utils.ErrInvalidToken)
+}
+
+func TestJwtManager_PublicOnlyKeyCannotBeActive(t *testing.T) {
+	key := newRSASigningKey(t, "rsa-1")
+	key.PrivateKey = nil
+
+	_, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{key}, key.KeyID, 15*time.Minute, 24*time.Hour)
+	require.ErrorIs(t, err, utils.ErrSigningKeyNotPrivate)
+}
+
+func TestJwtManager_RefusesToSignWithoutKey(t *testing.T) {
+	jwtManager, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{newRSASigningKey(t, "rsa-1")}, "", 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	_, err = jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
+	require.ErrorIs(t, err, utils.ErrNoSigningKey)
+}
+
+func TestLoadJwtSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey := newRSASigningKey(t, "rsa")
+	rsaDER := x509.MarshalPKCS1PrivateKey(rsaKey.PrivateKey.(*rsa.PrivateKey))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2024-01.pem"), pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: rsaDER}), 0o600))
+
+	edKey := newEdDSASigningKey(t, "ed")
+	edDER, err := x509.MarshalPKIXPublicKey(edKey.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2023-12.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edDER}), 0o600))
+
+	keys, err := utils.LoadJwtSigningKeys(dir)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	assert.Equal(t, "2023-12", keys[0].KeyID)
+	assert.Equal(t, utils.JwtAlgorithmEdDSA, keys[0].Algorithm)
+	assert.Nil(t, keys[0].PrivateKey)
+	assert.Equal(t, "2024-01", keys[1].KeyID)
+	assert.Equal(t, utils.JwtAlgorithmRS256, keys[1].Algorithm)
+	assert.NotNil(t, keys[1].PrivateKey)
+}
+
+func TestGetJWKS_PublishesPublicKeys(t *testing.T) {
+	rsaKey := newRSASigningKey(t, "rsa-1")
+	edKey := newEdDSASigningKey(t, "ed-1")
+	jwtManager, err := utils.NewJwtManagerWithKeys("test-secret", time.Now().Add(time.Hour), []utils.JwtSigningKey{rsaKey, edKey}, rsaKey.KeyID, 15*time.Minute, 24*time.Hour)
+	require.NoError(t, err)
+
+	uc := usecases.NewGetJWKSUseCase(jwtManager)
+
+	output, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+	require.Len(t, output.Keys, 2)
+
+	assert.Equal(t, "ed-1", output.Keys[0].Kid)
+	assert.Equal(t, "OKP", output.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", output.Keys[0].Crv)
+	assert.NotEmpty(t, output.Keys[0].X)
+
+	assert.Equal(t, "rsa-1", output.Keys[1].Kid)
+	assert.Equal(t, "RSA", output.Keys[1].Kty)
+	assert.Equal(t, "RS256", output.Keys[1].Alg)
+	assert.Equal(t, "AQAB", output.Keys[1].E)
+	assert.NotEmpty(t, output.Keys[1].N)
+}
+
+func TestGetJWKS_EmptyForHS256(t *testing.T) {
+	uc := usecases.NewGetJWKSUseCase(setupJwtManagerForUnit())
+
+	output, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, output.Keys)
+}
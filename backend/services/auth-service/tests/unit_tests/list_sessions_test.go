@@ -0,0 +1,73 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSessions_InvalidToken(t *testing.T) {
+	redis := new(mocks.MockRedis)
+
+	uc := usecases.NewListSessionsUseCase(logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.ListSessionsInput{AccessToken: "invalid-token"})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+
+	redis.AssertNotCalled(t, "ListUserSessions", mock.Anything, mock.Anything)
+}
+
+func TestListSessions_Success(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	now := time.Now()
+	sessions := []*utils.SessionData{
+		{
+			UserID:       "user-id",
+			SessionID:    "session-2",
+			IPAddress:    "10.0.0.2",
+			UserAgent:    "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36",
+			CreatedAt:    now.Add(-2 * time.Hour).Unix(),
+			LastActivity: now.Add(-time.Hour).Unix(),
+			ExpiresAt:    now.Add(time.Hour).Unix(),
+		},
+		{
+			UserID:       "user-id",
+			SessionID:    "session-1",
+			IPAddress:    "10.0.0.1",
+			UserAgent:    "Mozilla/5.0 (X11; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0",
+			CreatedAt:    now.Add(-time.Hour).Unix(),
+			LastActivity: now.Unix(),
+			ExpiresAt:    now.Add(time.Hour).Unix(),
+		},
+	}
+
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	redis.On("ListUserSessions", mock.Anything, "user-id").Return(sessions, nil).Once()
+
+	uc := usecases.NewListSessionsUseCase(logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.ListSessionsInput{AccessToken: accessToken})
+	require.NoError(t, err)
+	require.Len(t, output.Sessions, 2)
+
+	assert.Equal(t, "session-1", output.Sessions[0].SessionID)
+	assert.True(t, output.Sessions[0].Current)
+	assert.Equal(t, "Firefox on Linux", output.Sessions[0].Device)
+	assert.Equal(t, "session-2", output.Sessions[1].SessionID)
+	assert.False(t, output.Sessions[1].Current)
+	assert.Equal(t, "Chrome on Windows", output.Sessions[1].Device)
+	assert.Equal(t, "10.0.0.2", output.Sessions[1].IPAddress)
+
+	redis.AssertExpectations(t)
+}
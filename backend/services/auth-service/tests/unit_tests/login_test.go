@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
@@ -22,14 +24,28 @@ func setupJwtManagerForUnit() *utils.JwtManager {
 	return utils.NewJwtManager("test-secret", 15*time.Minute, 24*time.Hour)
 }
 
+// testOrganizationID is the organization test users other than super-admins
+// belong to.
+const testOrganizationID = "11111111-1111-1111-1111-111111111111"
+
+// newNoTwoFactorRepo returns a repository for users without two-factor
+// authentication set up.
+func newNoTwoFactorRepo() *authMocks.MockTwoFactorRepository {
+	repo := new(authMocks.MockTwoFactorRepository)
+	repo.On("FindByUserID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
 func TestLogin_InvalidEmail(t *testing.T) {
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "not-an-email",
@@ -49,8 +65,10 @@ func TestLogin_MissingEmail(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Password:  "Password123!",
@@ -66,8 +84,10 @@ func TestLogin_MissingPassword(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "user@example.com",
@@ -83,10 +103,12 @@ func TestLogin_UserNotFound(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), nil).Once()
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "user@example.com",
@@ -110,10 +132,12 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	_, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "user@example.com",
@@ -138,14 +162,17 @@ func TestLogin_Success(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
 	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
 	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
 	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, "127.0.0.1", "test-agent", mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "user@example.com",
@@ -176,14 +203,17 @@ func TestLogin_RedisStorageFailure(t *testing.T) {
 	logger := logger.NewNop()
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
+	twoFactorRepo := newNoTwoFactorRepo()
+	allowAttempts(redis)
 
 	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
 	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(errors.New("redis error")).Once()
 	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(errors.New("redis error")).Once()
 	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, "127.0.0.1", "test-agent", mock.Anything).Return(errors.New("redis error")).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewLoginUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger, jwtManager, redis, config.TwoFactorConfig{}, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.LoginInput{
 		Email:     "user@example.com",
@@ -0,0 +1,149 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionTestUser() *entities.User {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	return entities.NewUser(emailVO, "user", roleVO, "hash")
+}
+
+func TestLogout_MissingToken(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	uc := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LogoutInput{})
+	require.ErrorIs(t, err, usecases.ErrTokenRequired)
+
+	redis.AssertNotCalled(t, "RevokeAccessToken", mock.Anything, mock.Anything)
+}
+
+func TestLogout_RevokedToken(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("", errors.New("not found")).Once()
+
+	uc := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LogoutInput{AccessToken: accessToken})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+
+	redis.AssertExpectations(t)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLogout_CurrentSession(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	session := &utils.SessionData{
+		UserID:       user.ID,
+		SessionID:    "session-1",
+		AccessToken:  accessToken,
+		RefreshToken: "refresh-1",
+	}
+
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	redis.On("GetUserSession", mock.Anything, "session-1").Return(session, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, accessToken).Return(nil).Twice()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-1").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedOut, mock.MatchedBy(func(e events.AuthUserLoggedOutEvent) bool {
+		return e.ID == user.ID && e.Email == user.Email.String() && len(e.SessionIDs) == 1 && e.SessionIDs[0] == "session-1"
+	})).Return(nil).Once()
+
+	uc := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.LogoutInput{AccessToken: accessToken})
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.RevokedSessions)
+
+	redis.AssertNotCalled(t, "ListUserSessions", mock.Anything, mock.Anything)
+	redis.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestLogout_AllSessions(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	sessions := []*utils.SessionData{
+		{UserID: user.ID, SessionID: "session-1", AccessToken: accessToken, RefreshToken: "refresh-1"},
+		{UserID: user.ID, SessionID: "session-2", AccessToken: "access-2", RefreshToken: "refresh-2"},
+	}
+
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	redis.On("ListUserSessions", mock.Anything, user.ID).Return(sessions, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, accessToken).Return(nil).Twice()
+	redis.On("RevokeAccessToken", mock.Anything, "access-2").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-1").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-2").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-2").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-2").Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedOut, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.LogoutInput{AccessToken: accessToken, AllSessions: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.RevokedSessions)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestLogout_RedisFailure(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	redis.On("ListUserSessions", mock.Anything, user.ID).Return(nil, errors.New("redis error")).Once()
+
+	uc := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.LogoutInput{AccessToken: accessToken, AllSessions: true})
+	require.ErrorIs(t, err, usecases.ErrInternalServerError)
+
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,178 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testMagicLinkConfig = config.MagicLinkConfig{
+	AllowedRoles: []string{"student"},
+	TokenTTL:     15 * time.Minute,
+	LoginURL:     "http://asto-lms.local/magic-link",
+}
+
+func TestRequestMagicLink_SendsLink(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	var token string
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("StoreMagicLinkToken", mock.Anything, user.ID, mock.Anything, 15*time.Minute).Run(func(args mock.Arguments) {
+		token = args.String(2)
+	}).Return(nil).Once()
+
+	var sent events.AuthMagicLinkRequestedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthMagicLinkRequested, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(2).(events.AuthMagicLinkRequestedEvent)
+	}).Return(nil).Once()
+
+	uc := usecases.NewRequestMagicLinkUseCase(repo, publisher, logger.NewNop(), redis, testMagicLinkConfig)
+
+	output, err := uc.Execute(context.Background(), usecases.RequestMagicLinkInput{Email: "user@example.com", IPAddress: "127.0.0.1"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, output.Message)
+
+	require.NotEmpty(t, token)
+	assert.Equal(t, "user@example.com", sent.Email)
+	assert.Equal(t, testMagicLinkConfig.LoginURL+"?token="+token, sent.MagicLinkURL)
+	redis.AssertExpectations(t)
+}
+
+func TestRequestMagicLink_SameResponseWhenNotSent(t *testing.T) {
+	admin := newTwoFactorTestUser("admin", "Password123!")
+	banned := newTwoFactorTestUser("student", "Password123!")
+	banned.Status = valueobjects.StatusBanned
+
+	sent := usecases.RequestMagicLinkOutput{}
+	{
+		repo := new(mocks.MockUserRepository)
+		publisher := new(mocks.MockPublisher)
+		redis := new(mocks.MockRedis)
+		user := newTwoFactorTestUser("student", "Password123!")
+		repo.On("FindByEmail", mock.Anything, mock.Anything).Return(user, nil)
+		redis.On("StoreMagicLinkToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		output, err := usecases.NewRequestMagicLinkUseCase(repo, publisher, logger.NewNop(), redis, testMagicLinkConfig).
+			Execute(context.Background(), usecases.RequestMagicLinkInput{Email: "user@example.com"})
+		require.NoError(t, err)
+		sent = *output
+	}
+
+	for name, found := range map[string]interface{}{"unknown": nil, "role not allowed": admin, "banned": banned} {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mocks.MockUserRepository)
+			publisher := new(mocks.MockPublisher)
+			redis := new(mocks.MockRedis)
+			repo.On("FindByEmail", mock.Anything, "user@example.com").Return(found, nil).Once()
+
+			uc := usecases.NewRequestMagicLinkUseCase(repo, publisher, logger.NewNop(), redis, testMagicLinkConfig)
+
+			output, err := uc.Execute(context.Background(), usecases.RequestMagicLinkInput{Email: "user@example.com"})
+			require.NoError(t, err)
+			assert.Equal(t, sent, *output)
+
+			redis.AssertNotCalled(t, "StoreMagicLinkToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestVerifyMagicLink_StartsSession(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeMagicLinkToken", mock.Anything, "magic-token").Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, "127.0.0.1", "test-agent", mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewVerifyMagicLinkUseCase(repo, newNoTwoFactorRepo(), newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig(), testMagicLinkConfig)
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyMagicLinkInput{
+		Token:     "magic-token",
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.Equal(t, user.ID, result.User.ID)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestVerifyMagicLink_TwoFactorEnabled_ReturnsChallenge(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeMagicLinkToken", mock.Anything, "magic-token").Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(newEnabledTwoFactor(t, user.ID), nil).Once()
+	redis.On("StoreTwoFactorChallenge", mock.Anything, mock.Anything, mock.MatchedBy(func(c *utils.TwoFactorChallenge) bool {
+		return c.UserID == user.ID && !c.SetupRequired
+	}), mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewVerifyMagicLinkUseCase(repo, twoFactorRepo, newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig(), testMagicLinkConfig)
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyMagicLinkInput{Token: "magic-token"})
+	require.NoError(t, err)
+	assert.True(t, result.TwoFactorRequired)
+	assert.Empty(t, result.AccessToken)
+
+	redis.AssertNotCalled(t, "StoreAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyMagicLink_Rejected(t *testing.T) {
+	admin := newTwoFactorTestUser("admin", "Password123!")
+	student := newTwoFactorTestUser("student", "Password123!")
+
+	tests := []struct {
+		name    string
+		userID  string
+		consume error
+		found   interface{}
+	}{
+		{"unknown or used token", "", utils.ErrMagicLinkNotFound, nil},
+		{"role no longer allowed", admin.ID, nil, admin},
+		{"user deleted", student.ID, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockUserRepository)
+			redis := new(mocks.MockRedis)
+			redis.On("ConsumeMagicLinkToken", mock.Anything, "magic-token").Return(tt.userID, tt.consume).Once()
+			repo.On("FindByID", mock.Anything, tt.userID).Return(tt.found, nil).Maybe()
+
+			uc := usecases.NewVerifyMagicLinkUseCase(repo, newNoTwoFactorRepo(), newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig(), testMagicLinkConfig)
+
+			_, err := uc.Execute(context.Background(), usecases.VerifyMagicLinkInput{Token: "magic-token"})
+			require.ErrorIs(t, err, usecases.ErrInvalidMagicLink)
+
+			redis.AssertNotCalled(t, "StoreAccessToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
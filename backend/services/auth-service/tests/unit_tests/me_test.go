@@ -0,0 +1,246 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const mePassword = "Password123!"
+
+func newMeUser(t *testing.T) *entities.User {
+	hash, err := utils.HashPassword(mePassword)
+	require.NoError(t, err)
+	email, _ := valueobjects.NewEmail("user@example.com")
+	user := entities.NewUser(email, "user", valueobjects.RoleStudent, hash)
+	user.Status = valueobjects.StatusActive
+	return user
+}
+
+func meAccessToken(t *testing.T, redis *mocks.MockRedis, user *entities.User) string {
+	token, err := setupJwtManagerForUnit().GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "session-1", "")
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return(user.ID, nil)
+	return token
+}
+
+func TestGetMe_ReturnsProfile(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewGetMeUseCase(repo, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	output, err := uc.Execute(context.Background(), usecases.GetMeInput{AccessToken: token})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, output.User.ID)
+	assert.Equal(t, "user@example.com", output.User.Email)
+}
+
+func TestUpdateMe_UsernameTaken(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+
+	email, _ := valueobjects.NewEmail("other@example.com")
+	other := entities.NewUser(email, "taken", valueobjects.RoleStudent, "hash")
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("FindByUsername", mock.Anything, "taken").Return(other, nil).Once()
+
+	uc := usecases.NewUpdateMeUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.UpdateMeInput{AccessToken: token, Username: "taken"})
+	require.ErrorIs(t, err, usecases.ErrUsernameAlreadyExists)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateMe_ProfileOnly(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	publisher := new(mocks.MockPublisher)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("Update", mock.Anything, user).Return(nil).Once()
+
+	var published events.UserUpdatedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Run(func(args mock.Arguments) {
+		published = args.Get(2).(events.UserUpdatedEvent)
+	}).Return(nil).Once()
+
+	timezone := "Asia/Bangkok"
+	locale := "th-TH"
+	uc := usecases.NewUpdateMeUseCase(repo, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	output, err := uc.Execute(context.Background(), usecases.UpdateMeInput{
+		AccessToken: token,
+		Profile:     entities.ProfileUpdate{Timezone: &timezone, Locale: &locale},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user", output.User.Username)
+	assert.Equal(t, "Asia/Bangkok", output.User.Timezone)
+	assert.Equal(t, "th-TH", published.Locale)
+	assert.Equal(t, "user", published.Username)
+
+	repo.AssertNotCalled(t, "FindByUsername", mock.Anything, mock.Anything)
+	publisher.AssertExpectations(t)
+}
+
+func TestUpdateMe_InvalidTimezone(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	timezone := "Mars/Olympus_Mons"
+	uc := usecases.NewUpdateMeUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.UpdateMeInput{
+		AccessToken: token,
+		Profile:     entities.ProfileUpdate{Timezone: &timezone},
+	})
+	require.ErrorIs(t, err, valueobjects.ErrInvalidTimezone)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_RevokesOtherSessions(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+
+	current := &utils.SessionData{UserID: user.ID, SessionID: "session-1", AccessToken: token, RefreshToken: "refresh-1"}
+	other := &utils.SessionData{UserID: user.ID, SessionID: "session-2", AccessToken: "access-2", RefreshToken: "refresh-2"}
+
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("UpdatePassword", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
+	redis.On("ListUserSessions", mock.Anything, user.ID).Return([]*utils.SessionData{current, other}, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-2").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-2").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-2").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-2").Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserPasswordChanged, mock.MatchedBy(func(e events.AuthUserPasswordChangedEvent) bool {
+		return e.ID == user.ID && len(e.RevokedSessions) == 1 && e.RevokedSessions[0] == "session-2"
+	})).Return(nil).Once()
+
+	uc := usecases.NewChangePasswordUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	output, err := uc.Execute(context.Background(), usecases.ChangePasswordInput{
+		AccessToken:     token,
+		CurrentPassword: mePassword,
+		NewPassword:     "NewPassword123!",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.RevokedSessions)
+
+	redis.AssertNotCalled(t, "DeleteUserSession", mock.Anything, "session-1")
+	redis.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewChangePasswordUseCase(repo, newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.ChangePasswordInput{
+		AccessToken:     token,
+		CurrentPassword: "WrongPassword123!",
+		NewPassword:     "NewPassword123!",
+	})
+	require.ErrorIs(t, err, usecases.ErrCurrentPasswordIncorrect)
+
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_RejectsImpersonation(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	token := impersonationToken(t, redis, newImpersonationTarget("student"))
+
+	uc := usecases.NewChangePasswordUseCase(repo, newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.ChangePasswordInput{
+		AccessToken:     token,
+		CurrentPassword: mePassword,
+		NewPassword:     "NewPassword123!",
+	})
+	require.ErrorIs(t, err, usecases.ErrNotAllowedWhileImpersonating)
+
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeEmail_SendsVerificationToNewAddress(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return(nil, nil).Once()
+	redis.On("StoreVerifyEmailToken", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
+	redis.On("StorePendingEmail", mock.Anything, mock.Anything, "new@example.com").Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserRequestedEmailVerification, mock.MatchedBy(func(e events.AuthUserRequestedEmailVerificationEvent) bool {
+		return e.ID == user.ID && e.Email == "new@example.com"
+	})).Return(nil).Once()
+
+	uc := usecases.NewChangeEmailUseCase(repo, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, "http://gateway")
+
+	output, err := uc.Execute(context.Background(), usecases.ChangeEmailInput{
+		AccessToken:     token,
+		NewEmail:        "new@example.com",
+		CurrentPassword: mePassword,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", output.PendingEmail)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestChangeEmail_EmailTaken(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	user := newMeUser(t)
+	token := meAccessToken(t, redis, user)
+
+	email, _ := valueobjects.NewEmail("new@example.com")
+	other := entities.NewUser(email, "other", valueobjects.RoleStudent, "hash")
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return(other, nil).Once()
+
+	uc := usecases.NewChangeEmailUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, "http://gateway")
+
+	_, err := uc.Execute(context.Background(), usecases.ChangeEmailInput{
+		AccessToken:     token,
+		NewEmail:        "new@example.com",
+		CurrentPassword: mePassword,
+	})
+	require.ErrorIs(t, err, usecases.ErrEmailAlreadyExists)
+
+	redis.AssertNotCalled(t, "StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything)
+}
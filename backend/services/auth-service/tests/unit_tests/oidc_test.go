@@ -0,0 +1,515 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	authEntities "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/oidc"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stubClientID    = "lms-client"
+	stubRedirectURL = "https://asto-lms.local/auth/callback"
+	stubCode        = "stub-authorization-code"
+	stubSubject     = "stub-subject-1"
+)
+
+// stubIssuer is a minimal OpenID Connect provider. It serves discovery and
+// JWKS documents and a token endpoint that checks the PKCE verifier against
+// the challenge sent with the authorization request.
+type stubIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    utils.JwtSigningKey
+
+	codeChallenge string
+	nonce         string
+
+	email         string
+	emailVerified interface{}
+	tokenNonce    string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	issuer := &stubIssuer{
+		t:             t,
+		key:           newRSASigningKey(t, "stub-key-1"),
+		email:         "new.student@example.com",
+		emailVerified: true,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		jwtManager, err := utils.NewJwtManagerWithKeys("", time.Time{}, []utils.JwtSigningKey{issuer.key}, issuer.key.KeyID, time.Minute, time.Minute)
+		require.NoError(t, err)
+		json.NewEncoder(w).Encode(jwtManager.JWKS())
+	})
+	mux.HandleFunc("/token", issuer.token)
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (s *stubIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("grant_type") != "authorization_code" ||
+		r.Form.Get("code") != stubCode ||
+		r.Form.Get("client_id") != stubClientID ||
+		r.Form.Get("redirect_uri") != stubRedirectURL ||
+		oidc.CodeChallengeS256(r.Form.Get("code_verifier")) != s.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := s.nonce
+	if s.tokenNonce != "" {
+		nonce = s.tokenNonce
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.server.URL,
+		"aud":            stubClientID,
+		"sub":            stubSubject,
+		"email":          s.email,
+		"email_verified": s.emailVerified,
+		"nonce":          nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	})
+	token.Header["kid"] = s.key.KeyID
+	idToken, err := token.SignedString(s.key.PrivateKey)
+	require.NoError(s.t, err)
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func (s *stubIssuer) registry(allowedDomains ...string) *oidc.Registry {
+	return oidc.NewRegistry(config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{
+			Name:           "stub",
+			IssuerURL:      s.server.URL,
+			ClientID:       stubClientID,
+			ClientSecret:   "stub-secret",
+			RedirectURL:    stubRedirectURL,
+			Scopes:         []string{"openid", "email", "profile"},
+			AllowedDomains: allowedDomains,
+		}},
+		StateTTL: 10 * time.Minute,
+	}, s.server.Client())
+}
+
+// authorize runs the start of the flow and returns the state handed to the
+// browser along with what was stored for it, as the provider would see it.
+func (s *stubIssuer) authorize(t *testing.T, providers *oidc.Registry) (string, *utils.OIDCState) {
+	redis := new(mocks.MockRedis)
+	var stored *utils.OIDCState
+	var storedKey string
+	redis.On("StoreOIDCState", mock.Anything, mock.Anything, mock.Anything, 10*time.Minute).Run(func(args mock.Arguments) {
+		storedKey = args.String(1)
+		stored = args.Get(2).(*utils.OIDCState)
+	}).Return(nil).Once()
+
+	uc := usecases.NewStartOIDCLoginUseCase(providers, redis, logger.NewNop(), config.OIDCConfig{StateTTL: 10 * time.Minute})
+	output, err := uc.Execute(context.Background(), usecases.StartOIDCLoginInput{Provider: "stub"})
+	require.NoError(t, err)
+
+	authURL, err := url.Parse(output.AuthorizationURL)
+	require.NoError(t, err)
+	query := authURL.Query()
+	require.Equal(t, storedKey, query.Get("state"))
+
+	s.codeChallenge = query.Get("code_challenge")
+	s.nonce = query.Get("nonce")
+	return storedKey, stored
+}
+
+func allowSessionStart(redis *mocks.MockRedis, userID string) {
+	redis.On("StoreAccessToken", mock.Anything, userID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, userID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreUserSession", mock.Anything, mock.Anything, userID, mock.Anything, mock.Anything, "127.0.0.1", "test-agent", mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, userID, mock.Anything, mock.Anything).Return(nil).Once()
+}
+
+func newCompleteOIDCLoginUseCase(
+	providers *oidc.Registry,
+	repo *mocks.MockUserRepository,
+	identityRepo *authMocks.MockExternalIdentityRepository,
+	publisher *mocks.MockPublisher,
+	redis *mocks.MockRedis,
+	twoFactorConfig config.TwoFactorConfig,
+) *usecases.CompleteOIDCLoginUseCase {
+	return usecases.NewCompleteOIDCLoginUseCase(repo, identityRepo, newNoTwoFactorRepo(), newSecurityEventRepo(), providers, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, twoFactorConfig)
+}
+
+func completeOIDCInput(state string) usecases.CompleteOIDCLoginInput {
+	return usecases.CompleteOIDCLoginInput{
+		Provider:  "stub",
+		Code:      stubCode,
+		State:     state,
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	}
+}
+
+func TestStartOIDCLogin_RedirectsWithPKCE(t *testing.T) {
+	issuer := newStubIssuer(t)
+	providers := issuer.registry()
+	redis := new(mocks.MockRedis)
+
+	var stored *utils.OIDCState
+	var storedKey string
+	redis.On("StoreOIDCState", mock.Anything, mock.Anything, mock.Anything, 10*time.Minute).Run(func(args mock.Arguments) {
+		storedKey = args.String(1)
+		stored = args.Get(2).(*utils.OIDCState)
+	}).Return(nil).Once()
+
+	uc := usecases.NewStartOIDCLoginUseCase(providers, redis, logger.NewNop(), config.OIDCConfig{StateTTL: 10 * time.Minute})
+	output, err := uc.Execute(context.Background(), usecases.StartOIDCLoginInput{Provider: "stub"})
+	require.NoError(t, err)
+
+	authURL, err := url.Parse(output.AuthorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, issuer.server.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+
+	query := authURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, stubClientID, query.Get("client_id"))
+	assert.Equal(t, stubRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, storedKey, query.Get("state"))
+
+	require.NotNil(t, stored)
+	assert.Equal(t, "stub", stored.Provider)
+	assert.Equal(t, stored.Nonce, query.Get("nonce"))
+	assert.Equal(t, oidc.CodeChallengeS256(stored.CodeVerifier), query.Get("code_challenge"))
+	assert.NotContains(t, output.AuthorizationURL, stored.CodeVerifier)
+}
+
+func TestStartOIDCLogin_UnknownProvider(t *testing.T) {
+	issuer := newStubIssuer(t)
+	redis := new(mocks.MockRedis)
+
+	uc := usecases.NewStartOIDCLoginUseCase(issuer.registry(), redis, logger.NewNop(), config.OIDCConfig{StateTTL: 10 * time.Minute})
+	_, err := uc.Execute(context.Background(), usecases.StartOIDCLoginInput{Provider: "unknown"})
+	assert.ErrorIs(t, err, usecases.ErrOIDCProviderNotFound)
+
+	redis.AssertNotCalled(t, "StoreOIDCState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteOIDCLogin_CreatesStudent(t *testing.T) {
+	issuer := newStubIssuer(t)
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	var created *entities.User
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(nil, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "new.student@example.com").Return(nil, nil).Once()
+	repo.On("FindByUsername", mock.Anything, "new.student").Return(nil, nil).Once()
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.User)
+	}).Return(nil).Once()
+	identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *authEntities.ExternalIdentity) bool {
+		return identity.Provider == "stub" && identity.Subject == stubSubject && identity.Email == "new.student@example.com"
+	})).Return(nil).Once()
+	allowSessionStart(redis, mock.Anything)
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthStudentRegistered, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthIdentityLinked, mock.MatchedBy(func(e events.AuthIdentityLinkedEvent) bool {
+		return e.UserCreated && e.Provider == "stub" && e.Subject == stubSubject
+	})).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, publisher, redis, config.TwoFactorConfig{})
+	result, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	require.NoError(t, err)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "new.student", created.Username)
+	assert.Equal(t, "student", created.Role.String())
+	assert.True(t, created.EmailVerified)
+	assert.NotEmpty(t, created.PasswordHash)
+
+	assert.NotEmpty(t, result.AccessToken)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.Equal(t, created.ID, result.User.ID)
+
+	repo.AssertExpectations(t)
+	identityRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCompleteOIDCLogin_LinksVerifiedAccount(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.email = "user@example.com"
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	user := newTwoFactorTestUser("student", "Password123!")
+	user.VerifyEmail()
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(nil, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *authEntities.ExternalIdentity) bool {
+		return identity.UserID == user.ID
+	})).Return(nil).Once()
+	allowSessionStart(redis, user.ID)
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthIdentityLinked, mock.MatchedBy(func(e events.AuthIdentityLinkedEvent) bool {
+		return !e.UserCreated && e.ID == user.ID
+	})).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, publisher, redis, config.TwoFactorConfig{})
+	result, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.User.ID)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	identityRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCompleteOIDCLogin_ExistingIdentity(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.email = "renamed@example.com"
+	issuer.emailVerified = false
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	user := newTwoFactorTestUser("student", "Password123!")
+	identity := authEntities.NewExternalIdentity(user.ID, "stub", stubSubject, "user@example.com")
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(identity, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	identityRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *authEntities.ExternalIdentity) bool {
+		return updated.Email == "renamed@example.com" && !updated.LastLoginAt.IsZero()
+	})).Return(nil).Once()
+	allowSessionStart(redis, user.ID)
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, publisher, redis, config.TwoFactorConfig{})
+	result, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.User.ID)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+	identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	identityRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCompleteOIDCLogin_ClaimsUnverifiedAccount(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.email = "user@example.com"
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	user := newTwoFactorTestUser("student", "Password123!")
+	squatterSession := &utils.SessionData{SessionID: "session-1", UserID: user.ID, AccessToken: "access-1", RefreshToken: "refresh-1"}
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(nil, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	repo.On("UpdatePassword", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
+	repo.On("UpdateEmailVerified", mock.Anything, user.ID, true).Return(nil).Once()
+	redis.On("ListUserSessions", mock.Anything, user.ID).Return([]*utils.SessionData{squatterSession}, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-1").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-1").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+	identityRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	allowSessionStart(redis, user.ID)
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthIdentityLinked, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, publisher, redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	require.NoError(t, err)
+
+	assert.True(t, user.EmailVerified)
+	assert.Error(t, utils.VerifyPassword("Password123!", user.PasswordHash))
+	repo.AssertExpectations(t)
+	redis.AssertExpectations(t)
+}
+
+func TestCompleteOIDCLogin_TwoFactorRequired(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.email = "user@example.com"
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	user := newTwoFactorTestUser("admin", "Password123!")
+	user.VerifyEmail()
+	identity := authEntities.NewExternalIdentity(user.ID, "stub", stubSubject, "user@example.com")
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(identity, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	identityRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreTwoFactorChallenge", mock.Anything, mock.Anything, mock.MatchedBy(func(c *utils.TwoFactorChallenge) bool {
+		return c.UserID == user.ID && c.SetupRequired
+	}), 5*time.Minute).Return(nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, publisher, redis, newTwoFactorTestConfig())
+	result, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	require.NoError(t, err)
+	assert.True(t, result.TwoFactorRequired)
+	assert.NotEmpty(t, result.ChallengeToken)
+	assert.Empty(t, result.AccessToken)
+
+	redis.AssertNotCalled(t, "StoreUserSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteOIDCLogin_UnverifiedEmail(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.emailVerified = "false"
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	repo := new(mocks.MockUserRepository)
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+	identityRepo.On("FindByProviderSubject", mock.Anything, "stub", stubSubject).Return(nil, nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, repo, identityRepo, new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	assert.ErrorIs(t, err, usecases.ErrOIDCEmailNotVerified)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
+
+func TestCompleteOIDCLogin_EmailDomainNotAllowed(t *testing.T) {
+	issuer := newStubIssuer(t)
+	providers := issuer.registry("school.edu")
+	state, stored := issuer.authorize(t, providers)
+
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	redis := new(mocks.MockRedis)
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, new(mocks.MockUserRepository), identityRepo, new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	assert.ErrorIs(t, err, usecases.ErrOIDCEmailDomainNotAllowed)
+
+	identityRepo.AssertNotCalled(t, "FindByProviderSubject", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteOIDCLogin_UnknownState(t *testing.T) {
+	issuer := newStubIssuer(t)
+	redis := new(mocks.MockRedis)
+	redis.On("ConsumeOIDCState", mock.Anything, "replayed-state").Return(nil, utils.ErrOIDCStateNotFound).Once()
+
+	uc := newCompleteOIDCLoginUseCase(issuer.registry(), new(mocks.MockUserRepository), new(authMocks.MockExternalIdentityRepository), new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput("replayed-state"))
+	assert.ErrorIs(t, err, usecases.ErrInvalidOIDCState)
+}
+
+func TestCompleteOIDCLogin_StateFromOtherProvider(t *testing.T) {
+	issuer := newStubIssuer(t)
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+	stored.Provider = "other"
+
+	redis := new(mocks.MockRedis)
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, new(mocks.MockUserRepository), new(authMocks.MockExternalIdentityRepository), new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	assert.ErrorIs(t, err, usecases.ErrInvalidOIDCState)
+}
+
+func TestCompleteOIDCLogin_WrongCodeVerifier(t *testing.T) {
+	issuer := newStubIssuer(t)
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+	stored.CodeVerifier = "intercepted-verifier"
+
+	redis := new(mocks.MockRedis)
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, new(mocks.MockUserRepository), new(authMocks.MockExternalIdentityRepository), new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	assert.ErrorIs(t, err, usecases.ErrOIDCLoginFailed)
+}
+
+func TestCompleteOIDCLogin_NonceMismatch(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.tokenNonce = "another-nonce"
+	providers := issuer.registry()
+	state, stored := issuer.authorize(t, providers)
+
+	identityRepo := new(authMocks.MockExternalIdentityRepository)
+	redis := new(mocks.MockRedis)
+	redis.On("ConsumeOIDCState", mock.Anything, state).Return(stored, nil).Once()
+
+	uc := newCompleteOIDCLoginUseCase(providers, new(mocks.MockUserRepository), identityRepo, new(mocks.MockPublisher), redis, config.TwoFactorConfig{})
+	_, err := uc.Execute(context.Background(), completeOIDCInput(state))
+	assert.ErrorIs(t, err, usecases.ErrOIDCLoginFailed)
+
+	identityRepo.AssertNotCalled(t, "FindByProviderSubject", mock.Anything, mock.Anything, mock.Anything)
+}
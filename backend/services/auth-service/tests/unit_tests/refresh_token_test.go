@@ -0,0 +1,234 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken_MissingToken(t *testing.T) {
+	uc := usecases.NewRefreshTokenUseCase(new(mocks.MockUserRepository), newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), new(mocks.MockRedis))
+
+	_, err := uc.Execute(context.Background(), usecases.RefreshTokenInput{})
+	require.ErrorIs(t, err, usecases.ErrRefreshTokenRequired)
+}
+
+func TestRefreshToken_RotatesToken(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	refreshToken, _ := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	family := &utils.RefreshTokenFamily{FamilyID: "session-1", UserID: user.ID, CurrentTokenHash: utils.HashToken(refreshToken)}
+
+	redis.On("GetRefreshTokenFamily", mock.Anything, "session-1").Return(family, nil).Once()
+	redis.On("GetUserFromRefreshToken", mock.Anything, refreshToken).Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("RotateRefreshTokenFamily", mock.Anything, "session-1", refreshToken, mock.Anything, jwtManager.RefreshTokenDuration()).Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, refreshToken).Return(nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("GetUserSession", mock.Anything, "session-1").Return(&utils.SessionData{UserID: user.ID, SessionID: "session-1", AccessToken: "access-1"}, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-1").Return(nil).Once()
+	redis.On("UpdateUserSessionTokens", mock.Anything, "session-1", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewRefreshTokenUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.RefreshTokenInput{RefreshToken: refreshToken})
+	require.NoError(t, err)
+	assert.NotEmpty(t, output.AccessToken)
+	assert.NotEqual(t, refreshToken, output.RefreshToken)
+
+	claims, err := jwtManager.VerifyToken(output.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", claims.SessionID)
+
+	redis.AssertExpectations(t)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	usedToken, _ := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	currentToken, _ := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	family := &utils.RefreshTokenFamily{FamilyID: "session-1", UserID: user.ID, CurrentTokenHash: utils.HashToken(currentToken)}
+	session := &utils.SessionData{UserID: user.ID, SessionID: "session-1", AccessToken: "access-1", RefreshToken: currentToken}
+
+	redis.On("GetRefreshTokenFamily", mock.Anything, "session-1").Return(family, nil).Once()
+	redis.On("GetUserSession", mock.Anything, "session-1").Return(session, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-1").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, currentToken).Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthRefreshTokenReused, mock.MatchedBy(func(e events.AuthRefreshTokenReusedEvent) bool {
+		return e.ID == user.ID && e.FamilyID == "session-1" && e.Email == user.Email.String() && e.IPAddress == "10.0.0.9"
+	})).Return(nil).Once()
+
+	uc := usecases.NewRefreshTokenUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RefreshTokenInput{RefreshToken: usedToken, IPAddress: "10.0.0.9"})
+	require.ErrorIs(t, err, usecases.ErrRefreshTokenReused)
+
+	redis.AssertNotCalled(t, "GetUserFromRefreshToken", mock.Anything, mock.Anything)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestRefreshToken_ConcurrentRotationTreatedAsReuse(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	refreshToken, _ := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	family := &utils.RefreshTokenFamily{FamilyID: "session-1", UserID: user.ID, CurrentTokenHash: utils.HashToken(refreshToken)}
+
+	redis.On("GetRefreshTokenFamily", mock.Anything, "session-1").Return(family, nil).Once()
+	redis.On("GetUserFromRefreshToken", mock.Anything, refreshToken).Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	redis.On("RotateRefreshTokenFamily", mock.Anything, "session-1", refreshToken, mock.Anything, mock.Anything).Return(utils.ErrRefreshTokenReused).Once()
+	redis.On("GetUserSession", mock.Anything, "session-1").Return(nil, errors.New("not found")).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthRefreshTokenReused, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewRefreshTokenUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RefreshTokenInput{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, usecases.ErrRefreshTokenReused)
+
+	redis.AssertNotCalled(t, "StoreRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestRefreshToken_RevokedFamily(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	refreshToken, _ := jwtManager.GenerateRefreshToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetRefreshTokenFamily", mock.Anything, "session-1").Return(nil, errors.New("not found")).Once()
+
+	uc := usecases.NewRefreshTokenUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RefreshTokenInput{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, usecases.ErrInvalidRefreshToken)
+
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// sessionStore keeps access tokens and sessions in memory, so a test can
+// follow a token through refresh and logout. Everything else is mocked.
+type sessionStore struct {
+	*mocks.MockRedis
+	accessTokens map[string]string
+	sessions     map[string]*utils.SessionData
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{
+		MockRedis:    new(mocks.MockRedis),
+		accessTokens: map[string]string{},
+		sessions:     map[string]*utils.SessionData{},
+	}
+}
+
+func (s *sessionStore) StoreAccessToken(ctx context.Context, userID, accessToken string, expiration time.Duration) error {
+	s.accessTokens[accessToken] = userID
+	return nil
+}
+
+func (s *sessionStore) GetUserFromAccessToken(ctx context.Context, accessToken string) (string, error) {
+	userID, ok := s.accessTokens[accessToken]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return userID, nil
+}
+
+func (s *sessionStore) RevokeAccessToken(ctx context.Context, accessToken string) error {
+	delete(s.accessTokens, accessToken)
+	return nil
+}
+
+func (s *sessionStore) GetUserSession(ctx context.Context, sessionID string) (*utils.SessionData, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *sessionStore) UpdateUserSessionTokens(ctx context.Context, sessionID, accessToken, refreshToken string, expiration time.Duration) error {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return errors.New("not found")
+	}
+	session.AccessToken = accessToken
+	session.RefreshToken = refreshToken
+	return nil
+}
+
+func (s *sessionStore) DeleteUserSession(ctx context.Context, sessionID string) error {
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+func TestRefreshToken_LogoutRevokesReplacedAccessToken(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := newSessionStore()
+	jwtManager := setupJwtManagerForUnit()
+	ctx := context.Background()
+
+	firstAccessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	refreshToken, _ := jwtManager.GenerateRefreshToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	require.NoError(t, redis.StoreAccessToken(ctx, user.ID, firstAccessToken, jwtManager.AccessTokenDuration()))
+	redis.sessions["session-1"] = &utils.SessionData{UserID: user.ID, SessionID: "session-1", AccessToken: firstAccessToken, RefreshToken: refreshToken}
+	family := &utils.RefreshTokenFamily{FamilyID: "session-1", UserID: user.ID, CurrentTokenHash: utils.HashToken(refreshToken)}
+
+	redis.On("GetRefreshTokenFamily", mock.Anything, "session-1").Return(family, nil).Once()
+	redis.On("GetUserFromRefreshToken", mock.Anything, refreshToken).Return(user.ID, nil).Once()
+	redis.On("RotateRefreshTokenFamily", mock.Anything, "session-1", refreshToken, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, mock.Anything).Return(nil)
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedOut, mock.Anything).Return(nil).Once()
+
+	refreshed, err := usecases.NewRefreshTokenUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis).
+		Execute(ctx, usecases.RefreshTokenInput{RefreshToken: refreshToken})
+	require.NoError(t, err)
+
+	logout := usecases.NewLogoutUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+	_, err = logout.Execute(ctx, usecases.LogoutInput{AccessToken: refreshed.AccessToken})
+	require.NoError(t, err)
+
+	_, err = logout.Execute(ctx, usecases.LogoutInput{AccessToken: firstAccessToken})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
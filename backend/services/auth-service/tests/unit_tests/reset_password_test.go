@@ -22,7 +22,7 @@ func TestResetPassword_MissingToken(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		NewPassword: "NewPassword123!",
@@ -41,7 +41,7 @@ func TestResetPassword_MissingPassword(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:     "valid-token",
@@ -60,7 +60,7 @@ func TestResetPassword_MissingIPAddress(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "valid-token",
@@ -79,7 +79,7 @@ func TestResetPassword_MissingUserAgent(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "valid-token",
@@ -100,7 +100,7 @@ func TestResetPassword_InvalidToken(t *testing.T) {
 
 	redis.On("GetUserFromResetPasswordToken", mock.Anything, "invalid-token").Return("", nil).Once()
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "invalid-token",
@@ -121,7 +121,7 @@ func TestResetPassword_InvalidNewPassword(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "valid-token",
@@ -148,7 +148,7 @@ func TestResetPassword_UserNotFound(t *testing.T) {
 	redis.On("RevokeResetPasswordToken", mock.Anything, "valid-token").Return(nil).Once()
 	repo.On("FindByID", mock.Anything, "user-id").Return((*entities.User)(nil), nil).Once()
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "valid-token",
@@ -181,7 +181,7 @@ func TestResetPassword_Success(t *testing.T) {
 	repo.On("UpdatePassword", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserResetPassword, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewResetPasswordUseCase(repo, publisher, logger, redis)
+	uc := usecases.NewResetPasswordUseCase(repo, newSecurityEventRepo(), publisher, logger, redis)
 
 	result, err := uc.Execute(context.Background(), usecases.ResetPasswordInput{
 		Token:      "valid-token",
@@ -0,0 +1,91 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeSession_MissingSessionID(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	uc := usecases.NewRevokeSessionUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RevokeSessionInput{AccessToken: "token"})
+	require.ErrorIs(t, err, usecases.ErrSessionIDRequired)
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	redis.On("GetUserSession", mock.Anything, "missing").Return(nil, errors.New("not found")).Once()
+
+	uc := usecases.NewRevokeSessionUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RevokeSessionInput{AccessToken: accessToken, SessionID: "missing"})
+	require.ErrorIs(t, err, usecases.ErrSessionNotFound)
+
+	redis.AssertExpectations(t)
+}
+
+func TestRevokeSession_OtherUsersSession(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	redis.On("GetUserSession", mock.Anything, "session-9").Return(&utils.SessionData{UserID: "other-user", SessionID: "session-9"}, nil).Once()
+
+	uc := usecases.NewRevokeSessionUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RevokeSessionInput{AccessToken: accessToken, SessionID: "session-9"})
+	require.ErrorIs(t, err, usecases.ErrSessionNotFound)
+
+	redis.AssertNotCalled(t, "DeleteUserSession", mock.Anything, mock.Anything)
+}
+
+func TestRevokeSession_Success(t *testing.T) {
+	user := newSessionTestUser()
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	session := &utils.SessionData{UserID: user.ID, SessionID: "session-2", AccessToken: "access-2", RefreshToken: "refresh-2"}
+
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	redis.On("GetUserSession", mock.Anything, "session-2").Return(session, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-2").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-2").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-2").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-2").Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedOut, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewRevokeSessionUseCase(repo, newSecurityEventRepo(), publisher, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RevokeSessionInput{AccessToken: accessToken, SessionID: "session-2"})
+	require.NoError(t, err)
+
+	redis.AssertNotCalled(t, "RevokeAccessToken", mock.Anything, accessToken)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
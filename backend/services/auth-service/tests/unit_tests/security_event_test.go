@@ -0,0 +1,194 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	authEntities "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const chromeOnMac = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+
+// newSecurityEventRepo returns a repository that stores events and knows
+// every login source, so no new login alerts are sent.
+func newSecurityEventRepo() *authMocks.MockSecurityEventRepository {
+	repo := new(authMocks.MockSecurityEventRepository)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	repo.On("FindLoginSource", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&authEntities.LoginSource{HasPriorLogins: true, KnownIP: true, KnownDevice: true}, nil).Maybe()
+	return repo
+}
+
+// loginWithSource logs a student in with password, with the given history of
+// where they logged in before.
+func loginWithSource(t *testing.T, source *authEntities.LoginSource, publisher *mocks.MockPublisher) *authMocks.MockSecurityEventRepository {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+	allowAttempts(redis)
+
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, "203.0.113.9", chromeOnMac, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.MatchedBy(func(e events.AuthUserLoggedInEvent) bool {
+		return e.IPAddress == "203.0.113.9" && e.UserAgent == chromeOnMac
+	})).Return(nil).Once()
+	securityEventRepo.On("FindLoginSource", mock.Anything, user.ID, "203.0.113.9", "Chrome on macOS").Return(source, nil).Once()
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *authEntities.SecurityEvent) bool {
+		return e.UserID == user.ID && e.Type == authEntities.SecurityEventLogin && e.Detail == "password" && e.Device == "Chrome on macOS"
+	})).Return(nil).Once()
+
+	uc := usecases.NewLoginUseCase(repo, newNoTwoFactorRepo(), securityEventRepo, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, config.TwoFactorConfig{}, newLockoutTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "203.0.113.9",
+		UserAgent: chromeOnMac,
+	})
+	require.NoError(t, err)
+	return securityEventRepo
+}
+
+func TestLogin_FromNewIP_PublishesAlert(t *testing.T) {
+	publisher := new(mocks.MockPublisher)
+	var alert events.AuthNewLoginDetectedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthNewLoginDetected, mock.Anything).Run(func(args mock.Arguments) {
+		alert = args.Get(2).(events.AuthNewLoginDetectedEvent)
+	}).Return(nil).Once()
+
+	securityEventRepo := loginWithSource(t, &authEntities.LoginSource{HasPriorLogins: true, KnownIP: false, KnownDevice: true}, publisher)
+
+	securityEventRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+	assert.Equal(t, "user@example.com", alert.Email)
+	assert.Equal(t, "203.0.113.9", alert.IPAddress)
+	assert.Equal(t, "Chrome on macOS", alert.Device)
+	assert.True(t, alert.NewIP)
+	assert.False(t, alert.NewDevice)
+}
+
+func TestLogin_KnownSourceOrFirstLogin_NoAlert(t *testing.T) {
+	sources := map[string]*authEntities.LoginSource{
+		"known source": {HasPriorLogins: true, KnownIP: true, KnownDevice: true},
+		"first login":  {},
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			publisher := new(mocks.MockPublisher)
+
+			securityEventRepo := loginWithSource(t, source, publisher)
+
+			securityEventRepo.AssertExpectations(t)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything, events.EventTypeAuthNewLoginDetected, mock.Anything)
+		})
+	}
+}
+
+func TestLogin_InvalidPassword_RecordsFailedLogin(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+	allowAttempts(redis)
+
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	securityEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *authEntities.SecurityEvent) bool {
+		return e.UserID == user.ID && e.Type == authEntities.SecurityEventLoginFailed && e.IPAddress == "203.0.113.9"
+	})).Return(nil).Once()
+
+	uc := usecases.NewLoginUseCase(repo, newNoTwoFactorRepo(), securityEventRepo, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, config.TwoFactorConfig{}, newLockoutTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "WrongPassword123!",
+		IPAddress: "203.0.113.9",
+		UserAgent: chromeOnMac,
+	})
+	require.ErrorIs(t, err, usecases.ErrInvalidPassword)
+
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestListSecurityEvents_Own(t *testing.T) {
+	jwtManager := setupJwtManagerForUnit()
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	event := authEntities.NewSecurityEvent("user-id", authEntities.SecurityEventLogin, "password", "203.0.113.9", chromeOnMac, "Chrome on macOS")
+	securityEventRepo.On("ListByUserID", mock.Anything, "user-id", 50).Return([]*authEntities.SecurityEvent{event}, nil).Once()
+
+	uc := usecases.NewListSecurityEventsUseCase(new(mocks.MockUserRepository), securityEventRepo, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.ListSecurityEventsInput{AccessToken: accessToken})
+	require.NoError(t, err)
+	require.Len(t, output.Events, 1)
+	assert.Equal(t, authEntities.SecurityEventLogin, output.Events[0].Type)
+	assert.Equal(t, "Chrome on macOS", output.Events[0].Device)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestListSecurityEvents_AdminViewsAnyUser(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	jwtManager := setupJwtManagerForUnit()
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+
+	accessToken, _ := jwtManager.GenerateAccessToken("admin-id", "admin@example.com", "admin", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("admin-id", nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	securityEventRepo.On("ListByUserID", mock.Anything, user.ID, 100).Return([]*authEntities.SecurityEvent{}, nil).Once()
+
+	uc := usecases.NewListSecurityEventsUseCase(repo, securityEventRepo, logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.ListSecurityEventsInput{AccessToken: accessToken, UserID: user.ID, Limit: 500})
+	require.NoError(t, err)
+	assert.Empty(t, output.Events)
+	securityEventRepo.AssertExpectations(t)
+}
+
+func TestListSecurityEvents_TenantAdminOnlyViewsOwnOrganization(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+
+	accessToken := tenantAdminAccessToken(t, redis)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewListSecurityEventsUseCase(repo, securityEventRepo, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.ListSecurityEventsInput{AccessToken: accessToken, UserID: user.ID})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+	securityEventRepo.AssertNotCalled(t, "ListByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListSecurityEvents_OtherUserRequiresAdmin(t *testing.T) {
+	jwtManager := setupJwtManagerForUnit()
+	redis := new(mocks.MockRedis)
+	securityEventRepo := new(authMocks.MockSecurityEventRepository)
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+
+	uc := usecases.NewListSecurityEventsUseCase(new(mocks.MockUserRepository), securityEventRepo, logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.ListSecurityEventsInput{AccessToken: accessToken, UserID: "other-user-id"})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+	securityEventRepo.AssertNotCalled(t, "ListByUserID", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,426 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyFixture struct {
+	accounts *authMocks.MockServiceAccountRepository
+	keys     *authMocks.MockAPIKeyRepository
+	account  *entities.ServiceAccount
+	key      *entities.APIKey
+	plainKey string
+}
+
+func newAPIKeyFixture(t *testing.T, scopes ...string) *apiKeyFixture {
+	plainKey, prefix, err := authUtils.GenerateAPIKey()
+	require.NoError(t, err)
+
+	account := entities.NewServiceAccount("sis-integration", "", valueobjects.RoleAdmin, nil, "admin-id")
+	key := entities.NewAPIKey(account.ID, "nightly", prefix, utils.HashToken(plainKey), scopes, nil, "admin-id")
+
+	f := &apiKeyFixture{
+		accounts: new(authMocks.MockServiceAccountRepository),
+		keys:     new(authMocks.MockAPIKeyRepository),
+		account:  account,
+		key:      key,
+		plainKey: plainKey,
+	}
+	f.keys.On("FindByPrefix", mock.Anything, prefix).Return(key, nil).Maybe()
+	f.accounts.On("FindByID", mock.Anything, account.ID).Return(account, nil).Maybe()
+	return f
+}
+
+func (f *apiKeyFixture) verifyUseCase() *usecases.VerifyUseCase {
+	return usecases.NewVerifyUseCase(new(mocks.MockUserRepository), f.accounts, f.keys, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), new(mocks.MockRedis), config.UserCacheConfig{})
+}
+
+func TestVerify_APIKey_Success(t *testing.T) {
+	f := newAPIKeyFixture(t, "users:read")
+	f.keys.On("UpdateLastUsed", mock.Anything, f.key.ID, "10.0.0.1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	user, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:          f.plainKey,
+		RequiredRole:   "admin",
+		OriginalMethod: "GET",
+		OriginalURI:    "/api/v1/users?page=2",
+		IPAddress:      "10.0.0.1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, f.account.ID, user.ID)
+	assert.Equal(t, "admin", user.Role)
+
+	f.keys.AssertExpectations(t)
+}
+
+func TestVerify_APIKey_RecentUseNotRewritten(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.key.MarkUsed("10.0.0.1", time.Now().UTC().Add(-10*time.Second))
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:       f.plainKey,
+		OriginalURI: "/api/v1/courses",
+		IPAddress:   "10.0.0.1",
+	})
+	require.NoError(t, err)
+
+	f.keys.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerify_APIKey_WrongSecret(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token: f.plainKey + "x",
+	})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+}
+
+func TestVerify_APIKey_Revoked(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.key.Revoke()
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{Token: f.plainKey})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+}
+
+func TestVerify_APIKey_Expired(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	expired := time.Now().UTC().Add(-time.Hour)
+	f.key.ExpiresAt = &expired
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{Token: f.plainKey})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+}
+
+func TestVerify_APIKey_DisabledAccount(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.account.Disable()
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{Token: f.plainKey})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+}
+
+func TestVerify_APIKey_OutOfScope(t *testing.T) {
+	f := newAPIKeyFixture(t, "users:read")
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:          f.plainKey,
+		OriginalMethod: "POST",
+		OriginalURI:    "/api/v1/users",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+
+	_, err = f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:          f.plainKey,
+		OriginalMethod: "GET",
+		OriginalURI:    "/api/v1/courses",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+}
+
+func TestVerify_APIKey_RoleMismatch(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.account.Role = valueobjects.RoleStudent
+	organizationID := testOrganizationID
+	f.account.OrganizationID = &organizationID
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:        f.plainKey,
+		RequiredRole: "instructor,admin",
+		OriginalURI:  "/api/v1/courses",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+}
+
+func TestVerify_APIKey_CarriesOrganization(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.account.Role = valueobjects.RoleInstructor
+	organizationID := testOrganizationID
+	f.account.OrganizationID = &organizationID
+	f.keys.On("UpdateLastUsed", mock.Anything, f.key.ID, mock.Anything, mock.AnythingOfType("time.Time")).Return(nil).Maybe()
+
+	user, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:       f.plainKey,
+		OriginalURI: "/api/v1/courses",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, user.OrganizationID)
+	assert.Equal(t, testOrganizationID, *user.OrganizationID)
+}
+
+func TestVerify_APIKey_UnscopedNonAdminRefused(t *testing.T) {
+	f := newAPIKeyFixture(t, entities.ScopeAll)
+	f.account.Role = valueobjects.RoleInstructor
+
+	_, err := f.verifyUseCase().Execute(context.Background(), usecases.VerifyInput{
+		Token:       f.plainKey,
+		OriginalURI: "/api/v1/courses",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+}
+
+func TestAPIKey_AllowsRequest(t *testing.T) {
+	key := &entities.APIKey{Scopes: []string{"enrollments:*", "users:read"}}
+
+	assert.True(t, key.AllowsRequest("POST", "/api/v1/enrollments/abc"))
+	assert.True(t, key.AllowsRequest("GET", "/api/v1/users"))
+	assert.False(t, key.AllowsRequest("DELETE", "/api/v1/users/abc"))
+	assert.False(t, key.AllowsRequest("GET", "/api/v1/courses"))
+	assert.False(t, key.AllowsRequest("GET", ""))
+}
+
+func adminAccessToken(t *testing.T, redis *mocks.MockRedis, role string) string {
+	token, err := setupJwtManagerForUnit().GenerateAccessToken("admin-id", "admin@example.com", role, "active", "session-1", "")
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return("admin-id", nil)
+	return token
+}
+
+// tenantAdminAccessToken signs in an admin of testOrganizationID.
+func tenantAdminAccessToken(t *testing.T, redis *mocks.MockRedis) string {
+	token, err := setupJwtManagerForUnit().GenerateAccessToken("tenant-admin-id", "tenant-admin@example.com", "admin", "active", "session-1", testOrganizationID)
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return("tenant-admin-id", nil)
+	return token
+}
+
+func TestCreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	keys := new(authMocks.MockAPIKeyRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	account := entities.NewServiceAccount("reporting", "", valueobjects.RoleAdmin, nil, "admin-id")
+	accounts.On("FindByID", mock.Anything, account.ID).Return(account, nil).Once()
+
+	var stored *entities.APIKey
+	keys.On("Create", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*entities.APIKey)
+	}).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthAPIKeyCreated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewCreateAPIKeyUseCase(accounts, keys, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	output, err := uc.Execute(context.Background(), usecases.CreateAPIKeyInput{
+		AccessToken:      token,
+		ServiceAccountID: account.ID,
+		Name:             "weekly-report",
+		Scopes:           []string{"users:read"},
+		ExpiresAt:        &expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+
+	assert.True(t, authUtils.IsAPIKey(output.Key))
+	assert.Equal(t, utils.HashToken(output.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, output.Prefix)
+	assert.True(t, output.Active)
+
+	keys.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCreateAPIKey_Validation(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+	past := time.Now().Add(-time.Minute)
+
+	uc := usecases.NewCreateAPIKeyUseCase(new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	tests := []struct {
+		name  string
+		input usecases.CreateAPIKeyInput
+		err   error
+	}{
+		{"no scopes", usecases.CreateAPIKeyInput{Name: "k"}, usecases.ErrAPIKeyScopesRequired},
+		{"bad scope", usecases.CreateAPIKeyInput{Name: "k", Scopes: []string{"users:delete"}}, usecases.ErrInvalidAPIKeyScope},
+		{"past expiry", usecases.CreateAPIKeyInput{Name: "k", Scopes: []string{"*"}, ExpiresAt: &past}, usecases.ErrInvalidAPIKeyExpiry},
+		{"no name", usecases.CreateAPIKeyInput{Scopes: []string{"*"}}, usecases.ErrAPIKeyNameRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.AccessToken = token
+			_, err := uc.Execute(context.Background(), tt.input)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCreateServiceAccount_RequiresAdmin(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "instructor")
+
+	uc := usecases.NewCreateServiceAccountUseCase(accounts, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.CreateServiceAccountInput{
+		AccessToken: token,
+		Name:        "sis-integration",
+		Role:        "admin",
+	})
+	require.ErrorIs(t, err, usecases.ErrInsufficientPermissions)
+
+	accounts.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRevokeAPIKey_OtherAccountsKey(t *testing.T) {
+	keys := new(authMocks.MockAPIKeyRepository)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	key := entities.NewAPIKey("account-1", "k", "abc", "hash", []string{"*"}, nil, "admin-id")
+	keys.On("FindByID", mock.Anything, key.ID).Return(key, nil).Once()
+
+	uc := usecases.NewRevokeAPIKeyUseCase(new(authMocks.MockServiceAccountRepository), keys, new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.RevokeAPIKeyInput{
+		AccessToken:      token,
+		ServiceAccountID: "account-2",
+		APIKeyID:         key.ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrAPIKeyNotFound)
+
+	keys.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDisableServiceAccount_RevokesKeys(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	keys := new(authMocks.MockAPIKeyRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	account := entities.NewServiceAccount("sis-integration", "", valueobjects.RoleAdmin, nil, "admin-id")
+	accounts.On("FindByID", mock.Anything, account.ID).Return(account, nil).Once()
+	accounts.On("Update", mock.Anything, account).Return(nil).Once()
+	keys.On("RevokeByServiceAccountID", mock.Anything, account.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthServiceAccountDisabled, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewDisableServiceAccountUseCase(accounts, keys, publisher, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	output, err := uc.Execute(context.Background(), usecases.DisableServiceAccountInput{
+		AccessToken:      token,
+		ServiceAccountID: account.ID,
+	})
+	require.NoError(t, err)
+	assert.True(t, output.Disabled)
+
+	accounts.AssertExpectations(t)
+	keys.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCreateServiceAccount_TenantAdminCreatesInOwnOrganization(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	redis := new(mocks.MockRedis)
+	token := tenantAdminAccessToken(t, redis)
+
+	accounts.On("FindByName", mock.Anything, "sis-integration").Return(nil, nil).Once()
+	accounts.On("Create", mock.Anything, mock.MatchedBy(func(a *entities.ServiceAccount) bool {
+		return a.OrganizationID != nil && *a.OrganizationID == testOrganizationID
+	})).Return(nil).Once()
+
+	uc := usecases.NewCreateServiceAccountUseCase(accounts, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	otherOrganization := "22222222-2222-2222-2222-222222222222"
+	output, err := uc.Execute(context.Background(), usecases.CreateServiceAccountInput{
+		AccessToken:    token,
+		Name:           "sis-integration",
+		Role:           "admin",
+		OrganizationID: &otherOrganization,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output.OrganizationID)
+	assert.Equal(t, testOrganizationID, *output.OrganizationID)
+	accounts.AssertExpectations(t)
+}
+
+func TestCreateServiceAccount_NonAdminNeedsOrganization(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	redis := new(mocks.MockRedis)
+	token := adminAccessToken(t, redis, "admin")
+
+	uc := usecases.NewCreateServiceAccountUseCase(accounts, logger.NewNop(), setupJwtManagerForUnit(), redis)
+
+	_, err := uc.Execute(context.Background(), usecases.CreateServiceAccountInput{
+		AccessToken: token,
+		Name:        "sis-integration",
+		Role:        "instructor",
+	})
+	require.ErrorIs(t, err, usecases.ErrServiceAccountOrganizationRequired)
+
+	accounts.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestServiceAccounts_TenantAdminCannotManageOtherAccounts(t *testing.T) {
+	accounts := new(authMocks.MockServiceAccountRepository)
+	keys := new(authMocks.MockAPIKeyRepository)
+	redis := new(mocks.MockRedis)
+	token := tenantAdminAccessToken(t, redis)
+
+	organizationID := testOrganizationID
+	own := entities.NewServiceAccount("own", "", valueobjects.RoleInstructor, &organizationID, "tenant-admin-id")
+	unscoped := entities.NewServiceAccount("platform", "", valueobjects.RoleAdmin, nil, "admin-id")
+	accounts.On("List", mock.Anything).Return([]*entities.ServiceAccount{own, unscoped}, nil).Once()
+	accounts.On("FindByID", mock.Anything, unscoped.ID).Return(unscoped, nil)
+	key := entities.NewAPIKey(unscoped.ID, "k", "abc", "hash", []string{"*"}, nil, "admin-id")
+	keys.On("FindByID", mock.Anything, key.ID).Return(key, nil).Once()
+
+	jwtManager := setupJwtManagerForUnit()
+	list, err := usecases.NewListServiceAccountsUseCase(accounts, logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.ListServiceAccountsInput{AccessToken: token})
+	require.NoError(t, err)
+	require.Len(t, list.ServiceAccounts, 1)
+	assert.Equal(t, own.ID, list.ServiceAccounts[0].ID)
+	assert.Equal(t, 1, list.Total)
+
+	_, err = usecases.NewDisableServiceAccountUseCase(accounts, keys, new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.DisableServiceAccountInput{
+		AccessToken:      token,
+		ServiceAccountID: unscoped.ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrServiceAccountNotFound)
+
+	_, err = usecases.NewCreateAPIKeyUseCase(accounts, keys, new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.CreateAPIKeyInput{
+		AccessToken:      token,
+		ServiceAccountID: unscoped.ID,
+		Name:             "stolen",
+		Scopes:           []string{"*"},
+	})
+	require.ErrorIs(t, err, usecases.ErrServiceAccountNotFound)
+
+	_, err = usecases.NewListAPIKeysUseCase(accounts, keys, logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.ListAPIKeysInput{
+		AccessToken:      token,
+		ServiceAccountID: unscoped.ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrServiceAccountNotFound)
+
+	_, err = usecases.NewRevokeAPIKeyUseCase(accounts, keys, new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis).Execute(context.Background(), usecases.RevokeAPIKeyInput{
+		AccessToken:      token,
+		ServiceAccountID: unscoped.ID,
+		APIKeyID:         key.ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrAPIKeyNotFound)
+
+	accounts.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	keys.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	keys.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+	keys.AssertNotCalled(t, "ListByServiceAccountID", mock.Anything, mock.Anything)
+}
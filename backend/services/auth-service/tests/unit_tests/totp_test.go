@@ -0,0 +1,87 @@
+package unit_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 test key from RFC 6238 appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		code, err := authUtils.GenerateTOTPCode(rfc6238Secret, authUtils.TOTPStep(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+}
+
+func TestValidateTOTPCode_AcceptsAdjacentStep(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	previous, err := authUtils.GenerateTOTPCode(rfc6238Secret, authUtils.TOTPStep(now)-1)
+	require.NoError(t, err)
+
+	step, ok := authUtils.ValidateTOTPCode(rfc6238Secret, previous, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, authUtils.TOTPStep(now)-1, step)
+}
+
+func TestValidateTOTPCode_RejectsReplay(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := authUtils.GenerateTOTPCode(rfc6238Secret, authUtils.TOTPStep(now))
+	require.NoError(t, err)
+
+	step, ok := authUtils.ValidateTOTPCode(rfc6238Secret, code, now, 0)
+	require.True(t, ok)
+
+	_, ok = authUtils.ValidateTOTPCode(rfc6238Secret, code, now, step)
+	assert.False(t, ok)
+}
+
+func TestValidateTOTPCode_RejectsWrongCode(t *testing.T) {
+	_, ok := authUtils.ValidateTOTPCode(rfc6238Secret, "000000", time.Unix(1234567890, 0), 0)
+	assert.False(t, ok)
+
+	_, ok = authUtils.ValidateTOTPCode(rfc6238Secret, "12345", time.Unix(1234567890, 0), 0)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := authUtils.TOTPURI("Asto LMS", "user@example.com", "SECRET")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Asto%20LMS:user@example.com?"))
+	assert.Contains(t, uri, "secret=SECRET")
+	assert.Contains(t, uri, "issuer=Asto+LMS")
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := authUtils.GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "abcdefghij", authUtils.NormalizeRecoveryCode(" ABCDE-fghij "))
+	assert.Equal(t, "abcdefghij", authUtils.NormalizeRecoveryCode("abcde fghij"))
+}
@@ -0,0 +1,327 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	authEntities "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authUtils "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/utils"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTwoFactorTestConfig() config.TwoFactorConfig {
+	return config.TwoFactorConfig{
+		Issuer:        "Asto LMS",
+		RequiredRoles: []string{"admin"},
+		ChallengeTTL:  5 * time.Minute,
+		MaxAttempts:   3,
+	}
+}
+
+func newTwoFactorTestUser(role, password string) *entities.User {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole(role)
+	passwordHash, _ := utils.HashPassword(password)
+	return entities.NewUser(emailVO, "user", roleVO, passwordHash)
+}
+
+func newEnabledTwoFactor(t *testing.T, userID string) *authEntities.TwoFactor {
+	secret, err := authUtils.GenerateTOTPSecret()
+	require.NoError(t, err)
+	twoFactor := authEntities.NewTwoFactor(userID, secret)
+	twoFactor.Enable()
+	return twoFactor
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := authUtils.GenerateTOTPCode(secret, authUtils.TOTPStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func TestLogin_TwoFactorEnabled_ReturnsChallenge(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(newEnabledTwoFactor(t, user.ID), nil).Once()
+	redis.On("StoreTwoFactorChallenge", mock.Anything, mock.Anything, mock.MatchedBy(func(c *utils.TwoFactorChallenge) bool {
+		return c.UserID == user.ID && !c.SetupRequired && c.IPAddress == "127.0.0.1"
+	}), 5*time.Minute).Return(nil).Once()
+
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig(), newLockoutTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:     "user@example.com",
+		Password:  "Password123!",
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.TwoFactorRequired)
+	assert.False(t, result.TwoFactorSetupRequired)
+	assert.NotEmpty(t, result.ChallengeToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Nil(t, result.User)
+
+	redis.AssertNotCalled(t, "StoreUserSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	redis.AssertExpectations(t)
+}
+
+func TestLogin_TwoFactorRequiredForRole_ReturnsSetupChallenge(t *testing.T) {
+	user := newTwoFactorTestUser("admin", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(nil, nil).Once()
+	redis.On("StoreTwoFactorChallenge", mock.Anything, mock.Anything, mock.MatchedBy(func(c *utils.TwoFactorChallenge) bool {
+		return c.UserID == user.ID && c.SetupRequired
+	}), mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLoginUseCase(repo, twoFactorRepo, newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig(), newLockoutTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.LoginInput{
+		Email:    "user@example.com",
+		Password: "Password123!",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.TwoFactorRequired)
+	assert.True(t, result.TwoFactorSetupRequired)
+	assert.NotEmpty(t, result.ChallengeToken)
+
+	redis.AssertExpectations(t)
+}
+
+func TestLoginTwoFactor_InvalidChallenge(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	redis.On("GetTwoFactorChallenge", mock.Anything, "challenge").Return(nil, errors.New("redis: nil")).Once()
+
+	uc := usecases.NewLoginTwoFactorUseCase(new(mocks.MockUserRepository), new(authMocks.MockTwoFactorRepository), newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.LoginTwoFactorInput{ChallengeToken: "challenge", Code: "123456"})
+	require.ErrorIs(t, err, usecases.ErrInvalidTwoFactorChallenge)
+}
+
+func TestLoginTwoFactor_Success(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	twoFactor := newEnabledTwoFactor(t, user.ID)
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	challenge := &utils.TwoFactorChallenge{UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	redis.On("GetTwoFactorChallenge", mock.Anything, "challenge").Return(challenge, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(twoFactor, nil).Once()
+	twoFactorRepo.On("Save", mock.Anything, twoFactor).Return(nil).Once()
+	redis.On("RevokeTwoFactorChallenge", mock.Anything, "challenge").Return(nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, "127.0.0.1", "test-agent", mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLoginTwoFactorUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.LoginTwoFactorInput{
+		ChallengeToken: "challenge",
+		Code:           currentTOTPCode(t, twoFactor.Secret),
+		IPAddress:      "127.0.0.1",
+		UserAgent:      "test-agent",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.Equal(t, user.ID, result.User.ID)
+	assert.NotZero(t, twoFactor.LastUsedStep)
+
+	twoFactorRepo.AssertExpectations(t)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestLoginTwoFactor_RecoveryCode(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	twoFactor := newEnabledTwoFactor(t, user.ID)
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	challenge := &utils.TwoFactorChallenge{UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	redis.On("GetTwoFactorChallenge", mock.Anything, "challenge").Return(challenge, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(twoFactor, nil).Once()
+	twoFactorRepo.On("UseRecoveryCode", mock.Anything, user.ID, utils.HashToken("abcdefghij")).Return(true, nil).Once()
+	redis.On("RevokeTwoFactorChallenge", mock.Anything, "challenge").Return(nil).Once()
+	redis.On("StoreAccessToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshToken", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreUserSession", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreRefreshTokenFamily", mock.Anything, mock.Anything, user.ID, mock.Anything, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserLoggedIn, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLoginTwoFactorUseCase(repo, twoFactorRepo, newSecurityEventRepo(), publisher, logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.LoginTwoFactorInput{
+		ChallengeToken: "challenge",
+		RecoveryCode:   "ABCDE-FGHIJ",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+
+	twoFactorRepo.AssertExpectations(t)
+}
+
+func TestLoginTwoFactor_InvalidCodeRecordsAttempt(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	twoFactor := newEnabledTwoFactor(t, user.ID)
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+
+	challenge := &utils.TwoFactorChallenge{UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	redis.On("GetTwoFactorChallenge", mock.Anything, "challenge").Return(challenge, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(twoFactor, nil).Once()
+	redis.On("StoreTwoFactorChallenge", mock.Anything, "challenge", mock.MatchedBy(func(c *utils.TwoFactorChallenge) bool {
+		return c.Attempts == 1
+	}), mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLoginTwoFactorUseCase(repo, twoFactorRepo, newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.LoginTwoFactorInput{ChallengeToken: "challenge", Code: "abcdef"})
+	require.ErrorIs(t, err, usecases.ErrInvalidTwoFactorCode)
+
+	redis.AssertExpectations(t)
+	redis.AssertNotCalled(t, "StoreUserSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginTwoFactor_TooManyAttempts(t *testing.T) {
+	redis := new(mocks.MockRedis)
+
+	challenge := &utils.TwoFactorChallenge{UserID: "user-id", Attempts: 3, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+	redis.On("GetTwoFactorChallenge", mock.Anything, "challenge").Return(challenge, nil).Once()
+	redis.On("RevokeTwoFactorChallenge", mock.Anything, "challenge").Return(nil).Once()
+
+	uc := usecases.NewLoginTwoFactorUseCase(new(mocks.MockUserRepository), new(authMocks.MockTwoFactorRepository), newSecurityEventRepo(), new(mocks.MockPublisher), logger.NewNop(), setupJwtManagerForUnit(), redis, newTwoFactorTestConfig())
+
+	_, err := uc.Execute(context.Background(), usecases.LoginTwoFactorInput{ChallengeToken: "challenge", Code: "123456"})
+	require.ErrorIs(t, err, usecases.ErrTooManyTwoFactorAttempts)
+
+	redis.AssertExpectations(t)
+}
+
+func TestEnableTwoFactor_Success(t *testing.T) {
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	secret, err := authUtils.GenerateTOTPSecret()
+	require.NoError(t, err)
+	twoFactor := authEntities.NewTwoFactor("user-id", secret)
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, "user-id").Return(twoFactor, nil).Once()
+	twoFactorRepo.On("Save", mock.Anything, twoFactor).Return(nil).Once()
+	twoFactorRepo.On("ReplaceRecoveryCodes", mock.Anything, "user-id", mock.MatchedBy(func(codes []*authEntities.RecoveryCode) bool {
+		return len(codes) == 10
+	})).Return(nil).Once()
+
+	uc := usecases.NewEnableTwoFactorUseCase(twoFactorRepo, newSecurityEventRepo(), logger.NewNop(), jwtManager, redis)
+
+	output, err := uc.Execute(context.Background(), usecases.EnableTwoFactorInput{
+		AccessToken: accessToken,
+		Code:        currentTOTPCode(t, secret),
+	})
+	require.NoError(t, err)
+	assert.Len(t, output.RecoveryCodes, 10)
+	assert.True(t, twoFactor.Enabled)
+
+	twoFactorRepo.AssertExpectations(t)
+}
+
+func TestEnableTwoFactor_AlreadyEnabled(t *testing.T) {
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, "user-id").Return(newEnabledTwoFactor(t, "user-id"), nil).Once()
+
+	uc := usecases.NewEnableTwoFactorUseCase(twoFactorRepo, newSecurityEventRepo(), logger.NewNop(), jwtManager, redis)
+
+	_, err := uc.Execute(context.Background(), usecases.EnableTwoFactorInput{AccessToken: accessToken, Code: "123456"})
+	require.ErrorIs(t, err, usecases.ErrTwoFactorAlreadyEnabled)
+
+	twoFactorRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestDisableTwoFactor_RequiredForRole(t *testing.T) {
+	user := newTwoFactorTestUser("admin", "Password123!")
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "admin", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewDisableTwoFactorUseCase(repo, twoFactorRepo, newSecurityEventRepo(), logger.NewNop(), jwtManager, redis, newTwoFactorTestConfig())
+
+	err := uc.Execute(context.Background(), usecases.DisableTwoFactorInput{AccessToken: accessToken, Password: "Password123!", Code: "123456"})
+	require.ErrorIs(t, err, usecases.ErrTwoFactorRequiredForRole)
+
+	twoFactorRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDisableTwoFactor_Success(t *testing.T) {
+	user := newTwoFactorTestUser("student", "Password123!")
+	twoFactor := newEnabledTwoFactor(t, user.ID)
+	repo := new(mocks.MockUserRepository)
+	twoFactorRepo := new(authMocks.MockTwoFactorRepository)
+	redis := new(mocks.MockRedis)
+	jwtManager := setupJwtManagerForUnit()
+
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "session-1", "")
+	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(twoFactor, nil).Once()
+	twoFactorRepo.On("Save", mock.Anything, twoFactor).Return(nil).Once()
+	twoFactorRepo.On("Delete", mock.Anything, user.ID).Return(nil).Once()
+
+	uc := usecases.NewDisableTwoFactorUseCase(repo, twoFactorRepo, newSecurityEventRepo(), logger.NewNop(), jwtManager, redis, newTwoFactorTestConfig())
+
+	err := uc.Execute(context.Background(), usecases.DisableTwoFactorInput{
+		AccessToken: accessToken,
+		Password:    "Password123!",
+		Code:        currentTOTPCode(t, twoFactor.Secret),
+	})
+	require.NoError(t, err)
+
+	twoFactorRepo.AssertExpectations(t)
+}
@@ -0,0 +1,342 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testUserCacheConfig = config.UserCacheConfig{TTL: 30 * time.Second}
+
+// cacheRedis keeps access tokens and cached user statuses in memory so /verify
+// can be exercised, and benchmarked, against a working cache.
+type cacheRedis struct {
+	*mocks.MockRedis
+	mu       sync.Mutex
+	tokens   map[string]string
+	statuses map[string]utils.CachedUserStatus
+}
+
+func newCacheRedis() *cacheRedis {
+	return &cacheRedis{
+		MockRedis: new(mocks.MockRedis),
+		tokens:    map[string]string{},
+		statuses:  map[string]utils.CachedUserStatus{},
+	}
+}
+
+func (r *cacheRedis) GetUserFromAccessToken(ctx context.Context, accessToken string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	userID, ok := r.tokens[accessToken]
+	if !ok {
+		return "", fmt.Errorf("access token not found")
+	}
+	return userID, nil
+}
+
+func (r *cacheRedis) UpdateLastActivity(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (r *cacheRedis) CacheUserStatus(ctx context.Context, status *utils.CachedUserStatus, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses[status.UserID] = *status
+	return nil
+}
+
+func (r *cacheRedis) GetCachedUserStatus(ctx context.Context, userID string) (*utils.CachedUserStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status, ok := r.statuses[userID]
+	if !ok {
+		return nil, utils.ErrUserStatusNotCached
+	}
+	return &status, nil
+}
+
+func (r *cacheRedis) InvalidateUserStatus(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.statuses, userID)
+	return nil
+}
+
+// countingUserRepo stands in for Postgres and counts the queries /verify makes.
+type countingUserRepo struct {
+	*mocks.MockUserRepository
+	mu      sync.Mutex
+	users   map[string]*entities.User
+	queries int64
+}
+
+func (r *countingUserRepo) FindByID(ctx context.Context, id string) (*entities.User, error) {
+	atomic.AddInt64(&r.queries, 1)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *countingUserRepo) setStatus(id string, status valueobjects.Status) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[id].Status = status
+}
+
+func newActiveStudent(t testing.TB, n int) *entities.User {
+	emailVO, err := valueobjects.NewEmail(fmt.Sprintf("student%d@example.com", n))
+	require.NoError(t, err)
+	user := entities.NewUser(emailVO, fmt.Sprintf("student%d", n), valueobjects.RoleStudent, "hash")
+	user.Status = valueobjects.StatusActive
+	organizationID := testOrganizationID
+	user.MoveToOrganization(&organizationID)
+	return user
+}
+
+// newCachedVerifyFixture signs in count active students and returns their
+// access tokens.
+func newCachedVerifyFixture(t testing.TB, count int, cacheConfig config.UserCacheConfig) (*usecases.VerifyUseCase, *cacheRedis, *countingUserRepo, []string, []*entities.User) {
+	jwtManager := setupJwtManagerForUnit()
+	redis := newCacheRedis()
+	repo := &countingUserRepo{MockUserRepository: new(mocks.MockUserRepository), users: map[string]*entities.User{}}
+
+	tokens := make([]string, 0, count)
+	users := make([]*entities.User, 0, count)
+	for i := 0; i < count; i++ {
+		user := newActiveStudent(t, i)
+		token, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), fmt.Sprintf("session-%d", i), testOrganizationID)
+		require.NoError(t, err)
+		repo.users[user.ID] = user
+		redis.tokens[token] = user.ID
+		tokens = append(tokens, token)
+		users = append(users, user)
+	}
+
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis, cacheConfig)
+	return uc, redis, repo, tokens, users
+}
+
+func TestVerify_UsesCachedUserStatus(t *testing.T) {
+	user := newActiveStudent(t, 1)
+	jwtManager := setupJwtManagerForUnit()
+	token, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "", testOrganizationID)
+	require.NoError(t, err)
+
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return(user.ID, nil).Once()
+	redis.On("GetCachedUserStatus", mock.Anything, user.ID).Return(&utils.CachedUserStatus{
+		UserID:         user.ID,
+		Email:          user.Email.String(),
+		Role:           "student",
+		Status:         "active",
+		OrganizationID: testOrganizationID,
+	}, nil).Once()
+
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis, testUserCacheConfig)
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyInput{Token: token, RequiredRole: "student"})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.ID)
+	assert.Equal(t, user.Email.String(), result.Email)
+	assert.Equal(t, "student", result.Role)
+
+	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestVerify_CachesUserStatusOnMiss(t *testing.T) {
+	user := newActiveStudent(t, 1)
+	jwtManager := setupJwtManagerForUnit()
+	token, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "student", "active", "", testOrganizationID)
+	require.NoError(t, err)
+
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return(user.ID, nil).Once()
+	redis.On("GetCachedUserStatus", mock.Anything, user.ID).Return(nil, utils.ErrUserStatusNotCached).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("CacheUserStatus", mock.Anything, mock.MatchedBy(func(s *utils.CachedUserStatus) bool {
+		return s.UserID == user.ID && s.Role == "student" && s.Status == "active"
+	}), testUserCacheConfig.TTL).Return(nil).Once()
+
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis, testUserCacheConfig)
+
+	_, err = uc.Execute(context.Background(), usecases.VerifyInput{Token: token})
+	require.NoError(t, err)
+
+	redis.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestVerify_CachedStatusChecked(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		status   string
+		required string
+		err      error
+	}{
+		{"banned", "student", "banned", "", usecases.ErrUnauthorized},
+		{"demoted", "student", "active", "admin", usecases.ErrInsufficientPermissions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newActiveStudent(t, 1)
+			jwtManager := setupJwtManagerForUnit()
+			token, err := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), "admin", "active", "", "")
+			require.NoError(t, err)
+
+			redis := new(mocks.MockRedis)
+			redis.On("GetUserFromAccessToken", mock.Anything, token).Return(user.ID, nil).Once()
+			redis.On("GetCachedUserStatus", mock.Anything, user.ID).Return(&utils.CachedUserStatus{
+				UserID: user.ID,
+				Role:   tt.role,
+				Status: tt.status,
+			}, nil).Once()
+
+			uc := usecases.NewVerifyUseCase(new(mocks.MockUserRepository), new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis, testUserCacheConfig)
+
+			_, err = uc.Execute(context.Background(), usecases.VerifyInput{Token: token, RequiredRole: tt.required})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerify_BanAppliesAfterUserUpdatedEvent(t *testing.T) {
+	uc, redis, repo, tokens, users := newCachedVerifyFixture(t, 1, testUserCacheConfig)
+	user := users[0]
+
+	_, err := uc.Execute(context.Background(), usecases.VerifyInput{Token: tokens[0]})
+	require.NoError(t, err)
+	_, err = uc.Execute(context.Background(), usecases.VerifyInput{Token: tokens[0]})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, repo.queries)
+
+	repo.setStatus(user.ID, valueobjects.StatusBanned)
+	body, err := json.Marshal(events.UserUpdatedEvent{ID: user.ID, Status: "banned", Role: "student"})
+	require.NoError(t, err)
+	require.NoError(t, handlers.NewUserUpdatedHandler(redis, logger.NewNop()).Handle(body))
+
+	_, err = uc.Execute(context.Background(), usecases.VerifyInput{Token: tokens[0]})
+	require.ErrorIs(t, err, usecases.ErrUnauthorized)
+	assert.EqualValues(t, 2, repo.queries)
+}
+
+func TestUserDeletedHandler_InvalidatesCachedStatus(t *testing.T) {
+	redis := new(mocks.MockRedis)
+	redis.On("InvalidateUserStatus", mock.Anything, "user-1").Return(nil).Once()
+	redis.On("ListUserSessions", mock.Anything, "user-1").Return([]*utils.SessionData{}, nil).Once()
+
+	body, err := json.Marshal(events.UserDeletedEvent{ID: "user-1", DeletedAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, handlers.NewUserDeletedHandler(redis, logger.NewNop()).Handle(body))
+
+	redis.AssertExpectations(t)
+}
+
+func TestUserDeletedHandler_RevokesSessionsOfPurgedUser(t *testing.T) {
+	session := &utils.SessionData{
+		SessionID:    "session-1",
+		UserID:       "user-1",
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+	}
+
+	redis := new(mocks.MockRedis)
+	redis.On("InvalidateUserStatus", mock.Anything, "user-1").Return(nil).Once()
+	redis.On("ListUserSessions", mock.Anything, "user-1").Return([]*utils.SessionData{session}, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-1").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-1").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: "user-1", Role: "student", PurgedAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, handlers.NewUserDeletedHandler(redis, logger.NewNop()).Handle(body))
+
+	redis.AssertExpectations(t)
+}
+
+func TestUserSuspendedHandler_RevokesSessions(t *testing.T) {
+	session := &utils.SessionData{
+		SessionID:    "session-1",
+		UserID:       "user-1",
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+	}
+
+	redis := new(mocks.MockRedis)
+	redis.On("InvalidateUserStatus", mock.Anything, "user-1").Return(nil).Once()
+	redis.On("ListUserSessions", mock.Anything, "user-1").Return([]*utils.SessionData{session}, nil).Once()
+	redis.On("RevokeAccessToken", mock.Anything, "access-1").Return(nil).Once()
+	redis.On("RevokeRefreshToken", mock.Anything, "refresh-1").Return(nil).Once()
+	redis.On("RevokeRefreshTokenFamily", mock.Anything, "session-1").Return(nil).Once()
+	redis.On("DeleteUserSession", mock.Anything, "session-1").Return(nil).Once()
+
+	body, err := json.Marshal(events.UserSuspendedEvent{
+		ID:           "user-1",
+		SuspensionID: "suspension-1",
+		Reason:       "spam",
+		SuspendedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, handlers.NewUserSuspendedHandler(redis, logger.NewNop()).Handle(body))
+
+	redis.AssertExpectations(t)
+}
+
+func TestUserUpdatedHandler_InvalidBody(t *testing.T) {
+	redis := new(mocks.MockRedis)
+
+	err := handlers.NewUserUpdatedHandler(redis, logger.NewNop()).Handle([]byte("not json"))
+	require.Error(t, err)
+
+	redis.AssertNotCalled(t, "InvalidateUserStatus", mock.Anything, mock.Anything)
+}
+
+// The benchmarks send requests from 100 signed-in users through /verify and
+// report the user database queries each request costs.
+func benchmarkVerify(b *testing.B, cacheConfig config.UserCacheConfig) {
+	uc, _, repo, tokens, _ := newCachedVerifyFixture(b, 100, cacheConfig)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := uc.Execute(ctx, usecases.VerifyInput{Token: tokens[i%len(tokens)], RequiredRole: "student"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(atomic.LoadInt64(&repo.queries))/float64(b.N), "db_queries/op")
+}
+
+func BenchmarkVerify_WithoutUserCache(b *testing.B) {
+	benchmarkVerify(b, config.UserCacheConfig{})
+}
+
+func BenchmarkVerify_WithUserCache(b *testing.B) {
+	benchmarkVerify(b, testUserCacheConfig)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,7 @@ func TestVerifyEmail_MissingEmail(t *testing.T) {
 
 	redis.On("GetUserFromVerifyEmailToken", mock.Anything, "valid-token").Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("GetPendingEmail", mock.Anything, "valid-token").Return("", nil).Once()
 	repo.On("UpdateEmailVerified", mock.Anything, user.ID, true).Return(nil).Once()
 	redis.On("RevokeVerifyEmailToken", mock.Anything, "valid-token").Return(nil).Once()
 
@@ -118,6 +120,7 @@ func TestVerifyEmail_EmailMismatch(t *testing.T) {
 
 	redis.On("GetUserFromVerifyEmailToken", mock.Anything, "valid-token").Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("GetPendingEmail", mock.Anything, "valid-token").Return("", nil).Once()
 
 	uc := usecases.NewVerifyEmailUseCase(repo, publisher, logger, redis)
 
@@ -145,6 +148,7 @@ func TestVerifyEmail_AlreadyVerified(t *testing.T) {
 
 	redis.On("GetUserFromVerifyEmailToken", mock.Anything, "valid-token").Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("GetPendingEmail", mock.Anything, "valid-token").Return("", nil).Once()
 
 	uc := usecases.NewVerifyEmailUseCase(repo, publisher, logger, redis)
 
@@ -173,6 +177,7 @@ func TestVerifyEmail_Success(t *testing.T) {
 
 	redis.On("GetUserFromVerifyEmailToken", mock.Anything, "valid-token").Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("GetPendingEmail", mock.Anything, "valid-token").Return("", nil).Once()
 	repo.On("UpdateEmailVerified", mock.Anything, user.ID, true).Return(nil).Once()
 	redis.On("RevokeVerifyEmailToken", mock.Anything, "valid-token").Return(nil).Once()
 
@@ -189,3 +194,38 @@ func TestVerifyEmail_Success(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestVerifyEmail_SwitchesPendingEmail(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "user", roleVO, "hash")
+	user.EmailVerified = true
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetUserFromVerifyEmailToken", mock.Anything, "valid-token").Return(user.ID, nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	redis.On("GetPendingEmail", mock.Anything, "valid-token").Return("new@example.com", nil).Once()
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return(nil, nil).Once()
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Email.String() == "new@example.com" && u.EmailVerified
+	})).Return(nil).Once()
+	redis.On("RevokeVerifyEmailToken", mock.Anything, "valid-token").Return(nil).Once()
+	redis.On("RevokePendingEmail", mock.Anything, "valid-token").Return(nil).Once()
+	redis.On("InvalidateUserStatus", mock.Anything, user.ID).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeAuthUserEmailChanged, mock.MatchedBy(func(e events.AuthUserEmailChangedEvent) bool {
+		return e.OldEmail == "user@example.com" && e.NewEmail == "new@example.com"
+	})).Return(nil).Once()
+
+	uc := usecases.NewVerifyEmailUseCase(repo, publisher, logger, redis)
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyEmailInput{Token: "valid-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "Email changed successfully", result.Message)
+
+	redis.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
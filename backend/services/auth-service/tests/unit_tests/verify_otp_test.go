@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,7 +22,7 @@ func TestVerifyOTP_MissingEmail(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		OTP: "123456",
@@ -29,7 +31,7 @@ func TestVerifyOTP_MissingEmail(t *testing.T) {
 	assert.False(t, result.IsValid)
 	assert.Contains(t, result.ErrorMessage, "email is required")
 
-	redis.AssertNotCalled(t, "GetUserFromForgotPasswordOTP", mock.Anything, mock.Anything)
+	redis.AssertNotCalled(t, "VerifyForgotPasswordOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestVerifyOTP_MissingOTP(t *testing.T) {
@@ -37,7 +39,7 @@ func TestVerifyOTP_MissingOTP(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "user@example.com",
@@ -46,17 +48,23 @@ func TestVerifyOTP_MissingOTP(t *testing.T) {
 	assert.False(t, result.IsValid)
 	assert.Contains(t, result.ErrorMessage, "invalid OTP")
 
-	redis.AssertNotCalled(t, "GetUserFromForgotPasswordOTP", mock.Anything, mock.Anything)
+	redis.AssertNotCalled(t, "VerifyForgotPasswordOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestVerifyOTP_InvalidOTP(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "user", roleVO, "hash")
+
 	repo := new(mocks.MockUserRepository)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "invalid-otp").Return("", errors.New("not found")).Once()
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, "invalid-otp", 5).Return(utils.ErrOTPInvalid).Once()
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "user@example.com",
@@ -66,18 +74,25 @@ func TestVerifyOTP_InvalidOTP(t *testing.T) {
 	assert.False(t, result.IsValid)
 	assert.Contains(t, result.ErrorMessage, "invalid OTP")
 
-	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	redis.AssertCalled(t, "IncrementAuthFailures", mock.Anything, "otp:account:user@example.com", mock.Anything)
+	redis.AssertNotCalled(t, "StoreResetPasswordToken", mock.Anything, mock.Anything, mock.Anything)
 	redis.AssertExpectations(t)
 }
 
-func TestVerifyOTP_OTPNotFound(t *testing.T) {
+func TestVerifyOTP_RedisFailure(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "user", roleVO, "hash")
+
 	repo := new(mocks.MockUserRepository)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "123456").Return("", nil).Once()
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, "123456", 5).Return(errors.New("redis error")).Once()
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "user@example.com",
@@ -86,7 +101,6 @@ func TestVerifyOTP_OTPNotFound(t *testing.T) {
 	require.ErrorIs(t, err, usecases.ErrInvalidOTP)
 	assert.False(t, result.IsValid)
 
-	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
 	redis.AssertExpectations(t)
 }
 
@@ -95,10 +109,10 @@ func TestVerifyOTP_UserNotFound(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "123456").Return("user-id", nil).Once()
-	repo.On("FindByID", mock.Anything, "user-id").Return((*entities.User)(nil), nil).Once()
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), nil).Once()
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "user@example.com",
@@ -107,23 +121,26 @@ func TestVerifyOTP_UserNotFound(t *testing.T) {
 	require.ErrorIs(t, err, usecases.ErrInvalidOTP)
 	assert.False(t, result.IsValid)
 
-	redis.AssertExpectations(t)
+	redis.AssertNotCalled(t, "VerifyForgotPasswordOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	repo.AssertExpectations(t)
 }
 
-func TestVerifyOTP_EmailMismatch(t *testing.T) {
-	emailVO, _ := valueobjects.NewEmail("user@example.com")
+func TestVerifyOTP_BoundToRequestingUser(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("different@example.com")
 	roleVO, _ := valueobjects.NewRole("student")
-	user := entities.NewUser(emailVO, "user", roleVO, "hash")
+	other := entities.NewUser(emailVO, "other", roleVO, "hash")
 
 	repo := new(mocks.MockUserRepository)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "123456").Return(user.ID, nil).Once()
-	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	// The code was issued to another account, so it does not match the OTP
+	// stored for this one.
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "different@example.com").Return(other, nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, other.ID, "123456", 5).Return(utils.ErrOTPInvalid).Once()
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "different@example.com",
@@ -136,6 +153,51 @@ func TestVerifyOTP_EmailMismatch(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestVerifyOTP_AttemptsExceeded(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "user", roleVO, "hash")
+
+	repo := new(mocks.MockUserRepository)
+	logger := logger.NewNop()
+	redis := new(mocks.MockRedis)
+
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, "123456", 5).Return(utils.ErrOTPAttemptsExceeded).Once()
+
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
+		Email: "user@example.com",
+		OTP:   "123456",
+	})
+	require.ErrorIs(t, err, usecases.ErrOTPAttemptsExceeded)
+	assert.False(t, result.IsValid)
+
+	redis.AssertExpectations(t)
+}
+
+func TestVerifyOTP_Locked(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+
+	redis.On("GetAuthLockout", mock.Anything, "otp:account:user@example.com").Return(10*time.Minute, nil).Once()
+
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger.NewNop(), redis, newLockoutTestConfig())
+
+	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
+		Email:     "user@example.com",
+		OTP:       "123456",
+		IPAddress: "127.0.0.1",
+	})
+	require.ErrorIs(t, err, usecases.ErrAccountLocked)
+	assert.False(t, result.IsValid)
+
+	repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+	redis.AssertNotCalled(t, "VerifyForgotPasswordOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestVerifyOTP_Success(t *testing.T) {
 	emailVO, _ := valueobjects.NewEmail("user@example.com")
 	roleVO, _ := valueobjects.NewRole("student")
@@ -145,12 +207,12 @@ func TestVerifyOTP_Success(t *testing.T) {
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
 
-	redis.On("GetUserFromForgotPasswordOTP", mock.Anything, "123456").Return(user.ID, nil).Once()
-	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
-	redis.On("RevokeForgotPasswordOTP", mock.Anything, "123456").Return(nil).Once()
+	allowAttempts(redis)
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	redis.On("VerifyForgotPasswordOTP", mock.Anything, user.ID, "123456", 5).Return(nil).Once()
 	redis.On("StoreResetPasswordToken", mock.Anything, user.ID, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewVerifyOTPUseCase(repo, logger, redis)
+	uc := usecases.NewVerifyOTPUseCase(repo, new(mocks.MockPublisher), logger, redis, newLockoutTestConfig())
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyOTPInput{
 		Email: "user@example.com",
@@ -160,7 +222,7 @@ func TestVerifyOTP_Success(t *testing.T) {
 	assert.True(t, result.IsValid)
 	assert.NotEmpty(t, result.PasswordResetToken)
 
+	redis.AssertCalled(t, "ResetAuthFailures", mock.Anything, "otp:account:user@example.com")
 	redis.AssertExpectations(t)
 	repo.AssertExpectations(t)
 }
-
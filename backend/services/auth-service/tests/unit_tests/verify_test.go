@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/auth-service/internal/infrastructure/config"
+	authMocks "github.com/paingphyoaungkhant/asto-microservice/services/auth-service/tests/mocks"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
@@ -22,7 +24,7 @@ func TestVerify_InvalidToken(t *testing.T) {
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        "invalid-token",
@@ -41,10 +43,10 @@ func TestVerify_TokenNotFoundInRedis(t *testing.T) {
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
 
-	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active")
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("", errors.New("not found")).Once()
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        accessToken,
@@ -69,11 +71,11 @@ func TestVerify_InsufficientPermissions_RoleMismatch(t *testing.T) {
 	user := entities.NewUser(emailVO, "user", roleVO, "hash")
 	user.Status = statusVO
 
-	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active")
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
 	repo.On("FindByID", mock.Anything, "user-id").Return(user, nil).Once()
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        accessToken,
@@ -92,11 +94,11 @@ func TestVerify_UserNotFound(t *testing.T) {
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
 
-	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active")
+	accessToken, _ := jwtManager.GenerateAccessToken("user-id", "user@example.com", "student", "active", "", "")
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return("user-id", nil).Once()
 	repo.On("FindByID", mock.Anything, "user-id").Return((*entities.User)(nil), nil).Once()
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        accessToken,
@@ -121,11 +123,11 @@ func TestVerify_InactiveUser(t *testing.T) {
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
 
-	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", "")
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	_, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        accessToken,
@@ -143,6 +145,8 @@ func TestVerify_Success(t *testing.T) {
 	activeStatus, _ := valueobjects.NewStatus("active")
 	user := entities.NewUser(emailVO, "user", roleVO, "hash")
 	user.Status = activeStatus
+	organizationID := testOrganizationID
+	user.MoveToOrganization(&organizationID)
 
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
@@ -150,11 +154,11 @@ func TestVerify_Success(t *testing.T) {
 	jwtManager := setupJwtManagerForUnit()
 	redis := new(mocks.MockRedis)
 
-	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String())
+	accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", testOrganizationID)
 	redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
 
-	uc := usecases.NewVerifyUseCase(repo, publisher, logger, jwtManager, redis)
+	uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), publisher, logger, jwtManager, redis, config.UserCacheConfig{})
 
 	result, err := uc.Execute(context.Background(), usecases.VerifyInput{
 		Token:        accessToken,
@@ -170,3 +174,38 @@ func TestVerify_Success(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+
+func TestVerify_OnlySuperAdminsMayBelongToNoOrganization(t *testing.T) {
+	tests := []struct {
+		role string
+		err  error
+	}{
+		{"student", usecases.ErrInsufficientPermissions},
+		{"instructor", usecases.ErrInsufficientPermissions},
+		{"admin", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			emailVO, _ := valueobjects.NewEmail("user@example.com")
+			roleVO, _ := valueobjects.NewRole(tt.role)
+			user := entities.NewUser(emailVO, "user", roleVO, "hash")
+			user.Status = valueobjects.StatusActive
+
+			repo := new(mocks.MockUserRepository)
+			redis := new(mocks.MockRedis)
+			jwtManager := setupJwtManagerForUnit()
+			accessToken, _ := jwtManager.GenerateAccessToken(user.ID, user.Email.String(), user.Role.String(), user.Status.String(), "", "")
+			redis.On("GetUserFromAccessToken", mock.Anything, accessToken).Return(user.ID, nil).Once()
+			repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+			uc := usecases.NewVerifyUseCase(repo, new(authMocks.MockServiceAccountRepository), new(authMocks.MockAPIKeyRepository), new(mocks.MockPublisher), logger.NewNop(), jwtManager, redis, config.UserCacheConfig{})
+
+			_, err := uc.Execute(context.Background(), usecases.VerifyInput{Token: accessToken})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
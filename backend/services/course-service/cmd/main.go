@@ -23,14 +23,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/consumer"
 	appHandlers "github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/policies"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/infrastructure/external/files"
 	coursePostgres "github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/infrastructure/persistence/postgres"
 	httpRouter "github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/interfaces/http"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/interfaces/http/handlers"
 	sharedPostgres "github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -69,6 +72,14 @@ func main() {
 		defer rabbitMQ.Close()
 	}
 
+	// Redis also backs rate limiting. Without it the API still serves
+	// requests, only unthrottled.
+	authenticate, rateLimitStore, closeRateLimitStore, err := middleware.AuthenticateWithRedis(cfg.Auth, &cfg.Redis, appLogger)
+	if err != nil {
+		appLogger.Fatal("failed to set up token verification", zap.Error(err))
+	}
+	defer closeRateLimitStore()
+
 	categoryRepo := coursePostgres.NewPostgresCategoryRepository(db)
 	courseRepo := coursePostgres.NewPostgresCourseRepository(db)
 	courseCategoryRepo := coursePostgres.NewPostgresCourseCategoryRepository(db)
@@ -76,6 +87,13 @@ func main() {
 	instructorRepo := coursePostgres.NewPostgresCourseOfferingInstructorRepository(db)
 	sectionRepo := coursePostgres.NewPostgresCourseSectionRepository(db)
 	moduleRepo := coursePostgres.NewPostgresSectionModuleRepository(db)
+	questionRepo := coursePostgres.NewPostgresQuestionRepository(db)
+	quizRepo := coursePostgres.NewPostgresQuizRepository(db)
+	attemptRepo := coursePostgres.NewPostgresQuizAttemptRepository(db)
+	assignmentRepo := coursePostgres.NewPostgresAssignmentRepository(db)
+	submissionRepo := coursePostgres.NewPostgresAssignmentSubmissionRepository(db)
+	enrollmentRepo := coursePostgres.NewPostgresOfferingEnrollmentRepository(db)
+	fileClient := files.NewFileClient(cfg.FileServiceURL)
 
 	createCategoryUseCase := usecases.NewCreateCategoryUseCase(categoryRepo, appLogger)
 	findCategoryUseCase := usecases.NewFindCategoryUseCase(categoryRepo, appLogger)
@@ -102,7 +120,7 @@ func main() {
 	updateOfferingUseCase := usecases.NewUpdateCourseOfferingUseCase(offeringRepo, rabbitMQ, appLogger)
 	deleteOfferingUseCase := usecases.NewDeleteCourseOfferingUseCase(offeringRepo, rabbitMQ, appLogger)
 	findOfferingUseCase := usecases.NewFindCourseOfferingUseCase(offeringRepo, courseRepo, appLogger)
-	getOfferingUseCase := usecases.NewGetCourseOfferingUseCase(offeringRepo, courseRepo, instructorRepo, sectionRepo, moduleRepo)
+	getOfferingUseCase := usecases.NewGetCourseOfferingUseCase(offeringRepo, courseRepo, instructorRepo, sectionRepo, moduleRepo, cfg.Server.APIGatewayURL)
 	assignInstructorUseCase := usecases.NewAssignInstructorToOfferingUseCase(instructorRepo, offeringRepo, rabbitMQ, appLogger)
 	removeInstructorUseCase := usecases.NewRemoveInstructorFromOfferingUseCase(instructorRepo, rabbitMQ, appLogger)
 
@@ -113,13 +131,33 @@ func main() {
 	findSectionUseCase := usecases.NewFindCourseSectionUseCase(sectionRepo, appLogger)
 	reorderSectionsUseCase := usecases.NewReorderCourseSectionsUseCase(sectionRepo, appLogger)
 
-	createModuleUseCase := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, rabbitMQ, appLogger)
-	updateModuleUseCase := usecases.NewUpdateSectionModuleUseCase(moduleRepo, rabbitMQ, appLogger)
-	getModuleUseCase := usecases.NewGetSectionModuleUseCase(moduleRepo, appLogger)
+	createModuleUseCase := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, rabbitMQ, appLogger, cfg.Server.APIGatewayURL)
+	updateModuleUseCase := usecases.NewUpdateSectionModuleUseCase(moduleRepo, rabbitMQ, appLogger, cfg.Server.APIGatewayURL)
+	getModuleUseCase := usecases.NewGetSectionModuleUseCase(moduleRepo, appLogger, cfg.Server.APIGatewayURL)
 	deleteModuleUseCase := usecases.NewDeleteSectionModuleUseCase(moduleRepo, rabbitMQ, appLogger)
-	findModuleUseCase := usecases.NewFindSectionModuleUseCase(moduleRepo, appLogger)
+	findModuleUseCase := usecases.NewFindSectionModuleUseCase(moduleRepo, appLogger, cfg.Server.APIGatewayURL)
 	reorderModulesUseCase := usecases.NewReorderSectionModulesUseCase(moduleRepo, appLogger)
 
+	createQuestionUseCase := usecases.NewCreateQuestionUseCase(questionRepo, courseRepo, appLogger)
+	updateQuestionUseCase := usecases.NewUpdateQuestionUseCase(questionRepo, appLogger)
+	deleteQuestionUseCase := usecases.NewDeleteQuestionUseCase(questionRepo, quizRepo, appLogger)
+	findQuestionsUseCase := usecases.NewFindQuestionsUseCase(questionRepo, appLogger)
+
+	createQuizUseCase := usecases.NewCreateQuizUseCase(quizRepo, questionRepo, moduleRepo, sectionRepo, offeringRepo, appLogger)
+	updateQuizUseCase := usecases.NewUpdateQuizUseCase(quizRepo, questionRepo, appLogger)
+	getQuizUseCase := usecases.NewGetQuizUseCase(quizRepo, appLogger)
+	startAttemptUseCase := usecases.NewStartQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, rabbitMQ, appLogger)
+	submitAttemptUseCase := usecases.NewSubmitQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, rabbitMQ, appLogger)
+	saveAnswersUseCase := usecases.NewSaveQuizAttemptAnswersUseCase(questionRepo, attemptRepo, appLogger)
+	getAttemptUseCase := usecases.NewGetQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, rabbitMQ, appLogger)
+	findAttemptsUseCase := usecases.NewFindQuizAttemptsUseCase(quizRepo, questionRepo, attemptRepo, appLogger)
+	createAssignmentUseCase := usecases.NewCreateAssignmentUseCase(assignmentRepo, moduleRepo, sectionRepo, appLogger)
+	updateAssignmentUseCase := usecases.NewUpdateAssignmentUseCase(assignmentRepo, appLogger)
+	getAssignmentUseCase := usecases.NewGetAssignmentUseCase(assignmentRepo, appLogger)
+	submitAssignmentUseCase := usecases.NewSubmitAssignmentUseCase(assignmentRepo, submissionRepo, fileClient, moduleRepo, rabbitMQ, appLogger, cfg.Server.APIGatewayURL)
+	findSubmissionsUseCase := usecases.NewFindAssignmentSubmissionsUseCase(assignmentRepo, submissionRepo, appLogger, cfg.Server.APIGatewayURL)
+	gradeSubmissionUseCase := usecases.NewGradeAssignmentSubmissionUseCase(assignmentRepo, submissionRepo, moduleRepo, rabbitMQ, appLogger, cfg.Server.APIGatewayURL)
+
 	categoryHandler := handlers.NewCategoryHandler(createCategoryUseCase, nil, findCategoryUseCase, getCategoryUseCase, updateCategoryUseCase, deleteCategoryUseCase, appLogger)
 	courseHandler := handlers.NewCourseHandler(createCourseUseCase, listCoursesUseCase, findCourseUseCase, getCourseUseCase, getCourseWithDetailsUseCase, updateCourseUseCase, deleteCourseUseCase, appLogger)
 	courseOfferingHandler := handlers.NewCourseOfferingHandler(
@@ -150,10 +188,41 @@ func main() {
 		reorderModulesUseCase,
 		appLogger,
 	)
+	questionHandler := handlers.NewQuestionHandler(
+		createQuestionUseCase,
+		updateQuestionUseCase,
+		deleteQuestionUseCase,
+		findQuestionsUseCase,
+		appLogger,
+	)
+	quizHandler := handlers.NewQuizHandler(
+		createQuizUseCase,
+		updateQuizUseCase,
+		getQuizUseCase,
+		startAttemptUseCase,
+		submitAttemptUseCase,
+		saveAnswersUseCase,
+		getAttemptUseCase,
+		findAttemptsUseCase,
+		appLogger,
+	)
+	assignmentHandler := handlers.NewAssignmentHandler(
+		createAssignmentUseCase,
+		updateAssignmentUseCase,
+		getAssignmentUseCase,
+		submitAssignmentUseCase,
+		findSubmissionsUseCase,
+		gradeSubmissionUseCase,
+		appLogger,
+	)
 
 	userUpdatedHandler := appHandlers.NewUserUpdatedHandler(instructorRepo, appLogger)
+	userPurgedHandler := appHandlers.NewUserPurgedHandler(instructorRepo, attemptRepo, submissionRepo, appLogger)
 	zoomMeetingCreatedHandler := appHandlers.NewZoomMeetingCreatedHandler(moduleRepo, appLogger)
-	eventConsumer := consumer.NewEventConsumer(rabbitMQ, userUpdatedHandler, zoomMeetingCreatedHandler, appLogger)
+	enrollmentChangedHandler := appHandlers.NewEnrollmentChangedHandler(enrollmentRepo, appLogger)
+	enrollmentDeletedHandler := appHandlers.NewEnrollmentDeletedHandler(enrollmentRepo, appLogger)
+	replayRequestedHandler := appHandlers.NewReplayRequestedHandler(usecases.NewReplayEventsUseCase(instructorRepo, assignmentRepo, submissionRepo, rabbitMQ, appLogger), appLogger)
+	eventConsumer := consumer.NewEventConsumer(rabbitMQ, userUpdatedHandler, userPurgedHandler, zoomMeetingCreatedHandler, enrollmentChangedHandler, enrollmentDeletedHandler, replayRequestedHandler, appLogger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -176,6 +245,13 @@ func main() {
 		courseOfferingHandler,
 		courseSectionHandler,
 		sectionModuleHandler,
+		questionHandler,
+		quizHandler,
+		assignmentHandler,
+		policies.NewOfferingAccess(instructorRepo, sectionRepo, moduleRepo, offeringRepo, quizRepo, assignmentRepo, submissionRepo, attemptRepo, enrollmentRepo),
+		authenticate,
+		rateLimitStore,
+		cfg.RateLimit,
 		appLogger,
 	)
 	server := &http.Server{
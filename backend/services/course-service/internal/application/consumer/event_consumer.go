@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
@@ -13,20 +14,32 @@ import (
 type EventConsumer struct {
 	rabbitMQ              *messaging.RabbitMQ
 	userUpdatedHandler    *handlers.UserUpdatedHandler
+	userPurgedHandler     *handlers.UserPurgedHandler
 	zoomMeetingCreatedHandler *handlers.ZoomMeetingCreatedHandler
+	enrollmentChangedHandler  *handlers.EnrollmentChangedHandler
+	enrollmentDeletedHandler  *handlers.EnrollmentDeletedHandler
+	replayRequestedHandler    *handlers.ReplayRequestedHandler
 	logger                *logger.Logger
 }
 
 func NewEventConsumer(
 	rabbitMQ *messaging.RabbitMQ,
 	userUpdatedHandler *handlers.UserUpdatedHandler,
+	userPurgedHandler *handlers.UserPurgedHandler,
 	zoomMeetingCreatedHandler *handlers.ZoomMeetingCreatedHandler,
+	enrollmentChangedHandler *handlers.EnrollmentChangedHandler,
+	enrollmentDeletedHandler *handlers.EnrollmentDeletedHandler,
+	replayRequestedHandler *handlers.ReplayRequestedHandler,
 	logger *logger.Logger,
 ) *EventConsumer {
 	return &EventConsumer{
 		rabbitMQ:              rabbitMQ,
 		userUpdatedHandler:    userUpdatedHandler,
+		userPurgedHandler:     userPurgedHandler,
 		zoomMeetingCreatedHandler: zoomMeetingCreatedHandler,
+		enrollmentChangedHandler:  enrollmentChangedHandler,
+		enrollmentDeletedHandler:  enrollmentDeletedHandler,
+		replayRequestedHandler:    replayRequestedHandler,
 		logger:                logger,
 	}
 }
@@ -34,7 +47,13 @@ func NewEventConsumer(
 func (c *EventConsumer) Start(ctx context.Context) error {
 	routingKeys := []string{
 		events.EventTypeUserUpdated,
+		events.EventTypeUserPurged,
 		events.EventTypeZoomMeetingCreated,
+		events.EventTypeEnrollmentCreated,
+		events.EventTypeEnrollmentUpdated,
+		events.EventTypeEnrollmentDeleted,
+		events.EventTypeEnrollmentReplayed,
+		events.EventTypeReplayRequested,
 	}
 
 	messages, err := c.rabbitMQ.Consume(ctx, "course-service.queue", routingKeys)
@@ -46,6 +65,13 @@ func (c *EventConsumer) Start(ctx context.Context) error {
 		zap.Strings("routing_keys", routingKeys),
 	)
 
+	// Now that the queue is bound, replayed events reach it. The request is
+	// also how this service replays its own data to the others on startup.
+	request := events.ReplayRequestedEvent{Service: "course-service", RequestedAt: time.Now().UTC()}
+	if err := c.rabbitMQ.Publish(ctx, events.EventTypeReplayRequested, request); err != nil {
+		c.logger.Error("failed to request event replay", zap.Error(err))
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -78,8 +104,16 @@ func (c *EventConsumer) handleMessage(msg messaging.Message) error {
 	switch msg.RoutingKey {
 	case events.EventTypeUserUpdated:
 		return c.userUpdatedHandler.Handle(msg.Body)
+	case events.EventTypeUserPurged:
+		return c.userPurgedHandler.Handle(msg.Body)
 	case events.EventTypeZoomMeetingCreated:
 		return c.zoomMeetingCreatedHandler.Handle(msg.Body)
+	case events.EventTypeEnrollmentCreated, events.EventTypeEnrollmentUpdated, events.EventTypeEnrollmentReplayed:
+		return c.enrollmentChangedHandler.Handle(msg.Body)
+	case events.EventTypeEnrollmentDeleted:
+		return c.enrollmentDeletedHandler.Handle(msg.Body)
+	case events.EventTypeReplayRequested:
+		return c.replayRequestedHandler.Handle(msg.Body)
 	default:
 		c.logger.Warn("unknown routing key",
 			zap.String("routing_key", msg.RoutingKey),
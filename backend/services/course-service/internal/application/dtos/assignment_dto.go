@@ -0,0 +1,129 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+type AssignmentDTO struct {
+	ID                 string     `json:"id"`
+	SectionModuleID    string     `json:"section_module_id"`
+	CourseOfferingID   string     `json:"course_offering_id"`
+	Instructions       string     `json:"instructions"`
+	AllowFiles         bool       `json:"allow_files"`
+	AllowText          bool       `json:"allow_text"`
+	DueAt              *time.Time `json:"due_at,omitempty"`
+	LatePolicy         string     `json:"late_policy"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	MaxScore           float64    `json:"max_score"`
+	MaxSubmissions     *int       `json:"max_submissions,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func (d *AssignmentDTO) FromEntity(assignment *entities.Assignment) {
+	d.ID = assignment.ID
+	d.SectionModuleID = assignment.SectionModuleID
+	d.CourseOfferingID = assignment.CourseOfferingID
+	d.Instructions = assignment.Instructions
+	d.AllowFiles = assignment.AllowFiles
+	d.AllowText = assignment.AllowText
+	d.DueAt = assignment.DueAt
+	d.LatePolicy = string(assignment.LatePolicy)
+	d.LatePenaltyPercent = assignment.LatePenaltyPercent
+	d.MaxScore = assignment.MaxScore
+	d.MaxSubmissions = assignment.MaxSubmissions
+	d.CreatedAt = assignment.CreatedAt
+	d.UpdatedAt = assignment.UpdatedAt
+}
+
+// CreateAssignmentInput attaches an assignment to a section module of
+// content type assignment. LatePenaltyPercent only applies to the penalize
+// late policy, which like block needs a due date.
+type CreateAssignmentInput struct {
+	SectionModuleID    string     `json:"section_module_id" binding:"required"`
+	Instructions       string     `json:"instructions"`
+	AllowFiles         bool       `json:"allow_files"`
+	AllowText          bool       `json:"allow_text"`
+	DueAt              *time.Time `json:"due_at"`
+	LatePolicy         string     `json:"late_policy"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	MaxScore           float64    `json:"max_score" binding:"required"`
+	MaxSubmissions     *int       `json:"max_submissions"`
+}
+
+type UpdateAssignmentInput struct {
+	Instructions       string     `json:"instructions"`
+	AllowFiles         bool       `json:"allow_files"`
+	AllowText          bool       `json:"allow_text"`
+	DueAt              *time.Time `json:"due_at"`
+	LatePolicy         string     `json:"late_policy"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	MaxScore           float64    `json:"max_score" binding:"required"`
+	MaxSubmissions     *int       `json:"max_submissions"`
+}
+
+type SubmissionFileDTO struct {
+	ID          string `json:"id"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+type AssignmentSubmissionDTO struct {
+	ID               string              `json:"id"`
+	AssignmentID     string              `json:"assignment_id"`
+	StudentID        string              `json:"student_id"`
+	SubmissionNumber int                 `json:"submission_number"`
+	Text             *string             `json:"text,omitempty"`
+	Files            []SubmissionFileDTO `json:"files"`
+	Late             bool                `json:"late"`
+	PenaltyPercent   float64             `json:"penalty_percent"`
+	Status           string              `json:"status"`
+	Score            *float64            `json:"score,omitempty"`
+	FinalScore       *float64            `json:"final_score,omitempty"`
+	Feedback         *string             `json:"feedback,omitempty"`
+	GradedBy         *string             `json:"graded_by,omitempty"`
+	GradedAt         *time.Time          `json:"graded_at,omitempty"`
+	SubmittedAt      time.Time           `json:"submitted_at"`
+}
+
+// FromEntity fills the DTO from submission, linking each file to its
+// file-service download.
+func (d *AssignmentSubmissionDTO) FromEntity(submission *entities.AssignmentSubmission, apiGatewayURL string) {
+	d.ID = submission.ID
+	d.AssignmentID = submission.AssignmentID
+	d.StudentID = submission.StudentID
+	d.SubmissionNumber = submission.SubmissionNumber
+	d.Text = submission.Text
+	d.Late = submission.Late
+	d.PenaltyPercent = submission.PenaltyPercent
+	d.Status = string(submission.Status)
+	d.Score = submission.Score
+	d.FinalScore = submission.FinalScore
+	d.Feedback = submission.Feedback
+	d.GradedBy = submission.GradedBy
+	d.GradedAt = submission.GradedAt
+	d.SubmittedAt = submission.SubmittedAt
+
+	d.Files = make([]SubmissionFileDTO, len(submission.FileIDs))
+	for i, id := range submission.FileIDs {
+		d.Files[i].ID = id
+		if apiGatewayURL != "" {
+			d.Files[i].DownloadURL = apiGatewayURL + "/api/v1/files/" + id + "/download"
+		}
+	}
+}
+
+// SubmitAssignmentInput hands in Text, FileIDs of files uploaded to
+// file-service's assignment-submissions bucket, or both.
+type SubmitAssignmentInput struct {
+	Text    *string  `json:"text"`
+	FileIDs []string `json:"file_ids"`
+}
+
+// GradeAssignmentSubmissionInput grades a submission out of the assignment's
+// max score. The late penalty is deducted automatically.
+type GradeAssignmentSubmissionInput struct {
+	Score    *float64 `json:"score" binding:"required"`
+	Feedback *string  `json:"feedback"`
+}
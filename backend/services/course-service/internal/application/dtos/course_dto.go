@@ -15,6 +15,8 @@ type CourseDTO struct {
 	Categories   []CategoryDTO `json:"categories,omitempty"`
 	CreatedAt    time.Time    `json:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at"`
+	// OrganizationID is omitted for courses outside any organization.
+	OrganizationID *string `json:"organization_id,omitempty"`
 }
 
 func (d *CourseDTO) FromEntity(course *entities.Course, apiGatewayURL string) {
@@ -24,6 +26,7 @@ func (d *CourseDTO) FromEntity(course *entities.Course, apiGatewayURL string) {
 	d.ThumbnailID = course.ThumbnailID
 	d.CreatedAt = course.CreatedAt
 	d.UpdatedAt = course.UpdatedAt
+	d.OrganizationID = course.OrganizationID
 
 	// Compute thumbnail URL if thumbnail_id exists
 	if course.ThumbnailID != nil && *course.ThumbnailID != "" && apiGatewayURL != "" {
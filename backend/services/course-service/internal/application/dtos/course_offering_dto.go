@@ -19,6 +19,7 @@ type CourseOfferingDTO struct {
 	EnrollmentCost float64    `json:"enrollment_cost"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+	OrganizationID *string    `json:"organization_id,omitempty"`
 }
 
 func (d *CourseOfferingDTO) FromEntity(offering *entities.CourseOffering) {
@@ -33,6 +34,7 @@ func (d *CourseOfferingDTO) FromEntity(offering *entities.CourseOffering) {
 	d.EnrollmentCost = offering.EnrollmentCost
 	d.CreatedAt = offering.CreatedAt
 	d.UpdatedAt = offering.UpdatedAt
+	d.OrganizationID = offering.OrganizationID
 }
 
 type CreateCourseOfferingInput struct {
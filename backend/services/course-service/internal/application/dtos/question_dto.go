@@ -0,0 +1,67 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+// QuestionDTO is a question bank entry including its answer key. It is only
+// returned to staff; students see questions through their quiz attempts.
+type QuestionDTO struct {
+	ID               string                    `json:"id"`
+	CourseID         string                    `json:"course_id"`
+	Type             string                    `json:"type"`
+	Prompt           string                    `json:"prompt"`
+	Points           float64                   `json:"points"`
+	Options          []entities.QuestionOption `json:"options,omitempty"`
+	CorrectOptionIDs []string                  `json:"correct_option_ids,omitempty"`
+	AcceptedAnswers  []string                  `json:"accepted_answers,omitempty"`
+	NumericAnswer    *float64                  `json:"numeric_answer,omitempty"`
+	NumericTolerance float64                   `json:"numeric_tolerance,omitempty"`
+	CreatedAt        time.Time                 `json:"created_at"`
+	UpdatedAt        time.Time                 `json:"updated_at"`
+}
+
+func (d *QuestionDTO) FromEntity(question *entities.Question) {
+	d.ID = question.ID
+	d.CourseID = question.CourseID
+	d.Type = string(question.Type)
+	d.Prompt = question.Prompt
+	d.Points = question.Points
+	d.Options = question.Options
+	d.CorrectOptionIDs = question.CorrectOptionIDs
+	d.AcceptedAnswers = question.AcceptedAnswers
+	d.NumericAnswer = question.NumericAnswer
+	d.NumericTolerance = question.NumericTolerance
+	d.CreatedAt = question.CreatedAt
+	d.UpdatedAt = question.UpdatedAt
+}
+
+// CreateQuestionInput describes a question and its answer key. Multiple
+// choice and multi-select questions take Options and CorrectOptions, the
+// indexes of the correct options; true/false questions take CorrectOptions
+// only, [0] for True or [1] for False; short answer questions take
+// AcceptedAnswers and numeric questions NumericAnswer and NumericTolerance.
+type CreateQuestionInput struct {
+	Type             string   `json:"type" binding:"required"`
+	Prompt           string   `json:"prompt" binding:"required"`
+	Points           float64  `json:"points" binding:"required"`
+	Options          []string `json:"options"`
+	CorrectOptions   []int    `json:"correct_options"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	NumericTolerance float64  `json:"numeric_tolerance"`
+}
+
+// UpdateQuestionInput replaces the prompt, points and answer key of a
+// question. Its type cannot change.
+type UpdateQuestionInput struct {
+	Prompt           string   `json:"prompt" binding:"required"`
+	Points           float64  `json:"points" binding:"required"`
+	Options          []string `json:"options"`
+	CorrectOptions   []int    `json:"correct_options"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	NumericTolerance float64  `json:"numeric_tolerance"`
+}
@@ -0,0 +1,179 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+type QuizDTO struct {
+	ID                string     `json:"id"`
+	SectionModuleID   string     `json:"section_module_id"`
+	CourseID          string     `json:"course_id"`
+	QuestionIDs       []string   `json:"question_ids"`
+	TimeLimitMinutes  *int       `json:"time_limit_minutes,omitempty"`
+	MaxAttempts       *int       `json:"max_attempts,omitempty"`
+	ShuffleQuestions  bool       `json:"shuffle_questions"`
+	ShuffleOptions    bool       `json:"shuffle_options"`
+	ResultsVisibility string     `json:"results_visibility"`
+	ClosesAt          *time.Time `json:"closes_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (d *QuizDTO) FromEntity(quiz *entities.Quiz) {
+	d.ID = quiz.ID
+	d.SectionModuleID = quiz.SectionModuleID
+	d.CourseID = quiz.CourseID
+	d.QuestionIDs = quiz.QuestionIDs
+	d.TimeLimitMinutes = quiz.TimeLimitMinutes
+	d.MaxAttempts = quiz.MaxAttempts
+	d.ShuffleQuestions = quiz.ShuffleQuestions
+	d.ShuffleOptions = quiz.ShuffleOptions
+	d.ResultsVisibility = string(quiz.ResultsVisibility)
+	d.ClosesAt = quiz.ClosesAt
+	d.CreatedAt = quiz.CreatedAt
+	d.UpdatedAt = quiz.UpdatedAt
+}
+
+// CreateQuizInput attaches a quiz to a section module of content type quiz.
+// Questions must come from the question bank of the module's course.
+type CreateQuizInput struct {
+	SectionModuleID   string     `json:"section_module_id" binding:"required"`
+	QuestionIDs       []string   `json:"question_ids" binding:"required"`
+	TimeLimitMinutes  *int       `json:"time_limit_minutes"`
+	MaxAttempts       *int       `json:"max_attempts"`
+	ShuffleQuestions  bool       `json:"shuffle_questions"`
+	ShuffleOptions    bool       `json:"shuffle_options"`
+	ResultsVisibility string     `json:"results_visibility"`
+	ClosesAt          *time.Time `json:"closes_at"`
+}
+
+type UpdateQuizInput struct {
+	QuestionIDs       []string   `json:"question_ids" binding:"required"`
+	TimeLimitMinutes  *int       `json:"time_limit_minutes"`
+	MaxAttempts       *int       `json:"max_attempts"`
+	ShuffleQuestions  bool       `json:"shuffle_questions"`
+	ShuffleOptions    bool       `json:"shuffle_options"`
+	ResultsVisibility string     `json:"results_visibility"`
+	ClosesAt          *time.Time `json:"closes_at"`
+}
+
+// AttemptQuestionDTO is a question as a student sees it in an attempt, with
+// the options in the attempt's order. Result and the answer key are only set
+// when the quiz shows correct answers.
+type AttemptQuestionDTO struct {
+	ID               string                    `json:"id"`
+	Type             string                    `json:"type"`
+	Prompt           string                    `json:"prompt"`
+	Points           float64                   `json:"points"`
+	Options          []entities.QuestionOption `json:"options,omitempty"`
+	Answer           *entities.QuestionAnswer  `json:"answer,omitempty"`
+	Result           *entities.QuestionResult  `json:"result,omitempty"`
+	CorrectOptionIDs []string                  `json:"correct_option_ids,omitempty"`
+	AcceptedAnswers  []string                  `json:"accepted_answers,omitempty"`
+	NumericAnswer    *float64                  `json:"numeric_answer,omitempty"`
+}
+
+// QuizAttemptDTO is an attempt with its questions in the order they were
+// given. Score and MaxScore are only set once the attempt is closed and the
+// quiz shows scores.
+type QuizAttemptDTO struct {
+	ID            string               `json:"id"`
+	QuizID        string               `json:"quiz_id"`
+	StudentID     string               `json:"student_id"`
+	AttemptNumber int                  `json:"attempt_number"`
+	Status        string               `json:"status"`
+	Questions     []AttemptQuestionDTO `json:"questions"`
+	Score         *float64             `json:"score,omitempty"`
+	MaxScore      *float64             `json:"max_score,omitempty"`
+	StartedAt     time.Time            `json:"started_at"`
+	ExpiresAt     *time.Time           `json:"expires_at,omitempty"`
+	SubmittedAt   *time.Time           `json:"submitted_at,omitempty"`
+}
+
+// FromEntity fills the DTO from attempt and the questions it uses, keyed by
+// ID. Questions deleted since the attempt started are left out. showScore
+// and showAnswers decide whether the score, and the per-question results and
+// answer keys, are included.
+func (d *QuizAttemptDTO) FromEntity(attempt *entities.QuizAttempt, questions map[string]*entities.Question, showScore, showAnswers bool) {
+	d.ID = attempt.ID
+	d.QuizID = attempt.QuizID
+	d.StudentID = attempt.StudentID
+	d.AttemptNumber = attempt.AttemptNumber
+	d.Status = string(attempt.Status)
+	d.StartedAt = attempt.StartedAt
+	d.ExpiresAt = attempt.ExpiresAt
+	d.SubmittedAt = attempt.SubmittedAt
+
+	closed := attempt.Status != entities.QuizAttemptStatusInProgress
+	if closed && showScore {
+		score, maxScore := attempt.Score, attempt.MaxScore
+		d.Score = &score
+		d.MaxScore = &maxScore
+	}
+
+	results := make(map[string]entities.QuestionResult, len(attempt.Results))
+	for _, result := range attempt.Results {
+		results[result.QuestionID] = result
+	}
+
+	d.Questions = make([]AttemptQuestionDTO, 0, len(attempt.QuestionIDs))
+	for _, questionID := range attempt.QuestionIDs {
+		question, ok := questions[questionID]
+		if !ok {
+			continue
+		}
+		item := AttemptQuestionDTO{
+			ID:      question.ID,
+			Type:    string(question.Type),
+			Prompt:  question.Prompt,
+			Points:  question.Points,
+			Options: orderedOptions(question, attempt.OptionOrder[questionID]),
+		}
+		if answer, ok := attempt.Answers[questionID]; ok {
+			item.Answer = &answer
+		}
+		if closed && showAnswers {
+			if result, ok := results[questionID]; ok {
+				item.Result = &result
+			}
+			item.CorrectOptionIDs = question.CorrectOptionIDs
+			item.AcceptedAnswers = question.AcceptedAnswers
+			item.NumericAnswer = question.NumericAnswer
+		}
+		d.Questions = append(d.Questions, item)
+	}
+}
+
+// orderedOptions returns the question's options in order, followed by any
+// options added since the attempt started.
+func orderedOptions(question *entities.Question, order []string) []entities.QuestionOption {
+	if len(question.Options) == 0 {
+		return nil
+	}
+	byID := make(map[string]entities.QuestionOption, len(question.Options))
+	for _, option := range question.Options {
+		byID[option.ID] = option
+	}
+	options := make([]entities.QuestionOption, 0, len(question.Options))
+	for _, id := range order {
+		if option, ok := byID[id]; ok {
+			options = append(options, option)
+			delete(byID, id)
+		}
+	}
+	for _, option := range question.Options {
+		if _, ok := byID[option.ID]; ok {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// SubmitQuizAttemptInput maps question IDs to answers: the chosen option IDs
+// for the choice types and text for short answer and numeric questions. It is
+// also used to save answers while the attempt is in progress.
+type SubmitQuizAttemptInput struct {
+	Answers map[string]entities.QuestionAnswer `json:"answers"`
+}
@@ -7,22 +7,28 @@ import (
 )
 
 type SectionModuleDTO struct {
-	ID              string     `json:"id"`
-	CourseSectionID string     `json:"course_section_id"`
-	ContentID       *string    `json:"content_id,omitempty"`
-	Name            string     `json:"name"`
-	Description     string     `json:"description"`
-	ContentType     string     `json:"content_type"`
-	ContentStatus   string     `json:"content_status"`
-	Order           int        `json:"order"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID              string    `json:"id"`
+	CourseSectionID string    `json:"course_section_id"`
+	ContentID       *string   `json:"content_id,omitempty"`
+	ContentBody     *string   `json:"content_body,omitempty"`
+	ContentURL      *string   `json:"content_url,omitempty"`
+	StreamURL       string    `json:"stream_url,omitempty"`
+	DownloadURL     string    `json:"download_url,omitempty"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	ContentType     string    `json:"content_type"`
+	ContentStatus   string    `json:"content_status"`
+	Order           int       `json:"order"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
-func (d *SectionModuleDTO) FromEntity(module *entities.SectionModule) {
+func (d *SectionModuleDTO) FromEntity(module *entities.SectionModule, apiGatewayURL string) {
 	d.ID = module.ID
 	d.CourseSectionID = module.CourseSectionID
 	d.ContentID = module.ContentID
+	d.ContentBody = module.ContentBody
+	d.ContentURL = module.ContentURL
 	d.Name = module.Name
 	d.Description = module.Description
 	d.ContentType = string(module.ContentType)
@@ -30,18 +36,39 @@ func (d *SectionModuleDTO) FromEntity(module *entities.SectionModule) {
 	d.Order = module.Order
 	d.CreatedAt = module.CreatedAt
 	d.UpdatedAt = module.UpdatedAt
+
+	if module.ContentID == nil || *module.ContentID == "" || apiGatewayURL == "" {
+		return
+	}
+	switch module.ContentType {
+	case entities.ContentTypeVideo:
+		d.StreamURL = apiGatewayURL + "/api/v1/buckets/course-videos/files/" + *module.ContentID + "/download"
+	case entities.ContentTypeDocument:
+		d.DownloadURL = apiGatewayURL + "/api/v1/files/" + *module.ContentID + "/download"
+	}
 }
 
+// CreateSectionModuleInput carries the content that matches ContentType:
+// ContentID for video and document, ContentBody for text and ContentURL for
+// link and embed. Zoom and quiz modules take none; zoom-service and the
+// quizzes endpoint fill them in.
 type CreateSectionModuleInput struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	ContentType string `json:"content_type" binding:"required"`
-	Order       int    `json:"order"`
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description"`
+	ContentType string  `json:"content_type" binding:"required"`
+	ContentID   *string `json:"content_id"`
+	ContentBody *string `json:"content_body"`
+	ContentURL  *string `json:"content_url"`
+	Order       int     `json:"order"`
 }
 
+// UpdateSectionModuleInput replaces the module's content when any of the
+// content fields is set. The content type itself cannot change.
 type UpdateSectionModuleInput struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	Order       int    `json:"order"`
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description"`
+	ContentID   *string `json:"content_id"`
+	ContentBody *string `json:"content_body"`
+	ContentURL  *string `json:"content_url"`
+	Order       int     `json:"order"`
 }
-
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// EnrollmentChangedHandler records created enrollments and their status
+// changes, which decide whether the student may take the offering's quizzes.
+// The created and updated events carry the same fields.
+type EnrollmentChangedHandler struct {
+	enrollmentRepo repositories.OfferingEnrollmentRepository
+	logger         *logger.Logger
+}
+
+func NewEnrollmentChangedHandler(
+	enrollmentRepo repositories.OfferingEnrollmentRepository,
+	logger *logger.Logger,
+) *EnrollmentChangedHandler {
+	return &EnrollmentChangedHandler{
+		enrollmentRepo: enrollmentRepo,
+		logger:         logger,
+	}
+}
+
+func (h *EnrollmentChangedHandler) Handle(body []byte) error {
+	var event events.EnrollmentUpdatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal enrollment event", zap.Error(err))
+		return err
+	}
+
+	if err := h.enrollmentRepo.Save(context.Background(), event.ID, event.CourseOfferingID, event.StudentID, event.Status); err != nil {
+		h.logger.Error("failed to record offering enrollment",
+			zap.String("enrollment_id", event.ID),
+			zap.String("course_offering_id", event.CourseOfferingID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("recorded offering enrollment",
+		zap.String("enrollment_id", event.ID),
+		zap.String("course_offering_id", event.CourseOfferingID),
+		zap.String("status", event.Status),
+	)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// EnrollmentDeletedHandler forgets deleted enrollments, so the student can no
+// longer take the offering's quizzes.
+type EnrollmentDeletedHandler struct {
+	enrollmentRepo repositories.OfferingEnrollmentRepository
+	logger         *logger.Logger
+}
+
+func NewEnrollmentDeletedHandler(
+	enrollmentRepo repositories.OfferingEnrollmentRepository,
+	logger *logger.Logger,
+) *EnrollmentDeletedHandler {
+	return &EnrollmentDeletedHandler{
+		enrollmentRepo: enrollmentRepo,
+		logger:         logger,
+	}
+}
+
+func (h *EnrollmentDeletedHandler) Handle(body []byte) error {
+	var event events.EnrollmentDeletedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal enrollment deleted event", zap.Error(err))
+		return err
+	}
+
+	if err := h.enrollmentRepo.Delete(context.Background(), event.ID); err != nil {
+		h.logger.Error("failed to remove offering enrollment",
+			zap.String("enrollment_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("removed offering enrollment",
+		zap.String("enrollment_id", event.ID),
+		zap.String("course_offering_id", event.CourseOfferingID),
+	)
+
+	return nil
+}
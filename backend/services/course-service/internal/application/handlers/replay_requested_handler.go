@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// ReplayRequestedHandler replays the course data other services keep copies
+// of whenever one of them asks, including this service on startup.
+type ReplayRequestedHandler struct {
+	replayUseCase *usecases.ReplayEventsUseCase
+	logger        *logger.Logger
+}
+
+func NewReplayRequestedHandler(
+	replayUseCase *usecases.ReplayEventsUseCase,
+	logger *logger.Logger,
+) *ReplayRequestedHandler {
+	return &ReplayRequestedHandler{
+		replayUseCase: replayUseCase,
+		logger:        logger,
+	}
+}
+
+func (h *ReplayRequestedHandler) Handle(body []byte) error {
+	var event events.ReplayRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal replay requested event", zap.Error(err))
+		return err
+	}
+
+	if err := h.replayUseCase.Execute(context.Background()); err != nil {
+		h.logger.Error("failed to replay events",
+			zap.String("requested_by", event.Service),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
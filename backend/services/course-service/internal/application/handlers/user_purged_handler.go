@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// UserPurgedHandler removes a purged instructor from the offerings they were
+// assigned to, and a purged student's quiz attempts and assignment
+// submissions. The offerings themselves stay, as they belong to the course.
+type UserPurgedHandler struct {
+	instructorRepo repositories.CourseOfferingInstructorRepository
+	attemptRepo    repositories.QuizAttemptRepository
+	submissionRepo repositories.AssignmentSubmissionRepository
+	logger         *logger.Logger
+}
+
+func NewUserPurgedHandler(
+	instructorRepo repositories.CourseOfferingInstructorRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	logger *logger.Logger,
+) *UserPurgedHandler {
+	return &UserPurgedHandler{
+		instructorRepo: instructorRepo,
+		attemptRepo:    attemptRepo,
+		submissionRepo: submissionRepo,
+		logger:         logger,
+	}
+}
+
+func (h *UserPurgedHandler) Handle(body []byte) error {
+	var event events.UserPurgedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user purged event", zap.Error(err))
+		return err
+	}
+
+	removed, err := h.instructorRepo.DeleteByInstructorID(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Error("failed to remove purged instructor",
+			zap.String("instructor_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if removed > 0 {
+		h.logger.Info("removed purged instructor from offerings",
+			zap.String("instructor_id", event.ID),
+			zap.Int64("assignments", removed),
+		)
+	}
+
+	attempts, err := h.attemptRepo.DeleteByStudentID(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Error("failed to remove purged student's quiz attempts",
+			zap.String("student_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if attempts > 0 {
+		h.logger.Info("removed purged student's quiz attempts",
+			zap.String("student_id", event.ID),
+			zap.Int64("attempts", attempts),
+		)
+	}
+
+	submissions, err := h.submissionRepo.DeleteByStudentID(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Error("failed to remove purged student's assignment submissions",
+			zap.String("student_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if submissions > 0 {
+		h.logger.Info("removed purged student's assignment submissions",
+			zap.String("student_id", event.ID),
+			zap.Int64("submissions", submissions),
+		)
+	}
+
+	return nil
+}
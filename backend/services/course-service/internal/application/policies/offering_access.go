@@ -0,0 +1,217 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+)
+
+// OfferingAccess answers whether an instructor teaches the offering a
+// resource belongs to, based on the course_offering_instructor assignments,
+// or whether a student is enrolled in it. Its methods are used as ownership
+// checks by the route policies.
+type OfferingAccess struct {
+	instructorRepo repositories.CourseOfferingInstructorRepository
+	sectionRepo    repositories.CourseSectionRepository
+	moduleRepo     repositories.SectionModuleRepository
+	offeringRepo   repositories.CourseOfferingRepository
+	quizRepo       repositories.QuizRepository
+	assignmentRepo repositories.AssignmentRepository
+	submissionRepo repositories.AssignmentSubmissionRepository
+	attemptRepo    repositories.QuizAttemptRepository
+	enrollmentRepo repositories.OfferingEnrollmentRepository
+}
+
+func NewOfferingAccess(
+	instructorRepo repositories.CourseOfferingInstructorRepository,
+	sectionRepo repositories.CourseSectionRepository,
+	moduleRepo repositories.SectionModuleRepository,
+	offeringRepo repositories.CourseOfferingRepository,
+	quizRepo repositories.QuizRepository,
+	assignmentRepo repositories.AssignmentRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	enrollmentRepo repositories.OfferingEnrollmentRepository,
+) *OfferingAccess {
+	return &OfferingAccess{
+		instructorRepo: instructorRepo,
+		sectionRepo:    sectionRepo,
+		moduleRepo:     moduleRepo,
+		offeringRepo:   offeringRepo,
+		quizRepo:       quizRepo,
+		assignmentRepo: assignmentRepo,
+		submissionRepo: submissionRepo,
+		attemptRepo:    attemptRepo,
+		enrollmentRepo: enrollmentRepo,
+	}
+}
+
+func (a *OfferingAccess) TeachesOffering(ctx context.Context, instructorID, offeringID string) (bool, error) {
+	if _, err := uuid.Parse(offeringID); err != nil {
+		return false, nil
+	}
+	instructors, err := a.instructorRepo.FindByOfferingID(ctx, offeringID)
+	if err != nil {
+		return false, err
+	}
+	for _, instructor := range instructors {
+		if instructor.InstructorID == instructorID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *OfferingAccess) TeachesSection(ctx context.Context, instructorID, sectionID string) (bool, error) {
+	if _, err := uuid.Parse(sectionID); err != nil {
+		return false, nil
+	}
+	section, err := a.sectionRepo.FindByID(ctx, sectionID)
+	if err != nil {
+		return false, err
+	}
+	if section == nil {
+		return false, nil
+	}
+	return a.TeachesOffering(ctx, instructorID, section.CourseOfferingID)
+}
+
+func (a *OfferingAccess) TeachesModule(ctx context.Context, instructorID, moduleID string) (bool, error) {
+	if _, err := uuid.Parse(moduleID); err != nil {
+		return false, nil
+	}
+	module, err := a.moduleRepo.FindByID(ctx, moduleID)
+	if err != nil {
+		return false, err
+	}
+	if module == nil {
+		return false, nil
+	}
+	return a.TeachesSection(ctx, instructorID, module.CourseSectionID)
+}
+
+// TeachesCourse reports whether the instructor teaches any offering of the
+// course, which gives them its question bank.
+func (a *OfferingAccess) TeachesCourse(ctx context.Context, instructorID, courseID string) (bool, error) {
+	if _, err := uuid.Parse(courseID); err != nil {
+		return false, nil
+	}
+	offerings, err := a.offeringRepo.FindByCourseID(ctx, courseID)
+	if err != nil {
+		return false, err
+	}
+	for _, offering := range offerings {
+		teaches, err := a.TeachesOffering(ctx, instructorID, offering.ID)
+		if err != nil || teaches {
+			return teaches, err
+		}
+	}
+	return false, nil
+}
+
+func (a *OfferingAccess) TeachesQuiz(ctx context.Context, instructorID, quizID string) (bool, error) {
+	if _, err := uuid.Parse(quizID); err != nil {
+		return false, nil
+	}
+	quiz, err := a.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return false, err
+	}
+	if quiz == nil {
+		return false, nil
+	}
+	return a.TeachesModule(ctx, instructorID, quiz.SectionModuleID)
+}
+
+func (a *OfferingAccess) TeachesAssignment(ctx context.Context, instructorID, assignmentID string) (bool, error) {
+	if _, err := uuid.Parse(assignmentID); err != nil {
+		return false, nil
+	}
+	assignment, err := a.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return false, err
+	}
+	if assignment == nil {
+		return false, nil
+	}
+	return a.TeachesOffering(ctx, instructorID, assignment.CourseOfferingID)
+}
+
+// TeachesSubmission reports whether the instructor teaches the offering of
+// the assignment a submission was made for, which lets them grade it.
+func (a *OfferingAccess) TeachesSubmission(ctx context.Context, instructorID, submissionID string) (bool, error) {
+	if _, err := uuid.Parse(submissionID); err != nil {
+		return false, nil
+	}
+	submission, err := a.submissionRepo.FindByID(ctx, submissionID)
+	if err != nil {
+		return false, err
+	}
+	if submission == nil {
+		return false, nil
+	}
+	return a.TeachesAssignment(ctx, instructorID, submission.AssignmentID)
+}
+
+// EnrolledInQuiz reports whether the student has an approved enrollment in
+// the offering the quiz belongs to.
+func (a *OfferingAccess) EnrolledInQuiz(ctx context.Context, studentID, quizID string) (bool, error) {
+	if _, err := uuid.Parse(quizID); err != nil {
+		return false, nil
+	}
+	quiz, err := a.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return false, err
+	}
+	if quiz == nil {
+		return false, nil
+	}
+	module, err := a.moduleRepo.FindByID(ctx, quiz.SectionModuleID)
+	if err != nil {
+		return false, err
+	}
+	if module == nil {
+		return false, nil
+	}
+	section, err := a.sectionRepo.FindByID(ctx, module.CourseSectionID)
+	if err != nil {
+		return false, err
+	}
+	if section == nil {
+		return false, nil
+	}
+	return a.enrollmentRepo.IsEnrolled(ctx, section.CourseOfferingID, studentID)
+}
+
+// EnrolledInAttempt reports whether the attempt is the student's own and they
+// are still enrolled in the offering of its quiz.
+func (a *OfferingAccess) EnrolledInAttempt(ctx context.Context, studentID, attemptID string) (bool, error) {
+	if _, err := uuid.Parse(attemptID); err != nil {
+		return false, nil
+	}
+	attempt, err := a.attemptRepo.FindByID(ctx, attemptID)
+	if err != nil {
+		return false, err
+	}
+	if attempt == nil || attempt.StudentID != studentID {
+		return false, nil
+	}
+	return a.EnrolledInQuiz(ctx, studentID, attempt.QuizID)
+}
+
+// EnrolledInAssignment reports whether the student has an approved enrollment
+// in the assignment's offering.
+func (a *OfferingAccess) EnrolledInAssignment(ctx context.Context, studentID, assignmentID string) (bool, error) {
+	if _, err := uuid.Parse(assignmentID); err != nil {
+		return false, nil
+	}
+	assignment, err := a.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return false, err
+	}
+	if assignment == nil {
+		return false, nil
+	}
+	return a.enrollmentRepo.IsEnrolled(ctx, assignment.CourseOfferingID, studentID)
+}
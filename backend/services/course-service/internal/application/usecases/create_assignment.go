@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+var (
+	ErrAssignmentNotFound      = errors.New("assignment not found")
+	ErrNotAssignmentModule     = errors.New("section module is not an assignment module")
+	ErrAssignmentAlreadyExists = errors.New("section module already has an assignment")
+)
+
+type CreateAssignmentUseCase struct {
+	assignmentRepo repositories.AssignmentRepository
+	moduleRepo     repositories.SectionModuleRepository
+	sectionRepo    repositories.CourseSectionRepository
+	logger         *logger.Logger
+}
+
+func NewCreateAssignmentUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	moduleRepo repositories.SectionModuleRepository,
+	sectionRepo repositories.CourseSectionRepository,
+	logger *logger.Logger,
+) *CreateAssignmentUseCase {
+	return &CreateAssignmentUseCase{
+		assignmentRepo: assignmentRepo,
+		moduleRepo:     moduleRepo,
+		sectionRepo:    sectionRepo,
+		logger:         logger,
+	}
+}
+
+// Execute creates the assignment of an assignment section module and marks
+// the module's content as created.
+func (uc *CreateAssignmentUseCase) Execute(ctx context.Context, input dtos.CreateAssignmentInput) (*dtos.AssignmentDTO, error) {
+	module, err := uc.moduleRepo.FindByID(ctx, input.SectionModuleID)
+	if err != nil {
+		return nil, err
+	}
+	if module == nil {
+		return nil, ErrSectionModuleNotFound
+	}
+	if module.ContentType != entities.ContentTypeAssignment {
+		return nil, ErrNotAssignmentModule
+	}
+
+	existing, err := uc.assignmentRepo.FindBySectionModuleID(ctx, module.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAssignmentAlreadyExists
+	}
+
+	section, err := uc.sectionRepo.FindByID(ctx, module.CourseSectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section == nil {
+		return nil, ErrCourseSectionNotFound
+	}
+
+	assignment, err := entities.NewAssignment(module.ID, section.CourseOfferingID, entities.AssignmentSettings{
+		Instructions:       input.Instructions,
+		AllowFiles:         input.AllowFiles,
+		AllowText:          input.AllowText,
+		DueAt:              input.DueAt,
+		LatePolicy:         entities.LatePolicy(input.LatePolicy),
+		LatePenaltyPercent: input.LatePenaltyPercent,
+		MaxScore:           input.MaxScore,
+		MaxSubmissions:     input.MaxSubmissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignmentRepo.Create(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	module.UpdateContent(&assignment.ID, entities.ContentStatusCreated)
+	if err := uc.moduleRepo.Update(ctx, module); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.AssignmentDTO
+	dto.FromEntity(assignment)
+	return &dto, nil
+}
@@ -49,6 +49,7 @@ func (uc *CreateCourseOfferingUseCase) Execute(ctx context.Context, courseID str
 
 	offeringType := entities.OfferingType(input.OfferingType)
 	offering := entities.NewCourseOffering(courseID, input.Name, input.Description, offeringType, input.Duration, input.ClassTime, input.EnrollmentCost)
+	offering.OrganizationID = course.OrganizationID
 
 	if err := uc.offeringRepo.Create(ctx, offering); err != nil {
 		return nil, err
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+var (
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrQuestionInUse    = errors.New("question is used by a quiz")
+)
+
+type CreateQuestionUseCase struct {
+	questionRepo repositories.QuestionRepository
+	courseRepo   repositories.CourseRepository
+	logger       *logger.Logger
+}
+
+func NewCreateQuestionUseCase(
+	questionRepo repositories.QuestionRepository,
+	courseRepo repositories.CourseRepository,
+	logger *logger.Logger,
+) *CreateQuestionUseCase {
+	return &CreateQuestionUseCase{
+		questionRepo: questionRepo,
+		courseRepo:   courseRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *CreateQuestionUseCase) Execute(ctx context.Context, courseID string, input dtos.CreateQuestionInput) (*dtos.QuestionDTO, error) {
+	course, err := uc.courseRepo.FindByID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+
+	question, err := entities.NewQuestion(courseID, entities.QuestionType(input.Type), input.Prompt, input.Points, entities.AnswerKey{
+		Options:          input.Options,
+		CorrectOptions:   input.CorrectOptions,
+		AcceptedAnswers:  input.AcceptedAnswers,
+		NumericAnswer:    input.NumericAnswer,
+		NumericTolerance: input.NumericTolerance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.questionRepo.Create(ctx, question); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuestionDTO
+	dto.FromEntity(question)
+	return &dto, nil
+}
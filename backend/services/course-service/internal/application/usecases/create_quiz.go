@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+var (
+	ErrQuizNotFound      = errors.New("quiz not found")
+	ErrNotQuizModule     = errors.New("section module is not a quiz module")
+	ErrQuizAlreadyExists = errors.New("section module already has a quiz")
+	ErrQuestionNotInBank = errors.New("quiz questions must come from the course's question bank")
+)
+
+type CreateQuizUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	moduleRepo   repositories.SectionModuleRepository
+	sectionRepo  repositories.CourseSectionRepository
+	offeringRepo repositories.CourseOfferingRepository
+	logger       *logger.Logger
+}
+
+func NewCreateQuizUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	moduleRepo repositories.SectionModuleRepository,
+	sectionRepo repositories.CourseSectionRepository,
+	offeringRepo repositories.CourseOfferingRepository,
+	logger *logger.Logger,
+) *CreateQuizUseCase {
+	return &CreateQuizUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		moduleRepo:   moduleRepo,
+		sectionRepo:  sectionRepo,
+		offeringRepo: offeringRepo,
+		logger:       logger,
+	}
+}
+
+// Execute creates the quiz of a quiz section module and marks the module's
+// content as created.
+func (uc *CreateQuizUseCase) Execute(ctx context.Context, input dtos.CreateQuizInput) (*dtos.QuizDTO, error) {
+	module, err := uc.moduleRepo.FindByID(ctx, input.SectionModuleID)
+	if err != nil {
+		return nil, err
+	}
+	if module == nil {
+		return nil, ErrSectionModuleNotFound
+	}
+	if module.ContentType != entities.ContentTypeQuiz {
+		return nil, ErrNotQuizModule
+	}
+
+	existing, err := uc.quizRepo.FindBySectionModuleID(ctx, module.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrQuizAlreadyExists
+	}
+
+	courseID, err := uc.moduleCourseID(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkQuestionsInBank(ctx, uc.questionRepo, courseID, input.QuestionIDs); err != nil {
+		return nil, err
+	}
+
+	quiz, err := entities.NewQuiz(module.ID, courseID, entities.QuizSettings{
+		QuestionIDs:       input.QuestionIDs,
+		TimeLimitMinutes:  input.TimeLimitMinutes,
+		MaxAttempts:       input.MaxAttempts,
+		ShuffleQuestions:  input.ShuffleQuestions,
+		ShuffleOptions:    input.ShuffleOptions,
+		ResultsVisibility: entities.ResultsVisibility(input.ResultsVisibility),
+		ClosesAt:          input.ClosesAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.quizRepo.Create(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	module.UpdateContent(&quiz.ID, entities.ContentStatusCreated)
+	if err := uc.moduleRepo.Update(ctx, module); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuizDTO
+	dto.FromEntity(quiz)
+	return &dto, nil
+}
+
+func (uc *CreateQuizUseCase) moduleCourseID(ctx context.Context, module *entities.SectionModule) (string, error) {
+	section, err := uc.sectionRepo.FindByID(ctx, module.CourseSectionID)
+	if err != nil {
+		return "", err
+	}
+	if section == nil {
+		return "", ErrCourseSectionNotFound
+	}
+	offering, err := uc.offeringRepo.FindByID(ctx, section.CourseOfferingID)
+	if err != nil {
+		return "", err
+	}
+	if offering == nil {
+		return "", ErrCourseOfferingNotFound
+	}
+	return offering.CourseID, nil
+}
+
+// checkQuestionsInBank verifies every question exists in courseID's bank.
+func checkQuestionsInBank(ctx context.Context, questionRepo repositories.QuestionRepository, courseID string, questionIDs []string) error {
+	questions, err := questionRepo.FindByIDs(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(questions))
+	for _, question := range questions {
+		if question.CourseID == courseID {
+			found[question.ID] = true
+		}
+	}
+	for _, id := range questionIDs {
+		if !found[id] {
+			return ErrQuestionNotInBank
+		}
+	}
+	return nil
+}
+
+// loadQuizQuestions returns the questions with the given IDs in that order,
+// and keyed by ID. Questions deleted from the bank are left out.
+func loadQuizQuestions(ctx context.Context, questionRepo repositories.QuestionRepository, questionIDs []string) ([]*entities.Question, map[string]*entities.Question, error) {
+	questions, err := questionRepo.FindByIDs(ctx, questionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]*entities.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	ordered := make([]*entities.Question, 0, len(questions))
+	for _, id := range questionIDs {
+		if question, ok := byID[id]; ok {
+			ordered = append(ordered, question)
+		}
+	}
+	return ordered, byID, nil
+}
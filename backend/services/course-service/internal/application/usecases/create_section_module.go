@@ -2,7 +2,12 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
@@ -12,11 +17,17 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	ErrInvalidContentType   = errors.New("invalid content type")
+	ErrInvalidModuleContent = errors.New("invalid module content")
+)
+
 type CreateSectionModuleUseCase struct {
-	moduleRepo  repositories.SectionModuleRepository
-	sectionRepo repositories.CourseSectionRepository
-	publisher   messaging.Publisher
-	logger      *logger.Logger
+	moduleRepo    repositories.SectionModuleRepository
+	sectionRepo   repositories.CourseSectionRepository
+	publisher     messaging.Publisher
+	logger        *logger.Logger
+	apiGatewayURL string
 }
 
 func NewCreateSectionModuleUseCase(
@@ -24,12 +35,14 @@ func NewCreateSectionModuleUseCase(
 	sectionRepo repositories.CourseSectionRepository,
 	publisher messaging.Publisher,
 	logger *logger.Logger,
+	apiGatewayURL string,
 ) *CreateSectionModuleUseCase {
 	return &CreateSectionModuleUseCase{
-		moduleRepo:  moduleRepo,
-		sectionRepo: sectionRepo,
-		publisher:   publisher,
-		logger:      logger,
+		moduleRepo:    moduleRepo,
+		sectionRepo:   sectionRepo,
+		publisher:     publisher,
+		logger:        logger,
+		apiGatewayURL: apiGatewayURL,
 	}
 }
 
@@ -43,7 +56,25 @@ func (uc *CreateSectionModuleUseCase) Execute(ctx context.Context, sectionID str
 	}
 
 	contentType := entities.ContentType(input.ContentType)
+	if !contentType.IsValid() {
+		return nil, ErrInvalidContentType
+	}
+
 	module := entities.NewSectionModule(sectionID, input.Name, input.Description, contentType, input.Order)
+	switch contentType {
+	case entities.ContentTypeZoom:
+		if input.ContentID != nil || input.ContentBody != nil || input.ContentURL != nil {
+			return nil, fmt.Errorf("%w: zoom modules get their meeting from zoom-service", ErrInvalidModuleContent)
+		}
+	case entities.ContentTypeQuiz, entities.ContentTypeAssignment:
+		if input.ContentID != nil || input.ContentBody != nil || input.ContentURL != nil {
+			return nil, fmt.Errorf("%w: %s modules get their content when the %s is created", ErrInvalidModuleContent, contentType, contentType)
+		}
+	default:
+		if err := setModuleContent(module, input.ContentID, input.ContentBody, input.ContentURL); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := uc.moduleRepo.Create(ctx, module); err != nil {
 		return nil, err
@@ -69,7 +100,52 @@ func (uc *CreateSectionModuleUseCase) Execute(ctx context.Context, sectionID str
 	}
 
 	var dto dtos.SectionModuleDTO
-	dto.FromEntity(module)
+	dto.FromEntity(module, uc.apiGatewayURL)
 	return &dto, nil
 }
 
+// setModuleContent validates the content given for the module's type and
+// stores it. Videos and documents link to a file-service file, text modules
+// carry markdown, and link and embed modules carry an absolute URL; embeds
+// must use https so they can be framed by the web app.
+func setModuleContent(module *entities.SectionModule, contentID, body, rawURL *string) error {
+	switch module.ContentType {
+	case entities.ContentTypeVideo, entities.ContentTypeDocument:
+		if body != nil || rawURL != nil {
+			return fmt.Errorf("%w: %s modules only take a content_id", ErrInvalidModuleContent, module.ContentType)
+		}
+		if contentID == nil {
+			return fmt.Errorf("%w: %s modules require the content_id of an uploaded file", ErrInvalidModuleContent, module.ContentType)
+		}
+		if _, err := uuid.Parse(*contentID); err != nil {
+			return fmt.Errorf("%w: content_id must be a file id", ErrInvalidModuleContent)
+		}
+		module.UpdateContent(contentID, entities.ContentStatusCreated)
+	case entities.ContentTypeText:
+		if contentID != nil || rawURL != nil {
+			return fmt.Errorf("%w: text modules only take a content_body", ErrInvalidModuleContent)
+		}
+		if body == nil || strings.TrimSpace(*body) == "" {
+			return fmt.Errorf("%w: text modules require a content_body", ErrInvalidModuleContent)
+		}
+		module.UpdateInlineContent(body, nil)
+	case entities.ContentTypeLink, entities.ContentTypeEmbed:
+		if contentID != nil || body != nil {
+			return fmt.Errorf("%w: %s modules only take a content_url", ErrInvalidModuleContent, module.ContentType)
+		}
+		if rawURL == nil {
+			return fmt.Errorf("%w: %s modules require a content_url", ErrInvalidModuleContent, module.ContentType)
+		}
+		parsed, err := url.Parse(*rawURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return fmt.Errorf("%w: content_url must be an absolute http or https url", ErrInvalidModuleContent)
+		}
+		if module.ContentType == entities.ContentTypeEmbed && parsed.Scheme != "https" {
+			return fmt.Errorf("%w: embedded content must be served over https", ErrInvalidModuleContent)
+		}
+		module.UpdateInlineContent(nil, rawURL)
+	default:
+		return fmt.Errorf("%w: %s modules have no editable content", ErrInvalidModuleContent, module.ContentType)
+	}
+	return nil
+}
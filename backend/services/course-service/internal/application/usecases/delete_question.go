@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type DeleteQuestionUseCase struct {
+	questionRepo repositories.QuestionRepository
+	quizRepo     repositories.QuizRepository
+	logger       *logger.Logger
+}
+
+func NewDeleteQuestionUseCase(
+	questionRepo repositories.QuestionRepository,
+	quizRepo repositories.QuizRepository,
+	logger *logger.Logger,
+) *DeleteQuestionUseCase {
+	return &DeleteQuestionUseCase{
+		questionRepo: questionRepo,
+		quizRepo:     quizRepo,
+		logger:       logger,
+	}
+}
+
+// Execute deletes a question of courseID's bank. Questions still used by a
+// quiz must be removed from it first.
+func (uc *DeleteQuestionUseCase) Execute(ctx context.Context, courseID, questionID string) error {
+	question, err := findCourseQuestion(ctx, uc.questionRepo, courseID, questionID)
+	if err != nil {
+		return err
+	}
+
+	quizzes, err := uc.quizRepo.CountByQuestionID(ctx, question.ID)
+	if err != nil {
+		return err
+	}
+	if quizzes > 0 {
+		return ErrQuestionInUse
+	}
+
+	return uc.questionRepo.Delete(ctx, question.ID)
+}
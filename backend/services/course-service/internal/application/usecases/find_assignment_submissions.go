@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type FindAssignmentSubmissionsUseCase struct {
+	assignmentRepo repositories.AssignmentRepository
+	submissionRepo repositories.AssignmentSubmissionRepository
+	logger         *logger.Logger
+	apiGatewayURL  string
+}
+
+func NewFindAssignmentSubmissionsUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	logger *logger.Logger,
+	apiGatewayURL string,
+) *FindAssignmentSubmissionsUseCase {
+	return &FindAssignmentSubmissionsUseCase{
+		assignmentRepo: assignmentRepo,
+		submissionRepo: submissionRepo,
+		logger:         logger,
+		apiGatewayURL:  apiGatewayURL,
+	}
+}
+
+// Execute lists the submissions for an assignment. With a studentID only
+// that student's submissions are returned; without one, every student's are.
+func (uc *FindAssignmentSubmissionsUseCase) Execute(ctx context.Context, assignmentID, studentID string) ([]dtos.AssignmentSubmissionDTO, error) {
+	assignment, err := uc.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	var submissions []*entities.AssignmentSubmission
+	if studentID != "" {
+		submissions, err = uc.submissionRepo.FindByAssignmentAndStudent(ctx, assignment.ID, studentID)
+	} else {
+		submissions, err = uc.submissionRepo.FindByAssignmentID(ctx, assignment.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.AssignmentSubmissionDTO, len(submissions))
+	for i, submission := range submissions {
+		result[i].FromEntity(submission, uc.apiGatewayURL)
+	}
+	return result, nil
+}
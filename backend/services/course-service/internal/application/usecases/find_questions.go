@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type FindQuestionsUseCase struct {
+	questionRepo repositories.QuestionRepository
+	logger       *logger.Logger
+}
+
+func NewFindQuestionsUseCase(
+	questionRepo repositories.QuestionRepository,
+	logger *logger.Logger,
+) *FindQuestionsUseCase {
+	return &FindQuestionsUseCase{
+		questionRepo: questionRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *FindQuestionsUseCase) Execute(ctx context.Context, courseID string) ([]dtos.QuestionDTO, error) {
+	questions, err := uc.questionRepo.FindByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.QuestionDTO, len(questions))
+	for i, question := range questions {
+		result[i].FromEntity(question)
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type FindQuizAttemptsUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	attemptRepo  repositories.QuizAttemptRepository
+	logger       *logger.Logger
+}
+
+func NewFindQuizAttemptsUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	logger *logger.Logger,
+) *FindQuizAttemptsUseCase {
+	return &FindQuizAttemptsUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		attemptRepo:  attemptRepo,
+		logger:       logger,
+	}
+}
+
+// Execute lists every attempt at a quiz with full results, for staff.
+func (uc *FindQuizAttemptsUseCase) Execute(ctx context.Context, quizID string) ([]dtos.QuizAttemptDTO, error) {
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	attempts, err := uc.attemptRepo.FindByQuizID(ctx, quiz.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	questionIDs := append([]string(nil), quiz.QuestionIDs...)
+	seen := make(map[string]bool, len(questionIDs))
+	for _, id := range questionIDs {
+		seen[id] = true
+	}
+	for _, attempt := range attempts {
+		for _, id := range attempt.QuestionIDs {
+			if !seen[id] {
+				seen[id] = true
+				questionIDs = append(questionIDs, id)
+			}
+		}
+	}
+	_, questions, err := loadQuizQuestions(ctx, uc.questionRepo, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dtos.QuizAttemptDTO, len(attempts))
+	for i, attempt := range attempts {
+		result[i].FromEntity(attempt, questions, true, true)
+	}
+	return result, nil
+}
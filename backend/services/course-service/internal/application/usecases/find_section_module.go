@@ -14,21 +14,24 @@ type FindSectionModuleInput struct {
 
 type FindSectionModuleOutput struct {
 	Modules []dtos.SectionModuleDTO `json:"modules"`
-	Total   int                      `json:"total"`
+	Total   int                     `json:"total"`
 }
 
 type FindSectionModuleUseCase struct {
-	moduleRepo repositories.SectionModuleRepository
-	logger     *logger.Logger
+	moduleRepo    repositories.SectionModuleRepository
+	logger        *logger.Logger
+	apiGatewayURL string
 }
 
 func NewFindSectionModuleUseCase(
 	moduleRepo repositories.SectionModuleRepository,
 	logger *logger.Logger,
+	apiGatewayURL string,
 ) *FindSectionModuleUseCase {
 	return &FindSectionModuleUseCase{
-		moduleRepo: moduleRepo,
-		logger:     logger,
+		moduleRepo:    moduleRepo,
+		logger:        logger,
+		apiGatewayURL: apiGatewayURL,
 	}
 }
 
@@ -40,7 +43,7 @@ func (uc *FindSectionModuleUseCase) Execute(ctx context.Context, input FindSecti
 
 	moduleDTOs := make([]dtos.SectionModuleDTO, len(modules))
 	for i, module := range modules {
-		moduleDTOs[i].FromEntity(module)
+		moduleDTOs[i].FromEntity(module, uc.apiGatewayURL)
 	}
 
 	return &FindSectionModuleOutput{
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type GetAssignmentUseCase struct {
+	assignmentRepo repositories.AssignmentRepository
+	logger         *logger.Logger
+}
+
+func NewGetAssignmentUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	logger *logger.Logger,
+) *GetAssignmentUseCase {
+	return &GetAssignmentUseCase{
+		assignmentRepo: assignmentRepo,
+		logger:         logger,
+	}
+}
+
+func (uc *GetAssignmentUseCase) Execute(ctx context.Context, assignmentID string) (*dtos.AssignmentDTO, error) {
+	assignment, err := uc.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	var dto dtos.AssignmentDTO
+	dto.FromEntity(assignment)
+	return &dto, nil
+}
@@ -13,6 +13,7 @@ type GetCourseOfferingUseCase struct {
 	instructorRepo repositories.CourseOfferingInstructorRepository
 	sectionRepo    repositories.CourseSectionRepository
 	moduleRepo     repositories.SectionModuleRepository
+	apiGatewayURL  string
 }
 
 func NewGetCourseOfferingUseCase(
@@ -21,6 +22,7 @@ func NewGetCourseOfferingUseCase(
 	instructorRepo repositories.CourseOfferingInstructorRepository,
 	sectionRepo repositories.CourseSectionRepository,
 	moduleRepo repositories.SectionModuleRepository,
+	apiGatewayURL string,
 ) *GetCourseOfferingUseCase {
 	return &GetCourseOfferingUseCase{
 		offeringRepo:   offeringRepo,
@@ -28,6 +30,7 @@ func NewGetCourseOfferingUseCase(
 		instructorRepo: instructorRepo,
 		sectionRepo:    sectionRepo,
 		moduleRepo:     moduleRepo,
+		apiGatewayURL:  apiGatewayURL,
 	}
 }
 
@@ -42,7 +45,7 @@ func (uc *GetCourseOfferingUseCase) Execute(ctx context.Context, offeringID stri
 
 	var offeringDTO dtos.CourseOfferingDTO
 	offeringDTO.FromEntity(offering)
-	
+
 	course, err := uc.courseRepo.FindByID(ctx, offering.CourseID)
 	if err == nil && course != nil {
 		courseName := course.Name
@@ -79,7 +82,7 @@ func (uc *GetCourseOfferingUseCase) Execute(ctx context.Context, offeringID stri
 		moduleDTOs := []dtos.SectionModuleDTO{}
 		for _, module := range modules {
 			var moduleDTO dtos.SectionModuleDTO
-			moduleDTO.FromEntity(module)
+			moduleDTO.FromEntity(module, uc.apiGatewayURL)
 			moduleDTOs = append(moduleDTOs, moduleDTO)
 		}
 
@@ -86,7 +86,7 @@ func (uc *GetCourseWithDetailsUseCase) Execute(ctx context.Context, courseID str
 			moduleDTOs := []dtos.SectionModuleDTO{}
 			for _, module := range modules {
 				var moduleDTO dtos.SectionModuleDTO
-				moduleDTO.FromEntity(module)
+				moduleDTO.FromEntity(module, uc.apiGatewayURL)
 				moduleDTOs = append(moduleDTOs, moduleDTO)
 			}
 
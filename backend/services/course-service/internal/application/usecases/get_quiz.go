@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type GetQuizUseCase struct {
+	quizRepo repositories.QuizRepository
+	logger   *logger.Logger
+}
+
+func NewGetQuizUseCase(
+	quizRepo repositories.QuizRepository,
+	logger *logger.Logger,
+) *GetQuizUseCase {
+	return &GetQuizUseCase{
+		quizRepo: quizRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *GetQuizUseCase) Execute(ctx context.Context, quizID string) (*dtos.QuizDTO, error) {
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	var dto dtos.QuizDTO
+	dto.FromEntity(quiz)
+	return &dto, nil
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+)
+
+type GetQuizAttemptUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	attemptRepo  repositories.QuizAttemptRepository
+	publisher    messaging.Publisher
+	logger       *logger.Logger
+}
+
+func NewGetQuizAttemptUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *GetQuizAttemptUseCase {
+	return &GetQuizAttemptUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		attemptRepo:  attemptRepo,
+		publisher:    publisher,
+		logger:       logger,
+	}
+}
+
+// Execute returns one of the student's own attempts, showing its results as
+// the quiz's results visibility allows. An attempt that ran out of time is
+// closed as expired first.
+func (uc *GetQuizAttemptUseCase) Execute(ctx context.Context, attemptID, studentID string) (*dtos.QuizAttemptDTO, error) {
+	attempt, err := uc.attemptRepo.FindByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt == nil || attempt.StudentID != studentID {
+		return nil, ErrQuizAttemptNotFound
+	}
+
+	quiz, err := uc.quizRepo.FindByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	_, questions, err := loadQuizQuestions(ctx, uc.questionRepo, attempt.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if attempt.IsExpired(now) {
+		if err := expireQuizAttempt(ctx, uc.attemptRepo, uc.publisher, uc.logger, quiz, attempt, questions, now); err != nil {
+			return nil, err
+		}
+	}
+
+	var dto dtos.QuizAttemptDTO
+	dto.FromEntity(attempt, questions, quiz.ShowsScore(now), quiz.ShowsCorrectAnswers(now))
+	return &dto, nil
+}
@@ -13,17 +13,20 @@ type GetSectionModuleInput struct {
 }
 
 type GetSectionModuleUseCase struct {
-	moduleRepo repositories.SectionModuleRepository
-	logger     *logger.Logger
+	moduleRepo    repositories.SectionModuleRepository
+	logger        *logger.Logger
+	apiGatewayURL string
 }
 
 func NewGetSectionModuleUseCase(
 	moduleRepo repositories.SectionModuleRepository,
 	logger *logger.Logger,
+	apiGatewayURL string,
 ) *GetSectionModuleUseCase {
 	return &GetSectionModuleUseCase{
-		moduleRepo: moduleRepo,
-		logger:     logger,
+		moduleRepo:    moduleRepo,
+		logger:        logger,
+		apiGatewayURL: apiGatewayURL,
 	}
 }
 
@@ -37,7 +40,7 @@ func (uc *GetSectionModuleUseCase) Execute(ctx context.Context, input GetSection
 	}
 
 	var dto dtos.SectionModuleDTO
-	dto.FromEntity(module)
+	dto.FromEntity(module, uc.apiGatewayURL)
 	return &dto, nil
 }
 
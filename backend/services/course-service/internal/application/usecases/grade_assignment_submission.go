@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type GradeAssignmentSubmissionUseCase struct {
+	assignmentRepo repositories.AssignmentRepository
+	submissionRepo repositories.AssignmentSubmissionRepository
+	moduleRepo     repositories.SectionModuleRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+	apiGatewayURL  string
+}
+
+func NewGradeAssignmentSubmissionUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	moduleRepo repositories.SectionModuleRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	apiGatewayURL string,
+) *GradeAssignmentSubmissionUseCase {
+	return &GradeAssignmentSubmissionUseCase{
+		assignmentRepo: assignmentRepo,
+		submissionRepo: submissionRepo,
+		moduleRepo:     moduleRepo,
+		publisher:      publisher,
+		logger:         logger,
+		apiGatewayURL:  apiGatewayURL,
+	}
+}
+
+// Execute records graderID's score and feedback for a submission, replacing
+// any earlier grade.
+func (uc *GradeAssignmentSubmissionUseCase) Execute(ctx context.Context, submissionID, graderID string, input dtos.GradeAssignmentSubmissionInput) (*dtos.AssignmentSubmissionDTO, error) {
+	submission, err := uc.submissionRepo.FindByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, ErrAssignmentSubmissionNotFound
+	}
+	assignment, err := uc.assignmentRepo.FindByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	if err := submission.Grade(assignment, *input.Score, input.Feedback, graderID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.submissionRepo.Update(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	if uc.publisher != nil {
+		event := events.AssignmentGradedEvent{
+			ID:               submission.ID,
+			AssignmentID:     assignment.ID,
+			SectionModuleID:  assignment.SectionModuleID,
+			CourseOfferingID: assignment.CourseOfferingID,
+			AssignmentName:   assignmentName(ctx, uc.moduleRepo, uc.logger, assignment),
+			StudentID:        submission.StudentID,
+			StudentEmail:     submission.StudentEmail,
+			SubmissionNumber: submission.SubmissionNumber,
+			Late:             submission.Late,
+			Score:            *submission.Score,
+			PenaltyPercent:   submission.PenaltyPercent,
+			FinalScore:       *submission.FinalScore,
+			MaxScore:         assignment.MaxScore,
+			Feedback:         submission.Feedback,
+			GradedBy:         graderID,
+			GradedAt:         *submission.GradedAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeAssignmentGraded, event); err != nil {
+			uc.logger.Error("Failed to publish assignment graded event", zap.Error(err))
+		}
+	}
+
+	var dto dtos.AssignmentSubmissionDTO
+	dto.FromEntity(submission, uc.apiGatewayURL)
+	return &dto, nil
+}
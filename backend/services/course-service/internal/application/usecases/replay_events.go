@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// ReplayEventsUseCase publishes the course data other services keep copies
+// of, so that copies started after the data was created are filled in.
+// Consumers store replayed events idempotently, so replaying twice is safe.
+type ReplayEventsUseCase struct {
+	instructorRepo repositories.CourseOfferingInstructorRepository
+	assignmentRepo repositories.AssignmentRepository
+	submissionRepo repositories.AssignmentSubmissionRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+}
+
+func NewReplayEventsUseCase(
+	instructorRepo repositories.CourseOfferingInstructorRepository,
+	assignmentRepo repositories.AssignmentRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *ReplayEventsUseCase {
+	return &ReplayEventsUseCase{
+		instructorRepo: instructorRepo,
+		assignmentRepo: assignmentRepo,
+		submissionRepo: submissionRepo,
+		publisher:      publisher,
+		logger:         logger,
+	}
+}
+
+func (uc *ReplayEventsUseCase) Execute(ctx context.Context) error {
+	if uc.publisher == nil {
+		return nil
+	}
+
+	instructors, err := uc.instructorRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list instructor assignments: %w", err)
+	}
+	for _, instructor := range instructors {
+		event := events.InstructorAssignedToOfferingEvent{
+			ID:                 instructor.ID,
+			CourseOfferingID:   instructor.CourseOfferingID,
+			InstructorID:       instructor.InstructorID,
+			InstructorUsername: instructor.InstructorUsername,
+			CreatedAt:          instructor.CreatedAt,
+			UpdatedAt:          instructor.UpdatedAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeInstructorAssignmentReplayed, event); err != nil {
+			return fmt.Errorf("failed to replay instructor assignment: %w", err)
+		}
+	}
+
+	submissions, err := uc.submissionRepo.FindWithFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list submissions with files: %w", err)
+	}
+	assignments := map[string]*entities.Assignment{}
+	for _, submission := range submissions {
+		assignment, ok := assignments[submission.AssignmentID]
+		if !ok {
+			if assignment, err = uc.assignmentRepo.FindByID(ctx, submission.AssignmentID); err != nil {
+				return fmt.Errorf("failed to load assignment: %w", err)
+			}
+			assignments[submission.AssignmentID] = assignment
+		}
+		if assignment == nil {
+			continue
+		}
+		event := events.AssignmentSubmittedEvent{
+			ID:               submission.ID,
+			AssignmentID:     assignment.ID,
+			SectionModuleID:  assignment.SectionModuleID,
+			CourseOfferingID: assignment.CourseOfferingID,
+			StudentID:        submission.StudentID,
+			SubmissionNumber: submission.SubmissionNumber,
+			FileIDs:          submission.FileIDs,
+			Late:             submission.Late,
+			DueAt:            assignment.DueAt,
+			SubmittedAt:      submission.SubmittedAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeSubmissionReplayed, event); err != nil {
+			return fmt.Errorf("failed to replay submission: %w", err)
+		}
+	}
+
+	uc.logger.Info("replayed events",
+		zap.Int("instructor_assignments", len(instructors)),
+		zap.Int("submissions", len(submissions)),
+	)
+	return nil
+}
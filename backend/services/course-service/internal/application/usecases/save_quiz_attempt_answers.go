@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type SaveQuizAttemptAnswersUseCase struct {
+	questionRepo repositories.QuestionRepository
+	attemptRepo  repositories.QuizAttemptRepository
+	logger       *logger.Logger
+}
+
+func NewSaveQuizAttemptAnswersUseCase(
+	questionRepo repositories.QuestionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	logger *logger.Logger,
+) *SaveQuizAttemptAnswersUseCase {
+	return &SaveQuizAttemptAnswersUseCase{
+		questionRepo: questionRepo,
+		attemptRepo:  attemptRepo,
+		logger:       logger,
+	}
+}
+
+// Execute saves answers on the student's attempt while it is in progress, so
+// they are graded even if time runs out before the attempt is submitted.
+func (uc *SaveQuizAttemptAnswersUseCase) Execute(ctx context.Context, attemptID, studentID string, input dtos.SubmitQuizAttemptInput) (*dtos.QuizAttemptDTO, error) {
+	attempt, err := uc.attemptRepo.FindByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt == nil || attempt.StudentID != studentID {
+		return nil, ErrQuizAttemptNotFound
+	}
+
+	if err := attempt.SaveAnswers(input.Answers, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := uc.attemptRepo.Update(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	_, questions, err := loadQuizQuestions(ctx, uc.questionRepo, attempt.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuizAttemptDTO
+	dto.FromEntity(attempt, questions, false, false)
+	return &dto, nil
+}
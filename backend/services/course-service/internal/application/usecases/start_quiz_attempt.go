@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrQuizClosed              = errors.New("quiz is closed")
+	ErrQuizAttemptLimitReached = errors.New("no quiz attempts left")
+	ErrQuizAttemptNotFound     = errors.New("quiz attempt not found")
+)
+
+type StartQuizAttemptUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	attemptRepo  repositories.QuizAttemptRepository
+	publisher    messaging.Publisher
+	logger       *logger.Logger
+}
+
+func NewStartQuizAttemptUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *StartQuizAttemptUseCase {
+	return &StartQuizAttemptUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		attemptRepo:  attemptRepo,
+		publisher:    publisher,
+		logger:       logger,
+	}
+}
+
+// Execute starts the student's next attempt at a quiz. An attempt still in
+// progress is returned instead of starting another, and attempts that ran
+// out of time are closed as expired first.
+func (uc *StartQuizAttemptUseCase) Execute(ctx context.Context, quizID, studentID string) (*dtos.QuizAttemptDTO, error) {
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	now := time.Now().UTC()
+	attempts, err := uc.attemptRepo.FindByQuizAndStudent(ctx, quiz.ID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attempt := range attempts {
+		if attempt.Status != entities.QuizAttemptStatusInProgress {
+			continue
+		}
+		_, questions, err := loadQuizQuestions(ctx, uc.questionRepo, attempt.QuestionIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !attempt.IsExpired(now) {
+			var dto dtos.QuizAttemptDTO
+			dto.FromEntity(attempt, questions, false, false)
+			return &dto, nil
+		}
+		if err := expireQuizAttempt(ctx, uc.attemptRepo, uc.publisher, uc.logger, quiz, attempt, questions, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if quiz.IsClosed(now) {
+		return nil, ErrQuizClosed
+	}
+	if quiz.MaxAttempts != nil && len(attempts) >= *quiz.MaxAttempts {
+		return nil, ErrQuizAttemptLimitReached
+	}
+
+	ordered, questions, err := loadQuizQuestions(ctx, uc.questionRepo, quiz.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	attempt := entities.NewQuizAttempt(quiz, ordered, studentID, len(attempts)+1)
+	if err := uc.attemptRepo.Create(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuizAttemptDTO
+	dto.FromEntity(attempt, questions, false, false)
+	return &dto, nil
+}
+
+// expireQuizAttempt closes an attempt that ran out of time, grading its saved
+// answers against questions, and reports the score like a submission. If
+// another request closed the attempt first, it is reloaded instead.
+func expireQuizAttempt(
+	ctx context.Context,
+	attemptRepo repositories.QuizAttemptRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	quiz *entities.Quiz,
+	attempt *entities.QuizAttempt,
+	questions map[string]*entities.Question,
+	now time.Time,
+) error {
+	attempt.Expire(questions, now)
+	err := attemptRepo.Update(ctx, attempt)
+	if errors.Is(err, entities.ErrQuizAttemptClosed) {
+		current, err := attemptRepo.FindByID(ctx, attempt.ID)
+		if err != nil {
+			return err
+		}
+		if current != nil {
+			*attempt = *current
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	publishQuizAttemptSubmitted(ctx, publisher, logger, quiz, attempt)
+	return nil
+}
+
+func publishQuizAttemptSubmitted(ctx context.Context, publisher messaging.Publisher, logger *logger.Logger, quiz *entities.Quiz, attempt *entities.QuizAttempt) {
+	if publisher == nil {
+		return
+	}
+	event := events.QuizAttemptSubmittedEvent{
+		ID:              attempt.ID,
+		QuizID:          quiz.ID,
+		SectionModuleID: quiz.SectionModuleID,
+		CourseID:        quiz.CourseID,
+		StudentID:       attempt.StudentID,
+		AttemptNumber:   attempt.AttemptNumber,
+		Status:          string(attempt.Status),
+		Score:           attempt.Score,
+		MaxScore:        attempt.MaxScore,
+		SubmittedAt:     *attempt.SubmittedAt,
+	}
+	if err := publisher.Publish(ctx, events.EventTypeQuizAttemptSubmitted, event); err != nil {
+		logger.Error("Failed to publish quiz attempt submitted event", zap.Error(err))
+	}
+}
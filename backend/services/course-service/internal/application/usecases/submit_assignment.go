@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSubmissionLimitReached       = errors.New("no submissions left for this assignment")
+	ErrAssignmentSubmissionNotFound = errors.New("assignment submission not found")
+)
+
+type SubmitAssignmentUseCase struct {
+	assignmentRepo     repositories.AssignmentRepository
+	submissionRepo     repositories.AssignmentSubmissionRepository
+	submissionFileRepo repositories.SubmissionFileRepository
+	moduleRepo         repositories.SectionModuleRepository
+	publisher          messaging.Publisher
+	logger             *logger.Logger
+	apiGatewayURL      string
+}
+
+func NewSubmitAssignmentUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	submissionRepo repositories.AssignmentSubmissionRepository,
+	submissionFileRepo repositories.SubmissionFileRepository,
+	moduleRepo repositories.SectionModuleRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	apiGatewayURL string,
+) *SubmitAssignmentUseCase {
+	return &SubmitAssignmentUseCase{
+		assignmentRepo:     assignmentRepo,
+		submissionRepo:     submissionRepo,
+		submissionFileRepo: submissionFileRepo,
+		moduleRepo:         moduleRepo,
+		publisher:          publisher,
+		logger:             logger,
+		apiGatewayURL:      apiGatewayURL,
+	}
+}
+
+// Execute hands in the student's next submission for an assignment. Files
+// must have been uploaded by the student to file-service's submissions
+// bucket, which is checked with file-service using the student's
+// authorization. studentEmail is kept with the submission so the student can
+// be notified when it is received and graded.
+func (uc *SubmitAssignmentUseCase) Execute(ctx context.Context, assignmentID, studentID, studentEmail, authorization string, input dtos.SubmitAssignmentInput) (*dtos.AssignmentSubmissionDTO, error) {
+	assignment, err := uc.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	previous, err := uc.submissionRepo.FindByAssignmentAndStudent(ctx, assignment.ID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.MaxSubmissions != nil && len(previous) >= *assignment.MaxSubmissions {
+		return nil, ErrSubmissionLimitReached
+	}
+
+	submissionNumber := len(previous) + 1
+	submission, err := entities.NewAssignmentSubmission(assignment, studentID, studentEmail, submissionNumber, input.Text, input.FileIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(submission.FileIDs) > 0 {
+		owned, err := uc.submissionFileRepo.CountUploadedBy(ctx, submission.FileIDs, studentID, authorization)
+		if err != nil {
+			return nil, err
+		}
+		if owned != len(submission.FileIDs) {
+			return nil, fmt.Errorf("%w: file_ids must be files you uploaded for submissions", entities.ErrInvalidSubmission)
+		}
+	}
+
+	if err := uc.submissionRepo.Create(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	if uc.publisher != nil {
+		event := events.AssignmentSubmittedEvent{
+			ID:               submission.ID,
+			AssignmentID:     assignment.ID,
+			SectionModuleID:  assignment.SectionModuleID,
+			CourseOfferingID: assignment.CourseOfferingID,
+			AssignmentName:   assignmentName(ctx, uc.moduleRepo, uc.logger, assignment),
+			StudentID:        submission.StudentID,
+			StudentEmail:     submission.StudentEmail,
+			SubmissionNumber: submission.SubmissionNumber,
+			FileIDs:          submission.FileIDs,
+			Late:             submission.Late,
+			DueAt:            assignment.DueAt,
+			SubmittedAt:      submission.SubmittedAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeAssignmentSubmitted, event); err != nil {
+			uc.logger.Error("Failed to publish assignment submitted event", zap.Error(err))
+		}
+	}
+
+	var dto dtos.AssignmentSubmissionDTO
+	dto.FromEntity(submission, uc.apiGatewayURL)
+	return &dto, nil
+}
+
+// assignmentName returns the name of the assignment's section module for
+// notifications, or an empty string when it cannot be loaded.
+func assignmentName(ctx context.Context, moduleRepo repositories.SectionModuleRepository, logger *logger.Logger, assignment *entities.Assignment) string {
+	module, err := moduleRepo.FindByID(ctx, assignment.SectionModuleID)
+	if err != nil {
+		logger.Warn("Failed to load assignment module", zap.String("assignment_id", assignment.ID), zap.Error(err))
+		return ""
+	}
+	if module == nil {
+		return ""
+	}
+	return module.Name
+}
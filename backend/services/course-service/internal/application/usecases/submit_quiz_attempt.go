@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+)
+
+type SubmitQuizAttemptUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	attemptRepo  repositories.QuizAttemptRepository
+	publisher    messaging.Publisher
+	logger       *logger.Logger
+}
+
+func NewSubmitQuizAttemptUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	attemptRepo repositories.QuizAttemptRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *SubmitQuizAttemptUseCase {
+	return &SubmitQuizAttemptUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		attemptRepo:  attemptRepo,
+		publisher:    publisher,
+		logger:       logger,
+	}
+}
+
+// Execute grades and closes the student's attempt, returning it with as much
+// of the result as the quiz's results visibility allows.
+func (uc *SubmitQuizAttemptUseCase) Execute(ctx context.Context, attemptID, studentID string, input dtos.SubmitQuizAttemptInput) (*dtos.QuizAttemptDTO, error) {
+	attempt, err := uc.attemptRepo.FindByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt == nil || attempt.StudentID != studentID {
+		return nil, ErrQuizAttemptNotFound
+	}
+
+	quiz, err := uc.quizRepo.FindByID(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	_, questions, err := loadQuizQuestions(ctx, uc.questionRepo, attempt.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := attempt.Submit(input.Answers, questions, now); err != nil {
+		return nil, err
+	}
+	if err := uc.attemptRepo.Update(ctx, attempt); err != nil {
+		return nil, err
+	}
+	publishQuizAttemptSubmitted(ctx, uc.publisher, uc.logger, quiz, attempt)
+
+	var dto dtos.QuizAttemptDTO
+	dto.FromEntity(attempt, questions, quiz.ShowsScore(now), quiz.ShowsCorrectAnswers(now))
+	return &dto, nil
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type UpdateAssignmentUseCase struct {
+	assignmentRepo repositories.AssignmentRepository
+	logger         *logger.Logger
+}
+
+func NewUpdateAssignmentUseCase(
+	assignmentRepo repositories.AssignmentRepository,
+	logger *logger.Logger,
+) *UpdateAssignmentUseCase {
+	return &UpdateAssignmentUseCase{
+		assignmentRepo: assignmentRepo,
+		logger:         logger,
+	}
+}
+
+func (uc *UpdateAssignmentUseCase) Execute(ctx context.Context, assignmentID string, input dtos.UpdateAssignmentInput) (*dtos.AssignmentDTO, error) {
+	assignment, err := uc.assignmentRepo.FindByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	err = assignment.Configure(entities.AssignmentSettings{
+		Instructions:       input.Instructions,
+		AllowFiles:         input.AllowFiles,
+		AllowText:          input.AllowText,
+		DueAt:              input.DueAt,
+		LatePolicy:         entities.LatePolicy(input.LatePolicy),
+		LatePenaltyPercent: input.LatePenaltyPercent,
+		MaxScore:           input.MaxScore,
+		MaxSubmissions:     input.MaxSubmissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignmentRepo.Update(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.AssignmentDTO
+	dto.FromEntity(assignment)
+	return &dto, nil
+}
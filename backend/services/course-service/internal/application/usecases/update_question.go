@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type UpdateQuestionUseCase struct {
+	questionRepo repositories.QuestionRepository
+	logger       *logger.Logger
+}
+
+func NewUpdateQuestionUseCase(
+	questionRepo repositories.QuestionRepository,
+	logger *logger.Logger,
+) *UpdateQuestionUseCase {
+	return &UpdateQuestionUseCase{
+		questionRepo: questionRepo,
+		logger:       logger,
+	}
+}
+
+// Execute revises a question of courseID's bank. Attempts already submitted
+// keep their grades; attempts in progress are graded against the revision,
+// where options that were not reworded keep the IDs answers refer to.
+func (uc *UpdateQuestionUseCase) Execute(ctx context.Context, courseID, questionID string, input dtos.UpdateQuestionInput) (*dtos.QuestionDTO, error) {
+	question, err := findCourseQuestion(ctx, uc.questionRepo, courseID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = question.Revise(input.Prompt, input.Points, entities.AnswerKey{
+		Options:          input.Options,
+		CorrectOptions:   input.CorrectOptions,
+		AcceptedAnswers:  input.AcceptedAnswers,
+		NumericAnswer:    input.NumericAnswer,
+		NumericTolerance: input.NumericTolerance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.questionRepo.Update(ctx, question); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuestionDTO
+	dto.FromEntity(question)
+	return &dto, nil
+}
+
+// findCourseQuestion loads a question, treating questions of other courses'
+// banks as missing.
+func findCourseQuestion(ctx context.Context, questionRepo repositories.QuestionRepository, courseID, questionID string) (*entities.Question, error) {
+	question, err := questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil || question.CourseID != courseID {
+		return nil, ErrQuestionNotFound
+	}
+	return question, nil
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type UpdateQuizUseCase struct {
+	quizRepo     repositories.QuizRepository
+	questionRepo repositories.QuestionRepository
+	logger       *logger.Logger
+}
+
+func NewUpdateQuizUseCase(
+	quizRepo repositories.QuizRepository,
+	questionRepo repositories.QuestionRepository,
+	logger *logger.Logger,
+) *UpdateQuizUseCase {
+	return &UpdateQuizUseCase{
+		quizRepo:     quizRepo,
+		questionRepo: questionRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *UpdateQuizUseCase) Execute(ctx context.Context, quizID string, input dtos.UpdateQuizInput) (*dtos.QuizDTO, error) {
+	quiz, err := uc.quizRepo.FindByID(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, ErrQuizNotFound
+	}
+
+	if err := checkQuestionsInBank(ctx, uc.questionRepo, quiz.CourseID, input.QuestionIDs); err != nil {
+		return nil, err
+	}
+
+	err = quiz.Configure(entities.QuizSettings{
+		QuestionIDs:       input.QuestionIDs,
+		TimeLimitMinutes:  input.TimeLimitMinutes,
+		MaxAttempts:       input.MaxAttempts,
+		ShuffleQuestions:  input.ShuffleQuestions,
+		ShuffleOptions:    input.ShuffleOptions,
+		ResultsVisibility: entities.ResultsVisibility(input.ResultsVisibility),
+		ClosesAt:          input.ClosesAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.quizRepo.Update(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	var dto dtos.QuizDTO
+	dto.FromEntity(quiz)
+	return &dto, nil
+}
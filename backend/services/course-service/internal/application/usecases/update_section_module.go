@@ -17,20 +17,23 @@ var (
 )
 
 type UpdateSectionModuleUseCase struct {
-	moduleRepo repositories.SectionModuleRepository
-	publisher  messaging.Publisher
-	logger     *logger.Logger
+	moduleRepo    repositories.SectionModuleRepository
+	publisher     messaging.Publisher
+	logger        *logger.Logger
+	apiGatewayURL string
 }
 
 func NewUpdateSectionModuleUseCase(
 	moduleRepo repositories.SectionModuleRepository,
 	publisher messaging.Publisher,
 	logger *logger.Logger,
+	apiGatewayURL string,
 ) *UpdateSectionModuleUseCase {
 	return &UpdateSectionModuleUseCase{
-		moduleRepo: moduleRepo,
-		publisher:  publisher,
-		logger:     logger,
+		moduleRepo:    moduleRepo,
+		publisher:     publisher,
+		logger:        logger,
+		apiGatewayURL: apiGatewayURL,
 	}
 }
 
@@ -43,6 +46,11 @@ func (uc *UpdateSectionModuleUseCase) Execute(ctx context.Context, moduleID stri
 		return nil, ErrSectionModuleNotFound
 	}
 
+	if input.ContentID != nil || input.ContentBody != nil || input.ContentURL != nil {
+		if err := setModuleContent(module, input.ContentID, input.ContentBody, input.ContentURL); err != nil {
+			return nil, err
+		}
+	}
 	module.Update(input.Name, input.Description, input.Order)
 
 	if err := uc.moduleRepo.Update(ctx, module); err != nil {
@@ -69,7 +77,7 @@ func (uc *UpdateSectionModuleUseCase) Execute(ctx context.Context, moduleID stri
 	}
 
 	var dto dtos.SectionModuleDTO
-	dto.FromEntity(module)
+	dto.FromEntity(module, uc.apiGatewayURL)
 	return &dto, nil
 }
 
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LatePolicy decides what happens to submissions made after the due date.
+type LatePolicy string
+
+const (
+	// LatePolicyAccept takes late submissions as if they were on time, only
+	// marking them late.
+	LatePolicyAccept LatePolicy = "accept"
+	// LatePolicyPenalize takes late submissions but deducts
+	// LatePenaltyPercent from their score.
+	LatePolicyPenalize LatePolicy = "penalize"
+	// LatePolicyBlock refuses submissions after the due date.
+	LatePolicyBlock LatePolicy = "block"
+)
+
+func (p LatePolicy) IsValid() bool {
+	switch p {
+	case LatePolicyAccept, LatePolicyPenalize, LatePolicyBlock:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrAssignmentSubmissionTypeRequired = errors.New("an assignment must allow file or text submissions")
+	ErrInvalidAssignmentMaxScore        = errors.New("max score must be greater than zero")
+	ErrInvalidMaxSubmissions            = errors.New("max submissions must be at least one")
+	ErrInvalidLatePolicy                = errors.New("invalid late policy")
+	ErrInvalidLatePenalty               = errors.New("late penalty must be between 0 and 100 percent")
+	ErrAssignmentDueRequired            = errors.New("late policy needs a due date")
+)
+
+// AssignmentSettings are the parts of an assignment staff configure. A nil
+// MaxSubmissions means unlimited resubmissions and an empty LatePolicy means
+// LatePolicyAccept.
+type AssignmentSettings struct {
+	Instructions       string
+	AllowFiles         bool
+	AllowText          bool
+	DueAt              *time.Time
+	LatePolicy         LatePolicy
+	LatePenaltyPercent float64
+	MaxScore           float64
+	MaxSubmissions     *int
+}
+
+// Assignment is the content of an assignment section module. Students hand
+// in files uploaded to file-service, text, or both, and instructors of
+// CourseOfferingID grade them.
+type Assignment struct {
+	ID                 string
+	SectionModuleID    string
+	CourseOfferingID   string
+	Instructions       string
+	AllowFiles         bool
+	AllowText          bool
+	DueAt              *time.Time
+	LatePolicy         LatePolicy
+	LatePenaltyPercent float64
+	MaxScore           float64
+	MaxSubmissions     *int
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func NewAssignment(sectionModuleID, courseOfferingID string, settings AssignmentSettings) (*Assignment, error) {
+	now := time.Now().UTC()
+	assignment := &Assignment{
+		ID:               uuid.NewString(),
+		SectionModuleID:  sectionModuleID,
+		CourseOfferingID: courseOfferingID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := assignment.Configure(settings); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// Configure replaces the assignment settings, leaving the assignment
+// unchanged when they are invalid. Submissions already made keep the late
+// flag and penalty they were given.
+func (a *Assignment) Configure(settings AssignmentSettings) error {
+	if !settings.AllowFiles && !settings.AllowText {
+		return ErrAssignmentSubmissionTypeRequired
+	}
+	if settings.MaxScore <= 0 {
+		return ErrInvalidAssignmentMaxScore
+	}
+	if settings.MaxSubmissions != nil && *settings.MaxSubmissions < 1 {
+		return ErrInvalidMaxSubmissions
+	}
+	policy := settings.LatePolicy
+	if policy == "" {
+		policy = LatePolicyAccept
+	}
+	if !policy.IsValid() {
+		return ErrInvalidLatePolicy
+	}
+	if policy != LatePolicyAccept && settings.DueAt == nil {
+		return ErrAssignmentDueRequired
+	}
+	penalty := settings.LatePenaltyPercent
+	if policy != LatePolicyPenalize {
+		penalty = 0
+	}
+	if penalty < 0 || penalty > 100 {
+		return ErrInvalidLatePenalty
+	}
+
+	a.Instructions = settings.Instructions
+	a.AllowFiles = settings.AllowFiles
+	a.AllowText = settings.AllowText
+	a.DueAt = settings.DueAt
+	a.LatePolicy = policy
+	a.LatePenaltyPercent = penalty
+	a.MaxScore = settings.MaxScore
+	a.MaxSubmissions = settings.MaxSubmissions
+	a.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// IsLate reports whether a submission made at now is past the due date.
+func (a *Assignment) IsLate(now time.Time) bool {
+	return a.DueAt != nil && now.After(*a.DueAt)
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssignmentSubmissionStatus string
+
+const (
+	AssignmentSubmissionStatusSubmitted AssignmentSubmissionStatus = "submitted"
+	AssignmentSubmissionStatusGraded    AssignmentSubmissionStatus = "graded"
+)
+
+// MaxSubmissionFiles is how many files a single submission may include.
+const MaxSubmissionFiles = 10
+
+var (
+	ErrEmptySubmission   = errors.New("a submission needs text or at least one file")
+	ErrInvalidSubmission = errors.New("invalid submission")
+	ErrAssignmentPastDue = errors.New("the assignment is past its due date and no longer accepts submissions")
+	ErrInvalidScore      = errors.New("score must be between zero and the assignment's max score")
+)
+
+// AssignmentSubmission is one hand-in of a student for an assignment. A
+// resubmission is a new submission with the next SubmissionNumber, so
+// earlier hand-ins and their grades are kept.
+type AssignmentSubmission struct {
+	ID           string
+	AssignmentID string
+	StudentID    string
+	// StudentEmail is where submission and grading notices are sent.
+	StudentEmail     string
+	SubmissionNumber int
+	Text             *string
+	// FileIDs are file-service file IDs.
+	FileIDs []string
+	Late    bool
+	// PenaltyPercent is the late penalty applied to Score when graded.
+	PenaltyPercent float64
+	Status         AssignmentSubmissionStatus
+	Score          *float64
+	// FinalScore is Score after the late penalty.
+	FinalScore  *float64
+	Feedback    *string
+	GradedBy    *string
+	GradedAt    *time.Time
+	SubmittedAt time.Time
+}
+
+// NewAssignmentSubmission hands in text and fileIDs for assignment at now.
+// Late submissions are refused or penalized according to the assignment's
+// late policy.
+func NewAssignmentSubmission(assignment *Assignment, studentID, studentEmail string, submissionNumber int, text *string, fileIDs []string, now time.Time) (*AssignmentSubmission, error) {
+	if text != nil && strings.TrimSpace(*text) == "" {
+		text = nil
+	}
+	if text == nil && len(fileIDs) == 0 {
+		return nil, ErrEmptySubmission
+	}
+	if text != nil && !assignment.AllowText {
+		return nil, fmt.Errorf("%w: this assignment does not accept text", ErrInvalidSubmission)
+	}
+	if len(fileIDs) > 0 && !assignment.AllowFiles {
+		return nil, fmt.Errorf("%w: this assignment does not accept files", ErrInvalidSubmission)
+	}
+	if len(fileIDs) > MaxSubmissionFiles {
+		return nil, fmt.Errorf("%w: at most %d files can be submitted", ErrInvalidSubmission, MaxSubmissionFiles)
+	}
+	seen := make(map[string]bool, len(fileIDs))
+	for _, id := range fileIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("%w: file_ids must be file ids", ErrInvalidSubmission)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%w: a file can only be submitted once", ErrInvalidSubmission)
+		}
+		seen[id] = true
+	}
+
+	late := assignment.IsLate(now)
+	var penalty float64
+	if late {
+		switch assignment.LatePolicy {
+		case LatePolicyBlock:
+			return nil, ErrAssignmentPastDue
+		case LatePolicyPenalize:
+			penalty = assignment.LatePenaltyPercent
+		}
+	}
+
+	return &AssignmentSubmission{
+		ID:               uuid.NewString(),
+		AssignmentID:     assignment.ID,
+		StudentID:        studentID,
+		StudentEmail:     studentEmail,
+		SubmissionNumber: submissionNumber,
+		Text:             text,
+		FileIDs:          append([]string{}, fileIDs...),
+		Late:             late,
+		PenaltyPercent:   penalty,
+		Status:           AssignmentSubmissionStatusSubmitted,
+		SubmittedAt:      now.UTC(),
+	}, nil
+}
+
+// Grade scores the submission out of the assignment's MaxScore, deducting
+// the late penalty, and records the grader. A graded submission can be
+// graded again, replacing the earlier grade.
+func (s *AssignmentSubmission) Grade(assignment *Assignment, score float64, feedback *string, graderID string, now time.Time) error {
+	if score < 0 || score > assignment.MaxScore {
+		return ErrInvalidScore
+	}
+	finalScore := math.Round(score*(100-s.PenaltyPercent)) / 100
+	gradedAt := now.UTC()
+
+	s.Score = &score
+	s.FinalScore = &finalScore
+	s.Feedback = feedback
+	s.GradedBy = &graderID
+	s.GradedAt = &gradedAt
+	s.Status = AssignmentSubmissionStatusGraded
+	return nil
+}
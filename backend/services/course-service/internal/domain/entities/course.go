@@ -13,6 +13,9 @@ type Course struct {
 	ThumbnailID *string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// OrganizationID is the partner school offering the course, nil for
+	// courses created by super-admins outside any organization.
+	OrganizationID *string
 }
 
 func NewCourse(name, description string, thumbnailID *string) *Course {
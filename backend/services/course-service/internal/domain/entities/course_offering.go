@@ -34,6 +34,8 @@ type CourseOffering struct {
 	EnrollmentCost float64
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	// OrganizationID is always the course's organization.
+	OrganizationID *string
 }
 
 func NewCourseOffering(courseID, name, description string, offeringType OfferingType, duration, classTime *string, enrollmentCost float64) *CourseOffering {
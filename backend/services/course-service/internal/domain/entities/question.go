@@ -0,0 +1,276 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuestionType string
+
+const (
+	QuestionTypeMultipleChoice QuestionType = "multiple_choice"
+	QuestionTypeMultiSelect    QuestionType = "multi_select"
+	QuestionTypeTrueFalse      QuestionType = "true_false"
+	QuestionTypeShortAnswer    QuestionType = "short_answer"
+	QuestionTypeNumeric        QuestionType = "numeric"
+)
+
+func (t QuestionType) IsValid() bool {
+	switch t {
+	case QuestionTypeMultipleChoice, QuestionTypeMultiSelect, QuestionTypeTrueFalse, QuestionTypeShortAnswer, QuestionTypeNumeric:
+		return true
+	}
+	return false
+}
+
+// HasOptions reports whether questions of the type are answered by choosing
+// options rather than typing an answer.
+func (t QuestionType) HasOptions() bool {
+	return t == QuestionTypeMultipleChoice || t == QuestionTypeMultiSelect || t == QuestionTypeTrueFalse
+}
+
+// Option IDs of true/false questions, whose options are always True and
+// False in that order.
+const (
+	TrueOptionID  = "true"
+	FalseOptionID = "false"
+)
+
+var (
+	ErrInvalidQuestionType    = errors.New("invalid question type")
+	ErrQuestionPromptRequired = errors.New("question prompt is required")
+	ErrInvalidQuestionPoints  = errors.New("question points must be greater than zero")
+	ErrInvalidAnswerKey       = errors.New("invalid answer key")
+)
+
+type QuestionOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// AnswerKey describes the options and correct answer of a question. Options
+// and CorrectOptions, indexes into Options, are used by multiple choice and
+// multi-select questions; true/false questions only use CorrectOptions, where
+// 0 is True and 1 is False. AcceptedAnswers is used by short answer questions
+// and NumericAnswer and NumericTolerance by numeric questions.
+type AnswerKey struct {
+	Options          []string
+	CorrectOptions   []int
+	AcceptedAnswers  []string
+	NumericAnswer    *float64
+	NumericTolerance float64
+}
+
+// Question is an item of a course's question bank that quizzes of the course
+// draw from.
+type Question struct {
+	ID               string
+	CourseID         string
+	Type             QuestionType
+	Prompt           string
+	Points           float64
+	Options          []QuestionOption
+	CorrectOptionIDs []string
+	AcceptedAnswers  []string
+	NumericAnswer    *float64
+	NumericTolerance float64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewQuestion(courseID string, questionType QuestionType, prompt string, points float64, key AnswerKey) (*Question, error) {
+	if !questionType.IsValid() {
+		return nil, ErrInvalidQuestionType
+	}
+	now := time.Now().UTC()
+	question := &Question{
+		ID:        uuid.NewString(),
+		CourseID:  courseID,
+		Type:      questionType,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := question.Revise(prompt, points, key); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// Revise replaces the prompt, points and answer key, leaving the question
+// unchanged when any of them is invalid. Options whose text is unchanged keep
+// their IDs, so answers already saved in attempts still choose them; new or
+// reworded options get new IDs.
+func (q *Question) Revise(prompt string, points float64, key AnswerKey) error {
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return ErrQuestionPromptRequired
+	}
+	if points <= 0 || math.IsInf(points, 0) || math.IsNaN(points) {
+		return ErrInvalidQuestionPoints
+	}
+
+	var (
+		options          []QuestionOption
+		correctOptionIDs []string
+		acceptedAnswers  []string
+		numericAnswer    *float64
+		numericTolerance float64
+	)
+	switch q.Type {
+	case QuestionTypeMultipleChoice, QuestionTypeMultiSelect:
+		if len(key.Options) < 2 {
+			return fmt.Errorf("%w: at least two options are required", ErrInvalidAnswerKey)
+		}
+		previousIDs := make(map[string][]string, len(q.Options))
+		for _, option := range q.Options {
+			previousIDs[option.Text] = append(previousIDs[option.Text], option.ID)
+		}
+		for _, text := range key.Options {
+			text = strings.TrimSpace(text)
+			if text == "" {
+				return fmt.Errorf("%w: options cannot be blank", ErrInvalidAnswerKey)
+			}
+			id := uuid.NewString()
+			if ids := previousIDs[text]; len(ids) > 0 {
+				id, previousIDs[text] = ids[0], ids[1:]
+			}
+			options = append(options, QuestionOption{ID: id, Text: text})
+		}
+		ids, err := correctOptions(options, key.CorrectOptions)
+		if err != nil {
+			return err
+		}
+		if q.Type == QuestionTypeMultipleChoice && len(ids) != 1 {
+			return fmt.Errorf("%w: multiple choice questions have exactly one correct option", ErrInvalidAnswerKey)
+		}
+		correctOptionIDs = ids
+	case QuestionTypeTrueFalse:
+		options = []QuestionOption{{ID: TrueOptionID, Text: "True"}, {ID: FalseOptionID, Text: "False"}}
+		ids, err := correctOptions(options, key.CorrectOptions)
+		if err != nil {
+			return err
+		}
+		if len(ids) != 1 {
+			return fmt.Errorf("%w: true/false questions have exactly one correct option", ErrInvalidAnswerKey)
+		}
+		correctOptionIDs = ids
+	case QuestionTypeShortAnswer:
+		for _, answer := range key.AcceptedAnswers {
+			if answer = strings.TrimSpace(answer); answer != "" {
+				acceptedAnswers = append(acceptedAnswers, answer)
+			}
+		}
+		if len(acceptedAnswers) == 0 {
+			return fmt.Errorf("%w: short answer questions need at least one accepted answer", ErrInvalidAnswerKey)
+		}
+	case QuestionTypeNumeric:
+		if key.NumericAnswer == nil || math.IsInf(*key.NumericAnswer, 0) || math.IsNaN(*key.NumericAnswer) {
+			return fmt.Errorf("%w: numeric questions need a numeric answer", ErrInvalidAnswerKey)
+		}
+		if key.NumericTolerance < 0 {
+			return fmt.Errorf("%w: tolerance cannot be negative", ErrInvalidAnswerKey)
+		}
+		answer := *key.NumericAnswer
+		numericAnswer = &answer
+		numericTolerance = key.NumericTolerance
+	}
+
+	q.Prompt = prompt
+	q.Points = points
+	q.Options = options
+	q.CorrectOptionIDs = correctOptionIDs
+	q.AcceptedAnswers = acceptedAnswers
+	q.NumericAnswer = numericAnswer
+	q.NumericTolerance = numericTolerance
+	q.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func correctOptions(options []QuestionOption, indexes []int) ([]string, error) {
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("%w: at least one correct option is required", ErrInvalidAnswerKey)
+	}
+	seen := make(map[int]bool, len(indexes))
+	ids := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= len(options) {
+			return nil, fmt.Errorf("%w: correct option %d does not exist", ErrInvalidAnswerKey, index)
+		}
+		if seen[index] {
+			continue
+		}
+		seen[index] = true
+		ids = append(ids, options[index].ID)
+	}
+	return ids, nil
+}
+
+// QuestionAnswer is a student's answer to a question: the chosen option IDs
+// for the choice types, or Text for short answer and numeric questions.
+type QuestionAnswer struct {
+	OptionIDs []string `json:"option_ids,omitempty"`
+	Text      string   `json:"text,omitempty"`
+}
+
+// Grade returns the points answer earns. Answers are all or nothing:
+// multi-select answers must choose exactly the correct options, short answers
+// are compared ignoring case and surrounding whitespace, and numeric answers
+// must be within the tolerance.
+func (q *Question) Grade(answer QuestionAnswer) float64 {
+	if q.isCorrect(answer) {
+		return q.Points
+	}
+	return 0
+}
+
+func (q *Question) isCorrect(answer QuestionAnswer) bool {
+	switch q.Type {
+	case QuestionTypeMultipleChoice, QuestionTypeMultiSelect, QuestionTypeTrueFalse:
+		chosen := uniqueSorted(answer.OptionIDs)
+		correct := uniqueSorted(q.CorrectOptionIDs)
+		if len(chosen) != len(correct) {
+			return false
+		}
+		for i := range chosen {
+			if chosen[i] != correct[i] {
+				return false
+			}
+		}
+		return true
+	case QuestionTypeShortAnswer:
+		given := strings.TrimSpace(answer.Text)
+		for _, accepted := range q.AcceptedAnswers {
+			if strings.EqualFold(given, accepted) {
+				return true
+			}
+		}
+		return false
+	case QuestionTypeNumeric:
+		given, err := strconv.ParseFloat(strings.TrimSpace(answer.Text), 64)
+		if err != nil || q.NumericAnswer == nil {
+			return false
+		}
+		return math.Abs(given-*q.NumericAnswer) <= q.NumericTolerance
+	}
+	return false
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResultsVisibility decides what students see of their graded attempts.
+// Staff always see everything.
+type ResultsVisibility string
+
+const (
+	// ResultsVisibilityImmediate shows the score and the correct answers as
+	// soon as an attempt is submitted.
+	ResultsVisibilityImmediate ResultsVisibility = "immediate"
+	// ResultsVisibilityScoreOnly shows the score but never the correct
+	// answers.
+	ResultsVisibilityScoreOnly ResultsVisibility = "score_only"
+	// ResultsVisibilityAfterClose shows the score and correct answers once
+	// the quiz has closed.
+	ResultsVisibilityAfterClose ResultsVisibility = "after_close"
+	// ResultsVisibilityHidden never shows students their results.
+	ResultsVisibilityHidden ResultsVisibility = "hidden"
+)
+
+func (v ResultsVisibility) IsValid() bool {
+	switch v {
+	case ResultsVisibilityImmediate, ResultsVisibilityScoreOnly, ResultsVisibilityAfterClose, ResultsVisibilityHidden:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrQuizQuestionsRequired    = errors.New("a quiz needs at least one question")
+	ErrDuplicateQuizQuestion    = errors.New("a question can only appear once in a quiz")
+	ErrInvalidQuizTimeLimit     = errors.New("time limit must be at least one minute")
+	ErrInvalidQuizMaxAttempts   = errors.New("max attempts must be at least one")
+	ErrInvalidResultsVisibility = errors.New("invalid results visibility")
+	ErrQuizCloseRequired        = errors.New("results shown after close need a close time")
+)
+
+// QuizSettings are the parts of a quiz staff configure. Nil TimeLimitMinutes
+// and MaxAttempts mean no limit, and an empty ResultsVisibility means
+// ResultsVisibilityImmediate.
+type QuizSettings struct {
+	QuestionIDs       []string
+	TimeLimitMinutes  *int
+	MaxAttempts       *int
+	ShuffleQuestions  bool
+	ShuffleOptions    bool
+	ResultsVisibility ResultsVisibility
+	ClosesAt          *time.Time
+}
+
+// Quiz is the content of a quiz section module. Its questions come from the
+// question bank of CourseID, in the order listed unless shuffled.
+type Quiz struct {
+	ID                string
+	SectionModuleID   string
+	CourseID          string
+	QuestionIDs       []string
+	TimeLimitMinutes  *int
+	MaxAttempts       *int
+	ShuffleQuestions  bool
+	ShuffleOptions    bool
+	ResultsVisibility ResultsVisibility
+	ClosesAt          *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewQuiz(sectionModuleID, courseID string, settings QuizSettings) (*Quiz, error) {
+	now := time.Now().UTC()
+	quiz := &Quiz{
+		ID:              uuid.NewString(),
+		SectionModuleID: sectionModuleID,
+		CourseID:        courseID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := quiz.Configure(settings); err != nil {
+		return nil, err
+	}
+	return quiz, nil
+}
+
+// Configure replaces the quiz settings, leaving the quiz unchanged when they
+// are invalid. Attempts already started keep the questions they were given.
+func (q *Quiz) Configure(settings QuizSettings) error {
+	if len(settings.QuestionIDs) == 0 {
+		return ErrQuizQuestionsRequired
+	}
+	seen := make(map[string]bool, len(settings.QuestionIDs))
+	for _, id := range settings.QuestionIDs {
+		if seen[id] {
+			return ErrDuplicateQuizQuestion
+		}
+		seen[id] = true
+	}
+	if settings.TimeLimitMinutes != nil && *settings.TimeLimitMinutes < 1 {
+		return ErrInvalidQuizTimeLimit
+	}
+	if settings.MaxAttempts != nil && *settings.MaxAttempts < 1 {
+		return ErrInvalidQuizMaxAttempts
+	}
+	visibility := settings.ResultsVisibility
+	if visibility == "" {
+		visibility = ResultsVisibilityImmediate
+	}
+	if !visibility.IsValid() {
+		return ErrInvalidResultsVisibility
+	}
+	if visibility == ResultsVisibilityAfterClose && settings.ClosesAt == nil {
+		return ErrQuizCloseRequired
+	}
+
+	q.QuestionIDs = append([]string(nil), settings.QuestionIDs...)
+	q.TimeLimitMinutes = settings.TimeLimitMinutes
+	q.MaxAttempts = settings.MaxAttempts
+	q.ShuffleQuestions = settings.ShuffleQuestions
+	q.ShuffleOptions = settings.ShuffleOptions
+	q.ResultsVisibility = visibility
+	q.ClosesAt = settings.ClosesAt
+	q.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (q *Quiz) IsClosed(now time.Time) bool {
+	return q.ClosesAt != nil && !now.Before(*q.ClosesAt)
+}
+
+// ShowsScore reports whether students may see the score of their submitted
+// attempts at now.
+func (q *Quiz) ShowsScore(now time.Time) bool {
+	switch q.ResultsVisibility {
+	case ResultsVisibilityImmediate, ResultsVisibilityScoreOnly:
+		return true
+	case ResultsVisibilityAfterClose:
+		return q.IsClosed(now)
+	}
+	return false
+}
+
+// ShowsCorrectAnswers reports whether students may see which answers were
+// correct at now.
+func (q *Quiz) ShowsCorrectAnswers(now time.Time) bool {
+	switch q.ResultsVisibility {
+	case ResultsVisibilityImmediate:
+		return true
+	case ResultsVisibilityAfterClose:
+		return q.IsClosed(now)
+	}
+	return false
+}
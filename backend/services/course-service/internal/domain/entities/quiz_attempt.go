@@ -0,0 +1,200 @@
+package entities
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuizAttemptStatus string
+
+const (
+	QuizAttemptStatusInProgress QuizAttemptStatus = "in_progress"
+	QuizAttemptStatusSubmitted  QuizAttemptStatus = "submitted"
+	// QuizAttemptStatusExpired is an attempt whose time ran out before it
+	// was submitted. Only the answers saved in time are graded, and it counts
+	// towards the attempt limit.
+	QuizAttemptStatusExpired QuizAttemptStatus = "expired"
+)
+
+// QuizSubmissionGrace is how long after ExpiresAt a submission is still
+// accepted, to allow for the request being in flight when time runs out.
+const QuizSubmissionGrace = 30 * time.Second
+
+var ErrQuizAttemptClosed = errors.New("quiz attempt is no longer in progress")
+
+// QuestionResult is the grade of one question of an attempt.
+type QuestionResult struct {
+	QuestionID string  `json:"question_id"`
+	Points     float64 `json:"points"`
+	MaxPoints  float64 `json:"max_points"`
+	Correct    bool    `json:"correct"`
+}
+
+// QuizAttempt is one go of a student at a quiz. The question and option
+// order are fixed when the attempt starts, so a shuffled quiz looks the same
+// every time the attempt is loaded.
+type QuizAttempt struct {
+	ID            string
+	QuizID        string
+	StudentID     string
+	AttemptNumber int
+	Status        QuizAttemptStatus
+	QuestionIDs   []string
+	// OptionOrder maps each choice question to its option IDs in the order
+	// they are shown.
+	OptionOrder map[string][]string
+	Answers     map[string]QuestionAnswer
+	Results     []QuestionResult
+	Score       float64
+	MaxScore    float64
+	StartedAt   time.Time
+	ExpiresAt   *time.Time
+	SubmittedAt *time.Time
+}
+
+// NewQuizAttempt starts an attempt at quiz with questions, which must be the
+// quiz's questions in the quiz's order. The attempt expires when the time
+// limit runs out or the quiz closes, whichever is first.
+func NewQuizAttempt(quiz *Quiz, questions []*Question, studentID string, attemptNumber int) *QuizAttempt {
+	now := time.Now().UTC()
+
+	questionIDs := make([]string, len(questions))
+	optionOrder := make(map[string][]string)
+	var maxScore float64
+	for i, question := range questions {
+		questionIDs[i] = question.ID
+		maxScore += question.Points
+		if !question.Type.HasOptions() {
+			continue
+		}
+		optionIDs := make([]string, len(question.Options))
+		for j, option := range question.Options {
+			optionIDs[j] = option.ID
+		}
+		// True and False stay in their natural order.
+		if quiz.ShuffleOptions && question.Type != QuestionTypeTrueFalse {
+			rand.Shuffle(len(optionIDs), func(a, b int) { optionIDs[a], optionIDs[b] = optionIDs[b], optionIDs[a] })
+		}
+		optionOrder[question.ID] = optionIDs
+	}
+	if quiz.ShuffleQuestions {
+		rand.Shuffle(len(questionIDs), func(a, b int) { questionIDs[a], questionIDs[b] = questionIDs[b], questionIDs[a] })
+	}
+
+	var expiresAt *time.Time
+	if quiz.TimeLimitMinutes != nil {
+		limit := now.Add(time.Duration(*quiz.TimeLimitMinutes) * time.Minute)
+		expiresAt = &limit
+	}
+	if quiz.ClosesAt != nil && (expiresAt == nil || quiz.ClosesAt.Before(*expiresAt)) {
+		closesAt := quiz.ClosesAt.UTC()
+		expiresAt = &closesAt
+	}
+
+	return &QuizAttempt{
+		ID:            uuid.NewString(),
+		QuizID:        quiz.ID,
+		StudentID:     studentID,
+		AttemptNumber: attemptNumber,
+		Status:        QuizAttemptStatusInProgress,
+		QuestionIDs:   questionIDs,
+		OptionOrder:   optionOrder,
+		Answers:       map[string]QuestionAnswer{},
+		MaxScore:      maxScore,
+		StartedAt:     now,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+// IsExpired reports whether an in-progress attempt ran out of time, allowing
+// for QuizSubmissionGrace.
+func (a *QuizAttempt) IsExpired(now time.Time) bool {
+	return a.Status == QuizAttemptStatusInProgress && a.ExpiresAt != nil && now.After(a.ExpiresAt.Add(QuizSubmissionGrace))
+}
+
+// SaveAnswers records answers, keyed by question ID, on an in-progress
+// attempt, replacing any saved before. Saved answers are graded even if the
+// attempt runs out of time before it is submitted. Answers to questions
+// outside the attempt are ignored.
+func (a *QuizAttempt) SaveAnswers(answers map[string]QuestionAnswer, now time.Time) error {
+	if a.Status != QuizAttemptStatusInProgress || a.IsExpired(now) {
+		return ErrQuizAttemptClosed
+	}
+	if a.Answers == nil {
+		a.Answers = map[string]QuestionAnswer{}
+	}
+	for _, questionID := range a.QuestionIDs {
+		if answer, ok := answers[questionID]; ok {
+			a.Answers[questionID] = answer
+		}
+	}
+	return nil
+}
+
+// Submit grades answers against questions, keyed by question ID, and closes
+// the attempt. Answers are added to those already saved, answers to questions
+// outside the attempt are ignored and unanswered questions score zero. An
+// attempt submitted after it expired is closed as expired and only the
+// answers saved in time are graded.
+func (a *QuizAttempt) Submit(answers map[string]QuestionAnswer, questions map[string]*Question, now time.Time) error {
+	if a.Status != QuizAttemptStatusInProgress {
+		return ErrQuizAttemptClosed
+	}
+	if a.IsExpired(now) {
+		a.Expire(questions, now)
+		return nil
+	}
+	if err := a.SaveAnswers(answers, now); err != nil {
+		return err
+	}
+
+	submittedAt := now.UTC()
+	a.SubmittedAt = &submittedAt
+	a.grade(questions)
+	a.Status = QuizAttemptStatusSubmitted
+	return nil
+}
+
+// Expire closes an in-progress attempt that ran out of time, grading the
+// answers saved before it did.
+func (a *QuizAttempt) Expire(questions map[string]*Question, now time.Time) {
+	if a.SubmittedAt == nil {
+		submittedAt := now.UTC()
+		a.SubmittedAt = &submittedAt
+	}
+	a.grade(questions)
+	a.Status = QuizAttemptStatusExpired
+}
+
+// grade scores the saved answers against questions, keyed by question ID.
+func (a *QuizAttempt) grade(questions map[string]*Question) {
+	saved := a.Answers
+	a.Answers = make(map[string]QuestionAnswer, len(a.QuestionIDs))
+	a.Results = make([]QuestionResult, 0, len(a.QuestionIDs))
+	a.Score = 0
+	a.MaxScore = 0
+	for _, questionID := range a.QuestionIDs {
+		question, ok := questions[questionID]
+		if !ok {
+			// Deleted from the bank since the attempt started; it is not
+			// held against the student.
+			continue
+		}
+		answer, answered := saved[questionID]
+		if answered {
+			a.Answers[questionID] = answer
+		}
+		points := question.Grade(answer)
+		a.Score += points
+		a.MaxScore += question.Points
+		a.Results = append(a.Results, QuestionResult{
+			QuestionID: questionID,
+			Points:     points,
+			MaxPoints:  question.Points,
+			Correct:    answered && points == question.Points,
+		})
+	}
+}
@@ -9,9 +9,29 @@ import (
 type ContentType string
 
 const (
-	ContentTypeZoom ContentType = "zoom"
+	ContentTypeZoom       ContentType = "zoom"
+	ContentTypeVideo      ContentType = "video"
+	ContentTypeDocument   ContentType = "document"
+	ContentTypeText       ContentType = "text"
+	ContentTypeLink       ContentType = "link"
+	ContentTypeEmbed      ContentType = "embed"
+	ContentTypeQuiz       ContentType = "quiz"
+	ContentTypeAssignment ContentType = "assignment"
 )
 
+func (t ContentType) IsValid() bool {
+	switch t {
+	case ContentTypeZoom, ContentTypeVideo, ContentTypeDocument, ContentTypeText, ContentTypeLink, ContentTypeEmbed, ContentTypeQuiz, ContentTypeAssignment:
+		return true
+	}
+	return false
+}
+
+// IsFile reports whether the module's ContentID is a file-service file ID.
+func (t ContentType) IsFile() bool {
+	return t == ContentTypeVideo || t == ContentTypeDocument
+}
+
 type ContentStatus string
 
 const (
@@ -21,16 +41,18 @@ const (
 )
 
 type SectionModule struct {
-	ID            string
+	ID              string
 	CourseSectionID string
-	ContentID     *string
-	Name          string
-	Description   string
-	ContentType   ContentType
-	ContentStatus ContentStatus
-	Order         int
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ContentID       *string
+	ContentBody     *string
+	ContentURL      *string
+	Name            string
+	Description     string
+	ContentType     ContentType
+	ContentStatus   ContentStatus
+	Order           int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func NewSectionModule(courseSectionID, name, description string, contentType ContentType, order int) *SectionModule {
@@ -62,6 +84,15 @@ func (sm *SectionModule) UpdateContent(contentID *string, status ContentStatus)
 	sm.UpdatedAt = time.Now().UTC()
 }
 
+// UpdateInlineContent sets the content of modules that are stored in
+// course-service itself rather than in a backing service.
+func (sm *SectionModule) UpdateInlineContent(body, url *string) {
+	sm.ContentBody = body
+	sm.ContentURL = url
+	sm.ContentStatus = ContentStatusCreated
+	sm.UpdatedAt = time.Now().UTC()
+}
+
 func (sm *SectionModule) UpdateStatus(status ContentStatus) {
 	sm.ContentStatus = status
 	sm.UpdatedAt = time.Now().UTC()
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+type AssignmentRepository interface {
+	Create(ctx context.Context, assignment *entities.Assignment) error
+	FindByID(ctx context.Context, id string) (*entities.Assignment, error)
+	FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Assignment, error)
+	Update(ctx context.Context, assignment *entities.Assignment) error
+}
+
+type AssignmentSubmissionRepository interface {
+	Create(ctx context.Context, submission *entities.AssignmentSubmission) error
+	FindByID(ctx context.Context, id string) (*entities.AssignmentSubmission, error)
+	// FindByAssignmentID returns the submissions for the assignment, by
+	// student and then submission number.
+	FindByAssignmentID(ctx context.Context, assignmentID string) ([]*entities.AssignmentSubmission, error)
+	// FindByAssignmentAndStudent returns the student's submissions for the
+	// assignment in submission order.
+	FindByAssignmentAndStudent(ctx context.Context, assignmentID, studentID string) ([]*entities.AssignmentSubmission, error)
+	Update(ctx context.Context, submission *entities.AssignmentSubmission) error
+	// DeleteByStudentID removes every submission of the student and returns
+	// how many there were.
+	DeleteByStudentID(ctx context.Context, studentID string) (int64, error)
+	// FindWithFiles returns every submission that includes files, oldest
+	// first.
+	FindWithFiles(ctx context.Context) ([]*entities.AssignmentSubmission, error)
+}
@@ -10,8 +10,13 @@ type CourseOfferingInstructorRepository interface {
 	Create(ctx context.Context, instructor *entities.CourseOfferingInstructor) error
 	FindByOfferingID(ctx context.Context, offeringID string) ([]*entities.CourseOfferingInstructor, error)
 	FindByInstructorID(ctx context.Context, instructorID string) ([]*entities.CourseOfferingInstructor, error)
+	// FindAll returns every assignment, for replaying them to other services.
+	FindAll(ctx context.Context) ([]*entities.CourseOfferingInstructor, error)
 	Delete(ctx context.Context, id string) error
 	DeleteByOfferingID(ctx context.Context, offeringID string) error
+	// DeleteByInstructorID removes every assignment of the instructor and
+	// returns how many there were.
+	DeleteByInstructorID(ctx context.Context, instructorID string) (int64, error)
 	Update(ctx context.Context, instructor *entities.CourseOfferingInstructor) error
 }
 
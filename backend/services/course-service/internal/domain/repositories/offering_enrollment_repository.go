@@ -0,0 +1,14 @@
+package repositories
+
+import "context"
+
+// OfferingEnrollmentRepository holds the copy of enrollment-service's
+// enrollments used to check that a student is enrolled in an offering.
+type OfferingEnrollmentRepository interface {
+	// Save records the enrollment or replaces its status.
+	Save(ctx context.Context, enrollmentID, courseOfferingID, studentID, status string) error
+	Delete(ctx context.Context, enrollmentID string) error
+	// IsEnrolled reports whether the student has an approved enrollment in
+	// the offering.
+	IsEnrolled(ctx context.Context, courseOfferingID, studentID string) (bool, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+type QuestionRepository interface {
+	Create(ctx context.Context, question *entities.Question) error
+	FindByID(ctx context.Context, id string) (*entities.Question, error)
+	// FindByIDs returns the questions that exist among ids, in no particular
+	// order.
+	FindByIDs(ctx context.Context, ids []string) ([]*entities.Question, error)
+	FindByCourseID(ctx context.Context, courseID string) ([]*entities.Question, error)
+	Update(ctx context.Context, question *entities.Question) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+)
+
+type QuizRepository interface {
+	Create(ctx context.Context, quiz *entities.Quiz) error
+	FindByID(ctx context.Context, id string) (*entities.Quiz, error)
+	FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Quiz, error)
+	// CountByQuestionID returns how many quizzes use the question.
+	CountByQuestionID(ctx context.Context, questionID string) (int, error)
+	Update(ctx context.Context, quiz *entities.Quiz) error
+}
+
+type QuizAttemptRepository interface {
+	Create(ctx context.Context, attempt *entities.QuizAttempt) error
+	FindByID(ctx context.Context, id string) (*entities.QuizAttempt, error)
+	// FindByQuizID returns the attempts at the quiz, by student and then
+	// attempt number.
+	FindByQuizID(ctx context.Context, quizID string) ([]*entities.QuizAttempt, error)
+	// FindByQuizAndStudent returns the student's attempts at the quiz in
+	// attempt order.
+	FindByQuizAndStudent(ctx context.Context, quizID, studentID string) ([]*entities.QuizAttempt, error)
+	// Update saves an attempt that is still in progress in storage and
+	// returns entities.ErrQuizAttemptClosed if it has been closed since it
+	// was loaded.
+	Update(ctx context.Context, attempt *entities.QuizAttempt) error
+	// DeleteByStudentID removes every attempt of the student and returns how
+	// many there were.
+	DeleteByStudentID(ctx context.Context, studentID string) (int64, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+// SubmissionFileRepository looks up the files handed in with a submission in
+// file-service, which owns them, to check who uploaded them.
+type SubmissionFileRepository interface {
+	// CountUploadedBy returns how many of fileIDs the user uploaded to the
+	// submissions bucket. authorization is the user's Authorization header,
+	// forwarded so file-service answers as that user.
+	CountUploadedBy(ctx context.Context, fileIDs []string, uploadedBy, authorization string) (int, error)
+}
@@ -2,11 +2,19 @@
 package config
 
 import (
+	"time"
+
 	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 )
 
 type Config struct {
 	config.BaseConfig
+	// RateLimit applies per user to every API route.
+	RateLimit config.RateLimitConfig
+	Auth      config.TokenAuthConfig
+	// FileServiceURL is where file-service is asked who uploaded the files
+	// handed in with a submission.
+	FileServiceURL string
 }
 
 func DefaultConfig() *Config {
@@ -17,6 +25,16 @@ func DefaultConfig() *Config {
 	defaults.Database.DBName = "course_db"
 	return &Config{
 		BaseConfig: defaults,
+		RateLimit: config.RateLimitConfig{
+			Enabled:  true,
+			Requests: 300,
+			Window:   time.Minute,
+		},
+		Auth: config.TokenAuthConfig{
+			SecretKey:      "secret",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+		},
+		FileServiceURL: "http://localhost:8004",
 	}
 }
 
@@ -25,7 +43,10 @@ func Load() (*Config, error) {
 	baseDefaults := &defaults.BaseConfig
 	baseCfg := config.LoadBaseConfig(baseDefaults)
 	return &Config{
-		BaseConfig: baseCfg,
+		BaseConfig:     baseCfg,
+		RateLimit:      config.LoadRateLimitConfig("RATE_LIMIT_API", defaults.RateLimit),
+		Auth:           config.LoadTokenAuthConfig(defaults.Auth),
+		FileServiceURL: config.GetEnv("FILE_SERVICE_URL", defaults.FileServiceURL),
 	}, nil
 }
 
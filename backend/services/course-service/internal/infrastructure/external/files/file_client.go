@@ -0,0 +1,80 @@
+package files
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// submissionBucket is file-service's bucket for files students hand in.
+const submissionBucket = "assignment-submissions"
+
+// FileClient reads file metadata from file-service.
+type FileClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type fileResponse struct {
+	BucketName string     `json:"bucket_name"`
+	UploadedBy string     `json:"uploaded_by"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+}
+
+func NewFileClient(baseURL string) *FileClient {
+	return &FileClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// CountUploadedBy asks file-service for each file and counts the ones the
+// user uploaded to the submissions bucket and has not deleted. Files
+// file-service does not know are not counted.
+func (fc *FileClient) CountUploadedBy(ctx context.Context, fileIDs []string, uploadedBy, authorization string) (int, error) {
+	count := 0
+	for _, fileID := range fileIDs {
+		file, err := fc.getFile(ctx, fileID, authorization)
+		if err != nil {
+			return 0, err
+		}
+		if file != nil && file.BucketName == submissionBucket && file.UploadedBy == uploadedBy && file.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (fc *FileClient) getFile(ctx context.Context, fileID, authorization string) (*fileResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fc.baseURL+"/api/v1/files/"+url.PathEscape(fileID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := fc.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s: %w", fileID, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to get file %s: file-service returned status %d", fileID, resp.StatusCode)
+	}
+
+	var file fileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode file %s: %w", fileID, err)
+	}
+	return &file, nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+const assignmentColumns = `id, section_module_id, course_offering_id, instructions, allow_files, allow_text, due_at, late_policy, late_penalty_percent, max_score, max_submissions, created_at, updated_at`
+
+type PostgresAssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAssignmentRepository(db *sql.DB) repositories.AssignmentRepository {
+	return &PostgresAssignmentRepository{db: db}
+}
+
+func (r *PostgresAssignmentRepository) Create(ctx context.Context, assignment *entities.Assignment) error {
+	query := `
+		INSERT INTO assignment (` + assignmentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		assignment.ID,
+		assignment.SectionModuleID,
+		assignment.CourseOfferingID,
+		assignment.Instructions,
+		assignment.AllowFiles,
+		assignment.AllowText,
+		assignment.DueAt,
+		assignment.LatePolicy,
+		assignment.LatePenaltyPercent,
+		assignment.MaxScore,
+		assignment.MaxSubmissions,
+		assignment.CreatedAt,
+		assignment.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresAssignmentRepository) FindByID(ctx context.Context, id string) (*entities.Assignment, error) {
+	query := `
+		SELECT ` + assignmentColumns + `
+		FROM assignment
+		WHERE id = $1
+			AND ($2::uuid IS NULL OR course_offering_id IN (SELECT id FROM course_offering WHERE organization_id = $2))
+	`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *PostgresAssignmentRepository) FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Assignment, error) {
+	query := `
+		SELECT ` + assignmentColumns + `
+		FROM assignment
+		WHERE section_module_id = $1
+			AND ($2::uuid IS NULL OR course_offering_id IN (SELECT id FROM course_offering WHERE organization_id = $2))
+	`
+	return r.findOne(ctx, query, sectionModuleID)
+}
+
+func (r *PostgresAssignmentRepository) Update(ctx context.Context, assignment *entities.Assignment) error {
+	query := `
+		UPDATE assignment
+		SET instructions = $1, allow_files = $2, allow_text = $3, due_at = $4, late_policy = $5,
+			late_penalty_percent = $6, max_score = $7, max_submissions = $8, updated_at = $9
+		WHERE id = $10
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		assignment.Instructions,
+		assignment.AllowFiles,
+		assignment.AllowText,
+		assignment.DueAt,
+		assignment.LatePolicy,
+		assignment.LatePenaltyPercent,
+		assignment.MaxScore,
+		assignment.MaxSubmissions,
+		assignment.UpdatedAt,
+		assignment.ID,
+	)
+	return err
+}
+
+func (r *PostgresAssignmentRepository) findOne(ctx context.Context, query, id string) (*entities.Assignment, error) {
+	var assignment entities.Assignment
+	var dueAt sql.NullTime
+	var maxSubmissions sql.NullInt64
+	err := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx)).Scan(
+		&assignment.ID,
+		&assignment.SectionModuleID,
+		&assignment.CourseOfferingID,
+		&assignment.Instructions,
+		&assignment.AllowFiles,
+		&assignment.AllowText,
+		&dueAt,
+		&assignment.LatePolicy,
+		&assignment.LatePenaltyPercent,
+		&assignment.MaxScore,
+		&maxSubmissions,
+		&assignment.CreatedAt,
+		&assignment.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if dueAt.Valid {
+		assignment.DueAt = &dueAt.Time
+	}
+	if maxSubmissions.Valid {
+		submissions := int(maxSubmissions.Int64)
+		assignment.MaxSubmissions = &submissions
+	}
+	return &assignment, nil
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+const assignmentSubmissionColumns = `id, assignment_id, student_id, student_email, submission_number, text_content, file_ids, late, penalty_percent, status, score, final_score, feedback, graded_by, graded_at, submitted_at`
+
+type PostgresAssignmentSubmissionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAssignmentSubmissionRepository(db *sql.DB) repositories.AssignmentSubmissionRepository {
+	return &PostgresAssignmentSubmissionRepository{db: db}
+}
+
+func (r *PostgresAssignmentSubmissionRepository) Create(ctx context.Context, submission *entities.AssignmentSubmission) error {
+	fileIDs, err := marshalJSONArray(submission.FileIDs)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO assignment_submission (` + assignmentSubmissionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		submission.ID,
+		submission.AssignmentID,
+		submission.StudentID,
+		submission.StudentEmail,
+		submission.SubmissionNumber,
+		submission.Text,
+		fileIDs,
+		submission.Late,
+		submission.PenaltyPercent,
+		submission.Status,
+		submission.Score,
+		submission.FinalScore,
+		submission.Feedback,
+		submission.GradedBy,
+		submission.GradedAt,
+		submission.SubmittedAt,
+	)
+	return err
+}
+
+func (r *PostgresAssignmentSubmissionRepository) FindByID(ctx context.Context, id string) (*entities.AssignmentSubmission, error) {
+	query := `
+		SELECT ` + assignmentSubmissionColumns + `
+		FROM assignment_submission
+		WHERE id = $1
+			AND ($2::uuid IS NULL OR assignment_id IN (
+				SELECT a.id FROM assignment a
+				JOIN course_offering co ON co.id = a.course_offering_id
+				WHERE co.organization_id = $2
+			))
+	`
+	submission, err := scanAssignmentSubmission(r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return submission, err
+}
+
+func (r *PostgresAssignmentSubmissionRepository) FindByAssignmentID(ctx context.Context, assignmentID string) ([]*entities.AssignmentSubmission, error) {
+	query := `
+		SELECT ` + assignmentSubmissionColumns + `
+		FROM assignment_submission
+		WHERE assignment_id = $1
+			AND ($2::uuid IS NULL OR assignment_id IN (
+				SELECT a.id FROM assignment a
+				JOIN course_offering co ON co.id = a.course_offering_id
+				WHERE co.organization_id = $2
+			))
+		ORDER BY student_id ASC, submission_number ASC
+	`
+	return r.querySubmissions(ctx, query, assignmentID, tenant.Arg(ctx))
+}
+
+func (r *PostgresAssignmentSubmissionRepository) FindByAssignmentAndStudent(ctx context.Context, assignmentID, studentID string) ([]*entities.AssignmentSubmission, error) {
+	query := `
+		SELECT ` + assignmentSubmissionColumns + `
+		FROM assignment_submission
+		WHERE assignment_id = $1 AND student_id = $2
+			AND ($3::uuid IS NULL OR assignment_id IN (
+				SELECT a.id FROM assignment a
+				JOIN course_offering co ON co.id = a.course_offering_id
+				WHERE co.organization_id = $3
+			))
+		ORDER BY submission_number ASC
+	`
+	return r.querySubmissions(ctx, query, assignmentID, studentID, tenant.Arg(ctx))
+}
+
+func (r *PostgresAssignmentSubmissionRepository) Update(ctx context.Context, submission *entities.AssignmentSubmission) error {
+	query := `
+		UPDATE assignment_submission
+		SET status = $1, score = $2, final_score = $3, feedback = $4, graded_by = $5, graded_at = $6
+		WHERE id = $7
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		submission.Status,
+		submission.Score,
+		submission.FinalScore,
+		submission.Feedback,
+		submission.GradedBy,
+		submission.GradedAt,
+		submission.ID,
+	)
+	return err
+}
+
+func (r *PostgresAssignmentSubmissionRepository) DeleteByStudentID(ctx context.Context, studentID string) (int64, error) {
+	query := `DELETE FROM assignment_submission WHERE student_id = $1`
+	result, err := r.db.ExecContext(ctx, query, studentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *PostgresAssignmentSubmissionRepository) FindWithFiles(ctx context.Context) ([]*entities.AssignmentSubmission, error) {
+	query := `
+		SELECT ` + assignmentSubmissionColumns + `
+		FROM assignment_submission
+		WHERE jsonb_array_length(file_ids) > 0
+		ORDER BY submitted_at ASC
+	`
+	return r.querySubmissions(ctx, query)
+}
+
+func (r *PostgresAssignmentSubmissionRepository) querySubmissions(ctx context.Context, query string, args ...interface{}) ([]*entities.AssignmentSubmission, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	submissions := []*entities.AssignmentSubmission{}
+	for rows.Next() {
+		submission, err := scanAssignmentSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, rows.Err()
+}
+
+func scanAssignmentSubmission(row rowScanner) (*entities.AssignmentSubmission, error) {
+	var submission entities.AssignmentSubmission
+	var text, feedback, gradedBy sql.NullString
+	var fileIDs []byte
+	var score, finalScore sql.NullFloat64
+	var gradedAt sql.NullTime
+	err := row.Scan(
+		&submission.ID,
+		&submission.AssignmentID,
+		&submission.StudentID,
+		&submission.StudentEmail,
+		&submission.SubmissionNumber,
+		&text,
+		&fileIDs,
+		&submission.Late,
+		&submission.PenaltyPercent,
+		&submission.Status,
+		&score,
+		&finalScore,
+		&feedback,
+		&gradedBy,
+		&gradedAt,
+		&submission.SubmittedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(fileIDs, &submission.FileIDs); err != nil {
+		return nil, err
+	}
+	if text.Valid {
+		submission.Text = &text.String
+	}
+	if score.Valid {
+		submission.Score = &score.Float64
+	}
+	if finalScore.Valid {
+		submission.FinalScore = &finalScore.Float64
+	}
+	if feedback.Valid {
+		submission.Feedback = &feedback.String
+	}
+	if gradedBy.Valid {
+		submission.GradedBy = &gradedBy.String
+	}
+	if gradedAt.Valid {
+		submission.GradedAt = &gradedAt.Time
+	}
+	return &submission, nil
+}
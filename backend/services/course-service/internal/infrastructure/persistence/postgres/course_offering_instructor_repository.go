@@ -83,6 +83,30 @@ func (r *PostgresCourseOfferingInstructorRepository) FindByInstructorID(ctx cont
 	return instructors, nil
 }
 
+func (r *PostgresCourseOfferingInstructorRepository) FindAll(ctx context.Context) ([]*entities.CourseOfferingInstructor, error) {
+	query := `
+		SELECT id, course_offering_id, instructor_id, instructor_username, created_at, updated_at
+		FROM course_offering_instructor
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instructors := []*entities.CourseOfferingInstructor{}
+	for rows.Next() {
+		instructor, err := r.scanCourseOfferingInstructor(rows)
+		if err != nil {
+			return nil, err
+		}
+		instructors = append(instructors, instructor)
+	}
+
+	return instructors, nil
+}
+
 func (r *PostgresCourseOfferingInstructorRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM course_offering_instructor WHERE id = $1`
 	_, err := r.db.ExecContext(ctx, query, id)
@@ -95,6 +119,15 @@ func (r *PostgresCourseOfferingInstructorRepository) DeleteByOfferingID(ctx cont
 	return err
 }
 
+func (r *PostgresCourseOfferingInstructorRepository) DeleteByInstructorID(ctx context.Context, instructorID string) (int64, error) {
+	query := `DELETE FROM course_offering_instructor WHERE instructor_id = $1`
+	result, err := r.db.ExecContext(ctx, query, instructorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (r *PostgresCourseOfferingInstructorRepository) Update(ctx context.Context, instructor *entities.CourseOfferingInstructor) error {
 	query := `
 		UPDATE course_offering_instructor
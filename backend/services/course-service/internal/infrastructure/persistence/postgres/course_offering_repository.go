@@ -8,6 +8,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresCourseOfferingRepository struct {
@@ -20,9 +21,10 @@ func NewPostgresCourseOfferingRepository(db *sql.DB) repositories.CourseOffering
 
 func (r *PostgresCourseOfferingRepository) Create(ctx context.Context, offering *entities.CourseOffering) error {
 	query := `
-		INSERT INTO course_offering (id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO course_offering (id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
+	offering.OrganizationID = tenant.Owner(ctx, offering.OrganizationID)
 	_, err := r.db.ExecContext(ctx, query,
 		offering.ID,
 		offering.CourseID,
@@ -35,28 +37,29 @@ func (r *PostgresCourseOfferingRepository) Create(ctx context.Context, offering
 		offering.EnrollmentCost,
 		offering.CreatedAt,
 		offering.UpdatedAt,
+		offering.OrganizationID,
 	)
 	return err
 }
 
 func (r *PostgresCourseOfferingRepository) FindByID(ctx context.Context, id string) (*entities.CourseOffering, error) {
 	query := `
-		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at
+		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at, organization_id
 		FROM course_offering
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanCourseOffering(row)
 }
 
 func (r *PostgresCourseOfferingRepository) FindByCourseID(ctx context.Context, courseID string) ([]*entities.CourseOffering, error) {
 	query := `
-		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at
+		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at, organization_id
 		FROM course_offering
-		WHERE course_id = $1
+		WHERE course_id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 		ORDER BY created_at ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, courseID)
+	rows, err := r.db.QueryContext(ctx, query, courseID, tenant.Arg(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +78,7 @@ func (r *PostgresCourseOfferingRepository) FindByCourseID(ctx context.Context, c
 }
 
 func (r *PostgresCourseOfferingRepository) Find(ctx context.Context, query repositories.CourseOfferingQuery) (*repositories.CourseOfferingQueryResult, error) {
-	whereClause, args := r.buildWhereClause(query)
+	whereClause, args := r.buildWhereClause(ctx, query)
 	argIdx := len(args) + 1
 
 	// Count query
@@ -106,7 +109,7 @@ func (r *PostgresCourseOfferingRepository) Find(ctx context.Context, query repos
 	}
 
 	selectQuery := fmt.Sprintf(`
-		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at
+		SELECT id, course_id, name, description, offering_type, status, duration, class_time, enrollment_cost, created_at, updated_at, organization_id
 		FROM course_offering
 		%s
 		%s
@@ -135,7 +138,7 @@ func (r *PostgresCourseOfferingRepository) Find(ctx context.Context, query repos
 	}, nil
 }
 
-func (r *PostgresCourseOfferingRepository) buildWhereClause(query repositories.CourseOfferingQuery) (string, []interface{}) {
+func (r *PostgresCourseOfferingRepository) buildWhereClause(ctx context.Context, query repositories.CourseOfferingQuery) (string, []interface{}) {
 	clauses := []string{}
 	args := []interface{}{}
 	argIdx := 1
@@ -153,6 +156,12 @@ func (r *PostgresCourseOfferingRepository) buildWhereClause(query repositories.C
 		argIdx++
 	}
 
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		clauses = append(clauses, fmt.Sprintf("organization_id = $%d", argIdx))
+		args = append(args, organizationID)
+		argIdx++
+	}
+
 	whereClause := ""
 	if len(clauses) > 0 {
 		whereClause = "WHERE " + strings.Join(clauses, " AND ")
@@ -188,7 +197,7 @@ func (r *PostgresCourseOfferingRepository) Delete(ctx context.Context, id string
 
 func (r *PostgresCourseOfferingRepository) scanCourseOffering(row *sql.Row) (*entities.CourseOffering, error) {
 	var offering entities.CourseOffering
-	var duration, classTime sql.NullString
+	var duration, classTime, organizationID sql.NullString
 	err := row.Scan(
 		&offering.ID,
 		&offering.CourseID,
@@ -201,6 +210,7 @@ func (r *PostgresCourseOfferingRepository) scanCourseOffering(row *sql.Row) (*en
 		&offering.EnrollmentCost,
 		&offering.CreatedAt,
 		&offering.UpdatedAt,
+		&organizationID,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -214,12 +224,15 @@ func (r *PostgresCourseOfferingRepository) scanCourseOffering(row *sql.Row) (*en
 	if classTime.Valid {
 		offering.ClassTime = &classTime.String
 	}
+	if organizationID.Valid {
+		offering.OrganizationID = &organizationID.String
+	}
 	return &offering, nil
 }
 
 func (r *PostgresCourseOfferingRepository) scanCourseOfferingRow(rows *sql.Rows) (*entities.CourseOffering, error) {
 	var offering entities.CourseOffering
-	var duration, classTime sql.NullString
+	var duration, classTime, organizationID sql.NullString
 	err := rows.Scan(
 		&offering.ID,
 		&offering.CourseID,
@@ -232,6 +245,7 @@ func (r *PostgresCourseOfferingRepository) scanCourseOfferingRow(rows *sql.Rows)
 		&offering.EnrollmentCost,
 		&offering.CreatedAt,
 		&offering.UpdatedAt,
+		&organizationID,
 	)
 	if err != nil {
 		return nil, err
@@ -242,6 +256,9 @@ func (r *PostgresCourseOfferingRepository) scanCourseOfferingRow(rows *sql.Rows)
 	if classTime.Valid {
 		offering.ClassTime = &classTime.String
 	}
+	if organizationID.Valid {
+		offering.OrganizationID = &organizationID.String
+	}
 	return &offering, nil
 }
 
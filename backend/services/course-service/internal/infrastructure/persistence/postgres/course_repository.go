@@ -8,6 +8,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresCourseRepository struct {
@@ -20,9 +21,10 @@ func NewPostgresCourseRepository(db *sql.DB) repositories.CourseRepository {
 
 func (r *PostgresCourseRepository) Create(ctx context.Context, course *entities.Course) error {
 	query := `
-		INSERT INTO course (id, name, description, thumbnail_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO course (id, name, description, thumbnail_id, created_at, updated_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
+	course.OrganizationID = tenant.Owner(ctx, course.OrganizationID)
 	_, err := r.db.ExecContext(ctx, query,
 		course.ID,
 		course.Name,
@@ -30,22 +32,23 @@ func (r *PostgresCourseRepository) Create(ctx context.Context, course *entities.
 		course.ThumbnailID,
 		course.CreatedAt,
 		course.UpdatedAt,
+		course.OrganizationID,
 	)
 	return err
 }
 
 func (r *PostgresCourseRepository) FindByID(ctx context.Context, id string) (*entities.Course, error) {
 	query := `
-		SELECT id, name, description, thumbnail_id, created_at, updated_at
+		SELECT id, name, description, thumbnail_id, created_at, updated_at, organization_id
 		FROM course
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanCourse(row)
 }
 
 func (r *PostgresCourseRepository) Find(ctx context.Context, query repositories.CourseQuery) (*repositories.CourseQueryResult, error) {
-	whereClause, args := r.buildWhereClause(query)
+	whereClause, args := r.buildWhereClause(ctx, query)
 	argIdx := len(args) + 1
 
 	// Count query
@@ -76,7 +79,7 @@ func (r *PostgresCourseRepository) Find(ctx context.Context, query repositories.
 	}
 
 	selectQuery := fmt.Sprintf(`
-		SELECT id, name, description, thumbnail_id, created_at, updated_at
+		SELECT id, name, description, thumbnail_id, created_at, updated_at, organization_id
 		FROM course
 		%s
 		%s
@@ -127,7 +130,7 @@ func (r *PostgresCourseRepository) Delete(ctx context.Context, id string) error
 	return err
 }
 
-func (r *PostgresCourseRepository) buildWhereClause(query repositories.CourseQuery) (string, []interface{}) {
+func (r *PostgresCourseRepository) buildWhereClause(ctx context.Context, query repositories.CourseQuery) (string, []interface{}) {
 	clauses := []string{}
 	args := []interface{}{}
 	argIdx := 1
@@ -149,6 +152,12 @@ func (r *PostgresCourseRepository) buildWhereClause(query repositories.CourseQue
 		argIdx++
 	}
 
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		clauses = append(clauses, fmt.Sprintf("organization_id = $%d", argIdx))
+		args = append(args, organizationID)
+		argIdx++
+	}
+
 	whereClause := ""
 	if len(clauses) > 0 {
 		whereClause = "WHERE " + strings.Join(clauses, " AND ")
@@ -158,7 +167,7 @@ func (r *PostgresCourseRepository) buildWhereClause(query repositories.CourseQue
 
 func (r *PostgresCourseRepository) scanCourse(row *sql.Row) (*entities.Course, error) {
 	var course entities.Course
-	var thumbnailID sql.NullString
+	var thumbnailID, organizationID sql.NullString
 	err := row.Scan(
 		&course.ID,
 		&course.Name,
@@ -166,6 +175,7 @@ func (r *PostgresCourseRepository) scanCourse(row *sql.Row) (*entities.Course, e
 		&thumbnailID,
 		&course.CreatedAt,
 		&course.UpdatedAt,
+		&organizationID,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -176,12 +186,15 @@ func (r *PostgresCourseRepository) scanCourse(row *sql.Row) (*entities.Course, e
 	if thumbnailID.Valid {
 		course.ThumbnailID = &thumbnailID.String
 	}
+	if organizationID.Valid {
+		course.OrganizationID = &organizationID.String
+	}
 	return &course, nil
 }
 
 func (r *PostgresCourseRepository) scanCourseRow(rows *sql.Rows) (*entities.Course, error) {
 	var course entities.Course
-	var thumbnailID sql.NullString
+	var thumbnailID, organizationID sql.NullString
 	err := rows.Scan(
 		&course.ID,
 		&course.Name,
@@ -189,6 +202,7 @@ func (r *PostgresCourseRepository) scanCourseRow(rows *sql.Rows) (*entities.Cour
 		&thumbnailID,
 		&course.CreatedAt,
 		&course.UpdatedAt,
+		&organizationID,
 	)
 	if err != nil {
 		return nil, err
@@ -196,6 +210,9 @@ func (r *PostgresCourseRepository) scanCourseRow(rows *sql.Rows) (*entities.Cour
 	if thumbnailID.Valid {
 		course.ThumbnailID = &thumbnailID.String
 	}
+	if organizationID.Valid {
+		course.OrganizationID = &organizationID.String
+	}
 	return &course, nil
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresCourseSectionRepository struct {
@@ -39,8 +40,9 @@ func (r *PostgresCourseSectionRepository) FindByID(ctx context.Context, id strin
 		SELECT id, course_offering_id, name, description, "order", status, created_at, updated_at
 		FROM course_section
 		WHERE id = $1
+			AND ($2::uuid IS NULL OR course_offering_id IN (SELECT id FROM course_offering WHERE organization_id = $2))
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanCourseSection(row)
 }
 
@@ -49,9 +51,10 @@ func (r *PostgresCourseSectionRepository) FindByOfferingID(ctx context.Context,
 		SELECT id, course_offering_id, name, description, "order", status, created_at, updated_at
 		FROM course_section
 		WHERE course_offering_id = $1
+			AND ($2::uuid IS NULL OR course_offering_id IN (SELECT id FROM course_offering WHERE organization_id = $2))
 		ORDER BY "order" ASC, created_at ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, offeringID)
+	rows, err := r.db.QueryContext(ctx, query, offeringID, tenant.Arg(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+)
+
+const offeringEnrollmentApproved = "approved"
+
+type PostgresOfferingEnrollmentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOfferingEnrollmentRepository(db *sql.DB) repositories.OfferingEnrollmentRepository {
+	return &PostgresOfferingEnrollmentRepository{db: db}
+}
+
+// Save is idempotent, as enrollment events may be delivered more than once.
+func (r *PostgresOfferingEnrollmentRepository) Save(ctx context.Context, enrollmentID, courseOfferingID, studentID, status string) error {
+	query := `
+		INSERT INTO offering_enrollment (enrollment_id, course_offering_id, student_id, status)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (enrollment_id) DO UPDATE
+		SET course_offering_id = EXCLUDED.course_offering_id,
+			student_id = EXCLUDED.student_id,
+			status = EXCLUDED.status,
+			updated_at = NOW()
+	`
+	_, err := r.db.ExecContext(ctx, query, enrollmentID, courseOfferingID, studentID, status)
+	return err
+}
+
+func (r *PostgresOfferingEnrollmentRepository) Delete(ctx context.Context, enrollmentID string) error {
+	query := `DELETE FROM offering_enrollment WHERE enrollment_id = $1`
+	_, err := r.db.ExecContext(ctx, query, enrollmentID)
+	return err
+}
+
+func (r *PostgresOfferingEnrollmentRepository) IsEnrolled(ctx context.Context, courseOfferingID, studentID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM offering_enrollment
+			WHERE course_offering_id = $1 AND student_id = $2 AND status = $3
+		)
+	`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, courseOfferingID, studentID, offeringEnrollmentApproved).Scan(&exists)
+	return exists, err
+}
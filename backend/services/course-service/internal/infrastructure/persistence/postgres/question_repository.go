@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+const questionColumns = `id, course_id, question_type, prompt, points, options, correct_option_ids, accepted_answers, numeric_answer, numeric_tolerance, created_at, updated_at`
+
+type PostgresQuestionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQuestionRepository(db *sql.DB) repositories.QuestionRepository {
+	return &PostgresQuestionRepository{db: db}
+}
+
+func (r *PostgresQuestionRepository) Create(ctx context.Context, question *entities.Question) error {
+	options, correctOptionIDs, acceptedAnswers, err := marshalAnswerKey(question)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO question (` + questionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		question.ID,
+		question.CourseID,
+		question.Type,
+		question.Prompt,
+		question.Points,
+		options,
+		correctOptionIDs,
+		acceptedAnswers,
+		question.NumericAnswer,
+		question.NumericTolerance,
+		question.CreatedAt,
+		question.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresQuestionRepository) FindByID(ctx context.Context, id string) (*entities.Question, error) {
+	query := `
+		SELECT ` + questionColumns + `
+		FROM question
+		WHERE id = $1
+			AND ($2::uuid IS NULL OR course_id IN (SELECT id FROM course WHERE organization_id = $2))
+	`
+	question, err := scanQuestion(r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return question, err
+}
+
+func (r *PostgresQuestionRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Question, error) {
+	if len(ids) == 0 {
+		return []*entities.Question{}, nil
+	}
+	idsJSON, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT ` + questionColumns + `
+		FROM question
+		WHERE id::text IN (SELECT jsonb_array_elements_text($1::jsonb))
+			AND ($2::uuid IS NULL OR course_id IN (SELECT id FROM course WHERE organization_id = $2))
+	`
+	return r.queryQuestions(ctx, query, idsJSON, tenant.Arg(ctx))
+}
+
+func (r *PostgresQuestionRepository) FindByCourseID(ctx context.Context, courseID string) ([]*entities.Question, error) {
+	query := `
+		SELECT ` + questionColumns + `
+		FROM question
+		WHERE course_id = $1
+			AND ($2::uuid IS NULL OR course_id IN (SELECT id FROM course WHERE organization_id = $2))
+		ORDER BY created_at ASC
+	`
+	return r.queryQuestions(ctx, query, courseID, tenant.Arg(ctx))
+}
+
+func (r *PostgresQuestionRepository) Update(ctx context.Context, question *entities.Question) error {
+	options, correctOptionIDs, acceptedAnswers, err := marshalAnswerKey(question)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE question
+		SET prompt = $1, points = $2, options = $3, correct_option_ids = $4, accepted_answers = $5,
+			numeric_answer = $6, numeric_tolerance = $7, updated_at = $8
+		WHERE id = $9
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		question.Prompt,
+		question.Points,
+		options,
+		correctOptionIDs,
+		acceptedAnswers,
+		question.NumericAnswer,
+		question.NumericTolerance,
+		question.UpdatedAt,
+		question.ID,
+	)
+	return err
+}
+
+func (r *PostgresQuestionRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM question WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *PostgresQuestionRepository) queryQuestions(ctx context.Context, query string, args ...interface{}) ([]*entities.Question, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []*entities.Question{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	return questions, rows.Err()
+}
+
+func marshalAnswerKey(question *entities.Question) (options, correctOptionIDs, acceptedAnswers []byte, err error) {
+	if options, err = marshalJSONArray(question.Options); err != nil {
+		return nil, nil, nil, err
+	}
+	if correctOptionIDs, err = marshalJSONArray(question.CorrectOptionIDs); err != nil {
+		return nil, nil, nil, err
+	}
+	if acceptedAnswers, err = marshalJSONArray(question.AcceptedAnswers); err != nil {
+		return nil, nil, nil, err
+	}
+	return options, correctOptionIDs, acceptedAnswers, nil
+}
+
+// marshalJSONArray marshals a slice, storing nil as an empty array rather
+// than null.
+func marshalJSONArray[T any](values []T) ([]byte, error) {
+	if values == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(values)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanQuestion(row rowScanner) (*entities.Question, error) {
+	var question entities.Question
+	var options, correctOptionIDs, acceptedAnswers []byte
+	var numericAnswer sql.NullFloat64
+	err := row.Scan(
+		&question.ID,
+		&question.CourseID,
+		&question.Type,
+		&question.Prompt,
+		&question.Points,
+		&options,
+		&correctOptionIDs,
+		&acceptedAnswers,
+		&numericAnswer,
+		&question.NumericTolerance,
+		&question.CreatedAt,
+		&question.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(options, &question.Options); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(correctOptionIDs, &question.CorrectOptionIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(acceptedAnswers, &question.AcceptedAnswers); err != nil {
+		return nil, err
+	}
+	if numericAnswer.Valid {
+		question.NumericAnswer = &numericAnswer.Float64
+	}
+	return &question, nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+const quizAttemptColumns = `id, quiz_id, student_id, attempt_number, status, question_ids, option_order, answers, results, score, max_score, started_at, expires_at, submitted_at`
+
+type PostgresQuizAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQuizAttemptRepository(db *sql.DB) repositories.QuizAttemptRepository {
+	return &PostgresQuizAttemptRepository{db: db}
+}
+
+func (r *PostgresQuizAttemptRepository) Create(ctx context.Context, attempt *entities.QuizAttempt) error {
+	questionIDs, optionOrder, answers, results, err := marshalAttempt(attempt)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO quiz_attempt (` + quizAttemptColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		attempt.ID,
+		attempt.QuizID,
+		attempt.StudentID,
+		attempt.AttemptNumber,
+		attempt.Status,
+		questionIDs,
+		optionOrder,
+		answers,
+		results,
+		attempt.Score,
+		attempt.MaxScore,
+		attempt.StartedAt,
+		attempt.ExpiresAt,
+		attempt.SubmittedAt,
+	)
+	return err
+}
+
+func (r *PostgresQuizAttemptRepository) FindByID(ctx context.Context, id string) (*entities.QuizAttempt, error) {
+	query := `
+		SELECT ` + quizAttemptColumns + `
+		FROM quiz_attempt
+		WHERE id = $1
+			AND ($2::uuid IS NULL OR quiz_id IN (
+				SELECT q.id FROM quiz q
+				JOIN course c ON c.id = q.course_id
+				WHERE c.organization_id = $2
+			))
+	`
+	attempt, err := scanQuizAttempt(r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return attempt, err
+}
+
+func (r *PostgresQuizAttemptRepository) FindByQuizID(ctx context.Context, quizID string) ([]*entities.QuizAttempt, error) {
+	query := `
+		SELECT ` + quizAttemptColumns + `
+		FROM quiz_attempt
+		WHERE quiz_id = $1
+			AND ($2::uuid IS NULL OR quiz_id IN (
+				SELECT q.id FROM quiz q
+				JOIN course c ON c.id = q.course_id
+				WHERE c.organization_id = $2
+			))
+		ORDER BY student_id ASC, attempt_number ASC
+	`
+	return r.queryAttempts(ctx, query, quizID, tenant.Arg(ctx))
+}
+
+func (r *PostgresQuizAttemptRepository) FindByQuizAndStudent(ctx context.Context, quizID, studentID string) ([]*entities.QuizAttempt, error) {
+	query := `
+		SELECT ` + quizAttemptColumns + `
+		FROM quiz_attempt
+		WHERE quiz_id = $1 AND student_id = $2
+			AND ($3::uuid IS NULL OR quiz_id IN (
+				SELECT q.id FROM quiz q
+				JOIN course c ON c.id = q.course_id
+				WHERE c.organization_id = $3
+			))
+		ORDER BY attempt_number ASC
+	`
+	return r.queryAttempts(ctx, query, quizID, studentID, tenant.Arg(ctx))
+}
+
+// Update only changes an attempt that is still in progress, so two requests
+// closing the same attempt cannot both grade it. The one that loses gets
+// entities.ErrQuizAttemptClosed.
+func (r *PostgresQuizAttemptRepository) Update(ctx context.Context, attempt *entities.QuizAttempt) error {
+	_, _, answers, results, err := marshalAttempt(attempt)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE quiz_attempt
+		SET status = $1, answers = $2, results = $3, score = $4, max_score = $5, submitted_at = $6
+		WHERE id = $7 AND status = 'in_progress'
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		attempt.Status,
+		answers,
+		results,
+		attempt.Score,
+		attempt.MaxScore,
+		attempt.SubmittedAt,
+		attempt.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrQuizAttemptClosed
+	}
+	return nil
+}
+
+func (r *PostgresQuizAttemptRepository) DeleteByStudentID(ctx context.Context, studentID string) (int64, error) {
+	query := `DELETE FROM quiz_attempt WHERE student_id = $1`
+	result, err := r.db.ExecContext(ctx, query, studentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *PostgresQuizAttemptRepository) queryAttempts(ctx context.Context, query string, args ...interface{}) ([]*entities.QuizAttempt, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []*entities.QuizAttempt{}
+	for rows.Next() {
+		attempt, err := scanQuizAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, rows.Err()
+}
+
+func marshalAttempt(attempt *entities.QuizAttempt) (questionIDs, optionOrder, answers, results []byte, err error) {
+	if questionIDs, err = marshalJSONArray(attempt.QuestionIDs); err != nil {
+		return
+	}
+	if results, err = marshalJSONArray(attempt.Results); err != nil {
+		return
+	}
+	if optionOrder, err = json.Marshal(attempt.OptionOrder); err != nil {
+		return
+	}
+	answers, err = json.Marshal(attempt.Answers)
+	return
+}
+
+func scanQuizAttempt(row rowScanner) (*entities.QuizAttempt, error) {
+	var attempt entities.QuizAttempt
+	var questionIDs, optionOrder, answers, results []byte
+	var expiresAt, submittedAt sql.NullTime
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.QuizID,
+		&attempt.StudentID,
+		&attempt.AttemptNumber,
+		&attempt.Status,
+		&questionIDs,
+		&optionOrder,
+		&answers,
+		&results,
+		&attempt.Score,
+		&attempt.MaxScore,
+		&attempt.StartedAt,
+		&expiresAt,
+		&submittedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(questionIDs, &attempt.QuestionIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(optionOrder, &attempt.OptionOrder); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(answers, &attempt.Answers); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(results, &attempt.Results); err != nil {
+		return nil, err
+	}
+	if attempt.OptionOrder == nil {
+		attempt.OptionOrder = map[string][]string{}
+	}
+	if attempt.Answers == nil {
+		attempt.Answers = map[string]entities.QuestionAnswer{}
+	}
+	if expiresAt.Valid {
+		attempt.ExpiresAt = &expiresAt.Time
+	}
+	if submittedAt.Valid {
+		attempt.SubmittedAt = &submittedAt.Time
+	}
+	return &attempt, nil
+}
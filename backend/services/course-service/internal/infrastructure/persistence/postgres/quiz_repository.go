@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+const quizColumns = `id, section_module_id, course_id, question_ids, time_limit_minutes, max_attempts, shuffle_questions, shuffle_options, results_visibility, closes_at, created_at, updated_at`
+
+type PostgresQuizRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQuizRepository(db *sql.DB) repositories.QuizRepository {
+	return &PostgresQuizRepository{db: db}
+}
+
+func (r *PostgresQuizRepository) Create(ctx context.Context, quiz *entities.Quiz) error {
+	questionIDs, err := marshalJSONArray(quiz.QuestionIDs)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO quiz (` + quizColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		quiz.ID,
+		quiz.SectionModuleID,
+		quiz.CourseID,
+		questionIDs,
+		quiz.TimeLimitMinutes,
+		quiz.MaxAttempts,
+		quiz.ShuffleQuestions,
+		quiz.ShuffleOptions,
+		quiz.ResultsVisibility,
+		quiz.ClosesAt,
+		quiz.CreatedAt,
+		quiz.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresQuizRepository) FindByID(ctx context.Context, id string) (*entities.Quiz, error) {
+	query := `
+		SELECT ` + quizColumns + `
+		FROM quiz
+		WHERE id = $1
+			AND ($2::uuid IS NULL OR course_id IN (SELECT id FROM course WHERE organization_id = $2))
+	`
+	return r.findOne(ctx, query, id)
+}
+
+func (r *PostgresQuizRepository) FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Quiz, error) {
+	query := `
+		SELECT ` + quizColumns + `
+		FROM quiz
+		WHERE section_module_id = $1
+			AND ($2::uuid IS NULL OR course_id IN (SELECT id FROM course WHERE organization_id = $2))
+	`
+	return r.findOne(ctx, query, sectionModuleID)
+}
+
+func (r *PostgresQuizRepository) CountByQuestionID(ctx context.Context, questionID string) (int, error) {
+	query := `SELECT COUNT(*) FROM quiz WHERE question_ids ? $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, questionID).Scan(&count)
+	return count, err
+}
+
+func (r *PostgresQuizRepository) Update(ctx context.Context, quiz *entities.Quiz) error {
+	questionIDs, err := marshalJSONArray(quiz.QuestionIDs)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE quiz
+		SET question_ids = $1, time_limit_minutes = $2, max_attempts = $3, shuffle_questions = $4,
+			shuffle_options = $5, results_visibility = $6, closes_at = $7, updated_at = $8
+		WHERE id = $9
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		questionIDs,
+		quiz.TimeLimitMinutes,
+		quiz.MaxAttempts,
+		quiz.ShuffleQuestions,
+		quiz.ShuffleOptions,
+		quiz.ResultsVisibility,
+		quiz.ClosesAt,
+		quiz.UpdatedAt,
+		quiz.ID,
+	)
+	return err
+}
+
+func (r *PostgresQuizRepository) findOne(ctx context.Context, query, id string) (*entities.Quiz, error) {
+	var quiz entities.Quiz
+	var questionIDs []byte
+	var timeLimitMinutes, maxAttempts sql.NullInt64
+	var closesAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx)).Scan(
+		&quiz.ID,
+		&quiz.SectionModuleID,
+		&quiz.CourseID,
+		&questionIDs,
+		&timeLimitMinutes,
+		&maxAttempts,
+		&quiz.ShuffleQuestions,
+		&quiz.ShuffleOptions,
+		&quiz.ResultsVisibility,
+		&closesAt,
+		&quiz.CreatedAt,
+		&quiz.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(questionIDs, &quiz.QuestionIDs); err != nil {
+		return nil, err
+	}
+	if timeLimitMinutes.Valid {
+		minutes := int(timeLimitMinutes.Int64)
+		quiz.TimeLimitMinutes = &minutes
+	}
+	if maxAttempts.Valid {
+		attempts := int(maxAttempts.Int64)
+		quiz.MaxAttempts = &attempts
+	}
+	if closesAt.Valid {
+		quiz.ClosesAt = &closesAt.Time
+	}
+	return &quiz, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresSectionModuleRepository struct {
@@ -18,13 +19,15 @@ func NewPostgresSectionModuleRepository(db *sql.DB) repositories.SectionModuleRe
 
 func (r *PostgresSectionModuleRepository) Create(ctx context.Context, module *entities.SectionModule) error {
 	query := `
-		INSERT INTO section_module (id, course_section_id, content_id, name, description, content_type, content_status, "order", created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO section_module (id, course_section_id, content_id, content_body, content_url, name, description, content_type, content_status, "order", created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		module.ID,
 		module.CourseSectionID,
 		module.ContentID,
+		module.ContentBody,
+		module.ContentURL,
 		module.Name,
 		module.Description,
 		module.ContentType,
@@ -38,22 +41,32 @@ func (r *PostgresSectionModuleRepository) Create(ctx context.Context, module *en
 
 func (r *PostgresSectionModuleRepository) FindByID(ctx context.Context, id string) (*entities.SectionModule, error) {
 	query := `
-		SELECT id, course_section_id, content_id, name, description, content_type, content_status, "order", created_at, updated_at
+		SELECT id, course_section_id, content_id, content_body, content_url, name, description, content_type, content_status, "order", created_at, updated_at
 		FROM section_module
 		WHERE id = $1
+			AND ($2::uuid IS NULL OR course_section_id IN (
+				SELECT s.id FROM course_section s
+				JOIN course_offering o ON o.id = s.course_offering_id
+				WHERE o.organization_id = $2
+			))
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanSectionModule(row)
 }
 
 func (r *PostgresSectionModuleRepository) FindBySectionID(ctx context.Context, sectionID string) ([]*entities.SectionModule, error) {
 	query := `
-		SELECT id, course_section_id, content_id, name, description, content_type, content_status, "order", created_at, updated_at
+		SELECT id, course_section_id, content_id, content_body, content_url, name, description, content_type, content_status, "order", created_at, updated_at
 		FROM section_module
 		WHERE course_section_id = $1
+			AND ($2::uuid IS NULL OR course_section_id IN (
+				SELECT s.id FROM course_section s
+				JOIN course_offering o ON o.id = s.course_offering_id
+				WHERE o.organization_id = $2
+			))
 		ORDER BY "order" ASC, created_at ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, sectionID)
+	rows, err := r.db.QueryContext(ctx, query, sectionID, tenant.Arg(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -74,13 +87,15 @@ func (r *PostgresSectionModuleRepository) FindBySectionID(ctx context.Context, s
 func (r *PostgresSectionModuleRepository) Update(ctx context.Context, module *entities.SectionModule) error {
 	query := `
 		UPDATE section_module
-		SET name = $1, description = $2, content_id = $3, content_type = $4, content_status = $5, "order" = $6, updated_at = $7
-		WHERE id = $8
+		SET name = $1, description = $2, content_id = $3, content_body = $4, content_url = $5, content_type = $6, content_status = $7, "order" = $8, updated_at = $9
+		WHERE id = $10
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		module.Name,
 		module.Description,
 		module.ContentID,
+		module.ContentBody,
+		module.ContentURL,
 		module.ContentType,
 		module.ContentStatus,
 		module.Order,
@@ -104,11 +119,13 @@ func (r *PostgresSectionModuleRepository) DeleteBySectionID(ctx context.Context,
 
 func (r *PostgresSectionModuleRepository) scanSectionModule(row *sql.Row) (*entities.SectionModule, error) {
 	var module entities.SectionModule
-	var contentID sql.NullString
+	var contentID, contentBody, contentURL sql.NullString
 	err := row.Scan(
 		&module.ID,
 		&module.CourseSectionID,
 		&contentID,
+		&contentBody,
+		&contentURL,
 		&module.Name,
 		&module.Description,
 		&module.ContentType,
@@ -126,16 +143,24 @@ func (r *PostgresSectionModuleRepository) scanSectionModule(row *sql.Row) (*enti
 	if contentID.Valid {
 		module.ContentID = &contentID.String
 	}
+	if contentBody.Valid {
+		module.ContentBody = &contentBody.String
+	}
+	if contentURL.Valid {
+		module.ContentURL = &contentURL.String
+	}
 	return &module, nil
 }
 
 func (r *PostgresSectionModuleRepository) scanSectionModuleRow(rows *sql.Rows) (*entities.SectionModule, error) {
 	var module entities.SectionModule
-	var contentID sql.NullString
+	var contentID, contentBody, contentURL sql.NullString
 	err := rows.Scan(
 		&module.ID,
 		&module.CourseSectionID,
 		&contentID,
+		&contentBody,
+		&contentURL,
 		&module.Name,
 		&module.Description,
 		&module.ContentType,
@@ -150,6 +175,12 @@ func (r *PostgresSectionModuleRepository) scanSectionModuleRow(rows *sql.Rows) (
 	if contentID.Valid {
 		module.ContentID = &contentID.String
 	}
+	if contentBody.Valid {
+		module.ContentBody = &contentBody.String
+	}
+	if contentURL.Valid {
+		module.ContentURL = &contentURL.String
+	}
 	return &module, nil
 }
 
@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+)
+
+type AssignmentHandler struct {
+	createAssignmentUseCase *usecases.CreateAssignmentUseCase
+	updateAssignmentUseCase *usecases.UpdateAssignmentUseCase
+	getAssignmentUseCase    *usecases.GetAssignmentUseCase
+	submitUseCase           *usecases.SubmitAssignmentUseCase
+	findSubmissionsUseCase  *usecases.FindAssignmentSubmissionsUseCase
+	gradeSubmissionUseCase  *usecases.GradeAssignmentSubmissionUseCase
+	logger                  *logger.Logger
+}
+
+func NewAssignmentHandler(
+	createAssignmentUseCase *usecases.CreateAssignmentUseCase,
+	updateAssignmentUseCase *usecases.UpdateAssignmentUseCase,
+	getAssignmentUseCase *usecases.GetAssignmentUseCase,
+	submitUseCase *usecases.SubmitAssignmentUseCase,
+	findSubmissionsUseCase *usecases.FindAssignmentSubmissionsUseCase,
+	gradeSubmissionUseCase *usecases.GradeAssignmentSubmissionUseCase,
+	logger *logger.Logger,
+) *AssignmentHandler {
+	return &AssignmentHandler{
+		createAssignmentUseCase: createAssignmentUseCase,
+		updateAssignmentUseCase: updateAssignmentUseCase,
+		getAssignmentUseCase:    getAssignmentUseCase,
+		submitUseCase:           submitUseCase,
+		findSubmissionsUseCase:  findSubmissionsUseCase,
+		gradeSubmissionUseCase:  gradeSubmissionUseCase,
+		logger:                  logger,
+	}
+}
+
+// CreateAssignment godoc
+// @Summary Create the assignment of an assignment module
+// @Description Create the assignment of a section module with content type assignment. late_policy is one of accept (the default), penalize or block; penalize and block need due_at, and late_penalty_percent only applies to penalize.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param assignment body dtos.CreateAssignmentInput true "Assignment settings"
+// @Success 201 {object} dtos.AssignmentDTO "Assignment created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or settings"
+// @Failure 404 {object} map[string]interface{} "Section module not found"
+// @Failure 409 {object} map[string]interface{} "Section module already has an assignment"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments [post]
+func (h *AssignmentHandler) CreateAssignment(c *gin.Context) {
+	var input dtos.CreateAssignmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := uuid.Parse(input.SectionModuleID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "section module not found")
+		return
+	}
+
+	result, err := h.createAssignmentUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == usecases.ErrSectionModuleNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == usecases.ErrAssignmentAlreadyExists {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		if err == usecases.ErrNotAssignmentModule || isInvalidAssignment(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to create assignment: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetAssignment godoc
+// @Summary Get an assignment
+// @Description Retrieve the instructions, due date and submission rules of an assignment
+// @Tags assignments
+// @Produce json
+// @Security BearerAuth
+// @Param assignment_id path string true "Assignment ID" Format(uuid)
+// @Success 200 {object} dtos.AssignmentDTO "Assignment retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Assignment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments/{assignment_id} [get]
+func (h *AssignmentHandler) GetAssignment(c *gin.Context) {
+	assignmentID, ok := assignmentParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getAssignmentUseCase.Execute(c.Request.Context(), assignmentID)
+	if err != nil {
+		if err == usecases.ErrAssignmentNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to get assignment: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateAssignment godoc
+// @Summary Update an assignment
+// @Description Replace the settings of an assignment. Submissions already made keep their late flag and penalty.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param assignment_id path string true "Assignment ID" Format(uuid)
+// @Param assignment body dtos.UpdateAssignmentInput true "Assignment settings"
+// @Success 200 {object} dtos.AssignmentDTO "Assignment updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or settings"
+// @Failure 404 {object} map[string]interface{} "Assignment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments/{assignment_id} [put]
+func (h *AssignmentHandler) UpdateAssignment(c *gin.Context) {
+	assignmentID, ok := assignmentParam(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.UpdateAssignmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.updateAssignmentUseCase.Execute(c.Request.Context(), assignmentID, input)
+	if err != nil {
+		if err == usecases.ErrAssignmentNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if isInvalidAssignment(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to update assignment: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SubmitAssignment godoc
+// @Summary Submit an assignment
+// @Description Hand in text, files uploaded to the assignment-submissions bucket of file-service, or both. Each call is a new submission; earlier ones are kept. Late submissions are refused or penalized according to the assignment's late policy.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param assignment_id path string true "Assignment ID" Format(uuid)
+// @Param submission body dtos.SubmitAssignmentInput true "Submission"
+// @Success 201 {object} dtos.AssignmentSubmissionDTO "Assignment submitted"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or submission"
+// @Failure 404 {object} map[string]interface{} "Assignment not found"
+// @Failure 409 {object} map[string]interface{} "Assignment is past due or no submissions are left"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments/{assignment_id}/submissions [post]
+func (h *AssignmentHandler) SubmitAssignment(c *gin.Context) {
+	assignmentID, ok := assignmentParam(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.SubmitAssignmentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.submitUseCase.Execute(c.Request.Context(), assignmentID, c.GetHeader("X-User-ID"), c.GetHeader("X-User-Email"), c.GetHeader("Authorization"), input)
+	if err != nil {
+		if err == usecases.ErrAssignmentNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == entities.ErrAssignmentPastDue || err == usecases.ErrSubmissionLimitReached {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		if err == entities.ErrEmptySubmission || errors.Is(err, entities.ErrInvalidSubmission) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to submit assignment: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// FindAssignmentSubmissions godoc
+// @Summary List the submissions for an assignment
+// @Description List every student's submissions for an assignment, by student and submission number
+// @Tags assignments
+// @Produce json
+// @Security BearerAuth
+// @Param assignment_id path string true "Assignment ID" Format(uuid)
+// @Success 200 {array} dtos.AssignmentSubmissionDTO "Submissions retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Assignment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments/{assignment_id}/submissions [get]
+func (h *AssignmentHandler) FindAssignmentSubmissions(c *gin.Context) {
+	h.findSubmissions(c, "")
+}
+
+// FindMyAssignmentSubmissions godoc
+// @Summary List your submissions for an assignment
+// @Description List the student's own submissions for an assignment with their grades and feedback
+// @Tags assignments
+// @Produce json
+// @Security BearerAuth
+// @Param assignment_id path string true "Assignment ID" Format(uuid)
+// @Success 200 {array} dtos.AssignmentSubmissionDTO "Submissions retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Assignment not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignments/{assignment_id}/submissions/me [get]
+func (h *AssignmentHandler) FindMyAssignmentSubmissions(c *gin.Context) {
+	h.findSubmissions(c, c.GetHeader("X-User-ID"))
+}
+
+func (h *AssignmentHandler) findSubmissions(c *gin.Context, studentID string) {
+	assignmentID, ok := assignmentParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.findSubmissionsUseCase.Execute(c.Request.Context(), assignmentID, studentID)
+	if err != nil {
+		if err == usecases.ErrAssignmentNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to find assignment submissions: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GradeAssignmentSubmission godoc
+// @Summary Grade an assignment submission
+// @Description Grade a submission out of the assignment's max score, with optional feedback. Late penalties are deducted automatically and grading again replaces the earlier grade. The student is emailed the result.
+// @Tags assignments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param submission_id path string true "Assignment Submission ID" Format(uuid)
+// @Param grade body dtos.GradeAssignmentSubmissionInput true "Grade"
+// @Success 200 {object} dtos.AssignmentSubmissionDTO "Submission graded"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or score"
+// @Failure 404 {object} map[string]interface{} "Submission not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /assignment-submissions/{submission_id}/grade [put]
+func (h *AssignmentHandler) GradeAssignmentSubmission(c *gin.Context) {
+	submissionID := c.Param("submission_id")
+	if _, err := uuid.Parse(submissionID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "assignment submission not found")
+		return
+	}
+
+	var input dtos.GradeAssignmentSubmissionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.gradeSubmissionUseCase.Execute(c.Request.Context(), submissionID, c.GetHeader("X-User-ID"), input)
+	if err != nil {
+		if err == usecases.ErrAssignmentSubmissionNotFound || err == usecases.ErrAssignmentNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == entities.ErrInvalidScore {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to grade assignment submission: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func assignmentParam(c *gin.Context) (string, bool) {
+	assignmentID := c.Param("assignment_id")
+	if _, err := uuid.Parse(assignmentID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "assignment not found")
+		return "", false
+	}
+	return assignmentID, true
+}
+
+func isInvalidAssignment(err error) bool {
+	switch err {
+	case entities.ErrAssignmentSubmissionTypeRequired,
+		entities.ErrInvalidAssignmentMaxScore,
+		entities.ErrInvalidMaxSubmissions,
+		entities.ErrInvalidLatePolicy,
+		entities.ErrInvalidLatePenalty,
+		entities.ErrAssignmentDueRequired:
+		return true
+	}
+	return false
+}
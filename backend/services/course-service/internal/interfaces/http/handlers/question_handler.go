@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+)
+
+type QuestionHandler struct {
+	createQuestionUseCase *usecases.CreateQuestionUseCase
+	updateQuestionUseCase *usecases.UpdateQuestionUseCase
+	deleteQuestionUseCase *usecases.DeleteQuestionUseCase
+	findQuestionsUseCase  *usecases.FindQuestionsUseCase
+	logger                *logger.Logger
+}
+
+func NewQuestionHandler(
+	createQuestionUseCase *usecases.CreateQuestionUseCase,
+	updateQuestionUseCase *usecases.UpdateQuestionUseCase,
+	deleteQuestionUseCase *usecases.DeleteQuestionUseCase,
+	findQuestionsUseCase *usecases.FindQuestionsUseCase,
+	logger *logger.Logger,
+) *QuestionHandler {
+	return &QuestionHandler{
+		createQuestionUseCase: createQuestionUseCase,
+		updateQuestionUseCase: updateQuestionUseCase,
+		deleteQuestionUseCase: deleteQuestionUseCase,
+		findQuestionsUseCase:  findQuestionsUseCase,
+		logger:                logger,
+	}
+}
+
+// CreateQuestion godoc
+// @Summary Add a question to a course's question bank
+// @Description Add a multiple choice, multi-select, true/false, short answer or numeric question, with its answer key, to the question bank quizzes of the course draw from.
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param course_id path string true "Course ID" Format(uuid)
+// @Param question body dtos.CreateQuestionInput true "Question and answer key"
+// @Success 201 {object} dtos.QuestionDTO "Question created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, question type or answer key"
+// @Failure 404 {object} map[string]interface{} "Course not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /question-banks/{course_id}/questions [post]
+func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
+	courseID := c.Param("course_id")
+	if _, err := uuid.Parse(courseID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "course not found")
+		return
+	}
+
+	var input dtos.CreateQuestionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.createQuestionUseCase.Execute(c.Request.Context(), courseID, input)
+	if err != nil {
+		if err == usecases.ErrCourseNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if isInvalidQuestion(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to create question: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// FindQuestions godoc
+// @Summary List a course's question bank
+// @Description List the questions of a course's question bank with their answer keys
+// @Tags question-banks
+// @Produce json
+// @Security BearerAuth
+// @Param course_id path string true "Course ID" Format(uuid)
+// @Success 200 {array} dtos.QuestionDTO "Questions retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid course ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /question-banks/{course_id}/questions [get]
+func (h *QuestionHandler) FindQuestions(c *gin.Context) {
+	courseID := c.Param("course_id")
+	if _, err := uuid.Parse(courseID); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "invalid course id")
+		return
+	}
+
+	result, err := h.findQuestionsUseCase.Execute(c.Request.Context(), courseID)
+	if err != nil {
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to find questions: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateQuestion godoc
+// @Summary Update a question bank question
+// @Description Replace the prompt, points and answer key of a question. Its type cannot change. Submitted attempts keep their grades.
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param course_id path string true "Course ID" Format(uuid)
+// @Param question_id path string true "Question ID" Format(uuid)
+// @Param question body dtos.UpdateQuestionInput true "Question and answer key"
+// @Success 200 {object} dtos.QuestionDTO "Question updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or answer key"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /question-banks/{course_id}/questions/{question_id} [put]
+func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
+	courseID, questionID, ok := questionParams(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.UpdateQuestionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.updateQuestionUseCase.Execute(c.Request.Context(), courseID, questionID, input)
+	if err != nil {
+		if err == usecases.ErrQuestionNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if isInvalidQuestion(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to update question: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteQuestion godoc
+// @Summary Delete a question bank question
+// @Description Delete a question that no quiz uses
+// @Tags question-banks
+// @Produce json
+// @Security BearerAuth
+// @Param course_id path string true "Course ID" Format(uuid)
+// @Param question_id path string true "Question ID" Format(uuid)
+// @Success 200 {object} map[string]interface{} "Question deleted successfully"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 409 {object} map[string]interface{} "Question is used by a quiz"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /question-banks/{course_id}/questions/{question_id} [delete]
+func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
+	courseID, questionID, ok := questionParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.deleteQuestionUseCase.Execute(c.Request.Context(), courseID, questionID)
+	if err != nil {
+		if err == usecases.ErrQuestionNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == usecases.ErrQuestionInUse {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to delete question: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "question deleted successfully"})
+}
+
+func questionParams(c *gin.Context) (courseID, questionID string, ok bool) {
+	courseID = c.Param("course_id")
+	questionID = c.Param("question_id")
+	if _, err := uuid.Parse(courseID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "question not found")
+		return "", "", false
+	}
+	if _, err := uuid.Parse(questionID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "question not found")
+		return "", "", false
+	}
+	return courseID, questionID, true
+}
+
+func isInvalidQuestion(err error) bool {
+	return err == entities.ErrInvalidQuestionType ||
+		err == entities.ErrQuestionPromptRequired ||
+		err == entities.ErrInvalidQuestionPoints ||
+		errors.Is(err, entities.ErrInvalidAnswerKey)
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+)
+
+type QuizHandler struct {
+	createQuizUseCase    *usecases.CreateQuizUseCase
+	updateQuizUseCase    *usecases.UpdateQuizUseCase
+	getQuizUseCase       *usecases.GetQuizUseCase
+	startAttemptUseCase  *usecases.StartQuizAttemptUseCase
+	submitAttemptUseCase *usecases.SubmitQuizAttemptUseCase
+	saveAnswersUseCase   *usecases.SaveQuizAttemptAnswersUseCase
+	getAttemptUseCase    *usecases.GetQuizAttemptUseCase
+	findAttemptsUseCase  *usecases.FindQuizAttemptsUseCase
+	logger               *logger.Logger
+}
+
+func NewQuizHandler(
+	createQuizUseCase *usecases.CreateQuizUseCase,
+	updateQuizUseCase *usecases.UpdateQuizUseCase,
+	getQuizUseCase *usecases.GetQuizUseCase,
+	startAttemptUseCase *usecases.StartQuizAttemptUseCase,
+	submitAttemptUseCase *usecases.SubmitQuizAttemptUseCase,
+	saveAnswersUseCase *usecases.SaveQuizAttemptAnswersUseCase,
+	getAttemptUseCase *usecases.GetQuizAttemptUseCase,
+	findAttemptsUseCase *usecases.FindQuizAttemptsUseCase,
+	logger *logger.Logger,
+) *QuizHandler {
+	return &QuizHandler{
+		createQuizUseCase:    createQuizUseCase,
+		updateQuizUseCase:    updateQuizUseCase,
+		getQuizUseCase:       getQuizUseCase,
+		startAttemptUseCase:  startAttemptUseCase,
+		submitAttemptUseCase: submitAttemptUseCase,
+		saveAnswersUseCase:   saveAnswersUseCase,
+		getAttemptUseCase:    getAttemptUseCase,
+		findAttemptsUseCase:  findAttemptsUseCase,
+		logger:               logger,
+	}
+}
+
+// CreateQuiz godoc
+// @Summary Create the quiz of a quiz module
+// @Description Create the quiz of a section module with content type quiz, from questions of the course's question bank. results_visibility is one of immediate (the default), score_only, after_close or hidden; after_close needs closes_at.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param quiz body dtos.CreateQuizInput true "Quiz settings"
+// @Success 201 {object} dtos.QuizDTO "Quiz created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, settings or questions"
+// @Failure 404 {object} map[string]interface{} "Section module not found"
+// @Failure 409 {object} map[string]interface{} "Section module already has a quiz"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quizzes [post]
+func (h *QuizHandler) CreateQuiz(c *gin.Context) {
+	var input dtos.CreateQuizInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := uuid.Parse(input.SectionModuleID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "section module not found")
+		return
+	}
+
+	result, err := h.createQuizUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == usecases.ErrSectionModuleNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == usecases.ErrQuizAlreadyExists {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		if err == usecases.ErrNotQuizModule || isInvalidQuiz(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to create quiz: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetQuiz godoc
+// @Summary Get a quiz
+// @Description Retrieve the settings of a quiz. Questions are only shown through attempts.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param quiz_id path string true "Quiz ID" Format(uuid)
+// @Success 200 {object} dtos.QuizDTO "Quiz retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Quiz not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quizzes/{quiz_id} [get]
+func (h *QuizHandler) GetQuiz(c *gin.Context) {
+	quizID, ok := quizParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getQuizUseCase.Execute(c.Request.Context(), quizID)
+	if err != nil {
+		if err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to get quiz: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateQuiz godoc
+// @Summary Update a quiz
+// @Description Replace the questions and settings of a quiz. Attempts already started keep the questions they were given.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param quiz_id path string true "Quiz ID" Format(uuid)
+// @Param quiz body dtos.UpdateQuizInput true "Quiz settings"
+// @Success 200 {object} dtos.QuizDTO "Quiz updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, settings or questions"
+// @Failure 404 {object} map[string]interface{} "Quiz not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quizzes/{quiz_id} [put]
+func (h *QuizHandler) UpdateQuiz(c *gin.Context) {
+	quizID, ok := quizParam(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.UpdateQuizInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.updateQuizUseCase.Execute(c.Request.Context(), quizID, input)
+	if err != nil {
+		if err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if isInvalidQuiz(err) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to update quiz: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// StartQuizAttempt godoc
+// @Summary Start a quiz attempt
+// @Description Start the student's next attempt at a quiz, or return the attempt still in progress. Questions and options come in the attempt's order, without answers.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param quiz_id path string true "Quiz ID" Format(uuid)
+// @Success 201 {object} dtos.QuizAttemptDTO "Quiz attempt started"
+// @Failure 404 {object} map[string]interface{} "Quiz not found"
+// @Failure 409 {object} map[string]interface{} "Quiz is closed or no attempts are left"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quizzes/{quiz_id}/attempts [post]
+func (h *QuizHandler) StartQuizAttempt(c *gin.Context) {
+	quizID, ok := quizParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.startAttemptUseCase.Execute(c.Request.Context(), quizID, c.GetHeader("X-User-ID"))
+	if err != nil {
+		if err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == usecases.ErrQuizClosed || err == usecases.ErrQuizAttemptLimitReached {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to start quiz attempt: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// FindQuizAttempts godoc
+// @Summary List the attempts at a quiz
+// @Description List every student's attempts at a quiz with scores, answers and answer keys
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param quiz_id path string true "Quiz ID" Format(uuid)
+// @Success 200 {array} dtos.QuizAttemptDTO "Quiz attempts retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Quiz not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quizzes/{quiz_id}/attempts [get]
+func (h *QuizHandler) FindQuizAttempts(c *gin.Context) {
+	quizID, ok := quizParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.findAttemptsUseCase.Execute(c.Request.Context(), quizID)
+	if err != nil {
+		if err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to find quiz attempts: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetQuizAttempt godoc
+// @Summary Get one of your quiz attempts
+// @Description Retrieve one of the student's own attempts. The score and correct answers are shown as the quiz's results visibility allows.
+// @Tags quizzes
+// @Produce json
+// @Security BearerAuth
+// @Param attempt_id path string true "Quiz Attempt ID" Format(uuid)
+// @Success 200 {object} dtos.QuizAttemptDTO "Quiz attempt retrieved successfully"
+// @Failure 404 {object} map[string]interface{} "Quiz attempt not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quiz-attempts/{attempt_id} [get]
+func (h *QuizHandler) GetQuizAttempt(c *gin.Context) {
+	attemptID, ok := attemptParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.getAttemptUseCase.Execute(c.Request.Context(), attemptID, c.GetHeader("X-User-ID"))
+	if err != nil {
+		if err == usecases.ErrQuizAttemptNotFound || err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to get quiz attempt: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SaveQuizAttemptAnswers godoc
+// @Summary Save answers to a quiz attempt
+// @Description Save answers while the attempt is in progress, keyed by question ID like a submission. Saved answers are graded even if time runs out before the attempt is submitted.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param attempt_id path string true "Quiz Attempt ID" Format(uuid)
+// @Param answers body dtos.SubmitQuizAttemptInput true "Answers"
+// @Success 200 {object} dtos.QuizAttemptDTO "Answers saved"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 404 {object} map[string]interface{} "Quiz attempt not found"
+// @Failure 409 {object} map[string]interface{} "Quiz attempt already closed"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quiz-attempts/{attempt_id}/answers [put]
+func (h *QuizHandler) SaveQuizAttemptAnswers(c *gin.Context) {
+	attemptID, ok := attemptParam(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.SubmitQuizAttemptInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.saveAnswersUseCase.Execute(c.Request.Context(), attemptID, c.GetHeader("X-User-ID"), input)
+	if err != nil {
+		if err == usecases.ErrQuizAttemptNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == entities.ErrQuizAttemptClosed {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to save quiz attempt answers: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SubmitQuizAttempt godoc
+// @Summary Submit a quiz attempt
+// @Description Submit answers for grading, keyed by question ID: option_ids for choice questions and text for short answer and numeric questions. Answers are added to those already saved. Attempts submitted after their time ran out are closed as expired and only the answers saved in time are graded.
+// @Tags quizzes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param attempt_id path string true "Quiz Attempt ID" Format(uuid)
+// @Param answers body dtos.SubmitQuizAttemptInput true "Answers"
+// @Success 200 {object} dtos.QuizAttemptDTO "Quiz attempt graded"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 404 {object} map[string]interface{} "Quiz attempt not found"
+// @Failure 409 {object} map[string]interface{} "Quiz attempt already submitted"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /quiz-attempts/{attempt_id}/submit [post]
+func (h *QuizHandler) SubmitQuizAttempt(c *gin.Context) {
+	attemptID, ok := attemptParam(c)
+	if !ok {
+		return
+	}
+
+	var input dtos.SubmitQuizAttemptInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.submitAttemptUseCase.Execute(c.Request.Context(), attemptID, c.GetHeader("X-User-ID"), input)
+	if err != nil {
+		if err == usecases.ErrQuizAttemptNotFound || err == usecases.ErrQuizNotFound {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == entities.ErrQuizAttemptClosed {
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to submit quiz attempt: "+err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func quizParam(c *gin.Context) (string, bool) {
+	quizID := c.Param("quiz_id")
+	if _, err := uuid.Parse(quizID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "quiz not found")
+		return "", false
+	}
+	return quizID, true
+}
+
+func attemptParam(c *gin.Context) (string, bool) {
+	attemptID := c.Param("attempt_id")
+	if _, err := uuid.Parse(attemptID); err != nil {
+		middleware.AbortWithError(c, http.StatusNotFound, "quiz attempt not found")
+		return "", false
+	}
+	return attemptID, true
+}
+
+func isInvalidQuiz(err error) bool {
+	switch err {
+	case usecases.ErrQuestionNotInBank,
+		entities.ErrQuizQuestionsRequired,
+		entities.ErrDuplicateQuizQuestion,
+		entities.ErrInvalidQuizTimeLimit,
+		entities.ErrInvalidQuizMaxAttempts,
+		entities.ErrInvalidResultsVisibility,
+		entities.ErrQuizCloseRequired:
+		return true
+	}
+	return false
+}
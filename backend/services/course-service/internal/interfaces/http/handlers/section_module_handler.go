@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -43,7 +44,7 @@ func NewSectionModuleHandler(
 
 // CreateSectionModule godoc
 // @Summary Create a new section module
-// @Description Create a new module for a course section
+// @Description Create a new module for a course section. Video and document modules take the content_id of an uploaded file, text modules a markdown content_body, and link and embed modules a content_url. Zoom and quiz modules take no content; zoom-service attaches the meeting and POST /quizzes attaches the quiz.
 // @Tags section-modules
 // @Accept json
 // @Produce json
@@ -51,7 +52,7 @@ func NewSectionModuleHandler(
 // @Param section_id path string true "Course Section ID"
 // @Param module body dtos.CreateSectionModuleInput true "Section module creation data"
 // @Success 201 {object} dtos.SectionModuleDTO "Section module created successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, content type or content"
 // @Failure 404 {object} map[string]interface{} "Course section not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /course-sections/{section_id}/modules [post]
@@ -78,6 +79,10 @@ func (h *SectionModuleHandler) CreateSectionModule(c *gin.Context) {
 			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, usecases.ErrInvalidContentType) || errors.Is(err, usecases.ErrInvalidModuleContent) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to create section module: "+err.Error())
 		return
 	}
@@ -87,7 +92,7 @@ func (h *SectionModuleHandler) CreateSectionModule(c *gin.Context) {
 
 // UpdateSectionModule godoc
 // @Summary Update a section module
-// @Description Update an existing section module
+// @Description Update an existing section module. Setting any content field replaces the module's content, validated against its content type.
 // @Tags section-modules
 // @Accept json
 // @Produce json
@@ -95,7 +100,7 @@ func (h *SectionModuleHandler) CreateSectionModule(c *gin.Context) {
 // @Param module_id path string true "Section Module ID"
 // @Param module body dtos.UpdateSectionModuleInput true "Section module update data"
 // @Success 200 {object} dtos.SectionModuleDTO "Section module updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or content"
 // @Failure 404 {object} map[string]interface{} "Section module not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /section-modules/{module_id} [put]
@@ -122,6 +127,10 @@ func (h *SectionModuleHandler) UpdateSectionModule(c *gin.Context) {
 			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, usecases.ErrInvalidModuleContent) {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to update section module: "+err.Error())
 		return
 	}
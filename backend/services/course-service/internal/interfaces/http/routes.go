@@ -3,9 +3,14 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/paingphyoaungkhant/asto-microservice/services/course-service/cmd/docs"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/policies"
 	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/interfaces/http/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/policy"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -17,6 +22,13 @@ func SetUpRoutes(
 	courseOfferingHandler *handlers.CourseOfferingHandler,
 	courseSectionHandler *handlers.CourseSectionHandler,
 	sectionModuleHandler *handlers.SectionModuleHandler,
+	questionHandler *handlers.QuestionHandler,
+	quizHandler *handlers.QuizHandler,
+	assignmentHandler *handlers.AssignmentHandler,
+	offeringAccess *policies.OfferingAccess,
+	authenticate gin.HandlerFunc,
+	redis utils.RedisInterface,
+	rateLimit config.RateLimitConfig,
 	logger *logger.Logger,
 ) {
 	router.Use(middleware.RequestID())
@@ -30,15 +42,102 @@ func SetUpRoutes(
 	// Swagger documentation
 	router.GET("/api/v1/courses/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
+	isAdmin := policy.HasRole(valueobjects.RoleAdmin)
+	manageCatalog := policy.Require(policy.Policy{
+		Name: "catalog:manage",
+		Rule: isAdmin,
+	}, logger)
+	// Instructors may only change offerings they are assigned to, and the
+	// sections and modules under them.
+	manageOffering := policy.Require(policy.Policy{
+		Name: "course_offering:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("offering_id"), offeringAccess.TeachesOffering)),
+	}, logger)
+	manageSection := policy.Require(policy.Policy{
+		Name: "course_section:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("section_id"), offeringAccess.TeachesSection)),
+	}, logger)
+	manageModule := policy.Require(policy.Policy{
+		Name: "section_module:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("module_id"), offeringAccess.TeachesModule)),
+	}, logger)
+	// A course's question bank is shared by the instructors of all its
+	// offerings.
+	manageQuestionBank := policy.Require(policy.Policy{
+		Name: "question_bank:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("course_id"), offeringAccess.TeachesCourse)),
+	}, logger)
+	createQuiz := policy.Require(policy.Policy{
+		Name: "quiz:create",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.JSONField("section_module_id"), offeringAccess.TeachesModule)),
+	}, logger)
+	manageQuiz := policy.Require(policy.Policy{
+		Name: "quiz:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("quiz_id"), offeringAccess.TeachesQuiz)),
+	}, logger)
+	// Quizzes are read by the offering's instructors and enrolled students,
+	// taken by the latter, and an attempt is only ever seen by the student who
+	// made it.
+	readQuiz := policy.Require(policy.Policy{
+		Name: "quiz:read",
+		Rule: policy.AnyOf(
+			isAdmin,
+			policy.Owns(valueobjects.RoleInstructor, policy.Param("quiz_id"), offeringAccess.TeachesQuiz),
+			policy.Owns(valueobjects.RoleStudent, policy.Param("quiz_id"), offeringAccess.EnrolledInQuiz),
+		),
+	}, logger)
+	takeQuiz := policy.Require(policy.Policy{
+		Name: "quiz:attempt",
+		Rule: policy.Owns(valueobjects.RoleStudent, policy.Param("quiz_id"), offeringAccess.EnrolledInQuiz),
+	}, logger)
+	ownAttempt := policy.Require(policy.Policy{
+		Name: "quiz_attempt:own",
+		Rule: policy.Owns(valueobjects.RoleStudent, policy.Param("attempt_id"), offeringAccess.EnrolledInAttempt),
+	}, logger)
+	createAssignment := policy.Require(policy.Policy{
+		Name: "assignment:create",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.JSONField("section_module_id"), offeringAccess.TeachesModule)),
+	}, logger)
+	manageAssignment := policy.Require(policy.Policy{
+		Name: "assignment:manage",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("assignment_id"), offeringAccess.TeachesAssignment)),
+	}, logger)
+	// Only instructors of the assignment's offering grade its submissions.
+	gradeSubmission := policy.Require(policy.Policy{
+		Name: "assignment_submission:grade",
+		Rule: policy.AnyOf(isAdmin, policy.Owns(valueobjects.RoleInstructor, policy.Param("submission_id"), offeringAccess.TeachesSubmission)),
+	}, logger)
+	// Like quizzes, assignments are read by the offering's instructors and
+	// enrolled students, and handed in by the latter.
+	readAssignment := policy.Require(policy.Policy{
+		Name: "assignment:read",
+		Rule: policy.AnyOf(
+			isAdmin,
+			policy.Owns(valueobjects.RoleInstructor, policy.Param("assignment_id"), offeringAccess.TeachesAssignment),
+			policy.Owns(valueobjects.RoleStudent, policy.Param("assignment_id"), offeringAccess.EnrolledInAssignment),
+		),
+	}, logger)
+	submitAssignment := policy.Require(policy.Policy{
+		Name: "assignment:submit",
+		Rule: policy.Owns(valueobjects.RoleStudent, policy.Param("assignment_id"), offeringAccess.EnrolledInAssignment),
+	}, logger)
+
 	api := router.Group("/api/v1")
+	api.Use(authenticate)
+	api.Use(middleware.Tenant())
+	api.Use(middleware.RateLimit(redis, middleware.RateLimitPolicy{
+		Name:    "course-api",
+		Config:  rateLimit,
+		KeyFunc: middleware.RateLimitByUser,
+	}, logger))
 	{
 		categoryRoutes := api.Group("/categories")
 		{
 			categoryRoutes.GET("", categoryHandler.FindCategory)
 			categoryRoutes.GET("/:id", categoryHandler.GetCategory)
-			categoryRoutes.POST("", categoryHandler.CreateCategory)
-			categoryRoutes.PUT("/:id", categoryHandler.UpdateCategory)
-			categoryRoutes.DELETE("/:id", categoryHandler.DeleteCategory)
+			categoryRoutes.POST("", manageCatalog, categoryHandler.CreateCategory)
+			categoryRoutes.PUT("/:id", manageCatalog, categoryHandler.UpdateCategory)
+			categoryRoutes.DELETE("/:id", manageCatalog, categoryHandler.DeleteCategory)
 		}
 
 		courseRoutes := api.Group("/courses")
@@ -46,12 +145,12 @@ func SetUpRoutes(
 			courseRoutes.GET("", courseHandler.FindCourse)
 			courseRoutes.GET("/:id", courseHandler.GetCourse)
 			courseRoutes.GET("/:id/details", courseHandler.GetCourseWithDetails)
-			courseRoutes.POST("", courseHandler.CreateCourse)
-			courseRoutes.PUT("/:id", courseHandler.UpdateCourse)
-			courseRoutes.DELETE("/:id", courseHandler.DeleteCourse)
+			courseRoutes.POST("", manageCatalog, courseHandler.CreateCourse)
+			courseRoutes.PUT("/:id", manageCatalog, courseHandler.UpdateCourse)
+			courseRoutes.DELETE("/:id", manageCatalog, courseHandler.DeleteCourse)
 
 			// Course offerings
-			courseRoutes.POST("/:course_id/offerings", courseOfferingHandler.CreateCourseOffering)
+			courseRoutes.POST("/:course_id/offerings", manageCatalog, courseOfferingHandler.CreateCourseOffering)
 		}
 
 		// Course offerings
@@ -59,40 +158,82 @@ func SetUpRoutes(
 		{
 			courseOfferingRoutes.GET("", courseOfferingHandler.FindCourseOffering)
 			courseOfferingRoutes.GET("/:offering_id", courseOfferingHandler.GetCourseOffering)
-			courseOfferingRoutes.PUT("/:offering_id", courseOfferingHandler.UpdateCourseOffering)
-			courseOfferingRoutes.DELETE("/:offering_id", courseOfferingHandler.DeleteCourseOffering)
-			courseOfferingRoutes.POST("/:offering_id/instructors", courseOfferingHandler.AssignInstructor)
-			courseOfferingRoutes.DELETE("/:offering_id/instructors/:instructor_id", courseOfferingHandler.RemoveInstructor)
+			courseOfferingRoutes.PUT("/:offering_id", manageOffering, courseOfferingHandler.UpdateCourseOffering)
+			courseOfferingRoutes.DELETE("/:offering_id", manageOffering, courseOfferingHandler.DeleteCourseOffering)
+			courseOfferingRoutes.POST("/:offering_id/instructors", manageOffering, courseOfferingHandler.AssignInstructor)
+			courseOfferingRoutes.DELETE("/:offering_id/instructors/:instructor_id", manageOffering, courseOfferingHandler.RemoveInstructor)
 		}
 
 		// Course sections
 		courseSectionRoutes := api.Group("/course-offerings")
 		{
-			courseSectionRoutes.POST("/:offering_id/sections", courseSectionHandler.CreateCourseSection)
+			courseSectionRoutes.POST("/:offering_id/sections", manageOffering, courseSectionHandler.CreateCourseSection)
 			courseSectionRoutes.GET("/:offering_id/sections", courseSectionHandler.FindCourseSection)
-			courseSectionRoutes.PUT("/:offering_id/sections/reorder", courseSectionHandler.ReorderCourseSections)
+			courseSectionRoutes.PUT("/:offering_id/sections/reorder", manageOffering, courseSectionHandler.ReorderCourseSections)
 		}
 
 		courseSectionUpdateRoutes := api.Group("/course-sections")
 		{
 			courseSectionUpdateRoutes.GET("/:section_id", courseSectionHandler.GetCourseSection)
-			courseSectionUpdateRoutes.PUT("/:section_id", courseSectionHandler.UpdateCourseSection)
-			courseSectionUpdateRoutes.DELETE("/:section_id", courseSectionHandler.DeleteCourseSection)
+			courseSectionUpdateRoutes.PUT("/:section_id", manageSection, courseSectionHandler.UpdateCourseSection)
+			courseSectionUpdateRoutes.DELETE("/:section_id", manageSection, courseSectionHandler.DeleteCourseSection)
 		}
 
 		// Section modules
 		sectionModuleRoutes := api.Group("/course-sections")
 		{
-			sectionModuleRoutes.POST("/:section_id/modules", sectionModuleHandler.CreateSectionModule)
+			sectionModuleRoutes.POST("/:section_id/modules", manageSection, sectionModuleHandler.CreateSectionModule)
 			sectionModuleRoutes.GET("/:section_id/modules", sectionModuleHandler.FindSectionModule)
-			sectionModuleRoutes.PUT("/:section_id/modules/reorder", sectionModuleHandler.ReorderSectionModules)
+			sectionModuleRoutes.PUT("/:section_id/modules/reorder", manageSection, sectionModuleHandler.ReorderSectionModules)
 		}
 
 		sectionModuleUpdateRoutes := api.Group("/section-modules")
 		{
 			sectionModuleUpdateRoutes.GET("/:module_id", sectionModuleHandler.GetSectionModule)
-			sectionModuleUpdateRoutes.PUT("/:module_id", sectionModuleHandler.UpdateSectionModule)
-			sectionModuleUpdateRoutes.DELETE("/:module_id", sectionModuleHandler.DeleteSectionModule)
+			sectionModuleUpdateRoutes.PUT("/:module_id", manageModule, sectionModuleHandler.UpdateSectionModule)
+			sectionModuleUpdateRoutes.DELETE("/:module_id", manageModule, sectionModuleHandler.DeleteSectionModule)
+		}
+
+		// Question banks
+		questionBankRoutes := api.Group("/question-banks")
+		{
+			questionBankRoutes.GET("/:course_id/questions", manageQuestionBank, questionHandler.FindQuestions)
+			questionBankRoutes.POST("/:course_id/questions", manageQuestionBank, questionHandler.CreateQuestion)
+			questionBankRoutes.PUT("/:course_id/questions/:question_id", manageQuestionBank, questionHandler.UpdateQuestion)
+			questionBankRoutes.DELETE("/:course_id/questions/:question_id", manageQuestionBank, questionHandler.DeleteQuestion)
+		}
+
+		// Quizzes
+		quizRoutes := api.Group("/quizzes")
+		{
+			quizRoutes.POST("", createQuiz, quizHandler.CreateQuiz)
+			quizRoutes.GET("/:quiz_id", readQuiz, quizHandler.GetQuiz)
+			quizRoutes.PUT("/:quiz_id", manageQuiz, quizHandler.UpdateQuiz)
+			quizRoutes.POST("/:quiz_id/attempts", takeQuiz, quizHandler.StartQuizAttempt)
+			quizRoutes.GET("/:quiz_id/attempts", manageQuiz, quizHandler.FindQuizAttempts)
+		}
+
+		quizAttemptRoutes := api.Group("/quiz-attempts")
+		{
+			quizAttemptRoutes.GET("/:attempt_id", ownAttempt, quizHandler.GetQuizAttempt)
+			quizAttemptRoutes.PUT("/:attempt_id/answers", ownAttempt, quizHandler.SaveQuizAttemptAnswers)
+			quizAttemptRoutes.POST("/:attempt_id/submit", ownAttempt, quizHandler.SubmitQuizAttempt)
+		}
+
+		// Assignments
+		assignmentRoutes := api.Group("/assignments")
+		{
+			assignmentRoutes.POST("", createAssignment, assignmentHandler.CreateAssignment)
+			assignmentRoutes.GET("/:assignment_id", readAssignment, assignmentHandler.GetAssignment)
+			assignmentRoutes.PUT("/:assignment_id", manageAssignment, assignmentHandler.UpdateAssignment)
+			assignmentRoutes.POST("/:assignment_id/submissions", submitAssignment, assignmentHandler.SubmitAssignment)
+			assignmentRoutes.GET("/:assignment_id/submissions", manageAssignment, assignmentHandler.FindAssignmentSubmissions)
+			assignmentRoutes.GET("/:assignment_id/submissions/me", submitAssignment, assignmentHandler.FindMyAssignmentSubmissions)
+		}
+
+		assignmentSubmissionRoutes := api.Group("/assignment-submissions")
+		{
+			assignmentSubmissionRoutes.PUT("/:submission_id/grade", gradeSubmission, assignmentHandler.GradeAssignmentSubmission)
 		}
 	}
 }
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_course_offering_organization_id;
+DROP INDEX IF EXISTS idx_course_organization_id;
+
+ALTER TABLE course_offering DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE course DROP COLUMN IF EXISTS organization_id;
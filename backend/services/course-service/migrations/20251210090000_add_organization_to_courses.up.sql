@@ -0,0 +1,8 @@
+-- Organizations live in user-service, so there is no foreign key. Courses
+-- without one predate multi-tenancy and are only visible to super-admins and
+-- anonymous visitors.
+ALTER TABLE course ADD COLUMN IF NOT EXISTS organization_id UUID;
+ALTER TABLE course_offering ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_course_organization_id ON course(organization_id);
+CREATE INDEX IF NOT EXISTS idx_course_offering_organization_id ON course_offering(organization_id);
@@ -0,0 +1,11 @@
+-- Postgres cannot drop enum values, so the type is recreated with only the
+-- original value and modules of the newer types are removed.
+DELETE FROM section_module WHERE content_type <> 'zoom';
+
+ALTER TABLE section_module DROP COLUMN IF EXISTS content_url;
+ALTER TABLE section_module DROP COLUMN IF EXISTS content_body;
+
+ALTER TABLE section_module ALTER COLUMN content_type TYPE VARCHAR(50);
+DROP TYPE IF EXISTS content_type;
+CREATE TYPE content_type AS ENUM ('zoom');
+ALTER TABLE section_module ALTER COLUMN content_type TYPE content_type USING content_type::content_type;
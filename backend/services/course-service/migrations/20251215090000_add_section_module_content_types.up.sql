@@ -0,0 +1,11 @@
+-- Videos and documents reference a file-service file through content_id.
+-- Text modules keep their markdown in content_body; link and embed modules
+-- keep their URL in content_url.
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'video';
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'document';
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'text';
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'link';
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'embed';
+
+ALTER TABLE section_module ADD COLUMN IF NOT EXISTS content_body TEXT;
+ALTER TABLE section_module ADD COLUMN IF NOT EXISTS content_url TEXT;
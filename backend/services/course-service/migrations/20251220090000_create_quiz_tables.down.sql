@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS quiz_attempt;
+DROP TABLE IF EXISTS quiz;
+DROP TABLE IF EXISTS question;
+
+DROP TYPE IF EXISTS quiz_attempt_status;
+DROP TYPE IF EXISTS quiz_results_visibility;
+DROP TYPE IF EXISTS question_type;
+
+-- Postgres cannot drop enum values, so the type is recreated without 'quiz'
+-- and quiz modules are removed.
+DELETE FROM section_module WHERE content_type = 'quiz';
+ALTER TABLE section_module ALTER COLUMN content_type TYPE VARCHAR(50);
+DROP TYPE IF EXISTS content_type;
+CREATE TYPE content_type AS ENUM ('zoom', 'video', 'document', 'text', 'link', 'embed');
+ALTER TABLE section_module ALTER COLUMN content_type TYPE content_type USING content_type::content_type;
@@ -0,0 +1,61 @@
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'quiz';
+
+CREATE TYPE question_type AS ENUM ('multiple_choice', 'multi_select', 'true_false', 'short_answer', 'numeric');
+CREATE TYPE quiz_results_visibility AS ENUM ('immediate', 'score_only', 'after_close', 'hidden');
+CREATE TYPE quiz_attempt_status AS ENUM ('in_progress', 'submitted', 'expired');
+
+-- Question bank of a course. Options and the answer key are stored as JSON
+-- as their shape depends on the question type.
+CREATE TABLE IF NOT EXISTS question (
+    id UUID PRIMARY KEY,
+    course_id UUID NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    question_type question_type NOT NULL,
+    prompt TEXT NOT NULL,
+    points NUMERIC(8, 2) NOT NULL,
+    options JSONB NOT NULL DEFAULT '[]'::jsonb,
+    correct_option_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    accepted_answers JSONB NOT NULL DEFAULT '[]'::jsonb,
+    numeric_answer DOUBLE PRECISION,
+    numeric_tolerance DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS quiz (
+    id UUID PRIMARY KEY,
+    section_module_id UUID NOT NULL UNIQUE REFERENCES section_module(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    question_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    time_limit_minutes INT,
+    max_attempts INT,
+    shuffle_questions BOOLEAN NOT NULL DEFAULT FALSE,
+    shuffle_options BOOLEAN NOT NULL DEFAULT FALSE,
+    results_visibility quiz_results_visibility NOT NULL DEFAULT 'immediate',
+    closes_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS quiz_attempt (
+    id UUID PRIMARY KEY,
+    quiz_id UUID NOT NULL REFERENCES quiz(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL,
+    attempt_number INT NOT NULL,
+    status quiz_attempt_status NOT NULL DEFAULT 'in_progress',
+    question_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    option_order JSONB NOT NULL DEFAULT '{}'::jsonb,
+    answers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    results JSONB NOT NULL DEFAULT '[]'::jsonb,
+    score NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    max_score NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    submitted_at TIMESTAMPTZ,
+    UNIQUE (quiz_id, student_id, attempt_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_course_id ON question(course_id);
+CREATE INDEX IF NOT EXISTS idx_quiz_course_id ON quiz(course_id);
+CREATE INDEX IF NOT EXISTS idx_quiz_question_ids ON quiz USING GIN (question_ids);
+CREATE INDEX IF NOT EXISTS idx_quiz_attempt_quiz_student ON quiz_attempt(quiz_id, student_id);
+CREATE INDEX IF NOT EXISTS idx_quiz_attempt_student_id ON quiz_attempt(student_id);
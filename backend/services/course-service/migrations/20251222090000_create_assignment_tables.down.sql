@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS assignment_submission;
+DROP TABLE IF EXISTS assignment;
+
+DROP TYPE IF EXISTS assignment_submission_status;
+DROP TYPE IF EXISTS assignment_late_policy;
+
+-- Postgres cannot drop enum values, so the type is recreated without
+-- 'assignment' and assignment modules are removed.
+DELETE FROM section_module WHERE content_type = 'assignment';
+ALTER TABLE section_module ALTER COLUMN content_type TYPE VARCHAR(50);
+DROP TYPE IF EXISTS content_type;
+CREATE TYPE content_type AS ENUM ('zoom', 'video', 'document', 'text', 'link', 'embed', 'quiz');
+ALTER TABLE section_module ALTER COLUMN content_type TYPE content_type USING content_type::content_type;
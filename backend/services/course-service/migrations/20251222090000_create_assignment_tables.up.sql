@@ -0,0 +1,46 @@
+ALTER TYPE content_type ADD VALUE IF NOT EXISTS 'assignment';
+
+CREATE TYPE assignment_late_policy AS ENUM ('accept', 'penalize', 'block');
+CREATE TYPE assignment_submission_status AS ENUM ('submitted', 'graded');
+
+CREATE TABLE IF NOT EXISTS assignment (
+    id UUID PRIMARY KEY,
+    section_module_id UUID NOT NULL UNIQUE REFERENCES section_module(id) ON DELETE CASCADE,
+    course_offering_id UUID NOT NULL REFERENCES course_offering(id) ON DELETE CASCADE,
+    instructions TEXT NOT NULL DEFAULT '',
+    allow_files BOOLEAN NOT NULL DEFAULT TRUE,
+    allow_text BOOLEAN NOT NULL DEFAULT FALSE,
+    due_at TIMESTAMPTZ,
+    late_policy assignment_late_policy NOT NULL DEFAULT 'accept',
+    late_penalty_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    max_score NUMERIC(10, 2) NOT NULL,
+    max_submissions INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every resubmission is a new row; the highest submission_number is the one
+-- that counts. Files are stored by file-service and referenced by ID.
+CREATE TABLE IF NOT EXISTS assignment_submission (
+    id UUID PRIMARY KEY,
+    assignment_id UUID NOT NULL REFERENCES assignment(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL,
+    student_email VARCHAR(255) NOT NULL DEFAULT '',
+    submission_number INT NOT NULL,
+    text_content TEXT,
+    file_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    late BOOLEAN NOT NULL DEFAULT FALSE,
+    penalty_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    status assignment_submission_status NOT NULL DEFAULT 'submitted',
+    score NUMERIC(10, 2),
+    final_score NUMERIC(10, 2),
+    feedback TEXT,
+    graded_by UUID,
+    graded_at TIMESTAMPTZ,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (assignment_id, student_id, submission_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_course_offering_id ON assignment(course_offering_id);
+CREATE INDEX IF NOT EXISTS idx_assignment_submission_assignment_student ON assignment_submission(assignment_id, student_id);
+CREATE INDEX IF NOT EXISTS idx_assignment_submission_student_id ON assignment_submission(student_id);
@@ -0,0 +1,13 @@
+-- Enrollments are owned by enrollment-service. This copy is kept in sync from
+-- its enrollment events so quizzes can be limited to enrolled students.
+-- Existing enrollments are filled in by the replay this service requests from
+-- enrollment-service whenever it starts.
+CREATE TABLE IF NOT EXISTS offering_enrollment (
+    enrollment_id UUID PRIMARY KEY,
+    course_offering_id UUID NOT NULL,
+    student_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_offering_enrollment_offering_student ON offering_enrollment(course_offering_id, student_id);
@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedIntegration "github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignment_Integration_SubmitAndGrade(t *testing.T) {
+	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, sharedIntegration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"assignment_submission", "assignment", "section_module", "course_section", "course_offering", "course"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	courseRepo := SetupCourseRepository(db)
+	offeringRepo := SetupCourseOfferingRepository(db)
+	sectionRepo := SetupCourseSectionRepository(db)
+	moduleRepo := SetupSectionModuleRepository(db)
+	assignmentRepo := SetupAssignmentRepository(db)
+	submissionRepo := SetupAssignmentSubmissionRepository(db)
+	// Files live in file-service, which is not part of this test.
+	submissionFileRepo := new(mocks.MockSubmissionFileRepository)
+	publisher := new(sharedMocks.MockPublisher)
+	log := logger.NewNop()
+
+	ctx := context.Background()
+
+	course := entities.NewCourse("Test Course", "Test Description", nil)
+	require.NoError(t, courseRepo.Create(ctx, course))
+	offering := entities.NewCourseOffering(course.ID, "Spring 2024", "Spring offering", entities.OfferingTypeOnline, nil, nil, 0.0)
+	require.NoError(t, offeringRepo.Create(ctx, offering))
+	section := entities.NewCourseSection(offering.ID, "Introduction", "Introduction section", 1)
+	require.NoError(t, sectionRepo.Create(ctx, section))
+	module := entities.NewSectionModule(section.ID, "Essay 1", "", entities.ContentTypeAssignment, 1)
+	require.NoError(t, moduleRepo.Create(ctx, module))
+
+	// Due an hour ago, so every submission is late and penalized.
+	dueAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	maxSubmissions := 2
+	assignment, err := usecases.NewCreateAssignmentUseCase(assignmentRepo, moduleRepo, sectionRepo, log).Execute(ctx, dtos.CreateAssignmentInput{
+		SectionModuleID:    module.ID,
+		Instructions:       "Write 500 words.",
+		AllowFiles:         true,
+		AllowText:          true,
+		DueAt:              &dueAt,
+		LatePolicy:         string(entities.LatePolicyPenalize),
+		LatePenaltyPercent: 20,
+		MaxScore:           50,
+		MaxSubmissions:     &maxSubmissions,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, offering.ID, assignment.CourseOfferingID)
+
+	storedModule, err := moduleRepo.FindByID(ctx, module.ID)
+	require.NoError(t, err)
+	require.NotNil(t, storedModule.ContentID)
+	assert.Equal(t, assignment.ID, *storedModule.ContentID)
+
+	studentID := uuid.New().String()
+	publisher.On("Publish", mock.Anything, events.EventTypeAssignmentSubmitted, mock.Anything).Return(nil).Twice()
+	submit := usecases.NewSubmitAssignmentUseCase(assignmentRepo, submissionRepo, submissionFileRepo, moduleRepo, publisher, log, "")
+	text := "First draft"
+	_, err = submit.Execute(ctx, assignment.ID, studentID, "student@example.com", "", dtos.SubmitAssignmentInput{Text: &text})
+	require.NoError(t, err)
+	fileID := uuid.New().String()
+	submissionFileRepo.On("CountUploadedBy", mock.Anything, []string{fileID}, studentID, "").Return(1, nil)
+
+	// Files uploaded by someone else cannot be handed in.
+	otherFileID := uuid.New().String()
+	submissionFileRepo.On("CountUploadedBy", mock.Anything, []string{fileID, otherFileID}, studentID, "").Return(1, nil)
+	_, err = submit.Execute(ctx, assignment.ID, studentID, "student@example.com", "", dtos.SubmitAssignmentInput{FileIDs: []string{fileID, otherFileID}})
+	require.ErrorIs(t, err, entities.ErrInvalidSubmission)
+
+	second, err := submit.Execute(ctx, assignment.ID, studentID, "student@example.com", "", dtos.SubmitAssignmentInput{FileIDs: []string{fileID}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.SubmissionNumber)
+	assert.True(t, second.Late)
+
+	_, err = submit.Execute(ctx, assignment.ID, studentID, "student@example.com", "", dtos.SubmitAssignmentInput{Text: &text})
+	require.ErrorIs(t, err, usecases.ErrSubmissionLimitReached)
+
+	publisher.On("Publish", mock.Anything, events.EventTypeAssignmentGraded, mock.Anything).Return(nil).Once()
+	feedback := "Good structure."
+	score := 40.0
+	graderID := uuid.New().String()
+	graded, err := usecases.NewGradeAssignmentSubmissionUseCase(assignmentRepo, submissionRepo, moduleRepo, publisher, log, "").Execute(ctx, second.ID, graderID, dtos.GradeAssignmentSubmissionInput{
+		Score:    &score,
+		Feedback: &feedback,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 32.0, *graded.FinalScore)
+
+	submissions, err := usecases.NewFindAssignmentSubmissionsUseCase(assignmentRepo, submissionRepo, log, "").Execute(ctx, assignment.ID, studentID)
+	require.NoError(t, err)
+	require.Len(t, submissions, 2)
+	assert.Equal(t, string(entities.AssignmentSubmissionStatusSubmitted), submissions[0].Status)
+	assert.Equal(t, "First draft", *submissions[0].Text)
+	assert.Equal(t, string(entities.AssignmentSubmissionStatusGraded), submissions[1].Status)
+	require.Len(t, submissions[1].Files, 1)
+	assert.Equal(t, fileID, submissions[1].Files[0].ID)
+	assert.Equal(t, 20.0, submissions[1].PenaltyPercent)
+	assert.Equal(t, feedback, *submissions[1].Feedback)
+	assert.Equal(t, graderID, *submissions[1].GradedBy)
+
+	deleted, err := submissionRepo.DeleteByStudentID(ctx, studentID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	publisher.AssertExpectations(t)
+}
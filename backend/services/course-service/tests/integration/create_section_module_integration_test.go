@@ -54,7 +54,7 @@ func TestCreateSectionModule_Integration_Success(t *testing.T) {
 
 	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	result, err := createModuleUC.Execute(ctx, section.ID, input)
 
@@ -98,7 +98,7 @@ func TestCreateSectionModule_Integration_SectionNotFound(t *testing.T) {
 		Order:       1,
 	}
 
-	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	nonExistentSectionID := uuid.New().String()
 	_, err = createModuleUC.Execute(ctx, nonExistentSectionID, input)
@@ -139,7 +139,7 @@ func TestCreateSectionModule_Integration_MultipleModulesWithOrdering(t *testing.
 
 	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(3)
 
-	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	modules := []dtos.CreateSectionModuleInput{
 		{Name: "Module 1", Description: "First module", ContentType: "zoom", Order: 1},
@@ -163,3 +163,64 @@ func TestCreateSectionModule_Integration_MultipleModulesWithOrdering(t *testing.
 	publisher.AssertExpectations(t)
 }
 
+
+func TestCreateSectionModule_Integration_ContentTypes(t *testing.T) {
+	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, sharedIntegration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"section_module", "course_section", "course_offering", "course"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	courseRepo := SetupCourseRepository(db)
+	offeringRepo := SetupCourseOfferingRepository(db)
+	sectionRepo := SetupCourseSectionRepository(db)
+	moduleRepo := SetupSectionModuleRepository(db)
+	publisher := new(sharedMocks.MockPublisher)
+	logger := logger.NewNop()
+
+	ctx := context.Background()
+
+	course := entities.NewCourse("Test Course", "Test Description", nil)
+	require.NoError(t, courseRepo.Create(ctx, course))
+	offering := entities.NewCourseOffering(course.ID, "Spring 2024", "Spring offering", entities.OfferingTypeOnline, nil, nil, 0.0)
+	require.NoError(t, offeringRepo.Create(ctx, offering))
+	section := entities.NewCourseSection(offering.ID, "Introduction", "Introduction section", 1)
+	require.NoError(t, sectionRepo.Create(ctx, section))
+
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	createModuleUC := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "http://localhost:3000")
+
+	fileID := uuid.NewString()
+	body := "# Week 1\n\nRead chapter one."
+	link := "https://example.com/article"
+	embed := "https://www.youtube.com/embed/abc123"
+
+	video, err := createModuleUC.Execute(ctx, section.ID, dtos.CreateSectionModuleInput{Name: "Lecture", ContentType: "video", ContentID: &fileID, Order: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:3000/api/v1/buckets/course-videos/files/"+fileID+"/download", video.StreamURL)
+
+	_, err = createModuleUC.Execute(ctx, section.ID, dtos.CreateSectionModuleInput{Name: "Syllabus", ContentType: "document", ContentID: &fileID, Order: 2})
+	require.NoError(t, err)
+	_, err = createModuleUC.Execute(ctx, section.ID, dtos.CreateSectionModuleInput{Name: "Reading", ContentType: "text", ContentBody: &body, Order: 3})
+	require.NoError(t, err)
+	_, err = createModuleUC.Execute(ctx, section.ID, dtos.CreateSectionModuleInput{Name: "Article", ContentType: "link", ContentURL: &link, Order: 4})
+	require.NoError(t, err)
+	_, err = createModuleUC.Execute(ctx, section.ID, dtos.CreateSectionModuleInput{Name: "Talk", ContentType: "embed", ContentURL: &embed, Order: 5})
+	require.NoError(t, err)
+
+	modules, err := moduleRepo.FindBySectionID(ctx, section.ID)
+	require.NoError(t, err)
+	require.Len(t, modules, 5)
+
+	assert.Equal(t, entities.ContentTypeVideo, modules[0].ContentType)
+	assert.Equal(t, &fileID, modules[0].ContentID)
+	assert.Equal(t, entities.ContentTypeDocument, modules[1].ContentType)
+	assert.Equal(t, &body, modules[2].ContentBody)
+	assert.Equal(t, &link, modules[3].ContentURL)
+	assert.Equal(t, &embed, modules[4].ContentURL)
+	for _, module := range modules {
+		assert.Equal(t, entities.ContentStatusCreated, module.ContentStatus)
+	}
+}
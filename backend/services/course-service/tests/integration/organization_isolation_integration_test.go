@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	sharedIntegration "github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_Integration_NoCrossTenantLeakage(t *testing.T) {
+	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, sharedIntegration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"section_module", "course_section", "course_offering", "course"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	courseRepo := SetupCourseRepository(db)
+	courseCategoryRepo := SetupCourseCategoryRepository(db)
+	categoryRepo := SetupCategoryRepository(db)
+	offeringRepo := SetupCourseOfferingRepository(db)
+	instructorRepo := SetupCourseOfferingInstructorRepository(db)
+	sectionRepo := SetupCourseSectionRepository(db)
+	moduleRepo := SetupSectionModuleRepository(db)
+	logger := logger.NewNop()
+	apiGatewayURL := "http://localhost:3000"
+
+	riverside := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	hillcrest := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	superAdmin := context.Background()
+
+	createCourse := usecases.NewCreateCourseUseCase(courseRepo, courseCategoryRepo, categoryRepo, nil, logger, apiGatewayURL)
+	course, err := createCourse.Execute(riverside, dtos.CreateCourseInput{Name: "Riverside Algebra"})
+	require.NoError(t, err)
+	require.NotNil(t, course.OrganizationID)
+	assert.Equal(t, tenant.OrganizationID(riverside), *course.OrganizationID)
+
+	_, err = createCourse.Execute(hillcrest, dtos.CreateCourseInput{Name: "Hillcrest Biology"})
+	require.NoError(t, err)
+
+	offering, err := usecases.NewCreateCourseOfferingUseCase(offeringRepo, courseRepo, nil, logger).Execute(riverside, course.ID, dtos.CreateCourseOfferingInput{
+		Name:           "Spring 2026",
+		OfferingType:   "online",
+		EnrollmentCost: 100,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, offering.OrganizationID)
+	assert.Equal(t, *course.OrganizationID, *offering.OrganizationID)
+
+	// Another organization cannot add offerings to the course.
+	_, err = usecases.NewCreateCourseOfferingUseCase(offeringRepo, courseRepo, nil, logger).Execute(hillcrest, course.ID, dtos.CreateCourseOfferingInput{
+		Name:           "Hijacked",
+		OfferingType:   "online",
+		EnrollmentCost: 100,
+	})
+	require.ErrorIs(t, err, usecases.ErrCourseNotFound)
+
+	section, err := usecases.NewCreateCourseSectionUseCase(sectionRepo, offeringRepo, nil, logger).Execute(riverside, offering.ID, dtos.CreateCourseSectionInput{Name: "Week 1"})
+	require.NoError(t, err)
+
+	getCourse := usecases.NewGetCourseUseCase(courseRepo, courseCategoryRepo, categoryRepo, logger, apiGatewayURL)
+	_, err = getCourse.Execute(hillcrest, usecases.GetCourseInput{CourseID: course.ID})
+	require.ErrorIs(t, err, usecases.ErrCourseNotFound)
+	_, err = getCourse.Execute(riverside, usecases.GetCourseInput{CourseID: course.ID})
+	require.NoError(t, err)
+
+	getOffering := usecases.NewGetCourseOfferingUseCase(offeringRepo, courseRepo, instructorRepo, sectionRepo, moduleRepo, apiGatewayURL)
+	_, err = getOffering.Execute(hillcrest, offering.ID)
+	require.ErrorIs(t, err, usecases.ErrCourseOfferingNotFound)
+
+	_, err = usecases.NewGetCourseSectionUseCase(sectionRepo, logger).Execute(hillcrest, usecases.GetCourseSectionInput{SectionID: section.ID})
+	require.ErrorIs(t, err, usecases.ErrCourseSectionNotFound)
+
+	listCourses := usecases.NewListCoursesUseCase(courseRepo, courseCategoryRepo, categoryRepo, logger, apiGatewayURL)
+	courses, err := listCourses.Execute(hillcrest, usecases.ListCoursesInput{})
+	require.NoError(t, err)
+	require.Equal(t, 1, courses.Total)
+	assert.Equal(t, "Hillcrest Biology", courses.Courses[0].Name)
+
+	courses, err = listCourses.Execute(superAdmin, usecases.ListCoursesInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, courses.Total)
+
+	findOfferings := usecases.NewFindCourseOfferingUseCase(offeringRepo, courseRepo, logger)
+	offerings, err := findOfferings.Execute(hillcrest, usecases.FindCourseOfferingInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, offerings.Total)
+
+	offerings, err = findOfferings.Execute(riverside, usecases.FindCourseOfferingInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, offerings.Total)
+}
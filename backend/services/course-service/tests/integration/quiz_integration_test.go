@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedIntegration "github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuiz_Integration_TakeAndGrade(t *testing.T) {
+	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, sharedIntegration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"quiz_attempt", "quiz", "question", "section_module", "course_section", "course_offering", "course"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	courseRepo := SetupCourseRepository(db)
+	offeringRepo := SetupCourseOfferingRepository(db)
+	sectionRepo := SetupCourseSectionRepository(db)
+	moduleRepo := SetupSectionModuleRepository(db)
+	questionRepo := SetupQuestionRepository(db)
+	quizRepo := SetupQuizRepository(db)
+	attemptRepo := SetupQuizAttemptRepository(db)
+	publisher := new(sharedMocks.MockPublisher)
+	log := logger.NewNop()
+
+	ctx := context.Background()
+
+	course := entities.NewCourse("Test Course", "Test Description", nil)
+	require.NoError(t, courseRepo.Create(ctx, course))
+	offering := entities.NewCourseOffering(course.ID, "Spring 2024", "Spring offering", entities.OfferingTypeOnline, nil, nil, 0.0)
+	require.NoError(t, offeringRepo.Create(ctx, offering))
+	section := entities.NewCourseSection(offering.ID, "Introduction", "Introduction section", 1)
+	require.NoError(t, sectionRepo.Create(ctx, section))
+	module := entities.NewSectionModule(section.ID, "Week 1 quiz", "", entities.ContentTypeQuiz, 1)
+	require.NoError(t, moduleRepo.Create(ctx, module))
+
+	numericAnswer := 2.5
+	createQuestion := usecases.NewCreateQuestionUseCase(questionRepo, courseRepo, log)
+	choice, err := createQuestion.Execute(ctx, course.ID, dtos.CreateQuestionInput{
+		Type:           string(entities.QuestionTypeMultiSelect),
+		Prompt:         "Which are prime?",
+		Points:         2,
+		Options:        []string{"2", "4", "7"},
+		CorrectOptions: []int{0, 2},
+	})
+	require.NoError(t, err)
+	numeric, err := createQuestion.Execute(ctx, course.ID, dtos.CreateQuestionInput{
+		Type:          string(entities.QuestionTypeNumeric),
+		Prompt:        "10 / 4?",
+		Points:        1,
+		NumericAnswer: &numericAnswer,
+	})
+	require.NoError(t, err)
+
+	maxAttempts := 1
+	quiz, err := usecases.NewCreateQuizUseCase(quizRepo, questionRepo, moduleRepo, sectionRepo, offeringRepo, log).Execute(ctx, dtos.CreateQuizInput{
+		SectionModuleID:  module.ID,
+		QuestionIDs:      []string{choice.ID, numeric.ID},
+		MaxAttempts:      &maxAttempts,
+		ShuffleQuestions: true,
+		ShuffleOptions:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, course.ID, quiz.CourseID)
+
+	storedModule, err := moduleRepo.FindByID(ctx, module.ID)
+	require.NoError(t, err)
+	require.NotNil(t, storedModule.ContentID)
+	assert.Equal(t, quiz.ID, *storedModule.ContentID)
+	assert.Equal(t, entities.ContentStatusCreated, storedModule.ContentStatus)
+
+	studentID := uuid.New().String()
+	start := usecases.NewStartQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, publisher, log)
+	attempt, err := start.Execute(ctx, quiz.ID, studentID)
+	require.NoError(t, err)
+	require.Len(t, attempt.Questions, 2)
+
+	// Starting again resumes the same attempt.
+	resumed, err := start.Execute(ctx, quiz.ID, studentID)
+	require.NoError(t, err)
+	assert.Equal(t, attempt.ID, resumed.ID)
+	assert.Equal(t, attempt.Questions, resumed.Questions)
+
+	// Answers saved during the attempt are graded with the submission.
+	saved, err := usecases.NewSaveQuizAttemptAnswersUseCase(questionRepo, attemptRepo, log).Execute(ctx, attempt.ID, studentID, dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{numeric.ID: {Text: "2.4"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.QuizAttemptStatusInProgress), saved.Status)
+	concurrent, err := attemptRepo.FindByID(ctx, attempt.ID)
+	require.NoError(t, err)
+
+	publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.Anything).Return(nil).Once()
+	submitted, err := usecases.NewSubmitQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, publisher, log).Execute(ctx, attempt.ID, studentID, dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{
+			choice.ID: {OptionIDs: []string{choice.CorrectOptionIDs[1], choice.CorrectOptionIDs[0]}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, submitted.Score)
+	assert.Equal(t, 2.0, *submitted.Score)
+	assert.Equal(t, 3.0, *submitted.MaxScore)
+	publisher.AssertExpectations(t)
+
+	// A submission that loaded the attempt before it closed cannot grade it
+	// again.
+	require.NoError(t, concurrent.Submit(nil, map[string]*entities.Question{}, time.Now()))
+	require.ErrorIs(t, attemptRepo.Update(ctx, concurrent), entities.ErrQuizAttemptClosed)
+
+	stored, err := usecases.NewGetQuizAttemptUseCase(quizRepo, questionRepo, attemptRepo, publisher, log).Execute(ctx, attempt.ID, studentID)
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.QuizAttemptStatusSubmitted), stored.Status)
+	assert.Equal(t, submitted.Questions, stored.Questions)
+
+	_, err = start.Execute(ctx, quiz.ID, studentID)
+	require.ErrorIs(t, err, usecases.ErrQuizAttemptLimitReached)
+
+	err = usecases.NewDeleteQuestionUseCase(questionRepo, quizRepo, log).Execute(ctx, course.ID, numeric.ID)
+	require.ErrorIs(t, err, usecases.ErrQuestionInUse)
+
+	staff, err := usecases.NewFindQuizAttemptsUseCase(quizRepo, questionRepo, attemptRepo, log).Execute(ctx, quiz.ID)
+	require.NoError(t, err)
+	require.Len(t, staff, 1)
+	assert.Equal(t, studentID, staff[0].StudentID)
+}
@@ -35,3 +35,22 @@ func SetupSectionModuleRepository(db *sql.DB) repositories.SectionModuleReposito
 	return postgres.NewPostgresSectionModuleRepository(db)
 }
 
+func SetupQuestionRepository(db *sql.DB) repositories.QuestionRepository {
+	return postgres.NewPostgresQuestionRepository(db)
+}
+
+func SetupQuizRepository(db *sql.DB) repositories.QuizRepository {
+	return postgres.NewPostgresQuizRepository(db)
+}
+
+func SetupQuizAttemptRepository(db *sql.DB) repositories.QuizAttemptRepository {
+	return postgres.NewPostgresQuizAttemptRepository(db)
+}
+
+func SetupAssignmentRepository(db *sql.DB) repositories.AssignmentRepository {
+	return postgres.NewPostgresAssignmentRepository(db)
+}
+
+func SetupAssignmentSubmissionRepository(db *sql.DB) repositories.AssignmentSubmissionRepository {
+	return postgres.NewPostgresAssignmentSubmissionRepository(db)
+}
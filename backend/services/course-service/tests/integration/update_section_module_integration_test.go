@@ -57,7 +57,7 @@ func TestUpdateSectionModule_Integration_Success(t *testing.T) {
 
 	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	updateModuleUC := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger)
+	updateModuleUC := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger, "")
 
 	result, err := updateModuleUC.Execute(ctx, module.ID, input)
 
@@ -96,7 +96,7 @@ func TestUpdateSectionModule_Integration_ModuleNotFound(t *testing.T) {
 		Order:       2,
 	}
 
-	updateModuleUC := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger)
+	updateModuleUC := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger, "")
 
 	nonExistentModuleID := uuid.New().String()
 	_, err = updateModuleUC.Execute(ctx, nonExistentModuleID, input)
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedIntegration "github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPurged_Integration_RemovesInstructorAssignments(t *testing.T) {
+	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, sharedIntegration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"course_offering_instructor", "course_offering", "course"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	courseRepo := SetupCourseRepository(db)
+	offeringRepo := SetupCourseOfferingRepository(db)
+	instructorRepo := SetupCourseOfferingInstructorRepository(db)
+
+	ctx := context.Background()
+
+	course := entities.NewCourse("Test Course", "Test Description", nil)
+	require.NoError(t, courseRepo.Create(ctx, course))
+
+	spring := entities.NewCourseOffering(course.ID, "Spring 2024", "Spring offering", entities.OfferingTypeOnline, nil, nil, 0.0)
+	require.NoError(t, offeringRepo.Create(ctx, spring))
+	fall := entities.NewCourseOffering(course.ID, "Fall 2024", "Fall offering", entities.OfferingTypeOnline, nil, nil, 0.0)
+	require.NoError(t, offeringRepo.Create(ctx, fall))
+
+	purgedID := uuid.New().String()
+	otherID := uuid.New().String()
+	require.NoError(t, instructorRepo.Create(ctx, entities.NewCourseOfferingInstructor(spring.ID, purgedID, "purged_instructor")))
+	require.NoError(t, instructorRepo.Create(ctx, entities.NewCourseOfferingInstructor(fall.ID, purgedID, "purged_instructor")))
+	require.NoError(t, instructorRepo.Create(ctx, entities.NewCourseOfferingInstructor(spring.ID, otherID, "other_instructor")))
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: purgedID, Role: "instructor", PurgedAt: time.Now()})
+	require.NoError(t, err)
+
+	handler := handlers.NewUserPurgedHandler(instructorRepo, SetupQuizAttemptRepository(db), SetupAssignmentSubmissionRepository(db), logger.NewNop())
+	require.NoError(t, handler.Handle(body))
+
+	remaining, err := instructorRepo.FindByInstructorID(ctx, purgedID)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	springInstructors, err := instructorRepo.FindByOfferingID(ctx, spring.ID)
+	require.NoError(t, err)
+	require.Len(t, springInstructors, 1)
+	assert.Equal(t, otherID, springInstructors[0].InstructorID)
+
+	_, err = offeringRepo.FindByID(ctx, fall.ID)
+	require.NoError(t, err)
+
+	// A redelivered event finds nothing left to remove.
+	require.NoError(t, handler.Handle(body))
+}
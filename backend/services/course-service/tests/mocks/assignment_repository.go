@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentRepository) Create(ctx context.Context, assignment *entities.Assignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentRepository) FindByID(ctx context.Context, id string) (*entities.Assignment, error) {
+	args := m.Called(ctx, id)
+	assignment, _ := args.Get(0).(*entities.Assignment)
+	return assignment, args.Error(1)
+}
+
+func (m *MockAssignmentRepository) FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Assignment, error) {
+	args := m.Called(ctx, sectionModuleID)
+	assignment, _ := args.Get(0).(*entities.Assignment)
+	return assignment, args.Error(1)
+}
+
+func (m *MockAssignmentRepository) Update(ctx context.Context, assignment *entities.Assignment) error {
+	args := m.Called(ctx, assignment)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAssignmentSubmissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentSubmissionRepository) Create(ctx context.Context, submission *entities.AssignmentSubmission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentSubmissionRepository) FindByID(ctx context.Context, id string) (*entities.AssignmentSubmission, error) {
+	args := m.Called(ctx, id)
+	submission, _ := args.Get(0).(*entities.AssignmentSubmission)
+	return submission, args.Error(1)
+}
+
+func (m *MockAssignmentSubmissionRepository) FindByAssignmentID(ctx context.Context, assignmentID string) ([]*entities.AssignmentSubmission, error) {
+	args := m.Called(ctx, assignmentID)
+	submissions, _ := args.Get(0).([]*entities.AssignmentSubmission)
+	return submissions, args.Error(1)
+}
+
+func (m *MockAssignmentSubmissionRepository) FindByAssignmentAndStudent(ctx context.Context, assignmentID, studentID string) ([]*entities.AssignmentSubmission, error) {
+	args := m.Called(ctx, assignmentID, studentID)
+	submissions, _ := args.Get(0).([]*entities.AssignmentSubmission)
+	return submissions, args.Error(1)
+}
+
+func (m *MockAssignmentSubmissionRepository) Update(ctx context.Context, submission *entities.AssignmentSubmission) error {
+	args := m.Called(ctx, submission)
+	return args.Error(0)
+}
+
+func (m *MockAssignmentSubmissionRepository) DeleteByStudentID(ctx context.Context, studentID string) (int64, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAssignmentSubmissionRepository) FindWithFiles(ctx context.Context) ([]*entities.AssignmentSubmission, error) {
+	args := m.Called(ctx)
+	submissions, _ := args.Get(0).([]*entities.AssignmentSubmission)
+	return submissions, args.Error(1)
+}
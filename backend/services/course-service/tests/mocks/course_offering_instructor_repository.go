@@ -28,6 +28,12 @@ func (m *MockCourseOfferingInstructorRepository) FindByInstructorID(ctx context.
 	return instructors, args.Error(1)
 }
 
+func (m *MockCourseOfferingInstructorRepository) FindAll(ctx context.Context) ([]*entities.CourseOfferingInstructor, error) {
+	args := m.Called(ctx)
+	instructors, _ := args.Get(0).([]*entities.CourseOfferingInstructor)
+	return instructors, args.Error(1)
+}
+
 func (m *MockCourseOfferingInstructorRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -38,6 +44,11 @@ func (m *MockCourseOfferingInstructorRepository) DeleteByOfferingID(ctx context.
 	return args.Error(0)
 }
 
+func (m *MockCourseOfferingInstructorRepository) DeleteByInstructorID(ctx context.Context, instructorID string) (int64, error) {
+	args := m.Called(ctx, instructorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockCourseOfferingInstructorRepository) Update(ctx context.Context, instructor *entities.CourseOfferingInstructor) error {
 	args := m.Called(ctx, instructor)
 	return args.Error(0)
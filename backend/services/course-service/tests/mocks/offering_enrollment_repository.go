@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOfferingEnrollmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockOfferingEnrollmentRepository) Save(ctx context.Context, enrollmentID, courseOfferingID, studentID, status string) error {
+	args := m.Called(ctx, enrollmentID, courseOfferingID, studentID, status)
+	return args.Error(0)
+}
+
+func (m *MockOfferingEnrollmentRepository) Delete(ctx context.Context, enrollmentID string) error {
+	args := m.Called(ctx, enrollmentID)
+	return args.Error(0)
+}
+
+func (m *MockOfferingEnrollmentRepository) IsEnrolled(ctx context.Context, courseOfferingID, studentID string) (bool, error) {
+	args := m.Called(ctx, courseOfferingID, studentID)
+	return args.Bool(0), args.Error(1)
+}
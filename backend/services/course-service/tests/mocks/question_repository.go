@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuestionRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuestionRepository) Create(ctx context.Context, question *entities.Question) error {
+	args := m.Called(ctx, question)
+	return args.Error(0)
+}
+
+func (m *MockQuestionRepository) FindByID(ctx context.Context, id string) (*entities.Question, error) {
+	args := m.Called(ctx, id)
+	question, _ := args.Get(0).(*entities.Question)
+	return question, args.Error(1)
+}
+
+func (m *MockQuestionRepository) FindByIDs(ctx context.Context, ids []string) ([]*entities.Question, error) {
+	args := m.Called(ctx, ids)
+	questions, _ := args.Get(0).([]*entities.Question)
+	return questions, args.Error(1)
+}
+
+func (m *MockQuestionRepository) FindByCourseID(ctx context.Context, courseID string) ([]*entities.Question, error) {
+	args := m.Called(ctx, courseID)
+	questions, _ := args.Get(0).([]*entities.Question)
+	return questions, args.Error(1)
+}
+
+func (m *MockQuestionRepository) Update(ctx context.Context, question *entities.Question) error {
+	args := m.Called(ctx, question)
+	return args.Error(0)
+}
+
+func (m *MockQuestionRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
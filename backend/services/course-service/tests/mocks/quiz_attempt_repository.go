@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuizAttemptRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuizAttemptRepository) Create(ctx context.Context, attempt *entities.QuizAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockQuizAttemptRepository) FindByID(ctx context.Context, id string) (*entities.QuizAttempt, error) {
+	args := m.Called(ctx, id)
+	attempt, _ := args.Get(0).(*entities.QuizAttempt)
+	return attempt, args.Error(1)
+}
+
+func (m *MockQuizAttemptRepository) FindByQuizID(ctx context.Context, quizID string) ([]*entities.QuizAttempt, error) {
+	args := m.Called(ctx, quizID)
+	attempts, _ := args.Get(0).([]*entities.QuizAttempt)
+	return attempts, args.Error(1)
+}
+
+func (m *MockQuizAttemptRepository) FindByQuizAndStudent(ctx context.Context, quizID, studentID string) ([]*entities.QuizAttempt, error) {
+	args := m.Called(ctx, quizID, studentID)
+	attempts, _ := args.Get(0).([]*entities.QuizAttempt)
+	return attempts, args.Error(1)
+}
+
+func (m *MockQuizAttemptRepository) Update(ctx context.Context, attempt *entities.QuizAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockQuizAttemptRepository) DeleteByStudentID(ctx context.Context, studentID string) (int64, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(int64), args.Error(1)
+}
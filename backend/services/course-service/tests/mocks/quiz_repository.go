@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQuizRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuizRepository) Create(ctx context.Context, quiz *entities.Quiz) error {
+	args := m.Called(ctx, quiz)
+	return args.Error(0)
+}
+
+func (m *MockQuizRepository) FindByID(ctx context.Context, id string) (*entities.Quiz, error) {
+	args := m.Called(ctx, id)
+	quiz, _ := args.Get(0).(*entities.Quiz)
+	return quiz, args.Error(1)
+}
+
+func (m *MockQuizRepository) FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.Quiz, error) {
+	args := m.Called(ctx, sectionModuleID)
+	quiz, _ := args.Get(0).(*entities.Quiz)
+	return quiz, args.Error(1)
+}
+
+func (m *MockQuizRepository) CountByQuestionID(ctx context.Context, questionID string) (int, error) {
+	args := m.Called(ctx, questionID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuizRepository) Update(ctx context.Context, quiz *entities.Quiz) error {
+	args := m.Called(ctx, quiz)
+	return args.Error(0)
+}
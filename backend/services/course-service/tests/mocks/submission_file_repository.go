@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSubmissionFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubmissionFileRepository) CountUploadedBy(ctx context.Context, fileIDs []string, uploadedBy, authorization string) (int, error) {
+	args := m.Called(ctx, fileIDs, uploadedBy, authorization)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,166 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type assignmentFixture struct {
+	assignment         *entities.Assignment
+	module             *entities.SectionModule
+	assignmentRepo     *mocks.MockAssignmentRepository
+	submissionRepo     *mocks.MockAssignmentSubmissionRepository
+	submissionFileRepo *mocks.MockSubmissionFileRepository
+	moduleRepo         *mocks.MockSectionModuleRepository
+	publisher          *sharedMocks.MockPublisher
+}
+
+func newAssignmentFixture(t *testing.T, settings entities.AssignmentSettings) *assignmentFixture {
+	t.Helper()
+	module := entities.NewSectionModule("section-1", "Essay 1", "", entities.ContentTypeAssignment, 1)
+	assignment, err := entities.NewAssignment(module.ID, "offering-1", settings)
+	require.NoError(t, err)
+
+	f := &assignmentFixture{
+		assignment:         assignment,
+		module:             module,
+		assignmentRepo:     new(mocks.MockAssignmentRepository),
+		submissionRepo:     new(mocks.MockAssignmentSubmissionRepository),
+		submissionFileRepo: new(mocks.MockSubmissionFileRepository),
+		moduleRepo:         new(mocks.MockSectionModuleRepository),
+		publisher:          new(sharedMocks.MockPublisher),
+	}
+	f.assignmentRepo.On("FindByID", mock.Anything, assignment.ID).Return(assignment, nil)
+	f.moduleRepo.On("FindByID", mock.Anything, module.ID).Return(module, nil)
+	return f
+}
+
+func (f *assignmentFixture) submitUseCase() *usecases.SubmitAssignmentUseCase {
+	return usecases.NewSubmitAssignmentUseCase(f.assignmentRepo, f.submissionRepo, f.submissionFileRepo, f.moduleRepo, f.publisher, logger.NewNop(), "https://lms.example.com")
+}
+
+func (f *assignmentFixture) gradeUseCase() *usecases.GradeAssignmentSubmissionUseCase {
+	return usecases.NewGradeAssignmentSubmissionUseCase(f.assignmentRepo, f.submissionRepo, f.moduleRepo, f.publisher, logger.NewNop(), "https://lms.example.com")
+}
+
+func TestSubmitAssignment_CreatesSubmissionAndPublishes(t *testing.T) {
+	f := newAssignmentFixture(t, entities.AssignmentSettings{AllowFiles: true, MaxScore: 10})
+	fileID := uuid.NewString()
+	earlier, err := entities.NewAssignmentSubmission(f.assignment, "student-1", "student@example.com", 1, nil, []string{uuid.NewString()}, time.Now())
+	require.NoError(t, err)
+	f.submissionRepo.On("FindByAssignmentAndStudent", mock.Anything, f.assignment.ID, "student-1").Return([]*entities.AssignmentSubmission{earlier}, nil).Once()
+	f.submissionFileRepo.On("CountUploadedBy", mock.Anything, []string{fileID}, "student-1", "Bearer student-token").Return(1, nil).Once()
+	f.submissionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entities.AssignmentSubmission) bool {
+		return s.StudentID == "student-1" && s.SubmissionNumber == 2 && s.StudentEmail == "student@example.com"
+	})).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeAssignmentSubmitted, mock.MatchedBy(func(e events.AssignmentSubmittedEvent) bool {
+		return e.AssignmentName == "Essay 1" && e.StudentEmail == "student@example.com" && e.SubmissionNumber == 2 && !e.Late
+	})).Return(nil).Once()
+
+	result, err := f.submitUseCase().Execute(context.Background(), f.assignment.ID, "student-1", "student@example.com", "Bearer student-token", dtos.SubmitAssignmentInput{
+		FileIDs: []string{fileID},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.SubmissionNumber)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "https://lms.example.com/api/v1/files/"+fileID+"/download", result.Files[0].DownloadURL)
+	f.submissionRepo.AssertExpectations(t)
+	f.publisher.AssertExpectations(t)
+}
+
+func TestSubmitAssignment_RefusesFilesUploadedByOthers(t *testing.T) {
+	f := newAssignmentFixture(t, entities.AssignmentSettings{AllowFiles: true, MaxScore: 10})
+	ownFile, otherFile := uuid.NewString(), uuid.NewString()
+	f.submissionRepo.On("FindByAssignmentAndStudent", mock.Anything, f.assignment.ID, "student-1").Return([]*entities.AssignmentSubmission{}, nil).Once()
+	f.submissionFileRepo.On("CountUploadedBy", mock.Anything, []string{ownFile, otherFile}, "student-1", "Bearer student-token").Return(1, nil).Once()
+
+	_, err := f.submitUseCase().Execute(context.Background(), f.assignment.ID, "student-1", "", "Bearer student-token", dtos.SubmitAssignmentInput{
+		FileIDs: []string{ownFile, otherFile},
+	})
+	require.ErrorIs(t, err, entities.ErrInvalidSubmission)
+	f.submissionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubmitAssignment_SubmissionLimitReached(t *testing.T) {
+	f := newAssignmentFixture(t, entities.AssignmentSettings{AllowText: true, MaxScore: 10, MaxSubmissions: intPtr(1)})
+	text := "first try"
+	earlier, err := entities.NewAssignmentSubmission(f.assignment, "student-1", "", 1, &text, nil, time.Now())
+	require.NoError(t, err)
+	f.submissionRepo.On("FindByAssignmentAndStudent", mock.Anything, f.assignment.ID, "student-1").Return([]*entities.AssignmentSubmission{earlier}, nil).Once()
+
+	_, err = f.submitUseCase().Execute(context.Background(), f.assignment.ID, "student-1", "", "Bearer student-token", dtos.SubmitAssignmentInput{Text: &text})
+	require.ErrorIs(t, err, usecases.ErrSubmissionLimitReached)
+	f.submissionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubmitAssignment_BlockedAfterDueDate(t *testing.T) {
+	dueAt := time.Now().Add(-time.Minute)
+	f := newAssignmentFixture(t, entities.AssignmentSettings{AllowText: true, MaxScore: 10, DueAt: &dueAt, LatePolicy: entities.LatePolicyBlock})
+	f.submissionRepo.On("FindByAssignmentAndStudent", mock.Anything, f.assignment.ID, "student-1").Return([]*entities.AssignmentSubmission{}, nil).Once()
+	text := "too late"
+
+	_, err := f.submitUseCase().Execute(context.Background(), f.assignment.ID, "student-1", "", "Bearer student-token", dtos.SubmitAssignmentInput{Text: &text})
+	require.ErrorIs(t, err, entities.ErrAssignmentPastDue)
+	f.submissionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestGradeAssignmentSubmission_GradesAndPublishes(t *testing.T) {
+	dueAt := time.Now().Add(-time.Hour)
+	f := newAssignmentFixture(t, entities.AssignmentSettings{
+		AllowText:          true,
+		MaxScore:           20,
+		DueAt:              &dueAt,
+		LatePolicy:         entities.LatePolicyPenalize,
+		LatePenaltyPercent: 50,
+	})
+	text := "late essay"
+	submission, err := entities.NewAssignmentSubmission(f.assignment, "student-1", "student@example.com", 1, &text, nil, time.Now())
+	require.NoError(t, err)
+	f.submissionRepo.On("FindByID", mock.Anything, submission.ID).Return(submission, nil).Once()
+	f.submissionRepo.On("Update", mock.Anything, submission).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeAssignmentGraded, mock.MatchedBy(func(e events.AssignmentGradedEvent) bool {
+		return e.ID == submission.ID && e.Score == 16 && e.FinalScore == 8 && e.MaxScore == 20 &&
+			e.GradedBy == "instructor-1" && e.StudentEmail == "student@example.com" && *e.Feedback == "Well argued."
+	})).Return(nil).Once()
+
+	feedback := "Well argued."
+	result, err := f.gradeUseCase().Execute(context.Background(), submission.ID, "instructor-1", dtos.GradeAssignmentSubmissionInput{
+		Score:    floatPtr(16),
+		Feedback: &feedback,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, string(entities.AssignmentSubmissionStatusGraded), result.Status)
+	assert.Equal(t, 8.0, *result.FinalScore)
+	f.submissionRepo.AssertExpectations(t)
+	f.publisher.AssertExpectations(t)
+}
+
+func TestGradeAssignmentSubmission_ScoreAboveMax(t *testing.T) {
+	f := newAssignmentFixture(t, entities.AssignmentSettings{AllowText: true, MaxScore: 10})
+	text := "essay"
+	submission, err := entities.NewAssignmentSubmission(f.assignment, "student-1", "", 1, &text, nil, time.Now())
+	require.NoError(t, err)
+	f.submissionRepo.On("FindByID", mock.Anything, submission.ID).Return(submission, nil).Once()
+
+	_, err = f.gradeUseCase().Execute(context.Background(), submission.ID, "instructor-1", dtos.GradeAssignmentSubmissionInput{Score: floatPtr(11)})
+	require.ErrorIs(t, err, entities.ErrInvalidScore)
+	f.submissionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,162 @@
+package unit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignment_ValidatesSettings(t *testing.T) {
+	dueAt := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		settings entities.AssignmentSettings
+		wantErr  error
+	}{
+		{
+			name:     "defaults to accepting late submissions",
+			settings: entities.AssignmentSettings{AllowFiles: true, MaxScore: 10},
+		},
+		{
+			name:     "needs a submission type",
+			settings: entities.AssignmentSettings{MaxScore: 10},
+			wantErr:  entities.ErrAssignmentSubmissionTypeRequired,
+		},
+		{
+			name:     "needs a positive max score",
+			settings: entities.AssignmentSettings{AllowText: true},
+			wantErr:  entities.ErrInvalidAssignmentMaxScore,
+		},
+		{
+			name:     "needs at least one submission",
+			settings: entities.AssignmentSettings{AllowText: true, MaxScore: 10, MaxSubmissions: intPtr(0)},
+			wantErr:  entities.ErrInvalidMaxSubmissions,
+		},
+		{
+			name:     "rejects unknown late policies",
+			settings: entities.AssignmentSettings{AllowText: true, MaxScore: 10, DueAt: &dueAt, LatePolicy: "forgive"},
+			wantErr:  entities.ErrInvalidLatePolicy,
+		},
+		{
+			name:     "blocking needs a due date",
+			settings: entities.AssignmentSettings{AllowText: true, MaxScore: 10, LatePolicy: entities.LatePolicyBlock},
+			wantErr:  entities.ErrAssignmentDueRequired,
+		},
+		{
+			name:     "penalty over 100 percent",
+			settings: entities.AssignmentSettings{AllowText: true, MaxScore: 10, DueAt: &dueAt, LatePolicy: entities.LatePolicyPenalize, LatePenaltyPercent: 120},
+			wantErr:  entities.ErrInvalidLatePenalty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignment, err := entities.NewAssignment("module-1", "offering-1", tt.settings)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entities.LatePolicyAccept, assignment.LatePolicy)
+		})
+	}
+}
+
+func TestAssignmentSubmission_LatePolicies(t *testing.T) {
+	dueAt := time.Now().Add(-time.Hour)
+	fileID := uuid.NewString()
+
+	tests := []struct {
+		name        string
+		policy      entities.LatePolicy
+		penalty     float64
+		wantErr     error
+		wantPenalty float64
+	}{
+		{name: "accept", policy: entities.LatePolicyAccept, penalty: 50},
+		{name: "penalize", policy: entities.LatePolicyPenalize, penalty: 25, wantPenalty: 25},
+		{name: "block", policy: entities.LatePolicyBlock, wantErr: entities.ErrAssignmentPastDue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignment, err := entities.NewAssignment("module-1", "offering-1", entities.AssignmentSettings{
+				AllowFiles:         true,
+				DueAt:              &dueAt,
+				LatePolicy:         tt.policy,
+				LatePenaltyPercent: tt.penalty,
+				MaxScore:           100,
+			})
+			require.NoError(t, err)
+
+			submission, err := entities.NewAssignmentSubmission(assignment, "student-1", "student@example.com", 1, nil, []string{fileID}, time.Now())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, submission.Late)
+			assert.Equal(t, tt.wantPenalty, submission.PenaltyPercent)
+		})
+	}
+}
+
+func TestAssignmentSubmission_ChecksContent(t *testing.T) {
+	assignment, err := entities.NewAssignment("module-1", "offering-1", entities.AssignmentSettings{AllowFiles: true, MaxScore: 10})
+	require.NoError(t, err)
+	text := "my essay"
+	blank := "   "
+
+	_, err = entities.NewAssignmentSubmission(assignment, "student-1", "", 1, &blank, nil, time.Now())
+	require.ErrorIs(t, err, entities.ErrEmptySubmission)
+
+	_, err = entities.NewAssignmentSubmission(assignment, "student-1", "", 1, &text, nil, time.Now())
+	require.ErrorIs(t, err, entities.ErrInvalidSubmission)
+
+	_, err = entities.NewAssignmentSubmission(assignment, "student-1", "", 1, nil, []string{"not-a-file"}, time.Now())
+	require.ErrorIs(t, err, entities.ErrInvalidSubmission)
+
+	fileID := uuid.NewString()
+	_, err = entities.NewAssignmentSubmission(assignment, "student-1", "", 1, nil, []string{fileID, fileID}, time.Now())
+	require.ErrorIs(t, err, entities.ErrInvalidSubmission)
+
+	submission, err := entities.NewAssignmentSubmission(assignment, "student-1", "", 1, nil, []string{fileID}, time.Now())
+	require.NoError(t, err)
+	assert.False(t, submission.Late)
+	assert.Equal(t, entities.AssignmentSubmissionStatusSubmitted, submission.Status)
+}
+
+func TestAssignmentSubmission_GradeAppliesPenalty(t *testing.T) {
+	dueAt := time.Now().Add(-time.Hour)
+	assignment, err := entities.NewAssignment("module-1", "offering-1", entities.AssignmentSettings{
+		AllowText:          true,
+		DueAt:              &dueAt,
+		LatePolicy:         entities.LatePolicyPenalize,
+		LatePenaltyPercent: 10,
+		MaxScore:           50,
+	})
+	require.NoError(t, err)
+	text := "late work"
+	submission, err := entities.NewAssignmentSubmission(assignment, "student-1", "", 1, &text, nil, time.Now())
+	require.NoError(t, err)
+
+	require.ErrorIs(t, submission.Grade(assignment, 51, nil, "instructor-1", time.Now()), entities.ErrInvalidScore)
+	assert.Nil(t, submission.Score)
+
+	feedback := "Good, but late."
+	require.NoError(t, submission.Grade(assignment, 45, &feedback, "instructor-1", time.Now()))
+	assert.Equal(t, entities.AssignmentSubmissionStatusGraded, submission.Status)
+	assert.Equal(t, 45.0, *submission.Score)
+	assert.Equal(t, 40.5, *submission.FinalScore)
+	assert.Equal(t, "instructor-1", *submission.GradedBy)
+
+	require.NoError(t, submission.Grade(assignment, 30, nil, "instructor-2", time.Now()))
+	assert.Equal(t, 27.0, *submission.FinalScore)
+	assert.Nil(t, submission.Feedback)
+	assert.Equal(t, "instructor-2", *submission.GradedBy)
+}
@@ -0,0 +1,84 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type createQuizMocks struct {
+	quizRepo     *mocks.MockQuizRepository
+	questionRepo *mocks.MockQuestionRepository
+	moduleRepo   *mocks.MockSectionModuleRepository
+	sectionRepo  *mocks.MockCourseSectionRepository
+	offeringRepo *mocks.MockCourseOfferingRepository
+}
+
+func newCreateQuizUseCase(module *entities.SectionModule) (*usecases.CreateQuizUseCase, createQuizMocks) {
+	m := createQuizMocks{
+		quizRepo:     new(mocks.MockQuizRepository),
+		questionRepo: new(mocks.MockQuestionRepository),
+		moduleRepo:   new(mocks.MockSectionModuleRepository),
+		sectionRepo:  new(mocks.MockCourseSectionRepository),
+		offeringRepo: new(mocks.MockCourseOfferingRepository),
+	}
+	m.moduleRepo.On("FindByID", mock.Anything, module.ID).Return(module, nil)
+	m.quizRepo.On("FindBySectionModuleID", mock.Anything, module.ID).Return(nil, nil)
+	m.sectionRepo.On("FindByID", mock.Anything, "section-1").Return(&entities.CourseSection{ID: "section-1", CourseOfferingID: "offering-1"}, nil)
+	m.offeringRepo.On("FindByID", mock.Anything, "offering-1").Return(&entities.CourseOffering{ID: "offering-1", CourseID: "course-1"}, nil)
+	uc := usecases.NewCreateQuizUseCase(m.quizRepo, m.questionRepo, m.moduleRepo, m.sectionRepo, m.offeringRepo, logger.NewNop())
+	return uc, m
+}
+
+func TestCreateQuiz_AttachesQuizToModule(t *testing.T) {
+	module := entities.NewSectionModule("section-1", "Week 1 quiz", "", entities.ContentTypeQuiz, 1)
+	uc, m := newCreateQuizUseCase(module)
+	m.questionRepo.On("FindByIDs", mock.Anything, []string{"question-1"}).Return([]*entities.Question{{ID: "question-1", CourseID: "course-1"}}, nil).Once()
+	m.quizRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Quiz")).Return(nil).Once()
+	m.moduleRepo.On("Update", mock.Anything, module).Return(nil).Once()
+
+	result, err := uc.Execute(context.Background(), dtos.CreateQuizInput{
+		SectionModuleID: module.ID,
+		QuestionIDs:     []string{"question-1"},
+		MaxAttempts:     intPtr(2),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "course-1", result.CourseID)
+	assert.Equal(t, string(entities.ResultsVisibilityImmediate), result.ResultsVisibility)
+	require.NotNil(t, module.ContentID)
+	assert.Equal(t, result.ID, *module.ContentID)
+	assert.Equal(t, entities.ContentStatusCreated, module.ContentStatus)
+	m.quizRepo.AssertExpectations(t)
+	m.moduleRepo.AssertExpectations(t)
+}
+
+func TestCreateQuiz_RejectsOtherModuleTypes(t *testing.T) {
+	module := entities.NewSectionModule("section-1", "Reading", "", entities.ContentTypeText, 1)
+	uc, m := newCreateQuizUseCase(module)
+
+	_, err := uc.Execute(context.Background(), dtos.CreateQuizInput{SectionModuleID: module.ID, QuestionIDs: []string{"question-1"}})
+	require.ErrorIs(t, err, usecases.ErrNotQuizModule)
+	m.quizRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateQuiz_RejectsQuestionsFromOtherCourses(t *testing.T) {
+	module := entities.NewSectionModule("section-1", "Week 1 quiz", "", entities.ContentTypeQuiz, 1)
+	uc, m := newCreateQuizUseCase(module)
+	m.questionRepo.On("FindByIDs", mock.Anything, []string{"question-1", "question-2"}).Return([]*entities.Question{
+		{ID: "question-1", CourseID: "course-1"},
+		{ID: "question-2", CourseID: "course-2"},
+	}, nil).Once()
+
+	_, err := uc.Execute(context.Background(), dtos.CreateQuizInput{SectionModuleID: module.ID, QuestionIDs: []string{"question-1", "question-2"}})
+	require.ErrorIs(t, err, usecases.ErrQuestionNotInBank)
+	m.quizRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -47,7 +47,7 @@ func TestCreateSectionModule_Success(t *testing.T) {
 	})).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeSectionModuleCreated, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	dto, err := uc.Execute(context.Background(), sectionID, input)
 	require.NoError(t, err)
@@ -92,7 +92,7 @@ func TestCreateSectionModule_SectionNotFound(t *testing.T) {
 
 	sectionRepo.On("FindByID", mock.Anything, sectionID).Return((*entities.CourseSection)(nil), nil).Once()
 
-	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	_, err := uc.Execute(context.Background(), sectionID, input)
 	require.Error(t, err)
@@ -131,7 +131,7 @@ func TestCreateSectionModule_RepositoryError(t *testing.T) {
 	sectionRepo.On("FindByID", mock.Anything, sectionID).Return(section, nil).Once()
 	moduleRepo.On("Create", mock.Anything, mock.Anything).Return(assert.AnError).Once()
 
-	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger)
+	uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger, "")
 
 	_, err := uc.Execute(context.Background(), sectionID, input)
 	require.Error(t, err)
@@ -142,3 +142,130 @@ func TestCreateSectionModule_RepositoryError(t *testing.T) {
 	publisher.AssertExpectations(t)
 }
 
+func newSectionModuleTestSection(sectionID string) *entities.CourseSection {
+	return &entities.CourseSection{
+		ID:               sectionID,
+		CourseOfferingID: "offering-123",
+		Name:             "Introduction",
+		Status:           entities.SectionStatusDraft,
+	}
+}
+
+func TestCreateSectionModule_ContentTypes(t *testing.T) {
+	fileID := "8f8a1a63-3a3c-4c3e-9d59-1c1f7a0d2b11"
+	body := "# Reading\n\nChapter one."
+	link := "https://example.com/article"
+	embed := "https://www.youtube.com/embed/abc123"
+
+	tests := []struct {
+		name  string
+		input dtos.CreateSectionModuleInput
+		check func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO)
+	}{
+		{
+			name:  "video streams from the course-videos bucket",
+			input: dtos.CreateSectionModuleInput{Name: "Lecture", ContentType: "video", ContentID: &fileID},
+			check: func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO) {
+				assert.Equal(t, &fileID, module.ContentID)
+				assert.Equal(t, "http://localhost:3000/api/v1/buckets/course-videos/files/"+fileID+"/download", dto.StreamURL)
+				assert.Empty(t, dto.DownloadURL)
+			},
+		},
+		{
+			name:  "document downloads from file-service",
+			input: dtos.CreateSectionModuleInput{Name: "Syllabus", ContentType: "document", ContentID: &fileID},
+			check: func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO) {
+				assert.Equal(t, "http://localhost:3000/api/v1/files/"+fileID+"/download", dto.DownloadURL)
+				assert.Empty(t, dto.StreamURL)
+			},
+		},
+		{
+			name:  "text keeps its markdown",
+			input: dtos.CreateSectionModuleInput{Name: "Reading", ContentType: "text", ContentBody: &body},
+			check: func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO) {
+				assert.Nil(t, module.ContentID)
+				assert.Equal(t, &body, dto.ContentBody)
+			},
+		},
+		{
+			name:  "link keeps its url",
+			input: dtos.CreateSectionModuleInput{Name: "Article", ContentType: "link", ContentURL: &link},
+			check: func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO) {
+				assert.Equal(t, &link, dto.ContentURL)
+			},
+		},
+		{
+			name:  "embed keeps its url",
+			input: dtos.CreateSectionModuleInput{Name: "Talk", ContentType: "embed", ContentURL: &embed},
+			check: func(t *testing.T, module *entities.SectionModule, dto *dtos.SectionModuleDTO) {
+				assert.Equal(t, &embed, dto.ContentURL)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moduleRepo := new(mocks.MockSectionModuleRepository)
+			sectionRepo := new(mocks.MockCourseSectionRepository)
+			publisher := new(sharedMocks.MockPublisher)
+
+			var createdModule *entities.SectionModule
+			sectionRepo.On("FindByID", mock.Anything, "section-123").Return(newSectionModuleTestSection("section-123"), nil).Once()
+			moduleRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *entities.SectionModule) bool {
+				createdModule = m
+				return true
+			})).Return(nil).Once()
+			publisher.On("Publish", mock.Anything, events.EventTypeSectionModuleCreated, mock.Anything).Return(nil).Once()
+
+			uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger.NewNop(), "http://localhost:3000")
+
+			dto, err := uc.Execute(context.Background(), "section-123", tt.input)
+			require.NoError(t, err)
+			require.NotNil(t, createdModule)
+			assert.Equal(t, entities.ContentStatusCreated, createdModule.ContentStatus)
+			assertDTOEqualSectionModule(t, dto, createdModule)
+			tt.check(t, createdModule, dto)
+		})
+	}
+}
+
+func TestCreateSectionModule_InvalidContent(t *testing.T) {
+	fileID := "8f8a1a63-3a3c-4c3e-9d59-1c1f7a0d2b11"
+	notAFile := "lecture.mp4"
+	blank := "   "
+	insecure := "http://example.com/widget"
+	relative := "/courses/1"
+	link := "https://example.com"
+
+	tests := []struct {
+		name    string
+		input   dtos.CreateSectionModuleInput
+		wantErr error
+	}{
+		{"unknown type", dtos.CreateSectionModuleInput{Name: "Quiz", ContentType: "scorm"}, usecases.ErrInvalidContentType},
+		{"zoom with content", dtos.CreateSectionModuleInput{Name: "Live", ContentType: "zoom", ContentURL: &link}, usecases.ErrInvalidModuleContent},
+		{"video without file", dtos.CreateSectionModuleInput{Name: "Lecture", ContentType: "video"}, usecases.ErrInvalidModuleContent},
+		{"video with non-uuid file", dtos.CreateSectionModuleInput{Name: "Lecture", ContentType: "video", ContentID: &notAFile}, usecases.ErrInvalidModuleContent},
+		{"document with url", dtos.CreateSectionModuleInput{Name: "Syllabus", ContentType: "document", ContentID: &fileID, ContentURL: &link}, usecases.ErrInvalidModuleContent},
+		{"blank text", dtos.CreateSectionModuleInput{Name: "Reading", ContentType: "text", ContentBody: &blank}, usecases.ErrInvalidModuleContent},
+		{"relative link", dtos.CreateSectionModuleInput{Name: "Article", ContentType: "link", ContentURL: &relative}, usecases.ErrInvalidModuleContent},
+		{"insecure embed", dtos.CreateSectionModuleInput{Name: "Widget", ContentType: "embed", ContentURL: &insecure}, usecases.ErrInvalidModuleContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moduleRepo := new(mocks.MockSectionModuleRepository)
+			sectionRepo := new(mocks.MockCourseSectionRepository)
+			publisher := new(sharedMocks.MockPublisher)
+
+			sectionRepo.On("FindByID", mock.Anything, "section-123").Return(newSectionModuleTestSection("section-123"), nil).Once()
+
+			uc := usecases.NewCreateSectionModuleUseCase(moduleRepo, sectionRepo, publisher, logger.NewNop(), "")
+
+			_, err := uc.Execute(context.Background(), "section-123", tt.input)
+			require.ErrorIs(t, err, tt.wantErr)
+			moduleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
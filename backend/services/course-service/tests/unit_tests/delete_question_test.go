@@ -0,0 +1,26 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteQuestion_InUseByQuiz(t *testing.T) {
+	questionRepo := new(mocks.MockQuestionRepository)
+	quizRepo := new(mocks.MockQuizRepository)
+	questionRepo.On("FindByID", mock.Anything, "question-1").Return(&entities.Question{ID: "question-1", CourseID: "course-1"}, nil)
+	quizRepo.On("CountByQuestionID", mock.Anything, "question-1").Return(1, nil).Once()
+
+	uc := usecases.NewDeleteQuestionUseCase(questionRepo, quizRepo, logger.NewNop())
+	require.ErrorIs(t, uc.Execute(context.Background(), "course-1", "question-1"), usecases.ErrQuestionInUse)
+	questionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+
+	require.ErrorIs(t, uc.Execute(context.Background(), "course-2", "question-1"), usecases.ErrQuestionNotFound)
+}
@@ -0,0 +1,56 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/infrastructure/external/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileServiceStub(t *testing.T, files map[string]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer student-token", r.Header.Get("Authorization"))
+		file, ok := files[strings.TrimPrefix(r.URL.Path, "/api/v1/files/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(file))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFileClient_CountsOnlyTheStudentsSubmissionFiles(t *testing.T) {
+	server := newFileServiceStub(t, map[string]map[string]interface{}{
+		"own":     {"bucket_name": "assignment-submissions", "uploaded_by": "student-1"},
+		"other":   {"bucket_name": "assignment-submissions", "uploaded_by": "student-2"},
+		"avatar":  {"bucket_name": "user-avatars", "uploaded_by": "student-1"},
+		"deleted": {"bucket_name": "assignment-submissions", "uploaded_by": "student-1", "deleted_at": time.Now()},
+	})
+
+	count, err := files.NewFileClient(server.URL).CountUploadedBy(context.Background(),
+		[]string{"own", "other", "avatar", "deleted", "missing"}, "student-1", "Bearer student-token")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestFileClient_FileServiceErrorIsReturned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := files.NewFileClient(server.URL).CountUploadedBy(context.Background(), []string{"own"}, "student-1", "")
+
+	assert.Error(t, err)
+}
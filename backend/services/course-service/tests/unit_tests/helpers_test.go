@@ -80,3 +80,10 @@ func assertDTOEqualCourseOfferingInstructor(t *testing.T, dto *dtos.CourseOfferi
 	require.Equal(t, instructor.InstructorUsername, dto.InstructorUsername)
 }
 
+func intPtr(i int) *int {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,419 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/policies"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	policyOfferingID   = "11111111-1111-1111-1111-111111111111"
+	policySectionID    = "22222222-2222-2222-2222-222222222222"
+	policyModuleID     = "33333333-3333-3333-3333-333333333333"
+	policyCourseID     = "44444444-4444-4444-4444-444444444444"
+	policyQuizID       = "55555555-5555-5555-5555-555555555555"
+	policyAssignmentID = "66666666-6666-6666-6666-666666666666"
+	policySubmissionID = "77777777-7777-7777-7777-777777777777"
+	policyAttemptID    = "88888888-8888-8888-8888-888888888888"
+)
+
+type offeringAccessMocks struct {
+	instructorRepo *mocks.MockCourseOfferingInstructorRepository
+	sectionRepo    *mocks.MockCourseSectionRepository
+	moduleRepo     *mocks.MockSectionModuleRepository
+	offeringRepo   *mocks.MockCourseOfferingRepository
+	quizRepo       *mocks.MockQuizRepository
+	assignmentRepo *mocks.MockAssignmentRepository
+	submissionRepo *mocks.MockAssignmentSubmissionRepository
+	attemptRepo    *mocks.MockQuizAttemptRepository
+	enrollmentRepo *mocks.MockOfferingEnrollmentRepository
+}
+
+func newOfferingAccess() (*policies.OfferingAccess, offeringAccessMocks) {
+	m := offeringAccessMocks{
+		instructorRepo: new(mocks.MockCourseOfferingInstructorRepository),
+		sectionRepo:    new(mocks.MockCourseSectionRepository),
+		moduleRepo:     new(mocks.MockSectionModuleRepository),
+		offeringRepo:   new(mocks.MockCourseOfferingRepository),
+		quizRepo:       new(mocks.MockQuizRepository),
+		assignmentRepo: new(mocks.MockAssignmentRepository),
+		submissionRepo: new(mocks.MockAssignmentSubmissionRepository),
+		attemptRepo:    new(mocks.MockQuizAttemptRepository),
+		enrollmentRepo: new(mocks.MockOfferingEnrollmentRepository),
+	}
+	return policies.NewOfferingAccess(m.instructorRepo, m.sectionRepo, m.moduleRepo, m.offeringRepo, m.quizRepo, m.assignmentRepo, m.submissionRepo, m.attemptRepo, m.enrollmentRepo), m
+}
+
+func (m offeringAccessMocks) assignInstructor(instructorID string) {
+	m.instructorRepo.On("FindByOfferingID", mock.Anything, policyOfferingID).Return([]*entities.CourseOfferingInstructor{
+		entities.NewCourseOfferingInstructor(policyOfferingID, instructorID, "instructor"),
+	}, nil)
+}
+
+func newOfferingPolicyRouter(access *policies.OfferingAccess) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	manageOffering := policy.Require(policy.Policy{
+		Name: "course_offering:manage",
+		Rule: policy.AnyOf(
+			policy.HasRole(valueobjects.RoleAdmin),
+			policy.Owns(valueobjects.RoleInstructor, policy.Param("offering_id"), access.TeachesOffering),
+		),
+	}, logger.NewNop())
+	router.PUT("/api/v1/course-offerings/:offering_id", manageOffering, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func offeringUpdateRequest(userID, role string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/course-offerings/"+policyOfferingID, nil)
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+		req.Header.Set("X-User-Role", role)
+	}
+	return req
+}
+
+func TestOfferingAccess_TeachesOffering(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+
+	teaches, err := access.TeachesOffering(context.Background(), "instructor-1", policyOfferingID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+
+	teaches, err = access.TeachesOffering(context.Background(), "instructor-2", policyOfferingID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+}
+
+func TestOfferingAccess_TeachesModuleThroughSection(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+	m.moduleRepo.On("FindByID", mock.Anything, policyModuleID).Return(&entities.SectionModule{ID: policyModuleID, CourseSectionID: policySectionID}, nil).Once()
+	m.sectionRepo.On("FindByID", mock.Anything, policySectionID).Return(&entities.CourseSection{ID: policySectionID, CourseOfferingID: policyOfferingID}, nil).Once()
+
+	teaches, err := access.TeachesModule(context.Background(), "instructor-1", policyModuleID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+}
+
+func TestOfferingAccess_TeachesCourseThroughAnyOffering(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+	otherOfferingID := "66666666-6666-6666-6666-666666666666"
+	m.offeringRepo.On("FindByCourseID", mock.Anything, policyCourseID).Return([]*entities.CourseOffering{
+		{ID: otherOfferingID, CourseID: policyCourseID},
+		{ID: policyOfferingID, CourseID: policyCourseID},
+	}, nil)
+	m.instructorRepo.On("FindByOfferingID", mock.Anything, otherOfferingID).Return([]*entities.CourseOfferingInstructor{}, nil)
+
+	teaches, err := access.TeachesCourse(context.Background(), "instructor-1", policyCourseID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+
+	teaches, err = access.TeachesCourse(context.Background(), "instructor-2", policyCourseID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+}
+
+func TestOfferingAccess_TeachesQuizThroughModule(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+	m.quizRepo.On("FindByID", mock.Anything, policyQuizID).Return(&entities.Quiz{ID: policyQuizID, SectionModuleID: policyModuleID}, nil).Once()
+	m.moduleRepo.On("FindByID", mock.Anything, policyModuleID).Return(&entities.SectionModule{ID: policyModuleID, CourseSectionID: policySectionID}, nil).Once()
+	m.sectionRepo.On("FindByID", mock.Anything, policySectionID).Return(&entities.CourseSection{ID: policySectionID, CourseOfferingID: policyOfferingID}, nil).Once()
+
+	teaches, err := access.TeachesQuiz(context.Background(), "instructor-1", policyQuizID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+}
+
+func TestOfferingAccess_TeachesSubmissionThroughAssignment(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+	m.submissionRepo.On("FindByID", mock.Anything, policySubmissionID).Return(&entities.AssignmentSubmission{ID: policySubmissionID, AssignmentID: policyAssignmentID}, nil).Twice()
+	m.assignmentRepo.On("FindByID", mock.Anything, policyAssignmentID).Return(&entities.Assignment{ID: policyAssignmentID, CourseOfferingID: policyOfferingID}, nil).Twice()
+
+	teaches, err := access.TeachesSubmission(context.Background(), "instructor-1", policySubmissionID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+
+	teaches, err = access.TeachesSubmission(context.Background(), "instructor-2", policySubmissionID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+}
+
+func (m offeringAccessMocks) placeQuizInOffering() {
+	m.quizRepo.On("FindByID", mock.Anything, policyQuizID).Return(&entities.Quiz{ID: policyQuizID, SectionModuleID: policyModuleID}, nil)
+	m.moduleRepo.On("FindByID", mock.Anything, policyModuleID).Return(&entities.SectionModule{ID: policyModuleID, CourseSectionID: policySectionID}, nil)
+	m.sectionRepo.On("FindByID", mock.Anything, policySectionID).Return(&entities.CourseSection{ID: policySectionID, CourseOfferingID: policyOfferingID}, nil)
+}
+
+func TestOfferingAccess_EnrolledInQuizThroughOffering(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.placeQuizInOffering()
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil).Once()
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-2").Return(false, nil).Once()
+
+	enrolled, err := access.EnrolledInQuiz(context.Background(), "student-1", policyQuizID)
+	require.NoError(t, err)
+	assert.True(t, enrolled)
+
+	enrolled, err = access.EnrolledInQuiz(context.Background(), "student-2", policyQuizID)
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+}
+
+func TestOfferingAccess_EnrolledInOwnAttemptOnly(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.placeQuizInOffering()
+	m.attemptRepo.On("FindByID", mock.Anything, policyAttemptID).Return(&entities.QuizAttempt{ID: policyAttemptID, QuizID: policyQuizID, StudentID: "student-1"}, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil).Once()
+
+	enrolled, err := access.EnrolledInAttempt(context.Background(), "student-1", policyAttemptID)
+	require.NoError(t, err)
+	assert.True(t, enrolled)
+
+	// Another enrolled student cannot reach the attempt.
+	enrolled, err = access.EnrolledInAttempt(context.Background(), "student-2", policyAttemptID)
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+	m.enrollmentRepo.AssertNumberOfCalls(t, "IsEnrolled", 1)
+}
+
+func TestOfferingAccess_AttemptAfterEnrollmentEndsIsNotOwned(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.placeQuizInOffering()
+	m.attemptRepo.On("FindByID", mock.Anything, policyAttemptID).Return(&entities.QuizAttempt{ID: policyAttemptID, QuizID: policyQuizID, StudentID: "student-1"}, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(false, nil).Once()
+
+	enrolled, err := access.EnrolledInAttempt(context.Background(), "student-1", policyAttemptID)
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+}
+
+func TestOfferingAccess_EnrolledInAssignmentThroughOffering(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignmentRepo.On("FindByID", mock.Anything, policyAssignmentID).Return(&entities.Assignment{ID: policyAssignmentID, CourseOfferingID: policyOfferingID}, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil).Once()
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-2").Return(false, nil).Once()
+
+	enrolled, err := access.EnrolledInAssignment(context.Background(), "student-1", policyAssignmentID)
+	require.NoError(t, err)
+	assert.True(t, enrolled)
+
+	enrolled, err = access.EnrolledInAssignment(context.Background(), "student-2", policyAssignmentID)
+	require.NoError(t, err)
+	assert.False(t, enrolled)
+}
+
+func TestOfferingAccess_MissingSectionIsNotOwned(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.sectionRepo.On("FindByID", mock.Anything, policySectionID).Return(nil, nil).Once()
+
+	teaches, err := access.TeachesSection(context.Background(), "instructor-1", policySectionID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+	m.instructorRepo.AssertNotCalled(t, "FindByOfferingID", mock.Anything, mock.Anything)
+}
+
+func TestOfferingAccess_InvalidIDIsNotOwned(t *testing.T) {
+	access, m := newOfferingAccess()
+
+	teaches, err := access.TeachesOffering(context.Background(), "instructor-1", "not-a-uuid")
+	require.NoError(t, err)
+	assert.False(t, teaches)
+	m.instructorRepo.AssertNotCalled(t, "FindByOfferingID", mock.Anything, mock.Anything)
+}
+
+func TestOfferingPolicy_AssignedInstructorAllowed(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("instructor-1", "instructor"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOfferingPolicy_OtherInstructorForbidden(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignInstructor("instructor-1")
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("instructor-2", "instructor"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var body struct {
+		Message string                 `json:"message"`
+		Details map[string]interface{} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, policy.ErrForbidden.Error(), body.Message)
+	assert.Equal(t, "course_offering:manage", body.Details["policy"])
+}
+
+func TestOfferingPolicy_AdminSkipsOwnershipLookup(t *testing.T) {
+	access, m := newOfferingAccess()
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("admin-1", "admin"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	m.instructorRepo.AssertNotCalled(t, "FindByOfferingID", mock.Anything, mock.Anything)
+}
+
+func TestOfferingPolicy_StudentForbidden(t *testing.T) {
+	access, m := newOfferingAccess()
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("student-1", "student"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	m.instructorRepo.AssertNotCalled(t, "FindByOfferingID", mock.Anything, mock.Anything)
+}
+
+func TestOfferingPolicy_AnonymousUnauthorized(t *testing.T) {
+	access, _ := newOfferingAccess()
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("", ""))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOfferingPolicy_LookupErrorFailsClosed(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.instructorRepo.On("FindByOfferingID", mock.Anything, policyOfferingID).Return(nil, errors.New("db down")).Once()
+
+	w := httptest.NewRecorder()
+	newOfferingPolicyRouter(access).ServeHTTP(w, offeringUpdateRequest("instructor-1", "instructor"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestQuizPolicy_OnlyEnrolledStudentsStartAttempts(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.placeQuizInOffering()
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-2").Return(false, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	takeQuiz := policy.Require(policy.Policy{
+		Name: "quiz:attempt",
+		Rule: policy.Owns(valueobjects.RoleStudent, policy.Param("quiz_id"), access.EnrolledInQuiz),
+	}, logger.NewNop())
+	router.POST("/api/v1/quizzes/:quiz_id/attempts", takeQuiz, func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	for studentID, want := range map[string]int{"student-1": http.StatusCreated, "student-2": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/quizzes/"+policyQuizID+"/attempts", nil)
+		req.Header.Set("X-User-ID", studentID)
+		req.Header.Set("X-User-Role", "student")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, want, w.Code, studentID)
+	}
+}
+
+func TestQuizPolicy_ReadByOfferingInstructorsAndEnrolledStudents(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.placeQuizInOffering()
+	m.assignInstructor("instructor-1")
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-2").Return(false, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	readQuiz := policy.Require(policy.Policy{
+		Name: "quiz:read",
+		Rule: policy.AnyOf(
+			policy.HasRole(valueobjects.RoleAdmin),
+			policy.Owns(valueobjects.RoleInstructor, policy.Param("quiz_id"), access.TeachesQuiz),
+			policy.Owns(valueobjects.RoleStudent, policy.Param("quiz_id"), access.EnrolledInQuiz),
+		),
+	}, logger.NewNop())
+	router.GET("/api/v1/quizzes/:quiz_id", readQuiz, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		userID string
+		role   string
+		want   int
+	}{
+		{"instructor-1", "instructor", http.StatusOK},
+		{"instructor-2", "instructor", http.StatusForbidden},
+		{"student-1", "student", http.StatusOK},
+		{"student-2", "student", http.StatusForbidden},
+		{"admin-1", "admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/quizzes/"+policyQuizID, nil)
+		req.Header.Set("X-User-ID", tt.userID)
+		req.Header.Set("X-User-Role", tt.role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, tt.userID)
+	}
+}
+
+func TestAssignmentPolicy_ReadByOfferingInstructorsAndEnrolledStudents(t *testing.T) {
+	access, m := newOfferingAccess()
+	m.assignmentRepo.On("FindByID", mock.Anything, policyAssignmentID).Return(&entities.Assignment{ID: policyAssignmentID, CourseOfferingID: policyOfferingID}, nil)
+	m.assignInstructor("instructor-1")
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-1").Return(true, nil)
+	m.enrollmentRepo.On("IsEnrolled", mock.Anything, policyOfferingID, "student-2").Return(false, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	readAssignment := policy.Require(policy.Policy{
+		Name: "assignment:read",
+		Rule: policy.AnyOf(
+			policy.HasRole(valueobjects.RoleAdmin),
+			policy.Owns(valueobjects.RoleInstructor, policy.Param("assignment_id"), access.TeachesAssignment),
+			policy.Owns(valueobjects.RoleStudent, policy.Param("assignment_id"), access.EnrolledInAssignment),
+		),
+	}, logger.NewNop())
+	router.GET("/api/v1/assignments/:assignment_id", readAssignment, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		userID string
+		role   string
+		want   int
+	}{
+		{"instructor-1", "instructor", http.StatusOK},
+		{"instructor-2", "instructor", http.StatusForbidden},
+		{"student-1", "student", http.StatusOK},
+		{"student-2", "student", http.StatusForbidden},
+		{"admin-1", "admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/assignments/"+policyAssignmentID, nil)
+		req.Header.Set("X-User-ID", tt.userID)
+		req.Header.Set("X-User-Role", tt.role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, tt.userID)
+	}
+}
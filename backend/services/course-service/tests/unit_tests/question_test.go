@@ -0,0 +1,113 @@
+package unit_test
+
+import (
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuestion_GradesEachType(t *testing.T) {
+	multipleChoice, err := entities.NewQuestion("course-1", entities.QuestionTypeMultipleChoice, "2 + 2?", 2, entities.AnswerKey{
+		Options:        []string{"3", "4", "5"},
+		CorrectOptions: []int{1},
+	})
+	require.NoError(t, err)
+	multiSelect, err := entities.NewQuestion("course-1", entities.QuestionTypeMultiSelect, "Primes?", 3, entities.AnswerKey{
+		Options:        []string{"2", "4", "5"},
+		CorrectOptions: []int{0, 2},
+	})
+	require.NoError(t, err)
+	trueFalse, err := entities.NewQuestion("course-1", entities.QuestionTypeTrueFalse, "The sky is blue.", 1, entities.AnswerKey{
+		CorrectOptions: []int{0},
+	})
+	require.NoError(t, err)
+	shortAnswer, err := entities.NewQuestion("course-1", entities.QuestionTypeShortAnswer, "Capital of France?", 1, entities.AnswerKey{
+		AcceptedAnswers: []string{"Paris"},
+	})
+	require.NoError(t, err)
+	numeric, err := entities.NewQuestion("course-1", entities.QuestionTypeNumeric, "Pi to two places?", 1, entities.AnswerKey{
+		NumericAnswer:    floatPtr(3.14),
+		NumericTolerance: 0.005,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		question *entities.Question
+		answer   entities.QuestionAnswer
+		want     float64
+	}{
+		{"multiple choice correct", multipleChoice, entities.QuestionAnswer{OptionIDs: []string{multipleChoice.Options[1].ID}}, 2},
+		{"multiple choice wrong", multipleChoice, entities.QuestionAnswer{OptionIDs: []string{multipleChoice.Options[0].ID}}, 0},
+		{"multi-select exact", multiSelect, entities.QuestionAnswer{OptionIDs: []string{multiSelect.Options[2].ID, multiSelect.Options[0].ID}}, 3},
+		{"multi-select partial", multiSelect, entities.QuestionAnswer{OptionIDs: []string{multiSelect.Options[0].ID}}, 0},
+		{"multi-select extra", multiSelect, entities.QuestionAnswer{OptionIDs: []string{multiSelect.Options[0].ID, multiSelect.Options[1].ID, multiSelect.Options[2].ID}}, 0},
+		{"true/false correct", trueFalse, entities.QuestionAnswer{OptionIDs: []string{entities.TrueOptionID}}, 1},
+		{"true/false wrong", trueFalse, entities.QuestionAnswer{OptionIDs: []string{entities.FalseOptionID}}, 0},
+		{"short answer ignores case and spaces", shortAnswer, entities.QuestionAnswer{Text: "  paris "}, 1},
+		{"short answer wrong", shortAnswer, entities.QuestionAnswer{Text: "Lyon"}, 0},
+		{"numeric within tolerance", numeric, entities.QuestionAnswer{Text: "3.141"}, 1},
+		{"numeric outside tolerance", numeric, entities.QuestionAnswer{Text: "3.2"}, 0},
+		{"numeric not a number", numeric, entities.QuestionAnswer{Text: "pi"}, 0},
+		{"unanswered", multipleChoice, entities.QuestionAnswer{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.question.Grade(tt.answer))
+		})
+	}
+}
+
+func TestQuestion_InvalidAnswerKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		questionType entities.QuestionType
+		key          entities.AnswerKey
+		wantErr      error
+	}{
+		{"unknown type", entities.QuestionType("essay"), entities.AnswerKey{}, entities.ErrInvalidQuestionType},
+		{"one option", entities.QuestionTypeMultipleChoice, entities.AnswerKey{Options: []string{"a"}, CorrectOptions: []int{0}}, entities.ErrInvalidAnswerKey},
+		{"two correct options for multiple choice", entities.QuestionTypeMultipleChoice, entities.AnswerKey{Options: []string{"a", "b"}, CorrectOptions: []int{0, 1}}, entities.ErrInvalidAnswerKey},
+		{"correct option out of range", entities.QuestionTypeMultiSelect, entities.AnswerKey{Options: []string{"a", "b"}, CorrectOptions: []int{2}}, entities.ErrInvalidAnswerKey},
+		{"true/false without answer", entities.QuestionTypeTrueFalse, entities.AnswerKey{}, entities.ErrInvalidAnswerKey},
+		{"short answer without answers", entities.QuestionTypeShortAnswer, entities.AnswerKey{AcceptedAnswers: []string{" "}}, entities.ErrInvalidAnswerKey},
+		{"numeric without answer", entities.QuestionTypeNumeric, entities.AnswerKey{}, entities.ErrInvalidAnswerKey},
+		{"negative tolerance", entities.QuestionTypeNumeric, entities.AnswerKey{NumericAnswer: floatPtr(1), NumericTolerance: -1}, entities.ErrInvalidAnswerKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entities.NewQuestion("course-1", tt.questionType, "Prompt", 1, tt.key)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := entities.NewQuestion("course-1", entities.QuestionTypeNumeric, " ", 1, entities.AnswerKey{NumericAnswer: floatPtr(1)})
+	require.ErrorIs(t, err, entities.ErrQuestionPromptRequired)
+	_, err = entities.NewQuestion("course-1", entities.QuestionTypeNumeric, "Prompt", 0, entities.AnswerKey{NumericAnswer: floatPtr(1)})
+	require.ErrorIs(t, err, entities.ErrInvalidQuestionPoints)
+}
+
+func TestQuestion_ReviseKeepsIDsOfUnchangedOptions(t *testing.T) {
+	question, err := entities.NewQuestion("course-1", entities.QuestionTypeMultipleChoice, "2 + 2?", 2, entities.AnswerKey{
+		Options:        []string{"3", "4", "5"},
+		CorrectOptions: []int{1},
+	})
+	require.NoError(t, err)
+	saved := entities.QuestionAnswer{OptionIDs: []string{question.Options[1].ID}}
+	fourID, fiveID := question.Options[1].ID, question.Options[2].ID
+
+	// Fix a typo, drop an option and reorder the rest.
+	err = question.Revise("What is 2 + 2?", 2, entities.AnswerKey{
+		Options:        []string{"4", "22", "5"},
+		CorrectOptions: []int{0},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, fourID, question.Options[0].ID)
+	assert.Equal(t, fiveID, question.Options[2].ID)
+	assert.NotContains(t, []string{fourID, fiveID}, question.Options[1].ID)
+	assert.Equal(t, []string{fourID}, question.CorrectOptionIDs)
+	assert.Equal(t, 2.0, question.Grade(saved))
+}
@@ -0,0 +1,352 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type quizFixture struct {
+	quiz         *entities.Quiz
+	choice       *entities.Question
+	numeric      *entities.Question
+	quizRepo     *mocks.MockQuizRepository
+	questionRepo *mocks.MockQuestionRepository
+	attemptRepo  *mocks.MockQuizAttemptRepository
+	publisher    *sharedMocks.MockPublisher
+}
+
+func newQuizFixture(t *testing.T, settings entities.QuizSettings) *quizFixture {
+	t.Helper()
+	choice, err := entities.NewQuestion("course-1", entities.QuestionTypeMultipleChoice, "2 + 2?", 2, entities.AnswerKey{
+		Options:        []string{"3", "4", "5"},
+		CorrectOptions: []int{1},
+	})
+	require.NoError(t, err)
+	numeric, err := entities.NewQuestion("course-1", entities.QuestionTypeNumeric, "10 / 4?", 1, entities.AnswerKey{
+		NumericAnswer: floatPtr(2.5),
+	})
+	require.NoError(t, err)
+
+	settings.QuestionIDs = []string{choice.ID, numeric.ID}
+	quiz, err := entities.NewQuiz("module-1", "course-1", settings)
+	require.NoError(t, err)
+
+	f := &quizFixture{
+		quiz:         quiz,
+		choice:       choice,
+		numeric:      numeric,
+		quizRepo:     new(mocks.MockQuizRepository),
+		questionRepo: new(mocks.MockQuestionRepository),
+		attemptRepo:  new(mocks.MockQuizAttemptRepository),
+		publisher:    new(sharedMocks.MockPublisher),
+	}
+	f.quizRepo.On("FindByID", mock.Anything, quiz.ID).Return(quiz, nil)
+	f.questionRepo.On("FindByIDs", mock.Anything, mock.Anything).Return([]*entities.Question{choice, numeric}, nil)
+	return f
+}
+
+func (f *quizFixture) startUseCase() *usecases.StartQuizAttemptUseCase {
+	return usecases.NewStartQuizAttemptUseCase(f.quizRepo, f.questionRepo, f.attemptRepo, f.publisher, logger.NewNop())
+}
+
+func (f *quizFixture) submitUseCase() *usecases.SubmitQuizAttemptUseCase {
+	return usecases.NewSubmitQuizAttemptUseCase(f.quizRepo, f.questionRepo, f.attemptRepo, f.publisher, logger.NewNop())
+}
+
+func TestStartQuizAttempt_CreatesAttemptWithoutAnswers(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(10), ShuffleOptions: true})
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{}, nil).Once()
+	f.attemptRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *entities.QuizAttempt) bool {
+		return a.StudentID == "student-1" && a.AttemptNumber == 1 && a.Status == entities.QuizAttemptStatusInProgress
+	})).Return(nil).Once()
+
+	result, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.NoError(t, err)
+
+	require.NotNil(t, result.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), *result.ExpiresAt, time.Minute)
+	assert.Nil(t, result.Score)
+	require.Len(t, result.Questions, 2)
+	for _, question := range result.Questions {
+		assert.Nil(t, question.Result)
+		assert.Empty(t, question.CorrectOptionIDs)
+		assert.Nil(t, question.NumericAnswer)
+	}
+	assert.Equal(t, f.choice.ID, result.Questions[0].ID)
+	assert.ElementsMatch(t, f.choice.Options, result.Questions[0].Options)
+	f.attemptRepo.AssertExpectations(t)
+}
+
+func TestStartQuizAttempt_ResumesAttemptInProgress(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	inProgress := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{inProgress}, nil).Once()
+
+	result, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, inProgress.ID, result.ID)
+	f.attemptRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestStartQuizAttempt_AttemptLimitReached(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{MaxAttempts: intPtr(1)})
+	submitted := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	require.NoError(t, submitted.Submit(nil, map[string]*entities.Question{}, time.Now()))
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{submitted}, nil).Once()
+
+	_, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.ErrorIs(t, err, usecases.ErrQuizAttemptLimitReached)
+	f.attemptRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestStartQuizAttempt_ExpiresStaleAttemptFirst(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(5), MaxAttempts: intPtr(1)})
+	stale := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	expiredAt := time.Now().Add(-time.Hour)
+	stale.ExpiresAt = &expiredAt
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{stale}, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, stale).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.MatchedBy(func(e events.QuizAttemptSubmittedEvent) bool {
+		return e.ID == stale.ID && e.Status == string(entities.QuizAttemptStatusExpired) && e.Score == 0
+	})).Return(nil).Once()
+
+	_, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.ErrorIs(t, err, usecases.ErrQuizAttemptLimitReached)
+	assert.Equal(t, entities.QuizAttemptStatusExpired, stale.Status)
+	f.attemptRepo.AssertExpectations(t)
+	f.publisher.AssertExpectations(t)
+}
+
+func TestStartQuizAttempt_ClosedQuiz(t *testing.T) {
+	closedAt := time.Now().Add(-time.Minute)
+	f := newQuizFixture(t, entities.QuizSettings{ClosesAt: &closedAt})
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{}, nil).Once()
+
+	_, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.ErrorIs(t, err, usecases.ErrQuizClosed)
+}
+
+func TestSubmitQuizAttempt_GradesAndPublishes(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.MatchedBy(func(e events.QuizAttemptSubmittedEvent) bool {
+		return e.StudentID == "student-1" && e.CourseID == "course-1" && e.SectionModuleID == "module-1" &&
+			e.Score == 2 && e.MaxScore == 3 && e.Status == string(entities.QuizAttemptStatusSubmitted)
+	})).Return(nil).Once()
+
+	result, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{
+			f.choice.ID:  {OptionIDs: []string{f.choice.Options[1].ID}},
+			f.numeric.ID: {Text: "3"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Score)
+	assert.Equal(t, 2.0, *result.Score)
+	assert.Equal(t, 3.0, *result.MaxScore)
+	for _, question := range result.Questions {
+		require.NotNil(t, question.Result)
+		assert.Equal(t, question.ID == f.choice.ID, question.Result.Correct)
+	}
+	f.attemptRepo.AssertExpectations(t)
+	f.publisher.AssertExpectations(t)
+}
+
+func TestSubmitQuizAttempt_ResultsVisibility(t *testing.T) {
+	closesAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name        string
+		settings    entities.QuizSettings
+		wantScore   bool
+		wantAnswers bool
+	}{
+		{"immediate", entities.QuizSettings{ResultsVisibility: entities.ResultsVisibilityImmediate}, true, true},
+		{"score only", entities.QuizSettings{ResultsVisibility: entities.ResultsVisibilityScoreOnly}, true, false},
+		{"after close while open", entities.QuizSettings{ResultsVisibility: entities.ResultsVisibilityAfterClose, ClosesAt: &closesAt}, false, false},
+		{"hidden", entities.QuizSettings{ResultsVisibility: entities.ResultsVisibilityHidden}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newQuizFixture(t, tt.settings)
+			attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+			f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+			f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+			f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.Anything).Return(nil).Once()
+
+			result, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantScore, result.Score != nil)
+			assert.Equal(t, tt.wantAnswers, result.Questions[0].Result != nil)
+			assert.Equal(t, tt.wantAnswers, len(result.Questions[0].CorrectOptionIDs) > 0)
+		})
+	}
+}
+
+func TestSubmitQuizAttempt_OtherStudentsAttemptNotFound(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+
+	_, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-2", dtos.SubmitQuizAttemptInput{})
+	require.ErrorIs(t, err, usecases.ErrQuizAttemptNotFound)
+	f.attemptRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSubmitQuizAttempt_AlreadySubmitted(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	require.NoError(t, attempt.Submit(nil, map[string]*entities.Question{}, time.Now()))
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+
+	_, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{})
+	require.ErrorIs(t, err, entities.ErrQuizAttemptClosed)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSubmitQuizAttempt_AfterTimeLimitGradesSavedAnswers(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(1)})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	require.NoError(t, attempt.SaveAnswers(map[string]entities.QuestionAnswer{
+		f.choice.ID: {OptionIDs: []string{f.choice.Options[1].ID}},
+	}, time.Now()))
+	expiredAt := time.Now().Add(-entities.QuizSubmissionGrace - time.Second)
+	attempt.ExpiresAt = &expiredAt
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.MatchedBy(func(e events.QuizAttemptSubmittedEvent) bool {
+		return e.Status == string(entities.QuizAttemptStatusExpired) && e.Score == 2 && e.MaxScore == 3
+	})).Return(nil).Once()
+
+	// The numeric answer arrives after time ran out and is not graded.
+	result, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{f.numeric.ID: {Text: "2.5"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, string(entities.QuizAttemptStatusExpired), result.Status)
+	require.NotNil(t, result.Score)
+	assert.Equal(t, 2.0, *result.Score)
+	assert.NotContains(t, attempt.Answers, f.numeric.ID)
+	f.publisher.AssertExpectations(t)
+}
+
+func TestSubmitQuizAttempt_WithinGraceIsGraded(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(1)})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	expiredAt := time.Now().Add(-entities.QuizSubmissionGrace / 2)
+	attempt.ExpiresAt = &expiredAt
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.Anything).Return(nil).Once()
+
+	result, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{f.numeric.ID: {Text: "2.5"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, string(entities.QuizAttemptStatusSubmitted), result.Status)
+	require.NotNil(t, result.Score)
+	assert.Equal(t, 1.0, *result.Score)
+}
+
+func TestSubmitQuizAttempt_AddsToSavedAnswers(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	require.NoError(t, attempt.SaveAnswers(map[string]entities.QuestionAnswer{
+		f.choice.ID: {OptionIDs: []string{f.choice.Options[1].ID}},
+	}, time.Now()))
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+	f.publisher.On("Publish", mock.Anything, events.EventTypeQuizAttemptSubmitted, mock.Anything).Return(nil).Once()
+
+	result, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{f.numeric.ID: {Text: "2.5"}},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Score)
+	assert.Equal(t, 3.0, *result.Score)
+}
+
+func TestSubmitQuizAttempt_ClosedConcurrentlyIsNotPublished(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(entities.ErrQuizAttemptClosed).Once()
+
+	_, err := f.submitUseCase().Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{})
+	require.ErrorIs(t, err, entities.ErrQuizAttemptClosed)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStartQuizAttempt_StaleAttemptClosedConcurrentlyIsReloaded(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(5), MaxAttempts: intPtr(1)})
+	stale := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	expiredAt := time.Now().Add(-time.Hour)
+	stale.ExpiresAt = &expiredAt
+	submitted := *stale
+	submitted.Status = entities.QuizAttemptStatusSubmitted
+	f.attemptRepo.On("FindByQuizAndStudent", mock.Anything, f.quiz.ID, "student-1").Return([]*entities.QuizAttempt{stale}, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, stale).Return(entities.ErrQuizAttemptClosed).Once()
+	f.attemptRepo.On("FindByID", mock.Anything, stale.ID).Return(&submitted, nil).Once()
+
+	_, err := f.startUseCase().Execute(context.Background(), f.quiz.ID, "student-1")
+	require.ErrorIs(t, err, usecases.ErrQuizAttemptLimitReached)
+	assert.Equal(t, entities.QuizAttemptStatusSubmitted, stale.Status)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaveQuizAttemptAnswers_SavesWithoutGrading(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{ResultsVisibility: entities.ResultsVisibilityImmediate})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+	f.attemptRepo.On("Update", mock.Anything, attempt).Return(nil).Once()
+
+	saveAnswers := usecases.NewSaveQuizAttemptAnswersUseCase(f.questionRepo, f.attemptRepo, logger.NewNop())
+	result, err := saveAnswers.Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{
+			f.numeric.ID:  {Text: "2.5"},
+			"not-in-quiz": {Text: "1"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, string(entities.QuizAttemptStatusInProgress), result.Status)
+	assert.Nil(t, result.Score)
+	assert.Equal(t, map[string]entities.QuestionAnswer{f.numeric.ID: {Text: "2.5"}}, attempt.Answers)
+	f.attemptRepo.AssertExpectations(t)
+	f.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaveQuizAttemptAnswers_AfterTimeLimit(t *testing.T) {
+	f := newQuizFixture(t, entities.QuizSettings{TimeLimitMinutes: intPtr(1)})
+	attempt := entities.NewQuizAttempt(f.quiz, []*entities.Question{f.choice, f.numeric}, "student-1", 1)
+	expiredAt := time.Now().Add(-entities.QuizSubmissionGrace - time.Second)
+	attempt.ExpiresAt = &expiredAt
+	f.attemptRepo.On("FindByID", mock.Anything, attempt.ID).Return(attempt, nil).Once()
+
+	saveAnswers := usecases.NewSaveQuizAttemptAnswersUseCase(f.questionRepo, f.attemptRepo, logger.NewNop())
+	_, err := saveAnswers.Execute(context.Background(), attempt.ID, "student-1", dtos.SubmitQuizAttemptInput{
+		Answers: map[string]entities.QuestionAnswer{f.numeric.ID: {Text: "2.5"}},
+	})
+	require.ErrorIs(t, err, entities.ErrQuizAttemptClosed)
+	assert.Empty(t, attempt.Answers)
+	f.attemptRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
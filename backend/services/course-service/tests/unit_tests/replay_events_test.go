@@ -0,0 +1,91 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayEvents_ReplaysInstructorAssignments(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	submissionRepo := new(mocks.MockAssignmentSubmissionRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	assignments := []*entities.CourseOfferingInstructor{
+		entities.NewCourseOfferingInstructor("offering-1", "instructor-1", "ada"),
+		entities.NewCourseOfferingInstructor("offering-2", "instructor-1", "ada"),
+	}
+	instructorRepo.On("FindAll", mock.Anything).Return(assignments, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeInstructorAssignmentReplayed, mock.Anything).Return(nil).Twice()
+	submissionRepo.On("FindWithFiles", mock.Anything).Return([]*entities.AssignmentSubmission{}, nil).Once()
+
+	uc := usecases.NewReplayEventsUseCase(instructorRepo, new(mocks.MockAssignmentRepository), submissionRepo, publisher, logger.NewNop())
+
+	require.NoError(t, uc.Execute(context.Background()))
+	publisher.AssertExpectations(t)
+	event, ok := publisher.Calls[1].Arguments.Get(2).(events.InstructorAssignedToOfferingEvent)
+	require.True(t, ok)
+	assert.Equal(t, "offering-2", event.CourseOfferingID)
+	assert.Equal(t, "instructor-1", event.InstructorID)
+}
+
+func TestReplayEvents_StopsWhenPublishingFails(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	assignments := []*entities.CourseOfferingInstructor{
+		entities.NewCourseOfferingInstructor("offering-1", "instructor-1", "ada"),
+		entities.NewCourseOfferingInstructor("offering-2", "instructor-1", "ada"),
+	}
+	instructorRepo.On("FindAll", mock.Anything).Return(assignments, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeInstructorAssignmentReplayed, mock.Anything).Return(errors.New("channel closed")).Once()
+
+	uc := usecases.NewReplayEventsUseCase(instructorRepo, new(mocks.MockAssignmentRepository), new(mocks.MockAssignmentSubmissionRepository), publisher, logger.NewNop())
+
+	// The request is redelivered, so a failed replay is retried as a whole.
+	require.Error(t, uc.Execute(context.Background()))
+	publisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestReplayEvents_ReplaysSubmissionsWithFiles(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	assignmentRepo := new(mocks.MockAssignmentRepository)
+	submissionRepo := new(mocks.MockAssignmentSubmissionRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	assignment, err := entities.NewAssignment("module-1", "offering-1", entities.AssignmentSettings{AllowFiles: true, MaxScore: 10})
+	require.NoError(t, err)
+	first, err := entities.NewAssignmentSubmission(assignment, "student-1", "", 1, nil, []string{uuid.NewString()}, time.Now())
+	require.NoError(t, err)
+	secondFile := uuid.NewString()
+	second, err := entities.NewAssignmentSubmission(assignment, "student-1", "", 2, nil, []string{secondFile}, time.Now())
+	require.NoError(t, err)
+
+	instructorRepo.On("FindAll", mock.Anything).Return([]*entities.CourseOfferingInstructor{}, nil).Once()
+	submissionRepo.On("FindWithFiles", mock.Anything).Return([]*entities.AssignmentSubmission{first, second}, nil).Once()
+	// Both submissions share the assignment, which is loaded once.
+	assignmentRepo.On("FindByID", mock.Anything, assignment.ID).Return(assignment, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeSubmissionReplayed, mock.Anything).Return(nil).Twice()
+
+	uc := usecases.NewReplayEventsUseCase(instructorRepo, assignmentRepo, submissionRepo, publisher, logger.NewNop())
+
+	require.NoError(t, uc.Execute(context.Background()))
+	assignmentRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+	event, ok := publisher.Calls[1].Arguments.Get(2).(events.AssignmentSubmittedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "offering-1", event.CourseOfferingID)
+	assert.Equal(t, []string{secondFile}, event.FileIDs)
+}
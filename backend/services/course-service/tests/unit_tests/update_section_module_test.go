@@ -46,7 +46,7 @@ func TestUpdateSectionModule_Success(t *testing.T) {
 	})).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeSectionModuleUpdated, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger)
+	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger, "")
 
 	dto, err := uc.Execute(context.Background(), moduleID, input)
 	require.NoError(t, err)
@@ -82,7 +82,7 @@ func TestUpdateSectionModule_ModuleNotFound(t *testing.T) {
 
 	moduleRepo.On("FindByID", mock.Anything, moduleID).Return((*entities.SectionModule)(nil), nil).Once()
 
-	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger)
+	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger, "")
 
 	_, err := uc.Execute(context.Background(), moduleID, input)
 	require.Error(t, err)
@@ -119,7 +119,7 @@ func TestUpdateSectionModule_RepositoryError(t *testing.T) {
 	moduleRepo.On("FindByID", mock.Anything, moduleID).Return(existingModule, nil).Once()
 	moduleRepo.On("Update", mock.Anything, mock.Anything).Return(assert.AnError).Once()
 
-	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger)
+	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger, "")
 
 	_, err := uc.Execute(context.Background(), moduleID, input)
 	require.Error(t, err)
@@ -129,3 +129,53 @@ func TestUpdateSectionModule_RepositoryError(t *testing.T) {
 	publisher.AssertExpectations(t)
 }
 
+func TestUpdateSectionModule_ReplacesContent(t *testing.T) {
+	moduleRepo := new(mocks.MockSectionModuleRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	oldBody := "Old notes"
+	newBody := "## Updated notes"
+	existingModule := &entities.SectionModule{
+		ID:              "module-123",
+		CourseSectionID: "section-123",
+		ContentBody:     &oldBody,
+		Name:            "Reading",
+		ContentType:     entities.ContentTypeText,
+		ContentStatus:   entities.ContentStatusCreated,
+	}
+
+	moduleRepo.On("FindByID", mock.Anything, "module-123").Return(existingModule, nil).Once()
+	moduleRepo.On("Update", mock.Anything, existingModule).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeSectionModuleUpdated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger.NewNop(), "")
+
+	dto, err := uc.Execute(context.Background(), "module-123", dtos.UpdateSectionModuleInput{Name: "Reading", ContentBody: &newBody})
+	require.NoError(t, err)
+	assert.Equal(t, &newBody, dto.ContentBody)
+
+	moduleRepo.AssertExpectations(t)
+}
+
+func TestUpdateSectionModule_ContentMustMatchType(t *testing.T) {
+	moduleRepo := new(mocks.MockSectionModuleRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	body := "Not a meeting"
+	existingModule := &entities.SectionModule{
+		ID:              "module-123",
+		CourseSectionID: "section-123",
+		Name:            "Live class",
+		ContentType:     entities.ContentTypeZoom,
+		ContentStatus:   entities.ContentStatusDraft,
+	}
+
+	moduleRepo.On("FindByID", mock.Anything, "module-123").Return(existingModule, nil).Once()
+
+	uc := usecases.NewUpdateSectionModuleUseCase(moduleRepo, publisher, logger.NewNop(), "")
+
+	_, err := uc.Execute(context.Background(), "module-123", dtos.UpdateSectionModuleInput{Name: "Live class", ContentBody: &body})
+	require.ErrorIs(t, err, usecases.ErrInvalidModuleContent)
+	moduleRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
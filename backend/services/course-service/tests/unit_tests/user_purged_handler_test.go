@@ -0,0 +1,65 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/course-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPurgedHandler_RemovesAssignments(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	attemptRepo := new(mocks.MockQuizAttemptRepository)
+	submissionRepo := new(mocks.MockAssignmentSubmissionRepository)
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: "user-123", Role: "instructor", PurgedAt: time.Now()})
+	require.NoError(t, err)
+
+	instructorRepo.On("DeleteByInstructorID", context.Background(), "user-123").Return(int64(2), nil).Once()
+	attemptRepo.On("DeleteByStudentID", context.Background(), "user-123").Return(int64(3), nil).Once()
+	submissionRepo.On("DeleteByStudentID", context.Background(), "user-123").Return(int64(1), nil).Once()
+
+	handler := handlers.NewUserPurgedHandler(instructorRepo, attemptRepo, submissionRepo, logger.NewNop())
+	require.NoError(t, handler.Handle(body))
+
+	instructorRepo.AssertExpectations(t)
+	attemptRepo.AssertExpectations(t)
+	submissionRepo.AssertExpectations(t)
+}
+
+func TestUserPurgedHandler_DeleteError(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	attemptRepo := new(mocks.MockQuizAttemptRepository)
+	submissionRepo := new(mocks.MockAssignmentSubmissionRepository)
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: "user-123", Role: "instructor", PurgedAt: time.Now()})
+	require.NoError(t, err)
+
+	instructorRepo.On("DeleteByInstructorID", mock.Anything, "user-123").Return(int64(0), assert.AnError).Once()
+
+	handler := handlers.NewUserPurgedHandler(instructorRepo, attemptRepo, submissionRepo, logger.NewNop())
+	require.ErrorIs(t, handler.Handle(body), assert.AnError)
+
+	instructorRepo.AssertExpectations(t)
+	attemptRepo.AssertNotCalled(t, "DeleteByStudentID", mock.Anything, mock.Anything)
+	submissionRepo.AssertNotCalled(t, "DeleteByStudentID", mock.Anything, mock.Anything)
+}
+
+func TestUserPurgedHandler_InvalidBody(t *testing.T) {
+	instructorRepo := new(mocks.MockCourseOfferingInstructorRepository)
+	attemptRepo := new(mocks.MockQuizAttemptRepository)
+	submissionRepo := new(mocks.MockAssignmentSubmissionRepository)
+
+	handler := handlers.NewUserPurgedHandler(instructorRepo, attemptRepo, submissionRepo, logger.NewNop())
+	require.Error(t, handler.Handle([]byte("not json")))
+
+	instructorRepo.AssertNotCalled(t, "DeleteByInstructorID", mock.Anything, mock.Anything)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/consumer"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/policies"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/infrastructure/persistence/postgres"
@@ -31,6 +32,7 @@ import (
 	sharedPostgres "github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -69,19 +71,34 @@ func main() {
 		defer rabbitMQ.Close()
 	}
 
+	authenticate, _, closeTokenStore, err := middleware.AuthenticateWithRedis(cfg.Auth, &cfg.Redis, appLogger)
+	if err != nil {
+		appLogger.Fatal("failed to set up token verification", zap.Error(err))
+	}
+	defer closeTokenStore()
+
 	enrollmentRepo := postgres.NewPostgresEnrollmentRepository(db)
+	offeringInstructorRepo := postgres.NewPostgresOfferingInstructorRepository(db)
 
 
 	userUpdatedHandler := handlers.NewUserUpdatedHandler(enrollmentRepo, appLogger)
+	userPurgedHandler := handlers.NewUserPurgedHandler(enrollmentRepo, appLogger)
 	courseUpdatedHandler := handlers.NewCourseUpdatedHandler(enrollmentRepo, appLogger)
 	courseOfferingUpdatedHandler := handlers.NewCourseOfferingUpdatedHandler(enrollmentRepo, appLogger)
+	instructorAssignedHandler := handlers.NewInstructorAssignedHandler(offeringInstructorRepo, appLogger)
+	instructorRemovedHandler := handlers.NewInstructorRemovedHandler(offeringInstructorRepo, appLogger)
+	replayRequestedHandler := handlers.NewReplayRequestedHandler(usecases.NewReplayEventsUseCase(enrollmentRepo, rabbitMQ, appLogger), appLogger)
 
 
 	eventConsumer := consumer.NewEventConsumer(
 		rabbitMQ,
 		userUpdatedHandler,
+		userPurgedHandler,
 		courseUpdatedHandler,
 		courseOfferingUpdatedHandler,
+		instructorAssignedHandler,
+		instructorRemovedHandler,
+		replayRequestedHandler,
 		appLogger,
 	)
 
@@ -106,7 +123,7 @@ func main() {
 	}
 
 	router := gin.New()
-	httpRouter.SetUpRoutes(router, enrollmentHttpHandler, appLogger)
+	httpRouter.SetUpRoutes(router, enrollmentHttpHandler, authenticate, policies.NewEnrollmentAccess(enrollmentRepo, offeringInstructorRepo), appLogger)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
@@ -13,23 +14,35 @@ import (
 type EventConsumer struct {
 	rabbitMQ                      *messaging.RabbitMQ
 	userUpdatedHandler            *handlers.UserUpdatedHandler
+	userPurgedHandler             *handlers.UserPurgedHandler
 	courseUpdatedHandler          *handlers.CourseUpdatedHandler
 	courseOfferingUpdatedHandler  *handlers.CourseOfferingUpdatedHandler
+	instructorAssignedHandler     *handlers.InstructorAssignedHandler
+	instructorRemovedHandler      *handlers.InstructorRemovedHandler
+	replayRequestedHandler        *handlers.ReplayRequestedHandler
 	logger                        *logger.Logger
 }
 
 func NewEventConsumer(
 	rabbitMQ *messaging.RabbitMQ,
 	userUpdatedHandler *handlers.UserUpdatedHandler,
+	userPurgedHandler *handlers.UserPurgedHandler,
 	courseUpdatedHandler *handlers.CourseUpdatedHandler,
 	courseOfferingUpdatedHandler *handlers.CourseOfferingUpdatedHandler,
+	instructorAssignedHandler *handlers.InstructorAssignedHandler,
+	instructorRemovedHandler *handlers.InstructorRemovedHandler,
+	replayRequestedHandler *handlers.ReplayRequestedHandler,
 	logger *logger.Logger,
 ) *EventConsumer {
 	return &EventConsumer{
 		rabbitMQ:                     rabbitMQ,
 		userUpdatedHandler:           userUpdatedHandler,
+		userPurgedHandler:            userPurgedHandler,
 		courseUpdatedHandler:         courseUpdatedHandler,
 		courseOfferingUpdatedHandler: courseOfferingUpdatedHandler,
+		instructorAssignedHandler:    instructorAssignedHandler,
+		instructorRemovedHandler:     instructorRemovedHandler,
+		replayRequestedHandler:       replayRequestedHandler,
 		logger:                       logger,
 	}
 }
@@ -37,8 +50,13 @@ func NewEventConsumer(
 func (c *EventConsumer) Start(ctx context.Context) error {
 	routingKeys := []string{
 		events.EventTypeUserUpdated,
+		events.EventTypeUserPurged,
 		events.EventTypeCourseUpdated,
 		events.EventTypeCourseOfferingUpdated,
+		events.EventTypeInstructorAssignedToOffering,
+		events.EventTypeInstructorRemovedFromOffering,
+		events.EventTypeInstructorAssignmentReplayed,
+		events.EventTypeReplayRequested,
 	}
 
 	messages, err := c.rabbitMQ.Consume(ctx, "enrollment-service.queue", routingKeys)
@@ -50,6 +68,15 @@ func (c *EventConsumer) Start(ctx context.Context) error {
 		zap.Strings("routing_keys", routingKeys),
 	)
 
+	// Now that the queue is bound, ask course-service to replay the
+	// instructor assignments this service keeps a copy of, so assignments
+	// made before the copy existed, or while this service was down, are known.
+	// This service answers its own request too, replaying its enrollments.
+	request := events.ReplayRequestedEvent{Service: "enrollment-service", RequestedAt: time.Now().UTC()}
+	if err := c.rabbitMQ.Publish(ctx, events.EventTypeReplayRequested, request); err != nil {
+		c.logger.Error("failed to request event replay", zap.Error(err))
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -82,10 +109,18 @@ func (c *EventConsumer) handleMessage(msg messaging.Message) error {
 	switch msg.RoutingKey {
 	case events.EventTypeUserUpdated:
 		return c.userUpdatedHandler.Handle(msg.Body)
+	case events.EventTypeUserPurged:
+		return c.userPurgedHandler.Handle(msg.Body)
 	case events.EventTypeCourseUpdated:
 		return c.courseUpdatedHandler.Handle(msg.Body)
 	case events.EventTypeCourseOfferingUpdated:
 		return c.courseOfferingUpdatedHandler.Handle(msg.Body)
+	case events.EventTypeInstructorAssignedToOffering, events.EventTypeInstructorAssignmentReplayed:
+		return c.instructorAssignedHandler.Handle(msg.Body)
+	case events.EventTypeInstructorRemovedFromOffering:
+		return c.instructorRemovedHandler.Handle(msg.Body)
+	case events.EventTypeReplayRequested:
+		return c.replayRequestedHandler.Handle(msg.Body)
 	default:
 		c.logger.Warn("unknown routing key",
 			zap.String("routing_key", msg.RoutingKey),
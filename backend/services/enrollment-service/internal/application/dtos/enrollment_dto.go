@@ -17,6 +17,7 @@ type EnrollmentDTO struct {
 	Status             string    `json:"status"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+	OrganizationID     *string   `json:"organization_id,omitempty"`
 }
 
 func (dto *EnrollmentDTO) FromEntity(enrollment *entities.Enrollment) {
@@ -30,5 +31,6 @@ func (dto *EnrollmentDTO) FromEntity(enrollment *entities.Enrollment) {
 	dto.Status = enrollment.Status.String()
 	dto.CreatedAt = enrollment.CreatedAt
 	dto.UpdatedAt = enrollment.UpdatedAt
+	dto.OrganizationID = enrollment.OrganizationID
 }
 
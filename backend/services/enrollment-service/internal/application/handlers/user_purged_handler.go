@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// UserPurgedHandler anonymizes the enrollments of a purged student, so course
+// history and counts survive without pointing at a person.
+type UserPurgedHandler struct {
+	enrollmentRepo repositories.EnrollmentRepository
+	logger         *logger.Logger
+}
+
+func NewUserPurgedHandler(
+	enrollmentRepo repositories.EnrollmentRepository,
+	logger *logger.Logger,
+) *UserPurgedHandler {
+	return &UserPurgedHandler{
+		enrollmentRepo: enrollmentRepo,
+		logger:         logger,
+	}
+}
+
+func (h *UserPurgedHandler) Handle(body []byte) error {
+	var event events.UserPurgedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user purged event", zap.Error(err))
+		return err
+	}
+
+	anonymized, err := h.enrollmentRepo.AnonymizeStudent(context.Background(), event.ID)
+	if err != nil {
+		h.logger.Error("failed to anonymize enrollments of purged student",
+			zap.String("student_id", event.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if anonymized > 0 {
+		h.logger.Info("anonymized enrollments of purged student",
+			zap.String("student_id", event.ID),
+			zap.Int64("enrollments", anonymized),
+		)
+	}
+
+	return nil
+}
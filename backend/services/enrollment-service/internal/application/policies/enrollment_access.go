@@ -0,0 +1,67 @@
+package policies
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
+)
+
+// EnrollmentAccess answers whether a student owns an enrollment or an
+// instructor teaches its offering. Its methods are used as ownership checks by
+// the route policies.
+type EnrollmentAccess struct {
+	enrollmentRepo         repositories.EnrollmentRepository
+	offeringInstructorRepo repositories.OfferingInstructorRepository
+}
+
+func NewEnrollmentAccess(enrollmentRepo repositories.EnrollmentRepository, offeringInstructorRepo repositories.OfferingInstructorRepository) *EnrollmentAccess {
+	return &EnrollmentAccess{
+		enrollmentRepo:         enrollmentRepo,
+		offeringInstructorRepo: offeringInstructorRepo,
+	}
+}
+
+func (a *EnrollmentAccess) OwnsEnrollment(ctx context.Context, studentID, enrollmentID string) (bool, error) {
+	if _, err := uuid.Parse(enrollmentID); err != nil {
+		return false, nil
+	}
+	enrollment, err := a.findEnrollment(ctx, enrollmentID)
+	if err != nil || enrollment == nil {
+		return false, err
+	}
+	return enrollment.StudentID == studentID, nil
+}
+
+// TeachesEnrollmentOffering reports whether instructorID is assigned to the
+// offering the enrollment is for.
+func (a *EnrollmentAccess) TeachesEnrollmentOffering(ctx context.Context, instructorID, enrollmentID string) (bool, error) {
+	if _, err := uuid.Parse(enrollmentID); err != nil {
+		return false, nil
+	}
+	enrollment, err := a.findEnrollment(ctx, enrollmentID)
+	if err != nil || enrollment == nil {
+		return false, err
+	}
+	return a.offeringInstructorRepo.IsInstructor(ctx, enrollment.CourseOfferingID, instructorID)
+}
+
+// TeachesOffering reports whether instructorID is assigned to the offering.
+func (a *EnrollmentAccess) TeachesOffering(ctx context.Context, instructorID, courseOfferingID string) (bool, error) {
+	if _, err := uuid.Parse(courseOfferingID); err != nil {
+		return false, nil
+	}
+	return a.offeringInstructorRepo.IsInstructor(ctx, courseOfferingID, instructorID)
+}
+
+// findEnrollment loads the enrollment, returning nil without an error when it
+// does not exist so that the policy refuses the request rather than failing.
+func (a *EnrollmentAccess) findEnrollment(ctx context.Context, enrollmentID string) (*entities.Enrollment, error) {
+	enrollment, err := a.enrollmentRepo.FindByID(ctx, enrollmentID)
+	if errors.Is(err, repositories.ErrEnrollmentNotFound) {
+		return nil, nil
+	}
+	return enrollment, err
+}
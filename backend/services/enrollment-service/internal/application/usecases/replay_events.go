@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// ReplayEventsUseCase publishes the enrollments other services keep copies
+// of, so that copies started after the enrollments were made are filled in.
+// Consumers store replayed events idempotently, so replaying twice is safe.
+type ReplayEventsUseCase struct {
+	enrollmentRepo repositories.EnrollmentRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+}
+
+func NewReplayEventsUseCase(
+	enrollmentRepo repositories.EnrollmentRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *ReplayEventsUseCase {
+	return &ReplayEventsUseCase{
+		enrollmentRepo: enrollmentRepo,
+		publisher:      publisher,
+		logger:         logger,
+	}
+}
+
+func (uc *ReplayEventsUseCase) Execute(ctx context.Context) error {
+	if uc.publisher == nil {
+		return nil
+	}
+
+	enrollments, err := uc.enrollmentRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list enrollments: %w", err)
+	}
+	for _, enrollment := range enrollments {
+		event := events.EnrollmentUpdatedEvent{
+			ID:                 enrollment.ID,
+			StudentID:          enrollment.StudentID,
+			StudentUsername:    enrollment.StudentUsername,
+			CourseID:           enrollment.CourseID,
+			CourseName:         enrollment.CourseName,
+			CourseOfferingID:   enrollment.CourseOfferingID,
+			CourseOfferingName: enrollment.CourseOfferingName,
+			Status:             enrollment.Status.String(),
+			CreatedAt:          enrollment.CreatedAt,
+			UpdatedAt:          enrollment.UpdatedAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeEnrollmentReplayed, event); err != nil {
+			return fmt.Errorf("failed to replay enrollment: %w", err)
+		}
+	}
+
+	uc.logger.Info("replayed events", zap.Int("enrollments", len(enrollments)))
+	return nil
+}
@@ -7,6 +7,10 @@ import (
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/valueobjects"
 )
 
+// DeletedStudentUsername replaces the username on enrollments of students
+// whose account was purged.
+const DeletedStudentUsername = "deleted-user"
+
 type Enrollment struct {
 	ID                  string
 	StudentID           string
@@ -18,6 +22,8 @@ type Enrollment struct {
 	Status              valueobjects.EnrollmentStatus
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+	// OrganizationID is the partner school of the student who enrolled.
+	OrganizationID *string
 }
 
 func NewEnrollment(
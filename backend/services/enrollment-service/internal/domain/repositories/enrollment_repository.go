@@ -2,11 +2,15 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/valueobjects"
 )
 
+// ErrEnrollmentNotFound is returned by FindByID for an unknown enrollment.
+var ErrEnrollmentNotFound = errors.New("enrollment not found")
+
 type SortDirection string
 
 const (
@@ -37,7 +41,11 @@ type EnrollmentRepository interface {
 	Update(ctx context.Context, enrollment *entities.Enrollment) error
 	Delete(ctx context.Context, id string) error
 	Find(ctx context.Context, query EnrollmentQuery) (*EnrollmentQueryResult, error)
+	FindAll(ctx context.Context) ([]*entities.Enrollment, error)
 	UpdateStudentUsername(ctx context.Context, studentID, username string) error
+	// AnonymizeStudent keeps the student's enrollments for course history but
+	// drops their username and rejects the ones still pending.
+	AnonymizeStudent(ctx context.Context, studentID string) (int64, error)
 	UpdateCourseName(ctx context.Context, courseID, courseName string) error
 	UpdateCourseOfferingName(ctx context.Context, courseOfferingID, courseOfferingName string) error
 }
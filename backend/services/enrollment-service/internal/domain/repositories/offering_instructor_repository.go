@@ -0,0 +1,11 @@
+package repositories
+
+import "context"
+
+// OfferingInstructorRepository tracks which instructors course-service has
+// assigned to each course offering.
+type OfferingInstructorRepository interface {
+	Add(ctx context.Context, courseOfferingID, instructorID string) error
+	Remove(ctx context.Context, courseOfferingID, instructorID string) error
+	IsInstructor(ctx context.Context, courseOfferingID, instructorID string) (bool, error)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresEnrollmentRepository struct {
@@ -24,9 +25,11 @@ func (r *PostgresEnrollmentRepository) Create(ctx context.Context, enrollment *e
 	query := `
 		INSERT INTO enrollments (
 			id, student_id, student_username, course_id, course_name, 
-			course_offering_id, course_offering_name, status, created_at, updated_at
-		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			course_offering_id, course_offering_name, status, created_at, updated_at,
+			organization_id
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
+	enrollment.OrganizationID = tenant.Owner(ctx, enrollment.OrganizationID)
 	_, err := r.db.ExecContext(ctx, query,
 		enrollment.ID,
 		enrollment.StudentID,
@@ -38,6 +41,7 @@ func (r *PostgresEnrollmentRepository) Create(ctx context.Context, enrollment *e
 		enrollment.Status.String(),
 		enrollment.CreatedAt,
 		enrollment.UpdatedAt,
+		enrollment.OrganizationID,
 	)
 	return err
 }
@@ -46,12 +50,13 @@ func (r *PostgresEnrollmentRepository) FindByID(ctx context.Context, id string)
 	query := `
 		SELECT
 			id, student_id, student_username, course_id, course_name,
-			course_offering_id, course_offering_name, status, created_at, updated_at
+			course_offering_id, course_offering_name, status, created_at, updated_at,
+			organization_id
 		FROM enrollments
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanRowToEntity(row, nil)
 }
 
@@ -95,6 +100,26 @@ func (r *PostgresEnrollmentRepository) UpdateStudentUsername(ctx context.Context
 	return err
 }
 
+func (r *PostgresEnrollmentRepository) AnonymizeStudent(ctx context.Context, studentID string) (int64, error) {
+	query := `
+		UPDATE enrollments
+		SET student_username = $1,
+			status = CASE WHEN status = $2 THEN $3 ELSE status END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE student_id = $4
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		entities.DeletedStudentUsername,
+		valueobjects.EnrollmentStatusPending.String(),
+		valueobjects.EnrollmentStatusRejected.String(),
+		studentID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (r *PostgresEnrollmentRepository) UpdateCourseName(ctx context.Context, courseID, courseName string) error {
 	query := `
 		UPDATE enrollments
@@ -127,7 +152,7 @@ func (r *PostgresEnrollmentRepository) Delete(ctx context.Context, id string) er
 	return nil
 }
 
-func (r *PostgresEnrollmentRepository) buildWhereClause(query repositories.EnrollmentQuery) (string, []interface{}) {
+func (r *PostgresEnrollmentRepository) buildWhereClause(ctx context.Context, query repositories.EnrollmentQuery) (string, []interface{}) {
 	clauses := []string{}
 	args := []interface{}{}
 	argIdx := 1
@@ -168,6 +193,13 @@ func (r *PostgresEnrollmentRepository) buildWhereClause(query repositories.Enrol
 		argIdx++
 	}
 
+	// Organization the caller is scoped to
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		clauses = append(clauses, fmt.Sprintf("organization_id = $%d", argIdx))
+		args = append(args, organizationID)
+		argIdx++
+	}
+
 	whereClause := ""
 	if len(clauses) > 0 {
 		whereClause = " WHERE " + strings.Join(clauses, " AND ")
@@ -201,14 +233,15 @@ func (r *PostgresEnrollmentRepository) buildOrderClause(query repositories.Enrol
 
 func (r *PostgresEnrollmentRepository) Find(ctx context.Context, query repositories.EnrollmentQuery) (*repositories.EnrollmentQueryResult, error) {
 	var queryBuilder strings.Builder
-	whereClause, whereArgs := r.buildWhereClause(query)
+	whereClause, whereArgs := r.buildWhereClause(ctx, query)
 	args := whereArgs
 	argIdx := len(args) + 1
 
 	queryBuilder.WriteString(`
 		SELECT
 			id, student_id, student_username, course_id, course_name,
-			course_offering_id, course_offering_name, status, created_at, updated_at
+			course_offering_id, course_offering_name, status, created_at, updated_at,
+			organization_id
 		FROM enrollments
 	`)
 	queryBuilder.WriteString(whereClause)
@@ -269,6 +302,37 @@ func (r *PostgresEnrollmentRepository) Find(ctx context.Context, query repositor
 	}, nil
 }
 
+func (r *PostgresEnrollmentRepository) FindAll(ctx context.Context) ([]*entities.Enrollment, error) {
+	query := `
+		SELECT
+			id, student_id, student_username, course_id, course_name,
+			course_offering_id, course_offering_name, status, created_at, updated_at,
+			organization_id
+		FROM enrollments
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	enrollments := []*entities.Enrollment{}
+	for rows.Next() {
+		enrollment, err := r.scanRowToEntity(nil, rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return enrollments, nil
+}
+
 func (r *PostgresEnrollmentRepository) scanRowToEntity(row *sql.Row, rows *sql.Rows) (*entities.Enrollment, error) {
 	var (
 		id                 string
@@ -281,18 +345,19 @@ func (r *PostgresEnrollmentRepository) scanRowToEntity(row *sql.Row, rows *sql.R
 		status             string
 		createdAt          time.Time
 		updatedAt          time.Time
+		organizationID     sql.NullString
 	)
 	var err error
 	if row != nil {
 		err = row.Scan(&id, &studentID, &studentUsername, &courseID, &courseName,
-			&courseOfferingID, &courseOfferingName, &status, &createdAt, &updatedAt)
+			&courseOfferingID, &courseOfferingName, &status, &createdAt, &updatedAt, &organizationID)
 	} else if rows != nil {
 		err = rows.Scan(&id, &studentID, &studentUsername, &courseID, &courseName,
-			&courseOfferingID, &courseOfferingName, &status, &createdAt, &updatedAt)
+			&courseOfferingID, &courseOfferingName, &status, &createdAt, &updatedAt, &organizationID)
 	}
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("enrollment not found")
+		return nil, repositories.ErrEnrollmentNotFound
 	}
 
 	if err != nil {
@@ -304,7 +369,7 @@ func (r *PostgresEnrollmentRepository) scanRowToEntity(row *sql.Row, rows *sql.R
 		return nil, fmt.Errorf("invalid enrollment status: %w", err)
 	}
 
-	return &entities.Enrollment{
+	enrollment := &entities.Enrollment{
 		ID:                 id,
 		StudentID:         studentID,
 		StudentUsername:   studentUsername,
@@ -315,6 +380,10 @@ func (r *PostgresEnrollmentRepository) scanRowToEntity(row *sql.Row, rows *sql.R
 		Status:            statusVO,
 		CreatedAt:         createdAt,
 		UpdatedAt:         updatedAt,
-	}, nil
+	}
+	if organizationID.Valid {
+		enrollment.OrganizationID = &organizationID.String
+	}
+	return enrollment, nil
 }
 
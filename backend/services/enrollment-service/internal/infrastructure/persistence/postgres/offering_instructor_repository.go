@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
+)
+
+type PostgresOfferingInstructorRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOfferingInstructorRepository(db *sql.DB) repositories.OfferingInstructorRepository {
+	return &PostgresOfferingInstructorRepository{db: db}
+}
+
+// Add is idempotent, as instructor events may be delivered more than once.
+func (r *PostgresOfferingInstructorRepository) Add(ctx context.Context, courseOfferingID, instructorID string) error {
+	query := `
+		INSERT INTO offering_instructors (course_offering_id, instructor_id)
+		VALUES ($1, $2)
+		ON CONFLICT (course_offering_id, instructor_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, courseOfferingID, instructorID)
+	return err
+}
+
+func (r *PostgresOfferingInstructorRepository) Remove(ctx context.Context, courseOfferingID, instructorID string) error {
+	query := `DELETE FROM offering_instructors WHERE course_offering_id = $1 AND instructor_id = $2`
+	_, err := r.db.ExecContext(ctx, query, courseOfferingID, instructorID)
+	return err
+}
+
+func (r *PostgresOfferingInstructorRepository) IsInstructor(ctx context.Context, courseOfferingID, instructorID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM offering_instructors
+			WHERE course_offering_id = $1 AND instructor_id = $2
+		)
+	`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, courseOfferingID, instructorID).Scan(&exists)
+	return exists, err
+}
@@ -3,14 +3,17 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/cmd/docs"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/policies"
 	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/interfaces/http/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/policy"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetUpRoutes(router *gin.Engine, handler *handlers.EnrollmentHandler, logger *logger.Logger) {
+func SetUpRoutes(router *gin.Engine, handler *handlers.EnrollmentHandler, authenticate gin.HandlerFunc, enrollmentAccess *policies.EnrollmentAccess, logger *logger.Logger) {
 	// Middleware
 	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger(logger))
@@ -24,15 +27,47 @@ func SetUpRoutes(router *gin.Engine, handler *handlers.EnrollmentHandler, logger
 	// Swagger documentation
 	router.GET("/api/v1/enrollments/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
+	// Enrollment Policies
+	// Students may only enroll themselves and see their own enrollments, and
+	// instructors only the enrollments of offerings they teach.
+	isAdmin := policy.HasRole(valueobjects.RoleAdmin)
+	teachesEnrollment := policy.Owns(valueobjects.RoleInstructor, policy.Param("id"), enrollmentAccess.TeachesEnrollmentOffering)
+	createEnrollment := policy.Require(policy.Policy{
+		Name: "enrollment:create",
+		Rule: policy.AnyOf(isAdmin, policy.IsSubject(valueobjects.RoleStudent, policy.JSONField("student_id"))),
+	}, logger)
+	readEnrollment := policy.Require(policy.Policy{
+		Name: "enrollment:read",
+		Rule: policy.AnyOf(isAdmin, teachesEnrollment, policy.Owns(valueobjects.RoleStudent, policy.Param("id"), enrollmentAccess.OwnsEnrollment)),
+	}, logger)
+	listEnrollments := policy.Require(policy.Policy{
+		Name: "enrollment:list",
+		Rule: policy.AnyOf(
+			isAdmin,
+			policy.Owns(valueobjects.RoleInstructor, policy.Query("course_offering_id"), enrollmentAccess.TeachesOffering),
+			policy.IsSubject(valueobjects.RoleStudent, policy.Query("student_id")),
+		),
+	}, logger)
+	reviewEnrollment := policy.Require(policy.Policy{
+		Name: "enrollment:review",
+		Rule: policy.AnyOf(isAdmin, teachesEnrollment),
+	}, logger)
+	deleteEnrollment := policy.Require(policy.Policy{
+		Name: "enrollment:delete",
+		Rule: isAdmin,
+	}, logger)
+
 	// Enrollment Routes
 	api := router.Group("/api/v1")
+	api.Use(authenticate)
+	api.Use(middleware.Tenant())
 	{
 		enrollmentRouter := api.Group("/enrollments")
-		enrollmentRouter.POST("", handler.CreateEnrollment)
-		enrollmentRouter.GET("/:id", handler.GetEnrollment)
-		enrollmentRouter.PUT("/:id/status", handler.UpdateEnrollmentStatus)
-		enrollmentRouter.DELETE("/:id", handler.DeleteEnrollment)
-		enrollmentRouter.GET("", handler.FindEnrollment)
+		enrollmentRouter.POST("", createEnrollment, handler.CreateEnrollment)
+		enrollmentRouter.GET("/:id", readEnrollment, handler.GetEnrollment)
+		enrollmentRouter.PUT("/:id/status", reviewEnrollment, handler.UpdateEnrollmentStatus)
+		enrollmentRouter.DELETE("/:id", deleteEnrollment, handler.DeleteEnrollment)
+		enrollmentRouter.GET("", listEnrollments, handler.FindEnrollment)
 	}
 }
 
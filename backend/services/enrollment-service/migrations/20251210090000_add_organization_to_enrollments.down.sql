@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_enrollments_organization_id;
+
+ALTER TABLE enrollments DROP COLUMN IF EXISTS organization_id;
@@ -0,0 +1,5 @@
+-- Organizations live in user-service, so there is no foreign key. Enrollments
+-- without one predate multi-tenancy and are only visible to super-admins.
+ALTER TABLE enrollments ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_enrollments_organization_id ON enrollments(organization_id);
@@ -0,0 +1,12 @@
+-- Instructor assignments are owned by course-service. This copy is kept in
+-- sync from its instructor events so enrollment reviews can be limited to the
+-- offering's instructors. Existing assignments are filled in by the replay
+-- this service requests from course-service whenever it starts.
+CREATE TABLE IF NOT EXISTS offering_instructors (
+    course_offering_id UUID NOT NULL,
+    instructor_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_offering_id, instructor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_offering_instructors_instructor_id ON offering_instructors(instructor_id);
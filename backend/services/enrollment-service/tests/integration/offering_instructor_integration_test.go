@@ -0,0 +1,60 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfferingInstructor_Integration_FollowsInstructorEvents(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	instructorRepo := SetupOfferingInstructorRepository(db)
+	ctx := context.Background()
+
+	offeringID := uuid.New().String()
+	instructorID := uuid.New().String()
+
+	assigned, err := json.Marshal(events.InstructorAssignedToOfferingEvent{
+		ID:               uuid.New().String(),
+		CourseOfferingID: offeringID,
+		InstructorID:     instructorID,
+		CreatedAt:        time.Now(),
+	})
+	require.NoError(t, err)
+
+	assignedHandler := handlers.NewInstructorAssignedHandler(instructorRepo, logger.NewNop())
+	require.NoError(t, assignedHandler.Handle(assigned))
+	// Redelivered events must not fail.
+	require.NoError(t, assignedHandler.Handle(assigned))
+
+	teaches, err := instructorRepo.IsInstructor(ctx, offeringID, instructorID)
+	require.NoError(t, err)
+	assert.True(t, teaches)
+
+	teaches, err = instructorRepo.IsInstructor(ctx, uuid.New().String(), instructorID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+
+	removed, err := json.Marshal(events.InstructorRemovedFromOfferingEvent{
+		ID:               uuid.New().String(),
+		CourseOfferingID: offeringID,
+		InstructorID:     instructorID,
+		RemovedAt:        time.Now(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, handlers.NewInstructorRemovedHandler(instructorRepo, logger.NewNop()).Handle(removed))
+
+	teaches, err = instructorRepo.IsInstructor(ctx, offeringID, instructorID)
+	require.NoError(t, err)
+	assert.False(t, teaches)
+}
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_Integration_NoCrossTenantLeakage(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	enrollmentRepo := SetupEnrollmentRepository(db)
+	logger := logger.NewNop()
+
+	riverside := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	hillcrest := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	superAdmin := context.Background()
+
+	createUC := usecases.NewCreateEnrollmentUseCase(enrollmentRepo, nil, logger)
+	enroll := func(ctx context.Context, username string) string {
+		enrollment, err := createUC.Execute(ctx, usecases.CreateEnrollmentInput{
+			StudentID:          uuid.NewString(),
+			StudentUsername:    username,
+			CourseID:           uuid.NewString(),
+			CourseName:         "Algebra",
+			CourseOfferingID:   uuid.NewString(),
+			CourseOfferingName: "Spring 2026",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, enrollment.OrganizationID)
+		assert.Equal(t, tenant.OrganizationID(ctx), *enrollment.OrganizationID)
+		return enrollment.ID
+	}
+	riversideEnrollment := enroll(riverside, "river")
+	enroll(hillcrest, "hill")
+
+	getUC := usecases.NewGetEnrollmentUseCase(enrollmentRepo, logger)
+	_, err := getUC.Execute(hillcrest, usecases.GetEnrollmentInput{EnrollmentID: riversideEnrollment})
+	require.Error(t, err)
+	_, err = getUC.Execute(riverside, usecases.GetEnrollmentInput{EnrollmentID: riversideEnrollment})
+	require.NoError(t, err)
+
+	// Staff of another organization cannot review the enrollment.
+	_, err = usecases.NewUpdateEnrollmentStatusUseCase(enrollmentRepo, nil, logger).Execute(hillcrest, usecases.UpdateEnrollmentStatusInput{
+		EnrollmentID: riversideEnrollment,
+		Status:       valueobjects.EnrollmentStatusApproved,
+	})
+	require.Error(t, err)
+
+	findUC := usecases.NewFindEnrollmentUseCase(enrollmentRepo, logger)
+	found, err := findUC.Execute(riverside, usecases.FindEnrollmentInput{})
+	require.NoError(t, err)
+	require.Equal(t, 1, found.Total)
+	assert.Equal(t, riversideEnrollment, found.Enrollments[0].ID)
+
+	found, err = findUC.Execute(superAdmin, usecases.FindEnrollmentInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Total)
+}
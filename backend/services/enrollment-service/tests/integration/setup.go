@@ -12,7 +12,7 @@ import (
 func SetupTestDB(t *testing.T) (*sql.DB, func()) {
 	cfg := integration.TestDatabaseConfig{
 		MigrationPath:    "migrations",
-		TablesToCleanUp: []string{"enrollments"},
+		TablesToCleanUp: []string{"enrollments", "offering_instructors"},
 	}
 
 	db, cleanup, err := integration.SetUpTestDatabase(t, cfg)
@@ -26,3 +26,7 @@ func SetupEnrollmentRepository(db *sql.DB) repositories.EnrollmentRepository {
 	return postgres.NewPostgresEnrollmentRepository(db)
 }
 
+
+func SetupOfferingInstructorRepository(db *sql.DB) repositories.OfferingInstructorRepository {
+	return postgres.NewPostgresOfferingInstructorRepository(db)
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPurged_Integration_AnonymizesEnrollments(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	enrollmentRepo := SetupEnrollmentRepository(db)
+	ctx := context.Background()
+
+	studentID := uuid.New().String()
+	pending := entities.NewEnrollment(studentID, "purgedstudent", uuid.New().String(), "Pending Course", uuid.New().String(), "Fall 2024")
+	require.NoError(t, enrollmentRepo.Create(ctx, pending))
+
+	completed := entities.NewEnrollment(studentID, "purgedstudent", uuid.New().String(), "Completed Course", uuid.New().String(), "Spring 2024")
+	completed.Status = valueobjects.EnrollmentStatusCompleted
+	require.NoError(t, enrollmentRepo.Create(ctx, completed))
+
+	other := entities.NewEnrollment(uuid.New().String(), "otherstudent", uuid.New().String(), "Other Course", uuid.New().String(), "Fall 2024")
+	require.NoError(t, enrollmentRepo.Create(ctx, other))
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: studentID, Role: "student", PurgedAt: time.Now()})
+	require.NoError(t, err)
+
+	handler := handlers.NewUserPurgedHandler(enrollmentRepo, logger.NewNop())
+	require.NoError(t, handler.Handle(body))
+
+	got, err := enrollmentRepo.FindByID(ctx, pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, studentID, got.StudentID)
+	assert.Equal(t, entities.DeletedStudentUsername, got.StudentUsername)
+	assert.Equal(t, valueobjects.EnrollmentStatusRejected, got.Status)
+
+	got, err = enrollmentRepo.FindByID(ctx, completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.DeletedStudentUsername, got.StudentUsername)
+	assert.Equal(t, valueobjects.EnrollmentStatusCompleted, got.Status)
+
+	got, err = enrollmentRepo.FindByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "otherstudent", got.StudentUsername)
+	assert.Equal(t, valueobjects.EnrollmentStatusPending, got.Status)
+
+	// A redelivered event leaves the anonymized enrollments as they are.
+	require.NoError(t, handler.Handle(body))
+}
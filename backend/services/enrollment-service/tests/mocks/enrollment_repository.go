@@ -39,11 +39,22 @@ func (m *MockEnrollmentRepository) Find(ctx context.Context, query repositories.
 	return result, args.Error(1)
 }
 
+func (m *MockEnrollmentRepository) FindAll(ctx context.Context) ([]*entities.Enrollment, error) {
+	args := m.Called(ctx)
+	enrollments, _ := args.Get(0).([]*entities.Enrollment)
+	return enrollments, args.Error(1)
+}
+
 func (m *MockEnrollmentRepository) UpdateStudentUsername(ctx context.Context, studentID, username string) error {
 	args := m.Called(ctx, studentID, username)
 	return args.Error(0)
 }
 
+func (m *MockEnrollmentRepository) AnonymizeStudent(ctx context.Context, studentID string) (int64, error) {
+	args := m.Called(ctx, studentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockEnrollmentRepository) UpdateCourseName(ctx context.Context, courseID, courseName string) error {
 	args := m.Called(ctx, courseID, courseName)
 	return args.Error(0)
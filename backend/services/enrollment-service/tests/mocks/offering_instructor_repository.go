@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOfferingInstructorRepository struct {
+	mock.Mock
+}
+
+func (m *MockOfferingInstructorRepository) Add(ctx context.Context, courseOfferingID, instructorID string) error {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Error(0)
+}
+
+func (m *MockOfferingInstructorRepository) Remove(ctx context.Context, courseOfferingID, instructorID string) error {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Error(0)
+}
+
+func (m *MockOfferingInstructorRepository) IsInstructor(ctx context.Context, courseOfferingID, instructorID string) (bool, error) {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,126 @@
+package unit_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/policy"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const gatewayAddr = "10.0.0.5:41000"
+
+func newAuthenticateRouter(t *testing.T, redis utils.RedisInterface) (*gin.Engine, *utils.JwtManager) {
+	gin.SetMode(gin.TestMode)
+	jwtManager := utils.NewJwtManager("test-secret", 15*time.Minute, time.Hour)
+	trusted, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/24"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.Authenticate(jwtManager, redis, trusted, logger.NewNop()))
+	router.GET("/api/v1/enrollments", policy.Require(policy.Policy{
+		Name: "enrollment:list",
+		Rule: policy.HasRole(valueobjects.RoleStudent, valueobjects.RoleAdmin),
+	}, logger.NewNop()), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(middleware.ContextUserID)+"|"+c.GetHeader(middleware.UserRoleHeader))
+	})
+	return router, jwtManager
+}
+
+func TestAuthenticate_VerifiesTokenAndIgnoresSpoofedHeaders(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	router, jwtManager := newAuthenticateRouter(t, redis)
+	token, err := jwtManager.GenerateAccessToken(policyStudentID, "student@example.com", "student", "active", "session-1", "")
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return(policyStudentID, nil).Once()
+
+	req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil), otherStudentID, "admin")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, policyStudentID+"|student", w.Body.String())
+}
+
+func TestAuthenticate_RejectsRevokedToken(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	router, jwtManager := newAuthenticateRouter(t, redis)
+	token, err := jwtManager.GenerateAccessToken(policyStudentID, "student@example.com", "student", "active", "session-1", "")
+	require.NoError(t, err)
+	redis.On("GetUserFromAccessToken", mock.Anything, token).Return("", errors.New("redis: nil")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticate_RejectsTokenWithoutRevocationStore(t *testing.T) {
+	router, jwtManager := newAuthenticateRouter(t, nil)
+	token, err := jwtManager.GenerateAccessToken(policyStudentID, "student@example.com", "student", "active", "session-1", "")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthenticate_GatewayHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		authorize  string
+		status     int
+	}{
+		{"trusted proxy with api key", gatewayAddr, "Bearer lms_abcdef_secret", http.StatusOK},
+		{"trusted proxy without credentials", gatewayAddr, "", http.StatusUnauthorized},
+		{"direct caller without credentials", "10.0.1.7:52000", "", http.StatusUnauthorized},
+		{"direct caller with api key", "10.0.1.7:52000", "Bearer lms_abcdef_secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := new(sharedMocks.MockRedis)
+			router, _ := newAuthenticateRouter(t, redis)
+
+			req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil), policyStudentID, "student")
+			req.RemoteAddr = tt.remoteAddr
+			if tt.authorize != "" {
+				req.Header.Set("Authorization", tt.authorize)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			redis.AssertNotCalled(t, "GetUserFromAccessToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := middleware.ParseTrustedProxies([]string{"127.0.0.1", "::1", "10.0.0.0/8"})
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.True(t, networks[0].Contains(net.ParseIP("127.0.0.1")))
+	assert.False(t, networks[0].Contains(net.ParseIP("127.0.0.2")))
+
+	_, err = middleware.ParseTrustedProxies([]string{"gateway"})
+	assert.ErrorIs(t, err, middleware.ErrInvalidProxy)
+}
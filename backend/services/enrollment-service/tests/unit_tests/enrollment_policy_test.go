@@ -0,0 +1,219 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/policies"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	policyEnrollmentID = "44444444-4444-4444-4444-444444444444"
+	policyStudentID    = "55555555-5555-5555-5555-555555555555"
+	otherStudentID     = "66666666-6666-6666-6666-666666666666"
+	policyOfferingID   = "77777777-7777-7777-7777-777777777777"
+	policyInstructorID = "88888888-8888-8888-8888-888888888888"
+)
+
+func newEnrollmentPolicyRouter(repo *mocks.MockEnrollmentRepository, instructorRepo *mocks.MockOfferingInstructorRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	access := policies.NewEnrollmentAccess(repo, instructorRepo)
+	isAdmin := policy.HasRole(valueobjects.RoleAdmin)
+	teachesEnrollment := policy.Owns(valueobjects.RoleInstructor, policy.Param("id"), access.TeachesEnrollmentOffering)
+
+	router.GET("/api/v1/enrollments/:id", policy.Require(policy.Policy{
+		Name: "enrollment:read",
+		Rule: policy.AnyOf(isAdmin, teachesEnrollment, policy.Owns(valueobjects.RoleStudent, policy.Param("id"), access.OwnsEnrollment)),
+	}, logger.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.PUT("/api/v1/enrollments/:id/status", policy.Require(policy.Policy{
+		Name: "enrollment:review",
+		Rule: policy.AnyOf(isAdmin, teachesEnrollment),
+	}, logger.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/v1/enrollments", policy.Require(policy.Policy{
+		Name: "enrollment:list",
+		Rule: policy.AnyOf(
+			isAdmin,
+			policy.Owns(valueobjects.RoleInstructor, policy.Query("course_offering_id"), access.TeachesOffering),
+			policy.IsSubject(valueobjects.RoleStudent, policy.Query("student_id")),
+		),
+	}, logger.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/api/v1/enrollments", policy.Require(policy.Policy{
+		Name: "enrollment:create",
+		Rule: policy.AnyOf(policy.HasRole(valueobjects.RoleAdmin), policy.IsSubject(valueobjects.RoleStudent, policy.JSONField("student_id"))),
+	}, logger.NewNop()), func(c *gin.Context) {
+		var req struct {
+			StudentID string `json:"student_id" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.String(http.StatusCreated, req.StudentID)
+	})
+	return router
+}
+
+func asUser(req *http.Request, userID, role string) *http.Request {
+	req.Header.Set("X-User-ID", userID)
+	req.Header.Set("X-User-Role", role)
+	return req
+}
+
+func ownedEnrollment() *entities.Enrollment {
+	enrollment := entities.NewEnrollment(policyStudentID, "student", "course-id", "Course", policyOfferingID, "Fall")
+	enrollment.ID = policyEnrollmentID
+	return enrollment
+}
+
+func TestEnrollmentPolicy_StudentReadsOwnEnrollment(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(ownedEnrollment(), nil).Once()
+
+	w := httptest.NewRecorder()
+	req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), policyStudentID, "student")
+	newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestEnrollmentPolicy_StudentCannotReadOthersEnrollment(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(ownedEnrollment(), nil).Once()
+
+	w := httptest.NewRecorder()
+	req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), otherStudentID, "student")
+	newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"policy":"enrollment:read"`)
+}
+
+func TestEnrollmentPolicy_MissingEnrollmentForbidden(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(nil, repositories.ErrEnrollmentNotFound).Once()
+
+	w := httptest.NewRecorder()
+	req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), policyStudentID, "student")
+	newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestEnrollmentPolicy_RepositoryFailureIsNotForbidden(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(nil, errors.New("connection refused"))
+
+	for _, role := range []string{"student", "instructor"} {
+		t.Run(role, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), policyStudentID, role)
+			newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository)).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+		})
+	}
+}
+
+func TestEnrollmentPolicy_InstructorReadsAndReviewsTaughtEnrollment(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	instructorRepo := new(mocks.MockOfferingInstructorRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(ownedEnrollment(), nil)
+	instructorRepo.On("IsInstructor", mock.Anything, policyOfferingID, policyInstructorID).Return(true, nil)
+	router := newEnrollmentPolicyRouter(repo, instructorRepo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodPut, "/api/v1/enrollments/"+policyEnrollmentID+"/status", nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestEnrollmentPolicy_InstructorCannotReviewOtherOfferings(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	instructorRepo := new(mocks.MockOfferingInstructorRepository)
+	repo.On("FindByID", mock.Anything, policyEnrollmentID).Return(ownedEnrollment(), nil)
+	instructorRepo.On("IsInstructor", mock.Anything, policyOfferingID, policyInstructorID).Return(false, nil)
+	router := newEnrollmentPolicyRouter(repo, instructorRepo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments/"+policyEnrollmentID, nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodPut, "/api/v1/enrollments/"+policyEnrollmentID+"/status", nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"policy":"enrollment:review"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments?course_offering_id="+policyOfferingID, nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil), policyInstructorID, "instructor"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestEnrollmentPolicy_AdminReviewsAnyEnrollment(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+
+	w := httptest.NewRecorder()
+	req := asUser(httptest.NewRequest(http.MethodPut, "/api/v1/enrollments/"+policyEnrollmentID+"/status", nil), "admin-1", "admin")
+	newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestEnrollmentPolicy_StudentListsOnlyOwnEnrollments(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	router := newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments?student_id="+policyStudentID, nil), policyStudentID, "student"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments?student_id="+otherStudentID, nil), policyStudentID, "student"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodGet, "/api/v1/enrollments", nil), policyStudentID, "student"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestEnrollmentPolicy_StudentEnrollsOnlyThemselves(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	router := newEnrollmentPolicyRouter(repo, new(mocks.MockOfferingInstructorRepository))
+
+	w := httptest.NewRecorder()
+	body := `{"student_id":"` + policyStudentID + `"}`
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodPost, "/api/v1/enrollments", strings.NewReader(body)), policyStudentID, "student"))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, policyStudentID, w.Body.String())
+
+	w = httptest.NewRecorder()
+	body = `{"student_id":"` + otherStudentID + `"}`
+	router.ServeHTTP(w, asUser(httptest.NewRequest(http.MethodPost, "/api/v1/enrollments", strings.NewReader(body)), policyStudentID, "student"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,60 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/enrollment-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayEvents_PublishesEveryEnrollment(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	uc := usecases.NewReplayEventsUseCase(repo, publisher, logger.NewNop())
+
+	enrollment := entities.NewEnrollment(
+		"student-id-123",
+		"teststudent",
+		"course-id-123",
+		"Test Course",
+		"offering-id-123",
+		"Fall 2024",
+	)
+
+	repo.On("FindAll", mock.Anything).Return([]*entities.Enrollment{enrollment}, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeEnrollmentReplayed, mock.MatchedBy(func(event events.EnrollmentUpdatedEvent) bool {
+		return event.ID == enrollment.ID &&
+			event.CourseOfferingID == "offering-id-123" &&
+			event.Status == enrollment.Status.String()
+	})).Return(nil).Once()
+
+	err := uc.Execute(context.Background())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestReplayEvents_RepositoryError(t *testing.T) {
+	repo := new(mocks.MockEnrollmentRepository)
+	publisher := new(sharedMocks.MockPublisher)
+
+	uc := usecases.NewReplayEventsUseCase(repo, publisher, logger.NewNop())
+
+	repo.On("FindAll", mock.Anything).Return(nil, errors.New("database error")).Once()
+
+	err := uc.Execute(context.Background())
+
+	assert.Error(t, err)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -11,6 +11,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -20,6 +21,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/consumer"
+	appHandlers "github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/infrastructure/config"
 	filePostgres "github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/infrastructure/persistence/postgres"
@@ -28,6 +31,8 @@ import (
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/interfaces/http/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -65,10 +70,28 @@ func main() {
 	}
 	appLogger.Info("MinIO client connected successfully")
 
+	rabbitMQ, err := messaging.NewRabbitMQ(&cfg.RabbitMQ, appLogger)
+	if err != nil {
+		appLogger.Error("failed to create rabbitmq, purged users' avatars will not be deleted", zap.Error(err))
+	} else {
+		appLogger.Info("rabbitmq connected successfully")
+		defer rabbitMQ.Close()
+	}
+
+	// Redis also backs rate limiting. Without it the API still serves
+	// requests, only unthrottled.
+	authenticate, rateLimitStore, closeRateLimitStore, err := middleware.AuthenticateWithRedis(cfg.Auth, &cfg.Redis, appLogger)
+	if err != nil {
+		appLogger.Fatal("failed to set up token verification", zap.Error(err))
+	}
+	defer closeRateLimitStore()
+
 	fileRepo := filePostgres.NewPostgresFileRepository(db)
+	submittedFileRepo := filePostgres.NewPostgresSubmittedFileRepository(db)
+	offeringInstructorRepo := filePostgres.NewPostgresOfferingInstructorRepository(db)
 
 	uploadFileUseCase := usecases.NewUploadFileUseCase(fileRepo, minioClient, appLogger, cfg.Server.APIGatewayURL)
-	downloadFileUseCase := usecases.NewDownloadFileUseCase(fileRepo, minioClient, appLogger)
+	downloadFileUseCase := usecases.NewDownloadFileUseCase(fileRepo, submittedFileRepo, offeringInstructorRepo, minioClient, appLogger)
 	getFileUseCase := usecases.NewGetFileUseCase(fileRepo, appLogger, cfg.Server.APIGatewayURL)
 	listFilesUseCase := usecases.NewListFilesUseCase(fileRepo, appLogger, cfg.Server.APIGatewayURL)
 	deleteFileUseCase := usecases.NewDeleteFileUseCase(fileRepo, minioClient, appLogger)
@@ -82,12 +105,28 @@ func main() {
 		appLogger,
 	)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if rabbitMQ != nil {
+		userPurgedHandler := appHandlers.NewUserPurgedHandler(fileRepo, minioClient, appLogger)
+		assignmentSubmittedHandler := appHandlers.NewAssignmentSubmittedHandler(submittedFileRepo, appLogger)
+		instructorAssignedHandler := appHandlers.NewInstructorAssignedHandler(offeringInstructorRepo, appLogger)
+		instructorRemovedHandler := appHandlers.NewInstructorRemovedHandler(offeringInstructorRepo, appLogger)
+		eventConsumer := consumer.NewEventConsumer(rabbitMQ, userPurgedHandler, assignmentSubmittedHandler, instructorAssignedHandler, instructorRemovedHandler, appLogger)
+		go func() {
+			if err := eventConsumer.Start(ctx); err != nil {
+				appLogger.Error("event consumer stopped", zap.Error(err))
+			}
+		}()
+	}
+
 	if cfg.Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
-	httpRouter.SetUpRoutes(router, fileHttpHandler, appLogger)
+	httpRouter.SetUpRoutes(router, fileHttpHandler, authenticate, rateLimitStore, cfg.RateLimit, cfg.UploadRateLimit, appLogger)
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
 		Handler:      router,
@@ -106,6 +145,7 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	cancel()
 
 	appLogger.Info("Shutting down server...")
 	if err := utils.GracefulShutDown(server, nil, db, appLogger); err != nil {
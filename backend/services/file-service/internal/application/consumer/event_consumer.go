@@ -0,0 +1,113 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type EventConsumer struct {
+	rabbitMQ                   *messaging.RabbitMQ
+	userPurgedHandler          *handlers.UserPurgedHandler
+	assignmentSubmittedHandler *handlers.AssignmentSubmittedHandler
+	instructorAssignedHandler  *handlers.InstructorAssignedHandler
+	instructorRemovedHandler   *handlers.InstructorRemovedHandler
+	logger                     *logger.Logger
+}
+
+func NewEventConsumer(
+	rabbitMQ *messaging.RabbitMQ,
+	userPurgedHandler *handlers.UserPurgedHandler,
+	assignmentSubmittedHandler *handlers.AssignmentSubmittedHandler,
+	instructorAssignedHandler *handlers.InstructorAssignedHandler,
+	instructorRemovedHandler *handlers.InstructorRemovedHandler,
+	logger *logger.Logger,
+) *EventConsumer {
+	return &EventConsumer{
+		rabbitMQ:                   rabbitMQ,
+		userPurgedHandler:          userPurgedHandler,
+		assignmentSubmittedHandler: assignmentSubmittedHandler,
+		instructorAssignedHandler:  instructorAssignedHandler,
+		instructorRemovedHandler:   instructorRemovedHandler,
+		logger:                     logger,
+	}
+}
+
+func (c *EventConsumer) Start(ctx context.Context) error {
+	routingKeys := []string{
+		events.EventTypeUserPurged,
+		events.EventTypeAssignmentSubmitted,
+		events.EventTypeInstructorAssignedToOffering,
+		events.EventTypeInstructorRemovedFromOffering,
+		events.EventTypeSubmissionReplayed,
+		events.EventTypeInstructorAssignmentReplayed,
+	}
+
+	messages, err := c.rabbitMQ.Consume(ctx, "file-service.queue", routingKeys)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("started consuming events",
+		zap.Strings("routing_keys", routingKeys),
+	)
+
+	// Now that the queue is bound, ask course-service to replay the
+	// submissions and instructor assignments this service keeps copies of, so
+	// ones made before the copies existed, or while this service was down,
+	// are known.
+	request := events.ReplayRequestedEvent{Service: "file-service", RequestedAt: time.Now().UTC()}
+	if err := c.rabbitMQ.Publish(ctx, events.EventTypeReplayRequested, request); err != nil {
+		c.logger.Error("failed to request event replay", zap.Error(err))
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("stopping event consumer")
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				c.logger.Warn("message channel closed")
+				return nil
+			}
+
+			if err := c.handleMessage(msg); err != nil {
+				c.logger.Error("failed to handle message",
+					zap.String("routing_key", msg.RoutingKey),
+					zap.Error(err),
+				)
+				c.rabbitMQ.Reject(msg.Delivery, true)
+			} else {
+				c.rabbitMQ.Acknowledge(msg.Delivery)
+			}
+		}
+	}
+}
+
+func (c *EventConsumer) handleMessage(msg messaging.Message) error {
+	c.logger.Info("received message",
+		zap.String("routing_key", msg.RoutingKey),
+	)
+
+	switch msg.RoutingKey {
+	case events.EventTypeUserPurged:
+		return c.userPurgedHandler.Handle(msg.Body)
+	case events.EventTypeAssignmentSubmitted, events.EventTypeSubmissionReplayed:
+		return c.assignmentSubmittedHandler.Handle(msg.Body)
+	case events.EventTypeInstructorAssignedToOffering, events.EventTypeInstructorAssignmentReplayed:
+		return c.instructorAssignedHandler.Handle(msg.Body)
+	case events.EventTypeInstructorRemovedFromOffering:
+		return c.instructorRemovedHandler.Handle(msg.Body)
+	default:
+		c.logger.Warn("unknown routing key",
+			zap.String("routing_key", msg.RoutingKey),
+		)
+		return nil
+	}
+}
@@ -19,6 +19,7 @@ type FileDTO struct {
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	OrganizationID   *string    `json:"organization_id,omitempty"`
 }
 
 func (d *FileDTO) FromEntity(file *entities.File, apiGatewayURL string) {
@@ -33,6 +34,7 @@ func (d *FileDTO) FromEntity(file *entities.File, apiGatewayURL string) {
 	d.CreatedAt = file.CreatedAt
 	d.UpdatedAt = file.UpdatedAt
 	d.DeletedAt = file.DeletedAt
+	d.OrganizationID = file.OrganizationID
 	
 	if apiGatewayURL != "" {
 		d.DownloadURL = apiGatewayURL + "/api/v1/files/" + file.ID + "/download"
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// AssignmentSubmittedHandler records the offering each submitted file was
+// handed in to, so the offering's instructors may download it.
+type AssignmentSubmittedHandler struct {
+	submittedFileRepo repositories.SubmittedFileRepository
+	logger            *logger.Logger
+}
+
+func NewAssignmentSubmittedHandler(
+	submittedFileRepo repositories.SubmittedFileRepository,
+	logger *logger.Logger,
+) *AssignmentSubmittedHandler {
+	return &AssignmentSubmittedHandler{
+		submittedFileRepo: submittedFileRepo,
+		logger:            logger,
+	}
+}
+
+func (h *AssignmentSubmittedHandler) Handle(body []byte) error {
+	var event events.AssignmentSubmittedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal assignment submitted event", zap.Error(err))
+		return err
+	}
+	if len(event.FileIDs) == 0 {
+		return nil
+	}
+
+	if err := h.submittedFileRepo.Add(context.Background(), event.CourseOfferingID, event.FileIDs); err != nil {
+		h.logger.Error("failed to record submitted files",
+			zap.String("submission_id", event.ID),
+			zap.String("course_offering_id", event.CourseOfferingID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("recorded submitted files",
+		zap.String("submission_id", event.ID),
+		zap.String("course_offering_id", event.CourseOfferingID),
+		zap.Int("files", len(event.FileIDs)),
+	)
+
+	return nil
+}
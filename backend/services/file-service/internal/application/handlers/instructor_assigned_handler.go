@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// InstructorAssignedHandler records instructors assigned to an offering, who
+// may then download the files submitted to it.
+type InstructorAssignedHandler struct {
+	offeringInstructorRepo repositories.OfferingInstructorRepository
+	logger                 *logger.Logger
+}
+
+func NewInstructorAssignedHandler(
+	offeringInstructorRepo repositories.OfferingInstructorRepository,
+	logger *logger.Logger,
+) *InstructorAssignedHandler {
+	return &InstructorAssignedHandler{
+		offeringInstructorRepo: offeringInstructorRepo,
+		logger:                 logger,
+	}
+}
+
+func (h *InstructorAssignedHandler) Handle(body []byte) error {
+	var event events.InstructorAssignedToOfferingEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal instructor assigned event", zap.Error(err))
+		return err
+	}
+
+	if err := h.offeringInstructorRepo.Add(context.Background(), event.CourseOfferingID, event.InstructorID); err != nil {
+		h.logger.Error("failed to record offering instructor",
+			zap.String("course_offering_id", event.CourseOfferingID),
+			zap.String("instructor_id", event.InstructorID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("recorded offering instructor",
+		zap.String("course_offering_id", event.CourseOfferingID),
+		zap.String("instructor_id", event.InstructorID),
+	)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// InstructorRemovedHandler forgets instructors removed from an offering, so
+// they can no longer download the files submitted to it.
+type InstructorRemovedHandler struct {
+	offeringInstructorRepo repositories.OfferingInstructorRepository
+	logger                 *logger.Logger
+}
+
+func NewInstructorRemovedHandler(
+	offeringInstructorRepo repositories.OfferingInstructorRepository,
+	logger *logger.Logger,
+) *InstructorRemovedHandler {
+	return &InstructorRemovedHandler{
+		offeringInstructorRepo: offeringInstructorRepo,
+		logger:                 logger,
+	}
+}
+
+func (h *InstructorRemovedHandler) Handle(body []byte) error {
+	var event events.InstructorRemovedFromOfferingEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal instructor removed event", zap.Error(err))
+		return err
+	}
+
+	if err := h.offeringInstructorRepo.Remove(context.Background(), event.CourseOfferingID, event.InstructorID); err != nil {
+		h.logger.Error("failed to remove offering instructor",
+			zap.String("course_offering_id", event.CourseOfferingID),
+			zap.String("instructor_id", event.InstructorID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	h.logger.Info("removed offering instructor",
+		zap.String("course_offering_id", event.CourseOfferingID),
+		zap.String("instructor_id", event.InstructorID),
+	)
+
+	return nil
+}
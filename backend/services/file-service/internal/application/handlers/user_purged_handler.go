@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// purgeBatchSize is how many files are read per query while purging.
+const purgeBatchSize = 100
+
+// purgedBuckets hold files that belong to the user who uploaded them rather
+// than to a course.
+var purgedBuckets = []string{usecases.AvatarBucket, usecases.SubmissionBucket}
+
+// ObjectRemover removes stored objects; *storage.MinIOClient implements it.
+type ObjectRemover interface {
+	DeleteFile(ctx context.Context, bucketName, objectName string) error
+}
+
+// UserPurgedHandler deletes the avatars and assignment submission files of a
+// purged user. Other files they uploaded, such as course material, belong to
+// the courses using them and are kept.
+type UserPurgedHandler struct {
+	fileRepo repositories.FileRepository
+	storage  ObjectRemover
+	logger   *logger.Logger
+}
+
+func NewUserPurgedHandler(
+	fileRepo repositories.FileRepository,
+	storage ObjectRemover,
+	logger *logger.Logger,
+) *UserPurgedHandler {
+	return &UserPurgedHandler{
+		fileRepo: fileRepo,
+		storage:  storage,
+		logger:   logger,
+	}
+}
+
+func (h *UserPurgedHandler) Handle(body []byte) error {
+	ctx := context.Background()
+
+	var event events.UserPurgedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user purged event", zap.Error(err))
+		return err
+	}
+
+	for _, bucket := range purgedBuckets {
+		deleted, err := h.purgeBucket(ctx, event.ID, bucket)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			h.logger.Info("deleted files of purged user",
+				zap.String("user_id", event.ID),
+				zap.String("bucket", bucket),
+				zap.Int("files", deleted),
+			)
+		}
+	}
+
+	return nil
+}
+
+// purgeBucket deletes every file userID uploaded to bucket and returns how
+// many there were.
+func (h *UserPurgedHandler) purgeBucket(ctx context.Context, userID, bucket string) (int, error) {
+	limit := purgeBatchSize
+	deleted := 0
+	for {
+		// Deleted files drop out of the results, so every batch starts at
+		// the first remaining file.
+		result, err := h.fileRepo.Find(ctx, repositories.FileQuery{
+			UploadedBy: &userID,
+			BucketName: &bucket,
+			Limit:      &limit,
+		})
+		if err != nil {
+			h.logger.Error("failed to find files of purged user",
+				zap.String("user_id", userID),
+				zap.String("bucket", bucket),
+				zap.Error(err),
+			)
+			return deleted, err
+		}
+
+		for _, file := range result.Files {
+			if err := h.fileRepo.SoftDelete(ctx, file.ID); err != nil {
+				h.logger.Error("failed to delete file of purged user",
+					zap.String("user_id", userID),
+					zap.String("file_id", file.ID),
+					zap.Error(err),
+				)
+				return deleted, err
+			}
+			if err := h.storage.DeleteFile(ctx, file.BucketName, file.StoredFilename); err != nil {
+				h.logger.Warn("failed to delete file from MinIO",
+					zap.String("file_id", file.ID),
+					zap.String("bucket", file.BucketName),
+					zap.String("object", file.StoredFilename),
+					zap.Error(err),
+				)
+			}
+			deleted++
+		}
+
+		if len(result.Files) < purgeBatchSize {
+			return deleted, nil
+		}
+	}
+}
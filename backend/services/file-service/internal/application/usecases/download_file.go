@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/infrastructure/storage"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"go.uber.org/zap"
 )
@@ -19,6 +21,9 @@ var (
 type DownloadFileInput struct {
 	FileID     string
 	UserID     string
+	// UserRole decides who besides the uploader may download from
+	// SubmissionBucket.
+	UserRole   string
 	BucketName *string
 }
 
@@ -30,16 +35,26 @@ type DownloadFileOutput struct {
 }
 
 type DownloadFileUseCase struct {
-	fileRepo repositories.FileRepository
-	storage  *storage.MinIOClient
-	logger   *logger.Logger
+	fileRepo               repositories.FileRepository
+	submittedFileRepo      repositories.SubmittedFileRepository
+	offeringInstructorRepo repositories.OfferingInstructorRepository
+	storage                *storage.MinIOClient
+	logger                 *logger.Logger
 }
 
-func NewDownloadFileUseCase(fileRepo repositories.FileRepository, storage *storage.MinIOClient, logger *logger.Logger) *DownloadFileUseCase {
+func NewDownloadFileUseCase(
+	fileRepo repositories.FileRepository,
+	submittedFileRepo repositories.SubmittedFileRepository,
+	offeringInstructorRepo repositories.OfferingInstructorRepository,
+	storage *storage.MinIOClient,
+	logger *logger.Logger,
+) *DownloadFileUseCase {
 	return &DownloadFileUseCase{
-		fileRepo: fileRepo,
-		storage:  storage,
-		logger:   logger,
+		fileRepo:               fileRepo,
+		submittedFileRepo:      submittedFileRepo,
+		offeringInstructorRepo: offeringInstructorRepo,
+		storage:                storage,
+		logger:                 logger,
 	}
 }
 
@@ -63,6 +78,18 @@ func (uc *DownloadFileUseCase) Execute(ctx context.Context, input DownloadFileIn
 		return nil, ErrFileNotFound
 	}
 
+	allowed, err := uc.canDownload(ctx, file, input)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		uc.logger.Warn("file download refused",
+			zap.String("file_id", input.FileID),
+			zap.String("user_id", input.UserID),
+		)
+		return nil, ErrFileNotFound
+	}
+
 	bucketName := file.BucketName
 	if input.BucketName != nil {
 		bucketName = *input.BucketName
@@ -92,3 +119,32 @@ func (uc *DownloadFileUseCase) Execute(ctx context.Context, input DownloadFileIn
 	}, nil
 }
 
+// canDownload reports whether the caller may download file. Files handed in
+// for assignments are only given to the student who uploaded them, admins
+// and the instructors of the offerings they were submitted to.
+func (uc *DownloadFileUseCase) canDownload(ctx context.Context, file *entities.File, input DownloadFileInput) (bool, error) {
+	if file.BucketName != SubmissionBucket || file.UploadedBy == input.UserID {
+		return true, nil
+	}
+	if input.UserRole == valueobjects.RoleAdmin.String() {
+		return true, nil
+	}
+	if input.UserRole != valueobjects.RoleInstructor.String() {
+		return false, nil
+	}
+
+	offeringIDs, err := uc.submittedFileRepo.FindOfferingIDs(ctx, file.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find submission offerings: %w", err)
+	}
+	for _, offeringID := range offeringIDs {
+		teaches, err := uc.offeringInstructorRepo.IsInstructor(ctx, offeringID, input.UserID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check offering instructor: %w", err)
+		}
+		if teaches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/infrastructure/storage"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"go.uber.org/zap"
 )
@@ -23,18 +24,35 @@ var (
 	ErrUploadFailed      = errors.New("failed to upload file")
 	ErrBucketRequired    = errors.New("bucket name is required")
 	ErrFileRequired      = errors.New("file is required")
+	ErrBucketNotAllowed  = errors.New("uploading to this bucket is not allowed")
 )
 
 const (
 	MaxFileSize = 1024 * 1024 * 1024 
+	// MaxAvatarSize caps images in AvatarBucket, which any signed in user can
+	// upload to.
+	MaxAvatarSize = 5 * 1024 * 1024
+	// MaxSubmissionSize caps files students hand in to SubmissionBucket.
+	MaxSubmissionSize = 100 * 1024 * 1024
 )
 
+// AvatarBucket holds profile pictures. Like course-thumbnails it can be
+// downloaded without signing in. Students may only upload here and to
+// SubmissionBucket.
+const AvatarBucket = "user-avatars"
+
+// SubmissionBucket holds files students hand in for assignments. Its files
+// are referenced by course-service assignment submissions.
+const SubmissionBucket = "assignment-submissions"
+
 type UploadFileInput struct {
 	File        io.Reader
 	Filename    string
 	MimeType    string
 	Size        int64
 	UploadedBy  string
+	// UploaderRole limits students to AvatarBucket and SubmissionBucket.
+	UploaderRole string
 	BucketName  string
 	Tags        []string
 }
@@ -62,7 +80,10 @@ func (uc *UploadFileUseCase) Execute(ctx context.Context, input UploadFileInput)
 
 	bucketName := input.BucketName
 	if bucketName == "" {
-		bucketName = uc.determineBucket(input.MimeType, input.Tags)
+		bucketName = uc.determineBucket(input.MimeType, input.UploaderRole, input.Tags)
+	}
+	if err := uc.validateBucket(bucketName, input); err != nil {
+		return nil, err
 	}
 
 	ext := filepath.Ext(input.Filename)
@@ -123,9 +144,43 @@ func (uc *UploadFileUseCase) validateInput(input UploadFileInput) error {
 	return nil
 }
 
-func (uc *UploadFileUseCase) determineBucket(mimeType string, tags []string) string {
+func (uc *UploadFileUseCase) validateBucket(bucketName string, input UploadFileInput) error {
+	if bucketName == SubmissionBucket {
+		if input.Size > MaxSubmissionSize {
+			return fmt.Errorf("%w: maximum submission size is %d bytes", ErrFileTooLarge, MaxSubmissionSize)
+		}
+		return nil
+	}
+	if bucketName != AvatarBucket {
+		if input.UploaderRole == valueobjects.RoleStudent.String() {
+			return ErrBucketNotAllowed
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(input.MimeType, "image/") {
+		return fmt.Errorf("%w: avatars must be images", ErrInvalidMimeType)
+	}
+	if input.Size > MaxAvatarSize {
+		return fmt.Errorf("%w: maximum avatar size is %d bytes", ErrFileTooLarge, MaxAvatarSize)
+	}
+	return nil
+}
+
+func (uc *UploadFileUseCase) determineBucket(mimeType, uploaderRole string, tags []string) string {
+	for _, tag := range tags {
+		if tag == "submission" {
+			return SubmissionBucket
+		}
+	}
+	if uploaderRole == valueobjects.RoleStudent.String() {
+		return AvatarBucket
+	}
+
 	for _, tag := range tags {
 		switch tag {
+		case "avatar":
+			return AvatarBucket
 		case "thumbnail":
 			return "course-thumbnails"
 		case "video":
@@ -19,6 +19,8 @@ type File struct {
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 	DeletedAt        *time.Time
+	// OrganizationID is the partner school of the uploader.
+	OrganizationID *string
 }
 
 func NewFile(originalFilename, storedFilename, bucketName, mimeType string, sizeBytes int64, uploadedBy string, tags []string) *File {
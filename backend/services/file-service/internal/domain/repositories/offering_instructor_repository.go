@@ -0,0 +1,9 @@
+package repositories
+
+import "context"
+
+type OfferingInstructorRepository interface {
+	Add(ctx context.Context, courseOfferingID, instructorID string) error
+	Remove(ctx context.Context, courseOfferingID, instructorID string) error
+	IsInstructor(ctx context.Context, courseOfferingID, instructorID string) (bool, error)
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "context"
+
+// SubmittedFileRepository records which offerings a submission file was
+// handed in to.
+type SubmittedFileRepository interface {
+	Add(ctx context.Context, courseOfferingID string, fileIDs []string) error
+	FindOfferingIDs(ctx context.Context, fileID string) ([]string, error)
+}
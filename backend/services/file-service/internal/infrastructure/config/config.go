@@ -1,11 +1,18 @@
 package config
 
 import (
+	"time"
+
 	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 )
 
 type Config struct {
 	config.BaseConfig
+	// RateLimit applies per user to every API route, UploadRateLimit to
+	// uploads on top of it.
+	RateLimit       config.RateLimitConfig
+	UploadRateLimit config.RateLimitConfig
+	Auth            config.TokenAuthConfig
 }
 
 func DefaultConfig() *Config {
@@ -16,6 +23,20 @@ func DefaultConfig() *Config {
 	defaults.Database.DBName = "file_db"
 	return &Config{
 		BaseConfig: defaults,
+		RateLimit: config.RateLimitConfig{
+			Enabled:  true,
+			Requests: 120,
+			Window:   time.Minute,
+		},
+		UploadRateLimit: config.RateLimitConfig{
+			Enabled:  true,
+			Requests: 10,
+			Window:   time.Minute,
+		},
+		Auth: config.TokenAuthConfig{
+			SecretKey:      "secret",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+		},
 	}
 }
 
@@ -24,7 +45,10 @@ func Load() (*Config, error) {
 	baseDefaults := &defaults.BaseConfig
 	baseCfg := config.LoadBaseConfig(baseDefaults)
 	return &Config{
-		BaseConfig: baseCfg,
+		BaseConfig:      baseCfg,
+		RateLimit:       config.LoadRateLimitConfig("RATE_LIMIT_API", defaults.RateLimit),
+		UploadRateLimit: config.LoadRateLimitConfig("RATE_LIMIT_UPLOAD", defaults.UploadRateLimit),
+		Auth:            config.LoadTokenAuthConfig(defaults.Auth),
 	}, nil
 }
 
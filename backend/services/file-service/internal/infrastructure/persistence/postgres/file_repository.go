@@ -10,6 +10,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresFileRepository struct {
@@ -29,9 +30,10 @@ func (r *PostgresFileRepository) Create(ctx context.Context, file *entities.File
 	query := `
 		INSERT INTO files (
 			id, original_filename, stored_filename, bucket_name, mime_type, 
-			size_bytes, uploaded_by, tags, created_at, updated_at
-		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			size_bytes, uploaded_by, tags, created_at, updated_at, organization_id
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
+	file.OrganizationID = tenant.Owner(ctx, file.OrganizationID)
 	_, err = r.db.ExecContext(ctx, query,
 		file.ID,
 		file.OriginalFilename,
@@ -43,6 +45,7 @@ func (r *PostgresFileRepository) Create(ctx context.Context, file *entities.File
 		tagsJSON,
 		file.CreatedAt,
 		file.UpdatedAt,
+		file.OrganizationID,
 	)
 	return err
 }
@@ -51,12 +54,13 @@ func (r *PostgresFileRepository) FindByID(ctx context.Context, id string) (*enti
 	query := `
 		SELECT
 			id, original_filename, stored_filename, bucket_name, mime_type,
-			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at
+			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at,
+			organization_id
 		FROM files
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanRowToEntity(row, nil)
 }
 
@@ -64,14 +68,16 @@ func (r *PostgresFileRepository) FindByUserID(ctx context.Context, userID string
 	query := `
 		SELECT
 			id, original_filename, stored_filename, bucket_name, mime_type,
-			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at
+			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at,
+			organization_id
 		FROM files
 		WHERE uploaded_by = $1 AND deleted_at IS NULL
+			AND ($4::uuid IS NULL OR organization_id = $4)
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset, tenant.Arg(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query files: %w", err)
 	}
@@ -97,15 +103,17 @@ func (r *PostgresFileRepository) FindByTags(ctx context.Context, tags []string,
 	query := `
 		SELECT
 			id, original_filename, stored_filename, bucket_name, mime_type,
-			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at
+			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at,
+			organization_id
 		FROM files
 		WHERE tags ?| $1 AND deleted_at IS NULL
+			AND ($4::uuid IS NULL OR organization_id = $4)
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
 	tagsArray := fmt.Sprintf("{%s}", strings.Join(tags, ","))
-	rows, err := r.db.QueryContext(ctx, query, tagsArray, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, tagsArray, limit, offset, tenant.Arg(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query files: %w", err)
 	}
@@ -164,13 +172,20 @@ func (r *PostgresFileRepository) Find(ctx context.Context, query repositories.Fi
 		argIdx++
 	}
 
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("organization_id = $%d", argIdx))
+		whereArgs = append(whereArgs, organizationID)
+		argIdx++
+	}
+
 	whereClause := " WHERE " + strings.Join(whereClauses, " AND ")
 	args := whereArgs
 
 	queryBuilder.WriteString(`
 		SELECT
 			id, original_filename, stored_filename, bucket_name, mime_type,
-			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at
+			size_bytes, uploaded_by, tags, created_at, updated_at, deleted_at,
+			organization_id
 		FROM files
 	`)
 	queryBuilder.WriteString(whereClause)
@@ -318,15 +333,16 @@ func (r *PostgresFileRepository) scanRowToEntity(row *sql.Row, rows *sql.Rows) (
 		createdAt        time.Time
 		updatedAt        time.Time
 		deletedAt        sql.NullTime
+		organizationID   sql.NullString
 	)
 
 	var err error
 	if row != nil {
 		err = row.Scan(&id, &originalFilename, &storedFilename, &bucketName, &mimeType,
-			&sizeBytes, &uploadedBy, &tagsJSON, &createdAt, &updatedAt, &deletedAt)
+			&sizeBytes, &uploadedBy, &tagsJSON, &createdAt, &updatedAt, &deletedAt, &organizationID)
 	} else if rows != nil {
 		err = rows.Scan(&id, &originalFilename, &storedFilename, &bucketName, &mimeType,
-			&sizeBytes, &uploadedBy, &tagsJSON, &createdAt, &updatedAt, &deletedAt)
+			&sizeBytes, &uploadedBy, &tagsJSON, &createdAt, &updatedAt, &deletedAt, &organizationID)
 	}
 
 	if err == sql.ErrNoRows {
@@ -347,7 +363,7 @@ func (r *PostgresFileRepository) scanRowToEntity(row *sql.Row, rows *sql.Rows) (
 		deletedAtPtr = &deletedAt.Time
 	}
 
-	return &entities.File{
+	file := &entities.File{
 		ID:               id,
 		OriginalFilename: originalFilename,
 		StoredFilename:   storedFilename,
@@ -359,6 +375,10 @@ func (r *PostgresFileRepository) scanRowToEntity(row *sql.Row, rows *sql.Rows) (
 		CreatedAt:        createdAt,
 		UpdatedAt:        updatedAt,
 		DeletedAt:        deletedAtPtr,
-	}, nil
+	}
+	if organizationID.Valid {
+		file.OrganizationID = &organizationID.String
+	}
+	return file, nil
 }
 
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+)
+
+type PostgresSubmittedFileRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSubmittedFileRepository(db *sql.DB) repositories.SubmittedFileRepository {
+	return &PostgresSubmittedFileRepository{db: db}
+}
+
+// Add is idempotent, as submission events may be delivered more than once.
+func (r *PostgresSubmittedFileRepository) Add(ctx context.Context, courseOfferingID string, fileIDs []string) error {
+	query := `
+		INSERT INTO submitted_files (file_id, course_offering_id)
+		VALUES ($1, $2)
+		ON CONFLICT (file_id, course_offering_id) DO NOTHING
+	`
+	for _, fileID := range fileIDs {
+		if _, err := r.db.ExecContext(ctx, query, fileID, courseOfferingID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgresSubmittedFileRepository) FindOfferingIDs(ctx context.Context, fileID string) ([]string, error) {
+	query := `SELECT course_offering_id FROM submitted_files WHERE file_id = $1`
+	rows, err := r.db.QueryContext(ctx, query, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offeringIDs := []string{}
+	for rows.Next() {
+		var offeringID string
+		if err := rows.Scan(&offeringID); err != nil {
+			return nil, err
+		}
+		offeringIDs = append(offeringIDs, offeringID)
+	}
+	return offeringIDs, rows.Err()
+}
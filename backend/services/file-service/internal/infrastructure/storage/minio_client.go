@@ -32,7 +32,7 @@ func NewMinIOClient(cfg config.MinIOConfig, log *logger.Logger) (*MinIOClient, e
 	mc := &MinIOClient{
 		client: client,
 		logger: log,
-		buckets: []string{"course-thumbnails", "course-videos", "zoom-recordings", "general-files"},
+		buckets: []string{"course-thumbnails", "course-videos", "zoom-recordings", "general-files", "user-avatars", "assignment-submissions"},
 	}
 
 	
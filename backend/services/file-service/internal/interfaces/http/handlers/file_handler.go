@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -40,9 +41,15 @@ func NewFileHandler(
 	}
 }
 
+// publicBuckets can be downloaded from without signing in.
+var publicBuckets = map[string]bool{
+	"course-thumbnails":   true,
+	usecases.AvatarBucket: true,
+}
+
 // UploadFile godoc
 // @Summary Upload a file
-// @Description Upload a new file. Requires instructor or admin role.
+// @Description Upload a new file. Students may only upload images to the user-avatars bucket, which is used when they give no bucket, and assignment files to the assignment-submissions bucket (or with the submission tag); other buckets require instructor or admin role.
 // @Tags files
 // @Accept multipart/form-data
 // @Produce json
@@ -53,7 +60,7 @@ func NewFileHandler(
 // @Success 201 {object} map[string]interface{} "File uploaded successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 403 {object} map[string]interface{} "Forbidden - Instructor or admin role required"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Instructor or admin role required for this bucket"
 // @Failure 413 {object} map[string]interface{} "File too large"
 // @Router /files [post]
 func (h *FileHandler) UploadFile(c *gin.Context) {
@@ -92,25 +99,30 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 	}
 
 	input := usecases.UploadFileInput{
-		File:       file,
-		Filename:   header.Filename,
-		MimeType:   contentType,
-		Size:       header.Size,
-		UploadedBy: userID,
-		BucketName: bucketName,
-		Tags:       tags,
+		File:         file,
+		Filename:     header.Filename,
+		MimeType:     contentType,
+		Size:         header.Size,
+		UploadedBy:   userID,
+		UploaderRole: c.GetHeader("X-User-Role"),
+		BucketName:   bucketName,
+		Tags:         tags,
 	}
 
 	output, err := h.uploadFileUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
-		if err == usecases.ErrFileTooLarge {
+		if errors.Is(err, usecases.ErrFileTooLarge) {
 			middleware.AbortWithError(c, http.StatusRequestEntityTooLarge, err.Error())
 			return
 		}
-		if err == usecases.ErrInvalidMimeType || err == usecases.ErrFileRequired {
+		if errors.Is(err, usecases.ErrInvalidMimeType) || err == usecases.ErrFileRequired {
 			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
 			return
 		}
+		if err == usecases.ErrBucketNotAllowed {
+			middleware.AbortWithError(c, http.StatusForbidden, err.Error())
+			return
+		}
 		middleware.AbortWithError(c, http.StatusInternalServerError, "failed to upload file: "+err.Error())
 		return
 	}
@@ -120,7 +132,7 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 
 // DownloadFile godoc
 // @Summary Download a file by ID
-// @Description Download a file by its ID. Requires authentication.
+// @Description Download a file by its ID. Requires authentication. Assignment submission files are only available to the student who uploaded them, admins and the instructors of the offering they were submitted to.
 // @Tags files
 // @Produce application/octet-stream
 // @Security BearerAuth
@@ -144,8 +156,9 @@ func (h *FileHandler) DownloadFile(c *gin.Context) {
 	}
 
 	input := usecases.DownloadFileInput{
-		FileID: fileID,
-		UserID: userID,
+		FileID:   fileID,
+		UserID:   userID,
+		UserRole: c.GetHeader("X-User-Role"),
 	}
 
 	output, err := h.downloadFileUseCase.Execute(c.Request.Context(), input)
@@ -323,7 +336,7 @@ func (h *FileHandler) DeleteFile(c *gin.Context) {
 
 // DownloadFileByBucket godoc
 // @Summary Download a file from a specific bucket
-// @Description Download a file by ID from a specific bucket. Course thumbnails are public (no auth required). For zoom recordings, requires student, instructor, or admin role.
+// @Description Download a file by ID from a specific bucket. Course thumbnails and user avatars are public (no auth required). For zoom recordings, requires student, instructor, or admin role. Assignment submission files are only available to the student who uploaded them, admins and the instructors of the offering they were submitted to.
 // @Tags buckets
 // @Produce application/octet-stream
 // @Security BearerAuth
@@ -350,7 +363,7 @@ func (h *FileHandler) DownloadFileByBucket(c *gin.Context) {
 
 	userID := c.GetHeader("X-User-ID")
 	
-	if bucketName != "course-thumbnails" && userID == "" {
+	if !publicBuckets[bucketName] && userID == "" {
 		middleware.AbortWithError(c, http.StatusUnauthorized, "unauthorized")
 		return
 	}
@@ -358,6 +371,7 @@ func (h *FileHandler) DownloadFileByBucket(c *gin.Context) {
 	input := usecases.DownloadFileInput{
 		FileID: fileID,
 		UserID: userID,
+		UserRole: c.GetHeader("X-User-Role"),
 		BucketName: &bucketName,
 	}
 
@@ -4,13 +4,15 @@ import (
 	"github.com/gin-gonic/gin"
 	_ "github.com/paingphyoaungkhant/asto-microservice/services/file-service/cmd/docs"
 	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/interfaces/http/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetUpRoutes(router *gin.Engine, handler *handlers.FileHandler, logger *logger.Logger) {
+func SetUpRoutes(router *gin.Engine, handler *handlers.FileHandler, authenticate gin.HandlerFunc, redis utils.RedisInterface, rateLimit, uploadRateLimit config.RateLimitConfig, logger *logger.Logger) {
 	// Middleware
 	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger(logger))
@@ -25,9 +27,21 @@ func SetUpRoutes(router *gin.Engine, handler *handlers.FileHandler, logger *logg
 
 	// File Routes
 	api := router.Group("/api/v1")
+	api.Use(authenticate)
+	api.Use(middleware.Tenant())
+	api.Use(middleware.RateLimit(redis, middleware.RateLimitPolicy{
+		Name:    "file-api",
+		Config:  rateLimit,
+		KeyFunc: middleware.RateLimitByUser,
+	}, logger))
+	uploadLimit := middleware.RateLimit(redis, middleware.RateLimitPolicy{
+		Name:    "file-upload",
+		Config:  uploadRateLimit,
+		KeyFunc: middleware.RateLimitByUser,
+	}, logger)
 	{
 		fileRouter := api.Group("/files")
-		fileRouter.POST("", handler.UploadFile)
+		fileRouter.POST("", uploadLimit, handler.UploadFile)
 		fileRouter.GET("/:id", handler.GetFile)
 		fileRouter.GET("/:id/download", handler.DownloadFile)
 		fileRouter.GET("", handler.ListFiles)
@@ -0,0 +1,6 @@
+-- Organizations live in user-service, so there is no foreign key. Files
+-- without one predate multi-tenancy and are only visible to super-admins and
+-- anonymous downloads.
+ALTER TABLE files ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_files_organization_id ON files(organization_id);
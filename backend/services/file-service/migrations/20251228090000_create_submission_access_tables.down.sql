@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS offering_instructors;
+DROP TABLE IF EXISTS submitted_files;
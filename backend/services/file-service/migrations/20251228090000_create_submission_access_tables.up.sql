@@ -0,0 +1,20 @@
+-- Submissions and instructor assignments are owned by course-service. These
+-- copies are kept in sync from its events so assignment submission files can
+-- be limited to the student who uploaded them and the offering's instructors.
+-- Existing ones are filled in by the replay this service requests from
+-- course-service whenever it starts.
+CREATE TABLE IF NOT EXISTS submitted_files (
+    file_id UUID NOT NULL,
+    course_offering_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (file_id, course_offering_id)
+);
+
+CREATE TABLE IF NOT EXISTS offering_instructors (
+    course_offering_id UUID NOT NULL,
+    instructor_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (course_offering_id, instructor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_offering_instructors_instructor_id ON offering_instructors(instructor_id);
@@ -0,0 +1,56 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_Integration_NoCrossTenantLeakage(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	fileRepo := SetupFileRepository(db)
+	logger := SetupTestLogger()
+
+	riverside := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	hillcrest := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	superAdmin := context.Background()
+
+	upload := func(ctx context.Context, name string) *entities.File {
+		file := entities.NewFile(name, "stored-"+uuid.NewString()+".txt", "general-files", "text/plain", 100, uuid.NewString(), []string{"notes"})
+		require.NoError(t, fileRepo.Create(ctx, file))
+		require.NotNil(t, file.OrganizationID)
+		return file
+	}
+	riversideFile := upload(riverside, "river.txt")
+	hillcrestFile := upload(hillcrest, "hill.txt")
+
+	getUC := usecases.NewGetFileUseCase(fileRepo, logger, "http://localhost:3000")
+	_, err := getUC.Execute(hillcrest, usecases.GetFileInput{FileID: riversideFile.ID})
+	require.Error(t, err)
+	result, err := getUC.Execute(hillcrest, usecases.GetFileInput{FileID: hillcrestFile.ID})
+	require.NoError(t, err)
+	assert.Equal(t, hillcrestFile.ID, result.ID)
+
+	listUC := usecases.NewListFilesUseCase(fileRepo, logger, "http://localhost:3000")
+	files, total, err := listUC.Execute(riverside, usecases.ListFilesInput{Tags: []string{"notes"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, riversideFile.ID, files[0].ID)
+
+	uploadedBy := hillcrestFile.UploadedBy
+	_, total, err = listUC.Execute(riverside, usecases.ListFilesInput{UploadedBy: &uploadedBy})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	_, total, err = listUC.Execute(superAdmin, usecases.ListFilesInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
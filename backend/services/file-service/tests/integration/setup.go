@@ -13,7 +13,7 @@ import (
 func SetupTestDB(t *testing.T) (*sql.DB, func()) {
 	cfg := sharedIntegration.TestDatabaseConfig{
 		MigrationPath:    "migrations",
-		TablesToCleanUp: []string{"files"},
+		TablesToCleanUp: []string{"files", "submitted_files", "offering_instructors"},
 	}
 
 	db, cleanup, err := sharedIntegration.SetUpTestDatabase(t, cfg)
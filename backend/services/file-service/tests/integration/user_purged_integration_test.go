@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectRemover stands in for MinIO, which the integration tests do not run.
+type objectRemover struct {
+	removed []string
+}
+
+func (r *objectRemover) DeleteFile(ctx context.Context, bucketName, objectName string) error {
+	r.removed = append(r.removed, bucketName+"/"+objectName)
+	return nil
+}
+
+func TestUserPurged_Integration_DeletesOnlyAvatars(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	fileRepo := SetupFileRepository(db)
+	remover := &objectRemover{}
+	ctx := context.Background()
+
+	userID := uuid.New().String()
+	avatar := entities.NewFile("me.png", "avatar.png", usecases.AvatarBucket, "image/png", 100, userID, []string{"avatar"})
+	require.NoError(t, fileRepo.Create(ctx, avatar))
+	material := entities.NewFile("notes.pdf", "notes.pdf", "course-materials", "application/pdf", 100, userID, []string{})
+	require.NoError(t, fileRepo.Create(ctx, material))
+	otherAvatar := entities.NewFile("other.png", "other.png", usecases.AvatarBucket, "image/png", 100, uuid.New().String(), []string{"avatar"})
+	require.NoError(t, fileRepo.Create(ctx, otherAvatar))
+
+	body, err := json.Marshal(events.UserPurgedEvent{ID: userID, Role: "instructor", PurgedAt: time.Now()})
+	require.NoError(t, err)
+
+	handler := handlers.NewUserPurgedHandler(fileRepo, remover, SetupTestLogger())
+	require.NoError(t, handler.Handle(body))
+
+	got, err := fileRepo.FindByID(ctx, avatar.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsDeleted())
+	assert.Equal(t, []string{usecases.AvatarBucket + "/avatar.png"}, remover.removed)
+
+	got, err = fileRepo.FindByID(ctx, material.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsDeleted())
+
+	got, err = fileRepo.FindByID(ctx, otherAvatar.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsDeleted())
+}
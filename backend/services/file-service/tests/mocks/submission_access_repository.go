@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSubmittedFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubmittedFileRepository) Add(ctx context.Context, courseOfferingID string, fileIDs []string) error {
+	args := m.Called(ctx, courseOfferingID, fileIDs)
+	return args.Error(0)
+}
+
+func (m *MockSubmittedFileRepository) FindOfferingIDs(ctx context.Context, fileID string) ([]string, error) {
+	args := m.Called(ctx, fileID)
+	offeringIDs, _ := args.Get(0).([]string)
+	return offeringIDs, args.Error(1)
+}
+
+type MockOfferingInstructorRepository struct {
+	mock.Mock
+}
+
+func (m *MockOfferingInstructorRepository) Add(ctx context.Context, courseOfferingID, instructorID string) error {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Error(0)
+}
+
+func (m *MockOfferingInstructorRepository) Remove(ctx context.Context, courseOfferingID, instructorID string) error {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Error(0)
+}
+
+func (m *MockOfferingInstructorRepository) IsInstructor(ctx context.Context, courseOfferingID, instructorID string) (bool, error) {
+	args := m.Called(ctx, courseOfferingID, instructorID)
+	return args.Bool(0), args.Error(1)
+}
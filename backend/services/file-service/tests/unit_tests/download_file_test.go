@@ -22,7 +22,7 @@ func TestDownloadFile_NotFound(t *testing.T) {
 
 	repo.On("FindByID", mock.Anything, "file-id").Return(nil, errors.New("not found")).Once()
 
-	uc := usecases.NewDownloadFileUseCase(repo, storageClient, logger)
+	uc := usecases.NewDownloadFileUseCase(repo, new(mocks.MockSubmittedFileRepository), new(mocks.MockOfferingInstructorRepository), storageClient, logger)
 
 	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
 		FileID: "file-id",
@@ -43,7 +43,7 @@ func TestDownloadFile_BucketMismatch(t *testing.T) {
 
 	repo.On("FindByID", mock.Anything, "file-id").Return(file, nil).Once()
 
-	uc := usecases.NewDownloadFileUseCase(repo, storageClient, logger)
+	uc := usecases.NewDownloadFileUseCase(repo, new(mocks.MockSubmittedFileRepository), new(mocks.MockOfferingInstructorRepository), storageClient, logger)
 
 	bucket := "bucket2"
 	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
@@ -67,7 +67,7 @@ func TestDownloadFile_FileAlreadyDeleted(t *testing.T) {
 
 	repo.On("FindByID", mock.Anything, "file-id").Return(file, nil).Once()
 
-	uc := usecases.NewDownloadFileUseCase(repo, storageClient, logger)
+	uc := usecases.NewDownloadFileUseCase(repo, new(mocks.MockSubmittedFileRepository), new(mocks.MockOfferingInstructorRepository), storageClient, logger)
 
 	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
 		FileID: "file-id",
@@ -79,3 +79,67 @@ func TestDownloadFile_FileAlreadyDeleted(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestDownloadFile_SubmissionRefusedToOtherStudents(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	submittedFileRepo := new(mocks.MockSubmittedFileRepository)
+	offeringInstructorRepo := new(mocks.MockOfferingInstructorRepository)
+
+	file := entities.NewFile("essay.pdf", "stored.pdf", usecases.SubmissionBucket, "application/pdf", 100, "student-1", []string{"submission"})
+	repo.On("FindByID", mock.Anything, "file-id").Return(file, nil).Once()
+
+	uc := usecases.NewDownloadFileUseCase(repo, submittedFileRepo, offeringInstructorRepo, &storage.MinIOClient{}, logger.NewNop())
+
+	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
+		FileID:   "file-id",
+		UserID:   "student-2",
+		UserRole: "student",
+	})
+
+	assert.Equal(t, usecases.ErrFileNotFound, err)
+	submittedFileRepo.AssertNotCalled(t, "FindOfferingIDs", mock.Anything, mock.Anything)
+}
+
+func TestDownloadFile_SubmissionRefusedToInstructorsOfOtherOfferings(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	submittedFileRepo := new(mocks.MockSubmittedFileRepository)
+	offeringInstructorRepo := new(mocks.MockOfferingInstructorRepository)
+
+	file := entities.NewFile("essay.pdf", "stored.pdf", usecases.SubmissionBucket, "application/pdf", 100, "student-1", []string{"submission"})
+	repo.On("FindByID", mock.Anything, "file-id").Return(file, nil).Once()
+	submittedFileRepo.On("FindOfferingIDs", mock.Anything, file.ID).Return([]string{"offering-1"}, nil).Once()
+	offeringInstructorRepo.On("IsInstructor", mock.Anything, "offering-1", "instructor-2").Return(false, nil).Once()
+
+	uc := usecases.NewDownloadFileUseCase(repo, submittedFileRepo, offeringInstructorRepo, &storage.MinIOClient{}, logger.NewNop())
+
+	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
+		FileID:   "file-id",
+		UserID:   "instructor-2",
+		UserRole: "instructor",
+	})
+
+	assert.Equal(t, usecases.ErrFileNotFound, err)
+	submittedFileRepo.AssertExpectations(t)
+	offeringInstructorRepo.AssertExpectations(t)
+}
+
+func TestDownloadFile_SubmissionCheckFailureIsNotHidden(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	submittedFileRepo := new(mocks.MockSubmittedFileRepository)
+	offeringInstructorRepo := new(mocks.MockOfferingInstructorRepository)
+
+	file := entities.NewFile("essay.pdf", "stored.pdf", usecases.SubmissionBucket, "application/pdf", 100, "student-1", []string{"submission"})
+	repo.On("FindByID", mock.Anything, "file-id").Return(file, nil).Once()
+	submittedFileRepo.On("FindOfferingIDs", mock.Anything, file.ID).Return(nil, errors.New("db down")).Once()
+
+	uc := usecases.NewDownloadFileUseCase(repo, submittedFileRepo, offeringInstructorRepo, &storage.MinIOClient{}, logger.NewNop())
+
+	_, err := uc.Execute(context.Background(), usecases.DownloadFileInput{
+		FileID:   "file-id",
+		UserID:   "instructor-1",
+		UserRole: "instructor",
+	})
+
+	require.Error(t, err)
+	assert.NotEqual(t, usecases.ErrFileNotFound, err)
+	offeringInstructorRepo.AssertNotCalled(t, "IsInstructor", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,105 @@
+package unit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	sharedMocks "github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newUploadLimitRouter(redis utils.RedisInterface, cfg config.RateLimitConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/files", middleware.RateLimit(redis, middleware.RateLimitPolicy{
+		Name:    "file-upload",
+		Config:  cfg,
+		KeyFunc: middleware.RateLimitByUser,
+	}, logger.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return router
+}
+
+func uploadRequest(userID string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/files", nil)
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	return req
+}
+
+var uploadLimitConfig = config.RateLimitConfig{Enabled: true, Requests: 10, Window: time.Minute}
+
+func TestRateLimit_AllowedSetsHeaders(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	redis.On("AllowRateLimit", mock.Anything, "file-upload:user:user-1", 10, time.Minute).
+		Return(&utils.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, ResetAfter: 59500 * time.Millisecond}, nil).Once()
+
+	w := httptest.NewRecorder()
+	newUploadLimitRouter(redis, uploadLimitConfig).ServeHTTP(w, uploadRequest("user-1"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "9", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+	redis.AssertExpectations(t)
+}
+
+func TestRateLimit_ExceededReturns429(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	redis.On("AllowRateLimit", mock.Anything, "file-upload:user:user-1", 10, time.Minute).
+		Return(&utils.RateLimitResult{Allowed: false, Limit: 10, Remaining: 0, ResetAfter: 12 * time.Second}, nil).Once()
+
+	w := httptest.NewRecorder()
+	newUploadLimitRouter(redis, uploadLimitConfig).ServeHTTP(w, uploadRequest("user-1"))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "12", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Contains(t, w.Body.String(), "rate limit exceeded")
+}
+
+func TestRateLimit_AnonymousFallsBackToIP(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	redis.On("AllowRateLimit", mock.Anything, "file-upload:ip:192.0.2.1", 10, time.Minute).
+		Return(&utils.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, ResetAfter: time.Minute}, nil).Once()
+
+	w := httptest.NewRecorder()
+	newUploadLimitRouter(redis, uploadLimitConfig).ServeHTTP(w, uploadRequest(""))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	redis.AssertExpectations(t)
+}
+
+func TestRateLimit_RedisErrorFailsOpen(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+	redis.On("AllowRateLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("redis down")).Once()
+
+	w := httptest.NewRecorder()
+	newUploadLimitRouter(redis, uploadLimitConfig).ServeHTTP(w, uploadRequest("user-1"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimit_DisabledSkipsRedis(t *testing.T) {
+	redis := new(sharedMocks.MockRedis)
+
+	w := httptest.NewRecorder()
+	newUploadLimitRouter(redis, config.RateLimitConfig{Enabled: false, Requests: 10, Window: time.Minute}).ServeHTTP(w, uploadRequest("user-1"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	redis.AssertNotCalled(t, "AllowRateLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -118,3 +118,72 @@ func TestUploadFile_MissingUploadedBy(t *testing.T) {
 	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
+
+func TestUploadFile_StudentCannotUploadOutsideAvatars(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	uc := usecases.NewUploadFileUseCase(repo, &storage.MinIOClient{}, logger.NewNop(), "http://localhost:3000")
+
+	_, err := uc.Execute(context.Background(), usecases.UploadFileInput{
+		File:         bytes.NewReader([]byte("image")),
+		Filename:     "thumb.png",
+		MimeType:     "image/png",
+		Size:         5,
+		UploadedBy:   "user-123",
+		UploaderRole: "student",
+		BucketName:   "course-thumbnails",
+	})
+
+	require.ErrorIs(t, err, usecases.ErrBucketNotAllowed)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUploadFile_AvatarMustBeSmallImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		size     int64
+		err      error
+	}{
+		{"not an image", "application/pdf", 100, usecases.ErrInvalidMimeType},
+		{"too large", "image/png", usecases.MaxAvatarSize + 1, usecases.ErrFileTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockFileRepository)
+			uc := usecases.NewUploadFileUseCase(repo, &storage.MinIOClient{}, logger.NewNop(), "http://localhost:3000")
+
+			// Students get the avatar bucket without naming it.
+			_, err := uc.Execute(context.Background(), usecases.UploadFileInput{
+				File:         bytes.NewReader([]byte("avatar")),
+				Filename:     "avatar.png",
+				MimeType:     tt.mimeType,
+				Size:         tt.size,
+				UploadedBy:   "user-123",
+				UploaderRole: "student",
+			})
+
+			require.ErrorIs(t, err, tt.err)
+			repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUploadFile_StudentSubmissionIsSizeCapped(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	uc := usecases.NewUploadFileUseCase(repo, &storage.MinIOClient{}, logger.NewNop(), "http://localhost:3000")
+
+	// The submission tag sends student uploads to the submission bucket
+	// rather than the avatar bucket, so any file type is accepted.
+	_, err := uc.Execute(context.Background(), usecases.UploadFileInput{
+		File:         bytes.NewReader([]byte("essay")),
+		Filename:     "essay.pdf",
+		MimeType:     "application/pdf",
+		Size:         usecases.MaxSubmissionSize + 1,
+		UploadedBy:   "user-123",
+		UploaderRole: "student",
+		Tags:         []string{"submission"},
+	})
+
+	require.ErrorIs(t, err, usecases.ErrFileTooLarge)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
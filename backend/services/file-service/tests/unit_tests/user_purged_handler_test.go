@@ -0,0 +1,116 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/handlers"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/file-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// objectRemover records the objects it is asked to delete.
+type objectRemover struct {
+	removed []string
+	err     error
+}
+
+func (r *objectRemover) DeleteFile(ctx context.Context, bucketName, objectName string) error {
+	r.removed = append(r.removed, bucketName+"/"+objectName)
+	return r.err
+}
+
+func userPurgedBody(t *testing.T, userID string) []byte {
+	body, err := json.Marshal(events.UserPurgedEvent{ID: userID, Role: "student", PurgedAt: time.Now()})
+	require.NoError(t, err)
+	return body
+}
+
+func isAvatarQueryFor(userID string) interface{} {
+	return isBucketQueryFor(userID, usecases.AvatarBucket)
+}
+
+func isBucketQueryFor(userID, bucket string) interface{} {
+	return mock.MatchedBy(func(query repositories.FileQuery) bool {
+		return query.UploadedBy != nil && *query.UploadedBy == userID &&
+			query.BucketName != nil && *query.BucketName == bucket
+	})
+}
+
+func noFilesIn(repo *mocks.MockFileRepository, userID, bucket string) {
+	repo.On("Find", mock.Anything, isBucketQueryFor(userID, bucket)).
+		Return(&repositories.FileQueryResult{Files: []*entities.File{}}, nil).Once()
+}
+
+func TestUserPurgedHandler_DeletesAvatars(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	remover := &objectRemover{}
+
+	avatar := entities.NewFile("me.png", "stored.png", usecases.AvatarBucket, "image/png", 100, "user-123", []string{"avatar"})
+	repo.On("Find", mock.Anything, isAvatarQueryFor("user-123")).
+		Return(&repositories.FileQueryResult{Files: []*entities.File{avatar}, Total: 1}, nil).Once()
+	repo.On("SoftDelete", mock.Anything, avatar.ID).Return(nil).Once()
+	noFilesIn(repo, "user-123", usecases.SubmissionBucket)
+
+	handler := handlers.NewUserPurgedHandler(repo, remover, logger.NewNop())
+	require.NoError(t, handler.Handle(userPurgedBody(t, "user-123")))
+
+	assert.Equal(t, []string{usecases.AvatarBucket + "/stored.png"}, remover.removed)
+	repo.AssertExpectations(t)
+}
+
+func TestUserPurgedHandler_StorageErrorIgnored(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	remover := &objectRemover{err: errors.New("minio down")}
+
+	avatar := entities.NewFile("me.png", "stored.png", usecases.AvatarBucket, "image/png", 100, "user-123", []string{"avatar"})
+	repo.On("Find", mock.Anything, isAvatarQueryFor("user-123")).
+		Return(&repositories.FileQueryResult{Files: []*entities.File{avatar}, Total: 1}, nil).Once()
+	repo.On("SoftDelete", mock.Anything, avatar.ID).Return(nil).Once()
+	noFilesIn(repo, "user-123", usecases.SubmissionBucket)
+
+	handler := handlers.NewUserPurgedHandler(repo, remover, logger.NewNop())
+	require.NoError(t, handler.Handle(userPurgedBody(t, "user-123")))
+
+	repo.AssertExpectations(t)
+}
+
+func TestUserPurgedHandler_DeletesSubmissionFiles(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	remover := &objectRemover{}
+
+	essay := entities.NewFile("essay.pdf", "stored.pdf", usecases.SubmissionBucket, "application/pdf", 100, "user-123", []string{"submission"})
+	noFilesIn(repo, "user-123", usecases.AvatarBucket)
+	repo.On("Find", mock.Anything, isBucketQueryFor("user-123", usecases.SubmissionBucket)).
+		Return(&repositories.FileQueryResult{Files: []*entities.File{essay}, Total: 1}, nil).Once()
+	repo.On("SoftDelete", mock.Anything, essay.ID).Return(nil).Once()
+
+	handler := handlers.NewUserPurgedHandler(repo, remover, logger.NewNop())
+	require.NoError(t, handler.Handle(userPurgedBody(t, "user-123")))
+
+	assert.Equal(t, []string{usecases.SubmissionBucket + "/stored.pdf"}, remover.removed)
+	repo.AssertExpectations(t)
+}
+
+func TestUserPurgedHandler_FindError(t *testing.T) {
+	repo := new(mocks.MockFileRepository)
+	remover := &objectRemover{}
+
+	repo.On("Find", mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+	handler := handlers.NewUserPurgedHandler(repo, remover, logger.NewNop())
+	require.Error(t, handler.Handle(userPurgedBody(t, "user-123")))
+
+	assert.Empty(t, remover.removed)
+	repo.AssertNotCalled(t, "SoftDelete", mock.Anything, mock.Anything)
+}
@@ -67,6 +67,13 @@ func main() {
 	studentRegisteredHandler := handlers.NewStudentRegisteredHandler(emailService, appLogger)
 	emailVerificationRequestHandler := handlers.NewEmailVerificationRequestHandler(emailService, appLogger)
 	forgotPasswordHandler := handlers.NewForgotPasswordHandler(emailService, redis, appLogger)
+	accountLockedHandler := handlers.NewAccountLockedHandler(emailService, appLogger)
+	userInvitedHandler := handlers.NewUserInvitedHandler(emailService, appLogger)
+	magicLinkHandler := handlers.NewMagicLinkHandler(emailService, appLogger)
+	newLoginHandler := handlers.NewNewLoginHandler(emailService, appLogger)
+	userSuspendedHandler := handlers.NewUserSuspendedHandler(emailService, appLogger)
+	assignmentSubmittedHandler := handlers.NewAssignmentSubmittedHandler(emailService, appLogger)
+	assignmentGradedHandler := handlers.NewAssignmentGradedHandler(emailService, appLogger)
 
 	eventConsumer := consumer.NewEventConsumer(
 		rabbitMQ,
@@ -74,6 +81,13 @@ func main() {
 		studentRegisteredHandler,
 		emailVerificationRequestHandler,
 		forgotPasswordHandler,
+		accountLockedHandler,
+		userInvitedHandler,
+		magicLinkHandler,
+		newLoginHandler,
+		userSuspendedHandler,
+		assignmentSubmittedHandler,
+		assignmentGradedHandler,
 		appLogger,
 	)
 
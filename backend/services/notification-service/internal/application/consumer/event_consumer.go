@@ -16,6 +16,13 @@ type EventConsumer struct {
 	studentRegisteredHandler      *handlers.StudentRegisteredHandler
 	emailVerificationRequestHandler *handlers.EmailVerificationRequestHandler
 	forgotPasswordHandler        *handlers.ForgotPasswordHandler
+	accountLockedHandler          *handlers.AccountLockedHandler
+	userInvitedHandler            *handlers.UserInvitedHandler
+	magicLinkHandler              *handlers.MagicLinkHandler
+	newLoginHandler               *handlers.NewLoginHandler
+	userSuspendedHandler          *handlers.UserSuspendedHandler
+	assignmentSubmittedHandler    *handlers.AssignmentSubmittedHandler
+	assignmentGradedHandler       *handlers.AssignmentGradedHandler
 	logger                        *logger.Logger
 }
 
@@ -25,6 +32,13 @@ func NewEventConsumer(
 	studentRegisteredHandler *handlers.StudentRegisteredHandler,
 	emailVerificationRequestHandler *handlers.EmailVerificationRequestHandler,
 	forgotPasswordHandler *handlers.ForgotPasswordHandler,
+	accountLockedHandler *handlers.AccountLockedHandler,
+	userInvitedHandler *handlers.UserInvitedHandler,
+	magicLinkHandler *handlers.MagicLinkHandler,
+	newLoginHandler *handlers.NewLoginHandler,
+	userSuspendedHandler *handlers.UserSuspendedHandler,
+	assignmentSubmittedHandler *handlers.AssignmentSubmittedHandler,
+	assignmentGradedHandler *handlers.AssignmentGradedHandler,
 	logger *logger.Logger,
 ) *EventConsumer {
 	return &EventConsumer{
@@ -33,6 +47,13 @@ func NewEventConsumer(
 		studentRegisteredHandler:      studentRegisteredHandler,
 		emailVerificationRequestHandler: emailVerificationRequestHandler,
 		forgotPasswordHandler:        forgotPasswordHandler,
+		accountLockedHandler:          accountLockedHandler,
+		userInvitedHandler:            userInvitedHandler,
+		magicLinkHandler:              magicLinkHandler,
+		newLoginHandler:               newLoginHandler,
+		userSuspendedHandler:          userSuspendedHandler,
+		assignmentSubmittedHandler:    assignmentSubmittedHandler,
+		assignmentGradedHandler:       assignmentGradedHandler,
 		logger:                        logger,
 	}
 }
@@ -43,6 +64,13 @@ func (c *EventConsumer) Start(ctx context.Context) error {
 		events.EventTypeAuthStudentRegistered,
 		events.EventTypeAuthUserRequestedEmailVerification,
 		events.EventTypeAuthUserForgotPassword,
+		events.EventTypeAuthAccountLocked,
+		events.EventTypeAuthUserInvited,
+		events.EventTypeAuthMagicLinkRequested,
+		events.EventTypeAuthNewLoginDetected,
+		events.EventTypeUserSuspended,
+		events.EventTypeAssignmentSubmitted,
+		events.EventTypeAssignmentGraded,
 	}
 
 	messages, err := c.rabbitMQ.Consume(ctx, "notification-service.queue", routingKeys)
@@ -92,6 +120,20 @@ func (c *EventConsumer) handleMessage(msg messaging.Message) error {
 		return c.emailVerificationRequestHandler.Handle(msg.Body)
 	case events.EventTypeAuthUserForgotPassword:
 		return c.forgotPasswordHandler.Handle(msg.Body)
+	case events.EventTypeAuthAccountLocked:
+		return c.accountLockedHandler.Handle(msg.Body)
+	case events.EventTypeAuthUserInvited:
+		return c.userInvitedHandler.Handle(msg.Body)
+	case events.EventTypeAuthMagicLinkRequested:
+		return c.magicLinkHandler.Handle(msg.Body)
+	case events.EventTypeAuthNewLoginDetected:
+		return c.newLoginHandler.Handle(msg.Body)
+	case events.EventTypeUserSuspended:
+		return c.userSuspendedHandler.Handle(msg.Body)
+	case events.EventTypeAssignmentSubmitted:
+		return c.assignmentSubmittedHandler.Handle(msg.Body)
+	case events.EventTypeAssignmentGraded:
+		return c.assignmentGradedHandler.Handle(msg.Body)
 	default:
 		c.logger.Warn("unknown routing key",
 			zap.String("routing_key", msg.RoutingKey),
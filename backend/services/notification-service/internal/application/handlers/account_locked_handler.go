@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+var accountLockedActions = map[string]string{
+	"login": "sign-in",
+	"otp":   "password reset code",
+}
+
+type AccountLockedHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewAccountLockedHandler(emailService *email.EmailService, logger *logger.Logger) *AccountLockedHandler {
+	return &AccountLockedHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *AccountLockedHandler) Handle(body []byte) error {
+	var event events.AuthAccountLockedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal account locked event", zap.Error(err))
+		return err
+	}
+
+	action, ok := accountLockedActions[event.Action]
+	if !ok {
+		action = event.Action
+	}
+
+	templateData := map[string]interface{}{
+		"Username":       event.Username,
+		"Action":         action,
+		"FailedAttempts": event.FailedAttempts,
+		"IPAddress":      event.IPAddress,
+		"LockedAt":       event.LockedAt.UTC().Format(time.RFC1123),
+		"LockedUntil":    event.LockedUntil.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.AccountLocked, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.Email,
+		Subject: "Your account has been temporarily locked",
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send account locked email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("account locked email sent",
+		zap.String("user_id", event.ID),
+		zap.String("email", event.Email),
+	)
+
+	return nil
+}
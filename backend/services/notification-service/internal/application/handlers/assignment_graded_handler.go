@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html"
+	"strconv"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// AssignmentGradedHandler tells students their score and feedback when an
+// instructor grades one of their submissions.
+type AssignmentGradedHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewAssignmentGradedHandler(emailService *email.EmailService, logger *logger.Logger) *AssignmentGradedHandler {
+	return &AssignmentGradedHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *AssignmentGradedHandler) Handle(body []byte) error {
+	var event events.AssignmentGradedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal assignment graded event", zap.Error(err))
+		return err
+	}
+
+	if event.StudentEmail == "" {
+		h.logger.Warn("assignment graded event has no student email, skipping",
+			zap.String("submission_id", event.ID),
+			zap.String("student_id", event.StudentID),
+		)
+		return nil
+	}
+
+	// Feedback is free text written by the instructor, so it is escaped
+	// before going into the HTML body.
+	feedback := ""
+	if event.Feedback != nil {
+		feedback = html.EscapeString(*event.Feedback)
+	}
+
+	templateData := map[string]interface{}{
+		"AssignmentName":   html.EscapeString(assignmentName(event.AssignmentName)),
+		"SubmissionNumber": event.SubmissionNumber,
+		"Score":            formatScore(event.Score),
+		"FinalScore":       formatScore(event.FinalScore),
+		"MaxScore":         formatScore(event.MaxScore),
+		"PenaltyPercent":   event.PenaltyPercent,
+		"Feedback":         feedback,
+		"GradedAt":         event.GradedAt.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.AssignmentGraded, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.StudentEmail,
+		Subject: "Graded: " + assignmentName(event.AssignmentName),
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send assignment graded email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("assignment graded email sent",
+		zap.String("submission_id", event.ID),
+		zap.String("email", event.StudentEmail),
+	)
+
+	return nil
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
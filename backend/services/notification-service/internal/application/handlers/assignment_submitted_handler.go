@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// AssignmentSubmittedHandler sends students a receipt for each assignment
+// submission.
+type AssignmentSubmittedHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewAssignmentSubmittedHandler(emailService *email.EmailService, logger *logger.Logger) *AssignmentSubmittedHandler {
+	return &AssignmentSubmittedHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *AssignmentSubmittedHandler) Handle(body []byte) error {
+	var event events.AssignmentSubmittedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal assignment submitted event", zap.Error(err))
+		return err
+	}
+
+	if event.StudentEmail == "" {
+		h.logger.Warn("assignment submitted event has no student email, skipping",
+			zap.String("submission_id", event.ID),
+			zap.String("student_id", event.StudentID),
+		)
+		return nil
+	}
+
+	dueAt := "no due date"
+	if event.DueAt != nil {
+		dueAt = event.DueAt.UTC().Format(time.RFC1123)
+	}
+
+	templateData := map[string]interface{}{
+		"AssignmentName":   html.EscapeString(assignmentName(event.AssignmentName)),
+		"SubmissionNumber": event.SubmissionNumber,
+		"FileCount":        len(event.FileIDs),
+		"Late":             event.Late,
+		"DueAt":            dueAt,
+		"SubmittedAt":      event.SubmittedAt.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.AssignmentSubmitted, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.StudentEmail,
+		Subject: "Submission received: " + assignmentName(event.AssignmentName),
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send assignment submitted email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("assignment submitted email sent",
+		zap.String("submission_id", event.ID),
+		zap.String("email", event.StudentEmail),
+	)
+
+	return nil
+}
+
+// assignmentName falls back to a generic name when course-service could not
+// load the assignment's module.
+func assignmentName(name string) string {
+	if name == "" {
+		return "your assignment"
+	}
+	return name
+}
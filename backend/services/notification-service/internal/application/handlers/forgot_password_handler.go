@@ -33,7 +33,11 @@ func (h *ForgotPasswordHandler) Handle(body []byte) error {
 		return err
 	}
 
-	otp := utils.GenerateOTP(6)
+	otp, err := utils.GenerateOTP(6)
+	if err != nil {
+		h.logger.Error("failed to generate forgot password OTP", zap.Error(err))
+		return err
+	}
 
 	ctx := context.Background()
 	if err := h.redis.StoreForgotPasswordOTP(ctx, event.ID, otp); err != nil {
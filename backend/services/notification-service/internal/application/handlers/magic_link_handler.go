@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+type MagicLinkHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewMagicLinkHandler(emailService *email.EmailService, logger *logger.Logger) *MagicLinkHandler {
+	return &MagicLinkHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *MagicLinkHandler) Handle(body []byte) error {
+	var event events.AuthMagicLinkRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal magic link requested event", zap.Error(err))
+		return err
+	}
+
+	templateData := map[string]interface{}{
+		"Username":     event.Username,
+		"MagicLinkURL": event.MagicLinkURL,
+		"IPAddress":    event.IPAddress,
+		"ExpiresAt":    event.ExpiresAt.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.MagicLink, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.Email,
+		Subject: "Your ASTO LMS sign-in link",
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send magic link email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("magic link email sent",
+		zap.String("user_id", event.ID),
+		zap.String("email", event.Email),
+	)
+
+	return nil
+}
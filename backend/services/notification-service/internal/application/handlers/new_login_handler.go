@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+type NewLoginHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewNewLoginHandler(emailService *email.EmailService, logger *logger.Logger) *NewLoginHandler {
+	return &NewLoginHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *NewLoginHandler) Handle(body []byte) error {
+	var event events.AuthNewLoginDetectedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal new login detected event", zap.Error(err))
+		return err
+	}
+
+	templateData := map[string]interface{}{
+		"Username":   event.Username,
+		"Device":     event.Device,
+		"IPAddress":  event.IPAddress,
+		"NewDevice":  event.NewDevice,
+		"LoggedInAt": event.LoggedInAt.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.NewLogin, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.Email,
+		Subject: "New sign-in to your ASTO LMS account",
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send new login email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("new login email sent",
+		zap.String("user_id", event.ID),
+		zap.String("email", event.Email),
+	)
+
+	return nil
+}
@@ -30,6 +30,13 @@ func (h *StudentRegisteredHandler) Handle(body []byte) error {
 		return err
 	}
 
+	// Students signing up through an external provider arrive with a
+	// verified email and nothing to confirm.
+	if event.EmailVerified {
+		h.logger.Info("skipping verification email for verified student", zap.String("user_id", event.ID))
+		return nil
+	}
+
 	templateData := map[string]interface{}{
 		"VerificationURL": event.EmailVerificationURL,
 	}
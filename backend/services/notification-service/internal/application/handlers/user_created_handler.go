@@ -30,10 +30,18 @@ func (h *UserCreatedHandler) Handle(body []byte) error {
 	}
 
 	templateData := map[string]interface{}{
+		"Username":        event.Username,
 		"VerificationURL": event.EmailVerificationURL,
+		"SetPasswordURL":  event.SetPasswordURL,
 	}
 
-	htmlBody, err := h.emailService.RenderTemplate(templates.EmailVerification, templateData)
+	// Accounts created without a password also need a link to set one.
+	template, subject := templates.EmailVerification, "Verify Your Email Address"
+	if event.SetPasswordURL != "" {
+		template, subject = templates.AccountSetup, "Your ASTO LMS Account Is Ready"
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(template, templateData)
 	if err != nil {
 		h.logger.Error("failed to render email template", zap.Error(err))
 		return err
@@ -41,7 +49,7 @@ func (h *UserCreatedHandler) Handle(body []byte) error {
 
 	emailData := email.EmailData{
 		To:      event.Email,
-		Subject: "Verify Your Email Address",
+		Subject: subject,
 		Body:    htmlBody,
 	}
 
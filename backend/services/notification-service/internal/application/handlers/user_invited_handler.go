@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+var invitationRoles = map[string]string{
+	"instructor": "an instructor",
+	"admin":      "an administrator",
+}
+
+type UserInvitedHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewUserInvitedHandler(emailService *email.EmailService, logger *logger.Logger) *UserInvitedHandler {
+	return &UserInvitedHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *UserInvitedHandler) Handle(body []byte) error {
+	var event events.AuthUserInvitedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user invited event", zap.Error(err))
+		return err
+	}
+
+	role, ok := invitationRoles[event.Role]
+	if !ok {
+		role = event.Role
+	}
+	invitedBy := event.InvitedByEmail
+	if invitedBy == "" {
+		invitedBy = "An administrator"
+	}
+
+	templateData := map[string]interface{}{
+		"InvitedBy":     invitedBy,
+		"Role":          role,
+		"InvitationURL": event.InvitationURL,
+		"ExpiresAt":     event.ExpiresAt.UTC().Format(time.RFC1123),
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.Invitation, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.Email,
+		Subject: "You're invited to ASTO LMS",
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send invitation email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("invitation email sent",
+		zap.String("invitation_id", event.ID),
+		zap.String("email", event.Email),
+	)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/domain/templates"
+	"github.com/paingphyoaungkhant/asto-microservice/services/notification-service/internal/infrastructure/email"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+type UserSuspendedHandler struct {
+	emailService *email.EmailService
+	logger       *logger.Logger
+}
+
+func NewUserSuspendedHandler(emailService *email.EmailService, logger *logger.Logger) *UserSuspendedHandler {
+	return &UserSuspendedHandler{
+		emailService: emailService,
+		logger:       logger,
+	}
+}
+
+func (h *UserSuspendedHandler) Handle(body []byte) error {
+	var event events.UserSuspendedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		h.logger.Error("failed to unmarshal user suspended event", zap.Error(err))
+		return err
+	}
+
+	suspendedUntil := "until lifted by an administrator"
+	if event.ExpiresAt != nil {
+		suspendedUntil = event.ExpiresAt.UTC().Format(time.RFC1123)
+	}
+
+	templateData := map[string]interface{}{
+		"Username":       event.Username,
+		"Reason":         event.Reason,
+		"SuspendedAt":    event.SuspendedAt.UTC().Format(time.RFC1123),
+		"SuspendedUntil": suspendedUntil,
+	}
+
+	htmlBody, err := h.emailService.RenderTemplate(templates.UserSuspended, templateData)
+	if err != nil {
+		h.logger.Error("failed to render email template", zap.Error(err))
+		return err
+	}
+
+	emailData := email.EmailData{
+		To:      event.Email,
+		Subject: "Your account has been suspended",
+		Body:    htmlBody,
+	}
+
+	if err := h.emailService.SendEmail(emailData); err != nil {
+		h.logger.Error("failed to send user suspended email", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("user suspended email sent",
+		zap.String("user_id", event.ID),
+		zap.String("email", event.Email),
+	)
+
+	return nil
+}
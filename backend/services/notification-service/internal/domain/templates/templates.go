@@ -8,3 +8,27 @@ var EmailVerification string
 //go:embed forgot_password_otp.html
 var ForgotPasswordOTP string
 
+
+//go:embed account_locked.html
+var AccountLocked string
+
+//go:embed invitation.html
+var Invitation string
+
+//go:embed magic_link.html
+var MagicLink string
+
+//go:embed new_login.html
+var NewLogin string
+
+//go:embed account_setup.html
+var AccountSetup string
+
+//go:embed user_suspended.html
+var UserSuspended string
+
+//go:embed assignment_submitted.html
+var AssignmentSubmitted string
+
+//go:embed assignment_graded.html
+var AssignmentGraded string
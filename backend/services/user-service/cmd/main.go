@@ -11,6 +11,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -22,11 +23,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	userPostgres "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/persistence/postgres"
 	httpRouter "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/interfaces/http"
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/interfaces/http/handlers"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -65,27 +68,52 @@ func main() {
 		appLogger.Info("rabbitmq connected successfully")
 	}
 
-	redis, err := utils.NewRedis(&config.Redis)
+	// Redis also holds the email verification tokens of new users.
+	authenticate, redis, closeRedis, err := middleware.AuthenticateWithRedis(config.Auth, &config.Redis, appLogger)
 	if err != nil {
-		appLogger.Error("failed to create redis", zap.Error(err))
-	} else {
-		appLogger.Info("redis connected successfully")
+		appLogger.Fatal("failed to set up token verification", zap.Error(err))
 	}
+	defer closeRedis()
 
 	userRepo := postgres.NewPostgresUserRepository(db)
-	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, rabbitMQ, appLogger, redis, config.Server.APIGatewayURL)
+	suspensionRepo := userPostgres.NewPostgresSuspensionRepository(db)
+	groupRepo := userPostgres.NewPostgresGroupRepository(db)
+	organizationRepo := userPostgres.NewPostgresOrganizationRepository(db)
+	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, organizationRepo, rabbitMQ, appLogger, redis, config.Server.APIGatewayURL)
 	updateUserUseCase := usecases.NewUpdateUserUseCase(userRepo, rabbitMQ, appLogger)
 	getUserUseCase := usecases.NewGetUserUseCase(userRepo, appLogger)
 	findUserUseCase := usecases.NewFindUserUseCase(userRepo, appLogger)
-	deleteUserUseCase := usecases.NewDeleteUserUseCase(userRepo, rabbitMQ, appLogger)
-
-	userHttpHandler := handlers.NewUserHandler(createUserUseCase, getUserUseCase, updateUserUseCase, findUserUseCase, deleteUserUseCase, appLogger)
+	deleteUserUseCase := usecases.NewDeleteUserUseCase(userRepo, rabbitMQ, appLogger, config.Deletion)
+	restoreUserUseCase := usecases.NewRestoreUserUseCase(userRepo, rabbitMQ, appLogger, config.Deletion)
+	purgeDeletedUsersUseCase := usecases.NewPurgeDeletedUsersUseCase(userRepo, rabbitMQ, appLogger, config.Deletion)
+	suspendUserUseCase := usecases.NewSuspendUserUseCase(userRepo, suspensionRepo, rabbitMQ, appLogger)
+	liftSuspensionUseCase := usecases.NewLiftSuspensionUseCase(userRepo, suspensionRepo, rabbitMQ, appLogger)
+	listSuspensionsUseCase := usecases.NewListSuspensionsUseCase(suspensionRepo, appLogger)
+	reinstateExpiredSuspensionsUseCase := usecases.NewReinstateExpiredSuspensionsUseCase(userRepo, suspensionRepo, rabbitMQ, appLogger, config.Suspension)
+	createGroupUseCase := usecases.NewCreateGroupUseCase(groupRepo, rabbitMQ, appLogger)
+	getGroupUseCase := usecases.NewGetGroupUseCase(groupRepo, appLogger)
+	findGroupsUseCase := usecases.NewFindGroupsUseCase(groupRepo, appLogger)
+	updateGroupUseCase := usecases.NewUpdateGroupUseCase(groupRepo, rabbitMQ, appLogger)
+	deleteGroupUseCase := usecases.NewDeleteGroupUseCase(groupRepo, userRepo, rabbitMQ, appLogger)
+	addGroupMembersUseCase := usecases.NewAddGroupMembersUseCase(groupRepo, userRepo, rabbitMQ, appLogger)
+	removeGroupMemberUseCase := usecases.NewRemoveGroupMemberUseCase(groupRepo, userRepo, rabbitMQ, appLogger)
+	createOrganizationUseCase := usecases.NewCreateOrganizationUseCase(organizationRepo, appLogger)
+	getOrganizationUseCase := usecases.NewGetOrganizationUseCase(organizationRepo, appLogger)
+	findOrganizationsUseCase := usecases.NewFindOrganizationsUseCase(organizationRepo, appLogger)
+	updateOrganizationUseCase := usecases.NewUpdateOrganizationUseCase(organizationRepo, appLogger)
+	moveUserToOrganizationUseCase := usecases.NewMoveUserToOrganizationUseCase(userRepo, organizationRepo, rabbitMQ, appLogger)
+	importUsersUseCase := usecases.NewImportUsersUseCase(userRepo, rabbitMQ, appLogger, redis, config.Server.APIGatewayURL, config.Import)
+	exportUsersUseCase := usecases.NewExportUsersUseCase(userRepo, appLogger)
+
+	userHttpHandler := handlers.NewUserHandler(createUserUseCase, getUserUseCase, updateUserUseCase, findUserUseCase, deleteUserUseCase, restoreUserUseCase, suspendUserUseCase, liftSuspensionUseCase, listSuspensionsUseCase, importUsersUseCase, exportUsersUseCase, appLogger)
+	groupHttpHandler := handlers.NewGroupHandler(createGroupUseCase, getGroupUseCase, findGroupsUseCase, updateGroupUseCase, deleteGroupUseCase, addGroupMembersUseCase, removeGroupMemberUseCase, appLogger)
+	organizationHttpHandler := handlers.NewOrganizationHandler(createOrganizationUseCase, getOrganizationUseCase, findOrganizationsUseCase, updateOrganizationUseCase, moveUserToOrganizationUseCase, appLogger)
 	if config.Server.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
-	httpRouter.SetUpRoutes(router, userHttpHandler, appLogger)
+	httpRouter.SetUpRoutes(router, userHttpHandler, groupHttpHandler, organizationHttpHandler, authenticate, appLogger)
 	server := &http.Server{
 		Addr:         ":" + config.Server.Port,
 		Handler:      router,
@@ -101,9 +129,15 @@ func main() {
 		}
 	}()
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go purgeDeletedUsersUseCase.Run(jobsCtx)
+	go reinstateExpiredSuspensionsUseCase.Run(jobsCtx)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopJobs()
 
 	appLogger.Info("Shutting down server...")
 	if err := utils.GracefulShutDown(server, rabbitMQ, db, appLogger); err != nil {
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// MaxGroupMembersPerRequest caps how many users are added to a group at once.
+const MaxGroupMembersPerRequest = 500
+
+var (
+	ErrNoGroupMembers        = errors.New("at least one user is required")
+	ErrTooManyGroupMembers   = fmt.Errorf("at most %d users can be added at once", MaxGroupMembersPerRequest)
+	ErrGroupMemberNotFound   = errors.New("users not found")
+	ErrGroupMemberNotStudent = errors.New("only students can be added to a group")
+)
+
+type AddGroupMembersInput struct {
+	GroupID string
+	UserIDs []string
+}
+
+type AddGroupMembersOutput struct {
+	// Added lists the users who were not members already.
+	Added []string `json:"added"`
+}
+
+type AddGroupMembersUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	userRepo  repositories.UserRepository
+	publisher messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewAddGroupMembersUseCase(
+	groupRepo userRepositories.GroupRepository,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *AddGroupMembersUseCase {
+	return &AddGroupMembersUseCase{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Execute adds the students to the group. Nobody is added when any of the
+// users does not exist or is not a student.
+func (uc *AddGroupMembersUseCase) Execute(ctx context.Context, input AddGroupMembersInput) (*AddGroupMembersOutput, error) {
+	userIDs := uniqueStrings(input.UserIDs)
+	if len(userIDs) == 0 {
+		return nil, ErrNoGroupMembers
+	}
+	if len(userIDs) > MaxGroupMembersPerRequest {
+		return nil, ErrTooManyGroupMembers
+	}
+
+	group, err := uc.groupRepo.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	var missing, notStudents []string
+	for _, userID := range userIDs {
+		user, err := uc.userRepo.FindByID(ctx, userID)
+		// Users of other organizations are reported as missing so their IDs
+		// cannot be probed.
+		if err != nil || user == nil || !sameOrganization(user.OrganizationID, group.OrganizationID) {
+			missing = append(missing, userID)
+			continue
+		}
+		if !user.Role.IsStudent() {
+			notStudents = append(notStudents, userID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrGroupMemberNotFound, strings.Join(missing, ", "))
+	}
+	if len(notStudents) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrGroupMemberNotStudent, strings.Join(notStudents, ", "))
+	}
+
+	added, err := uc.groupRepo.AddMembers(ctx, group.ID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add group members: %w", err)
+	}
+
+	if len(added) > 0 {
+		publishGroupEvent(ctx, uc.publisher, uc.logger, events.EventTypeGroupMembersAdded, events.GroupMembersAddedEvent{
+			GroupID: group.ID,
+			UserIDs: added,
+			AddedAt: time.Now().UTC(),
+		})
+		publishMembershipChanged(ctx, uc.userRepo, uc.publisher, uc.logger, added)
+	}
+
+	uc.logger.Info("Group members added",
+		zap.String("group_id", group.ID),
+		zap.Int("added", len(added)),
+	)
+
+	return &AddGroupMembersOutput{Added: added}, nil
+}
+
+// uniqueStrings drops empty and repeated values, keeping the first of each.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		unique = append(unique, value)
+	}
+	return unique
+}
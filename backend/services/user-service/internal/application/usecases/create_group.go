@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"go.uber.org/zap"
+)
+
+type CreateGroupInput struct {
+	Name        string
+	Description string
+	CreatedBy   string
+}
+
+type CreateGroupUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	publisher messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewCreateGroupUseCase(
+	groupRepo userRepositories.GroupRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *CreateGroupUseCase {
+	return &CreateGroupUseCase{
+		groupRepo: groupRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (uc *CreateGroupUseCase) Execute(ctx context.Context, input CreateGroupInput) (*GroupOutput, error) {
+	group, err := entities.NewGroup(input.Name, input.Description, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups belong to the organization of the admin creating them.
+	group.OrganizationID = tenant.Owner(ctx, nil)
+
+	existing, err := uc.groupRepo.FindByName(ctx, group.OrganizationID, group.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrGroupNameTaken
+	}
+
+	if err := uc.groupRepo.Create(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	publishGroupEvent(ctx, uc.publisher, uc.logger, events.EventTypeGroupCreated, events.GroupCreatedEvent{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedBy:   group.CreatedBy,
+		CreatedAt:   group.CreatedAt,
+	})
+
+	uc.logger.Info("Group created",
+		zap.String("group_id", group.ID),
+		zap.String("name", group.Name),
+	)
+
+	output := toGroupOutput(group)
+	return &output, nil
+}
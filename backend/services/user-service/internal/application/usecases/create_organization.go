@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+type CreateOrganizationInput struct {
+	Name      string
+	CreatedBy string
+}
+
+type CreateOrganizationUseCase struct {
+	organizationRepo userRepositories.OrganizationRepository
+	logger           *logger.Logger
+}
+
+func NewCreateOrganizationUseCase(organizationRepo userRepositories.OrganizationRepository, logger *logger.Logger) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		organizationRepo: organizationRepo,
+		logger:           logger,
+	}
+}
+
+// Execute adds a partner school. Only super-admins can create organizations.
+func (uc *CreateOrganizationUseCase) Execute(ctx context.Context, input CreateOrganizationInput) (*OrganizationOutput, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	organization, err := entities.NewOrganization(input.Name, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.organizationRepo.FindByName(ctx, organization.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrOrganizationNameTaken
+	}
+
+	if err := uc.organizationRepo.Create(ctx, organization); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	uc.logger.Info("Organization created",
+		zap.String("organization_id", organization.ID),
+		zap.String("name", organization.Name),
+	)
+
+	output := toOrganizationOutput(organization)
+	return &output, nil
+}
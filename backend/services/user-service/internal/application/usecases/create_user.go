@@ -7,11 +7,11 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
-	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
@@ -32,19 +32,24 @@ type CreateUserInput struct {
 	Username string
 	Password string
 	Role     string
+	// OrganizationID defaults to the caller's organization. Only super-admins
+	// can name another.
+	OrganizationID *string
 }
 
 type CreateUserUseCase struct {
 	userRepo  repositories.UserRepository
+	organizationRepo userRepositories.OrganizationRepository
 	publisher messaging.Publisher
 	logger    *logger.Logger
 	redis utils.RedisInterface
 	apiGatewayURL string
 }
 
-func NewCreateUserUseCase(userRepo repositories.UserRepository, publisher messaging.Publisher, logger *logger.Logger, redis utils.RedisInterface, apiGatewayURL string) *CreateUserUseCase {
+func NewCreateUserUseCase(userRepo repositories.UserRepository, organizationRepo userRepositories.OrganizationRepository, publisher messaging.Publisher, logger *logger.Logger, redis utils.RedisInterface, apiGatewayURL string) *CreateUserUseCase {
 	return &CreateUserUseCase{
 		userRepo:  userRepo,
+		organizationRepo: organizationRepo,
 		publisher: publisher,
 		logger:    logger,
 		redis: redis,
@@ -79,6 +84,11 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput)
 		return nil, ErrUsernameAlreadyExists
 	}
 
+	organizationID, err := resolveOrganization(ctx, uc.organizationRepo, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := utils.ValidatePassword(input.Password); err != nil {
 		return nil, fmt.Errorf("invalid password: %w", err)
 	}
@@ -89,6 +99,7 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput)
 	}
 
 	user := entities.NewUser(email, input.Username, role, passwordHash)
+	user.OrganizationID = organizationID
 
 	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, err
@@ -101,24 +112,7 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, input CreateUserInput)
 	}
 	
 	emailVerificationURL := utils.GenerateEmailVerificationURL(uc.apiGatewayURL, token)
-	event := events.UserCreatedEvent{
-		ID:         user.ID,
-		Email:      user.Email.String(),
-		Username:   user.Username,
-		Role:       user.Role.String(),
-		Status:     user.Status.String(),
-		EmailVerified: user.EmailVerified,
-		EmailVerifiedAt: user.EmailVerifiedAt,
-		CreatedAt:  user.CreatedAt,
-		UpdatedAt:  user.UpdatedAt,
-		EmailVerificationURL: emailVerificationURL,
-	}
-
-	if uc.publisher != nil {
-		if err := uc.publisher.Publish(ctx, events.EventTypeUserCreated, event); err != nil {
-			uc.logger.Error("Failed to publish user created event", zap.Error(err))
-		}
-	}
+	publishUserCreated(ctx, uc.publisher, uc.logger, user, emailVerificationURL, "")
 
 	uc.logger.Info("User Created Successfully.",
 		zap.String("user_id", user.ID),
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type DeleteGroupInput struct {
+	GroupID string
+}
+
+type DeleteGroupUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	userRepo  repositories.UserRepository
+	publisher messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewDeleteGroupUseCase(
+	groupRepo userRepositories.GroupRepository,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *DeleteGroupUseCase {
+	return &DeleteGroupUseCase{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Execute deletes the group along with its memberships. The users themselves
+// are kept.
+func (uc *DeleteGroupUseCase) Execute(ctx context.Context, input DeleteGroupInput) error {
+	group, err := uc.groupRepo.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	memberIDs, err := uc.groupRepo.ListMemberIDs(ctx, group.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	if err := uc.groupRepo.Delete(ctx, group.ID); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	publishGroupEvent(ctx, uc.publisher, uc.logger, events.EventTypeGroupDeleted, events.GroupDeletedEvent{
+		ID:        group.ID,
+		MemberIDs: memberIDs,
+		DeletedAt: time.Now().UTC(),
+	})
+	publishMembershipChanged(ctx, uc.userRepo, uc.publisher, uc.logger, memberIDs)
+
+	uc.logger.Info("Group deleted",
+		zap.String("group_id", group.ID),
+		zap.Int("members", len(memberIDs)),
+	)
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
@@ -18,35 +20,47 @@ var ErrCannotDeleteAdminUser = errors.New("cannot delete admin user")
 
 type DeleteUserInput struct {
 	UserID string
+	// Permanent purges the user straight away instead of soft deleting them,
+	// and also purges a user that is already soft deleted.
+	Permanent bool
 }
 
 type DeleteUserOutput struct {
-	Message string `json:"message"`
+	Message      string     `json:"message"`
+	RestoreUntil *time.Time `json:"restore_until,omitempty"`
 }
 
 type DeleteUserUsecase struct {
-	userRepo  repositories.UserRepository
-	publisher messaging.Publisher
-	logger    *logger.Logger
+	userRepo       repositories.UserRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+	deletionConfig config.DeletionConfig
 }
 
 func NewDeleteUserUseCase(
 	userRepo repositories.UserRepository,
 	publisher messaging.Publisher,
 	logger *logger.Logger,
+	deletionConfig config.DeletionConfig,
 ) *DeleteUserUsecase {
 	return &DeleteUserUsecase{
-		userRepo:  userRepo,
-		publisher: publisher,
-		logger:    logger,
+		userRepo:       userRepo,
+		publisher:      publisher,
+		logger:         logger,
+		deletionConfig: deletionConfig,
 	}
 }
 
+// Execute soft deletes the user, who can be restored until the restore
+// window ends and is purged after it.
 func (uc *DeleteUserUsecase) Execute(
 	ctx context.Context,
 	input DeleteUserInput,
 ) (*DeleteUserOutput, error) {
 	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil && input.Permanent {
+		user, err = uc.userRepo.FindDeletedByID(ctx, input.UserID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -55,13 +69,25 @@ func (uc *DeleteUserUsecase) Execute(
 		return nil, ErrCannotDeleteAdminUser
 	}
 
-	if err := uc.userRepo.Delete(ctx, input.UserID); err != nil {
+	if input.Permanent {
+		if err := purgeUser(ctx, uc.userRepo, uc.publisher, uc.logger, user); err != nil {
+			return nil, err
+		}
+		return &DeleteUserOutput{
+			Message: "User Deleted Permanently.",
+		}, nil
+	}
+
+	now := time.Now().UTC()
+	if err := uc.userRepo.SoftDelete(ctx, user.ID, now); err != nil {
 		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
+	restoreUntil := now.Add(uc.deletionConfig.RestoreWindow)
 
 	event := events.UserDeletedEvent{
-		ID:        user.ID,
-		DeletedAt: time.Now(),
+		ID:           user.ID,
+		DeletedAt:    now,
+		RestoreUntil: restoreUntil,
 	}
 
 	if uc.publisher != nil {
@@ -76,9 +102,43 @@ func (uc *DeleteUserUsecase) Execute(
 
 	uc.logger.Info("User deleted Successfully",
 		zap.String("user_id", user.ID),
+		zap.Time("restore_until", restoreUntil),
 	)
 
 	return &DeleteUserOutput{
-		Message: "User Deleted Successfully.",
+		Message:      "User Deleted Successfully.",
+		RestoreUntil: &restoreUntil,
 	}, nil
 }
+
+// purgeUser removes the user for good and tells the other services to drop
+// what they hold about them. Purging the same user twice is harmless, as is
+// the second event, so replicas purging at the same time need no lock.
+func purgeUser(
+	ctx context.Context,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	user *entities.User,
+) error {
+	if err := userRepo.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to purge user: %w", err)
+	}
+
+	event := events.UserPurgedEvent{
+		ID:       user.ID,
+		Role:     user.Role.String(),
+		PurgedAt: time.Now().UTC(),
+	}
+
+	if publisher != nil {
+		if err := publisher.Publish(ctx, events.EventTypeUserPurged, event); err != nil {
+			logger.Error("failed to publish user purged event.", zap.Error(err))
+		}
+	}
+
+	logger.Info("User purged",
+		zap.String("user_id", user.ID),
+	)
+	return nil
+}
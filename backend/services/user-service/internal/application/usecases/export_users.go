@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+// exportPageSize is how many users are read per query while exporting.
+const exportPageSize = 500
+
+// ExportColumns is the header row of an export. email, username and role can
+// be imported again as they are.
+var ExportColumns = []string{"id", "email", "username", "role", "status", "email_verified", "created_at", "updated_at"}
+
+// ExportUsersInput takes the filters of FindUserInput; all matching users
+// are exported, so there is no limit or offset.
+type ExportUsersInput struct {
+	SearchQuery    *string
+	Role           *valueobjects.Role
+	Status         *valueobjects.Status
+	GroupID        *string
+	OrganizationID *string
+	SortColumn     *string
+	SortDirection  *repositories.SortDirection
+}
+
+type ExportUsersUseCase struct {
+	userRepo repositories.UserRepository
+	logger   *logger.Logger
+}
+
+func NewExportUsersUseCase(userRepo repositories.UserRepository, logger *logger.Logger) *ExportUsersUseCase {
+	return &ExportUsersUseCase{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// Execute writes matching users to w as CSV a page at a time, so the export
+// is never held in memory whole. Nothing is written until the first page has
+// been read, so an error on it can still be reported to the caller.
+func (uc *ExportUsersUseCase) Execute(ctx context.Context, input ExportUsersInput, w io.Writer) error {
+	sortColumn := "created_at"
+	if input.SortColumn != nil && *input.SortColumn != "" {
+		sortColumn = *input.SortColumn
+	}
+	limit := exportPageSize
+	query := repositories.UserQuery{
+		SearchQuery:    input.SearchQuery,
+		Role:           input.Role,
+		Status:         input.Status,
+		GroupID:        input.GroupID,
+		OrganizationID: input.OrganizationID,
+		Limit:          &limit,
+		SortColumn:     &sortColumn,
+		SortDirection:  input.SortDirection,
+	}
+
+	writer := csv.NewWriter(w)
+	exported := 0
+	for offset := 0; ; offset += exportPageSize {
+		page := offset
+		query.Offset = &page
+
+		result, err := uc.userRepo.Find(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		if offset == 0 {
+			if err := writer.Write(ExportColumns); err != nil {
+				return err
+			}
+		}
+		for _, user := range result.Users {
+			record := []string{
+				user.ID,
+				user.Email.String(),
+				user.Username,
+				user.Role.String(),
+				user.Status.String(),
+				strconv.FormatBool(user.EmailVerified),
+				user.CreatedAt.UTC().Format(time.RFC3339),
+				user.UpdatedAt.UTC().Format(time.RFC3339),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+
+		exported += len(result.Users)
+		if len(result.Users) < exportPageSize {
+			break
+		}
+	}
+
+	uc.logger.Info("Users exported.", zap.Int("count", exported))
+	return nil
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+const (
+	defaultGroupPageSize = 20
+	maxGroupPageSize     = 100
+)
+
+type FindGroupsInput struct {
+	SearchQuery string
+	Limit       int
+	Offset      int
+}
+
+type FindGroupsOutput struct {
+	Groups []GroupOutput `json:"groups"`
+	Total  int           `json:"total"`
+}
+
+type FindGroupsUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	logger    *logger.Logger
+}
+
+func NewFindGroupsUseCase(groupRepo userRepositories.GroupRepository, logger *logger.Logger) *FindGroupsUseCase {
+	return &FindGroupsUseCase{
+		groupRepo: groupRepo,
+		logger:    logger,
+	}
+}
+
+// Execute lists groups by name, 20 at a time unless another limit up to 100
+// is given.
+func (uc *FindGroupsUseCase) Execute(ctx context.Context, input FindGroupsInput) (*FindGroupsOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultGroupPageSize
+	}
+	if limit > maxGroupPageSize {
+		limit = maxGroupPageSize
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	result, err := uc.groupRepo.Find(ctx, userRepositories.GroupQuery{
+		SearchQuery: input.SearchQuery,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find groups: %w", err)
+	}
+
+	output := &FindGroupsOutput{
+		Groups: make([]GroupOutput, 0, len(result.Groups)),
+		Total:  result.Total,
+	}
+	for _, group := range result.Groups {
+		output.Groups = append(output.Groups, toGroupOutput(group))
+	}
+	return output, nil
+}
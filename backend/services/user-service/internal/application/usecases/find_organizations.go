@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+const (
+	defaultOrganizationPageSize = 20
+	maxOrganizationPageSize     = 100
+)
+
+type FindOrganizationsInput struct {
+	SearchQuery string
+	Limit       int
+	Offset      int
+}
+
+type FindOrganizationsOutput struct {
+	Organizations []OrganizationOutput `json:"organizations"`
+	Total         int                  `json:"total"`
+}
+
+type FindOrganizationsUseCase struct {
+	organizationRepo userRepositories.OrganizationRepository
+	logger           *logger.Logger
+}
+
+func NewFindOrganizationsUseCase(organizationRepo userRepositories.OrganizationRepository, logger *logger.Logger) *FindOrganizationsUseCase {
+	return &FindOrganizationsUseCase{
+		organizationRepo: organizationRepo,
+		logger:           logger,
+	}
+}
+
+// Execute lists organizations by name, 20 at a time unless another limit up
+// to 100 is given. Members of an organization only find their own.
+func (uc *FindOrganizationsUseCase) Execute(ctx context.Context, input FindOrganizationsInput) (*FindOrganizationsOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultOrganizationPageSize
+	}
+	if limit > maxOrganizationPageSize {
+		limit = maxOrganizationPageSize
+	}
+	offset := input.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	result, err := uc.organizationRepo.Find(ctx, userRepositories.OrganizationQuery{
+		SearchQuery: input.SearchQuery,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organizations: %w", err)
+	}
+
+	output := &FindOrganizationsOutput{
+		Organizations: make([]OrganizationOutput, 0, len(result.Organizations)),
+		Total:         result.Total,
+	}
+	for _, organization := range result.Organizations {
+		output.Organizations = append(output.Organizations, toOrganizationOutput(organization))
+	}
+	return output, nil
+}
@@ -13,10 +13,14 @@ type FindUserInput struct {
 	SearchQuery   *string
 	Role          *valueobjects.Role
 	Status        *valueobjects.Status
+	GroupID       *string
+	OrganizationID *string
 	Limit         *int
 	Offset        *int
 	SortColumn    *string
 	SortDirection *repositories.SortDirection
+	// Deleted finds users that are deleted but can still be restored.
+	Deleted bool
 }
 
 type FindUserOutput struct {
@@ -41,10 +45,13 @@ func (uc *FindUserUseCase) Execute(ctx context.Context, input FindUserInput) (*F
 		SearchQuery:   input.SearchQuery,
 		Role:          input.Role,
 		Status:        input.Status,
+		GroupID:       input.GroupID,
+		OrganizationID: input.OrganizationID,
 		Limit:         input.Limit,
 		Offset:        input.Offset,
 		SortColumn:    input.SortColumn,
 		SortDirection: input.SortDirection,
+		Deleted:       input.Deleted,
 	}
 
 	result, err := uc.userRepo.Find(ctx, query)
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type GetGroupInput struct {
+	GroupID string
+}
+
+type GetGroupUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	logger    *logger.Logger
+}
+
+func NewGetGroupUseCase(groupRepo userRepositories.GroupRepository, logger *logger.Logger) *GetGroupUseCase {
+	return &GetGroupUseCase{
+		groupRepo: groupRepo,
+		logger:    logger,
+	}
+}
+
+func (uc *GetGroupUseCase) Execute(ctx context.Context, input GetGroupInput) (*GroupOutput, error) {
+	group, err := uc.groupRepo.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	output := toGroupOutput(group)
+	return &output, nil
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type GetOrganizationInput struct {
+	OrganizationID string
+}
+
+type GetOrganizationUseCase struct {
+	organizationRepo userRepositories.OrganizationRepository
+	logger           *logger.Logger
+}
+
+func NewGetOrganizationUseCase(organizationRepo userRepositories.OrganizationRepository, logger *logger.Logger) *GetOrganizationUseCase {
+	return &GetOrganizationUseCase{
+		organizationRepo: organizationRepo,
+		logger:           logger,
+	}
+}
+
+// Execute returns the organization. Members of an organization only find
+// their own.
+func (uc *GetOrganizationUseCase) Execute(ctx context.Context, input GetOrganizationInput) (*OrganizationOutput, error) {
+	organization, err := uc.organizationRepo.FindByID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	output := toOrganizationOutput(organization)
+	return &output, nil
+}
@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrGroupNotFound  = errors.New("group not found")
+	ErrGroupNameTaken = errors.New("a group with this name already exists")
+)
+
+type GroupOutput struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	CreatedBy      string    `json:"created_by"`
+	OrganizationID *string   `json:"organization_id,omitempty"`
+	MemberCount    int       `json:"member_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func toGroupOutput(group *entities.Group) GroupOutput {
+	return GroupOutput{
+		ID:             group.ID,
+		Name:           group.Name,
+		Description:    group.Description,
+		CreatedBy:      group.CreatedBy,
+		OrganizationID: group.OrganizationID,
+		MemberCount:    group.MemberCount,
+		CreatedAt:      group.CreatedAt,
+		UpdatedAt:      group.UpdatedAt,
+	}
+}
+
+// sameOrganization reports whether two rows belong to the same organization,
+// or are both outside any.
+func sameOrganization(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func publishGroupEvent(ctx context.Context, publisher messaging.Publisher, logger *logger.Logger, eventType string, event interface{}) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(ctx, eventType, event); err != nil {
+		logger.Error("failed to publish group event", zap.String("event_type", eventType), zap.Error(err))
+	}
+}
+
+// publishMembershipChanged sends a user updated event for each user, so that
+// consumers see their new group IDs. Users deleted in the meantime are
+// skipped.
+func publishMembershipChanged(
+	ctx context.Context,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	userIDs []string,
+) {
+	if publisher == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		user, err := userRepo.FindByID(ctx, userID)
+		if err != nil || user == nil {
+			continue
+		}
+		publishUserUpdated(ctx, publisher, logger, user)
+	}
+}
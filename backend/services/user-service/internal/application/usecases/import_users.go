@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCSV              = errors.New("invalid csv file")
+	ErrCSVEmpty                = errors.New("csv file has no rows")
+	ErrCSVMissingColumn        = errors.New("csv file is missing a required column")
+	ErrTooManyRows             = errors.New("csv file has too many rows")
+	ErrDuplicateEmailInFile    = errors.New("email appears more than once in the file")
+	ErrDuplicateUsernameInFile = errors.New("username appears more than once in the file")
+)
+
+// Actions reported for each imported row. In a dry run they are what would
+// have happened.
+const (
+	ImportActionCreated   = "created"
+	ImportActionUpdated   = "updated"
+	ImportActionUnchanged = "unchanged"
+	ImportActionFailed    = "failed"
+)
+
+// requiredImportColumns must be in the header row. An optional password
+// column is read too; any other column, such as the ones an export adds, is
+// ignored.
+var requiredImportColumns = []string{"email", "username", "role"}
+
+type ImportUsersInput struct {
+	CSV    io.Reader
+	DryRun bool
+}
+
+type ImportUserRowResult struct {
+	// Row is the line of the file the row starts on, the header being 1.
+	Row    int    `json:"row"`
+	Email  string `json:"email"`
+	Action string `json:"action"`
+	UserID string `json:"user_id,omitempty"`
+	// SetPasswordURL is returned for users created without a password, who
+	// are also emailed it.
+	SetPasswordURL string `json:"set_password_url,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+type ImportUsersOutput struct {
+	DryRun    bool                  `json:"dry_run"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+	Failed    int                   `json:"failed"`
+	Rows      []ImportUserRowResult `json:"rows"`
+}
+
+type ImportUsersUseCase struct {
+	userRepo      repositories.UserRepository
+	publisher     messaging.Publisher
+	logger        *logger.Logger
+	redis         utils.RedisInterface
+	apiGatewayURL string
+	importConfig  config.ImportConfig
+}
+
+func NewImportUsersUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	redis utils.RedisInterface,
+	apiGatewayURL string,
+	importConfig config.ImportConfig,
+) *ImportUsersUseCase {
+	return &ImportUsersUseCase{
+		userRepo:      userRepo,
+		publisher:     publisher,
+		logger:        logger,
+		redis:         redis,
+		apiGatewayURL: apiGatewayURL,
+		importConfig:  importConfig,
+	}
+}
+
+type importRow struct {
+	line     int
+	email    string
+	username string
+	role     string
+	password string
+}
+
+// Execute creates users whose email is new and updates the username, role
+// and, when given, password of the others. Rows are applied one by one, so a
+// failed row is reported without undoing or stopping the rest. Rows without
+// a password create users who are emailed a link to set one.
+func (uc *ImportUsersUseCase) Execute(ctx context.Context, input ImportUsersInput) (*ImportUsersOutput, error) {
+	rows, err := uc.readRows(input.CSV)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ImportUsersOutput{DryRun: input.DryRun, Rows: make([]ImportUserRowResult, 0, len(rows))}
+	seenEmails := make(map[string]bool, len(rows))
+	seenUsernames := make(map[string]bool, len(rows))
+
+	for _, row := range rows {
+		result := ImportUserRowResult{Row: row.line, Email: row.email}
+
+		user, action, setPasswordURL, err := uc.importRow(ctx, row, input.DryRun, seenEmails, seenUsernames)
+		if err != nil {
+			result.Action = ImportActionFailed
+			result.Error = err.Error()
+		} else {
+			result.Action = action
+			result.SetPasswordURL = setPasswordURL
+			if user != nil {
+				result.UserID = user.ID
+				result.Email = user.Email.String()
+			}
+		}
+
+		switch result.Action {
+		case ImportActionCreated:
+			output.Created++
+		case ImportActionUpdated:
+			output.Updated++
+		case ImportActionUnchanged:
+			output.Unchanged++
+		default:
+			output.Failed++
+		}
+		output.Rows = append(output.Rows, result)
+	}
+
+	uc.logger.Info("Users imported.",
+		zap.Bool("dry_run", input.DryRun),
+		zap.Int("created", output.Created),
+		zap.Int("updated", output.Updated),
+		zap.Int("unchanged", output.Unchanged),
+		zap.Int("failed", output.Failed),
+	)
+
+	return output, nil
+}
+
+// readRows reads the whole file before anything is written, so a file that
+// is malformed or too long is rejected without being half imported.
+func (uc *ImportUsersUseCase) readRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrCSVEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrCSVMissingColumn, name)
+		}
+	}
+
+	rawField := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+	field := func(record []string, name string) string {
+		return strings.TrimSpace(rawField(record, name))
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(rows) == uc.importConfig.MaxRows {
+			return nil, fmt.Errorf("%w: at most %d are allowed", ErrTooManyRows, uc.importConfig.MaxRows)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, importRow{
+			line:     line,
+			email:    field(record, "email"),
+			username: field(record, "username"),
+			role:     strings.ToLower(field(record, "role")),
+			// Spaces may be part of a password, so it is taken as is.
+			password: rawField(record, "password"),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrCSVEmpty
+	}
+	return rows, nil
+}
+
+func (uc *ImportUsersUseCase) importRow(
+	ctx context.Context,
+	row importRow,
+	dryRun bool,
+	seenEmails, seenUsernames map[string]bool,
+) (*entities.User, string, string, error) {
+	switch {
+	case row.email == "":
+		return nil, "", "", ErrEmailRequired
+	case row.username == "":
+		return nil, "", "", ErrUsernameRequired
+	case row.role == "":
+		return nil, "", "", ErrRoleRequired
+	}
+
+	email, err := valueobjects.NewEmail(row.email)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("invalid email: %w", err)
+	}
+	role, err := valueobjects.NewRole(row.role)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("invalid role: %w", err)
+	}
+	if row.password != "" {
+		if err := utils.ValidatePassword(row.password); err != nil {
+			return nil, "", "", fmt.Errorf("invalid password: %w", err)
+		}
+	}
+
+	if seenEmails[email.String()] {
+		return nil, "", "", ErrDuplicateEmailInFile
+	}
+	seenEmails[email.String()] = true
+	if seenUsernames[row.username] {
+		return nil, "", "", ErrDuplicateUsernameInFile
+	}
+	seenUsernames[row.username] = true
+
+	// Emails and usernames are unique across organizations, so the lookups are
+	// not scoped, but a match the caller cannot see is never updated.
+	existing, _ := uc.userRepo.FindByEmail(ctx, email.String())
+	if existing != nil && !tenant.Allows(ctx, existing.OrganizationID) {
+		return nil, "", "", ErrEmailAlreadyExists
+	}
+	if sameUsername, _ := uc.userRepo.FindByUsername(ctx, row.username); sameUsername != nil {
+		if existing == nil || sameUsername.ID != existing.ID {
+			return nil, "", "", ErrUsernameAlreadyExists
+		}
+	}
+
+	if existing == nil {
+		if dryRun {
+			return nil, ImportActionCreated, "", nil
+		}
+		user, setPasswordURL, err := uc.createUser(ctx, email, row.username, role, row.password)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return user, ImportActionCreated, setPasswordURL, nil
+	}
+
+	if existing.Username == row.username && existing.Role == role && row.password == "" {
+		return existing, ImportActionUnchanged, "", nil
+	}
+	if dryRun {
+		return existing, ImportActionUpdated, "", nil
+	}
+	if err := uc.updateUser(ctx, existing, row.username, role, row.password); err != nil {
+		return nil, "", "", err
+	}
+	return existing, ImportActionUpdated, "", nil
+}
+
+func (uc *ImportUsersUseCase) createUser(
+	ctx context.Context,
+	email valueobjects.Email,
+	username string,
+	role valueobjects.Role,
+	password string,
+) (*entities.User, string, error) {
+	// Without a password the account gets one nobody knows until the user
+	// sets their own through the emailed link.
+	withoutPassword := password == ""
+	if withoutPassword {
+		password = uuid.New().String()
+	}
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := entities.NewUser(email, username, role, passwordHash)
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, "", err
+	}
+
+	token := uuid.New().String()
+	if err := uc.redis.StoreVerifyEmailToken(ctx, user.ID, token); err != nil {
+		return nil, "", fmt.Errorf("failed to generate verify email token: %w", err)
+	}
+	emailVerificationURL := utils.GenerateEmailVerificationURL(uc.apiGatewayURL, token)
+
+	setPasswordURL := ""
+	if withoutPassword {
+		token := uuid.New().String()
+		if err := uc.redis.StorePasswordSetupToken(ctx, user.ID, token, uc.importConfig.SetPasswordTokenTTL); err != nil {
+			return nil, "", fmt.Errorf("failed to generate set password token: %w", err)
+		}
+		setPasswordURL = utils.GenerateSetPasswordURL(uc.importConfig.SetPasswordURL, token)
+	}
+
+	publishUserCreated(ctx, uc.publisher, uc.logger, user, emailVerificationURL, setPasswordURL)
+	return user, setPasswordURL, nil
+}
+
+func (uc *ImportUsersUseCase) updateUser(
+	ctx context.Context,
+	user *entities.User,
+	username string,
+	role valueobjects.Role,
+	password string,
+) error {
+	user.Username = username
+	user.Role = role
+	user.UpdatedAt = time.Now().UTC()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if password != "" {
+		passwordHash, err := utils.HashPassword(password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		if err := uc.userRepo.UpdatePassword(ctx, user.ID, passwordHash); err != nil {
+			return err
+		}
+	}
+
+	publishUserUpdated(ctx, uc.publisher, uc.logger, user)
+	return nil
+}
@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+)
+
+var ErrUserNotSuspended = errors.New("user is not suspended")
+
+type LiftSuspensionInput struct {
+	UserID   string
+	LiftedBy string
+}
+
+type LiftSuspensionUseCase struct {
+	userRepo       repositories.UserRepository
+	suspensionRepo userRepositories.SuspensionRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+}
+
+func NewLiftSuspensionUseCase(
+	userRepo repositories.UserRepository,
+	suspensionRepo userRepositories.SuspensionRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *LiftSuspensionUseCase {
+	return &LiftSuspensionUseCase{
+		userRepo:       userRepo,
+		suspensionRepo: suspensionRepo,
+		publisher:      publisher,
+		logger:         logger,
+	}
+}
+
+// Execute reinstates the user before their suspension ends.
+func (uc *LiftSuspensionUseCase) Execute(ctx context.Context, input LiftSuspensionInput) (*SuspensionOutput, error) {
+	suspension, err := uc.suspensionRepo.FindActiveByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suspension: %w", err)
+	}
+	if suspension == nil {
+		return nil, ErrUserNotSuspended
+	}
+
+	if err := liftSuspension(ctx, uc.userRepo, uc.suspensionRepo, uc.publisher, uc.logger, suspension, input.LiftedBy); err != nil {
+		return nil, err
+	}
+
+	output := toSuspensionOutput(suspension)
+	return &output, nil
+}
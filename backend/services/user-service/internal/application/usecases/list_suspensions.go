@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+)
+
+type ListSuspensionsInput struct {
+	UserID string
+}
+
+type ListSuspensionsOutput struct {
+	Suspensions []SuspensionOutput `json:"suspensions"`
+}
+
+type ListSuspensionsUseCase struct {
+	suspensionRepo userRepositories.SuspensionRepository
+	logger         *logger.Logger
+}
+
+func NewListSuspensionsUseCase(
+	suspensionRepo userRepositories.SuspensionRepository,
+	logger *logger.Logger,
+) *ListSuspensionsUseCase {
+	return &ListSuspensionsUseCase{
+		suspensionRepo: suspensionRepo,
+		logger:         logger,
+	}
+}
+
+// Execute lists the user's suspension history, most recent first.
+func (uc *ListSuspensionsUseCase) Execute(ctx context.Context, input ListSuspensionsInput) (*ListSuspensionsOutput, error) {
+	suspensions, err := uc.suspensionRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suspensions: %w", err)
+	}
+
+	output := &ListSuspensionsOutput{Suspensions: make([]SuspensionOutput, 0, len(suspensions))}
+	for _, suspension := range suspensions {
+		output.Suspensions = append(output.Suspensions, toSuspensionOutput(suspension))
+	}
+	return output, nil
+}
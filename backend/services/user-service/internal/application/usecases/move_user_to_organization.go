@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type MoveUserToOrganizationInput struct {
+	UserID         string
+	OrganizationID string
+}
+
+type MoveUserToOrganizationUseCase struct {
+	userRepo         repositories.UserRepository
+	organizationRepo userRepositories.OrganizationRepository
+	publisher        messaging.Publisher
+	logger           *logger.Logger
+}
+
+func NewMoveUserToOrganizationUseCase(
+	userRepo repositories.UserRepository,
+	organizationRepo userRepositories.OrganizationRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *MoveUserToOrganizationUseCase {
+	return &MoveUserToOrganizationUseCase{
+		userRepo:         userRepo,
+		organizationRepo: organizationRepo,
+		publisher:        publisher,
+		logger:           logger,
+	}
+}
+
+// Execute moves the user into the organization. Only super-admins can move
+// users. Tokens issued before the move stop being accepted, as they name the
+// old organization.
+func (uc *MoveUserToOrganizationUseCase) Execute(ctx context.Context, input MoveUserToOrganizationInput) (*dtos.UserDTO, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	organization, err := uc.organizationRepo.FindByID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	user.MoveToOrganization(&organization.ID)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	publishUserUpdated(ctx, uc.publisher, uc.logger, user)
+
+	uc.logger.Info("User moved to organization",
+		zap.String("user_id", user.ID),
+		zap.String("organization_id", organization.ID),
+	)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+	return &dto, nil
+}
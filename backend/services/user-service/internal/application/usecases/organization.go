@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationNameTaken = errors.New("an organization with this name already exists")
+	// ErrSuperAdminRequired is returned to members of an organization, its
+	// admins included, for changes that span organizations.
+	ErrSuperAdminRequired = errors.New("only super-admins can manage organizations")
+)
+
+type OrganizationOutput struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toOrganizationOutput(organization *entities.Organization) OrganizationOutput {
+	return OrganizationOutput{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedBy: organization.CreatedBy,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+}
+
+// requireSuperAdmin refuses callers scoped to an organization. Routes calling
+// it are limited to admins by the gateway, so the rest are super-admins.
+func requireSuperAdmin(ctx context.Context) error {
+	if tenant.Scoped(ctx) {
+		return ErrSuperAdminRequired
+	}
+	return nil
+}
+
+// resolveOrganization returns the organization a new row belongs to. Callers
+// scoped to an organization can only name their own, and default to it.
+func resolveOrganization(ctx context.Context, organizationRepo userRepositories.OrganizationRepository, organizationID *string) (*string, error) {
+	if organizationID == nil || *organizationID == "" {
+		return tenant.Owner(ctx, nil), nil
+	}
+	organization, err := organizationRepo.FindByID(ctx, *organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return &organization.ID, nil
+}
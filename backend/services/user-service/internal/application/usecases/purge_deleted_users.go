@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// purgeBatchSize is how many deleted users are read per query while purging.
+const purgeBatchSize = 100
+
+type PurgeDeletedUsersUseCase struct {
+	userRepo       repositories.UserRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+	deletionConfig config.DeletionConfig
+}
+
+func NewPurgeDeletedUsersUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	deletionConfig config.DeletionConfig,
+) *PurgeDeletedUsersUseCase {
+	return &PurgeDeletedUsersUseCase{
+		userRepo:       userRepo,
+		publisher:      publisher,
+		logger:         logger,
+		deletionConfig: deletionConfig,
+	}
+}
+
+// Execute purges every user deleted longer ago than the restore window and
+// returns how many were purged.
+func (uc *PurgeDeletedUsersUseCase) Execute(ctx context.Context) (int, error) {
+	cutoff := time.Now().UTC().Add(-uc.deletionConfig.RestoreWindow)
+
+	purged := 0
+	for {
+		users, err := uc.userRepo.FindDeletedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, user := range users {
+			if err := purgeUser(ctx, uc.userRepo, uc.publisher, uc.logger, user); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(users) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// Run purges once straight away and then every PurgeInterval until ctx is
+// done.
+func (uc *PurgeDeletedUsersUseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.deletionConfig.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := uc.Execute(ctx); err != nil {
+			uc.logger.Error("failed to purge deleted users", zap.Int("purged", purged), zap.Error(err))
+		} else if purged > 0 {
+			uc.logger.Info("purged deleted users", zap.Int("purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// reinstateBatchSize is how many expired suspensions are read per query.
+const reinstateBatchSize = 100
+
+type ReinstateExpiredSuspensionsUseCase struct {
+	userRepo         repositories.UserRepository
+	suspensionRepo   userRepositories.SuspensionRepository
+	publisher        messaging.Publisher
+	logger           *logger.Logger
+	suspensionConfig config.SuspensionConfig
+}
+
+func NewReinstateExpiredSuspensionsUseCase(
+	userRepo repositories.UserRepository,
+	suspensionRepo userRepositories.SuspensionRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	suspensionConfig config.SuspensionConfig,
+) *ReinstateExpiredSuspensionsUseCase {
+	return &ReinstateExpiredSuspensionsUseCase{
+		userRepo:         userRepo,
+		suspensionRepo:   suspensionRepo,
+		publisher:        publisher,
+		logger:           logger,
+		suspensionConfig: suspensionConfig,
+	}
+}
+
+// Execute lifts every suspension that has expired and returns how many were
+// lifted.
+func (uc *ReinstateExpiredSuspensionsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	lifted := 0
+	for {
+		suspensions, err := uc.suspensionRepo.FindExpired(ctx, now, reinstateBatchSize)
+		if err != nil {
+			return lifted, err
+		}
+
+		for _, suspension := range suspensions {
+			if err := liftSuspension(ctx, uc.userRepo, uc.suspensionRepo, uc.publisher, uc.logger, suspension, ""); err != nil {
+				return lifted, err
+			}
+			lifted++
+		}
+
+		if len(suspensions) < reinstateBatchSize {
+			return lifted, nil
+		}
+	}
+}
+
+// Run reinstates once straight away and then every ReinstateInterval until
+// ctx is done.
+func (uc *ReinstateExpiredSuspensionsUseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.suspensionConfig.ReinstateInterval)
+	defer ticker.Stop()
+
+	for {
+		if lifted, err := uc.Execute(ctx); err != nil {
+			uc.logger.Error("failed to reinstate suspended users", zap.Int("lifted", lifted), zap.Error(err))
+		} else if lifted > 0 {
+			uc.logger.Info("reinstated suspended users", zap.Int("lifted", lifted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+var ErrNotGroupMember = errors.New("user is not a member of the group")
+
+type RemoveGroupMemberInput struct {
+	GroupID string
+	UserID  string
+}
+
+type RemoveGroupMemberUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	userRepo  repositories.UserRepository
+	publisher messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewRemoveGroupMemberUseCase(
+	groupRepo userRepositories.GroupRepository,
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *RemoveGroupMemberUseCase {
+	return &RemoveGroupMemberUseCase{
+		groupRepo: groupRepo,
+		userRepo:  userRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (uc *RemoveGroupMemberUseCase) Execute(ctx context.Context, input RemoveGroupMemberInput) error {
+	group, err := uc.groupRepo.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return fmt.Errorf("failed to find group: %w", err)
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	removed, err := uc.groupRepo.RemoveMember(ctx, group.ID, input.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+	if !removed {
+		return ErrNotGroupMember
+	}
+
+	publishGroupEvent(ctx, uc.publisher, uc.logger, events.EventTypeGroupMembersRemoved, events.GroupMembersRemovedEvent{
+		GroupID:   group.ID,
+		UserIDs:   []string{input.UserID},
+		RemovedAt: time.Now().UTC(),
+	})
+	publishMembershipChanged(ctx, uc.userRepo, uc.publisher, uc.logger, []string{input.UserID})
+
+	uc.logger.Info("Group member removed",
+		zap.String("group_id", group.ID),
+		zap.String("user_id", input.UserID),
+	)
+	return nil
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+var ErrRestoreWindowExpired = errors.New("user can no longer be restored")
+
+type RestoreUserInput struct {
+	UserID string
+}
+
+type RestoreUserUseCase struct {
+	userRepo       repositories.UserRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+	deletionConfig config.DeletionConfig
+}
+
+func NewRestoreUserUseCase(
+	userRepo repositories.UserRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	deletionConfig config.DeletionConfig,
+) *RestoreUserUseCase {
+	return &RestoreUserUseCase{
+		userRepo:       userRepo,
+		publisher:      publisher,
+		logger:         logger,
+		deletionConfig: deletionConfig,
+	}
+}
+
+// Execute undoes a soft delete within the restore window. The email and
+// username may have been taken by someone else in the meantime, in which case
+// the user has to be changed or purged instead.
+func (uc *RestoreUserUseCase) Execute(ctx context.Context, input RestoreUserInput) (*dtos.UserDTO, error) {
+	user, err := uc.userRepo.FindDeletedByID(ctx, input.UserID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	now := time.Now().UTC()
+	if user.DeletedAt != nil && now.After(user.DeletedAt.Add(uc.deletionConfig.RestoreWindow)) {
+		return nil, ErrRestoreWindowExpired
+	}
+
+	if existing, _ := uc.userRepo.FindByEmail(ctx, user.Email.String()); existing != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+	if existing, _ := uc.userRepo.FindByUsername(ctx, user.Username); existing != nil {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	if err := uc.userRepo.Restore(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+	user.DeletedAt = nil
+	user.UpdatedAt = now
+
+	event := events.UserRestoredEvent{
+		ID:         user.ID,
+		RestoredAt: now,
+	}
+	if uc.publisher != nil {
+		if err := uc.publisher.Publish(ctx, events.EventTypeUserRestored, event); err != nil {
+			uc.logger.Error("failed to publish user restored event.", zap.Error(err))
+		}
+	}
+
+	uc.logger.Info("User restored",
+		zap.String("user_id", user.ID),
+	)
+
+	var dto dtos.UserDTO
+	dto.FromEntity(user)
+	return &dto, nil
+}
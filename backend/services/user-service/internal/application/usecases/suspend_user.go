@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+const MaxSuspensionReasonLength = 1000
+
+var (
+	ErrSuspensionReasonRequired = errors.New("reason is required")
+	ErrSuspensionReasonTooLong  = fmt.Errorf("reason must be at most %d characters", MaxSuspensionReasonLength)
+	ErrSuspensionExpiryInPast   = errors.New("suspension must end in the future")
+	ErrUserAlreadySuspended     = errors.New("user is already suspended")
+)
+
+type SuspendUserInput struct {
+	UserID      string
+	Reason      string
+	SuspendedBy string
+	// ExpiresAt ends the suspension automatically. Left nil, it lasts until
+	// an admin lifts it.
+	ExpiresAt *time.Time
+}
+
+type SuspendUserUseCase struct {
+	userRepo       repositories.UserRepository
+	suspensionRepo userRepositories.SuspensionRepository
+	publisher      messaging.Publisher
+	logger         *logger.Logger
+}
+
+func NewSuspendUserUseCase(
+	userRepo repositories.UserRepository,
+	suspensionRepo userRepositories.SuspensionRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *SuspendUserUseCase {
+	return &SuspendUserUseCase{
+		userRepo:       userRepo,
+		suspensionRepo: suspensionRepo,
+		publisher:      publisher,
+		logger:         logger,
+	}
+}
+
+// Execute suspends the user. The user's status is changed before the
+// suspension is recorded, so retrying after a failure completes it.
+func (uc *SuspendUserUseCase) Execute(ctx context.Context, input SuspendUserInput) (*SuspensionOutput, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrSuspensionReasonRequired
+	}
+	if utf8.RuneCountInString(reason) > MaxSuspensionReasonLength {
+		return nil, ErrSuspensionReasonTooLong
+	}
+
+	var expiresAt *time.Time
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return nil, ErrSuspensionExpiryInPast
+		}
+		utc := input.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	active, err := uc.suspensionRepo.FindActiveByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find suspension: %w", err)
+	}
+	if active != nil {
+		return nil, ErrUserAlreadySuspended
+	}
+
+	if err := user.Suspend(); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to suspend user: %w", err)
+	}
+
+	suspension := entities.NewSuspension(user.ID, reason, input.SuspendedBy, expiresAt)
+	if err := uc.suspensionRepo.Create(ctx, suspension); err != nil {
+		return nil, fmt.Errorf("failed to record suspension: %w", err)
+	}
+
+	publishUserUpdated(ctx, uc.publisher, uc.logger, user)
+	if uc.publisher != nil {
+		event := events.UserSuspendedEvent{
+			ID:           user.ID,
+			Email:        user.Email.String(),
+			Username:     user.Username,
+			SuspensionID: suspension.ID,
+			Reason:       suspension.Reason,
+			SuspendedBy:  suspension.SuspendedBy,
+			SuspendedAt:  suspension.SuspendedAt,
+			ExpiresAt:    suspension.ExpiresAt,
+		}
+		if err := uc.publisher.Publish(ctx, events.EventTypeUserSuspended, event); err != nil {
+			uc.logger.Error("failed to publish user suspended event.", zap.Error(err))
+		}
+	}
+
+	uc.logger.Info("User suspended",
+		zap.String("user_id", user.ID),
+		zap.String("suspension_id", suspension.ID),
+		zap.String("suspended_by", suspension.SuspendedBy),
+	)
+
+	output := toSuspensionOutput(suspension)
+	return &output, nil
+}
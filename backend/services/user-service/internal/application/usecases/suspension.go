@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type SuspensionOutput struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Reason      string     `json:"reason"`
+	SuspendedBy string     `json:"suspended_by"`
+	SuspendedAt time.Time  `json:"suspended_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LiftedAt    *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy    string     `json:"lifted_by,omitempty"`
+}
+
+func toSuspensionOutput(suspension *entities.Suspension) SuspensionOutput {
+	return SuspensionOutput{
+		ID:          suspension.ID,
+		UserID:      suspension.UserID,
+		Reason:      suspension.Reason,
+		SuspendedBy: suspension.SuspendedBy,
+		SuspendedAt: suspension.SuspendedAt,
+		ExpiresAt:   suspension.ExpiresAt,
+		LiftedAt:    suspension.LiftedAt,
+		LiftedBy:    suspension.LiftedBy,
+	}
+}
+
+// liftSuspension ends the suspension and reactivates the user, unless their
+// status was changed to something else in the meantime, such as a ban.
+// liftedBy is empty when the suspension expired.
+func liftSuspension(
+	ctx context.Context,
+	userRepo repositories.UserRepository,
+	suspensionRepo userRepositories.SuspensionRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	suspension *entities.Suspension,
+	liftedBy string,
+) error {
+	now := time.Now().UTC()
+	suspension.Lift(liftedBy, now)
+	if err := suspensionRepo.Lift(ctx, suspension); err != nil {
+		return fmt.Errorf("failed to lift suspension: %w", err)
+	}
+
+	// A user deleted while suspended keeps nothing to reactivate.
+	user, err := userRepo.FindByID(ctx, suspension.UserID)
+	if err != nil || user == nil {
+		logger.Info("lifted suspension of missing user",
+			zap.String("user_id", suspension.UserID),
+			zap.String("suspension_id", suspension.ID),
+		)
+		return nil
+	}
+
+	if user.Status.IsSuspended() {
+		if err := user.Activate(); err != nil {
+			return err
+		}
+		if err := userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to reactivate user: %w", err)
+		}
+		publishUserUpdated(ctx, publisher, logger, user)
+	}
+
+	if publisher != nil {
+		event := events.UserReinstatedEvent{
+			ID:           user.ID,
+			SuspensionID: suspension.ID,
+			LiftedBy:     liftedBy,
+			ReinstatedAt: now,
+		}
+		if err := publisher.Publish(ctx, events.EventTypeUserReinstated, event); err != nil {
+			logger.Error("failed to publish user reinstated event.", zap.Error(err))
+		}
+	}
+
+	logger.Info("Suspension lifted",
+		zap.String("user_id", user.ID),
+		zap.String("suspension_id", suspension.ID),
+		zap.Bool("expired", liftedBy == ""),
+	)
+	return nil
+}
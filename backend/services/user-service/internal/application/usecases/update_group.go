@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+type UpdateGroupInput struct {
+	GroupID string
+	// Name and Description are left unchanged when nil.
+	Name        *string
+	Description *string
+}
+
+type UpdateGroupUseCase struct {
+	groupRepo userRepositories.GroupRepository
+	publisher messaging.Publisher
+	logger    *logger.Logger
+}
+
+func NewUpdateGroupUseCase(
+	groupRepo userRepositories.GroupRepository,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+) *UpdateGroupUseCase {
+	return &UpdateGroupUseCase{
+		groupRepo: groupRepo,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (uc *UpdateGroupUseCase) Execute(ctx context.Context, input UpdateGroupInput) (*GroupOutput, error) {
+	group, err := uc.groupRepo.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+
+	name, description := group.Name, group.Description
+	if input.Name != nil {
+		name = *input.Name
+	}
+	if input.Description != nil {
+		description = *input.Description
+	}
+	if err := group.Rename(name, description); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.groupRepo.FindByName(ctx, group.OrganizationID, group.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find group: %w", err)
+	}
+	if existing != nil && existing.ID != group.ID {
+		return nil, ErrGroupNameTaken
+	}
+
+	if err := uc.groupRepo.Update(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+
+	publishGroupEvent(ctx, uc.publisher, uc.logger, events.EventTypeGroupUpdated, events.GroupUpdatedEvent{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		UpdatedAt:   group.UpdatedAt,
+	})
+
+	uc.logger.Info("Group updated",
+		zap.String("group_id", group.ID),
+	)
+
+	output := toGroupOutput(group)
+	return &output, nil
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	userRepositories "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"go.uber.org/zap"
+)
+
+type UpdateOrganizationInput struct {
+	OrganizationID string
+	Name           string
+}
+
+type UpdateOrganizationUseCase struct {
+	organizationRepo userRepositories.OrganizationRepository
+	logger           *logger.Logger
+}
+
+func NewUpdateOrganizationUseCase(organizationRepo userRepositories.OrganizationRepository, logger *logger.Logger) *UpdateOrganizationUseCase {
+	return &UpdateOrganizationUseCase{
+		organizationRepo: organizationRepo,
+		logger:           logger,
+	}
+}
+
+// Execute renames the organization. Only super-admins can rename
+// organizations.
+func (uc *UpdateOrganizationUseCase) Execute(ctx context.Context, input UpdateOrganizationInput) (*OrganizationOutput, error) {
+	if err := requireSuperAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	organization, err := uc.organizationRepo.FindByID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	if err := organization.Rename(input.Name); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.organizationRepo.FindByName(ctx, organization.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	if existing != nil && existing.ID != organization.ID {
+		return nil, ErrOrganizationNameTaken
+	}
+
+	if err := uc.organizationRepo.Update(ctx, organization); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	uc.logger.Info("Organization updated",
+		zap.String("organization_id", organization.ID),
+		zap.String("name", organization.Name),
+	)
+
+	output := toOrganizationOutput(organization)
+	return &output, nil
+}
@@ -2,23 +2,30 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/shared/application/dtos"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
-	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrStatusRequiresSuspension = errors.New("users are suspended through the suspend endpoint")
+	ErrStatusRequiresLift       = errors.New("a suspended user can only be banned until the suspension is lifted")
+)
+
 type UpdateUserInput struct {
 	ID       string
 	Username *string
 	Email    *string
 	Role     *string
 	Status   *string
+	Profile  entities.ProfileUpdate
 }
 
 type UpdateUserUseCase struct {
@@ -73,9 +80,22 @@ func (uc *UpdateUserUseCase) Execute(
 		if err != nil {
 			return nil, err
 		}
+		// Suspensions keep a record of why and until when, so the status is
+		// only changed to and from suspended alongside it. A suspended user
+		// may still be banned.
+		if status.IsSuspended() && !user.Status.IsSuspended() {
+			return nil, ErrStatusRequiresSuspension
+		}
+		if user.Status.IsSuspended() && !status.IsSuspended() && !status.IsBanned() {
+			return nil, ErrStatusRequiresLift
+		}
 		user.Status = status
 	}
 
+	if err := user.UpdateProfile(input.Profile); err != nil {
+		return nil, err
+	}
+
 	user.UpdatedAt = time.Now().UTC()
 
 	err = uc.userRepo.Update(ctx, user)
@@ -83,27 +103,7 @@ func (uc *UpdateUserUseCase) Execute(
 		return nil, err
 	}
 
-	event := events.UserUpdatedEvent{
-		ID:            user.ID,
-		Email:         user.Email.String(),
-		Username:      user.Username,
-		Role:          user.Role.String(),
-		Status:        user.Status.String(),
-		EmailVerified: user.EmailVerified,
-		EmailVerifiedAt: user.EmailVerifiedAt,
-		CreatedAt:     user.CreatedAt,
-		UpdatedAt:     user.UpdatedAt,
-	}
-
-	if uc.publisher != nil {
-		if err := uc.publisher.Publish(
-			ctx,
-			events.EventTypeUserUpdated,
-			event,
-		); err != nil {
-			uc.logger.Error("Failed to publish user updated event", zap.Error(err))
-		}
-	}
+	publishUserUpdated(ctx, uc.publisher, uc.logger, user)
 
 	uc.logger.Info("User Updated Successfully.",
 		zap.String("user_id", user.ID),
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"go.uber.org/zap"
+)
+
+// publishUserCreated announces a new user. setPasswordURL is only given for
+// accounts created without a password.
+func publishUserCreated(
+	ctx context.Context,
+	publisher messaging.Publisher,
+	logger *logger.Logger,
+	user *entities.User,
+	emailVerificationURL, setPasswordURL string,
+) {
+	if publisher == nil {
+		return
+	}
+	event := events.UserCreatedEvent{
+		ID:                   user.ID,
+		OrganizationID:       user.OrganizationID,
+		Email:                user.Email.String(),
+		Username:             user.Username,
+		Role:                 user.Role.String(),
+		Status:               user.Status.String(),
+		EmailVerified:        user.EmailVerified,
+		EmailVerifiedAt:      user.EmailVerifiedAt,
+		CreatedAt:            user.CreatedAt,
+		UpdatedAt:            user.UpdatedAt,
+		EmailVerificationURL: emailVerificationURL,
+		SetPasswordURL:       setPasswordURL,
+	}
+	if err := publisher.Publish(ctx, events.EventTypeUserCreated, event); err != nil {
+		logger.Error("Failed to publish user created event", zap.Error(err))
+	}
+}
+
+func publishUserUpdated(ctx context.Context, publisher messaging.Publisher, logger *logger.Logger, user *entities.User) {
+	if publisher == nil {
+		return
+	}
+	event := events.UserUpdatedEvent{
+		ID:              user.ID,
+		OrganizationID:  user.OrganizationID,
+		Email:           user.Email.String(),
+		Username:        user.Username,
+		Role:            user.Role.String(),
+		Status:          user.Status.String(),
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		DisplayName:     user.DisplayName,
+		AvatarID:        user.AvatarID,
+		Timezone:        user.Timezone,
+		Locale:          user.Locale,
+		Phone:           user.Phone,
+		GroupIDs:        user.GroupIDs,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+	if event.GroupIDs == nil {
+		event.GroupIDs = []string{}
+	}
+	if err := publisher.Publish(ctx, events.EventTypeUserUpdated, event); err != nil {
+		logger.Error("Failed to publish user updated event", zap.Error(err))
+	}
+}
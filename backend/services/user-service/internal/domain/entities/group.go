@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxGroupNameLength        = 100
+	MaxGroupDescriptionLength = 1000
+)
+
+var (
+	ErrGroupNameRequired       = errors.New("group name is required")
+	ErrGroupNameTooLong        = fmt.Errorf("group name must be at most %d characters", MaxGroupNameLength)
+	ErrGroupDescriptionTooLong = fmt.Errorf("group description must be at most %d characters", MaxGroupDescriptionLength)
+)
+
+// Group is a cohort of students, such as "2026 Evening Batch", that can be
+// enrolled or messaged as a unit. Names are unique within an organization
+// regardless of case. MemberCount is only read with the group.
+type Group struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedBy   string
+	MemberCount int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// OrganizationID is the organization the group and its members belong
+	// to.
+	OrganizationID *string
+}
+
+func NewGroup(name, description, createdBy string) (*Group, error) {
+	now := time.Now().UTC()
+	group := &Group{
+		ID:        uuid.NewString(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := group.Rename(name, description); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// Rename changes the name and description, leaving the group unchanged when
+// either is invalid.
+func (g *Group) Rename(name, description string) error {
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" {
+		return ErrGroupNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxGroupNameLength {
+		return ErrGroupNameTooLong
+	}
+	if utf8.RuneCountInString(description) > MaxGroupDescriptionLength {
+		return ErrGroupDescriptionTooLong
+	}
+
+	g.Name = name
+	g.Description = description
+	g.UpdatedAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const MaxOrganizationNameLength = 100
+
+var (
+	ErrOrganizationNameRequired = errors.New("organization name is required")
+	ErrOrganizationNameTooLong  = fmt.Errorf("organization name must be at most %d characters", MaxOrganizationNameLength)
+)
+
+// Organization is a partner school hosted on the deployment. Its users,
+// courses and other data are only visible to its own members and to
+// super-admins. Names are unique regardless of case.
+type Organization struct {
+	ID        string
+	Name      string
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewOrganization(name, createdBy string) (*Organization, error) {
+	now := time.Now().UTC()
+	organization := &Organization{
+		ID:        uuid.NewString(),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := organization.Rename(name); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// Rename leaves the organization unchanged when name is invalid.
+func (o *Organization) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrOrganizationNameRequired
+	}
+	if utf8.RuneCountInString(name) > MaxOrganizationNameLength {
+		return ErrOrganizationNameTooLong
+	}
+
+	o.Name = name
+	o.UpdatedAt = time.Now().UTC()
+	return nil
+}
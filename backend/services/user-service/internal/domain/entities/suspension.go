@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Suspension blocks a user until it expires or an admin lifts it. ExpiresAt
+// is nil for a suspension without an end, and LiftedBy is empty when it was
+// lifted because it expired. A user has at most one suspension not yet
+// lifted.
+type Suspension struct {
+	ID          string
+	UserID      string
+	Reason      string
+	SuspendedBy string
+	SuspendedAt time.Time
+	ExpiresAt   *time.Time
+	LiftedAt    *time.Time
+	LiftedBy    string
+}
+
+func NewSuspension(userID, reason, suspendedBy string, expiresAt *time.Time) *Suspension {
+	return &Suspension{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Reason:      reason,
+		SuspendedBy: suspendedBy,
+		SuspendedAt: time.Now().UTC(),
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (s *Suspension) IsLifted() bool {
+	return s.LiftedAt != nil
+}
+
+// HasExpired reports whether the suspension has run its course by now, even
+// if it has not been lifted yet.
+func (s *Suspension) HasExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+func (s *Suspension) Lift(liftedBy string, at time.Time) {
+	s.LiftedAt = &at
+	s.LiftedBy = liftedBy
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+)
+
+type GroupQuery struct {
+	// SearchQuery matches part of the name.
+	SearchQuery string
+	Limit       int
+	Offset      int
+}
+
+type GroupQueryResult struct {
+	Groups []*entities.Group
+	Total  int
+}
+
+// GroupRepository limits FindByID and Find to the organization the context
+// is scoped to.
+type GroupRepository interface {
+	Create(ctx context.Context, group *entities.Group) error
+	// FindByID and FindByName return nil when there is no such group.
+	FindByID(ctx context.Context, id string) (*entities.Group, error)
+	// FindByName looks among the groups of organizationID, or those outside
+	// any organization when it is nil.
+	FindByName(ctx context.Context, organizationID *string, name string) (*entities.Group, error)
+	// Find returns groups ordered by name.
+	Find(ctx context.Context, query GroupQuery) (*GroupQueryResult, error)
+	Update(ctx context.Context, group *entities.Group) error
+	Delete(ctx context.Context, id string) error
+	// AddMembers adds the users to the group and returns those that were not
+	// members already.
+	AddMembers(ctx context.Context, groupID string, userIDs []string) ([]string, error)
+	// RemoveMember reports whether the user was a member.
+	RemoveMember(ctx context.Context, groupID, userID string) (bool, error)
+	ListMemberIDs(ctx context.Context, groupID string) ([]string, error)
+}
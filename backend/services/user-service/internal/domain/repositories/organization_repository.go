@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+)
+
+type OrganizationQuery struct {
+	// SearchQuery matches part of the name.
+	SearchQuery string
+	Limit       int
+	Offset      int
+}
+
+type OrganizationQueryResult struct {
+	Organizations []*entities.Organization
+	Total         int
+}
+
+// OrganizationRepository only finds the caller's own organization when the
+// context is scoped to one.
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *entities.Organization) error
+	// FindByID and FindByName return nil when there is no such organization.
+	FindByID(ctx context.Context, id string) (*entities.Organization, error)
+	FindByName(ctx context.Context, name string) (*entities.Organization, error)
+	// Find returns organizations ordered by name.
+	Find(ctx context.Context, query OrganizationQuery) (*OrganizationQueryResult, error)
+	Update(ctx context.Context, organization *entities.Organization) error
+}
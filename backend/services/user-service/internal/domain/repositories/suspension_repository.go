@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+)
+
+type SuspensionRepository interface {
+	Create(ctx context.Context, suspension *entities.Suspension) error
+	// FindActiveByUserID returns the user's suspension that is not lifted
+	// yet, or nil when there is none.
+	FindActiveByUserID(ctx context.Context, userID string) (*entities.Suspension, error)
+	// ListByUserID returns the user's suspensions, most recent first. It
+	// returns none for users outside the organization the context is scoped
+	// to.
+	ListByUserID(ctx context.Context, userID string) ([]*entities.Suspension, error)
+	// FindExpired returns suspensions not lifted yet that expired by now.
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Suspension, error)
+	Lift(ctx context.Context, suspension *entities.Suspension) error
+}
@@ -2,11 +2,45 @@
 package config
 
 import (
+	"time"
+
 	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 )
 
+// ImportConfig controls bulk user import by CSV.
+type ImportConfig struct {
+	// MaxRows caps the rows of one file, so it is never held in memory
+	// whole when someone uploads something huge.
+	MaxRows int
+	// SetPasswordURL is the frontend page that reads the token from the
+	// query string and posts it to /auth/reset-password with the new
+	// password. Rows imported without a password get a link to it.
+	SetPasswordURL      string
+	SetPasswordTokenTTL time.Duration
+}
+
+// DeletionConfig controls soft deletion of users.
+type DeletionConfig struct {
+	// RestoreWindow is how long a deleted user can be restored before being
+	// purged, which also tells every other service to drop its data.
+	RestoreWindow time.Duration
+	// PurgeInterval is how often users past the window are looked for.
+	PurgeInterval time.Duration
+}
+
+// SuspensionConfig controls time-bound suspensions.
+type SuspensionConfig struct {
+	// ReinstateInterval is how often expired suspensions are looked for, so
+	// a user is reinstated at most this long after their suspension ends.
+	ReinstateInterval time.Duration
+}
+
 type Config struct {
 	config.BaseConfig
+	Auth       config.TokenAuthConfig
+	Import     ImportConfig
+	Deletion   DeletionConfig
+	Suspension SuspensionConfig
 }
 
 func DefaultConfig() *Config {
@@ -17,6 +51,22 @@ func DefaultConfig() *Config {
 	defaults.Database.DBName = "user_service"
 	return &Config{
 		BaseConfig: defaults,
+		Auth: config.TokenAuthConfig{
+			SecretKey:      "secret",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+		},
+		Import: ImportConfig{
+			MaxRows:             5000,
+			SetPasswordURL:      defaults.Server.APIGatewayURL + "/set-password",
+			SetPasswordTokenTTL: 7 * 24 * time.Hour,
+		},
+		Deletion: DeletionConfig{
+			RestoreWindow: 30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Suspension: SuspensionConfig{
+			ReinstateInterval: time.Minute,
+		},
 	}
 }
 
@@ -26,5 +76,18 @@ func Load() (*Config, error) {
 	baseCfg := config.LoadBaseConfig(baseDefaults)
 	return &Config{
 		BaseConfig: baseCfg,
+		Auth:       config.LoadTokenAuthConfig(defaults.Auth),
+		Import: ImportConfig{
+			MaxRows:             config.GetEnvAsInt("USER_IMPORT_MAX_ROWS", defaults.Import.MaxRows),
+			SetPasswordURL:      config.GetEnv("USER_IMPORT_SET_PASSWORD_URL", baseCfg.Server.APIGatewayURL+"/set-password"),
+			SetPasswordTokenTTL: config.GetEnvAsDuration("USER_IMPORT_SET_PASSWORD_TOKEN_TTL", defaults.Import.SetPasswordTokenTTL),
+		},
+		Deletion: DeletionConfig{
+			RestoreWindow: config.GetEnvAsDuration("USER_DELETION_RESTORE_WINDOW", defaults.Deletion.RestoreWindow),
+			PurgeInterval: config.GetEnvAsDuration("USER_DELETION_PURGE_INTERVAL", defaults.Deletion.PurgeInterval),
+		},
+		Suspension: SuspensionConfig{
+			ReinstateInterval: config.GetEnvAsDuration("USER_SUSPENSION_REINSTATE_INTERVAL", defaults.Suspension.ReinstateInterval),
+		},
 	}, nil
 }
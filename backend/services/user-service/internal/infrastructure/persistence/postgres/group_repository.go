@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+type PostgresGroupRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresGroupRepository(db *sql.DB) repositories.GroupRepository {
+	return &PostgresGroupRepository{db: db}
+}
+
+const groupColumns = `
+	id, name, description, created_by, created_at, updated_at, organization_id,
+	(SELECT COUNT(*) FROM user_group_members WHERE group_id = user_groups.id) AS member_count
+`
+
+func scanGroup(row rowScanner) (*entities.Group, error) {
+	var group entities.Group
+	err := row.Scan(
+		&group.ID,
+		&group.Name,
+		&group.Description,
+		&group.CreatedBy,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.OrganizationID,
+		&group.MemberCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *PostgresGroupRepository) Create(ctx context.Context, group *entities.Group) error {
+	group.OrganizationID = tenant.Owner(ctx, group.OrganizationID)
+	query := `
+		INSERT INTO user_groups (id, name, description, created_by, created_at, updated_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		group.ID,
+		group.Name,
+		group.Description,
+		group.CreatedBy,
+		group.CreatedAt,
+		group.UpdatedAt,
+		group.OrganizationID,
+	)
+	return err
+}
+
+func (r *PostgresGroupRepository) FindByID(ctx context.Context, id string) (*entities.Group, error) {
+	query := `
+		SELECT ` + groupColumns + `
+		FROM user_groups
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
+	`
+	return r.findOne(ctx, query, id, tenant.Arg(ctx))
+}
+
+func (r *PostgresGroupRepository) FindByName(ctx context.Context, organizationID *string, name string) (*entities.Group, error) {
+	query := `
+		SELECT ` + groupColumns + `
+		FROM user_groups
+		WHERE LOWER(name) = LOWER($1) AND organization_id IS NOT DISTINCT FROM $2::uuid
+	`
+	return r.findOne(ctx, query, name, organizationID)
+}
+
+func (r *PostgresGroupRepository) findOne(ctx context.Context, query string, args ...any) (*entities.Group, error) {
+	group, err := scanGroup(r.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return group, err
+}
+
+func (r *PostgresGroupRepository) Find(ctx context.Context, query repositories.GroupQuery) (*repositories.GroupQueryResult, error) {
+	clauses := []string{}
+	args := []any{}
+	if query.SearchQuery != "" {
+		args = append(args, "%"+query.SearchQuery+"%")
+		clauses = append(clauses, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		args = append(args, organizationID)
+		clauses = append(clauses, fmt.Sprintf("organization_id = $%d", len(args)))
+	}
+	where := ""
+	if len(clauses) > 0 {
+		where = " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_groups`+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	listQuery := `
+		SELECT ` + groupColumns + `
+		FROM user_groups` + where + fmt.Sprintf(`
+		ORDER BY LOWER(name)
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, listQuery, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*entities.Group{}
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &repositories.GroupQueryResult{Groups: groups, Total: total}, nil
+}
+
+func (r *PostgresGroupRepository) Update(ctx context.Context, group *entities.Group) error {
+	query := `
+		UPDATE user_groups
+		SET name = $1, description = $2, updated_at = $3
+		WHERE id = $4
+	`
+	_, err := r.db.ExecContext(ctx, query, group.Name, group.Description, group.UpdatedAt, group.ID)
+	return err
+}
+
+func (r *PostgresGroupRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_groups WHERE id = $1`, id)
+	return err
+}
+
+func (r *PostgresGroupRepository) AddMembers(ctx context.Context, groupID string, userIDs []string) ([]string, error) {
+	query := `
+		INSERT INTO user_group_members (group_id, user_id, added_at)
+		SELECT $1, user_id, $3
+		FROM UNNEST($2::uuid[]) AS user_id
+		ON CONFLICT (group_id, user_id) DO NOTHING
+		RETURNING user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, groupID, pq.Array(userIDs), time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	added := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		added = append(added, userID)
+	}
+	return added, rows.Err()
+}
+
+func (r *PostgresGroupRepository) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM user_group_members WHERE group_id = $1 AND user_id = $2`,
+		groupID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	removed, err := result.RowsAffected()
+	return removed > 0, err
+}
+
+func (r *PostgresGroupRepository) ListMemberIDs(ctx context.Context, groupID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT user_id FROM user_group_members WHERE group_id = $1 ORDER BY added_at`,
+		groupID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+type PostgresOrganizationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOrganizationRepository(db *sql.DB) repositories.OrganizationRepository {
+	return &PostgresOrganizationRepository{db: db}
+}
+
+const organizationColumns = `id, name, created_by, created_at, updated_at`
+
+func scanOrganization(row rowScanner) (*entities.Organization, error) {
+	var organization entities.Organization
+	err := row.Scan(
+		&organization.ID,
+		&organization.Name,
+		&organization.CreatedBy,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *PostgresOrganizationRepository) Create(ctx context.Context, organization *entities.Organization) error {
+	query := `
+		INSERT INTO organizations (` + organizationColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		organization.ID,
+		organization.Name,
+		organization.CreatedBy,
+		organization.CreatedAt,
+		organization.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgresOrganizationRepository) FindByID(ctx context.Context, id string) (*entities.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organizations
+		WHERE id = $1 AND ($2::uuid IS NULL OR id = $2)
+	`
+	return r.findOne(ctx, query, id, tenant.Arg(ctx))
+}
+
+func (r *PostgresOrganizationRepository) FindByName(ctx context.Context, name string) (*entities.Organization, error) {
+	query := `
+		SELECT ` + organizationColumns + `
+		FROM organizations
+		WHERE LOWER(name) = LOWER($1) AND ($2::uuid IS NULL OR id = $2)
+	`
+	return r.findOne(ctx, query, name, tenant.Arg(ctx))
+}
+
+func (r *PostgresOrganizationRepository) findOne(ctx context.Context, query string, args ...any) (*entities.Organization, error) {
+	organization, err := scanOrganization(r.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return organization, err
+}
+
+func (r *PostgresOrganizationRepository) Find(ctx context.Context, query repositories.OrganizationQuery) (*repositories.OrganizationQueryResult, error) {
+	clauses := []string{}
+	args := []any{}
+	if query.SearchQuery != "" {
+		args = append(args, "%"+query.SearchQuery+"%")
+		clauses = append(clauses, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if organizationID := tenant.OrganizationID(ctx); organizationID != "" {
+		args = append(args, organizationID)
+		clauses = append(clauses, fmt.Sprintf("id = $%d", len(args)))
+	}
+	where := ""
+	if len(clauses) > 0 {
+		where = " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM organizations`+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	listQuery := `
+		SELECT ` + organizationColumns + `
+		FROM organizations` + where + fmt.Sprintf(`
+		ORDER BY LOWER(name)
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, listQuery, append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	organizations := []*entities.Organization{}
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &repositories.OrganizationQueryResult{Organizations: organizations, Total: total}, nil
+}
+
+func (r *PostgresOrganizationRepository) Update(ctx context.Context, organization *entities.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $1, updated_at = $2
+		WHERE id = $3
+	`
+	_, err := r.db.ExecContext(ctx, query, organization.Name, organization.UpdatedAt, organization.ID)
+	return err
+}
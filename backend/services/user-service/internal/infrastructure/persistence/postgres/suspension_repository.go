@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+type PostgresSuspensionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSuspensionRepository(db *sql.DB) repositories.SuspensionRepository {
+	return &PostgresSuspensionRepository{db: db}
+}
+
+const suspensionColumns = `id, user_id, reason, suspended_by, suspended_at, expires_at, lifted_at, lifted_by`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSuspension(row rowScanner) (*entities.Suspension, error) {
+	var suspension entities.Suspension
+	var expiresAt, liftedAt sql.NullTime
+	var liftedBy sql.NullString
+	err := row.Scan(
+		&suspension.ID,
+		&suspension.UserID,
+		&suspension.Reason,
+		&suspension.SuspendedBy,
+		&suspension.SuspendedAt,
+		&expiresAt,
+		&liftedAt,
+		&liftedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		suspension.ExpiresAt = &expiresAt.Time
+	}
+	if liftedAt.Valid {
+		suspension.LiftedAt = &liftedAt.Time
+	}
+	suspension.LiftedBy = liftedBy.String
+	return &suspension, nil
+}
+
+func (r *PostgresSuspensionRepository) Create(ctx context.Context, suspension *entities.Suspension) error {
+	query := `
+		INSERT INTO user_suspensions (` + suspensionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		suspension.ID,
+		suspension.UserID,
+		suspension.Reason,
+		suspension.SuspendedBy,
+		suspension.SuspendedAt,
+		suspension.ExpiresAt,
+		suspension.LiftedAt,
+		sql.NullString{String: suspension.LiftedBy, Valid: suspension.LiftedBy != ""},
+	)
+	return err
+}
+
+func (r *PostgresSuspensionRepository) FindActiveByUserID(ctx context.Context, userID string) (*entities.Suspension, error) {
+	query := `
+		SELECT ` + suspensionColumns + `
+		FROM user_suspensions
+		WHERE user_id = $1 AND lifted_at IS NULL
+	`
+	suspension, err := scanSuspension(r.db.QueryRowContext(ctx, query, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return suspension, err
+}
+
+func (r *PostgresSuspensionRepository) ListByUserID(ctx context.Context, userID string) ([]*entities.Suspension, error) {
+	query := `
+		SELECT ` + suspensionColumns + `
+		FROM user_suspensions
+		WHERE user_id = $1
+			AND ($2::uuid IS NULL OR user_id IN (SELECT id FROM users WHERE organization_id = $2))
+		ORDER BY suspended_at DESC
+	`
+	return r.list(ctx, query, userID, tenant.Arg(ctx))
+}
+
+func (r *PostgresSuspensionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Suspension, error) {
+	query := `
+		SELECT ` + suspensionColumns + `
+		FROM user_suspensions
+		WHERE lifted_at IS NULL AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+	return r.list(ctx, query, now, limit)
+}
+
+func (r *PostgresSuspensionRepository) list(ctx context.Context, query string, args ...any) ([]*entities.Suspension, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suspensions := []*entities.Suspension{}
+	for rows.Next() {
+		suspension, err := scanSuspension(rows)
+		if err != nil {
+			return nil, err
+		}
+		suspensions = append(suspensions, suspension)
+	}
+	return suspensions, rows.Err()
+}
+
+func (r *PostgresSuspensionRepository) Lift(ctx context.Context, suspension *entities.Suspension) error {
+	query := `
+		UPDATE user_suspensions
+		SET lifted_at = $1, lifted_by = $2
+		WHERE id = $3 AND lifted_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		suspension.LiftedAt,
+		sql.NullString{String: suspension.LiftedBy, Valid: suspension.LiftedBy != ""},
+		suspension.ID,
+	)
+	return err
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+)
+
+type GroupHandler struct {
+	createGroupUseCase       *usecases.CreateGroupUseCase
+	getGroupUseCase          *usecases.GetGroupUseCase
+	findGroupsUseCase        *usecases.FindGroupsUseCase
+	updateGroupUseCase       *usecases.UpdateGroupUseCase
+	deleteGroupUseCase       *usecases.DeleteGroupUseCase
+	addGroupMembersUseCase   *usecases.AddGroupMembersUseCase
+	removeGroupMemberUseCase *usecases.RemoveGroupMemberUseCase
+	logger                   *logger.Logger
+}
+
+func NewGroupHandler(
+	createGroupUseCase *usecases.CreateGroupUseCase,
+	getGroupUseCase *usecases.GetGroupUseCase,
+	findGroupsUseCase *usecases.FindGroupsUseCase,
+	updateGroupUseCase *usecases.UpdateGroupUseCase,
+	deleteGroupUseCase *usecases.DeleteGroupUseCase,
+	addGroupMembersUseCase *usecases.AddGroupMembersUseCase,
+	removeGroupMemberUseCase *usecases.RemoveGroupMemberUseCase,
+	logger *logger.Logger,
+) *GroupHandler {
+	return &GroupHandler{
+		createGroupUseCase:       createGroupUseCase,
+		getGroupUseCase:          getGroupUseCase,
+		findGroupsUseCase:        findGroupsUseCase,
+		updateGroupUseCase:       updateGroupUseCase,
+		deleteGroupUseCase:       deleteGroupUseCase,
+		addGroupMembersUseCase:   addGroupMembersUseCase,
+		removeGroupMemberUseCase: removeGroupMemberUseCase,
+		logger:                   logger,
+	}
+}
+
+// abortWithGroupError maps the errors shared by the group endpoints to a
+// response.
+func abortWithGroupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrGroupNotFound), errors.Is(err, usecases.ErrNotGroupMember):
+		middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrGroupNameTaken):
+		middleware.AbortWithError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entities.ErrGroupNameRequired),
+		errors.Is(err, entities.ErrGroupNameTooLong),
+		errors.Is(err, entities.ErrGroupDescriptionTooLong),
+		errors.Is(err, usecases.ErrNoGroupMembers),
+		errors.Is(err, usecases.ErrTooManyGroupMembers),
+		errors.Is(err, usecases.ErrGroupMemberNotFound),
+		errors.Is(err, usecases.ErrGroupMemberNotStudent):
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+	default:
+		middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// groupIDParam reads the group ID from the path, aborting the request when
+// it is not a valid ID.
+func groupIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("group_id")
+	if _, err := uuid.Parse(id); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid group ID format")
+		return "", false
+	}
+	return id, true
+}
+
+type CreateGroupRequest struct {
+	Name        string `json:"name" binding:"required,max=100" example:"2026 Evening Batch"`
+	Description string `json:"description" binding:"max=1000" example:"Students starting the evening programme in 2026."`
+}
+
+// CreateGroup godoc
+// @Summary Create a group
+// @Description Create a group of students, such as a cohort. Names are unique regardless of case. Requires admin role.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateGroupRequest true "Group details"
+// @Success 201 {object} usecases.GroupOutput "Group created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 409 {object} map[string]interface{} "Group name already exists"
+// @Router /groups [post]
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	createdBy := c.GetHeader("X-User-ID")
+	if createdBy == "" {
+		middleware.AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.createGroupUseCase.Execute(c.Request.Context(), usecases.CreateGroupInput{
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+type FindGroupsRequest struct {
+	SearchQuery string `form:"search_query" binding:"omitempty,max=255"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset      int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// FindGroups godoc
+// @Summary Find groups
+// @Description List groups by name with their member counts. Requires admin or instructor role.
+// @Tags groups
+// @Produce json
+// @Security BearerAuth
+// @Param search_query query string false "Part of the group name"
+// @Param limit query int false "Number of results per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip" default(0) minimum(0)
+// @Success 200 {object} usecases.FindGroupsOutput "Groups retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin or instructor role required"
+// @Router /groups [get]
+func (h *GroupHandler) FindGroups(c *gin.Context) {
+	var req FindGroupsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.findGroupsUseCase.Execute(c.Request.Context(), usecases.FindGroupsInput{
+		SearchQuery: req.SearchQuery,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetGroup godoc
+// @Summary Get a group
+// @Description Get a group with its member count. Members are listed by finding users with the group_id filter. Requires admin or instructor role.
+// @Tags groups
+// @Produce json
+// @Security BearerAuth
+// @Param group_id path string true "Group ID" Format(uuid)
+// @Success 200 {object} usecases.GroupOutput "Group retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid group ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin or instructor role required"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Router /groups/{group_id} [get]
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	id, ok := groupIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getGroupUseCase.Execute(c.Request.Context(), usecases.GetGroupInput{GroupID: id})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+type UpdateGroupRequest struct {
+	// Fields are left unchanged when omitted.
+	Name        *string `json:"name" binding:"omitempty,max=100" example:"2026 Evening Batch"`
+	Description *string `json:"description" binding:"omitempty,max=1000" example:"Students starting the evening programme in 2026."`
+}
+
+// UpdateGroup godoc
+// @Summary Update a group
+// @Description Rename a group or change its description. Omitted fields are left unchanged. Requires admin role.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_id path string true "Group ID" Format(uuid)
+// @Param request body UpdateGroupRequest true "Group changes"
+// @Success 200 {object} usecases.GroupOutput "Group updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or group ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Failure 409 {object} map[string]interface{} "Group name already exists"
+// @Router /groups/{group_id} [put]
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	id, ok := groupIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.updateGroupUseCase.Execute(c.Request.Context(), usecases.UpdateGroupInput{
+		GroupID:     id,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// DeleteGroup godoc
+// @Summary Delete a group
+// @Description Delete a group. Its members are kept and only leave the group. Requires admin role.
+// @Tags groups
+// @Produce json
+// @Security BearerAuth
+// @Param group_id path string true "Group ID" Format(uuid)
+// @Success 200 {object} map[string]interface{} "Group deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid group ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Router /groups/{group_id} [delete]
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	id, ok := groupIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteGroupUseCase.Execute(c.Request.Context(), usecases.DeleteGroupInput{GroupID: id}); err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Group deleted successfully."})
+}
+
+type AddGroupMembersRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=500,dive,uuid" example:"5f0c6f9e-3c1a-4d8e-9a55-0b6f8f3f2f10"`
+}
+
+// AddGroupMembers godoc
+// @Summary Add students to a group
+// @Description Add up to 500 students to a group. Students who are members already are skipped, and nobody is added when any user is missing or not a student. Requires admin role.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param group_id path string true "Group ID" Format(uuid)
+// @Param request body AddGroupMembersRequest true "Users to add"
+// @Success 200 {object} usecases.AddGroupMembersOutput "Members added successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body, group ID or users"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Router /groups/{group_id}/members [post]
+func (h *GroupHandler) AddGroupMembers(c *gin.Context) {
+	id, ok := groupIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req AddGroupMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.addGroupMembersUseCase.Execute(c.Request.Context(), usecases.AddGroupMembersInput{
+		GroupID: id,
+		UserIDs: req.UserIDs,
+	})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// RemoveGroupMember godoc
+// @Summary Remove a student from a group
+// @Description Remove a member from a group. Requires admin role.
+// @Tags groups
+// @Produce json
+// @Security BearerAuth
+// @Param group_id path string true "Group ID" Format(uuid)
+// @Param user_id path string true "User ID" Format(uuid)
+// @Success 200 {object} map[string]interface{} "Member removed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid group or user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Group not found or user is not a member"
+// @Router /groups/{group_id}/members/{user_id} [delete]
+func (h *GroupHandler) RemoveGroupMember(c *gin.Context) {
+	id, ok := groupIDParam(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	err := h.removeGroupMemberUseCase.Execute(c.Request.Context(), usecases.RemoveGroupMemberInput{
+		GroupID: id,
+		UserID:  userID,
+	})
+	if err != nil {
+		abortWithGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully."})
+}
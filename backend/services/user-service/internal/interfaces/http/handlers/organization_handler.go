@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+)
+
+type OrganizationHandler struct {
+	createOrganizationUseCase     *usecases.CreateOrganizationUseCase
+	getOrganizationUseCase        *usecases.GetOrganizationUseCase
+	findOrganizationsUseCase      *usecases.FindOrganizationsUseCase
+	updateOrganizationUseCase     *usecases.UpdateOrganizationUseCase
+	moveUserToOrganizationUseCase *usecases.MoveUserToOrganizationUseCase
+	logger                        *logger.Logger
+}
+
+func NewOrganizationHandler(
+	createOrganizationUseCase *usecases.CreateOrganizationUseCase,
+	getOrganizationUseCase *usecases.GetOrganizationUseCase,
+	findOrganizationsUseCase *usecases.FindOrganizationsUseCase,
+	updateOrganizationUseCase *usecases.UpdateOrganizationUseCase,
+	moveUserToOrganizationUseCase *usecases.MoveUserToOrganizationUseCase,
+	logger *logger.Logger,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		createOrganizationUseCase:     createOrganizationUseCase,
+		getOrganizationUseCase:        getOrganizationUseCase,
+		findOrganizationsUseCase:      findOrganizationsUseCase,
+		updateOrganizationUseCase:     updateOrganizationUseCase,
+		moveUserToOrganizationUseCase: moveUserToOrganizationUseCase,
+		logger:                        logger,
+	}
+}
+
+// abortWithOrganizationError maps the errors shared by the organization
+// endpoints to a response.
+func abortWithOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrSuperAdminRequired):
+		middleware.AbortWithError(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, usecases.ErrOrganizationNotFound), errors.Is(err, usecases.ErrUserNotFound):
+		middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecases.ErrOrganizationNameTaken):
+		middleware.AbortWithError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entities.ErrOrganizationNameRequired),
+		errors.Is(err, entities.ErrOrganizationNameTooLong):
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+	default:
+		middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// organizationIDParam reads the organization ID from the path, aborting the
+// request when it is not a valid ID.
+func organizationIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("organization_id")
+	if _, err := uuid.Parse(id); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid organization ID format")
+		return "", false
+	}
+	return id, true
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Riverside High School"`
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Add a partner school hosted on this deployment. Names are unique regardless of case. Requires a super-admin, an admin outside any organization.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOrganizationRequest true "Organization details"
+// @Success 201 {object} usecases.OrganizationOutput "Organization created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Super-admin required"
+// @Failure 409 {object} map[string]interface{} "Organization name already exists"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	createdBy := c.GetHeader("X-User-ID")
+	if createdBy == "" {
+		middleware.AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.createOrganizationUseCase.Execute(c.Request.Context(), usecases.CreateOrganizationInput{
+		Name:      req.Name,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		abortWithOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+type FindOrganizationsRequest struct {
+	SearchQuery string `form:"search_query" binding:"omitempty,max=255"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset      int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// FindOrganizations godoc
+// @Summary Find organizations
+// @Description List organizations by name. Admins of an organization only find their own. Requires admin role.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param search_query query string false "Part of the organization name"
+// @Param limit query int false "Number of results per page" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip" default(0) minimum(0)
+// @Success 200 {object} usecases.FindOrganizationsOutput "Organizations retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Router /organizations [get]
+func (h *OrganizationHandler) FindOrganizations(c *gin.Context) {
+	var req FindOrganizationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.findOrganizationsUseCase.Execute(c.Request.Context(), usecases.FindOrganizationsInput{
+		SearchQuery: req.SearchQuery,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	})
+	if err != nil {
+		abortWithOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetOrganization godoc
+// @Summary Get an organization
+// @Description Get an organization. Admins of an organization only find their own. Requires admin role.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID" Format(uuid)
+// @Success 200 {object} usecases.OrganizationOutput "Organization retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Router /organizations/{organization_id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	id, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getOrganizationUseCase.Execute(c.Request.Context(), usecases.GetOrganizationInput{OrganizationID: id})
+	if err != nil {
+		abortWithOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Riverside High School"`
+}
+
+// UpdateOrganization godoc
+// @Summary Rename an organization
+// @Description Rename an organization. Requires a super-admin.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID" Format(uuid)
+// @Param request body UpdateOrganizationRequest true "Organization changes"
+// @Success 200 {object} usecases.OrganizationOutput "Organization updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or organization ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Super-admin required"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 409 {object} map[string]interface{} "Organization name already exists"
+// @Router /organizations/{organization_id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	id, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := h.updateOrganizationUseCase.Execute(c.Request.Context(), usecases.UpdateOrganizationInput{
+		OrganizationID: id,
+		Name:           req.Name,
+	})
+	if err != nil {
+		abortWithOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// MoveUserToOrganization godoc
+// @Summary Move a user into an organization
+// @Description Move a user into an organization. Tokens issued before the move stop being accepted. Requires a super-admin.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID" Format(uuid)
+// @Param user_id path string true "User ID" Format(uuid)
+// @Success 200 {object} map[string]interface{} "User moved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid organization or user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Super-admin required"
+// @Failure 404 {object} map[string]interface{} "Organization or user not found"
+// @Router /organizations/{organization_id}/members/{user_id} [put]
+func (h *OrganizationHandler) MoveUserToOrganization(c *gin.Context) {
+	id, ok := organizationIDParam(c)
+	if !ok {
+		return
+	}
+
+	userID := c.Param("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	output, err := h.moveUserToOrganizationUseCase.Execute(c.Request.Context(), usecases.MoveUserToOrganizationInput{
+		UserID:         userID,
+		OrganizationID: id,
+	})
+	if err != nil {
+		abortWithOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -1,16 +1,23 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"go.uber.org/zap"
 )
 
 type UserHandler struct {
@@ -19,6 +26,12 @@ type UserHandler struct {
 	updateUserUseCase *usecases.UpdateUserUseCase
 	findUserUseCase *usecases.FindUserUseCase
 	deleteUserUseCase *usecases.DeleteUserUsecase
+	restoreUserUseCase *usecases.RestoreUserUseCase
+	suspendUserUseCase *usecases.SuspendUserUseCase
+	liftSuspensionUseCase *usecases.LiftSuspensionUseCase
+	listSuspensionsUseCase *usecases.ListSuspensionsUseCase
+	importUsersUseCase *usecases.ImportUsersUseCase
+	exportUsersUseCase *usecases.ExportUsersUseCase
 	logger *logger.Logger
 }
 
@@ -28,6 +41,12 @@ func NewUserHandler(
 	updateUserUseCase *usecases.UpdateUserUseCase, 
 	findUserUseCase *usecases.FindUserUseCase,
 	deleteUserUseCase *usecases.DeleteUserUsecase,
+	restoreUserUseCase *usecases.RestoreUserUseCase,
+	suspendUserUseCase *usecases.SuspendUserUseCase,
+	liftSuspensionUseCase *usecases.LiftSuspensionUseCase,
+	listSuspensionsUseCase *usecases.ListSuspensionsUseCase,
+	importUsersUseCase *usecases.ImportUsersUseCase,
+	exportUsersUseCase *usecases.ExportUsersUseCase,
 	logger *logger.Logger,
 	) *UserHandler {
 	return &UserHandler{
@@ -36,6 +55,12 @@ func NewUserHandler(
 		updateUserUseCase: updateUserUseCase,
 		findUserUseCase: findUserUseCase,
 		deleteUserUseCase: deleteUserUseCase,
+		restoreUserUseCase: restoreUserUseCase,
+		suspendUserUseCase: suspendUserUseCase,
+		liftSuspensionUseCase: liftSuspensionUseCase,
+		listSuspensionsUseCase: listSuspensionsUseCase,
+		importUsersUseCase: importUsersUseCase,
+		exportUsersUseCase: exportUsersUseCase,
 		logger: logger,
 	}
 }
@@ -45,11 +70,13 @@ type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=255" example:"testuser"`
 	Password string `json:"password" binding:"required,min=8,max=255" example:"Password@123"`
 	Role     string `json:"role" binding:"required,oneof=student instructor admin" example:"student"`
+	// OrganizationID defaults to the caller's organization.
+	OrganizationID *string `json:"organization_id" binding:"omitempty,uuid" example:"3f2b6a1e-8c4d-4e5f-9a0b-1c2d3e4f5a6b"`
 }
 
 // CreateUser godoc
 // @Summary Create a new user
-// @Description Create a new user account. Requires admin role.
+// @Description Create a new user account in the caller's organization. Super-admins may name another organization, and admins created by super-admins without one are super-admins themselves. Requires admin role.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -59,6 +86,7 @@ type CreateUserRequest struct {
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
 // @Failure 409 {object} map[string]interface{} "Email or username already exists"
 // @Router / [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -73,6 +101,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		Username: req.Username,
 		Password: req.Password,
 		Role:     req.Role,
+		OrganizationID: req.OrganizationID,
 	}
 
 	output, err := h.createUserUseCase.Execute(c.Request.Context(), input)
@@ -81,6 +110,10 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 			middleware.AbortWithError(c, http.StatusConflict, err.Error())
 			return
 		}
+		if errors.Is(err, usecases.ErrOrganizationNotFound) {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
 		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -135,11 +168,26 @@ type UpdateUserRequest struct {
 	Email    string `json:"email" binding:"omitempty,email" example:"updated@example.com"`
 	Role     string `json:"role" binding:"omitempty,oneof=student instructor admin" example:"student"`
 	Status   string `json:"status" binding:"omitempty,oneof=active inactive pending banned" example:"active"`
+	// Profile fields are left unchanged when omitted and cleared when empty.
+	DisplayName *string `json:"display_name" example:"Aung Aung"`
+	Bio         *string `json:"bio" example:"Second year computer science student."`
+	AvatarID    *string `json:"avatar_id" example:"5f0c6f9e-3c1a-4d8e-9a55-0b6f8f3f2f10"`
+	Timezone    *string `json:"timezone" example:"Asia/Yangon"`
+	Locale      *string `json:"locale" example:"my-MM"`
+	Phone       *string `json:"phone" example:"+959123456789"`
+}
+
+// optionalString treats an empty string as not given.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
 }
 
 // UpdateUser godoc
 // @Summary Update user information
-// @Description Update user information. Requires admin role.
+// @Description Update user information and profile. Omitted fields are left unchanged. Requires admin role.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -172,10 +220,18 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	input := usecases.UpdateUserInput{
 		ID: id,
-		Username: &req.Username,
-		Email: &req.Email,
-		Role: &req.Role,
-		Status: &req.Status,
+		Username: optionalString(req.Username),
+		Email: optionalString(req.Email),
+		Role: optionalString(req.Role),
+		Status: optionalString(req.Status),
+		Profile: entities.ProfileUpdate{
+			DisplayName: req.DisplayName,
+			Bio:         req.Bio,
+			AvatarID:    req.AvatarID,
+			Timezone:    req.Timezone,
+			Locale:      req.Locale,
+			Phone:       req.Phone,
+		},
 	}
 
 	output, err := h.updateUserUseCase.Execute(c.Request.Context(), input)
@@ -184,6 +240,8 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
 			return
 		}
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	c.JSON(http.StatusOK, output)
@@ -191,11 +249,12 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 // DeleteUser godoc
 // @Summary Delete user
-// @Description Delete a user account. Requires admin role. Cannot delete admin users.
+// @Description Delete a user account. The user can be restored until the restore window ends, after which every service drops their data. Permanent deletion purges the user straight away, also when already deleted. Requires admin role. Cannot delete admin users.
 // @Tags users
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID" Format(uuid)
+// @Param permanent query bool false "Purge the user now instead of allowing a restore"
 // @Success 200 {object} map[string]interface{} "User deleted successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid user ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
@@ -214,8 +273,15 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	permanent, err := strconv.ParseBool(c.DefaultQuery("permanent", "false"))
+	if err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "permanent must be true or false")
+		return
+	}
+
 	input := usecases.DeleteUserInput{
-		UserID: id,
+		UserID:    id,
+		Permanent: permanent,
 	}
 
 	output, err := h.deleteUserUseCase.Execute(c.Request.Context(), input)
@@ -224,6 +290,207 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
 			return
 		}
+		if err == usecases.ErrCannotDeleteAdminUser {
+			middleware.AbortWithError(c, http.StatusForbidden, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Undo the deletion of a user within the restore window. Requires admin role.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" Format(uuid)
+// @Success 200 {object} map[string]interface{} "User restored successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "Deleted user not found"
+// @Failure 409 {object} map[string]interface{} "Email or username taken since the deletion"
+// @Failure 410 {object} map[string]interface{} "Restore window has ended"
+// @Router /{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	output, err := h.restoreUserUseCase.Execute(c.Request.Context(), usecases.RestoreUserInput{UserID: id})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrUserNotFound):
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, usecases.ErrEmailAlreadyExists), errors.Is(err, usecases.ErrUsernameAlreadyExists):
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+		case errors.Is(err, usecases.ErrRestoreWindowExpired):
+			middleware.AbortWithError(c, http.StatusGone, err.Error())
+		default:
+			middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000" example:"Repeated spam in course discussions."`
+	// At most one of ExpiresAt and Duration is given. Without either the
+	// suspension lasts until it is lifted.
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-12-31T00:00:00Z"`
+	Duration  string     `json:"duration" example:"168h"`
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Description Suspend a user for a reason, either until a given time or until lifted. The user is signed out everywhere and reinstated automatically when the suspension ends. Requires admin role. Cannot suspend admin or banned users.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" Format(uuid)
+// @Param request body SuspendUserRequest true "Suspension details"
+// @Success 201 {object} usecases.SuspensionOutput "User suspended successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid request body or user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required or cannot suspend user"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "User is already suspended"
+// @Router /{id}/suspend [post]
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	suspendedBy := c.GetHeader("X-User-ID")
+	if suspendedBy == "" {
+		middleware.AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	expiresAt := req.ExpiresAt
+	if req.Duration != "" {
+		if expiresAt != nil {
+			middleware.AbortWithError(c, http.StatusBadRequest, "Only one of expires_at and duration may be given")
+			return
+		}
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			middleware.AbortWithError(c, http.StatusBadRequest, "duration must be a positive duration such as 72h")
+			return
+		}
+		until := time.Now().UTC().Add(duration)
+		expiresAt = &until
+	}
+
+	output, err := h.suspendUserUseCase.Execute(c.Request.Context(), usecases.SuspendUserInput{
+		UserID:      id,
+		Reason:      req.Reason,
+		SuspendedBy: suspendedBy,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrUserNotFound):
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, entities.ErrCannotSuspendAdmin), errors.Is(err, entities.ErrCannotSuspendBanned):
+			middleware.AbortWithError(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, usecases.ErrUserAlreadySuspended):
+			middleware.AbortWithError(c, http.StatusConflict, err.Error())
+		case errors.Is(err, usecases.ErrSuspensionReasonRequired),
+			errors.Is(err, usecases.ErrSuspensionReasonTooLong),
+			errors.Is(err, usecases.ErrSuspensionExpiryInPast):
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		default:
+			middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+// ReinstateUser godoc
+// @Summary Lift a user's suspension
+// @Description Lift the user's current suspension before it ends and reactivate them. Requires admin role.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID" Format(uuid)
+// @Success 200 {object} usecases.SuspensionOutput "Suspension lifted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Failure 404 {object} map[string]interface{} "User is not suspended"
+// @Router /{id}/reinstate [post]
+func (h *UserHandler) ReinstateUser(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	liftedBy := c.GetHeader("X-User-ID")
+	if liftedBy == "" {
+		middleware.AbortWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	output, err := h.liftSuspensionUseCase.Execute(c.Request.Context(), usecases.LiftSuspensionInput{
+		UserID:   id,
+		LiftedBy: liftedBy,
+	})
+	if err != nil {
+		if errors.Is(err, usecases.ErrUserNotSuspended) {
+			middleware.AbortWithError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// ListSuspensions godoc
+// @Summary List a user's suspensions
+// @Description List every suspension of a user, current and past, most recent first. Requires admin role.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string true "User ID" Format(uuid)
+// @Success 200 {object} usecases.ListSuspensionsOutput "Suspensions"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Router /suspensions [get]
+func (h *UserHandler) ListSuspensions(c *gin.Context) {
+	userID := c.Query("user_id")
+	if _, err := uuid.Parse(userID); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, "user_id must be a valid user ID")
+		return
+	}
+
+	output, err := h.listSuspensionsUseCase.Execute(c.Request.Context(), usecases.ListSuspensionsInput{UserID: userID})
+	if err != nil {
+		middleware.AbortWithError(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
 	c.JSON(http.StatusOK, output)
@@ -232,10 +499,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 type FindUserRequest struct {
 	SearchQuery   string `json:"search_query" form:"search_query" binding:"omitempty,min=1,max=255" example:"testuser"`
 	Role          string `json:"role" form:"role" binding:"omitempty,oneof=student instructor admin" example:"student"`
-	Status        string `json:"status" form:"status" binding:"omitempty,oneof=active inactive pending banned" example:"active"`
+	Status        string `json:"status" form:"status" binding:"omitempty,oneof=active inactive pending banned suspended" example:"active"`
+	GroupID       string `json:"group_id" form:"group_id" binding:"omitempty,uuid" example:"5f0c6f9e-3c1a-4d8e-9a55-0b6f8f3f2f10"`
+	// OrganizationID is ignored for callers in an organization, who only
+	// ever see its users.
+	OrganizationID string `json:"organization_id" form:"organization_id" binding:"omitempty,uuid" example:"3f2b6a1e-8c4d-4e5f-9a0b-1c2d3e4f5a6b"`
 	Limit         int    `json:"limit" form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
 	Offset        int    `json:"offset" form:"offset" binding:"omitempty,min=0" example:"0"`
-	SortColumn    string `json:"sort_column" form:"sort_column" binding:"omitempty,oneof=username email role status created_at updated_at" example:"created_at"`
+	SortColumn    string `json:"sort_column" form:"sort_column" binding:"omitempty,oneof=username email role status created_at updated_at deleted_at" example:"created_at"`
 	SortDirection string `json:"sort_direction" form:"sort_direction" binding:"omitempty,oneof=asc desc" example:"desc"`
 }
 
@@ -248,7 +519,9 @@ type FindUserRequest struct {
 // @Security BearerAuth
 // @Param search_query query string false "Search query for username or email"
 // @Param role query string false "Filter by role" Enums(student, instructor, admin)
-// @Param status query string false "Filter by status" Enums(active, inactive, pending, banned)
+// @Param status query string false "Filter by status" Enums(active, inactive, pending, banned, suspended)
+// @Param group_id query string false "Filter by group membership" Format(uuid)
+// @Param organization_id query string false "Filter by organization; only used for super-admins" Format(uuid)
 // @Param limit query int false "Number of results per page" default(10) minimum(1) maximum(100)
 // @Param offset query int false "Number of results to skip" default(0) minimum(0)
 // @Param sort_column query string false "Column to sort by" Enums(username, email, role, status, created_at, updated_at)
@@ -259,10 +532,61 @@ type FindUserRequest struct {
 // @Failure 403 {object} map[string]interface{} "Forbidden - Admin or instructor role required"
 // @Router / [get]
 func (h *UserHandler) FindUser(c *gin.Context) { 
+	input, ok := bindFindUserInput(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.findUserUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// FindDeletedUsers godoc
+// @Summary Find deleted users
+// @Description Search users that are deleted but can still be restored, with the same filters as finding users. Requires admin role.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param search_query query string false "Search query for username or email"
+// @Param role query string false "Filter by role" Enums(student, instructor, admin)
+// @Param status query string false "Filter by status" Enums(active, inactive, pending, banned)
+// @Param limit query int false "Number of results per page" default(10) minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip" default(0) minimum(0)
+// @Param sort_column query string false "Column to sort by" Enums(username, email, role, status, created_at, updated_at, deleted_at)
+// @Param sort_direction query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {object} map[string]interface{} "Deleted users retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Router /deleted [get]
+func (h *UserHandler) FindDeletedUsers(c *gin.Context) {
+	input, ok := bindFindUserInput(c)
+	if !ok {
+		return
+	}
+	input.Deleted = true
+
+	output, err := h.findUserUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// bindFindUserInput reads the filters of FindUserRequest from the query
+// string, aborting the request when they are invalid.
+func bindFindUserInput(c *gin.Context) (usecases.FindUserInput, bool) {
 	var req FindUserRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
-		return
+		return usecases.FindUserInput{}, false
 	}
 
 	input := usecases.FindUserInput{}
@@ -281,6 +605,13 @@ func (h *UserHandler) FindUser(c *gin.Context) {
 		input.Status = &status
 	}
 
+	if req.GroupID != "" {
+		input.GroupID = &req.GroupID
+	}
+	if req.OrganizationID != "" {
+		input.OrganizationID = &req.OrganizationID
+	}
+
 	if req.Limit > 0 {
 		input.Limit = &req.Limit
 	}
@@ -306,15 +637,157 @@ func (h *UserHandler) FindUser(c *gin.Context) {
 		input.SortDirection = &sortDir
 	}
 
-	output, err := h.findUserUseCase.Execute(c.Request.Context(), input)
+	return input, true
+}
+
+// maxImportFileSize bounds the body of an import, whose rows are read into
+// memory before any is applied.
+const maxImportFileSize = 10 << 20
+
+// ImportUsers godoc
+// @Summary Import users from CSV
+// @Description Create or update users in bulk from a CSV file with email, username, role and optional password columns, matched to existing users by email. Rows without a password create users who are emailed a link to set one. Each row is reported on; failed rows do not stop the rest. Requires admin role.
+// @Tags users
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "CSV file, unless the body is the CSV itself"
+// @Param dry_run query bool false "Validate and report without changing anything"
+// @Success 200 {object} usecases.ImportUsersOutput "Per-row import report"
+// @Failure 400 {object} map[string]interface{} "Missing, malformed or too large CSV file"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Router /import [post]
+func (h *UserHandler) ImportUsers(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
 	if err != nil {
-		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		middleware.AbortWithError(c, http.StatusBadRequest, "dry_run must be true or false")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	var file io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			middleware.AbortWithError(c, http.StatusRequestEntityTooLarge, "CSV file is too large")
+			return
+		}
+		if err != nil {
+			middleware.AbortWithError(c, http.StatusBadRequest, "CSV file is required")
+			return
+		}
+		opened, err := header.Open()
+		if err != nil {
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer opened.Close()
+		file = opened
+	}
+
+	output, err := h.importUsersUseCase.Execute(c.Request.Context(), usecases.ImportUsersInput{
+		CSV:    file,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			middleware.AbortWithError(c, http.StatusRequestEntityTooLarge, "CSV file is too large")
+		case errors.Is(err, usecases.ErrInvalidCSV),
+			errors.Is(err, usecases.ErrCSVEmpty),
+			errors.Is(err, usecases.ErrCSVMissingColumn),
+			errors.Is(err, usecases.ErrTooManyRows):
+			middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.Error("failed to import users", zap.Error(err))
+			middleware.AbortWithError(c, http.StatusInternalServerError, "failed to import users")
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, output)
 }
 
+type ExportUsersRequest struct {
+	SearchQuery   string `form:"search_query" binding:"omitempty,min=1,max=255"`
+	Role          string `form:"role" binding:"omitempty,oneof=student instructor admin"`
+	Status        string `form:"status" binding:"omitempty,oneof=active inactive pending banned suspended"`
+	GroupID       string `form:"group_id" binding:"omitempty,uuid"`
+	OrganizationID string `form:"organization_id" binding:"omitempty,uuid"`
+	SortColumn    string `form:"sort_column" binding:"omitempty,oneof=username email role status created_at updated_at"`
+	SortDirection string `form:"sort_direction" binding:"omitempty,oneof=asc desc"`
+}
+
+// ExportUsers godoc
+// @Summary Export users as CSV
+// @Description Stream every user matching the filters of GET / as a CSV file. Requires admin role.
+// @Tags users
+// @Produce text/csv
+// @Security BearerAuth
+// @Param search_query query string false "Search query for username or email"
+// @Param role query string false "Filter by role" Enums(student, instructor, admin)
+// @Param status query string false "Filter by status" Enums(active, inactive, pending, banned, suspended)
+// @Param group_id query string false "Filter by group membership" Format(uuid)
+// @Param organization_id query string false "Filter by organization; only used for super-admins" Format(uuid)
+// @Param sort_column query string false "Column to sort by" Enums(username, email, role, status, created_at, updated_at)
+// @Param sort_direction query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {file} file "CSV file of users"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Admin role required"
+// @Router /export [get]
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	var req ExportUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.AbortWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	input := usecases.ExportUsersInput{}
+	if req.SearchQuery != "" {
+		input.SearchQuery = &req.SearchQuery
+	}
+	if req.Role != "" {
+		role := valueobjects.Role(req.Role)
+		input.Role = &role
+	}
+	if req.Status != "" {
+		status := valueobjects.Status(req.Status)
+		input.Status = &status
+	}
+	if req.GroupID != "" {
+		input.GroupID = &req.GroupID
+	}
+	if req.OrganizationID != "" {
+		input.OrganizationID = &req.OrganizationID
+	}
+	if req.SortColumn != "" {
+		input.SortColumn = &req.SortColumn
+	}
+	if req.SortDirection != "" {
+		sortDir := repositories.SortDirection(strings.ToUpper(req.SortDirection))
+		input.SortDirection = &sortDir
+	}
+
+	filename := fmt.Sprintf("users-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.exportUsersUseCase.Execute(c.Request.Context(), input, c.Writer); err != nil {
+		h.logger.Error("failed to export users", zap.Error(err))
+		// Once rows are sent the status is too, so the export just ends early.
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			middleware.AbortWithError(c, http.StatusInternalServerError, "failed to export users")
+		}
+	}
+}
+
 // Health godoc
 // @Summary Health check
 // @Description Check if the user service is running and healthy
@@ -11,7 +11,7 @@ import (
 )
 
 
-func SetUpRoutes(router *gin.Engine, handler *handlers.UserHandler, logger *logger.Logger){
+func SetUpRoutes(router *gin.Engine, handler *handlers.UserHandler, groupHandler *handlers.GroupHandler, organizationHandler *handlers.OrganizationHandler, authenticate gin.HandlerFunc, logger *logger.Logger){
 	// Middleware
 	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger(logger))
@@ -27,13 +27,38 @@ func SetUpRoutes(router *gin.Engine, handler *handlers.UserHandler, logger *logg
 
 	// User Routes
 	api := router.Group("/api/v1")
+	api.Use(authenticate)
+	api.Use(middleware.Tenant())
 	{
 		userRouter := api.Group("/users")
 		userRouter.POST("", handler.CreateUser)
+		userRouter.POST("/import", handler.ImportUsers)
+		userRouter.GET("/export", handler.ExportUsers)
+		userRouter.GET("/deleted", handler.FindDeletedUsers)
+		userRouter.GET("/suspensions", handler.ListSuspensions)
 		userRouter.GET("/:id", handler.GetUser)
 		userRouter.PUT("/:id", handler.UpdateUser)
 		userRouter.DELETE("/:id", handler.DeleteUser)
+		userRouter.POST("/:id/restore", handler.RestoreUser)
+		userRouter.POST("/:id/suspend", handler.SuspendUser)
+		userRouter.POST("/:id/reinstate", handler.ReinstateUser)
 		userRouter.GET("", handler.FindUser)
+
+		groupRouter := userRouter.Group("/groups")
+		groupRouter.POST("", groupHandler.CreateGroup)
+		groupRouter.GET("", groupHandler.FindGroups)
+		groupRouter.GET("/:group_id", groupHandler.GetGroup)
+		groupRouter.PUT("/:group_id", groupHandler.UpdateGroup)
+		groupRouter.DELETE("/:group_id", groupHandler.DeleteGroup)
+		groupRouter.POST("/:group_id/members", groupHandler.AddGroupMembers)
+		groupRouter.DELETE("/:group_id/members/:user_id", groupHandler.RemoveGroupMember)
+
+		organizationRouter := userRouter.Group("/organizations")
+		organizationRouter.POST("", organizationHandler.CreateOrganization)
+		organizationRouter.GET("", organizationHandler.FindOrganizations)
+		organizationRouter.GET("/:organization_id", organizationHandler.GetOrganization)
+		organizationRouter.PUT("/:organization_id", organizationHandler.UpdateOrganization)
+		organizationRouter.PUT("/:organization_id/members/:user_id", organizationHandler.MoveUserToOrganization)
 	}
 
 }
\ No newline at end of file
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS user_suspensions;
+
+-- Enum values cannot be dropped, so the type is recreated without 'suspended'.
+UPDATE users SET status = 'inactive' WHERE status = 'suspended';
+
+ALTER TYPE user_status RENAME TO user_status_old;
+CREATE TYPE user_status AS ENUM ('active', 'inactive', 'pending', 'banned');
+ALTER TABLE users ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN status TYPE user_status USING status::text::user_status;
+ALTER TABLE users ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE user_status_old;
@@ -0,0 +1,17 @@
+ALTER TYPE user_status ADD VALUE IF NOT EXISTS 'suspended';
+
+CREATE TABLE IF NOT EXISTS user_suspensions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    suspended_by UUID NOT NULL,
+    suspended_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    lifted_at TIMESTAMPTZ,
+    lifted_by UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_suspensions_user_id_suspended_at ON user_suspensions(user_id, suspended_at DESC);
+-- A user has at most one suspension in force, found again when lifting it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_suspensions_active ON user_suspensions(user_id) WHERE lifted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_suspensions_expires_at ON user_suspensions(expires_at) WHERE lifted_at IS NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_user_groups_organization_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_groups_name ON user_groups(LOWER(name));
+ALTER TABLE user_groups DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_users_organization_id;
+ALTER TABLE users DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_name ON organizations(LOWER(name));
+
+-- Users and groups without an organization predate multi-tenancy. Admins
+-- among them are super-admins, who manage every organization.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id);
+
+ALTER TABLE user_groups
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE RESTRICT;
+
+-- Group names only need to be unique within an organization.
+DROP INDEX IF EXISTS idx_user_groups_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_groups_organization_name
+    ON user_groups(COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(name));
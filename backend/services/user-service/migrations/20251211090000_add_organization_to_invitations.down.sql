@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_invitations_organization_id;
+ALTER TABLE invitations DROP COLUMN IF EXISTS organization_id;
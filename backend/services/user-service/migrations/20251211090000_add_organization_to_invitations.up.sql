@@ -0,0 +1,6 @@
+-- Invitations made by an organization's admin add the invitee to that
+-- organization. Those without one were made by super-admins.
+ALTER TABLE invitations
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_invitations_organization_id ON invitations(organization_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_service_accounts_organization_id;
+ALTER TABLE service_accounts DROP COLUMN IF EXISTS organization_id;
@@ -0,0 +1,6 @@
+-- Service accounts act within the organization of the admin who created
+-- them. Only admin accounts may belong to none, like super-admins.
+ALTER TABLE service_accounts
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_service_accounts_organization_id ON service_accounts(organization_id);
@@ -30,6 +30,7 @@ func TestCreateUser_Integration_Success(t *testing.T) {
 	apiGatewayURL := "http://localhost:3000"
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
@@ -82,6 +83,7 @@ func TestCreateUser_Integration_DuplicateEmail(t *testing.T) {
 
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
@@ -130,6 +132,7 @@ func TestCreateUser_Integration_DuplicateUsername(t *testing.T) {
 
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
@@ -178,6 +181,7 @@ func TestCreateUser_Integration_InvalidPassword(t *testing.T) {
 
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
@@ -213,6 +217,7 @@ func TestCreateUser_Integration_AllRoles(t *testing.T) {
 
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
@@ -254,6 +259,7 @@ func TestCreateUser_Integration_MissingRequiredFields(t *testing.T) {
 
 	createUserUC := usecases.NewCreateUserUseCase(
 		userRepo,
+		nil,
 		mockPublisher,
 		logger,
 		mockRedis,
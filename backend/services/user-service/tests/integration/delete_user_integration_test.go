@@ -3,8 +3,10 @@ package integration
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
@@ -16,6 +18,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testDeletionConfig = config.DeletionConfig{
+	RestoreWindow: 30 * 24 * time.Hour,
+	PurgeInterval: time.Hour,
+}
+
 func TestDeleteUser_Integration_Success(t *testing.T) {
 	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
 		MigrationPath:  "migrations",
@@ -28,7 +35,7 @@ func TestDeleteUser_Integration_Success(t *testing.T) {
 	mockPublisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 
-	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger)
+	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
 
 	ctx := context.Background()
 
@@ -52,12 +59,78 @@ func TestDeleteUser_Integration_Success(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Contains(t, result.Message, "Deleted")
 
+	require.NotNil(t, result.RestoreUntil)
+
 	_, err = userRepo.FindByID(ctx, user.ID)
 	require.Error(t, err)
 
+	deletedUser, err := userRepo.FindDeletedByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, deletedUser.DeletedAt)
+
 	mockPublisher.AssertExpectations(t)
 }
 
+func TestDeleteUser_Integration_RestoreAndPurge(t *testing.T) {
+	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
+		MigrationPath:  "migrations",
+		TablesToCleanUp: []string{"users"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	userRepo := integration.SetupUserRepository(db)
+	mockPublisher := new(mocks.MockPublisher)
+	logger := logger.NewNop()
+	mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
+	restoreUserUC := usecases.NewRestoreUserUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
+	purgeUC := usecases.NewPurgeDeletedUsersUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
+
+	ctx := context.Background()
+
+	email, _ := valueobjects.NewEmail("restore@example.com")
+	role, _ := valueobjects.NewRole("student")
+	passwordHash, _ := utils.HashPassword("Password123!")
+	user := entities.NewUser(email, "restoreuser", role, passwordHash)
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	_, err = deleteUserUC.Execute(ctx, usecases.DeleteUserInput{UserID: user.ID})
+	require.NoError(t, err)
+
+	// The email is free again while the user is deleted.
+	takenBy := entities.NewUser(email, "newcomer", role, passwordHash)
+	require.NoError(t, userRepo.Create(ctx, takenBy))
+	_, err = restoreUserUC.Execute(ctx, usecases.RestoreUserInput{UserID: user.ID})
+	require.ErrorIs(t, err, usecases.ErrEmailAlreadyExists)
+	require.NoError(t, userRepo.Delete(ctx, takenBy.ID))
+
+	restored, err := restoreUserUC.Execute(ctx, usecases.RestoreUserInput{UserID: user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, restored.ID)
+
+	_, err = userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	// Nothing is purged within the restore window.
+	_, err = deleteUserUC.Execute(ctx, usecases.DeleteUserInput{UserID: user.ID})
+	require.NoError(t, err)
+	purged, err := purgeUC.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	expiredAt := time.Now().UTC().Add(-testDeletionConfig.RestoreWindow - time.Hour)
+	_, err = db.ExecContext(ctx, `UPDATE users SET deleted_at = $1 WHERE id = $2`, expiredAt, user.ID)
+	require.NoError(t, err)
+	purged, err = purgeUC.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = userRepo.FindDeletedByID(ctx, user.ID)
+	require.Error(t, err)
+}
+
 func TestDeleteUser_Integration_CannotDeleteAdmin(t *testing.T) {
 	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
 		MigrationPath:  "migrations",
@@ -70,7 +143,7 @@ func TestDeleteUser_Integration_CannotDeleteAdmin(t *testing.T) {
 	mockPublisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 
-	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger)
+	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
 
 	ctx := context.Background()
 
@@ -108,7 +181,7 @@ func TestDeleteUser_Integration_NotFound(t *testing.T) {
 	mockPublisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 
-	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger)
+	deleteUserUC := usecases.NewDeleteUserUseCase(userRepo, mockPublisher, logger, testDeletionConfig)
 
 	ctx := context.Background()
 
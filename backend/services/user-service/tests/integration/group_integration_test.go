@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	userPostgres "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/persistence/postgres"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroups_Integration_MembershipAndFilter(t *testing.T) {
+	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"user_group_members", "user_groups", "users"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	userRepo := integration.SetupUserRepository(db)
+	groupRepo := userPostgres.NewPostgresGroupRepository(db)
+	mockPublisher := new(mocks.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	logger := logger.NewNop()
+
+	ctx := context.Background()
+
+	role, _ := valueobjects.NewRole("student")
+	passwordHash, _ := utils.HashPassword("Password123!")
+	var students []*entities.User
+	for _, name := range []string{"groupone", "grouptwo", "groupthree"} {
+		email, _ := valueobjects.NewEmail(name + "@example.com")
+		student := entities.NewUser(email, name, role, passwordHash)
+		require.NoError(t, userRepo.Create(ctx, student))
+		students = append(students, student)
+	}
+
+	group, err := usecases.NewCreateGroupUseCase(groupRepo, mockPublisher, logger).Execute(ctx, usecases.CreateGroupInput{
+		Name:      "2026 Evening Batch",
+		CreatedBy: students[0].ID,
+	})
+	require.NoError(t, err)
+
+	_, err = usecases.NewCreateGroupUseCase(groupRepo, mockPublisher, logger).Execute(ctx, usecases.CreateGroupInput{
+		Name:      "2026 evening batch",
+		CreatedBy: students[0].ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrGroupNameTaken)
+
+	addMembers := usecases.NewAddGroupMembersUseCase(groupRepo, userRepo, mockPublisher, logger)
+	added, err := addMembers.Execute(ctx, usecases.AddGroupMembersInput{
+		GroupID: group.ID,
+		UserIDs: []string{students[0].ID, students[1].ID},
+	})
+	require.NoError(t, err)
+	assert.Len(t, added.Added, 2)
+
+	added, err = addMembers.Execute(ctx, usecases.AddGroupMembersInput{
+		GroupID: group.ID,
+		UserIDs: []string{students[1].ID},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, added.Added)
+
+	member, err := userRepo.FindByID(ctx, students[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{group.ID}, member.GroupIDs)
+
+	found, err := usecases.NewFindUserUseCase(userRepo, logger).Execute(ctx, usecases.FindUserInput{GroupID: &group.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Total)
+
+	fetched, err := usecases.NewGetGroupUseCase(groupRepo, logger).Execute(ctx, usecases.GetGroupInput{GroupID: group.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetched.MemberCount)
+
+	err = usecases.NewRemoveGroupMemberUseCase(groupRepo, userRepo, mockPublisher, logger).Execute(ctx, usecases.RemoveGroupMemberInput{
+		GroupID: group.ID,
+		UserID:  students[0].ID,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, usecases.NewDeleteGroupUseCase(groupRepo, userRepo, mockPublisher, logger).Execute(ctx, usecases.DeleteGroupInput{GroupID: group.ID}))
+
+	former, err := userRepo.FindByID(ctx, students[1].ID)
+	require.NoError(t, err)
+	assert.Empty(t, former.GroupIDs)
+}
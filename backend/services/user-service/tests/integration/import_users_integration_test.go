@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	userPostgres "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/persistence/postgres"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportUsers_Integration_CannotTakeOverAnotherOrganization(t *testing.T) {
+	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"user_group_members", "user_groups", "users", "organizations"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	userRepo := integration.SetupUserRepository(db)
+	organizationRepo := userPostgres.NewPostgresOrganizationRepository(db)
+	mockPublisher := new(mocks.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedis := new(mocks.MockRedis)
+	mockRedis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	logger := logger.NewNop()
+
+	superAdmin := context.Background()
+	createOrganization := usecases.NewCreateOrganizationUseCase(organizationRepo, logger)
+	riverside, err := createOrganization.Execute(superAdmin, usecases.CreateOrganizationInput{Name: "Riverside High", CreatedBy: "admin"})
+	require.NoError(t, err)
+	hillcrest, err := createOrganization.Execute(superAdmin, usecases.CreateOrganizationInput{Name: "Hillcrest Academy", CreatedBy: "admin"})
+	require.NoError(t, err)
+
+	createUser := usecases.NewCreateUserUseCase(userRepo, organizationRepo, mockPublisher, logger, mockRedis, "http://localhost:3000")
+	hillcrestStudent, err := createUser.Execute(superAdmin, usecases.CreateUserInput{
+		Email:          "hill@example.com",
+		Username:       "hill",
+		Password:       "Password123!",
+		Role:           "student",
+		OrganizationID: &hillcrest.ID,
+	})
+	require.NoError(t, err)
+	platformAdmin, err := createUser.Execute(superAdmin, usecases.CreateUserInput{
+		Email:    "root@example.com",
+		Username: "root",
+		Password: "Password123!",
+		Role:     "admin",
+	})
+	require.NoError(t, err)
+
+	importUsers := usecases.NewImportUsersUseCase(userRepo, mockPublisher, logger, mockRedis, "http://localhost:3000", config.ImportConfig{MaxRows: 10})
+	csv := "email,username,role,password\n" +
+		"hill@example.com,hijacked,admin,Hijacked123!\n" +
+		"root@example.com,root,admin,Hijacked123!\n"
+
+	riversideAdmin := tenant.WithOrganizationID(context.Background(), riverside.ID)
+	output, err := importUsers.Execute(riversideAdmin, usecases.ImportUsersInput{CSV: strings.NewReader(csv)})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, output.Failed)
+	assert.Equal(t, 0, output.Updated)
+	require.Len(t, output.Rows, 2)
+	for _, row := range output.Rows {
+		assert.Equal(t, usecases.ImportActionFailed, row.Action)
+		assert.Equal(t, usecases.ErrEmailAlreadyExists.Error(), row.Error)
+	}
+
+	for _, id := range []string{hillcrestStudent.ID, platformAdmin.ID} {
+		stored, err := userRepo.FindByID(superAdmin, id)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		require.NoError(t, utils.VerifyPassword("Password123!", stored.PasswordHash))
+	}
+	stored, err := userRepo.FindByID(superAdmin, hillcrestStudent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "hill", stored.Username)
+	assert.Equal(t, "student", stored.Role.String())
+	require.NotNil(t, stored.OrganizationID)
+	assert.Equal(t, hillcrest.ID, *stored.OrganizationID)
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	userPostgres "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/persistence/postgres"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_Integration_NoCrossTenantLeakage(t *testing.T) {
+	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"user_group_members", "user_groups", "users", "organizations"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	userRepo := integration.SetupUserRepository(db)
+	organizationRepo := userPostgres.NewPostgresOrganizationRepository(db)
+	groupRepo := userPostgres.NewPostgresGroupRepository(db)
+	mockPublisher := new(mocks.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedis := new(mocks.MockRedis)
+	mockRedis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	logger := logger.NewNop()
+
+	superAdmin := context.Background()
+	createOrganization := usecases.NewCreateOrganizationUseCase(organizationRepo, logger)
+	riverside, err := createOrganization.Execute(superAdmin, usecases.CreateOrganizationInput{Name: "Riverside High", CreatedBy: "admin"})
+	require.NoError(t, err)
+	hillcrest, err := createOrganization.Execute(superAdmin, usecases.CreateOrganizationInput{Name: "Hillcrest Academy", CreatedBy: "admin"})
+	require.NoError(t, err)
+
+	riversideAdmin := tenant.WithOrganizationID(context.Background(), riverside.ID)
+	hillcrestAdmin := tenant.WithOrganizationID(context.Background(), hillcrest.ID)
+
+	createUser := usecases.NewCreateUserUseCase(userRepo, organizationRepo, mockPublisher, logger, mockRedis, "http://localhost:3000")
+	riversideStudent, err := createUser.Execute(riversideAdmin, usecases.CreateUserInput{
+		Email:    "river@example.com",
+		Username: "river",
+		Password: "Password123!",
+		Role:     "student",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, riversideStudent.OrganizationID)
+	assert.Equal(t, riverside.ID, *riversideStudent.OrganizationID)
+
+	// A tenant admin cannot create users in another organization.
+	_, err = createUser.Execute(riversideAdmin, usecases.CreateUserInput{
+		Email:          "sneaky@example.com",
+		Username:       "sneaky",
+		Password:       "Password123!",
+		Role:           "student",
+		OrganizationID: &hillcrest.ID,
+	})
+	require.ErrorIs(t, err, usecases.ErrOrganizationNotFound)
+
+	hillcrestStudent, err := createUser.Execute(superAdmin, usecases.CreateUserInput{
+		Email:          "hill@example.com",
+		Username:       "hill",
+		Password:       "Password123!",
+		Role:           "student",
+		OrganizationID: &hillcrest.ID,
+	})
+	require.NoError(t, err)
+
+	getUser := usecases.NewGetUserUseCase(userRepo, logger)
+	_, err = getUser.Execute(riversideAdmin, usecases.GetUserInput{UserID: hillcrestStudent.ID})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+	_, err = getUser.Execute(hillcrestAdmin, usecases.GetUserInput{UserID: hillcrestStudent.ID})
+	require.NoError(t, err)
+
+	findUser := usecases.NewFindUserUseCase(userRepo, logger)
+	found, err := findUser.Execute(riversideAdmin, usecases.FindUserInput{})
+	require.NoError(t, err)
+	require.Equal(t, 1, found.Total)
+	assert.Equal(t, riversideStudent.ID, found.Users[0].ID)
+
+	// A scoped caller cannot widen the search with the organization filter.
+	found, err = findUser.Execute(riversideAdmin, usecases.FindUserInput{OrganizationID: &hillcrest.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.Total)
+
+	found, err = findUser.Execute(superAdmin, usecases.FindUserInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Total)
+
+	// Groups with the same name may exist in both organizations, but each
+	// organization only sees its own.
+	createGroup := usecases.NewCreateGroupUseCase(groupRepo, mockPublisher, logger)
+	riversideGroup, err := createGroup.Execute(riversideAdmin, usecases.CreateGroupInput{Name: "Evening Batch", CreatedBy: "admin"})
+	require.NoError(t, err)
+	_, err = createGroup.Execute(hillcrestAdmin, usecases.CreateGroupInput{Name: "Evening Batch", CreatedBy: "admin"})
+	require.NoError(t, err)
+
+	_, err = usecases.NewGetGroupUseCase(groupRepo, logger).Execute(hillcrestAdmin, usecases.GetGroupInput{GroupID: riversideGroup.ID})
+	require.ErrorIs(t, err, usecases.ErrGroupNotFound)
+
+	_, err = usecases.NewAddGroupMembersUseCase(groupRepo, userRepo, mockPublisher, logger).Execute(riversideAdmin, usecases.AddGroupMembersInput{
+		GroupID: riversideGroup.ID,
+		UserIDs: []string{hillcrestStudent.ID},
+	})
+	require.ErrorIs(t, err, usecases.ErrGroupMemberNotFound)
+
+	groups, err := usecases.NewFindGroupsUseCase(groupRepo, logger).Execute(riversideAdmin, usecases.FindGroupsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, groups.Total)
+
+	// Tenant admins only see their own organization and cannot move users.
+	organizations, err := usecases.NewFindOrganizationsUseCase(organizationRepo, logger).Execute(riversideAdmin, usecases.FindOrganizationsInput{})
+	require.NoError(t, err)
+	require.Equal(t, 1, organizations.Total)
+	assert.Equal(t, riverside.ID, organizations.Organizations[0].ID)
+
+	_, err = usecases.NewGetOrganizationUseCase(organizationRepo, logger).Execute(riversideAdmin, usecases.GetOrganizationInput{OrganizationID: hillcrest.ID})
+	require.ErrorIs(t, err, usecases.ErrOrganizationNotFound)
+
+	// Writes by id are scoped too, so another organization's user cannot be
+	// deleted or restored.
+	require.NoError(t, userRepo.SoftDelete(riversideAdmin, hillcrestStudent.ID, time.Now()))
+	_, err = getUser.Execute(hillcrestAdmin, usecases.GetUserInput{UserID: hillcrestStudent.ID})
+	require.NoError(t, err)
+	require.NoError(t, userRepo.SoftDelete(hillcrestAdmin, hillcrestStudent.ID, time.Now()))
+	require.NoError(t, userRepo.Restore(riversideAdmin, hillcrestStudent.ID))
+	_, err = getUser.Execute(hillcrestAdmin, usecases.GetUserInput{UserID: hillcrestStudent.ID})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+	require.NoError(t, userRepo.Restore(hillcrestAdmin, hillcrestStudent.ID))
+	require.NoError(t, userRepo.Delete(riversideAdmin, hillcrestStudent.ID))
+	_, err = getUser.Execute(hillcrestAdmin, usecases.GetUserInput{UserID: hillcrestStudent.ID})
+	require.NoError(t, err)
+
+	moveUser := usecases.NewMoveUserToOrganizationUseCase(userRepo, organizationRepo, mockPublisher, logger)
+	_, err = moveUser.Execute(riversideAdmin, usecases.MoveUserToOrganizationInput{UserID: riversideStudent.ID, OrganizationID: riverside.ID})
+	require.ErrorIs(t, err, usecases.ErrSuperAdminRequired)
+
+	moved, err := moveUser.Execute(superAdmin, usecases.MoveUserToOrganizationInput{UserID: hillcrestStudent.ID, OrganizationID: riverside.ID})
+	require.NoError(t, err)
+	assert.Equal(t, riverside.ID, *moved.OrganizationID)
+
+	found, err = findUser.Execute(riversideAdmin, usecases.FindUserInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.Total)
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	userPostgres "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/persistence/postgres"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/integration"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuspendUser_Integration_SuspendAndReinstate(t *testing.T) {
+	db, cleanup, err := integration.SetUpTestDatabase(t, integration.TestDatabaseConfig{
+		MigrationPath:   "migrations",
+		TablesToCleanUp: []string{"user_suspensions", "users"},
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	userRepo := integration.SetupUserRepository(db)
+	suspensionRepo := userPostgres.NewPostgresSuspensionRepository(db)
+	mockPublisher := new(mocks.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	logger := logger.NewNop()
+
+	suspendUserUC := usecases.NewSuspendUserUseCase(userRepo, suspensionRepo, mockPublisher, logger)
+	reinstateUC := usecases.NewReinstateExpiredSuspensionsUseCase(userRepo, suspensionRepo, mockPublisher, logger,
+		config.SuspensionConfig{ReinstateInterval: time.Minute})
+	listSuspensionsUC := usecases.NewListSuspensionsUseCase(suspensionRepo, logger)
+
+	ctx := context.Background()
+
+	email, _ := valueobjects.NewEmail("suspend@example.com")
+	role, _ := valueobjects.NewRole("student")
+	passwordHash, _ := utils.HashPassword("Password123!")
+	user := entities.NewUser(email, "suspenduser", role, passwordHash)
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	expiresAt := time.Now().Add(time.Hour)
+	suspension, err := suspendUserUC.Execute(ctx, usecases.SuspendUserInput{
+		UserID:    user.ID,
+		Reason:    "spam",
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	suspended, err := userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, suspended.Status.IsSuspended())
+
+	_, err = suspendUserUC.Execute(ctx, usecases.SuspendUserInput{UserID: user.ID, Reason: "again"})
+	require.ErrorIs(t, err, usecases.ErrUserAlreadySuspended)
+
+	lifted, err := reinstateUC.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, lifted)
+
+	_, err = db.ExecContext(ctx, `UPDATE user_suspensions SET expires_at = $1 WHERE id = $2`, time.Now().Add(-time.Minute), suspension.ID)
+	require.NoError(t, err)
+
+	lifted, err = reinstateUC.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lifted)
+
+	reinstated, err := userRepo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, reinstated.Status.IsActive())
+
+	history, err := listSuspensionsUC.Execute(ctx, usecases.ListSuspensionsInput{UserID: user.ID})
+	require.NoError(t, err)
+	require.Len(t, history.Suspensions, 1)
+	assert.NotNil(t, history.Suspensions[0].LiftedAt)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) Create(ctx context.Context, group *entities.Group) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) FindByID(ctx context.Context, id string) (*entities.Group, error) {
+	args := m.Called(ctx, id)
+	group, _ := args.Get(0).(*entities.Group)
+	return group, args.Error(1)
+}
+
+func (m *MockGroupRepository) FindByName(ctx context.Context, organizationID *string, name string) (*entities.Group, error) {
+	args := m.Called(ctx, organizationID, name)
+	group, _ := args.Get(0).(*entities.Group)
+	return group, args.Error(1)
+}
+
+func (m *MockGroupRepository) Find(ctx context.Context, query repositories.GroupQuery) (*repositories.GroupQueryResult, error) {
+	args := m.Called(ctx, query)
+	result, _ := args.Get(0).(*repositories.GroupQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockGroupRepository) Update(ctx context.Context, group *entities.Group) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) AddMembers(ctx context.Context, groupID string, userIDs []string) ([]string, error) {
+	args := m.Called(ctx, groupID, userIDs)
+	added, _ := args.Get(0).([]string)
+	return added, args.Error(1)
+}
+
+func (m *MockGroupRepository) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	args := m.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) ListMemberIDs(ctx context.Context, groupID string) ([]string, error) {
+	args := m.Called(ctx, groupID)
+	userIDs, _ := args.Get(0).([]string)
+	return userIDs, args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, organization *entities.Organization) error {
+	args := m.Called(ctx, organization)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) FindByID(ctx context.Context, id string) (*entities.Organization, error) {
+	args := m.Called(ctx, id)
+	organization, _ := args.Get(0).(*entities.Organization)
+	return organization, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindByName(ctx context.Context, name string) (*entities.Organization, error) {
+	args := m.Called(ctx, name)
+	organization, _ := args.Get(0).(*entities.Organization)
+	return organization, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Find(ctx context.Context, query repositories.OrganizationQuery) (*repositories.OrganizationQueryResult, error) {
+	args := m.Called(ctx, query)
+	result, _ := args.Get(0).(*repositories.OrganizationQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) Update(ctx context.Context, organization *entities.Organization) error {
+	args := m.Called(ctx, organization)
+	return args.Error(0)
+}
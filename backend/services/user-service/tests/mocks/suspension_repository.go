@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSuspensionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuspensionRepository) Create(ctx context.Context, suspension *entities.Suspension) error {
+	args := m.Called(ctx, suspension)
+	return args.Error(0)
+}
+
+func (m *MockSuspensionRepository) FindActiveByUserID(ctx context.Context, userID string) (*entities.Suspension, error) {
+	args := m.Called(ctx, userID)
+	suspension, _ := args.Get(0).(*entities.Suspension)
+	return suspension, args.Error(1)
+}
+
+func (m *MockSuspensionRepository) ListByUserID(ctx context.Context, userID string) ([]*entities.Suspension, error) {
+	args := m.Called(ctx, userID)
+	suspensions, _ := args.Get(0).([]*entities.Suspension)
+	return suspensions, args.Error(1)
+}
+
+func (m *MockSuspensionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entities.Suspension, error) {
+	args := m.Called(ctx, now, limit)
+	suspensions, _ := args.Get(0).([]*entities.Suspension)
+	return suspensions, args.Error(1)
+}
+
+func (m *MockSuspensionRepository) Lift(ctx context.Context, suspension *entities.Suspension) error {
+	args := m.Called(ctx, suspension)
+	return args.Error(0)
+}
@@ -21,7 +21,7 @@ func TestCreateUser_InvalidEmail(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
-	uc := usecases.NewCreateUserUseCase(repo, publisher, logger, redis, "http://localhost:3000")
+	uc := usecases.NewCreateUserUseCase(repo, nil, publisher, logger, redis, "http://localhost:3000")
 
 	_, err := uc.Execute(context.Background(), usecases.CreateUserInput{
 		Email:    "not-an-email",
@@ -41,7 +41,7 @@ func TestCreateUser_InvalidRole(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
-	uc := usecases.NewCreateUserUseCase(repo, publisher, logger, redis, "http://localhost:3000")
+	uc := usecases.NewCreateUserUseCase(repo, nil, publisher, logger, redis, "http://localhost:3000")
 
 	_, err := uc.Execute(context.Background(), usecases.CreateUserInput{
 		Email:    "user@example.com",
@@ -66,7 +66,7 @@ func TestCreateUser_EmailAlreadyExists(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
-	uc := usecases.NewCreateUserUseCase(repo, publisher, logger, redis, "http://localhost:3000")
+	uc := usecases.NewCreateUserUseCase(repo, nil, publisher, logger, redis, "http://localhost:3000")
 
 	_, err := uc.Execute(context.Background(), usecases.CreateUserInput{
 		Email:    "existing@example.com",
@@ -92,7 +92,7 @@ func TestCreateUser_UsernameAlreadyExists(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 	logger := logger.NewNop()
 	redis := new(mocks.MockRedis)
-	uc := usecases.NewCreateUserUseCase(repo, publisher, logger, redis, "http://localhost:3000")
+	uc := usecases.NewCreateUserUseCase(repo, nil, publisher, logger, redis, "http://localhost:3000")
 
 	_, err := uc.Execute(context.Background(), usecases.CreateUserInput{
 		Email:    "new@example.com",
@@ -130,7 +130,7 @@ func TestCreateUser_Success(t *testing.T) {
 	redis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeUserCreated, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewCreateUserUseCase(repo, publisher, logger, redis, apiGatewayURL)
+	uc := usecases.NewCreateUserUseCase(repo, nil, publisher, logger, redis, apiGatewayURL)
 
 	dto, err := uc.Execute(context.Background(), input)
 	require.NoError(t, err)
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
@@ -17,13 +18,18 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var testDeletionConfig = config.DeletionConfig{
+	RestoreWindow: 30 * 24 * time.Hour,
+	PurgeInterval: time.Hour,
+}
+
 func TestDeleteUser_FindByIDError(t *testing.T) {
 	expectedErr := errors.New("db error")
 	repo := new(mocks.MockUserRepository)
 	publisher := new(mocks.MockPublisher)
 	repo.On("FindByID", mock.Anything, "user-1").Return((*entities.User)(nil), expectedErr).Once()
 
-	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop())
+	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
 	_, err := uc.Execute(context.Background(), usecases.DeleteUserInput{UserID: "user-1"})
 	require.ErrorIs(t, err, expectedErr)
 
@@ -40,7 +46,7 @@ func TestDeleteUser_AdminForbidden(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
 
-	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop())
+	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
 	_, err := uc.Execute(context.Background(), usecases.DeleteUserInput{UserID: user.ID})
 	require.ErrorIs(t, err, usecases.ErrCannotDeleteAdminUser)
 
@@ -58,21 +64,55 @@ func TestDeleteUser_Success(t *testing.T) {
 	publisher := new(mocks.MockPublisher)
 
 	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
-	repo.On("Delete", mock.Anything, user.ID).Return(nil).Once()
+	repo.On("SoftDelete", mock.Anything, user.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
 	publisher.On("Publish", mock.Anything, events.EventTypeUserDeleted, mock.Anything).Return(nil).Once()
 
-	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop())
+	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
 
 	out, err := uc.Execute(context.Background(), usecases.DeleteUserInput{UserID: user.ID})
 	require.NoError(t, err)
 	require.NotNil(t, out)
 	require.NotEmpty(t, out.Message)
+	require.NotNil(t, out.RestoreUntil)
 
 	require.Len(t, publisher.Calls, 1)
 	event, ok := publisher.Calls[0].Arguments.Get(2).(events.UserDeletedEvent)
 	require.True(t, ok)
 	assert.Equal(t, user.ID, event.ID)
 	assert.WithinDuration(t, time.Now(), event.DeletedAt, 2*time.Second)
+	assert.Equal(t, event.DeletedAt.Add(testDeletionConfig.RestoreWindow), event.RestoreUntil)
+	assert.Equal(t, event.RestoreUntil, *out.RestoreUntil)
+
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestDeleteUser_PermanentPurgesDeletedUser(t *testing.T) {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("instructor")
+	user := entities.NewUser(emailVO, "teacher", roleVO, "hash")
+	deletedAt := time.Now().UTC().Add(-time.Hour)
+	user.DeletedAt = &deletedAt
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+
+	repo.On("FindByID", mock.Anything, user.ID).Return((*entities.User)(nil), errors.New("user not found")).Once()
+	repo.On("FindDeletedByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("Delete", mock.Anything, user.ID).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserPurged, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewDeleteUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+
+	out, err := uc.Execute(context.Background(), usecases.DeleteUserInput{UserID: user.ID, Permanent: true})
+	require.NoError(t, err)
+	assert.Nil(t, out.RestoreUntil)
+
+	event, ok := publisher.Calls[0].Arguments.Get(2).(events.UserPurgedEvent)
+	require.True(t, ok)
+	assert.Equal(t, user.ID, event.ID)
+	assert.Equal(t, "instructor", event.Role)
 
 	repo.AssertExpectations(t)
 	publisher.AssertExpectations(t)
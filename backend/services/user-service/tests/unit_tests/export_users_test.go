@@ -0,0 +1,66 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newUserPage(start, count int) []*entities.User {
+	users := make([]*entities.User, 0, count)
+	for i := start; i < start+count; i++ {
+		users = append(users, newExistingUser(fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("user%d", i), "student"))
+	}
+	return users
+}
+
+func offsetIs(offset int) interface{} {
+	return mock.MatchedBy(func(q repositories.UserQuery) bool {
+		return q.Offset != nil && *q.Offset == offset
+	})
+}
+
+func TestExportUsers_StreamsEveryPage(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	role := valueobjects.RoleStudent
+
+	repo.On("Find", mock.Anything, mock.MatchedBy(func(q repositories.UserQuery) bool {
+		return *q.Offset == 0 && *q.Role == role && *q.SortColumn == "created_at" && *q.Limit == 500
+	})).Return(&repositories.UserQueryResult{Users: newUserPage(0, 500), Total: 501}, nil).Once()
+	repo.On("Find", mock.Anything, offsetIs(500)).Return(&repositories.UserQueryResult{Users: newUserPage(500, 1), Total: 501}, nil).Once()
+
+	var out strings.Builder
+	err := usecases.NewExportUsersUseCase(repo, logger.NewNop()).Execute(context.Background(), usecases.ExportUsersInput{Role: &role}, &out)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 502)
+	assert.Equal(t, usecases.ExportColumns, records[0])
+	assert.Equal(t, "user0@example.com", records[1][1])
+	assert.Equal(t, "user500", records[501][2])
+	repo.AssertExpectations(t)
+}
+
+func TestExportUsers_FirstPageErrorWritesNothing(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	repo.On("Find", mock.Anything, offsetIs(0)).Return(nil, errors.New("db down")).Once()
+
+	var out strings.Builder
+	err := usecases.NewExportUsersUseCase(repo, logger.NewNop()).Execute(context.Background(), usecases.ExportUsersInput{}, &out)
+	require.Error(t, err)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,193 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	userEntities "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userMocks "github.com/paingphyoaungkhant/asto-microservice/services/user-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGroup(t *testing.T, name string) *userEntities.Group {
+	t.Helper()
+	group, err := userEntities.NewGroup(name, "", "admin-1")
+	require.NoError(t, err)
+	return group
+}
+
+func TestCreateGroup_Success(t *testing.T) {
+	groupRepo := new(userMocks.MockGroupRepository)
+	publisher := new(mocks.MockPublisher)
+	groupRepo.On("FindByName", mock.Anything, mock.Anything, "2026 Evening Batch").Return(nil, nil).Once()
+	groupRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Group")).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeGroupCreated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewCreateGroupUseCase(groupRepo, publisher, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.CreateGroupInput{
+		Name:        "  2026 Evening Batch ",
+		Description: "Evening programme",
+		CreatedBy:   "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2026 Evening Batch", out.Name)
+	assert.Equal(t, "admin-1", out.CreatedBy)
+
+	groupRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestCreateGroup_NameTaken(t *testing.T) {
+	groupRepo := new(userMocks.MockGroupRepository)
+	groupRepo.On("FindByName", mock.Anything, mock.Anything, "Cohort A").Return(newTestGroup(t, "cohort a"), nil).Once()
+
+	uc := usecases.NewCreateGroupUseCase(groupRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.CreateGroupInput{Name: "Cohort A", CreatedBy: "admin-1"})
+	require.ErrorIs(t, err, usecases.ErrGroupNameTaken)
+
+	groupRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateGroup_NameRequired(t *testing.T) {
+	groupRepo := new(userMocks.MockGroupRepository)
+
+	uc := usecases.NewCreateGroupUseCase(groupRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.CreateGroupInput{Name: "  ", CreatedBy: "admin-1"})
+	require.ErrorIs(t, err, userEntities.ErrGroupNameRequired)
+}
+
+func TestUpdateGroup_KeepsOwnName(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	groupRepo.On("FindByName", mock.Anything, mock.Anything, "Cohort A").Return(group, nil).Once()
+	groupRepo.On("Update", mock.Anything, group).Return(nil).Once()
+
+	description := "Morning students"
+	uc := usecases.NewUpdateGroupUseCase(groupRepo, nil, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.UpdateGroupInput{GroupID: group.ID, Description: &description})
+	require.NoError(t, err)
+	assert.Equal(t, "Cohort A", out.Name)
+	assert.Equal(t, description, out.Description)
+
+	groupRepo.AssertExpectations(t)
+}
+
+func TestAddGroupMembers_AddsStudents(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+	first := newTestUser(t, "first@example.com", "student")
+	second := newTestUser(t, "second@example.com", "student")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	repo.On("FindByID", mock.Anything, first.ID).Return(first, nil)
+	repo.On("FindByID", mock.Anything, second.ID).Return(second, nil)
+	groupRepo.On("AddMembers", mock.Anything, group.ID, []string{first.ID, second.ID}).Return([]string{second.ID}, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeGroupMembersAdded, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewAddGroupMembersUseCase(groupRepo, repo, publisher, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.AddGroupMembersInput{
+		GroupID: group.ID,
+		UserIDs: []string{first.ID, second.ID, first.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.ID}, out.Added)
+
+	event, ok := publisher.Calls[0].Arguments.Get(2).(events.GroupMembersAddedEvent)
+	require.True(t, ok)
+	assert.Equal(t, group.ID, event.GroupID)
+	assert.Equal(t, []string{second.ID}, event.UserIDs)
+
+	groupRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestAddGroupMembers_RejectsNonStudents(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+	student := newTestUser(t, "student@example.com", "student")
+	instructor := newTestUser(t, "instructor@example.com", "instructor")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	repo := new(mocks.MockUserRepository)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	repo.On("FindByID", mock.Anything, student.ID).Return(student, nil).Once()
+	repo.On("FindByID", mock.Anything, instructor.ID).Return(instructor, nil).Once()
+
+	uc := usecases.NewAddGroupMembersUseCase(groupRepo, repo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.AddGroupMembersInput{
+		GroupID: group.ID,
+		UserIDs: []string{student.ID, instructor.ID},
+	})
+	require.ErrorIs(t, err, usecases.ErrGroupMemberNotStudent)
+	assert.Contains(t, err.Error(), instructor.ID)
+
+	groupRepo.AssertNotCalled(t, "AddMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddGroupMembers_RejectsMissingUsers(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	repo := new(mocks.MockUserRepository)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	repo.On("FindByID", mock.Anything, "missing").Return((*entities.User)(nil), errors.New("user not found")).Once()
+
+	uc := usecases.NewAddGroupMembersUseCase(groupRepo, repo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.AddGroupMembersInput{GroupID: group.ID, UserIDs: []string{"missing"}})
+	require.ErrorIs(t, err, usecases.ErrGroupMemberNotFound)
+
+	groupRepo.AssertNotCalled(t, "AddMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveGroupMember_NotMember(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	groupRepo.On("RemoveMember", mock.Anything, group.ID, "user-1").Return(false, nil).Once()
+
+	uc := usecases.NewRemoveGroupMemberUseCase(groupRepo, new(mocks.MockUserRepository), nil, logger.NewNop())
+	err := uc.Execute(context.Background(), usecases.RemoveGroupMemberInput{GroupID: group.ID, UserID: "user-1"})
+	require.ErrorIs(t, err, usecases.ErrNotGroupMember)
+
+	groupRepo.AssertExpectations(t)
+}
+
+func TestDeleteGroup_PublishesMembersUpdated(t *testing.T) {
+	group := newTestGroup(t, "Cohort A")
+	member := newTestUser(t, "member@example.com", "student")
+
+	groupRepo := new(userMocks.MockGroupRepository)
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	groupRepo.On("FindByID", mock.Anything, group.ID).Return(group, nil).Once()
+	groupRepo.On("ListMemberIDs", mock.Anything, group.ID).Return([]string{member.ID}, nil).Once()
+	groupRepo.On("Delete", mock.Anything, group.ID).Return(nil).Once()
+	repo.On("FindByID", mock.Anything, member.ID).Return(member, nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeGroupDeleted, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewDeleteGroupUseCase(groupRepo, repo, publisher, logger.NewNop())
+	require.NoError(t, uc.Execute(context.Background(), usecases.DeleteGroupInput{GroupID: group.ID}))
+
+	event, ok := publisher.Calls[1].Arguments.Get(2).(events.UserUpdatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, member.ID, event.ID)
+	assert.NotNil(t, event.GroupIDs)
+
+	groupRepo.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
@@ -0,0 +1,226 @@
+package unit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testImportConfig = config.ImportConfig{
+	MaxRows:             3,
+	SetPasswordURL:      "http://localhost:3000/set-password",
+	SetPasswordTokenTTL: 7 * 24 * time.Hour,
+}
+
+func newImportUsersUseCase(repo *mocks.MockUserRepository, publisher *mocks.MockPublisher, redis *mocks.MockRedis) *usecases.ImportUsersUseCase {
+	return usecases.NewImportUsersUseCase(repo, publisher, logger.NewNop(), redis, "http://localhost:3000", testImportConfig)
+}
+
+func newExistingUser(email, username, role string) *entities.User {
+	emailVO, _ := valueobjects.NewEmail(email)
+	roleVO, _ := valueobjects.NewRole(role)
+	return entities.NewUser(emailVO, username, roleVO, "hash")
+}
+
+func TestImportUsers_CreatesAndUpdates(t *testing.T) {
+	existing := newExistingUser("old@example.com", "olduser", "student")
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return((*entities.User)(nil), nil).Once()
+	repo.On("FindByUsername", mock.Anything, "newuser").Return((*entities.User)(nil), nil).Once()
+	repo.On("FindByEmail", mock.Anything, "old@example.com").Return(existing, nil).Once()
+	repo.On("FindByUsername", mock.Anything, "renamed").Return((*entities.User)(nil), nil).Once()
+
+	var created *entities.User
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		created = u
+		return true
+	})).Return(nil).Once()
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.ID == existing.ID && u.Username == "renamed" && u.Role == valueobjects.RoleInstructor
+	})).Return(nil).Once()
+	redis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserCreated, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Once()
+
+	csv := "email,username,role,password\n" +
+		"new@example.com,newuser,student,Secret123!\n" +
+		"old@example.com,renamed,Instructor,\n"
+
+	output, err := newImportUsersUseCase(repo, publisher, redis).Execute(context.Background(), usecases.ImportUsersInput{CSV: strings.NewReader(csv)})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, output.Created)
+	assert.Equal(t, 1, output.Updated)
+	assert.Equal(t, 0, output.Failed)
+	require.Len(t, output.Rows, 2)
+	assert.Equal(t, usecases.ImportActionCreated, output.Rows[0].Action)
+	assert.Equal(t, 2, output.Rows[0].Row)
+	assert.Equal(t, created.ID, output.Rows[0].UserID)
+	assert.Empty(t, output.Rows[0].SetPasswordURL)
+	assert.Equal(t, usecases.ImportActionUpdated, output.Rows[1].Action)
+	assert.Equal(t, existing.ID, output.Rows[1].UserID)
+	require.NoError(t, utils.VerifyPassword("Secret123!", created.PasswordHash))
+
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	redis.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestImportUsers_WithoutPasswordSendsSetPasswordLink(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return((*entities.User)(nil), nil).Once()
+	repo.On("FindByUsername", mock.Anything, "newuser").Return((*entities.User)(nil), nil).Once()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	redis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	var token string
+	redis.On("StorePasswordSetupToken", mock.Anything, mock.Anything, mock.Anything, testImportConfig.SetPasswordTokenTTL).Run(func(args mock.Arguments) {
+		token = args.String(2)
+	}).Return(nil).Once()
+
+	var sent events.UserCreatedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeUserCreated, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(2).(events.UserCreatedEvent)
+	}).Return(nil).Once()
+
+	output, err := newImportUsersUseCase(repo, publisher, redis).Execute(context.Background(), usecases.ImportUsersInput{
+		CSV: strings.NewReader("email,username,role\nnew@example.com,newuser,student\n"),
+	})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, token)
+	setPasswordURL := testImportConfig.SetPasswordURL + "?token=" + token
+	assert.Equal(t, setPasswordURL, sent.SetPasswordURL)
+	assert.Equal(t, setPasswordURL, output.Rows[0].SetPasswordURL)
+	redis.AssertExpectations(t)
+}
+
+func TestImportUsers_DryRunReportsWithoutWriting(t *testing.T) {
+	existing := newExistingUser("old@example.com", "olduser", "student")
+	taken := newExistingUser("other@example.com", "taken", "student")
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return((*entities.User)(nil), nil)
+	repo.On("FindByUsername", mock.Anything, "newuser").Return((*entities.User)(nil), nil)
+	repo.On("FindByEmail", mock.Anything, "old@example.com").Return(existing, nil)
+	repo.On("FindByUsername", mock.Anything, "olduser").Return(existing, nil)
+	repo.On("FindByEmail", mock.Anything, "clash@example.com").Return((*entities.User)(nil), nil)
+	repo.On("FindByUsername", mock.Anything, "taken").Return(taken, nil)
+
+	csv := "Email,Username,Role,Password,Status\n" +
+		"new@example.com,newuser,student,,active\n" +
+		"old@example.com,olduser,student,,active\n" +
+		"clash@example.com,taken,student,,active\n" +
+		"NEW@example.com,another,student,,active\n" +
+		"bad-email,bad,student,,active\n" +
+		"x@example.com,x,superuser,,active\n" +
+		"y@example.com,y,student,short,active\n"
+
+	uc := usecases.NewImportUsersUseCase(repo, publisher, logger.NewNop(), redis, "http://localhost:3000", config.ImportConfig{MaxRows: 10})
+	output, err := uc.Execute(context.Background(), usecases.ImportUsersInput{CSV: strings.NewReader(csv), DryRun: true})
+	require.NoError(t, err)
+
+	assert.True(t, output.DryRun)
+	assert.Equal(t, 1, output.Created)
+	assert.Equal(t, 1, output.Unchanged)
+	assert.Equal(t, 5, output.Failed)
+
+	actions := make([]string, 0, len(output.Rows))
+	for _, row := range output.Rows {
+		actions = append(actions, row.Action)
+	}
+	assert.Equal(t, []string{
+		usecases.ImportActionCreated,
+		usecases.ImportActionUnchanged,
+		usecases.ImportActionFailed,
+		usecases.ImportActionFailed,
+		usecases.ImportActionFailed,
+		usecases.ImportActionFailed,
+		usecases.ImportActionFailed,
+	}, actions)
+	assert.Equal(t, usecases.ErrUsernameAlreadyExists.Error(), output.Rows[2].Error)
+	assert.Equal(t, usecases.ErrDuplicateEmailInFile.Error(), output.Rows[3].Error)
+	assert.Contains(t, output.Rows[4].Error, "invalid email")
+	assert.Contains(t, output.Rows[5].Error, "invalid role")
+	assert.Contains(t, output.Rows[6].Error, "invalid password")
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportUsers_RejectsFile(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		err  error
+	}{
+		{"empty", "", usecases.ErrCSVEmpty},
+		{"header only", "email,username,role\n", usecases.ErrCSVEmpty},
+		{"missing column", "email,username\na@example.com,a\n", usecases.ErrCSVMissingColumn},
+		{"malformed", "email,username,role\n\"a@example.com,a,student\n", usecases.ErrInvalidCSV},
+		{"too many rows", "email,username,role\na@x.com,a,student\nb@x.com,b,student\nc@x.com,c,student\nd@x.com,d,student\n", usecases.ErrTooManyRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockUserRepository)
+
+			_, err := newImportUsersUseCase(repo, new(mocks.MockPublisher), new(mocks.MockRedis)).Execute(context.Background(), usecases.ImportUsersInput{
+				CSV: strings.NewReader(tt.csv),
+			})
+			require.ErrorIs(t, err, tt.err)
+
+			repo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestImportUsers_RefusesUserOutsideCallerOrganization(t *testing.T) {
+	otherOrganization := "22222222-2222-2222-2222-222222222222"
+	existing := newExistingUser("other@example.com", "other", "student")
+	existing.OrganizationID = &otherOrganization
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	redis := new(mocks.MockRedis)
+	repo.On("FindByEmail", mock.Anything, "other@example.com").Return(existing, nil).Once()
+
+	csv := "email,username,role,password\n" +
+		"other@example.com,other,admin,Secret123!\n"
+
+	ctx := tenant.WithOrganizationID(context.Background(), "11111111-1111-1111-1111-111111111111")
+	output, err := newImportUsersUseCase(repo, publisher, redis).Execute(ctx, usecases.ImportUsersInput{CSV: strings.NewReader(csv)})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, output.Failed)
+	require.Len(t, output.Rows, 1)
+	assert.Equal(t, usecases.ImportActionFailed, output.Rows[0].Action)
+	assert.Equal(t, usecases.ErrEmailAlreadyExists.Error(), output.Rows[0].Error)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
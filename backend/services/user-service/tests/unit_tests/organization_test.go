@@ -0,0 +1,168 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	userEntities "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	userMocks "github.com/paingphyoaungkhant/asto-microservice/services/user-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrganization(t *testing.T, name string) *userEntities.Organization {
+	t.Helper()
+	organization, err := userEntities.NewOrganization(name, "admin-1")
+	require.NoError(t, err)
+	return organization
+}
+
+func TestCreateOrganization_Success(t *testing.T) {
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	organizationRepo.On("FindByName", mock.Anything, "Riverside High").Return(nil, nil).Once()
+	organizationRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Organization")).Return(nil).Once()
+
+	uc := usecases.NewCreateOrganizationUseCase(organizationRepo, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.CreateOrganizationInput{Name: " Riverside High ", CreatedBy: "admin-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Riverside High", out.Name)
+	assert.Equal(t, "admin-1", out.CreatedBy)
+
+	organizationRepo.AssertExpectations(t)
+}
+
+func TestCreateOrganization_NameTaken(t *testing.T) {
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	organizationRepo.On("FindByName", mock.Anything, "Riverside High").Return(newTestOrganization(t, "riverside high"), nil).Once()
+
+	uc := usecases.NewCreateOrganizationUseCase(organizationRepo, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.CreateOrganizationInput{Name: "Riverside High", CreatedBy: "admin-1"})
+	require.ErrorIs(t, err, usecases.ErrOrganizationNameTaken)
+
+	organizationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrganization_TenantAdminRefused(t *testing.T) {
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	ctx := tenant.WithOrganizationID(context.Background(), "org-1")
+
+	uc := usecases.NewCreateOrganizationUseCase(organizationRepo, logger.NewNop())
+	_, err := uc.Execute(ctx, usecases.CreateOrganizationInput{Name: "Riverside High", CreatedBy: "admin-1"})
+	require.ErrorIs(t, err, usecases.ErrSuperAdminRequired)
+
+	organizationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrganization_TenantAdminRefused(t *testing.T) {
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	ctx := tenant.WithOrganizationID(context.Background(), "org-1")
+
+	uc := usecases.NewUpdateOrganizationUseCase(organizationRepo, logger.NewNop())
+	_, err := uc.Execute(ctx, usecases.UpdateOrganizationInput{OrganizationID: "org-1", Name: "Renamed"})
+	require.ErrorIs(t, err, usecases.ErrSuperAdminRequired)
+
+	organizationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestGetOrganization_NotFound(t *testing.T) {
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	organizationRepo.On("FindByID", mock.Anything, "org-2").Return(nil, nil).Once()
+
+	uc := usecases.NewGetOrganizationUseCase(organizationRepo, logger.NewNop())
+	_, err := uc.Execute(tenant.WithOrganizationID(context.Background(), "org-1"), usecases.GetOrganizationInput{OrganizationID: "org-2"})
+	require.ErrorIs(t, err, usecases.ErrOrganizationNotFound)
+}
+
+func TestMoveUserToOrganization_Success(t *testing.T) {
+	organization := newTestOrganization(t, "Riverside High")
+	emailVO, _ := valueobjects.NewEmail("student@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "student", roleVO, "hash")
+
+	userRepo := new(mocks.MockUserRepository)
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	publisher := new(mocks.MockPublisher)
+	organizationRepo.On("FindByID", mock.Anything, organization.ID).Return(organization, nil).Once()
+	userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	userRepo.On("Update", mock.Anything, user).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.MatchedBy(func(event events.UserUpdatedEvent) bool {
+		return event.OrganizationID != nil && *event.OrganizationID == organization.ID
+	})).Return(nil).Once()
+
+	uc := usecases.NewMoveUserToOrganizationUseCase(userRepo, organizationRepo, publisher, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.MoveUserToOrganizationInput{UserID: user.ID, OrganizationID: organization.ID})
+	require.NoError(t, err)
+	require.NotNil(t, out.OrganizationID)
+	assert.Equal(t, organization.ID, *out.OrganizationID)
+
+	userRepo.AssertExpectations(t)
+	organizationRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestMoveUserToOrganization_TenantAdminRefused(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	ctx := tenant.WithOrganizationID(context.Background(), "org-1")
+
+	uc := usecases.NewMoveUserToOrganizationUseCase(userRepo, organizationRepo, nil, logger.NewNop())
+	_, err := uc.Execute(ctx, usecases.MoveUserToOrganizationInput{UserID: "user-1", OrganizationID: "org-1"})
+	require.ErrorIs(t, err, usecases.ErrSuperAdminRequired)
+
+	userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateUser_TenantAdminCreatesInOwnOrganization(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	redis := new(mocks.MockRedis)
+	ctx := tenant.WithOrganizationID(context.Background(), "org-1")
+
+	var createdUser *entities.User
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return((*entities.User)(nil), nil).Once()
+	repo.On("FindByUsername", mock.Anything, "newuser").Return((*entities.User)(nil), nil).Once()
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		createdUser = u
+		return true
+	})).Return(nil).Once()
+	redis.On("StoreVerifyEmailToken", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewCreateUserUseCase(repo, nil, nil, logger.NewNop(), redis, "http://localhost:3000")
+	_, err := uc.Execute(ctx, usecases.CreateUserInput{
+		Email:    "new@example.com",
+		Username: "newuser",
+		Password: "Secret123!",
+		Role:     "student",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, createdUser.OrganizationID)
+	assert.Equal(t, "org-1", *createdUser.OrganizationID)
+}
+
+func TestCreateUser_OrganizationNotFound(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	organizationRepo := new(userMocks.MockOrganizationRepository)
+	organizationID := "org-2"
+	repo.On("FindByEmail", mock.Anything, "new@example.com").Return((*entities.User)(nil), nil).Once()
+	repo.On("FindByUsername", mock.Anything, "newuser").Return((*entities.User)(nil), nil).Once()
+	organizationRepo.On("FindByID", mock.Anything, organizationID).Return(nil, nil).Once()
+
+	uc := usecases.NewCreateUserUseCase(repo, organizationRepo, nil, logger.NewNop(), nil, "http://localhost:3000")
+	_, err := uc.Execute(context.Background(), usecases.CreateUserInput{
+		Email:          "new@example.com",
+		Username:       "newuser",
+		Password:       "Secret123!",
+		Role:           "student",
+		OrganizationID: &organizationID,
+	})
+	require.ErrorIs(t, err, usecases.ErrOrganizationNotFound)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
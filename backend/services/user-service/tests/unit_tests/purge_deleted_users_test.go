@@ -0,0 +1,58 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeletedUsers_PurgesExpiredUsers(t *testing.T) {
+	first := newDeletedUser(testDeletionConfig.RestoreWindow + time.Hour)
+	second := newDeletedUser(testDeletionConfig.RestoreWindow + 2*time.Hour)
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	cutoff := mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-testDeletionConfig.RestoreWindow + time.Second))
+	})
+	repo.On("FindDeletedBefore", mock.Anything, cutoff, 100).Return([]*entities.User{first, second}, nil).Once()
+	repo.On("Delete", mock.Anything, first.ID).Return(nil).Once()
+	repo.On("Delete", mock.Anything, second.ID).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserPurged, mock.Anything).Return(nil).Twice()
+
+	uc := usecases.NewPurgeDeletedUsersUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	purged, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestPurgeDeletedUsers_StopsOnError(t *testing.T) {
+	user := newDeletedUser(testDeletionConfig.RestoreWindow + time.Hour)
+	expectedErr := errors.New("db error")
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindDeletedBefore", mock.Anything, mock.Anything, 100).Return([]*entities.User{user}, nil).Once()
+	repo.On("Delete", mock.Anything, user.ID).Return(expectedErr).Once()
+
+	uc := usecases.NewPurgeDeletedUsersUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	purged, err := uc.Execute(context.Background())
+	require.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, 0, purged)
+
+	publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeletedUser(deletedAgo time.Duration) *entities.User {
+	emailVO, _ := valueobjects.NewEmail("user@example.com")
+	roleVO, _ := valueobjects.NewRole("student")
+	user := entities.NewUser(emailVO, "student", roleVO, "hash")
+	deletedAt := time.Now().UTC().Add(-deletedAgo)
+	user.DeletedAt = &deletedAt
+	return user
+}
+
+func TestRestoreUser_Success(t *testing.T) {
+	user := newDeletedUser(24 * time.Hour)
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindDeletedByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return((*entities.User)(nil), errors.New("user not found")).Once()
+	repo.On("FindByUsername", mock.Anything, "student").Return((*entities.User)(nil), errors.New("user not found")).Once()
+	repo.On("Restore", mock.Anything, user.ID).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserRestored, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewRestoreUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	out, err := uc.Execute(context.Background(), usecases.RestoreUserInput{UserID: user.ID})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, out.ID)
+	assert.Nil(t, out.DeletedAt)
+
+	event, ok := publisher.Calls[0].Arguments.Get(2).(events.UserRestoredEvent)
+	require.True(t, ok)
+	assert.Equal(t, user.ID, event.ID)
+
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestRestoreUser_NotDeleted(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindDeletedByID", mock.Anything, "user-1").Return((*entities.User)(nil), errors.New("user not found")).Once()
+
+	uc := usecases.NewRestoreUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	_, err := uc.Execute(context.Background(), usecases.RestoreUserInput{UserID: "user-1"})
+	require.ErrorIs(t, err, usecases.ErrUserNotFound)
+
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestRestoreUser_WindowExpired(t *testing.T) {
+	user := newDeletedUser(testDeletionConfig.RestoreWindow + time.Hour)
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindDeletedByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewRestoreUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	_, err := uc.Execute(context.Background(), usecases.RestoreUserInput{UserID: user.ID})
+	require.ErrorIs(t, err, usecases.ErrRestoreWindowExpired)
+
+	repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestRestoreUser_EmailTaken(t *testing.T) {
+	user := newDeletedUser(time.Hour)
+	other := newDeletedUser(0)
+	other.DeletedAt = nil
+
+	repo := new(mocks.MockUserRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindDeletedByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("FindByEmail", mock.Anything, "user@example.com").Return(other, nil).Once()
+
+	uc := usecases.NewRestoreUserUseCase(repo, publisher, logger.NewNop(), testDeletionConfig)
+	_, err := uc.Execute(context.Background(), usecases.RestoreUserInput{UserID: user.ID})
+	require.ErrorIs(t, err, usecases.ErrEmailAlreadyExists)
+
+	repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
@@ -0,0 +1,235 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/application/usecases"
+	userEntities "github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/services/user-service/internal/infrastructure/config"
+	userMocks "github.com/paingphyoaungkhant/asto-microservice/services/user-service/tests/mocks"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/events"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSuspensionConfig = config.SuspensionConfig{ReinstateInterval: time.Minute}
+
+func newSuspendedUser(t *testing.T) (*entities.User, *userEntities.Suspension) {
+	t.Helper()
+	user := newTestUser(t, "user@example.com", "student")
+	require.NoError(t, user.Suspend())
+	expiresAt := time.Now().UTC().Add(-time.Minute)
+	return user, userEntities.NewSuspension(user.ID, "spam", "admin-1", &expiresAt)
+}
+
+func TestSuspendUser_Success(t *testing.T) {
+	user := newTestUser(t, "user@example.com", "student")
+	expiresAt := time.Now().Add(72 * time.Hour)
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	publisher := new(mocks.MockPublisher)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, user.ID).Return(nil, nil).Once()
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Status.IsSuspended()
+	})).Return(nil).Once()
+	suspensionRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Suspension")).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserSuspended, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewSuspendUserUseCase(repo, suspensionRepo, publisher, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.SuspendUserInput{
+		UserID:      user.ID,
+		Reason:      "  Repeated spam  ",
+		SuspendedBy: "admin-1",
+		ExpiresAt:   &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, out.UserID)
+	assert.Equal(t, "Repeated spam", out.Reason)
+	assert.Equal(t, "admin-1", out.SuspendedBy)
+	require.NotNil(t, out.ExpiresAt)
+	assert.True(t, out.ExpiresAt.Equal(expiresAt))
+
+	event, ok := publisher.Calls[1].Arguments.Get(2).(events.UserSuspendedEvent)
+	require.True(t, ok)
+	assert.Equal(t, user.ID, event.ID)
+	assert.Equal(t, out.ID, event.SuspensionID)
+	assert.Equal(t, "Repeated spam", event.Reason)
+
+	repo.AssertExpectations(t)
+	suspensionRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestSuspendUser_Validation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name  string
+		input usecases.SuspendUserInput
+		want  error
+	}{
+		{"missing reason", usecases.SuspendUserInput{UserID: "user-1", Reason: "   "}, usecases.ErrSuspensionReasonRequired},
+		{"expiry in past", usecases.SuspendUserInput{UserID: "user-1", Reason: "spam", ExpiresAt: &past}, usecases.ErrSuspensionExpiryInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockUserRepository)
+			suspensionRepo := new(userMocks.MockSuspensionRepository)
+
+			uc := usecases.NewSuspendUserUseCase(repo, suspensionRepo, nil, logger.NewNop())
+			_, err := uc.Execute(context.Background(), tt.input)
+			require.ErrorIs(t, err, tt.want)
+
+			repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSuspendUser_AlreadySuspended(t *testing.T) {
+	user, suspension := newSuspendedUser(t)
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, user.ID).Return(suspension, nil).Once()
+
+	uc := usecases.NewSuspendUserUseCase(repo, suspensionRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.SuspendUserInput{UserID: user.ID, Reason: "spam"})
+	require.ErrorIs(t, err, usecases.ErrUserAlreadySuspended)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	suspensionRepo.AssertExpectations(t)
+}
+
+func TestSuspendUser_CannotSuspendAdmin(t *testing.T) {
+	user := newTestUser(t, "admin@example.com", "admin")
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, user.ID).Return(nil, nil).Once()
+
+	uc := usecases.NewSuspendUserUseCase(repo, suspensionRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.SuspendUserInput{UserID: user.ID, Reason: "spam"})
+	require.ErrorIs(t, err, entities.ErrCannotSuspendAdmin)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	suspensionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLiftSuspension_ReactivatesUser(t *testing.T) {
+	user, suspension := newSuspendedUser(t)
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	publisher := new(mocks.MockPublisher)
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, user.ID).Return(suspension, nil).Once()
+	suspensionRepo.On("Lift", mock.Anything, suspension).Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(u *entities.User) bool {
+		return u.Status.IsActive()
+	})).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Once()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserReinstated, mock.Anything).Return(nil).Once()
+
+	uc := usecases.NewLiftSuspensionUseCase(repo, suspensionRepo, publisher, logger.NewNop())
+	out, err := uc.Execute(context.Background(), usecases.LiftSuspensionInput{UserID: user.ID, LiftedBy: "admin-2"})
+	require.NoError(t, err)
+	require.NotNil(t, out.LiftedAt)
+	assert.Equal(t, "admin-2", out.LiftedBy)
+
+	event, ok := publisher.Calls[1].Arguments.Get(2).(events.UserReinstatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, suspension.ID, event.SuspensionID)
+	assert.Equal(t, "admin-2", event.LiftedBy)
+
+	repo.AssertExpectations(t)
+	suspensionRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestLiftSuspension_NotSuspended(t *testing.T) {
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, "user-1").Return(nil, nil).Once()
+
+	uc := usecases.NewLiftSuspensionUseCase(repo, suspensionRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.LiftSuspensionInput{UserID: "user-1"})
+	require.ErrorIs(t, err, usecases.ErrUserNotSuspended)
+
+	suspensionRepo.AssertExpectations(t)
+}
+
+func TestLiftSuspension_KeepsBanOfSuspendedUser(t *testing.T) {
+	user, suspension := newSuspendedUser(t)
+	user.Status = valueobjects.StatusBanned
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	suspensionRepo.On("FindActiveByUserID", mock.Anything, user.ID).Return(suspension, nil).Once()
+	suspensionRepo.On("Lift", mock.Anything, suspension).Return(nil).Once()
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewLiftSuspensionUseCase(repo, suspensionRepo, nil, logger.NewNop())
+	_, err := uc.Execute(context.Background(), usecases.LiftSuspensionInput{UserID: user.ID, LiftedBy: "admin-2"})
+	require.NoError(t, err)
+	assert.True(t, user.Status.IsBanned())
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	suspensionRepo.AssertExpectations(t)
+}
+
+func TestReinstateExpiredSuspensions_LiftsExpired(t *testing.T) {
+	first, firstSuspension := newSuspendedUser(t)
+	second, secondSuspension := newSuspendedUser(t)
+
+	repo := new(mocks.MockUserRepository)
+	suspensionRepo := new(userMocks.MockSuspensionRepository)
+	publisher := new(mocks.MockPublisher)
+	suspensionRepo.On("FindExpired", mock.Anything, mock.Anything, 100).
+		Return([]*userEntities.Suspension{firstSuspension, secondSuspension}, nil).Once()
+	suspensionRepo.On("Lift", mock.Anything, mock.Anything).Return(nil).Twice()
+	repo.On("FindByID", mock.Anything, first.ID).Return(first, nil).Once()
+	repo.On("FindByID", mock.Anything, second.ID).Return(second, nil).Once()
+	repo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Return(nil).Twice()
+	publisher.On("Publish", mock.Anything, events.EventTypeUserReinstated, mock.Anything).Return(nil).Twice()
+
+	uc := usecases.NewReinstateExpiredSuspensionsUseCase(repo, suspensionRepo, publisher, logger.NewNop(), testSuspensionConfig)
+	lifted, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, lifted)
+	assert.True(t, first.Status.IsActive())
+	assert.True(t, second.Status.IsActive())
+	assert.True(t, firstSuspension.IsLifted())
+	assert.Empty(t, firstSuspension.LiftedBy)
+
+	repo.AssertExpectations(t)
+	suspensionRepo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestUpdateUser_CannotSetSuspendedStatus(t *testing.T) {
+	user := newTestUser(t, "user@example.com", "student")
+	repo := new(mocks.MockUserRepository)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	uc := usecases.NewUpdateUserUseCase(repo, nil, logger.NewNop())
+	status := "suspended"
+	_, err := uc.Execute(context.Background(), usecases.UpdateUserInput{ID: user.ID, Status: &status})
+	require.ErrorIs(t, err, usecases.ErrStatusRequiresSuspension)
+
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
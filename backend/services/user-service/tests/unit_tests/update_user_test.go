@@ -144,3 +144,84 @@ func newTestUser(t *testing.T, email, role string) *entities.User {
 	return entities.NewUser(emailVO, "username", roleVO, "hash")
 }
 
+func TestUpdateUser_Profile(t *testing.T) {
+	user := newTestUser(t, "user@example.com", "student")
+	repo := new(mocks.MockUserRepository)
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+	repo.On("Update", mock.Anything, user).Return(nil).Once()
+	publisher := new(mocks.MockPublisher)
+
+	var published events.UserUpdatedEvent
+	publisher.On("Publish", mock.Anything, events.EventTypeUserUpdated, mock.Anything).Run(func(args mock.Arguments) {
+		published = args.Get(2).(events.UserUpdatedEvent)
+	}).Return(nil).Once()
+
+	displayName := "  Aung Aung "
+	timezone := "Asia/Yangon"
+	locale := "my_mm"
+	phone := "+95 9 123 456 789"
+	avatarID := "5F0C6F9E-3C1A-4D8E-9A55-0B6F8F3F2F10"
+
+	uc := usecases.NewUpdateUserUseCase(repo, publisher, logger.NewNop())
+	dto, err := uc.Execute(context.Background(), usecases.UpdateUserInput{
+		ID: user.ID,
+		Profile: entities.ProfileUpdate{
+			DisplayName: &displayName,
+			Timezone:    &timezone,
+			Locale:      &locale,
+			Phone:       &phone,
+			AvatarID:    &avatarID,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Aung Aung", dto.DisplayName)
+	assert.Equal(t, "Asia/Yangon", dto.Timezone)
+	assert.Equal(t, "my-MM", dto.Locale)
+	assert.Equal(t, "+959123456789", dto.Phone)
+	require.NotNil(t, dto.AvatarID)
+	assert.Equal(t, "5f0c6f9e-3c1a-4d8e-9a55-0b6f8f3f2f10", *dto.AvatarID)
+
+	assert.Equal(t, "Asia/Yangon", published.Timezone)
+	assert.Equal(t, "my-MM", published.Locale)
+	assert.Equal(t, "Aung Aung", published.DisplayName)
+
+	repo.AssertExpectations(t)
+	publisher.AssertExpectations(t)
+}
+
+func TestUpdateUser_InvalidProfileLeavesUserUnchanged(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile func(value *string) entities.ProfileUpdate
+		value   string
+		err     error
+	}{
+		{"timezone", func(v *string) entities.ProfileUpdate { return entities.ProfileUpdate{Timezone: v} }, "GMT+6:30", valueobjects.ErrInvalidTimezone},
+		{"local timezone", func(v *string) entities.ProfileUpdate { return entities.ProfileUpdate{Timezone: v} }, "Local", valueobjects.ErrInvalidTimezone},
+		{"locale", func(v *string) entities.ProfileUpdate { return entities.ProfileUpdate{Locale: v} }, "english", valueobjects.ErrInvalidLocale},
+		{"phone", func(v *string) entities.ProfileUpdate { return entities.ProfileUpdate{Phone: v} }, "09123456789", valueobjects.ErrInvalidPhone},
+		{"avatar", func(v *string) entities.ProfileUpdate { return entities.ProfileUpdate{AvatarID: v} }, "avatar.png", valueobjects.ErrInvalidAvatarID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newTestUser(t, "user@example.com", "student")
+			repo := new(mocks.MockUserRepository)
+			repo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+			publisher := new(mocks.MockPublisher)
+
+			displayName := "New Name"
+			profile := tt.profile(&tt.value)
+			profile.DisplayName = &displayName
+
+			uc := usecases.NewUpdateUserUseCase(repo, publisher, logger.NewNop())
+			_, err := uc.Execute(context.Background(), usecases.UpdateUserInput{ID: user.ID, Profile: profile})
+			require.ErrorIs(t, err, tt.err)
+
+			assert.Empty(t, user.DisplayName)
+			assert.Equal(t, valueobjects.DefaultTimezone, user.Timezone)
+			repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	sharedPostgres "github.com/paingphyoaungkhant/asto-microservice/shared/infrastructure/persistence/postgres"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/messaging"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
 	"go.uber.org/zap"
 )
@@ -67,6 +68,12 @@ func main() {
 		defer rabbitMQ.Close()
 	}
 
+	authenticate, _, closeTokenStore, err := middleware.AuthenticateWithRedis(cfg.Auth, &cfg.Redis, appLogger)
+	if err != nil {
+		appLogger.Fatal("failed to set up token verification", zap.Error(err))
+	}
+	defer closeTokenStore()
+
 	meetingRepo := zoomPostgres.NewPostgresZoomMeetingRepository(db)
 	recordingRepo := zoomPostgres.NewPostgresZoomRecordingRepository(db)
 
@@ -116,6 +123,7 @@ func main() {
 		router,
 		zoomMeetingHandler,
 		zoomRecordingHandler,
+		authenticate,
 		appLogger,
 	)
 
@@ -18,6 +18,7 @@ type ZoomMeetingDTO struct {
 	Password        *string    `json:"password,omitempty"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
+	OrganizationID  *string    `json:"organization_id,omitempty"`
 }
 
 func (d *ZoomMeetingDTO) FromEntity(meeting *entities.ZoomMeeting) {
@@ -32,6 +33,7 @@ func (d *ZoomMeetingDTO) FromEntity(meeting *entities.ZoomMeeting) {
 	d.Password = meeting.Password
 	d.CreatedAt = meeting.CreatedAt
 	d.UpdatedAt = meeting.UpdatedAt
+	d.OrganizationID = meeting.OrganizationID
 }
 
 type CreateZoomMeetingInput struct {
@@ -18,6 +18,9 @@ type ZoomMeeting struct {
 	Password        *string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	// OrganizationID is the partner school of the instructor or admin who
+	// scheduled the meeting.
+	OrganizationID *string
 }
 
 func NewZoomMeeting(sectionModuleID, zoomMeetingID, topic, joinURL, startURL string, startTime *time.Time, duration *int, password *string) *ZoomMeeting {
@@ -6,6 +6,7 @@ import (
 
 type Config struct {
 	config.BaseConfig
+	Auth config.TokenAuthConfig
 }
 
 func DefaultConfig() *Config {
@@ -16,6 +17,10 @@ func DefaultConfig() *Config {
 	defaults.Database.DBName = "zoom_db"
 	return &Config{
 		BaseConfig: defaults,
+		Auth: config.TokenAuthConfig{
+			SecretKey:      "secret",
+			TrustedProxies: []string{"127.0.0.1", "::1"},
+		},
 	}
 }
 
@@ -25,6 +30,7 @@ func Load() (*Config, error) {
 	baseCfg := config.LoadBaseConfig(baseDefaults)
 	return &Config{
 		BaseConfig: baseCfg,
+		Auth:       config.LoadTokenAuthConfig(defaults.Auth),
 	}, nil
 }
 
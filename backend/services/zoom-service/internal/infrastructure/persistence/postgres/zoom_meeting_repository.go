@@ -6,6 +6,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresZoomMeetingRepository struct {
@@ -18,9 +19,10 @@ func NewPostgresZoomMeetingRepository(db *sql.DB) repositories.ZoomMeetingReposi
 
 func (r *PostgresZoomMeetingRepository) Create(ctx context.Context, meeting *entities.ZoomMeeting) error {
 	query := `
-		INSERT INTO zoom_meeting (id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO zoom_meeting (id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
+	meeting.OrganizationID = tenant.Owner(ctx, meeting.OrganizationID)
 	_, err := r.db.ExecContext(ctx, query,
 		meeting.ID,
 		meeting.SectionModuleID,
@@ -33,37 +35,38 @@ func (r *PostgresZoomMeetingRepository) Create(ctx context.Context, meeting *ent
 		meeting.Password,
 		meeting.CreatedAt,
 		meeting.UpdatedAt,
+		meeting.OrganizationID,
 	)
 	return err
 }
 
 func (r *PostgresZoomMeetingRepository) FindByID(ctx context.Context, id string) (*entities.ZoomMeeting, error) {
 	query := `
-		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at
+		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at, organization_id
 		FROM zoom_meeting
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanZoomMeeting(row)
 }
 
 func (r *PostgresZoomMeetingRepository) FindByZoomMeetingID(ctx context.Context, zoomMeetingID string) (*entities.ZoomMeeting, error) {
 	query := `
-		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at
+		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at, organization_id
 		FROM zoom_meeting
-		WHERE zoom_meeting_id = $1
+		WHERE zoom_meeting_id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	row := r.db.QueryRowContext(ctx, query, zoomMeetingID)
+	row := r.db.QueryRowContext(ctx, query, zoomMeetingID, tenant.Arg(ctx))
 	return r.scanZoomMeeting(row)
 }
 
 func (r *PostgresZoomMeetingRepository) FindBySectionModuleID(ctx context.Context, sectionModuleID string) (*entities.ZoomMeeting, error) {
 	query := `
-		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at
+		SELECT id, section_module_id, zoom_meeting_id, topic, start_time, duration, join_url, start_url, password, created_at, updated_at, organization_id
 		FROM zoom_meeting
-		WHERE section_module_id = $1
+		WHERE section_module_id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	row := r.db.QueryRowContext(ctx, query, sectionModuleID)
+	row := r.db.QueryRowContext(ctx, query, sectionModuleID, tenant.Arg(ctx))
 	return r.scanZoomMeeting(row)
 }
 
@@ -96,7 +99,7 @@ func (r *PostgresZoomMeetingRepository) scanZoomMeeting(row *sql.Row) (*entities
 	var meeting entities.ZoomMeeting
 	var startTime sql.NullTime
 	var duration sql.NullInt32
-	var password sql.NullString
+	var password, organizationID sql.NullString
 	err := row.Scan(
 		&meeting.ID,
 		&meeting.SectionModuleID,
@@ -109,6 +112,7 @@ func (r *PostgresZoomMeetingRepository) scanZoomMeeting(row *sql.Row) (*entities
 		&password,
 		&meeting.CreatedAt,
 		&meeting.UpdatedAt,
+		&organizationID,
 	)
 	if err != nil {
 		return nil, err
@@ -123,6 +127,9 @@ func (r *PostgresZoomMeetingRepository) scanZoomMeeting(row *sql.Row) (*entities
 	if password.Valid {
 		meeting.Password = &password.String
 	}
+	if organizationID.Valid {
+		meeting.OrganizationID = &organizationID.String
+	}
 	return &meeting, nil
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/domain/repositories"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
 type PostgresZoomRecordingRepository struct {
@@ -40,8 +41,9 @@ func (r *PostgresZoomRecordingRepository) FindByID(ctx context.Context, id strin
 		SELECT id, zoom_meeting_id, file_id, recording_type, recording_start_time, recording_end_time, file_size, created_at, updated_at
 		FROM zoom_recording
 		WHERE id = $1
+			AND ($2::uuid IS NULL OR zoom_meeting_id IN (SELECT id FROM zoom_meeting WHERE organization_id = $2))
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return r.scanZoomRecording(row)
 }
 
@@ -50,9 +52,10 @@ func (r *PostgresZoomRecordingRepository) FindByZoomMeetingID(ctx context.Contex
 		SELECT id, zoom_meeting_id, file_id, recording_type, recording_start_time, recording_end_time, file_size, created_at, updated_at
 		FROM zoom_recording
 		WHERE zoom_meeting_id = $1
+			AND ($2::uuid IS NULL OR zoom_meeting_id IN (SELECT id FROM zoom_meeting WHERE organization_id = $2))
 		ORDER BY created_at ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, zoomMeetingID)
+	rows, err := r.db.QueryContext(ctx, query, zoomMeetingID, tenant.Arg(ctx))
 	if err != nil {
 		return nil, err
 	}
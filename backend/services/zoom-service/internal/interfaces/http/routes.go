@@ -14,6 +14,7 @@ func SetUpRoutes(
 	router *gin.Engine,
 	zoomMeetingHandler *handlers.ZoomMeetingHandler,
 	zoomRecordingHandler *handlers.ZoomRecordingHandler,
+	authenticate gin.HandlerFunc,
 	logger *logger.Logger,
 ) {
 	router.Use(middleware.RequestID())
@@ -27,6 +28,8 @@ func SetUpRoutes(
 	router.GET("/api/v1/zoom/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	api := router.Group("/api/v1")
+	api.Use(authenticate)
+	api.Use(middleware.Tenant())
 	{
 		zoomRoutes := api.Group("/zoom")
 		{
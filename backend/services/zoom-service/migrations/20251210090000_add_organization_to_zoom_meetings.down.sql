@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_zoom_meeting_organization_id;
+
+ALTER TABLE zoom_meeting DROP COLUMN IF EXISTS organization_id;
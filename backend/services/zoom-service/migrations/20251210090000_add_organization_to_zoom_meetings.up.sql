@@ -0,0 +1,5 @@
+-- Organizations live in user-service, so there is no foreign key. Recordings
+-- belong to the organization of their meeting.
+ALTER TABLE zoom_meeting ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_zoom_meeting_organization_id ON zoom_meeting(organization_id);
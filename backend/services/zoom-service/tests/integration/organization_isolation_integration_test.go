@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/application/usecases"
+	"github.com/paingphyoaungkhant/asto-microservice/services/zoom-service/internal/domain/entities"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizations_Integration_NoCrossTenantLeakage(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	meetingRepo := SetupZoomMeetingRepository(db)
+	recordingRepo := SetupZoomRecordingRepository(db)
+
+	riverside := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	hillcrest := tenant.WithOrganizationID(context.Background(), uuid.NewString())
+	superAdmin := context.Background()
+
+	meeting := entities.NewZoomMeeting(
+		uuid.NewString(),
+		"zoom-meeting-id-"+uuid.NewString(),
+		"Riverside Office Hours",
+		"https://zoom.us/j/123",
+		"https://zoom.us/s/123",
+		nil,
+		nil,
+		nil,
+	)
+	require.NoError(t, meetingRepo.Create(riverside, meeting))
+	require.NotNil(t, meeting.OrganizationID)
+
+	recording := entities.NewZoomRecording(meeting.ID, uuid.NewString(), nil, nil, nil, nil)
+	require.NoError(t, recordingRepo.Create(riverside, recording))
+
+	getMeeting := usecases.NewGetZoomMeetingUseCase(meetingRepo)
+	_, err := getMeeting.Execute(hillcrest, meeting.ID)
+	require.Error(t, err)
+	result, err := getMeeting.Execute(riverside, meeting.ID)
+	require.NoError(t, err)
+	assert.Equal(t, meeting.ID, result.ID)
+	_, err = getMeeting.Execute(superAdmin, meeting.ID)
+	require.NoError(t, err)
+
+	_, err = usecases.NewGetZoomMeetingByModuleUseCase(meetingRepo).Execute(hillcrest, meeting.SectionModuleID)
+	require.Error(t, err)
+
+	_, err = usecases.NewGetZoomRecordingUseCase(recordingRepo).Execute(hillcrest, recording.ID)
+	require.Error(t, err)
+
+	_, err = usecases.NewListZoomRecordingsUseCase(recordingRepo, meetingRepo).Execute(hillcrest, meeting.ID)
+	require.Error(t, err)
+
+	recordings, err := usecases.NewListZoomRecordingsUseCase(recordingRepo, meetingRepo).Execute(riverside, meeting.ID)
+	require.NoError(t, err)
+	assert.Len(t, recordings, 1)
+}
@@ -9,25 +9,46 @@ import (
 
 type UserDTO struct {
 	ID             string     `json:"id"`
+	OrganizationID *string    `json:"organization_id,omitempty"`
 	Email          string     `json:"email"`
 	Username       string     `json:"username"`
 	Role           string     `json:"role"`
 	Status         string     `json:"status"`
 	EmailVerified  bool       `json:"email_verified"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	DisplayName    string     `json:"display_name"`
+	Bio            string     `json:"bio"`
+	AvatarID       *string    `json:"avatar_id,omitempty"`
+	Timezone       string     `json:"timezone"`
+	Locale         string     `json:"locale"`
+	Phone          string     `json:"phone,omitempty"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	GroupIDs       []string   `json:"group_ids"`
 }
 
 func (d *UserDTO) FromEntity(user *entities.User) {
 	d.ID = user.ID
+	d.OrganizationID = user.OrganizationID
 	d.Email = user.Email.String()
 	d.Username = user.Username
 	d.Role = user.Role.String()
 	d.Status = user.Status.String()
 	d.EmailVerified = user.EmailVerified
 	d.EmailVerifiedAt = user.EmailVerifiedAt
+	d.DisplayName = user.DisplayName
+	d.Bio = user.Bio
+	d.AvatarID = user.AvatarID
+	d.Timezone = user.Timezone
+	d.Locale = user.Locale
+	d.Phone = user.Phone
 	d.CreatedAt = user.CreatedAt
 	d.UpdatedAt = user.UpdatedAt
+	d.DeletedAt = user.DeletedAt
+	d.GroupIDs = user.GroupIDs
+	if d.GroupIDs == nil {
+		d.GroupIDs = []string{}
+	}
 }
 
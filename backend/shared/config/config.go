@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -161,6 +162,67 @@ func LoadBaseConfig(defaults *BaseConfig) BaseConfig {
 	}
 }
 
+// RateLimitConfig allows Requests per Window for each client. A limit with
+// Enabled false or no Requests lets everything through.
+type RateLimitConfig struct {
+	Enabled  bool
+	Requests int
+	Window   time.Duration
+}
+
+// LoadRateLimitConfig reads <prefix>_ENABLED, <prefix>_REQUESTS and
+// <prefix>_WINDOW, e.g. RATE_LIMIT_UPLOAD_REQUESTS.
+func LoadRateLimitConfig(prefix string, defaults RateLimitConfig) RateLimitConfig {
+	enabled := defaults.Enabled
+	if enabledStr := GetEnv(prefix+"_ENABLED", ""); enabledStr != "" {
+		if parsed, err := strconv.ParseBool(enabledStr); err == nil {
+			enabled = parsed
+		}
+	}
+
+	return RateLimitConfig{
+		Enabled:  enabled,
+		Requests: GetEnvAsInt(prefix+"_REQUESTS", defaults.Requests),
+		Window:   GetEnvAsDuration(prefix+"_WINDOW", defaults.Window),
+	}
+}
+
+// TokenAuthConfig lets a service verify access tokens itself rather than
+// trusting whoever reached it. SecretKey and SigningKeysDir match the JWT
+// settings of auth-service, though the directory only needs public keys.
+type TokenAuthConfig struct {
+	SecretKey      string
+	SigningKeysDir string
+	// AcceptHS256Until is when tokens signed with SecretKey stop being
+	// accepted once SigningKeysDir is set. The zero time refuses them.
+	AcceptHS256Until time.Time
+	// TrustedProxies lists the gateway addresses, as IPs or CIDRs, whose
+	// identity headers are accepted for callers it authenticated without an
+	// access token, such as service accounts using API keys.
+	TrustedProxies []string
+}
+
+// LoadTokenAuthConfig reads JWT_SECRET_KEY, JWT_SIGNING_KEYS_DIR,
+// JWT_ACCEPT_HS256_UNTIL and the comma separated AUTH_TRUSTED_PROXIES.
+func LoadTokenAuthConfig(defaults TokenAuthConfig) TokenAuthConfig {
+	trustedProxies := defaults.TrustedProxies
+	if value := GetEnv("AUTH_TRUSTED_PROXIES", ""); value != "" {
+		trustedProxies = []string{}
+		for _, proxy := range strings.Split(value, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy != "" {
+				trustedProxies = append(trustedProxies, proxy)
+			}
+		}
+	}
+
+	return TokenAuthConfig{
+		SecretKey:        GetEnv("JWT_SECRET_KEY", defaults.SecretKey),
+		SigningKeysDir:   GetEnv("JWT_SIGNING_KEYS_DIR", defaults.SigningKeysDir),
+		AcceptHS256Until: GetEnvAsTime("JWT_ACCEPT_HS256_UNTIL", defaults.AcceptHS256Until),
+		TrustedProxies:   trustedProxies,
+	}
+}
+
 func LoadZoomConfig(defaults ZoomConfig) ZoomConfig {
 	return ZoomConfig{
 		AccountID:    GetEnv("ZOOM_ACCOUNT_ID", defaults.AccountID),
@@ -229,3 +291,13 @@ func GetEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// GetEnvAsTime reads an RFC 3339 timestamp.
+func GetEnvAsTime(key string, defaultValue time.Time) time.Time {
+	if value := os.Getenv(key); value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return defaultValue
+}
@@ -11,10 +11,15 @@ import (
 var (
 	ErrCannotBanAdmin       = errors.New("cannot ban admin user")
 	ErrCannotActivateBanned = errors.New("cannot activate banned user")
+	ErrCannotSuspendAdmin   = errors.New("cannot suspend admin user")
+	ErrCannotSuspendBanned  = errors.New("cannot suspend banned user")
 )
 
 type User struct {
 	ID            string
+	// OrganizationID is the partner school the user belongs to. Admins
+	// without one are super-admins and manage every organization.
+	OrganizationID *string
 	Email         valueobjects.Email
 	Username      string
 	PasswordHash  string
@@ -22,8 +27,38 @@ type User struct {
 	Status        valueobjects.Status
 	EmailVerified bool
 	EmailVerifiedAt *time.Time
+	DisplayName   string
+	Bio           string
+	// AvatarID is the ID of an image in file-service's user-avatars bucket.
+	AvatarID      *string
+	// Timezone is an IANA name and Locale a language tag; notifications and
+	// schedules are rendered with them.
+	Timezone      string
+	Locale        string
+	Phone         string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	// DeletedAt is set while a deleted user can still be restored.
+	DeletedAt     *time.Time
+	// GroupIDs lists the groups the user belongs to. It is only read with
+	// the user; membership is changed through the groups.
+	GroupIDs      []string
+}
+
+// ProfileUpdate holds the profile fields to change; nil fields are left as
+// they are and empty ones are cleared.
+type ProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	AvatarID    *string
+	Timezone    *string
+	Locale      *string
+	Phone       *string
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (p ProfileUpdate) IsEmpty() bool {
+	return p == ProfileUpdate{}
 }
 
 func NewUser(email valueobjects.Email, username string, role valueobjects.Role, password_hash string) *User {
@@ -36,6 +71,8 @@ func NewUser(email valueobjects.Email, username string, role valueobjects.Role,
 		Role:         role,
 		Status:       valueobjects.StatusActive,
 		EmailVerified: false,
+		Timezone:     valueobjects.DefaultTimezone,
+		Locale:       valueobjects.DefaultLocale,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
@@ -74,6 +111,32 @@ func (u *User) Ban() error {
 	return nil
 }
 
+// Suspend blocks the user until Activate is called, normally when their
+// suspension is lifted or expires.
+func (u *User) Suspend() error {
+	if u.Role.IsAdmin() {
+		return ErrCannotSuspendAdmin
+	}
+	if u.Status.IsBanned() {
+		return ErrCannotSuspendBanned
+	}
+	u.Status = valueobjects.StatusSuspended
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// IsSuperAdmin reports whether the user is an admin outside any organization.
+func (u *User) IsSuperAdmin() bool {
+	return u.Role.IsAdmin() && u.OrganizationID == nil
+}
+
+// MoveToOrganization changes the organization the user belongs to; nil takes
+// them out of every organization.
+func (u *User) MoveToOrganization(organizationID *string) {
+	u.OrganizationID = organizationID
+	u.UpdatedAt = time.Now().UTC()
+}
+
 func (u *User) CanEnroll() bool {
 	return u.Status.IsActive() && u.Role.IsStudent()
 }
@@ -93,3 +156,58 @@ func (u *User) VerifyEmail() {
 	u.UpdatedAt = now
 }
 
+// UpdateProfile validates every field of update before applying any, so an
+// invalid field leaves the user unchanged.
+func (u *User) UpdateProfile(update ProfileUpdate) error {
+	updated := *u
+
+	if update.DisplayName != nil {
+		displayName, err := valueobjects.NormalizeDisplayName(*update.DisplayName)
+		if err != nil {
+			return err
+		}
+		updated.DisplayName = displayName
+	}
+	if update.Bio != nil {
+		bio, err := valueobjects.NormalizeBio(*update.Bio)
+		if err != nil {
+			return err
+		}
+		updated.Bio = bio
+	}
+	if update.AvatarID != nil {
+		avatarID, err := valueobjects.NormalizeAvatarID(*update.AvatarID)
+		if err != nil {
+			return err
+		}
+		updated.AvatarID = nil
+		if avatarID != "" {
+			updated.AvatarID = &avatarID
+		}
+	}
+	if update.Timezone != nil {
+		timezone, err := valueobjects.NormalizeTimezone(*update.Timezone)
+		if err != nil {
+			return err
+		}
+		updated.Timezone = timezone
+	}
+	if update.Locale != nil {
+		locale, err := valueobjects.NormalizeLocale(*update.Locale)
+		if err != nil {
+			return err
+		}
+		updated.Locale = locale
+	}
+	if update.Phone != nil {
+		phone, err := valueobjects.NormalizePhone(*update.Phone)
+		if err != nil {
+			return err
+		}
+		updated.Phone = phone
+	}
+
+	updated.UpdatedAt = time.Now().UTC()
+	*u = updated
+	return nil
+}
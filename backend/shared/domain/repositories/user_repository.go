@@ -3,6 +3,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
@@ -19,10 +20,18 @@ type UserQuery struct {
 	SearchQuery   *string
 	Role          *valueobjects.Role
 	Status        *valueobjects.Status
+	// GroupID only returns members of the group.
+	GroupID       *string
+	// OrganizationID narrows an unscoped query to one organization. Scoped
+	// queries are always limited to the caller's organization.
+	OrganizationID *string
 	Limit         *int
 	Offset        *int
 	SortColumn    *string
 	SortDirection *SortDirection
+	// Deleted returns users that are deleted but can still be restored
+	// instead of the others.
+	Deleted bool
 }
 
 type UserQueryResult struct {
@@ -30,13 +39,22 @@ type UserQueryResult struct {
 	Total int
 }
 
+// UserRepository limits FindByID, FindDeletedByID and Find to the
+// organization the context is scoped to. FindByEmail and FindByUsername are
+// not scoped, as emails and usernames are unique across organizations.
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	FindByID(ctx context.Context, id string) (*entities.User, error)
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindByUsername(ctx context.Context, username string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
+	// Delete removes the user for good. Every Find method skips users that
+	// are soft deleted, apart from FindDeleted*.
 	Delete(ctx context.Context, id string) error
+	SoftDelete(ctx context.Context, id string, deletedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	FindDeletedByID(ctx context.Context, id string) (*entities.User, error)
+	FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.User, error)
 	UpdatePassword(ctx context.Context, userID, passwordHash string) error
 	UpdateEmailVerified(ctx context.Context, userID string, verified bool) error
 	Find(ctx context.Context, query UserQuery) (*UserQueryResult, error)
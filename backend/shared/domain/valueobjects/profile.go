@@ -0,0 +1,122 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	// Timezones are checked against the embedded database so validation does
+	// not depend on the zoneinfo files of the image a service runs in.
+	_ "time/tzdata"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultTimezone = "UTC"
+	DefaultLocale   = "en"
+
+	MaxDisplayNameLength = 100
+	MaxBioLength         = 1000
+)
+
+var (
+	ErrDisplayNameTooLong = fmt.Errorf("display name must be at most %d characters", MaxDisplayNameLength)
+	ErrBioTooLong         = fmt.Errorf("bio must be at most %d characters", MaxBioLength)
+	ErrInvalidAvatarID    = errors.New("avatar id must be a file id")
+	ErrInvalidTimezone    = errors.New("invalid timezone, expected an IANA name such as Asia/Yangon")
+	ErrInvalidLocale      = errors.New("invalid locale, expected a language tag such as en or en-US")
+	ErrInvalidPhone       = errors.New("invalid phone number, expected international format such as +959123456789")
+
+	localeRegex     = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:-([a-zA-Z]{4}))?(?:-([a-zA-Z]{2}|[0-9]{3}))?$`)
+	phoneRegex      = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+// NormalizeDisplayName trims the name. An empty name falls back to the
+// username wherever a name is shown.
+func NormalizeDisplayName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return "", ErrDisplayNameTooLong
+	}
+	return name, nil
+}
+
+func NormalizeBio(bio string) (string, error) {
+	bio = strings.TrimSpace(bio)
+	if utf8.RuneCountInString(bio) > MaxBioLength {
+		return "", ErrBioTooLong
+	}
+	return bio, nil
+}
+
+// NormalizeAvatarID checks that id looks like a file-service file ID. An
+// empty id removes the avatar.
+func NormalizeAvatarID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", ErrInvalidAvatarID
+	}
+	return parsed.String(), nil
+}
+
+// NormalizeTimezone accepts IANA names only, so offsets such as "+06:30" that
+// ignore daylight saving time are rejected. An empty timezone resets it to
+// DefaultTimezone.
+func NormalizeTimezone(timezone string) (string, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		return DefaultTimezone, nil
+	}
+	if timezone == "Local" {
+		return "", ErrInvalidTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", ErrInvalidTimezone
+	}
+	return location.String(), nil
+}
+
+// NormalizeLocale accepts a language with an optional script and region,
+// such as "my", "zh-Hant-TW" or "es-419", and returns it in canonical case.
+// An empty locale resets it to DefaultLocale.
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return DefaultLocale, nil
+	}
+	parts := localeRegex.FindStringSubmatch(locale)
+	if parts == nil {
+		return "", ErrInvalidLocale
+	}
+
+	normalized := strings.ToLower(parts[1])
+	if parts[2] != "" {
+		normalized += "-" + strings.ToUpper(parts[2][:1]) + strings.ToLower(parts[2][1:])
+	}
+	if parts[3] != "" {
+		normalized += "-" + strings.ToUpper(parts[3])
+	}
+	return normalized, nil
+}
+
+// NormalizePhone strips common separators and requires E.164 format. An
+// empty number removes it.
+func NormalizePhone(phone string) (string, error) {
+	phone = phoneSeparators.Replace(strings.TrimSpace(phone))
+	if phone == "" {
+		return "", nil
+	}
+	if !phoneRegex.MatchString(phone) {
+		return "", ErrInvalidPhone
+	}
+	return phone, nil
+}
@@ -13,12 +13,15 @@ const (
 	StatusInActive Status = "inactive"
 	StatusPending  Status = "pending"
 	StatusBanned   Status = "banned"
+	// StatusSuspended is set while a suspension is in force and is lifted
+	// again, unlike a ban.
+	StatusSuspended Status = "suspended"
 )
 
 func NewStatus(status string) (Status, error) {
 	s := Status(status)
 	switch s {
-	case StatusActive, StatusInActive, StatusPending, StatusBanned:
+	case StatusActive, StatusInActive, StatusPending, StatusBanned, StatusSuspended:
 		return s, nil
 	default:
 		return "", ErrInvalidStatus
@@ -41,3 +44,6 @@ func (s Status) IsBanned() bool {
 	return s == StatusBanned
 }
 
+func (s Status) IsSuspended() bool {
+	return s == StatusSuspended
+}
@@ -0,0 +1,43 @@
+package events
+
+import "time"
+
+// AssignmentSubmittedEvent is published for every hand-in of an assignment,
+// including resubmissions. StudentEmail may be empty when the student's email
+// was not known at submission.
+type AssignmentSubmittedEvent struct {
+	ID               string     `json:"id"`
+	AssignmentID     string     `json:"assignment_id"`
+	SectionModuleID  string     `json:"section_module_id"`
+	CourseOfferingID string     `json:"course_offering_id"`
+	AssignmentName   string     `json:"assignment_name"`
+	StudentID        string     `json:"student_id"`
+	StudentEmail     string     `json:"student_email"`
+	SubmissionNumber int        `json:"submission_number"`
+	FileIDs          []string   `json:"file_ids"`
+	Late             bool       `json:"late"`
+	DueAt            *time.Time `json:"due_at,omitempty"`
+	SubmittedAt      time.Time  `json:"submitted_at"`
+}
+
+// AssignmentGradedEvent carries an instructor's grade for a submission.
+// FinalScore is Score less the late penalty, out of MaxScore. It is
+// published again when a submission is re-graded.
+type AssignmentGradedEvent struct {
+	ID               string    `json:"id"`
+	AssignmentID     string    `json:"assignment_id"`
+	SectionModuleID  string    `json:"section_module_id"`
+	CourseOfferingID string    `json:"course_offering_id"`
+	AssignmentName   string    `json:"assignment_name"`
+	StudentID        string    `json:"student_id"`
+	StudentEmail     string    `json:"student_email"`
+	SubmissionNumber int       `json:"submission_number"`
+	Late             bool      `json:"late"`
+	Score            float64   `json:"score"`
+	PenaltyPercent   float64   `json:"penalty_percent"`
+	FinalScore       float64   `json:"final_score"`
+	MaxScore         float64   `json:"max_score"`
+	Feedback         *string   `json:"feedback,omitempty"`
+	GradedBy         string    `json:"graded_by"`
+	GradedAt         time.Time `json:"graded_at"`
+}
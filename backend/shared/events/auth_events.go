@@ -25,6 +25,8 @@ type AuthUserLoggedInEvent struct {
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
+	IPAddress     string     `json:"ip_address"`
+	UserAgent     string     `json:"user_agent"`
 }
 
 type AuthUserForgotPasswordEvent struct {
@@ -56,6 +58,8 @@ type AuthUserLoggedOutEvent struct {
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
+	SessionIDs    []string   `json:"session_ids"`
+	LoggedOutAt   time.Time  `json:"logged_out_at"`
 }
 
 type AuthUserRequestedEmailVerificationEvent struct {
@@ -64,3 +68,185 @@ type AuthUserRequestedEmailVerificationEvent struct {
 	EmailVerificationURL string `json:"email_verification_url"`
 }
 
+// AuthRefreshTokenReusedEvent is published when an already used refresh token
+// is presented again. The whole token family and its session are revoked.
+type AuthRefreshTokenReusedEvent struct {
+	ID         string    `json:"id"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username"`
+	FamilyID   string    `json:"family_id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// AuthAccountLockedEvent is published when repeated failures lock an account.
+// Action is the endpoint that was being guessed, e.g. login or otp.
+type AuthAccountLockedEvent struct {
+	ID             string    `json:"id"`
+	Email          string    `json:"email"`
+	Username       string    `json:"username"`
+	Action         string    `json:"action"`
+	FailedAttempts int64     `json:"failed_attempts"`
+	IPAddress      string    `json:"ip_address"`
+	UserAgent      string    `json:"user_agent"`
+	LockedAt       time.Time `json:"locked_at"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+type AuthAccountUnlockedEvent struct {
+	ID         string    `json:"id"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username"`
+	UnlockedBy string    `json:"unlocked_by"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+// AuthIdentityLinkedEvent is published when an external sign-in provider is
+// attached to an account, either on first login or to an existing user.
+type AuthIdentityLinkedEvent struct {
+	ID          string    `json:"id"`
+	Email       string    `json:"email"`
+	Username    string    `json:"username"`
+	Provider    string    `json:"provider"`
+	Subject     string    `json:"subject"`
+	UserCreated bool      `json:"user_created"`
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	LinkedAt    time.Time `json:"linked_at"`
+}
+
+// AuthAPIKeyCreatedEvent is published when an admin issues an API key for a
+// service account.
+type AuthAPIKeyCreatedEvent struct {
+	ID                 string     `json:"id"`
+	ServiceAccountID   string     `json:"service_account_id"`
+	ServiceAccountName string     `json:"service_account_name"`
+	Name               string     `json:"name"`
+	Prefix             string     `json:"prefix"`
+	Scopes             []string   `json:"scopes"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	CreatedBy          string     `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type AuthAPIKeyRevokedEvent struct {
+	ID               string    `json:"id"`
+	ServiceAccountID string    `json:"service_account_id"`
+	Prefix           string    `json:"prefix"`
+	RevokedBy        string    `json:"revoked_by"`
+	RevokedAt        time.Time `json:"revoked_at"`
+}
+
+// AuthServiceAccountDisabledEvent is published when a service account is
+// disabled. All of its API keys are revoked with it.
+type AuthServiceAccountDisabledEvent struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	DisabledBy string    `json:"disabled_by"`
+	DisabledAt time.Time `json:"disabled_at"`
+}
+
+// AuthImpersonationStartedEvent is published when an admin starts acting as
+// another user. ID identifies the impersonation session in later events.
+type AuthImpersonationStartedEvent struct {
+	ID           string    `json:"id"`
+	ActorID      string    `json:"actor_id"`
+	ActorEmail   string    `json:"actor_email"`
+	TargetUserID string    `json:"target_user_id"`
+	TargetEmail  string    `json:"target_email"`
+	TargetRole   string    `json:"target_role"`
+	Reason       string    `json:"reason"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	StartedAt    time.Time `json:"started_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type AuthImpersonationStoppedEvent struct {
+	ID           string    `json:"id"`
+	ActorID      string    `json:"actor_id"`
+	TargetUserID string    `json:"target_user_id"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	StartedAt    time.Time `json:"started_at"`
+	StoppedAt    time.Time `json:"stopped_at"`
+}
+
+// AuthUserPasswordChangedEvent is published when a signed in user changes
+// their password. Every other session of the user is revoked with it.
+type AuthUserPasswordChangedEvent struct {
+	ID              string    `json:"id"`
+	Email           string    `json:"email"`
+	Username        string    `json:"username"`
+	RevokedSessions []string  `json:"revoked_sessions"`
+	IPAddress       string    `json:"ip_address"`
+	UserAgent       string    `json:"user_agent"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// AuthUserEmailChangedEvent is published once the new address of an email
+// change has been verified and the account switched over to it.
+type AuthUserEmailChangedEvent struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	OldEmail  string    `json:"old_email"`
+	NewEmail  string    `json:"new_email"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// AuthUserInvitedEvent is published when an admin invites someone, and again
+// on every resend with a fresh InvitationURL.
+type AuthUserInvitedEvent struct {
+	ID             string    `json:"id"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	InvitedBy      string    `json:"invited_by"`
+	InvitedByEmail string    `json:"invited_by_email"`
+	InvitationURL  string    `json:"invitation_url"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type AuthInvitationAcceptedEvent struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username"`
+	Role       string    `json:"role"`
+	InvitedBy  string    `json:"invited_by"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+type AuthInvitationRevokedEvent struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	RevokedBy string    `json:"revoked_by"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// AuthMagicLinkRequestedEvent carries a single-use sign-in link to email to
+// the user.
+type AuthMagicLinkRequestedEvent struct {
+	ID           string    `json:"id"`
+	Email        string    `json:"email"`
+	Username     string    `json:"username"`
+	MagicLinkURL string    `json:"magic_link_url"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// AuthNewLoginDetectedEvent is published when a user signs in from an IP
+// address or device they have not signed in from before. It is not sent for a
+// user's first login.
+type AuthNewLoginDetectedEvent struct {
+	ID         string    `json:"id"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username"`
+	Device     string    `json:"device"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	NewIP      bool      `json:"new_ip"`
+	NewDevice  bool      `json:"new_device"`
+	LoggedInAt time.Time `json:"logged_in_at"`
+}
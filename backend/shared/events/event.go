@@ -5,6 +5,15 @@ const (
 	EventTypeUserCreated = "user.user.created"
 	EventTypeUserUpdated = "user.user.updated"
 	EventTypeUserDeleted = "user.user.deleted"
+	EventTypeUserRestored = "user.user.restored"
+	EventTypeUserPurged  = "user.user.purged"
+	EventTypeUserSuspended  = "user.user.suspended"
+	EventTypeUserReinstated = "user.user.reinstated"
+	EventTypeGroupCreated        = "user.group.created"
+	EventTypeGroupUpdated        = "user.group.updated"
+	EventTypeGroupDeleted        = "user.group.deleted"
+	EventTypeGroupMembersAdded   = "user.group.members_added"
+	EventTypeGroupMembersRemoved = "user.group.members_removed"
 
 	// Auth Service events
 	EventTypeAuthStudentRegistered  = "auth.student.registered"
@@ -13,6 +22,22 @@ const (
 	EventTypeAuthUserForgotPassword = "auth.user.forgot_password"
 	EventTypeAuthUserResetPassword  = "auth.user.reset_password"
 	EventTypeAuthUserRequestedEmailVerification = "auth.user.requested_email_verification"
+	EventTypeAuthRefreshTokenReused = "auth.refresh_token.reused"
+	EventTypeAuthAccountLocked      = "auth.account.locked"
+	EventTypeAuthAccountUnlocked    = "auth.account.unlocked"
+	EventTypeAuthIdentityLinked     = "auth.identity.linked"
+	EventTypeAuthAPIKeyCreated      = "auth.api_key.created"
+	EventTypeAuthAPIKeyRevoked      = "auth.api_key.revoked"
+	EventTypeAuthServiceAccountDisabled = "auth.service_account.disabled"
+	EventTypeAuthImpersonationStarted   = "auth.impersonation.started"
+	EventTypeAuthImpersonationStopped   = "auth.impersonation.stopped"
+	EventTypeAuthUserPasswordChanged    = "auth.user.password_changed"
+	EventTypeAuthUserEmailChanged       = "auth.user.email_changed"
+	EventTypeAuthUserInvited            = "auth.user.invited"
+	EventTypeAuthInvitationAccepted     = "auth.invitation.accepted"
+	EventTypeAuthInvitationRevoked      = "auth.invitation.revoked"
+	EventTypeAuthMagicLinkRequested     = "auth.magic_link.requested"
+	EventTypeAuthNewLoginDetected       = "auth.user.new_login_detected"
 
 	// Course Service events
 	EventTypeCourseCreated                = "course.course.created"
@@ -23,12 +48,21 @@ const (
 	EventTypeCourseOfferingDeleted        = "course.offering.deleted"
 	EventTypeInstructorAssignedToOffering = "course.instructor.assigned"
 	EventTypeInstructorRemovedFromOffering = "course.instructor.removed"
+	// EventTypeInstructorAssignmentReplayed carries an existing assignment
+	// as an InstructorAssignedToOfferingEvent in answer to a replay request.
+	EventTypeInstructorAssignmentReplayed = "course.instructor.replayed"
 	EventTypeCourseSectionCreated         = "course.section.created"
 	EventTypeCourseSectionUpdated         = "course.section.updated"
 	EventTypeCourseSectionDeleted         = "course.section.deleted"
 	EventTypeSectionModuleCreated         = "course.module.created"
 	EventTypeSectionModuleUpdated         = "course.module.updated"
 	EventTypeSectionModuleDeleted         = "course.module.deleted"
+	EventTypeQuizAttemptSubmitted         = "course.quiz_attempt.submitted"
+	EventTypeAssignmentSubmitted          = "course.assignment.submitted"
+	EventTypeAssignmentGraded             = "course.assignment.graded"
+	// EventTypeSubmissionReplayed carries an existing submission with files
+	// as an AssignmentSubmittedEvent in answer to a replay request.
+	EventTypeSubmissionReplayed = "course.submission.replayed"
 
 	// Zoom Service events
 	EventTypeZoomMeetingCreated = "zoom.meeting.created"
@@ -37,5 +71,11 @@ const (
 	EventTypeEnrollmentCreated = "enrollment.enrollment.created"
 	EventTypeEnrollmentUpdated = "enrollment.enrollment.updated"
 	EventTypeEnrollmentDeleted = "enrollment.enrollment.deleted"
+	// EventTypeEnrollmentReplayed carries an existing enrollment as an
+	// EnrollmentUpdatedEvent in answer to a replay request.
+	EventTypeEnrollmentReplayed = "enrollment.enrollment.replayed"
+
+	// Replay events
+	EventTypeReplayRequested = "replay.requested"
 )
 
@@ -0,0 +1,43 @@
+package events
+
+import "time"
+
+// Groups are cohorts of students kept by user-service. Services that act on
+// a whole cohort, such as bulk enrollment, follow membership through the
+// members events.
+
+type GroupCreatedEvent struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type GroupUpdatedEvent struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// GroupDeletedEvent lists the users who were members when the group was
+// deleted.
+type GroupDeletedEvent struct {
+	ID        string    `json:"id"`
+	MemberIDs []string  `json:"member_ids"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// GroupMembersAddedEvent only lists users who were not members already.
+type GroupMembersAddedEvent struct {
+	GroupID string    `json:"group_id"`
+	UserIDs []string  `json:"user_ids"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+type GroupMembersRemovedEvent struct {
+	GroupID   string    `json:"group_id"`
+	UserIDs   []string  `json:"user_ids"`
+	RemovedAt time.Time `json:"removed_at"`
+}
@@ -0,0 +1,19 @@
+package events
+
+import "time"
+
+// QuizAttemptSubmittedEvent carries the auto-graded score of a quiz attempt,
+// so gradebooks can record it. Status is "expired" for attempts that ran out
+// of time before being submitted; they score zero.
+type QuizAttemptSubmittedEvent struct {
+	ID              string    `json:"id"`
+	QuizID          string    `json:"quiz_id"`
+	SectionModuleID string    `json:"section_module_id"`
+	CourseID        string    `json:"course_id"`
+	StudentID       string    `json:"student_id"`
+	AttemptNumber   int       `json:"attempt_number"`
+	Status          string    `json:"status"`
+	Score           float64   `json:"score"`
+	MaxScore        float64   `json:"max_score"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import "time"
+
+// ReplayRequestedEvent asks the services that own data other services keep
+// copies of to publish it again, so that copies created after the data was,
+// or that otherwise missed its events, catch up. Owners answer with
+// *.replayed events, which carry the usual payloads but are only consumed by
+// the copies, so nothing else reacts to them twice.
+type ReplayRequestedEvent struct {
+	Service     string    `json:"service"`
+	RequestedAt time.Time `json:"requested_at"`
+}
@@ -5,6 +5,7 @@ import "time"
 
 type UserCreatedEvent struct {
 	ID                  string     `json:"id"`
+	OrganizationID      *string    `json:"organization_id,omitempty"`
 	Email               string     `json:"email"`
 	Username            string     `json:"username"`
 	Role                string     `json:"role"`
@@ -14,21 +15,73 @@ type UserCreatedEvent struct {
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 	EmailVerificationURL string     `json:"email_verification_url"`
+	// SetPasswordURL is only set for accounts created without a password,
+	// such as by a bulk import, and lets the user choose one.
+	SetPasswordURL      string     `json:"set_password_url,omitempty"`
 }
 
 type UserUpdatedEvent struct {
 	ID            string     `json:"id"`
+	OrganizationID *string   `json:"organization_id,omitempty"`
 	Email         string     `json:"email"`
 	Username      string     `json:"username"`
 	Role          string     `json:"role"`
 	Status        string     `json:"status"`
 	EmailVerified bool       `json:"email_verified"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	DisplayName   string     `json:"display_name"`
+	AvatarID      *string    `json:"avatar_id,omitempty"`
+	// Timezone and Locale let consumers render dates and messages for the
+	// user.
+	Timezone      string     `json:"timezone"`
+	Locale        string     `json:"locale"`
+	Phone         string     `json:"phone,omitempty"`
+	// GroupIDs lists every group the user belongs to after the update.
+	GroupIDs      []string   `json:"group_ids"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
+// UserDeletedEvent is published when a user is deleted. The account can be
+// restored until RestoreUntil, so services should stop serving the user but
+// keep their data until UserPurgedEvent.
 type UserDeletedEvent struct {
-	ID        string    `json:"id"`
-	DeletedAt time.Time `json:"deleted_at"`
-}
\ No newline at end of file
+	ID           string    `json:"id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+	RestoreUntil time.Time `json:"restore_until"`
+}
+
+type UserRestoredEvent struct {
+	ID         string    `json:"id"`
+	RestoredAt time.Time `json:"restored_at"`
+}
+
+// UserPurgedEvent is published once a deleted user is removed for good.
+// Services then remove or anonymize what they hold about the user.
+type UserPurgedEvent struct {
+	ID       string    `json:"id"`
+	Role     string    `json:"role"`
+	PurgedAt time.Time `json:"purged_at"`
+}
+
+// UserSuspendedEvent is published when an admin suspends a user. ExpiresAt is
+// nil for a suspension that lasts until an admin lifts it.
+type UserSuspendedEvent struct {
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	Username     string     `json:"username"`
+	SuspensionID string     `json:"suspension_id"`
+	Reason       string     `json:"reason"`
+	SuspendedBy  string     `json:"suspended_by"`
+	SuspendedAt  time.Time  `json:"suspended_at"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+// UserReinstatedEvent is published when a suspension ends. LiftedBy is empty
+// when the suspension expired.
+type UserReinstatedEvent struct {
+	ID           string    `json:"id"`
+	SuspensionID string    `json:"suspension_id"`
+	LiftedBy     string    `json:"lifted_by,omitempty"`
+	ReinstatedAt time.Time `json:"reinstated_at"`
+}
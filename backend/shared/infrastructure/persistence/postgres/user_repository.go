@@ -8,11 +8,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
 )
 
+// userColumns is the column order scanRowToEntity expects.
+const userColumns = `
+	id, email, username, password_hash, role, status, email_verified, email_verified_at,
+	display_name, bio, avatar_id, timezone, locale, phone, created_at, updated_at, deleted_at,
+	organization_id
+`
+
+// userGroupIDsColumn follows userColumns when reading users and lists the
+// groups each one belongs to.
+const userGroupIDsColumn = `,
+	ARRAY(SELECT group_id::text FROM user_group_members WHERE user_id = users.id ORDER BY group_id) AS group_ids
+`
+
 type PostgresUserRepository struct {
 	db *sql.DB
 }
@@ -22,10 +37,10 @@ func NewPostgresUserRepository(db *sql.DB) repositories.UserRepository {
 }
 
 func (r *PostgresUserRepository) Create(ctx context.Context, user *entities.User) error {
+	user.OrganizationID = tenant.Owner(ctx, user.OrganizationID)
 	query := `
-		INSERT INTO users (
-			id, email, username, password_hash, role, status, email_verified, email_verified_at, created_at, updated_at
-		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO users (`+userColumns+`)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
@@ -36,30 +51,37 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *entities.User
 		user.Status.String(),
 		user.EmailVerified,
 		user.EmailVerifiedAt,
+		user.DisplayName,
+		user.Bio,
+		user.AvatarID,
+		user.Timezone,
+		user.Locale,
+		user.Phone,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.DeletedAt,
+		user.OrganizationID,
 	)
 	return err
 }
 
 func (r *PostgresUserRepository) FindByID(ctx context.Context, id string) (*entities.User, error) {
 	query := `
-		SELECT
-			id, email, username, password_hash, role, status, email_verified, email_verified_at, created_at, updated_at
+		SELECT `+userColumns+userGroupIDsColumn+`
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
+			AND ($2::uuid IS NULL OR organization_id = $2)
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
 	return scanRowToEntity(row, nil)
 }
 
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	query := `
-		SELECT
-			id, email, username, password_hash, role, status, email_verified, email_verified_at, created_at, updated_at
+		SELECT `+userColumns+userGroupIDsColumn+`
 		FROM users
-		WHERE email = $1
+		WHERE email = $1 AND deleted_at IS NULL
 	`
 
 	row := r.db.QueryRowContext(ctx, query, email)
@@ -68,9 +90,9 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 
 func (r *PostgresUserRepository) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
 	query := `
-		SELECT id, email, username, password_hash, role, status, email_verified, email_verified_at, created_at, updated_at
+		SELECT `+userColumns+userGroupIDsColumn+`
 		FROM users
-		WHERE username = $1
+		WHERE username = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -89,8 +111,15 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entities.User
 			status = $5,
 			email_verified = $6,
 			email_verified_at = $7,
-			updated_at = $8
-		WHERE id = $9
+			display_name = $8,
+			bio = $9,
+			avatar_id = $10,
+			timezone = $11,
+			locale = $12,
+			phone = $13,
+			organization_id = $14,
+			updated_at = $15
+		WHERE id = $16 AND ($17::uuid IS NULL OR organization_id = $17)
 	`
 
 	_, err := r.db.ExecContext(
@@ -102,8 +131,16 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entities.User
 		user.Status.String(),
 		user.EmailVerified,
 		user.EmailVerifiedAt,
+		user.DisplayName,
+		user.Bio,
+		user.AvatarID,
+		user.Timezone,
+		user.Locale,
+		user.Phone,
+		user.OrganizationID,
 		user.UpdatedAt,
 		user.ID,
+		tenant.Arg(ctx),
 	)
 	return err
 }
@@ -111,9 +148,9 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entities.User
 func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
 	query := `
 		DELETE FROM users
-		WHERE id = $1
+		WHERE id = $1 AND ($2::uuid IS NULL OR organization_id = $2)
 	`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := r.db.ExecContext(ctx, query, id, tenant.Arg(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -121,18 +158,95 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func buildWhereClause(query repositories.UserQuery) (string, []interface{}) {
-	clauses := []string{}
+func (r *PostgresUserRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET deleted_at = $1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+			AND ($3::uuid IS NULL OR organization_id = $3)
+	`
+	_, err := r.db.ExecContext(ctx, query, deletedAt, id, tenant.Arg(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to soft delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) Restore(ctx context.Context, id string) error {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+			AND ($2::uuid IS NULL OR organization_id = $2)
+	`
+	_, err := r.db.ExecContext(ctx, query, id, tenant.Arg(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) FindDeletedByID(ctx context.Context, id string) (*entities.User, error) {
+	query := `
+		SELECT ` + userColumns + userGroupIDsColumn + `
+		FROM users
+		WHERE id = $1 AND deleted_at IS NOT NULL
+			AND ($2::uuid IS NULL OR organization_id = $2)
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id, tenant.Arg(ctx))
+	return scanRowToEntity(row, nil)
+}
+
+func (r *PostgresUserRepository) FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.User, error) {
+	query := `
+		SELECT ` + userColumns + userGroupIDsColumn + `
+		FROM users
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*entities.User, 0, limit)
+	for rows.Next() {
+		user, err := scanRowToEntity(nil, rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return users, nil
+}
+
+func buildWhereClause(ctx context.Context, query repositories.UserQuery) (string, []interface{}) {
+	clauses := []string{"deleted_at IS NULL"}
 	args := []interface{}{}
 	argIdx := 1
 	whereClause := ""
 
+	if query.Deleted {
+		clauses[0] = "deleted_at IS NOT NULL"
+	}
+
 	// Search query
 	if query.SearchQuery != nil && *query.SearchQuery != "" {
-		clauses = append(clauses, fmt.Sprintf("(email ILIKE $%d OR username ILIKE $%d)", argIdx, argIdx+1))
+		clauses = append(clauses, fmt.Sprintf("(email ILIKE $%d OR username ILIKE $%d OR display_name ILIKE $%d)", argIdx, argIdx+1, argIdx+2))
 		searchPattern := "%" + *query.SearchQuery + "%"
-		args = append(args, searchPattern, searchPattern)
-		argIdx += 2
+		args = append(args, searchPattern, searchPattern, searchPattern)
+		argIdx += 3
 	}
 
 	// Role filter
@@ -149,10 +263,26 @@ func buildWhereClause(query repositories.UserQuery) (string, []interface{}) {
 		argIdx++
 	}
 
-	if len(clauses) > 0 {
-		whereClause = " WHERE " + strings.Join(clauses, " AND ")
+	// Group filter
+	if query.GroupID != nil && *query.GroupID != "" {
+		clauses = append(clauses, fmt.Sprintf("id IN (SELECT user_id FROM user_group_members WHERE group_id = $%d)", argIdx))
+		args = append(args, *query.GroupID)
+		argIdx++
+	}
+
+	// Organization filter
+	organizationID := tenant.OrganizationID(ctx)
+	if organizationID == "" && query.OrganizationID != nil {
+		organizationID = *query.OrganizationID
+	}
+	if organizationID != "" {
+		clauses = append(clauses, fmt.Sprintf("organization_id = $%d", argIdx))
+		args = append(args, organizationID)
+		argIdx++
 	}
 
+	whereClause = " WHERE " + strings.Join(clauses, " AND ")
+
 	return whereClause, args
 }
 
@@ -160,9 +290,9 @@ func (r *PostgresUserRepository) UpdatePassword(ctx context.Context, userID, pas
 	query := `
 		UPDATE users
 		SET password_hash = $1
-		WHERE id = $2
+		WHERE id = $2 AND ($3::uuid IS NULL OR organization_id = $3)
 	`
-	_, err := r.db.ExecContext(ctx, query, passwordHash, userID)
+	_, err := r.db.ExecContext(ctx, query, passwordHash, userID, tenant.Arg(ctx))
 	return err
 }
 
@@ -197,6 +327,7 @@ func buildOrderClause(query repositories.UserQuery) string {
 		"status":     true,
 		"created_at": true,
 		"updated_at": true,
+		"deleted_at": true,
 	}
 	if !allowedColumns[column] {
 		return ""
@@ -214,18 +345,18 @@ func buildOrderClause(query repositories.UserQuery) string {
 		}
 	}
 
-	return fmt.Sprintf(" ORDER BY %s %s", column, direction)
+	// id breaks ties so pages read with OFFSET neither repeat nor skip users.
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
 }
 
 func (r *PostgresUserRepository) Find(ctx context.Context, query repositories.UserQuery) (*repositories.UserQueryResult, error) {
 	var queryBuilder strings.Builder
-	whereClause, whereArgs := buildWhereClause(query)
+	whereClause, whereArgs := buildWhereClause(ctx, query)
 	args := whereArgs
 	argIdx := len(args) + 1
 
 	queryBuilder.WriteString(`
-		SELECT
-			id, email, username, password_hash, role, status, email_verified, email_verified_at, created_at, updated_at
+		SELECT `+userColumns+userGroupIDsColumn+`
 		FROM users
 	`)
 	queryBuilder.WriteString(whereClause)
@@ -299,14 +430,23 @@ func scanRowToEntity(row *sql.Row, rows *sql.Rows) (*entities.User, error) {
 		status         string
 		emailVerified  bool
 		emailVerifiedAt sql.NullTime
+		displayName    string
+		bio            string
+		avatarID       sql.NullString
+		timezone       string
+		locale         string
+		phone          string
 		createdAt      time.Time
 		updatedAt      time.Time
+		deletedAt      sql.NullTime
+		organizationID sql.NullString
+		groupIDs       []string
 	)
 	var err error
 	if row != nil {
-		err = row.Scan(&userID, &email, &username, &passwordHash, &role, &status, &emailVerified, &emailVerifiedAt, &createdAt, &updatedAt)
+		err = row.Scan(&userID, &email, &username, &passwordHash, &role, &status, &emailVerified, &emailVerifiedAt, &displayName, &bio, &avatarID, &timezone, &locale, &phone, &createdAt, &updatedAt, &deletedAt, &organizationID, pq.Array(&groupIDs))
 	} else if rows != nil {
-		err = rows.Scan(&userID, &email, &username, &passwordHash, &role, &status, &emailVerified, &emailVerifiedAt, &createdAt, &updatedAt)
+		err = rows.Scan(&userID, &email, &username, &passwordHash, &role, &status, &emailVerified, &emailVerifiedAt, &displayName, &bio, &avatarID, &timezone, &locale, &phone, &createdAt, &updatedAt, &deletedAt, &organizationID, pq.Array(&groupIDs))
 	}
 
 	if err == sql.ErrNoRows {
@@ -326,8 +466,24 @@ func scanRowToEntity(row *sql.Row, rows *sql.Rows) (*entities.User, error) {
 		verifiedAt = &emailVerifiedAt.Time
 	}
 
+	var avatar *string
+	if avatarID.Valid {
+		avatar = &avatarID.String
+	}
+
+	var deleted *time.Time
+	if deletedAt.Valid {
+		deleted = &deletedAt.Time
+	}
+
+	var organization *string
+	if organizationID.Valid {
+		organization = &organizationID.String
+	}
+
 	return &entities.User{
 		ID:             userID,
+		OrganizationID: organization,
 		Email:          emailVO,
 		Username:       username,
 		PasswordHash:   passwordHash,
@@ -335,8 +491,16 @@ func scanRowToEntity(row *sql.Row, rows *sql.Rows) (*entities.User, error) {
 		Status:         statusVO,
 		EmailVerified:  emailVerified,
 		EmailVerifiedAt: verifiedAt,
+		DisplayName:    displayName,
+		Bio:            bio,
+		AvatarID:       avatar,
+		Timezone:       timezone,
+		Locale:         locale,
+		Phone:          phone,
 		CreatedAt:      createdAt,
 		UpdatedAt:      updatedAt,
+		DeletedAt:      deleted,
+		GroupIDs:       groupIDs,
 	}, nil
 }
 
@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/utils"
+	"go.uber.org/zap"
+)
+
+// Context keys set by Authenticate for the authenticated caller.
+const (
+	ContextUserID    = "user_id"
+	ContextUserEmail = "user_email"
+	ContextUserRole  = "user_role"
+	ContextActorID   = "actor_id"
+	// ContextOrganizationID is empty for callers outside any organization.
+	ContextOrganizationID = "organization_id"
+)
+
+var (
+	ErrInvalidAccessToken = errors.New("invalid or expired access token")
+	ErrInvalidProxy       = errors.New("invalid trusted proxy")
+)
+
+var identityHeaders = []string{UserIDHeader, UserEmailHeader, UserRoleHeader, ActorIDHeader, OrganizationIDHeader}
+
+// ParseTrustedProxies parses IPs and CIDRs, such as the gateway's, for
+// Authenticate. A plain IP trusts that address only.
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidProxy, proxy)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			proxy = fmt.Sprintf("%s/%d", proxy, bits)
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProxy, proxy)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Authenticate identifies the caller without depending on the gateway having
+// run. An access token is verified locally and checked against the tokens
+// auth-service has revoked. Identity headers are only kept when a trusted
+// proxy sends them for a caller it authenticated some other way, such as an
+// API key; from anyone else they are removed before the handler runs.
+//
+// On success the caller is stored in the context under the Context* keys and
+// in the identity headers, so handlers and policies reading either keep
+// working. Requests without credentials continue anonymously and are left to
+// policy.Require or the handler to refuse.
+func Authenticate(jwtManager *utils.JwtManager, redis utils.RedisInterface, trustedProxies []*net.IPNet, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		trusted := isTrustedProxy(c, trustedProxies) && c.GetHeader(UserIDHeader) != ""
+
+		// Access tokens are verified even behind the gateway, as it passes
+		// client headers through unchanged on routes it does not protect.
+		if isJwt(token) {
+			claims, err := verifyAccessToken(c, jwtManager, redis, token)
+			if err != nil {
+				log.Warn("rejected access token",
+					zap.String("path", c.Request.URL.Path),
+					zap.String("ip", c.ClientIP()),
+					zap.Error(err),
+				)
+				AbortWithError(c, http.StatusUnauthorized, ErrInvalidAccessToken.Error())
+				return
+			}
+			actorID := ""
+			if claims.IsImpersonated() {
+				actorID = claims.Actor.Subject
+			}
+			setIdentity(c, claims.UserID, claims.Email, claims.Role, actorID, claims.OrganizationID)
+			c.Next()
+			return
+		}
+
+		if trusted && token != "" {
+			setIdentity(c,
+				c.GetHeader(UserIDHeader),
+				c.GetHeader(UserEmailHeader),
+				c.GetHeader(UserRoleHeader),
+				c.GetHeader(ActorIDHeader),
+				c.GetHeader(OrganizationIDHeader),
+			)
+			c.Next()
+			return
+		}
+
+		for _, header := range identityHeaders {
+			c.Request.Header.Del(header)
+		}
+		if token != "" {
+			// API keys can only be checked by auth-service, which these
+			// callers skipped by not going through the gateway.
+			AbortWithError(c, http.StatusUnauthorized, ErrInvalidAccessToken.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuthenticateFromConfig builds Authenticate from a service's configuration.
+func AuthenticateFromConfig(cfg config.TokenAuthConfig, redis utils.RedisInterface, log *logger.Logger) (gin.HandlerFunc, error) {
+	jwtManager, err := utils.NewJwtVerifier(cfg.SecretKey, cfg.SigningKeysDir, cfg.AcceptHS256Until)
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return Authenticate(jwtManager, redis, trustedProxies, log), nil
+}
+
+// AuthenticateWithRedis connects to Redis, which holds the access tokens
+// auth-service has issued, and builds Authenticate from a service's
+// configuration. Without Redis only requests authenticated by the gateway are
+// accepted, and store is nil. closeStore releases the connection and is safe
+// to call either way.
+func AuthenticateWithRedis(cfg config.TokenAuthConfig, redisCfg *config.RedisConfig, log *logger.Logger) (authenticate gin.HandlerFunc, store utils.RedisInterface, closeStore func(), err error) {
+	closeStore = func() {}
+	redis, err := utils.NewRedis(redisCfg)
+	if err != nil {
+		log.Error("failed to create redis, access tokens will be refused", zap.Error(err))
+	} else {
+		log.Info("redis connected successfully")
+		store = redis
+		closeStore = func() { redis.Close() }
+	}
+
+	authenticate, err = AuthenticateFromConfig(cfg, store, log)
+	if err != nil {
+		closeStore()
+		return nil, nil, func() {}, err
+	}
+	return authenticate, store, closeStore, nil
+}
+
+func verifyAccessToken(c *gin.Context, jwtManager *utils.JwtManager, redis utils.RedisInterface, token string) (utils.JwtClaims, error) {
+	claims, err := jwtManager.VerifyToken(token)
+	if err != nil {
+		return utils.JwtClaims{}, err
+	}
+	if claims.Status != valueobjects.StatusActive.String() {
+		return utils.JwtClaims{}, fmt.Errorf("user status is %q", claims.Status)
+	}
+	// Revoked tokens are removed from Redis, so without it a token cannot be
+	// told apart from one that was signed out.
+	if redis == nil {
+		return utils.JwtClaims{}, errors.New("token revocation store unavailable")
+	}
+	userID, err := redis.GetUserFromAccessToken(c.Request.Context(), token)
+	if err != nil {
+		return utils.JwtClaims{}, err
+	}
+	if userID != claims.UserID {
+		return utils.JwtClaims{}, errors.New("token was issued to another user")
+	}
+	return claims, nil
+}
+
+func setIdentity(c *gin.Context, userID, email, role, actorID, organizationID string) {
+	c.Request.Header.Set(UserIDHeader, userID)
+	c.Request.Header.Set(UserEmailHeader, email)
+	c.Request.Header.Set(UserRoleHeader, role)
+	c.Request.Header.Del(ActorIDHeader)
+	if actorID != "" {
+		c.Request.Header.Set(ActorIDHeader, actorID)
+	}
+	c.Request.Header.Del(OrganizationIDHeader)
+	if organizationID != "" {
+		c.Request.Header.Set(OrganizationIDHeader, organizationID)
+	}
+
+	c.Set(ContextUserID, userID)
+	c.Set(ContextUserEmail, email)
+	c.Set(ContextUserRole, role)
+	c.Set(ContextActorID, actorID)
+	c.Set(ContextOrganizationID, organizationID)
+}
+
+// isTrustedProxy checks the address the connection came from. Forwarding
+// headers such as X-Forwarded-For are ignored as any client can set them.
+func isTrustedProxy(c *gin.Context, trustedProxies []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+	if err != nil {
+		host = c.Request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func bearerToken(c *gin.Context) string {
+	token := c.GetHeader("Authorization")
+	if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+		return strings.TrimSpace(token[7:])
+	}
+	return ""
+}
+
+func isJwt(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/tenant"
+)
+
+// Tenant scopes the request context to the organization in the
+// X-Organization-ID header, so repositories only return that organization's
+// rows. It must run after Authenticate where a service uses it, as the header
+// is only trusted once Authenticate or the gateway has set it.
+//
+// Only super-admins may act outside an organization. Any other authenticated
+// caller without one is refused rather than left unscoped. Anonymous requests
+// continue unscoped and are left to policy.Require or the handler.
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		organizationID := c.GetHeader(OrganizationIDHeader)
+		if organizationID == "" {
+			if c.GetHeader(UserIDHeader) != "" && c.GetHeader(UserRoleHeader) != valueobjects.RoleAdmin.String() {
+				AbortWithError(c, http.StatusForbidden, tenant.ErrNoOrganization.Error())
+				return
+			}
+			c.Next()
+			return
+		}
+		c.Request = c.Request.WithContext(tenant.WithOrganizationID(c.Request.Context(), organizationID))
+		c.Next()
+	}
+}
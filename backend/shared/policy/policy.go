@@ -0,0 +1,228 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/valueobjects"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/logger"
+	"github.com/paingphyoaungkhant/asto-microservice/shared/middleware"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("you do not have permission to perform this action")
+)
+
+const maxPolicyBodySize = 1 << 20
+
+// Subject is the caller a policy is evaluated for, as identified by the
+// gateway headers.
+type Subject struct {
+	UserID string
+	Email  string
+	Role   valueobjects.Role
+	// ActorID is the admin acting as the user, if any.
+	ActorID string
+	// OrganizationID is empty for callers outside any organization.
+	OrganizationID string
+}
+
+func SubjectFromRequest(c *gin.Context) Subject {
+	return Subject{
+		UserID:         c.GetHeader(middleware.UserIDHeader),
+		Email:          c.GetHeader(middleware.UserEmailHeader),
+		Role:           valueobjects.Role(c.GetHeader(middleware.UserRoleHeader)),
+		ActorID:        c.GetHeader(middleware.ActorIDHeader),
+		OrganizationID: c.GetHeader(middleware.OrganizationIDHeader),
+	}
+}
+
+func (s Subject) Impersonated() bool {
+	return s.ActorID != ""
+}
+
+func (s Subject) Authenticated() bool {
+	return s.UserID != "" && s.Role != ""
+}
+
+// Rule decides whether subject may perform the request. An error means the
+// decision could not be made, and the request is refused.
+type Rule func(c *gin.Context, subject Subject) (bool, error)
+
+// OwnershipFunc reports whether userID owns, or is otherwise responsible for,
+// the resource with the given ID. A resource that does not exist is not owned.
+type OwnershipFunc func(ctx context.Context, userID, resourceID string) (bool, error)
+
+// ValueFunc extracts a value, usually a resource or user ID, from the request.
+type ValueFunc func(c *gin.Context) (string, error)
+
+// Policy names a rule so denials can be traced back to it in logs and
+// responses.
+type Policy struct {
+	Name string
+	Rule Rule
+}
+
+// Require evaluates policy before the handler runs. Requests without a
+// subject get 401, denied requests get 403 and requests the policy fails to
+// evaluate get 500; the handler is not reached in any of these cases.
+func Require(policy Policy, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject := SubjectFromRequest(c)
+		if !subject.Authenticated() {
+			middleware.AbortWithErrorDetails(c, http.StatusUnauthorized, ErrUnauthenticated.Error(), map[string]interface{}{
+				"policy": policy.Name,
+			})
+			return
+		}
+		c.Set(middleware.ContextUserID, subject.UserID)
+
+		allowed, err := policy.Rule(c, subject)
+		if err != nil {
+			log.Error("failed to evaluate policy",
+				zap.String("policy", policy.Name),
+				zap.String("user_id", subject.UserID),
+				zap.Error(err),
+			)
+			middleware.AbortWithErrorDetails(c, http.StatusInternalServerError, "failed to authorize request", map[string]interface{}{
+				"policy": policy.Name,
+			})
+			return
+		}
+		if !allowed {
+			log.Warn("request denied by policy",
+				zap.String("policy", policy.Name),
+				zap.String("user_id", subject.UserID),
+				zap.String("role", subject.Role.String()),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+			)
+			middleware.AbortWithErrorDetails(c, http.StatusForbidden, ErrForbidden.Error(), map[string]interface{}{
+				"policy": policy.Name,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// HasRole allows subjects with any of roles.
+func HasRole(roles ...valueobjects.Role) Rule {
+	return func(c *gin.Context, subject Subject) (bool, error) {
+		for _, role := range roles {
+			if subject.Role == role {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// AnyOf allows the request when one of rules does. Rules are evaluated in
+// order and evaluation stops at the first that allows it.
+func AnyOf(rules ...Rule) Rule {
+	return func(c *gin.Context, subject Subject) (bool, error) {
+		for _, rule := range rules {
+			allowed, err := rule(c, subject)
+			if err != nil {
+				return false, err
+			}
+			if allowed {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// AllOf allows the request only when every rule does.
+func AllOf(rules ...Rule) Rule {
+	return func(c *gin.Context, subject Subject) (bool, error) {
+		for _, rule := range rules {
+			allowed, err := rule(c, subject)
+			if err != nil {
+				return false, err
+			}
+			if !allowed {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+// Owns allows subjects with role that own the resource identified by id, for
+// example an instructor assigned to the offering in the route.
+func Owns(role valueobjects.Role, id ValueFunc, owns OwnershipFunc) Rule {
+	return func(c *gin.Context, subject Subject) (bool, error) {
+		if subject.Role != role {
+			return false, nil
+		}
+		resourceID, err := id(c)
+		if err != nil {
+			return false, err
+		}
+		if resourceID == "" {
+			return false, nil
+		}
+		return owns(c.Request.Context(), subject.UserID, resourceID)
+	}
+}
+
+// IsSubject allows subjects with role acting on themselves, that is when the
+// user ID the request names is their own.
+func IsSubject(role valueobjects.Role, userID ValueFunc) Rule {
+	return func(c *gin.Context, subject Subject) (bool, error) {
+		if subject.Role != role {
+			return false, nil
+		}
+		id, err := userID(c)
+		if err != nil {
+			return false, err
+		}
+		return id != "" && id == subject.UserID, nil
+	}
+}
+
+func Param(name string) ValueFunc {
+	return func(c *gin.Context) (string, error) {
+		return c.Param(name), nil
+	}
+}
+
+func Query(name string) ValueFunc {
+	return func(c *gin.Context) (string, error) {
+		return c.Query(name), nil
+	}
+}
+
+// JSONField reads a top level string field of the JSON request body. The body
+// is restored afterwards so the handler can still bind it. A body that is not
+// JSON yields an empty value and is left for the handler to reject.
+func JSONField(name string) ValueFunc {
+	return func(c *gin.Context) (string, error) {
+		if c.Request.Body == nil {
+			return "", nil
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPolicyBodySize))
+		if err != nil {
+			return "", err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return "", nil
+		}
+		value, _ := fields[name].(string)
+		return value, nil
+	}
+}
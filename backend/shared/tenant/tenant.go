@@ -0,0 +1,72 @@
+// Package tenant scopes requests to the organization, a partner school
+// hosted on the deployment, that the caller belongs to.
+//
+// The organization travels in the request context. Repositories read it to
+// limit what Find and FindByID return, so use cases do not pass it around.
+// A context without an organization is unscoped and sees every row. Only
+// super-admins, admins who belong to no organization, background jobs and
+// event consumers run unscoped: middleware.Tenant and auth-service's /verify
+// refuse any other caller that belongs to no organization.
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNoOrganization is returned for callers that must be scoped to an
+// organization but belong to none.
+var ErrNoOrganization = errors.New("caller does not belong to an organization")
+
+type contextKey struct{}
+
+// WithOrganizationID returns a copy of ctx scoped to organizationID. An empty
+// ID leaves ctx unscoped.
+func WithOrganizationID(ctx context.Context, organizationID string) context.Context {
+	if organizationID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, organizationID)
+}
+
+// OrganizationID returns the organization ctx is scoped to, or "" if it is
+// unscoped.
+func OrganizationID(ctx context.Context) string {
+	organizationID, _ := ctx.Value(contextKey{}).(string)
+	return organizationID
+}
+
+// Scoped reports whether ctx is limited to a single organization.
+func Scoped(ctx context.Context) bool {
+	return OrganizationID(ctx) != ""
+}
+
+// Arg is the organization ctx is scoped to as a query argument, NULL when it
+// is unscoped. Queries with a fixed shape use it as
+//
+//	($n::uuid IS NULL OR organization_id = $n)
+func Arg(ctx context.Context) sql.NullString {
+	organizationID := OrganizationID(ctx)
+	return sql.NullString{String: organizationID, Valid: organizationID != ""}
+}
+
+// Owner returns the organization a row created through ctx belongs to. Scoped
+// callers always create rows in their own organization; unscoped callers keep
+// the one they chose, if any.
+func Owner(ctx context.Context, organizationID *string) *string {
+	if scoped := OrganizationID(ctx); scoped != "" {
+		return &scoped
+	}
+	return organizationID
+}
+
+// Allows reports whether ctx may see a row that belongs to organizationID.
+// Rows outside any organization are only visible to unscoped callers.
+func Allows(ctx context.Context, organizationID *string) bool {
+	scoped := OrganizationID(ctx)
+	if scoped == "" {
+		return true
+	}
+	return organizationID != nil && *organizationID == scoped
+}
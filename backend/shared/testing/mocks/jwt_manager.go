@@ -11,13 +11,13 @@ type MockJwtManager struct {
 	mock.Mock
 }
 
-func (m *MockJwtManager) GenerateAccessToken(userId, email, role, status string) (string, error) {
-	args := m.Called(userId, email, role, status)
+func (m *MockJwtManager) GenerateAccessToken(userId, email, role, status, sessionID, organizationID string) (string, error) {
+	args := m.Called(userId, email, role, status, sessionID, organizationID)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockJwtManager) GenerateRefreshToken(userId, email, role, status string) (string, error) {
-	args := m.Called(userId, email, role, status)
+func (m *MockJwtManager) GenerateRefreshToken(userId, email, role, status, sessionID, organizationID string) (string, error) {
+	args := m.Called(userId, email, role, status, sessionID, organizationID)
 	return args.String(0), args.Error(1)
 }
 
@@ -35,4 +35,3 @@ func (m *MockJwtManager) RefreshTokenDuration() time.Duration {
 	args := m.Called()
 	return args.Get(0).(time.Duration)
 }
-
@@ -58,6 +58,54 @@ func (m *MockRedis) DeleteUserSession(ctx context.Context, sessionID string) err
 	return args.Error(0)
 }
 
+func (m *MockRedis) ListUserSessions(ctx context.Context, userID string) ([]*utils.SessionData, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]*utils.SessionData)
+	return sessions, args.Error(1)
+}
+
+func (m *MockRedis) StoreRefreshTokenFamily(ctx context.Context, familyID, userID, refreshToken string, expiration time.Duration) error {
+	args := m.Called(ctx, familyID, userID, refreshToken, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) GetRefreshTokenFamily(ctx context.Context, familyID string) (*utils.RefreshTokenFamily, error) {
+	args := m.Called(ctx, familyID)
+	family, _ := args.Get(0).(*utils.RefreshTokenFamily)
+	return family, args.Error(1)
+}
+
+func (m *MockRedis) RotateRefreshTokenFamily(ctx context.Context, familyID, usedToken, newToken string, expiration time.Duration) error {
+	args := m.Called(ctx, familyID, usedToken, newToken, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockRedis) StoreTwoFactorChallenge(ctx context.Context, token string, challenge *utils.TwoFactorChallenge, expiration time.Duration) error {
+	args := m.Called(ctx, token, challenge, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) GetTwoFactorChallenge(ctx context.Context, token string) (*utils.TwoFactorChallenge, error) {
+	args := m.Called(ctx, token)
+	challenge, _ := args.Get(0).(*utils.TwoFactorChallenge)
+	return challenge, args.Error(1)
+}
+
+func (m *MockRedis) RevokeTwoFactorChallenge(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockRedis) UpdateUserSessionTokens(ctx context.Context, sessionID, accessToken, refreshToken string, expiration time.Duration) error {
+	args := m.Called(ctx, sessionID, accessToken, refreshToken, expiration)
+	return args.Error(0)
+}
+
 func (m *MockRedis) ExpireUserSession(ctx context.Context, sessionID string, expiration time.Duration) error {
 	args := m.Called(ctx, sessionID, expiration)
 	return args.Error(0)
@@ -68,26 +116,73 @@ func (m *MockRedis) UpdateLastActivity(ctx context.Context, sessionID string) er
 	return args.Error(0)
 }
 
+func (m *MockRedis) StoreOIDCState(ctx context.Context, state string, data *utils.OIDCState, expiration time.Duration) error {
+	args := m.Called(ctx, state, data, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) ConsumeOIDCState(ctx context.Context, state string) (*utils.OIDCState, error) {
+	args := m.Called(ctx, state)
+	data, _ := args.Get(0).(*utils.OIDCState)
+	return data, args.Error(1)
+}
+
 func (m *MockRedis) StoreForgotPasswordOTP(ctx context.Context, userID, otp string) error {
 	args := m.Called(ctx, userID, otp)
 	return args.Error(0)
 }
 
-func (m *MockRedis) GetUserFromForgotPasswordOTP(ctx context.Context, otp string) (string, error) {
-	args := m.Called(ctx, otp)
-	return args.String(0), args.Error(1)
+func (m *MockRedis) VerifyForgotPasswordOTP(ctx context.Context, userID, otp string, maxAttempts int) error {
+	args := m.Called(ctx, userID, otp, maxAttempts)
+	return args.Error(0)
+}
+
+func (m *MockRedis) RevokeForgotPasswordOTP(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRedis) IncrementAuthFailures(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	args := m.Called(ctx, subject, window)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockRedis) RevokeForgotPasswordOTP(ctx context.Context, otp string) error {
-	args := m.Called(ctx, otp)
+func (m *MockRedis) ResetAuthFailures(ctx context.Context, subject string) error {
+	args := m.Called(ctx, subject)
 	return args.Error(0)
 }
 
+func (m *MockRedis) LockAuthSubject(ctx context.Context, subject string, duration time.Duration) error {
+	args := m.Called(ctx, subject, duration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) GetAuthLockout(ctx context.Context, subject string) (time.Duration, error) {
+	args := m.Called(ctx, subject)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockRedis) ClearAuthLockout(ctx context.Context, subject string) error {
+	args := m.Called(ctx, subject)
+	return args.Error(0)
+}
+
+func (m *MockRedis) AllowRateLimit(ctx context.Context, key string, limit int, window time.Duration) (*utils.RateLimitResult, error) {
+	args := m.Called(ctx, key, limit, window)
+	result, _ := args.Get(0).(*utils.RateLimitResult)
+	return result, args.Error(1)
+}
+
 func (m *MockRedis) StoreResetPasswordToken(ctx context.Context, userID, token string) error {
 	args := m.Called(ctx, userID, token)
 	return args.Error(0)
 }
 
+func (m *MockRedis) StorePasswordSetupToken(ctx context.Context, userID, token string, expiration time.Duration) error {
+	args := m.Called(ctx, userID, token, expiration)
+	return args.Error(0)
+}
+
 func (m *MockRedis) GetUserFromResetPasswordToken(ctx context.Context, token string) (string, error) {
 	args := m.Called(ctx, token)
 	return args.String(0), args.Error(1)
@@ -113,3 +208,43 @@ func (m *MockRedis) RevokeVerifyEmailToken(ctx context.Context, token string) er
 	return args.Error(0)
 }
 
+func (m *MockRedis) StorePendingEmail(ctx context.Context, token, email string) error {
+	args := m.Called(ctx, token, email)
+	return args.Error(0)
+}
+
+func (m *MockRedis) GetPendingEmail(ctx context.Context, token string) (string, error) {
+	args := m.Called(ctx, token)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRedis) RevokePendingEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockRedis) StoreMagicLinkToken(ctx context.Context, userID, token string, expiration time.Duration) error {
+	args := m.Called(ctx, userID, token, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) ConsumeMagicLinkToken(ctx context.Context, token string) (string, error) {
+	args := m.Called(ctx, token)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRedis) CacheUserStatus(ctx context.Context, status *utils.CachedUserStatus, expiration time.Duration) error {
+	args := m.Called(ctx, status, expiration)
+	return args.Error(0)
+}
+
+func (m *MockRedis) GetCachedUserStatus(ctx context.Context, userID string) (*utils.CachedUserStatus, error) {
+	args := m.Called(ctx, userID)
+	status, _ := args.Get(0).(*utils.CachedUserStatus)
+	return status, args.Error(1)
+}
+
+func (m *MockRedis) InvalidateUserStatus(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
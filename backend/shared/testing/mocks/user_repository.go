@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/entities"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/domain/repositories"
@@ -45,6 +46,28 @@ func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SoftDelete(ctx context.Context, id string, deletedAt time.Time) error {
+	args := m.Called(ctx, id, deletedAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindDeletedByID(ctx context.Context, id string) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*entities.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) FindDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*entities.User, error) {
+	args := m.Called(ctx, before, limit)
+	users, _ := args.Get(0).([]*entities.User)
+	return users, args.Error(1)
+}
+
 func (m *MockUserRepository) Find(ctx context.Context, query repositories.UserQuery) (*repositories.UserQueryResult, error) {
 	args := m.Called(ctx, query)
 	result, _ := args.Get(0).(*repositories.UserQueryResult)
@@ -6,3 +6,14 @@ func GenerateEmailVerificationURL(apiGatewayURL, token string) string {
 	return fmt.Sprintf("%s/api/v1/auth/verify-email?token=%s", apiGatewayURL, token)
 }
 
+func GenerateInvitationURL(acceptURL, token string) string {
+	return fmt.Sprintf("%s?token=%s", acceptURL, token)
+}
+
+func GenerateSetPasswordURL(setPasswordURL, token string) string {
+	return fmt.Sprintf("%s?token=%s", setPasswordURL, token)
+}
+
+func GenerateMagicLinkURL(loginURL, token string) string {
+	return fmt.Sprintf("%s?token=%s", loginURL, token)
+}
@@ -1,21 +1,24 @@
 package utils
 
 import (
+	"crypto/rand"
 	"fmt"
-	"math"
-	"math/rand"
+	"math/big"
 )
 
-func GenerateOTP(length int) string {
+// GenerateOTP returns a numeric code of the given length drawn from
+// crypto/rand, zero padded so every code has the same length.
+func GenerateOTP(length int) (string, error) {
 	if length <= 0 {
 		length = 6
 	}
 
-	max := int(math.Pow10(length))
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
 
-	min := int(math.Pow10(length - 1))
-
-	otp := rand.Intn(max-min) + min
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate otp: %w", err)
+	}
 
-	return fmt.Sprintf("%0*d", length, otp)
-}
\ No newline at end of file
+	return fmt.Sprintf("%0*d", length, n), nil
+}
@@ -3,29 +3,66 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrInvalidTokenExpired = errors.New("token expired")
+	ErrInvalidToken              = errors.New("invalid token")
+	ErrInvalidTokenExpired       = errors.New("token expired")
 	ErrInvalidTokenSigningMethod = errors.New("invalid token signing method")
-	ErrInvalidTokenClaims = errors.New("invalid token claims")
+	ErrInvalidTokenClaims        = errors.New("invalid token claims")
+	ErrUnknownSigningKey         = errors.New("unknown signing key")
+	ErrNoSigningKey              = errors.New("no active signing key or HS256 secret configured")
 )
+
 type JwtClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
-	Status string `json:"status"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	SessionID string `json:"session_id,omitempty"`
+	// OrganizationID is the organization the user belongs to, empty for
+	// super-admins who manage every organization.
+	OrganizationID string `json:"org_id,omitempty"`
+	// Actor is set when someone else, e.g. an admin, acts as the user.
+	Actor *JwtActor `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// JwtActor is the "act" claim of RFC 8693: the party actually holding a
+// token issued for another user.
+type JwtActor struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email,omitempty"`
+}
+
+// IsImpersonated reports whether the token was issued to an actor on behalf
+// of the user.
+func (c JwtClaims) IsImpersonated() bool {
+	return c.Actor != nil
+}
+
+// JwtManager issues and verifies tokens. It signs with the shared HS256 secret
+// unless an asymmetric key set is configured, in which case tokens are signed
+// with the active key and carry its id in the "kid" header. Every key in the
+// set is accepted during verification so keys can be rotated without
+// invalidating tokens that are still in flight.
 type JwtManager struct {
 	secretKey            string
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
+
+	// hs256Until is when tokens signed with secretKey stop being accepted.
+	// The zero time means they never do, as when HS256 is the only method.
+	hs256Until time.Time
+
+	mu          sync.RWMutex
+	keys        map[string]*JwtSigningKey
+	activeKeyID string
 }
 
 func NewJwtManager(secretKey string, accessTokenDuration, refreshTokenDuration time.Duration) *JwtManager {
@@ -33,7 +70,46 @@ func NewJwtManager(secretKey string, accessTokenDuration, refreshTokenDuration t
 		secretKey:            secretKey,
 		accessTokenDuration:  accessTokenDuration,
 		refreshTokenDuration: refreshTokenDuration,
+		keys:                 make(map[string]*JwtSigningKey),
+	}
+}
+
+// NewJwtManagerWithKeys creates a manager that signs with the key identified
+// by activeKeyID. HS256 tokens signed with secretKey before the switch to
+// asymmetric keys are accepted until hs256Until, which should leave time for
+// the last refresh token to expire. A zero hs256Until refuses them outright.
+func NewJwtManagerWithKeys(secretKey string, hs256Until time.Time, keys []JwtSigningKey, activeKeyID string, accessTokenDuration, refreshTokenDuration time.Duration) (*JwtManager, error) {
+	if hs256Until.IsZero() {
+		secretKey = ""
 	}
+	m := NewJwtManager(secretKey, accessTokenDuration, refreshTokenDuration)
+	m.hs256Until = hs256Until
+	for i := range keys {
+		if err := m.AddKey(keys[i]); err != nil {
+			return nil, err
+		}
+	}
+	if activeKeyID != "" {
+		if err := m.SetActiveKey(activeKeyID); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// NewJwtVerifier creates a manager for services that only verify tokens. It
+// accepts tokens signed by any key in signingKeysDir, which may hold public
+// keys only, and HS256 tokens signed with secretKey while HS256 is still
+// used: without signingKeysDir, or until hs256Until.
+func NewJwtVerifier(secretKey, signingKeysDir string, hs256Until time.Time) (*JwtManager, error) {
+	if signingKeysDir == "" {
+		return NewJwtManager(secretKey, 0, 0), nil
+	}
+	keys, err := LoadJwtSigningKeys(signingKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewJwtManagerWithKeys(secretKey, hs256Until, keys, "", 0, 0)
 }
 
 func (m *JwtManager) AccessTokenDuration() time.Duration {
@@ -44,13 +120,16 @@ func (m *JwtManager) RefreshTokenDuration() time.Duration {
 	return m.refreshTokenDuration
 }
 
-func (m *JwtManager) GenerateAccessToken(userId, email, role, status string) (string, error) {
+func (m *JwtManager) GenerateAccessToken(userId, email, role, status, sessionID, organizationID string) (string, error) {
 	claims := JwtClaims{
-		UserID: userId,
-		Email:  email,
-		Role:   role,
-		Status: status,
+		UserID:         userId,
+		Email:          email,
+		Role:           role,
+		Status:         status,
+		SessionID:      sessionID,
+		OrganizationID: organizationID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userId,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -58,29 +137,59 @@ func (m *JwtManager) GenerateAccessToken(userId, email, role, status string) (st
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(m.secretKey))
+	tokenString, err := m.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate access token: %w", err)
 	}
 	return tokenString, nil
 }
 
-func (m *JwtManager) GenerateRefreshToken(userId, email, role, status string) (string, error) {
+// GenerateImpersonationToken issues an access token for the user that names
+// actor as its real holder. It lasts for duration rather than the normal
+// access token lifetime and has no refresh token.
+func (m *JwtManager) GenerateImpersonationToken(userId, email, role, status, sessionID, organizationID string, actor JwtActor, duration time.Duration) (string, error) {
+	now := time.Now()
+	claims := JwtClaims{
+		UserID:         userId,
+		Email:          email,
+		Role:           role,
+		Status:         status,
+		SessionID:      sessionID,
+		OrganizationID: organizationID,
+		Actor:          &actor,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userId,
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	tokenString, err := m.sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+	return tokenString, nil
+}
+
+func (m *JwtManager) GenerateRefreshToken(userId, email, role, status, sessionID, organizationID string) (string, error) {
 	claims := JwtClaims{
-		UserID: userId,
-		Email:  email,
-		Role:   role,
-		Status: status,
+		UserID:         userId,
+		Email:          email,
+		Role:           role,
+		Status:         status,
+		SessionID:      sessionID,
+		OrganizationID: organizationID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   userId,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(m.secretKey))
+	tokenString, err := m.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -89,14 +198,9 @@ func (m *JwtManager) GenerateRefreshToken(userId, email, role, status string) (s
 
 func (m *JwtManager) VerifyToken(tokenString string) (JwtClaims, error) {
 	token, err := jwt.ParseWithClaims(
-		tokenString, 
+		tokenString,
 		&JwtClaims{},
-		 func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, ErrInvalidTokenSigningMethod
-			}
-			return []byte(m.secretKey), nil
-		},
+		m.verificationKey,
 	)
 	if err != nil {
 		return JwtClaims{}, ErrInvalidToken
@@ -109,5 +213,44 @@ func (m *JwtManager) VerifyToken(tokenString string) (JwtClaims, error) {
 		return JwtClaims{}, ErrInvalidTokenExpired
 	}
 	return *claims, nil
-} 
+}
 
+func (m *JwtManager) sign(claims JwtClaims) (string, error) {
+	m.mu.RLock()
+	key := m.keys[m.activeKeyID]
+	m.mu.RUnlock()
+
+	if key == nil {
+		// An empty HMAC key would produce tokens anyone can forge.
+		if m.secretKey == "" {
+			return "", ErrNoSigningKey
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(m.secretKey))
+	}
+
+	token := jwt.NewWithClaims(key.signingMethod(), claims)
+	token.Header["kid"] = key.KeyID
+	return token.SignedString(key.PrivateKey)
+}
+
+func (m *JwtManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if m.secretKey == "" || (!m.hs256Until.IsZero() && time.Now().After(m.hs256Until)) {
+			return nil, ErrInvalidTokenSigningMethod
+		}
+		return []byte(m.secretKey), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	m.mu.RLock()
+	key := m.keys[kid]
+	m.mu.RUnlock()
+	if key == nil {
+		return nil, ErrUnknownSigningKey
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, ErrInvalidTokenSigningMethod
+	}
+	return key.PublicKey, nil
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	JwtAlgorithmHS256 = "HS256"
+	JwtAlgorithmRS256 = "RS256"
+	JwtAlgorithmEdDSA = "EdDSA"
+)
+
+var (
+	ErrInvalidSigningKey    = errors.New("invalid signing key")
+	ErrSigningKeyIDRequired = errors.New("signing key id is required")
+	ErrSigningKeyNotPrivate = errors.New("signing key has no private key")
+	ErrActiveSigningKey     = errors.New("cannot remove the active signing key")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
+// JwtSigningKey is an asymmetric key used to sign or verify tokens.
+// PrivateKey is nil for keys that are only kept around to verify tokens
+// issued before a rotation.
+type JwtSigningKey struct {
+	KeyID      string
+	Algorithm  string
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+func (k *JwtSigningKey) signingMethod() jwt.SigningMethod {
+	if k.Algorithm == JwtAlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JSONWebKey is the public part of a signing key as published in a JWKS document.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// PublicKey decodes the verification key described by an RSA or Ed25519 JWK.
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSigningKey, k.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSigningKey, k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSigningKey, k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, k.Kty)
+	}
+}
+
+// ParseJwtSigningKeyPEM parses an RSA or Ed25519 key in PEM format. Private keys
+// can sign and verify, public keys can only verify.
+func ParseJwtSigningKeyPEM(keyID string, data []byte) (JwtSigningKey, error) {
+	if keyID == "" {
+		return JwtSigningKey{}, ErrSigningKeyIDRequired
+	}
+
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return JwtSigningKey{KeyID: keyID, Algorithm: JwtAlgorithmRS256, PrivateKey: key, PublicKey: &key.PublicKey}, nil
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		edKey := key.(ed25519.PrivateKey)
+		return JwtSigningKey{KeyID: keyID, Algorithm: JwtAlgorithmEdDSA, PrivateKey: edKey, PublicKey: edKey.Public()}, nil
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return JwtSigningKey{KeyID: keyID, Algorithm: JwtAlgorithmRS256, PublicKey: key}, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return JwtSigningKey{KeyID: keyID, Algorithm: JwtAlgorithmEdDSA, PublicKey: key}, nil
+	}
+	return JwtSigningKey{}, fmt.Errorf("%w: %s", ErrInvalidSigningKey, keyID)
+}
+
+// LoadJwtSigningKeys reads every *.pem file in dir. The file name without the
+// extension is used as the key id.
+func LoadJwtSigningKeys(dir string) ([]JwtSigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	sort.Strings(paths)
+
+	keys := make([]JwtSigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key %s: %w", path, err)
+		}
+		keyID := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		key, err := ParseJwtSigningKeyPEM(keyID, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// AddKey registers a key for verification. It does not change the active key.
+func (m *JwtManager) AddKey(key JwtSigningKey) error {
+	if key.KeyID == "" {
+		return ErrSigningKeyIDRequired
+	}
+	switch key.Algorithm {
+	case JwtAlgorithmRS256:
+		if _, ok := key.PublicKey.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidSigningKey, key.KeyID)
+		}
+	case JwtAlgorithmEdDSA:
+		if _, ok := key.PublicKey.(ed25519.PublicKey); !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidSigningKey, key.KeyID)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, key.Algorithm)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[key.KeyID] = &key
+	return nil
+}
+
+// SetActiveKey switches signing to a previously added key.
+func (m *JwtManager) SetActiveKey(keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key, ok := m.keys[keyID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSigningKey, keyID)
+	}
+	if key.PrivateKey == nil {
+		return fmt.Errorf("%w: %s", ErrSigningKeyNotPrivate, keyID)
+	}
+	m.activeKeyID = keyID
+	return nil
+}
+
+// RotateKey adds key and makes it the active signing key. The previous key
+// stays available for verification until it is removed with RemoveKey.
+func (m *JwtManager) RotateKey(key JwtSigningKey) error {
+	if err := m.AddKey(key); err != nil {
+		return err
+	}
+	return m.SetActiveKey(key.KeyID)
+}
+
+// RemoveKey retires a key. Tokens signed with it no longer verify.
+func (m *JwtManager) RemoveKey(keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if keyID == m.activeKeyID {
+		return ErrActiveSigningKey
+	}
+	delete(m.keys, keyID)
+	return nil
+}
+
+func (m *JwtManager) ActiveKeyID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.activeKeyID
+}
+
+// JWKS returns the public keys accepted by the manager. The HS256 secret is
+// never published.
+func (m *JwtManager) JWKS() JSONWebKeySet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(m.keys))}
+	for _, key := range m.keys {
+		jwk := JSONWebKey{Use: "sig", Kid: key.KeyID, Alg: key.Algorithm}
+		switch pub := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -2,11 +2,16 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/paingphyoaungkhant/asto-microservice/shared/config"
 	"github.com/redis/go-redis/v9"
 )
@@ -16,9 +21,19 @@ const (
 	RedisKeyAccessToken        = "auth:token:access:%s"
 	RedisKeyRefreshToken       = "auth:token:refresh:%s"
 	RedisKeySession            = "auth:session:%s"
+	RedisKeyUserSessions       = "auth:user_sessions:%s"
+	RedisKeyRefreshTokenFamily = "auth:refresh_family:%s"
+	RedisKeyTwoFactorChallenge = "auth:two_factor_challenge:%s"
+	RedisKeyOIDCState          = "auth:oidc_state:%s"
 	RedisKeyForgotPasswordOTP = "auth:forgot_password_otp:%s"
+	RedisKeyAuthFailures       = "auth:failures:%s"
+	RedisKeyAuthLockout        = "auth:lockout:%s"
+	RedisKeyRateLimit          = "ratelimit:%s"
 	RedisKeyResetPassword      = "auth:reset_password:%s"
 	RedisKeyVerifyEmail        = "auth:verify_email:%s"
+	RedisKeyPendingEmail       = "auth:pending_email:%s"
+	RedisKeyMagicLink          = "auth:magic_link:%s"
+	RedisKeyUserStatus         = "auth:user_status:%s"
 )
 
 type RedisInterface interface {
@@ -31,17 +46,43 @@ type RedisInterface interface {
 	StoreUserSession(ctx context.Context, sessionID, userID, accessToken, refreshToken, ipAddress, userAgent string, expiration time.Duration) error
 	GetUserSession(ctx context.Context, sessionID string) (*SessionData, error)
 	DeleteUserSession(ctx context.Context, sessionID string) error
+	ListUserSessions(ctx context.Context, userID string) ([]*SessionData, error)
+	UpdateUserSessionTokens(ctx context.Context, sessionID, accessToken, refreshToken string, expiration time.Duration) error
 	ExpireUserSession(ctx context.Context, sessionID string, expiration time.Duration) error
 	UpdateLastActivity(ctx context.Context, sessionID string) error
+	StoreRefreshTokenFamily(ctx context.Context, familyID, userID, refreshToken string, expiration time.Duration) error
+	GetRefreshTokenFamily(ctx context.Context, familyID string) (*RefreshTokenFamily, error)
+	RotateRefreshTokenFamily(ctx context.Context, familyID, usedToken, newToken string, expiration time.Duration) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	StoreTwoFactorChallenge(ctx context.Context, token string, challenge *TwoFactorChallenge, expiration time.Duration) error
+	GetTwoFactorChallenge(ctx context.Context, token string) (*TwoFactorChallenge, error)
+	RevokeTwoFactorChallenge(ctx context.Context, token string) error
+	StoreOIDCState(ctx context.Context, state string, data *OIDCState, expiration time.Duration) error
+	ConsumeOIDCState(ctx context.Context, state string) (*OIDCState, error)
 	StoreForgotPasswordOTP(ctx context.Context, userID, otp string) error
-	GetUserFromForgotPasswordOTP(ctx context.Context, otp string) (string, error)
-	RevokeForgotPasswordOTP(ctx context.Context, otp string) error
+	VerifyForgotPasswordOTP(ctx context.Context, userID, otp string, maxAttempts int) error
+	RevokeForgotPasswordOTP(ctx context.Context, userID string) error
+	IncrementAuthFailures(ctx context.Context, subject string, window time.Duration) (int64, error)
+	ResetAuthFailures(ctx context.Context, subject string) error
+	LockAuthSubject(ctx context.Context, subject string, duration time.Duration) error
+	GetAuthLockout(ctx context.Context, subject string) (time.Duration, error)
+	ClearAuthLockout(ctx context.Context, subject string) error
+	AllowRateLimit(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error)
 	StoreResetPasswordToken(ctx context.Context, userID, token string) error
+	StorePasswordSetupToken(ctx context.Context, userID, token string, expiration time.Duration) error
 	GetUserFromResetPasswordToken(ctx context.Context, token string) (string, error)
 	RevokeResetPasswordToken(ctx context.Context, token string) error
 	StoreVerifyEmailToken(ctx context.Context, userID, token string) error
 	GetUserFromVerifyEmailToken(ctx context.Context, token string) (string, error)
 	RevokeVerifyEmailToken(ctx context.Context, token string) error
+	StorePendingEmail(ctx context.Context, token, email string) error
+	GetPendingEmail(ctx context.Context, token string) (string, error)
+	RevokePendingEmail(ctx context.Context, token string) error
+	StoreMagicLinkToken(ctx context.Context, userID, token string, expiration time.Duration) error
+	ConsumeMagicLinkToken(ctx context.Context, token string) (string, error)
+	CacheUserStatus(ctx context.Context, status *CachedUserStatus, expiration time.Duration) error
+	GetCachedUserStatus(ctx context.Context, userID string) (*CachedUserStatus, error)
+	InvalidateUserStatus(ctx context.Context, userID string) error
 }
 
 type Redis struct {
@@ -241,7 +282,26 @@ func (r *Redis) StoreUserSession(ctx context.Context, sessionID, userID, accessT
 		ExpiresAt:    now.Add(expiration).Unix(),
 		LastActivity: now.Unix(),
 	}
-	return r.Set(ctx, key, sessionData, expiration)
+	if err := r.Set(ctx, key, sessionData, expiration); err != nil {
+		return err
+	}
+	return r.addUserSessionID(ctx, userID, sessionID, expiration)
+}
+
+func (r *Redis) addUserSessionID(ctx context.Context, userID, sessionID string, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyUserSessions, userID)
+	if err := r.client.SAdd(ctx, key, sessionID).Err(); err != nil {
+		return fmt.Errorf("failed to index user session: %w", err)
+	}
+	// The index lives as long as the longest session it holds.
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get user session index ttl: %w", err)
+	}
+	if ttl < expiration {
+		return r.Expire(ctx, key, expiration)
+	}
+	return nil
 }
 
 
@@ -256,9 +316,54 @@ func (r *Redis) GetUserSession(ctx context.Context, sessionID string) (*SessionD
 
 func (r *Redis) DeleteUserSession(ctx context.Context, sessionID string) error {
 	key := fmt.Sprintf(RedisKeySession, sessionID)
+	if session, err := r.GetUserSession(ctx, sessionID); err == nil {
+		indexKey := fmt.Sprintf(RedisKeyUserSessions, session.UserID)
+		if err := r.client.SRem(ctx, indexKey, sessionID).Err(); err != nil {
+			return fmt.Errorf("failed to remove session from user index: %w", err)
+		}
+	}
 	return r.Delete(ctx, key)
 }
 
+func (r *Redis) ListUserSessions(ctx context.Context, userID string) ([]*SessionData, error) {
+	indexKey := fmt.Sprintf(RedisKeyUserSessions, userID)
+	sessionIDs, err := r.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	sessions := make([]*SessionData, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := r.GetUserSession(ctx, sessionID)
+		if err != nil {
+			// Session expired on its own; drop it from the index.
+			r.client.SRem(ctx, indexKey, sessionID)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *Redis) UpdateUserSessionTokens(ctx context.Context, sessionID, accessToken, refreshToken string, expiration time.Duration) error {
+	session, err := r.GetUserSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session for token update: %w", err)
+	}
+
+	now := time.Now()
+	session.AccessToken = accessToken
+	session.RefreshToken = refreshToken
+	session.ExpiresAt = now.Add(expiration).Unix()
+	session.LastActivity = now.Unix()
+
+	key := fmt.Sprintf(RedisKeySession, sessionID)
+	if err := r.Set(ctx, key, session, expiration); err != nil {
+		return err
+	}
+	return r.addUserSessionID(ctx, session.UserID, sessionID, expiration)
+}
+
 func (r *Redis) ExpireUserSession(ctx context.Context, sessionID string, expiration time.Duration) error {
 	key := fmt.Sprintf(RedisKeySession, sessionID)
 	return r.Expire(ctx, key, expiration)
@@ -275,22 +380,384 @@ func (r *Redis) UpdateLastActivity(ctx context.Context, sessionID string) error
 	return r.Set(ctx, key, session, time.Until(time.Unix(session.ExpiresAt, 0)))
 }
 
+/*	------------------------------------------- Refresh Token Family Management ------------------------------------------- */
+
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+// RefreshTokenFamily tracks the chain of refresh tokens issued for one login.
+// Only the latest token in the chain is valid; presenting any earlier one
+// means the token was replayed.
+type RefreshTokenFamily struct {
+	FamilyID         string `json:"family_id"`
+	UserID           string `json:"user_id"`
+	CurrentTokenHash string `json:"current_token_hash"`
+	Generation       int    `json:"generation"`
+	CreatedAt        int64  `json:"created_at"`
+	RotatedAt        int64  `json:"rotated_at"`
+}
+
+func (f *RefreshTokenFamily) IsCurrent(refreshToken string) bool {
+	return subtle.ConstantTimeCompare([]byte(f.CurrentTokenHash), []byte(HashToken(refreshToken))) == 1
+}
+
+// HashToken returns the hex encoded SHA-256 of a token, so tokens can be
+// compared without keeping them in plain text.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *Redis) StoreRefreshTokenFamily(ctx context.Context, familyID, userID, refreshToken string, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyRefreshTokenFamily, familyID)
+	now := time.Now().Unix()
+	family := RefreshTokenFamily{
+		FamilyID:         familyID,
+		UserID:           userID,
+		CurrentTokenHash: HashToken(refreshToken),
+		CreatedAt:        now,
+		RotatedAt:        now,
+	}
+	return r.Set(ctx, key, family, expiration)
+}
+
+func (r *Redis) GetRefreshTokenFamily(ctx context.Context, familyID string) (*RefreshTokenFamily, error) {
+	key := fmt.Sprintf(RedisKeyRefreshTokenFamily, familyID)
+	var family RefreshTokenFamily
+	if err := r.GetJSON(ctx, key, &family); err != nil {
+		return nil, fmt.Errorf("failed to get refresh token family: %w", err)
+	}
+	return &family, nil
+}
+
+// RotateRefreshTokenFamily replaces the current token of the family with
+// newToken. It returns ErrRefreshTokenReused if usedToken is not the current
+// token, including when two requests race to rotate the same token.
+func (r *Redis) RotateRefreshTokenFamily(ctx context.Context, familyID, usedToken, newToken string, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyRefreshTokenFamily, familyID)
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		val, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get refresh token family: %w", err)
+		}
+
+		var family RefreshTokenFamily
+		if err := json.Unmarshal([]byte(val), &family); err != nil {
+			return fmt.Errorf("failed to unmarshal refresh token family: %w", err)
+		}
+		if !family.IsCurrent(usedToken) {
+			return ErrRefreshTokenReused
+		}
+
+		family.CurrentTokenHash = HashToken(newToken)
+		family.Generation++
+		family.RotatedAt = time.Now().Unix()
+		data, err := json.Marshal(family)
+		if err != nil {
+			return fmt.Errorf("failed to marshal refresh token family: %w", err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, expiration)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrRefreshTokenReused
+	}
+	return err
+}
+
+func (r *Redis) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	key := fmt.Sprintf(RedisKeyRefreshTokenFamily, familyID)
+	return r.Delete(ctx, key)
+}
+
+/*	------------------------------------------- Two Factor Challenge Management ------------------------------------------- */
+
+// TwoFactorChallenge is the state between the password step and the code step
+// of a login. SetupRequired is set when the user's role enforces 2FA but the
+// user has not enrolled yet.
+type TwoFactorChallenge struct {
+	UserID        string `json:"user_id"`
+	IPAddress     string `json:"ip_address"`
+	UserAgent     string `json:"user_agent"`
+	SetupRequired bool   `json:"setup_required"`
+	Attempts      int    `json:"attempts"`
+	ExpiresAt     int64  `json:"expires_at"`
+}
+
+func (r *Redis) StoreTwoFactorChallenge(ctx context.Context, token string, challenge *TwoFactorChallenge, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyTwoFactorChallenge, token)
+	return r.Set(ctx, key, challenge, expiration)
+}
+
+func (r *Redis) GetTwoFactorChallenge(ctx context.Context, token string) (*TwoFactorChallenge, error) {
+	key := fmt.Sprintf(RedisKeyTwoFactorChallenge, token)
+	var challenge TwoFactorChallenge
+	if err := r.GetJSON(ctx, key, &challenge); err != nil {
+		return nil, fmt.Errorf("failed to get two factor challenge: %w", err)
+	}
+	return &challenge, nil
+}
+
+func (r *Redis) RevokeTwoFactorChallenge(ctx context.Context, token string) error {
+	key := fmt.Sprintf(RedisKeyTwoFactorChallenge, token)
+	return r.Delete(ctx, key)
+}
+
+/*	------------------------------------------- OIDC Login State ------------------------------------------- */
+
+var ErrOIDCStateNotFound = errors.New("oidc state not found or expired")
+
+// OIDCState is kept between redirecting to a provider and its callback. It
+// holds the PKCE verifier and nonce, which must never reach the browser.
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func (r *Redis) StoreOIDCState(ctx context.Context, state string, data *OIDCState, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyOIDCState, state)
+	return r.Set(ctx, key, data, expiration)
+}
+
+// ConsumeOIDCState returns and deletes the state in one step so a callback
+// cannot be replayed.
+func (r *Redis) ConsumeOIDCState(ctx context.Context, state string) (*OIDCState, error) {
+	key := fmt.Sprintf(RedisKeyOIDCState, state)
+	val, err := r.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, ErrOIDCStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oidc state: %w", err)
+	}
+
+	var data OIDCState
+	if err := json.Unmarshal([]byte(val), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oidc state: %w", err)
+	}
+	return &data, nil
+}
+
 /*	------------------------------------------- Forgot Password OTP Management ------------------------------------------- */
 
+var (
+	ErrOTPInvalid          = errors.New("otp invalid or expired")
+	ErrOTPAttemptsExceeded = errors.New("otp attempts exceeded")
+)
+
+// ForgotPasswordOTP is stored under the user it was issued to, so a guessed
+// code can only ever unlock that one account. Only the hash of the code is kept.
+type ForgotPasswordOTP struct {
+	OTPHash  string `json:"otp_hash"`
+	Attempts int    `json:"attempts"`
+}
+
+// StoreForgotPasswordOTP replaces any OTP previously issued to the user.
 func (r *Redis) StoreForgotPasswordOTP(ctx context.Context, userID, otp string) error {
-	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, otp)
-	return r.Set(ctx, key, userID, 15*time.Minute)
+	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, userID)
+	return r.Set(ctx, key, ForgotPasswordOTP{OTPHash: HashToken(otp)}, 15*time.Minute)
+}
+
+// VerifyForgotPasswordOTP consumes the user's OTP if it matches. Each wrong
+// guess counts against maxAttempts and the OTP is discarded once they are used up.
+func (r *Redis) VerifyForgotPasswordOTP(ctx context.Context, userID, otp string, maxAttempts int) error {
+	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, userID)
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		val, err := tx.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return ErrOTPInvalid
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get forgot password otp: %w", err)
+		}
+
+		var stored ForgotPasswordOTP
+		if err := json.Unmarshal([]byte(val), &stored); err != nil {
+			return fmt.Errorf("failed to unmarshal forgot password otp: %w", err)
+		}
+
+		if stored.Attempts >= maxAttempts {
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Del(ctx, key)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return ErrOTPAttemptsExceeded
+		}
+
+		if subtle.ConstantTimeCompare([]byte(stored.OTPHash), []byte(HashToken(otp))) == 1 {
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Del(ctx, key)
+				return nil
+			})
+			return err
+		}
+
+		stored.Attempts++
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("failed to marshal forgot password otp: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if stored.Attempts >= maxAttempts {
+				pipe.Del(ctx, key)
+			} else {
+				pipe.SetArgs(ctx, key, data, redis.SetArgs{KeepTTL: true})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if stored.Attempts >= maxAttempts {
+			return ErrOTPAttemptsExceeded
+		}
+		return ErrOTPInvalid
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		// A concurrent guess changed the OTP; treat this one as a miss rather
+		// than letting it through uncounted.
+		return ErrOTPInvalid
+	}
+	return err
 }
 
-func (r *Redis) GetUserFromForgotPasswordOTP(ctx context.Context, otp string) (string, error) {
-	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, otp)
-	return r.Get(ctx, key)
+func (r *Redis) RevokeForgotPasswordOTP(ctx context.Context, userID string) error {
+	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, userID)
+	return r.Delete(ctx, key)
+}
+
+/*	------------------------------------------- Brute Force Protection ------------------------------------------- */
+
+// IncrementAuthFailures counts a failed attempt for subject and returns the
+// total. The window starts with the first failure and is not extended by later ones.
+func (r *Redis) IncrementAuthFailures(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(RedisKeyAuthFailures, subject)
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment auth failures: %w", err)
+	}
+	if count == 1 {
+		if err := r.Expire(ctx, key, window); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
 }
 
-func (r *Redis) RevokeForgotPasswordOTP(ctx context.Context, otp string) error {
-	key := fmt.Sprintf(RedisKeyForgotPasswordOTP, otp)
+func (r *Redis) ResetAuthFailures(ctx context.Context, subject string) error {
+	key := fmt.Sprintf(RedisKeyAuthFailures, subject)
 	return r.Delete(ctx, key)
 }
+
+// LockAuthSubject blocks subject for duration. An existing longer lock is kept.
+func (r *Redis) LockAuthSubject(ctx context.Context, subject string, duration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyAuthLockout, subject)
+	remaining, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get auth lockout: %w", err)
+	}
+	if remaining >= duration {
+		return nil
+	}
+	return r.Set(ctx, key, time.Now().Add(duration).Unix(), duration)
+}
+
+// GetAuthLockout returns how long subject stays locked, or zero if it is not.
+func (r *Redis) GetAuthLockout(ctx context.Context, subject string) (time.Duration, error) {
+	key := fmt.Sprintf(RedisKeyAuthLockout, subject)
+	remaining, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get auth lockout: %w", err)
+	}
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// ClearAuthLockout lifts a lock and forgets the failures that led to it.
+func (r *Redis) ClearAuthLockout(ctx context.Context, subject string) error {
+	if err := r.Delete(ctx, fmt.Sprintf(RedisKeyAuthLockout, subject)); err != nil {
+		return err
+	}
+	return r.ResetAuthFailures(ctx, subject)
+}
+/*	------------------------------------------- Rate Limiting ------------------------------------------- */
+
+// RateLimitResult describes a rate limit decision. ResetAfter is how long until
+// the oldest request in the window expires and frees up a slot.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+}
+
+// rateLimitScript implements a sliding window log. Every accepted request is a
+// member of a sorted set scored by its arrival time in milliseconds; members
+// older than the window are dropped before counting.
+var rateLimitScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	redis.call('PEXPIRE', key, window)
+	count = count + 1
+	allowed = 1
+end
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+// AllowRateLimit records a request against key and reports whether it fits in
+// limit requests per window. Rejected requests are not counted.
+func (r *Redis) AllowRateLimit(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	now := time.Now()
+	member := fmt.Sprintf("%d-%s", now.UnixNano(), uuid.NewString())
+	res, err := rateLimitScript.Run(
+		ctx,
+		r.client,
+		[]string{fmt.Sprintf(RedisKeyRateLimit, key)},
+		now.UnixMilli(),
+		window.Milliseconds(),
+		limit,
+		member,
+	).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate rate limit: %w", err)
+	}
+
+	remaining := int(res[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &RateLimitResult{
+		Allowed:    res[0] == 1,
+		Limit:      limit,
+		Remaining:  remaining,
+		ResetAfter: time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
+
 /*	------------------------------------------- Reset Password Management ------------------------------------------- */
 
 func (r *Redis) StoreResetPasswordToken(ctx context.Context, userID, token string) error {
@@ -298,6 +765,14 @@ func (r *Redis) StoreResetPasswordToken(ctx context.Context, userID, token strin
 	return r.Set(ctx, key, userID, 15*time.Minute)
 }
 
+// StorePasswordSetupToken stores a reset password token for an account
+// created without a password. It lasts longer than one from the forgot
+// password flow as the user may not open the email for days.
+func (r *Redis) StorePasswordSetupToken(ctx context.Context, userID, token string, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyResetPassword, token)
+	return r.Set(ctx, key, userID, expiration)
+}
+
 func (r *Redis) GetUserFromResetPasswordToken(ctx context.Context, token string) (string, error) {
 	key := fmt.Sprintf(RedisKeyResetPassword, token)
 	return r.Get(ctx, key)
@@ -322,4 +797,99 @@ func (r *Redis) GetUserFromVerifyEmailToken(ctx context.Context, token string) (
 func (r *Redis) RevokeVerifyEmailToken(ctx context.Context, token string) error {
 	key := fmt.Sprintf(RedisKeyVerifyEmail, token)
 	return r.Delete(ctx, key)
-}
\ No newline at end of file
+}
+
+/*	------------------------------------------- Pending Email Management ------------------------------------------- */
+
+// StorePendingEmail records the new address a verify email token will switch
+// the user to. It expires together with the token.
+func (r *Redis) StorePendingEmail(ctx context.Context, token, email string) error {
+	key := fmt.Sprintf(RedisKeyPendingEmail, token)
+	return r.Set(ctx, key, email, 24*time.Hour)
+}
+
+// GetPendingEmail returns the pending address for a verify email token, or an
+// empty string when the token only verifies the current address.
+func (r *Redis) GetPendingEmail(ctx context.Context, token string) (string, error) {
+	key := fmt.Sprintf(RedisKeyPendingEmail, token)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get key %s: %w", key, err)
+	}
+	return val, nil
+}
+
+func (r *Redis) RevokePendingEmail(ctx context.Context, token string) error {
+	key := fmt.Sprintf(RedisKeyPendingEmail, token)
+	return r.Delete(ctx, key)
+}
+
+/*	------------------------------------------- Magic Link Management ------------------------------------------- */
+
+var ErrMagicLinkNotFound = errors.New("magic link not found or expired")
+
+func (r *Redis) StoreMagicLinkToken(ctx context.Context, userID, token string, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyMagicLink, token)
+	return r.Set(ctx, key, userID, expiration)
+}
+
+// ConsumeMagicLinkToken returns the user a magic link was issued to and
+// deletes it in one step, so each link signs in only once.
+func (r *Redis) ConsumeMagicLinkToken(ctx context.Context, token string) (string, error) {
+	key := fmt.Sprintf(RedisKeyMagicLink, token)
+	val, err := r.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return "", ErrMagicLinkNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get magic link: %w", err)
+	}
+	return val, nil
+}
+
+/*	------------------------------------------- User Status Cache ------------------------------------------- */
+
+var ErrUserStatusNotCached = errors.New("user status not cached")
+
+// CachedUserStatus is the part of a user that /verify needs to authorize a
+// request. It is cached briefly so the user database is not read on every
+// request through the gateway.
+type CachedUserStatus struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+	// OrganizationID is empty for users outside any organization.
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+func (r *Redis) CacheUserStatus(ctx context.Context, status *CachedUserStatus, expiration time.Duration) error {
+	key := fmt.Sprintf(RedisKeyUserStatus, status.UserID)
+	return r.Set(ctx, key, status, expiration)
+}
+
+// GetCachedUserStatus returns ErrUserStatusNotCached when the user has no
+// entry, so callers can tell a miss from Redis being unavailable.
+func (r *Redis) GetCachedUserStatus(ctx context.Context, userID string) (*CachedUserStatus, error) {
+	key := fmt.Sprintf(RedisKeyUserStatus, userID)
+	val, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, ErrUserStatusNotCached
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user status: %w", err)
+	}
+	var status CachedUserStatus
+	if err := json.Unmarshal([]byte(val), &status); err != nil {
+		return nil, fmt.Errorf("failed to decode user status: %w", err)
+	}
+	return &status, nil
+}
+
+func (r *Redis) InvalidateUserStatus(ctx context.Context, userID string) error {
+	key := fmt.Sprintf(RedisKeyUserStatus, userID)
+	return r.Delete(ctx, key)
+}